@@ -230,6 +230,22 @@ To use this filter, the search query must contain \`type:diff\` or \`type:commit
         examples: ['repo:has(owner:jordan)', '-repo:has(team:search)'],
         showSuggestions: false,
     },
+    {
+        ...createQueryExampleFromString('has.language({language}>{percent}%)'),
+        field: FilterType.repo,
+        description:
+            'Search inside repositories with code in the given language. With a percentage, only repositories in which the language makes up more or less than that share of the code are searched.',
+        examples: ['repo:has.language(go>50%)', '-repo:has.language(java)'],
+        showSuggestions: false,
+    },
+    {
+        ...createQueryExampleFromString('has.size({comparison})'),
+        field: FilterType.repo,
+        description:
+            'Search inside repositories by their size on disk (in B, KB, MB or GB) or by their number of files.',
+        examples: ['repo:has.size(<100MB)', 'repo:has.size(>1000 files)'],
+        showSuggestions: false,
+    },
     {
         ...createQueryExampleFromString('{revision}'),
         field: FilterType.rev,
@@ -248,6 +264,7 @@ To use this filter, the search query must contain \`type:diff\` or \`type:commit
 - \`select:commit.diff.removed\`
 - \`select:file\`
 - \`select:file.directory\`
+- \`select:file.owners\`
 - \`select:file.path\`
 - \`select:content\`
 - \`select:symbol.symboltype\`
@@ -59,6 +59,7 @@ describe('getCompletionItems()', () => {
             'case',
             'committer',
             '-committer',
+            'container',
             'content',
             '-content',
             'context',
@@ -122,6 +123,7 @@ describe('getCompletionItems()', () => {
             'case',
             'committer',
             '-committer',
+            'container',
             'content',
             '-content',
             'context',
@@ -162,6 +164,7 @@ describe('getCompletionItems()', () => {
             'case',
             'committer',
             '-committer',
+            'container',
             'content',
             '-content',
             'context',
@@ -201,6 +204,7 @@ describe('getCompletionItems()', () => {
             'case',
             'committer',
             '-committer',
+            'container',
             'content',
             '-content',
             'context',
@@ -240,6 +244,7 @@ describe('getCompletionItems()', () => {
             'case',
             'committer',
             '-committer',
+            'container',
             'content',
             '-content',
             'context',
@@ -385,6 +390,8 @@ describe('getCompletionItems()', () => {
               "has.description(\${1}) ",
               "has.tag(\${1}) ",
               "has(\${1:key}:\${2:value}) ",
+              "has.language(\${1:go}>\${2:50}%) ",
+              "has.size(>\${1:10MB}) ",
               "^repo/with\\\\ a\\\\ space$ "
             ]
         `)
@@ -408,7 +415,9 @@ describe('getCompletionItems()', () => {
               "has.commit.after(\${1:1 month ago}) ",
               "has.description(\${1}) ",
               "has.tag(\${1}) ",
-              "has(\${1:key}:\${2:value}) "
+              "has(\${1:key}:\${2:value}) ",
+              "has.language(\${1:go}>\${2:50}%) ",
+              "has.size(>\${1:10MB}) "
             ]
         `)
     })
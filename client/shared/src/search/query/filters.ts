@@ -14,6 +14,7 @@ export enum FilterType {
     before = 'before',
     case = 'case',
     committer = 'committer',
+    container = 'container',
     content = 'content',
     context = 'context',
     count = 'count',
@@ -215,6 +216,11 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
         negatable: true,
         singular: true,
     },
+    [FilterType.container]: {
+        description: 'Include only symbols contained in the given symbol (e.g. the methods of a class). Requires type:symbol.',
+        placeholder: 'symbol name',
+        singular: true,
+    },
     [FilterType.content]: {
         description: (negated: boolean): string =>
             `${negated ? 'Exclude' : 'Include only'} results from files if their content matches the search pattern.`,
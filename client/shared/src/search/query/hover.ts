@@ -176,6 +176,12 @@ const toPredicateHover = (token: MetaPredicate): string => {
             return '**Built-in predicate**. Search only inside repositories that are tagged with the given tag'
         case 'has':
             return '**Built-in predicate**. Search only inside repositories that are associated with the given key:value pair'
+        case 'has.language':
+            return `**Built-in predicate**. Search only inside repositories whose code is written in the given language, optionally in a given share: \`${parameters}\`.`
+        case 'has.size':
+            return `**Built-in predicate**. Search only inside repositories whose size or number of files is \`${parameters}\`.`
+        case 'has.owner':
+            return `**Built-in predicate**. Search only inside files that are owned by \`${parameters}\` according to the repository's CODEOWNERS file.`
     }
     return ''
 }
@@ -39,6 +39,8 @@ export const PREDICATES: Access[] = [
                     },
                     { name: 'description' },
                     { name: 'tag' },
+                    { name: 'language' },
+                    { name: 'size' },
                 ],
             },
         ],
@@ -52,7 +54,7 @@ export const PREDICATES: Access[] = [
             },
             {
                 name: 'has',
-                fields: [{ name: 'content' }],
+                fields: [{ name: 'content' }, { name: 'owner' }],
             },
         ],
     },
@@ -208,6 +210,16 @@ export const predicateCompletion = (field: string): Completion[] => {
                 insertText: 'has(${1:key}:${2:value})',
                 asSnippet: true,
             },
+            {
+                label: 'has.language(...)',
+                insertText: 'has.language(${1:go}>${2:50}%)',
+                asSnippet: true,
+            },
+            {
+                label: 'has.size(...)',
+                insertText: 'has.size(>${1:10MB})',
+                asSnippet: true,
+            },
         ]
     }
     return []
@@ -19,7 +19,9 @@ import { Link, Code, Text } from '@sourcegraph/wildcard'
 import awsCodeCommitSchemaJSON from '../../../../../schema/aws_codecommit.schema.json'
 import bitbucketCloudSchemaJSON from '../../../../../schema/bitbucket_cloud.schema.json'
 import bitbucketServerSchemaJSON from '../../../../../schema/bitbucket_server.schema.json'
+import azureDevOpsSchemaJSON from '../../../../../schema/azuredevops.schema.json'
 import gerritSchemaJSON from '../../../../../schema/gerrit.schema.json'
+import giteaSchemaJSON from '../../../../../schema/gitea.schema.json'
 import githubSchemaJSON from '../../../../../schema/github.schema.json'
 import gitlabSchemaJSON from '../../../../../schema/gitlab.schema.json'
 import gitoliteSchemaJSON from '../../../../../schema/gitolite.schema.json'
@@ -1278,6 +1280,70 @@ const GERRIT: AddExternalServiceOptions = {
     editorActions: [],
 }
 
+const AZURE_DEVOPS: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.AZUREDEVOPS,
+    title: 'Azure DevOps',
+    icon: GitIcon,
+    jsonSchema: azureDevOpsSchemaJSON,
+    defaultDisplayName: 'Azure DevOps',
+    defaultConfig: `{
+  "url": "https://dev.azure.com",
+  "username": "<admin username>",
+  "token": "<personal access token>",
+  "orgs": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the URL of Azure DevOps Services or of your
+                    Azure DevOps Server instance.
+                </li>
+                <li>
+                    Set <Field>username</Field> and <Field>token</Field> to a username and a personal access token
+                    with the <Code>Code (Read)</Code> scope.
+                </li>
+                <li>
+                    Add the organizations to mirror to <Field>orgs</Field>, or individual projects to{' '}
+                    <Field>projects</Field>.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [],
+}
+
+const GITEA: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.GITEA,
+    title: 'Gitea',
+    icon: GitIcon,
+    jsonSchema: giteaSchemaJSON,
+    defaultDisplayName: 'Gitea',
+    defaultConfig: `{
+  "url": "https://gitea.example.com",
+  "token": "<access token>",
+  "orgs": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the URL of your Gitea or Forgejo instance.
+                </li>
+                <li>
+                    Set <Field>token</Field> to an access token with read access to repositories, organizations and
+                    users.
+                </li>
+                <li>
+                    Add the organizations to mirror to <Field>orgs</Field>, users to <Field>users</Field>, or
+                    repository search queries to <Field>searchQuery</Field>.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [],
+}
+
 const NPM_PACKAGES: AddExternalServiceOptions = {
     kind: ExternalServiceKind.NPMPACKAGES,
     title: 'npm Dependencies',
@@ -1458,6 +1524,8 @@ export const codeHostExternalServices: Record<string, AddExternalServiceOptions>
     ...(window.context?.experimentalFeatures?.perforce === 'enabled' ? { perforce: PERFORCE } : {}),
     ...(window.context?.experimentalFeatures?.pagure === 'enabled' ? { pagure: PAGURE } : {}),
     ...(window.context?.experimentalFeatures?.gerrit === 'enabled' ? { gerrit: GERRIT } : {}),
+    ...(window.context?.experimentalFeatures?.azureDevOps === 'enabled' ? { azureDevOps: AZURE_DEVOPS } : {}),
+    ...(window.context?.experimentalFeatures?.gitea === 'enabled' ? { gitea: GITEA } : {}),
 }
 
 export const nonCodeHostExternalServices: Record<string, AddExternalServiceOptions> = {
@@ -1478,8 +1546,10 @@ export const defaultExternalServices: Record<ExternalServiceKind, AddExternalSer
     [ExternalServiceKind.PHABRICATOR]: PHABRICATOR_SERVICE,
     [ExternalServiceKind.OTHER]: GENERIC_GIT,
     [ExternalServiceKind.AWSCODECOMMIT]: AWS_CODE_COMMIT,
+    [ExternalServiceKind.AZUREDEVOPS]: AZURE_DEVOPS,
     [ExternalServiceKind.PERFORCE]: PERFORCE,
     [ExternalServiceKind.GERRIT]: GERRIT,
+    [ExternalServiceKind.GITEA]: GITEA,
     [ExternalServiceKind.PAGURE]: PAGURE,
     [ExternalServiceKind.GOMODULES]: GO_MODULES,
     [ExternalServiceKind.JVMPACKAGES]: JVM_PACKAGES,
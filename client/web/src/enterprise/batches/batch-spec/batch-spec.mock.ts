@@ -221,12 +221,27 @@ export const mockStep = (
     exitCode: 0,
     finishedAt: subMinutes(now, 1).toISOString(),
     ifCondition: null,
+    matrixValues: null,
+    timeout: null,
+    retries: 0,
+    continueOnError: false,
     number,
     outputLines: ['stdout: Hello World', 'stdout: '],
     outputVariables: [],
     run: `echo Hello World Step ${number} | tee -a $(find -name README.md)`,
     skipped: false,
     startedAt: subMinutes(now, 2).toISOString(),
+    attempts: [
+        {
+            __typename: 'BatchSpecWorkspaceStepAttempt',
+            number: 1,
+            outputLines: ['stdout: Hello World', 'stdout: '],
+            startedAt: subMinutes(now, 2).toISOString(),
+            finishedAt: subMinutes(now, 1).toISOString(),
+            exitCode: 0,
+            error: null,
+        },
+    ],
     ...step,
 })
 
@@ -242,6 +257,7 @@ export const mockWorkspace = (
     startedAt: subHours(now, 1).toISOString(),
     finishedAt: now.toISOString(),
     failureMessage: null,
+    policyViolations: [],
     placeInQueue: null,
     placeInGlobalQueue: null,
     path: '/some/path',
@@ -404,6 +420,21 @@ export const FAILED_WORKSPACE = mockWorkspace(1, {
     steps: [mockStep(1), mockStep(2, { exitCode: 1, diffStat: null })],
 })
 
+export const POLICY_VIOLATION_WORKSPACE = mockWorkspace(1, {
+    state: BatchSpecWorkspaceState.FAILED,
+    failureMessage:
+        'found 1 violation(s) of the changeset policy:\n- refs/heads/my-branch: README.md: file is outside of the allowed paths',
+    policyViolations: [
+        {
+            check: 'allowedPaths',
+            branch: 'refs/heads/my-branch',
+            path: 'README.md',
+            message: 'file is outside of the allowed paths',
+        },
+    ],
+    changesetSpecs: [],
+})
+
 export const CANCELING_WORKSPACE = mockWorkspace(1, {
     state: BatchSpecWorkspaceState.CANCELING,
     finishedAt: null,
@@ -52,6 +52,12 @@ const batchSpecWorkspaceFieldsFragment = gql`
         }
         searchResultPaths
         failureMessage
+        policyViolations {
+            check
+            branch
+            path
+            message
+        }
         changesetSpecs {
             __typename
             ...BatchSpecWorkspaceChangesetSpecFields
@@ -107,6 +113,10 @@ const batchSpecWorkspaceFieldsFragment = gql`
         }
         container
         ifCondition
+        matrixValues
+        timeout
+        retries
+        continueOnError
         cachedResultFound
         skipped
         outputLines
@@ -121,6 +131,14 @@ const batchSpecWorkspaceFieldsFragment = gql`
             name
             value
         }
+        attempts {
+            number
+            outputLines
+            startedAt
+            finishedAt
+            exitCode
+            error
+        }
     }
 
     fragment BatchSpecWorkspaceExecutionLogEntryFields on ExecutionLogEntry {
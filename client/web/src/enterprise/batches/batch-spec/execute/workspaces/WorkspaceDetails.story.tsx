@@ -19,6 +19,7 @@ import {
     UNSUPPORTED_WORKSPACE,
     LOTS_OF_STEPS_WORKSPACE,
     FAILED_WORKSPACE,
+    POLICY_VIOLATION_WORKSPACE,
     CANCELING_WORKSPACE,
     CANCELED_WORKSPACE,
 } from '../../batch-spec.mock'
@@ -121,6 +122,9 @@ export const VisibleWorkspaceFailed: Story = () => (
 )
 VisibleWorkspaceFailed.storyName = 'Visible workspace: failed'
 
+export const VisibleWorkspacePolicyViolations: Story = () => <BaseStory node={POLICY_VIOLATION_WORKSPACE} />
+VisibleWorkspacePolicyViolations.storyName = 'Visible workspace: changeset policy violations'
+
 export const VisibleWorkspaceCanceling: Story = () => <BaseStory node={CANCELING_WORKSPACE} />
 VisibleWorkspaceCanceling.storyName = 'Visible workspace: canceling'
 
@@ -19,6 +19,7 @@ import indicator from 'ordinal/indicator'
 import { useHistory } from 'react-router'
 
 import { ErrorAlert } from '@sourcegraph/branded/src/components/alerts'
+import { pluralize } from '@sourcegraph/common'
 import { Maybe } from '@sourcegraph/shared/src/graphql-operations'
 import { ThemeProps } from '@sourcegraph/shared/src/theme'
 import {
@@ -270,6 +271,28 @@ const VisibleWorkspaceDetails: React.FunctionComponent<React.PropsWithChildren<V
                     {retryError && <ErrorAlert error={retryError} />}
                 </>
             )}
+            {workspace.policyViolations.length > 0 && (
+                <div className="mb-3">
+                    <H4>Changeset policy violations</H4>
+                    <ul className="mb-0">
+                        {workspace.policyViolations.map((violation, index) => (
+                            <li key={index}>
+                                <Code>{violation.branch}</Code>
+                                {violation.path && (
+                                    <>
+                                        {' '}
+                                        in <Code>{violation.path}</Code>
+                                    </>
+                                )}
+                                : {violation.message}{' '}
+                                <Badge variant="secondary" small={true}>
+                                    {violation.check}
+                                </Badge>
+                            </li>
+                        ))}
+                    </ul>
+                </div>
+            )}
 
             {workspace.changesetSpecs && workspace.state === BatchSpecWorkspaceState.COMPLETED && (
                 <div className="mb-3">
@@ -572,7 +595,12 @@ const WorkspaceStep: React.FunctionComponent<React.PropsWithChildren<WorkspaceSt
                                         {!step.startedAt && (
                                             <Text className="text-muted mb-0">Step not started yet</Text>
                                         )}
-                                        {step.startedAt && outputLines && <LogOutput text={outputLines.join('\n')} />}
+                                        {step.startedAt && step.attempts.length > 1 && (
+                                            <WorkspaceStepAttempts attempts={step.attempts} />
+                                        )}
+                                        {step.startedAt && step.attempts.length <= 1 && outputLines && (
+                                            <LogOutput text={outputLines.join('\n')} />
+                                        )}
                                     </TabPanel>
                                     <TabPanel className="pt-2" key="output-variables">
                                         {!step.startedAt && (
@@ -623,10 +651,30 @@ const WorkspaceStep: React.FunctionComponent<React.PropsWithChildren<WorkspaceSt
                                                 <LogOutput text={step.ifCondition} className="mb-2" />
                                             </>
                                         )}
+                                        {step.matrixValues !== null && (
+                                            <>
+                                                <H4>Matrix values</H4>
+                                                <LogOutput
+                                                    text={JSON.stringify(step.matrixValues, null, 2)}
+                                                    className="mb-2"
+                                                />
+                                            </>
+                                        )}
                                         <H4>Command</H4>
                                         <LogOutput text={step.run} className="mb-2" />
                                         <H4>Container</H4>
-                                        <Text className="text-monospace mb-0">{step.container}</Text>
+                                        <Text className="text-monospace">{step.container}</Text>
+                                        <H4>Attempts</H4>
+                                        <Text className="mb-0">
+                                            Retried up to {step.retries} {pluralize('time', step.retries)}
+                                            {step.timeout !== null && (
+                                                <>, each attempt times out after {step.timeout}</>
+                                            )}
+                                            {step.continueOnError && (
+                                                <>, following steps run even if all attempts fail</>
+                                            )}
+                                            .
+                                        </Text>
                                     </TabPanel>
                                 </TabPanels>
                             </Tabs>
@@ -650,6 +698,35 @@ const WorkspaceStep: React.FunctionComponent<React.PropsWithChildren<WorkspaceSt
     )
 }
 
+const WorkspaceStepAttempts: React.FunctionComponent<
+    React.PropsWithChildren<{ attempts: BatchSpecWorkspaceStepFields['attempts'] }>
+> = ({ attempts }) => (
+    <>
+        {attempts.map(attempt => (
+            <div key={attempt.number}>
+                <H4>
+                    Attempt {attempt.number}
+                    {attempt.startedAt && (
+                        <span className="text-monospace text-muted ml-2">
+                            <StepTimer startedAt={attempt.startedAt} finishedAt={attempt.finishedAt} />
+                        </span>
+                    )}
+                </H4>
+                <LogOutput
+                    text={[
+                        ...attempt.outputLines,
+                        ...(attempt.error !== null ? [`stderr: ${attempt.error}`] : []),
+                        ...(attempt.exitCode !== null && attempt.exitCode !== 0
+                            ? [`stderr: Command failed with status ${attempt.exitCode}`]
+                            : []),
+                    ].join('\n')}
+                    className="mb-2"
+                />
+            </div>
+        ))}
+    </>
+)
+
 const StepTimer: React.FunctionComponent<React.PropsWithChildren<{ startedAt: string; finishedAt: Maybe<string> }>> = ({
     startedAt,
     finishedAt,
@@ -54,9 +54,13 @@ const scopeRequirements: Record<ExternalServiceKind, JSX.Element> = {
             <Code>pipeline:read</Code> permissions.
         </span>
     ),
+    [ExternalServiceKind.GERRIT]: (
+        <span>
+            with permission to push to <Code>refs/for/*</Code>, and to abandon, restore and submit changes.
+        </span>
+    ),
 
     // These are just for type completeness and serve as placeholders for a bright future.
-    [ExternalServiceKind.GERRIT]: <span>Unsupported</span>,
     [ExternalServiceKind.GITOLITE]: <span>Unsupported</span>,
     [ExternalServiceKind.GOMODULES]: <span>Unsupported</span>,
     [ExternalServiceKind.PYTHONPACKAGES]: <span>Unsupported</span>,
@@ -67,6 +71,8 @@ const scopeRequirements: Record<ExternalServiceKind, JSX.Element> = {
     [ExternalServiceKind.PERFORCE]: <span>Unsupported</span>,
     [ExternalServiceKind.PHABRICATOR]: <span>Unsupported</span>,
     [ExternalServiceKind.AWSCODECOMMIT]: <span>Unsupported</span>,
+    [ExternalServiceKind.AZUREDEVOPS]: <span>Unsupported</span>,
+    [ExternalServiceKind.GITEA]: <span>Unsupported</span>,
     [ExternalServiceKind.PAGURE]: <span>Unsupported</span>,
     [ExternalServiceKind.OTHER]: <span>Unsupported</span>,
 }
@@ -138,7 +144,11 @@ export const AddCredentialModal: React.FunctionComponent<React.PropsWithChildren
     )
 
     const patLabel =
-        externalServiceKind === ExternalServiceKind.BITBUCKETCLOUD ? 'App password' : 'Personal access token'
+        externalServiceKind === ExternalServiceKind.BITBUCKETCLOUD
+            ? 'App password'
+            : externalServiceKind === ExternalServiceKind.GERRIT
+            ? 'HTTP password'
+            : 'Personal access token'
 
     return (
         <Modal onDismiss={onCancel} aria-labelledby={labelId}>
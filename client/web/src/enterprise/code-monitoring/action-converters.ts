@@ -8,8 +8,12 @@ import {
     MonitorEmailPriority,
     MonitorWebhookInput,
     MonitorSlackWebhookInput,
+    MonitorTeamsWebhookInput,
+    MonitorIssueInput,
     MonitorWebhookFields,
     MonitorSlackWebhookFields,
+    MonitorTeamsWebhookFields,
+    MonitorIssueFields,
     MonitorEmailFields,
 } from '../../graphql-operations'
 
@@ -34,6 +38,22 @@ function convertSlackWebhookAction(action: MonitorSlackWebhookFields): MonitorSl
     }
 }
 
+function convertTeamsWebhookAction(action: MonitorTeamsWebhookFields): MonitorTeamsWebhookInput {
+    return {
+        enabled: action.enabled,
+        includeResults: action.includeResults,
+        url: action.url,
+    }
+}
+
+function convertIssueAction(action: MonitorIssueFields): MonitorIssueInput {
+    return {
+        enabled: action.enabled,
+        includeResults: action.includeResults,
+        repository: action.repository.id,
+    }
+}
+
 function convertWebhookAction(action: MonitorWebhookFields): MonitorWebhookInput {
     return {
         enabled: action.enabled,
@@ -56,6 +76,14 @@ export function convertActionsForCreate(
                 return {
                     slackWebhook: convertSlackWebhookAction(action),
                 }
+            case 'MonitorTeamsWebhook':
+                return {
+                    teamsWebhook: convertTeamsWebhookAction(action),
+                }
+            case 'MonitorIssue':
+                return {
+                    issue: convertIssueAction(action),
+                }
             case 'MonitorWebhook':
                 return {
                     webhook: convertWebhookAction(action),
@@ -85,6 +113,20 @@ export function convertActionsForUpdate(
                         update: convertSlackWebhookAction(action),
                     },
                 }
+            case 'MonitorTeamsWebhook':
+                return {
+                    teamsWebhook: {
+                        id: action.id || null,
+                        update: convertTeamsWebhookAction(action),
+                    },
+                }
+            case 'MonitorIssue':
+                return {
+                    issue: {
+                        id: action.id || null,
+                        update: convertIssueAction(action),
+                    },
+                }
             case 'MonitorWebhook':
                 return {
                     webhook: {
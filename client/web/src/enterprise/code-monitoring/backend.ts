@@ -61,6 +61,29 @@ const MonitorSlackWebhookFragment = gql`
     }
 `
 
+const MonitorTeamsWebhookFragment = gql`
+    fragment MonitorTeamsWebhookFields on MonitorTeamsWebhook {
+        __typename
+        id
+        enabled
+        includeResults
+        url
+    }
+`
+
+const MonitorIssueFragment = gql`
+    fragment MonitorIssueFields on MonitorIssue {
+        __typename
+        id
+        enabled
+        includeResults
+        repository {
+            id
+            name
+        }
+    }
+`
+
 const CodeMonitorFragment = gql`
     fragment CodeMonitorFields on Monitor {
         id
@@ -78,12 +101,16 @@ const CodeMonitorFragment = gql`
                 ...MonitorEmailFields
                 ...MonitorWebhookFields
                 ...MonitorSlackWebhookFields
+                ...MonitorTeamsWebhookFields
+                ...MonitorIssueFields
             }
         }
     }
     ${MonitorEmailFragment}
     ${MonitorWebhookFragment}
     ${MonitorSlackWebhookFragment}
+    ${MonitorTeamsWebhookFragment}
+    ${MonitorIssueFragment}
 `
 
 const ListCodeMonitorsFragment = gql`
@@ -227,6 +254,21 @@ export const fetchCodeMonitor = (id: string): Observable<FetchCodeMonitorResult>
                                 includeResults
                                 url
                             }
+                            ... on MonitorTeamsWebhook {
+                                id
+                                enabled
+                                includeResults
+                                url
+                            }
+                            ... on MonitorIssue {
+                                id
+                                enabled
+                                includeResults
+                                repository {
+                                    id
+                                    name
+                                }
+                            }
                         }
                     }
                     trigger {
@@ -7,7 +7,9 @@ import { CodeMonitorFields } from '../../../graphql-operations'
 import { useExperimentalFeatures } from '../../../stores'
 
 import { EmailAction } from './actions/EmailAction'
+import { IssueAction } from './actions/IssueAction'
 import { SlackWebhookAction } from './actions/SlackWebhookAction'
+import { TeamsWebhookAction } from './actions/TeamsWebhookAction'
 import { WebhookAction } from './actions/WebhookAction'
 
 export interface ActionAreaProps {
@@ -53,14 +55,24 @@ export const FormActionArea: React.FunctionComponent<React.PropsWithChildren<Act
         actions.nodes.find(action => action.__typename === 'MonitorSlackWebhook')
     )
 
+    const [teamsWebhookAction, setTeamsWebhookAction] = useState<MonitorAction | undefined>(
+        actions.nodes.find(action => action.__typename === 'MonitorTeamsWebhook')
+    )
+
     const [webhookAction, setWebhookAction] = useState<MonitorAction | undefined>(
         actions.nodes.find(action => action.__typename === 'MonitorWebhook')
     )
 
+    const [issueAction, setIssueAction] = useState<MonitorAction | undefined>(
+        actions.nodes.find(action => action.__typename === 'MonitorIssue')
+    )
+
     // Form is completed if there is at least one action
     useEffect(() => {
-        setActionsCompleted(!!emailAction || !!slackWebhookAction || !!webhookAction)
-    }, [emailAction, setActionsCompleted, slackWebhookAction, webhookAction])
+        setActionsCompleted(
+            !!emailAction || !!slackWebhookAction || !!teamsWebhookAction || !!webhookAction || !!issueAction
+        )
+    }, [emailAction, setActionsCompleted, slackWebhookAction, teamsWebhookAction, webhookAction, issueAction])
 
     useEffect(() => {
         const actions: CodeMonitorFields['actions'] = { nodes: [] }
@@ -70,11 +82,17 @@ export const FormActionArea: React.FunctionComponent<React.PropsWithChildren<Act
         if (slackWebhookAction) {
             actions.nodes.push(slackWebhookAction)
         }
+        if (teamsWebhookAction) {
+            actions.nodes.push(teamsWebhookAction)
+        }
         if (webhookAction) {
             actions.nodes.push(webhookAction)
         }
+        if (issueAction) {
+            actions.nodes.push(issueAction)
+        }
         onActionsChange(actions)
-    }, [emailAction, onActionsChange, slackWebhookAction, webhookAction])
+    }, [emailAction, onActionsChange, slackWebhookAction, teamsWebhookAction, webhookAction, issueAction])
 
     const showWebhooks = useExperimentalFeatures(features => features.codeMonitoringWebHooks)
 
@@ -101,6 +119,16 @@ export const FormActionArea: React.FunctionComponent<React.PropsWithChildren<Act
                 />
             )}
 
+            {(showWebhooks || teamsWebhookAction) && (
+                <TeamsWebhookAction
+                    disabled={disabled}
+                    action={teamsWebhookAction}
+                    setAction={setTeamsWebhookAction}
+                    monitorName={monitorName}
+                    authenticatedUser={authenticatedUser}
+                />
+            )}
+
             {(showWebhooks || webhookAction) && (
                 <WebhookAction
                     disabled={disabled}
@@ -111,6 +139,17 @@ export const FormActionArea: React.FunctionComponent<React.PropsWithChildren<Act
                 />
             )}
 
+            {/* Issue actions use code host connection tokens, so only site admins can configure them. */}
+            {(authenticatedUser.siteAdmin || issueAction) && (
+                <IssueAction
+                    disabled={disabled || !authenticatedUser.siteAdmin}
+                    action={issueAction}
+                    setAction={setIssueAction}
+                    monitorName={monitorName}
+                    authenticatedUser={authenticatedUser}
+                />
+            )}
+
             <small className="text-muted">
                 What other actions would you like to take?{' '}
                 <Link to="mailto:feedback@sourcegraph.com" target="_blank" rel="noopener">
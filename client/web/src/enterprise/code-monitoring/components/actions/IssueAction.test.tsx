@@ -0,0 +1,165 @@
+import { MockedResponse } from '@apollo/client/testing'
+import { render, waitFor } from '@testing-library/react'
+import userEvent from '@testing-library/user-event'
+import sinon from 'sinon'
+
+import { MockedTestProvider } from '@sourcegraph/shared/src/testing/apollo'
+
+import { IssueActionRepositoryResult } from '../../../../graphql-operations'
+import { mockAuthenticatedUser } from '../../testing/util'
+import { ActionProps } from '../FormActionArea'
+
+import { IssueAction, ISSUE_ACTION_REPOSITORY } from './IssueAction'
+
+const REPOSITORY = { id: 'UmVwb3NpdG9yeTox', name: 'github.com/sourcegraph/issues' }
+
+describe('IssueAction', () => {
+    const props: ActionProps = {
+        action: undefined,
+        setAction: sinon.stub(),
+        disabled: false,
+        monitorName: 'Test',
+        authenticatedUser: mockAuthenticatedUser,
+    }
+
+    test('open and submit', async () => {
+        const mockedResponse: MockedResponse<IssueActionRepositoryResult> = {
+            request: {
+                query: ISSUE_ACTION_REPOSITORY,
+                variables: { name: REPOSITORY.name },
+            },
+            result: { data: { repository: REPOSITORY } },
+        }
+
+        const setActionSpy = sinon.spy()
+        const { getByTestId } = render(
+            <MockedTestProvider mocks={[mockedResponse]}>
+                <IssueAction {...props} setAction={setActionSpy} />
+            </MockedTestProvider>
+        )
+
+        userEvent.click(getByTestId('form-action-toggle-issue'))
+
+        expect(getByTestId('submit-action-issue')).toBeDisabled()
+
+        userEvent.type(getByTestId('issue-repository'), REPOSITORY.name)
+        // The repository name is resolved to an ID after the input is debounced.
+        await waitFor(() => expect(getByTestId('submit-action-issue')).toBeEnabled())
+
+        userEvent.click(getByTestId('include-results-toggle-issue'))
+
+        userEvent.click(getByTestId('submit-action-issue'))
+
+        sinon.assert.calledOnceWithExactly(setActionSpy, {
+            __typename: 'MonitorIssue',
+            enabled: true,
+            includeResults: true,
+            id: '',
+            repository: REPOSITORY,
+        })
+    })
+
+    test('open and edit', () => {
+        const setActionSpy = sinon.spy()
+        const { getByTestId } = render(
+            <MockedTestProvider>
+                <IssueAction
+                    {...props}
+                    setAction={setActionSpy}
+                    action={{
+                        __typename: 'MonitorIssue',
+                        enabled: true,
+                        includeResults: false,
+                        id: '1',
+                        repository: REPOSITORY,
+                    }}
+                />
+            </MockedTestProvider>
+        )
+
+        userEvent.click(getByTestId('form-action-toggle-issue'))
+        expect(getByTestId('submit-action-issue')).toBeEnabled()
+
+        userEvent.clear(getByTestId('issue-repository'))
+        expect(getByTestId('submit-action-issue')).toBeDisabled()
+
+        userEvent.type(getByTestId('issue-repository'), REPOSITORY.name)
+        expect(getByTestId('submit-action-issue')).toBeEnabled()
+
+        userEvent.click(getByTestId('submit-action-issue'))
+
+        sinon.assert.calledOnceWithExactly(setActionSpy, {
+            __typename: 'MonitorIssue',
+            enabled: true,
+            includeResults: false,
+            id: '1',
+            repository: REPOSITORY,
+        })
+    })
+
+    test('open and delete', () => {
+        const setActionSpy = sinon.spy()
+        const { getByTestId } = render(
+            <MockedTestProvider>
+                <IssueAction
+                    {...props}
+                    action={{
+                        __typename: 'MonitorIssue',
+                        enabled: true,
+                        includeResults: false,
+                        id: '2',
+                        repository: REPOSITORY,
+                    }}
+                    setAction={setActionSpy}
+                />
+            </MockedTestProvider>
+        )
+
+        userEvent.click(getByTestId('form-action-toggle-issue'))
+        userEvent.click(getByTestId('delete-action-issue'))
+
+        sinon.assert.calledOnceWithExactly(setActionSpy, undefined)
+    })
+
+    test('enable and disable', () => {
+        const setActionSpy = sinon.spy()
+        const { getByTestId } = render(
+            <MockedTestProvider>
+                <IssueAction
+                    {...props}
+                    action={{
+                        __typename: 'MonitorIssue',
+                        enabled: false,
+                        includeResults: false,
+                        id: '5',
+                        repository: REPOSITORY,
+                    }}
+                    setAction={setActionSpy}
+                />
+            </MockedTestProvider>
+        )
+
+        expect(getByTestId('enable-action-toggle-collapsed-issue')).not.toBeChecked()
+
+        userEvent.click(getByTestId('enable-action-toggle-collapsed-issue'))
+        expect(getByTestId('enable-action-toggle-collapsed-issue')).toBeChecked()
+        sinon.assert.calledOnceWithExactly(setActionSpy, {
+            __typename: 'MonitorIssue',
+            enabled: true,
+            includeResults: false,
+            id: '5',
+            repository: REPOSITORY,
+        })
+    })
+
+    test('send test is disabled', () => {
+        const { getByTestId } = render(
+            <MockedTestProvider>
+                <IssueAction {...props} />
+            </MockedTestProvider>
+        )
+
+        userEvent.click(getByTestId('form-action-toggle-issue'))
+        expect(getByTestId('send-test-issue')).toBeDisabled()
+    })
+})
@@ -0,0 +1,151 @@
+import React, { useCallback, useState } from 'react'
+
+import { gql, useQuery } from '@apollo/client'
+import { noop } from 'lodash'
+
+import { Alert, Input, Link, ProductStatusBadge, Label, useDebounce } from '@sourcegraph/wildcard'
+
+import { IssueActionRepositoryResult, IssueActionRepositoryVariables } from '../../../../graphql-operations'
+import { ActionProps } from '../FormActionArea'
+
+import { ActionEditor } from './ActionEditor'
+
+export const ISSUE_ACTION_REPOSITORY = gql`
+    query IssueActionRepository($name: String!) {
+        repository(name: $name) {
+            id
+            name
+        }
+    }
+`
+
+export const IssueAction: React.FunctionComponent<React.PropsWithChildren<ActionProps>> = ({
+    action,
+    setAction,
+    disabled,
+    _testStartOpen,
+}) => {
+    const [enabled, setEnabled] = useState(action ? action.enabled : true)
+
+    const toggleIssueEnabled: (enabled: boolean, saveImmediately: boolean) => void = useCallback(
+        (enabled, saveImmediately) => {
+            setEnabled(enabled)
+            if (action && saveImmediately) {
+                setAction({ ...action, enabled })
+            }
+        },
+        [action, setAction]
+    )
+
+    const savedRepository = action && action.__typename === 'MonitorIssue' ? action.repository : null
+    const [repositoryName, setRepositoryName] = useState(savedRepository ? savedRepository.name : '')
+
+    // Resolve the entered repository name to a repository ID, which is what the
+    // API expects.
+    const debouncedRepositoryName = useDebounce(repositoryName, 300)
+    const { data, loading } = useQuery<IssueActionRepositoryResult, IssueActionRepositoryVariables>(
+        ISSUE_ACTION_REPOSITORY,
+        {
+            variables: { name: debouncedRepositoryName },
+            skip: !debouncedRepositoryName || debouncedRepositoryName === savedRepository?.name,
+        }
+    )
+    const repository =
+        savedRepository?.name === repositoryName
+            ? savedRepository
+            : data?.repository?.name === repositoryName
+            ? data.repository
+            : null
+
+    const [includeResults, setIncludeResults] = useState(action ? action.includeResults : false)
+    const toggleIncludeResults: (includeResults: boolean) => void = useCallback(includeResults => {
+        setIncludeResults(includeResults)
+    }, [])
+
+    const onSubmit: React.FormEventHandler = useCallback(
+        event => {
+            event.preventDefault()
+            if (!repository) {
+                return
+            }
+            setAction({
+                __typename: 'MonitorIssue',
+                id: action ? action.id : '',
+                repository: { id: repository.id, name: repository.name },
+                enabled,
+                includeResults,
+            })
+        },
+        [action, includeResults, setAction, repository, enabled]
+    )
+
+    const onCancel: React.FormEventHandler = useCallback(() => {
+        setEnabled(action ? action.enabled : true)
+        setRepositoryName(savedRepository ? savedRepository.name : '')
+        setIncludeResults(action ? action.includeResults : false)
+    }, [action, savedRepository])
+
+    const onDelete: React.FormEventHandler = useCallback(() => {
+        setAction(undefined)
+    }, [setAction])
+
+    const repositoryIsValid = !!repository
+    const showRepositoryError = !!repositoryName && !loading && repositoryName === debouncedRepositoryName
+
+    return (
+        <ActionEditor
+            title={
+                <div>
+                    Open an issue <ProductStatusBadge className="ml-1 mb-1" status="beta" />{' '}
+                </div>
+            }
+            subtitle="Open an issue on GitHub or GitLab, or comment on the issue opened previously."
+            idName="issue"
+            disabled={disabled}
+            completed={!!action}
+            completedSubtitle="An issue will be opened or commented on in the specified repository."
+            actionEnabled={enabled}
+            toggleActionEnabled={toggleIssueEnabled}
+            canSubmit={repositoryIsValid}
+            includeResults={includeResults}
+            toggleIncludeResults={toggleIncludeResults}
+            onSubmit={onSubmit}
+            onCancel={onCancel}
+            canDelete={!!action}
+            onDelete={onDelete}
+            testState={undefined}
+            testButtonDisabledReason="Sending a test would open a real issue in the repository"
+            testButtonText="Send test issue"
+            testAgainButtonText="Send again"
+            onTest={noop}
+            _testStartOpen={_testStartOpen}
+        >
+            <Alert aria-live="off" variant="info" className="mt-4">
+                Issues are opened with the token of the code host connection that syncs the repository. Only site
+                admins can configure this action.
+                <br />
+                <Link to="/help/code_monitoring/how-tos/issues" target="_blank" rel="noopener">
+                    Read more about opening issues from code monitors in the docs.
+                </Link>
+            </Alert>
+            <div className="form-group">
+                <Label htmlFor="code-monitor-issue-repository">Repository</Label>
+                <Input
+                    id="code-monitor-issue-repository"
+                    className="mb-2"
+                    data-testid="issue-repository"
+                    placeholder="github.com/owner/repo"
+                    required={true}
+                    onChange={event => {
+                        setRepositoryName(event.target.value)
+                    }}
+                    value={repositoryName}
+                    autoFocus={true}
+                    spellCheck={false}
+                    status={repositoryIsValid ? 'valid' : showRepositoryError ? 'error' : undefined}
+                    error={!repositoryIsValid && showRepositoryError && 'Enter the name of a repository.'}
+                />
+            </div>
+        </ActionEditor>
+    )
+}
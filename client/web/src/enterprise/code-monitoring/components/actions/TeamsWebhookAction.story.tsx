@@ -0,0 +1,73 @@
+import { Meta, Story } from '@storybook/react'
+import sinon from 'sinon'
+
+import { H2 } from '@sourcegraph/wildcard'
+
+import { WebStory } from '../../../../components/WebStory'
+import { mockAuthenticatedUser } from '../../testing/util'
+import { ActionProps } from '../FormActionArea'
+
+import { TeamsWebhookAction } from './TeamsWebhookAction'
+
+const config: Meta = {
+    title: 'web/enterprise/code-monitoring/actions/TeamsWebhookAction',
+    parameters: {
+        chromatic: { disableSnapshot: false },
+    },
+}
+
+export default config
+
+const defaultProps: ActionProps = {
+    action: undefined,
+    setAction: sinon.fake(),
+    disabled: false,
+    monitorName: 'Example code monitor',
+    authenticatedUser: mockAuthenticatedUser,
+}
+
+const action: ActionProps['action'] = {
+    __typename: 'MonitorTeamsWebhook',
+    id: 'id1',
+    url: 'https://example.webhook.office.com/webhookb2/00000000-0000-0000-0000-000000000000@00000000-0000-0000-0000-000000000000/IncomingWebhook/XXXXXXXXXXXXXXXXXXXXXXXX/00000000-0000-0000-0000-000000000000',
+    enabled: true,
+    includeResults: false,
+}
+
+export const TeamsWebhookActionStory: Story = () => (
+    <WebStory>
+        {() => (
+            <>
+                <H2>Action card disabled</H2>
+                <TeamsWebhookAction {...defaultProps} disabled={true} />
+
+                <H2>Closed, not populated</H2>
+                <TeamsWebhookAction {...defaultProps} />
+
+                <H2>Open, not populated</H2>
+                <TeamsWebhookAction {...defaultProps} _testStartOpen={true} />
+
+                <H2>Closed, populated, enabled</H2>
+                <TeamsWebhookAction {...defaultProps} action={action} />
+
+                <H2>Open, populated, enabled</H2>
+                <TeamsWebhookAction {...defaultProps} _testStartOpen={true} action={action} />
+
+                <H2>Open, populated with error, enabled</H2>
+                <TeamsWebhookAction
+                    {...defaultProps}
+                    _testStartOpen={true}
+                    action={{ ...action, url: 'https://example.com' }}
+                />
+
+                <H2>Closed, populated, disabled</H2>
+                <TeamsWebhookAction {...defaultProps} action={{ ...action, enabled: false }} />
+
+                <H2>Open, populated, disabled</H2>
+                <TeamsWebhookAction {...defaultProps} _testStartOpen={true} action={{ ...action, enabled: false }} />
+            </>
+        )}
+    </WebStory>
+)
+
+TeamsWebhookActionStory.storyName = 'TeamsWebhookAction'
@@ -0,0 +1,294 @@
+import { MockedResponse } from '@apollo/client/testing'
+import { render } from '@testing-library/react'
+import userEvent from '@testing-library/user-event'
+import sinon from 'sinon'
+
+import { MockedTestProvider, waitForNextApolloResponse } from '@sourcegraph/shared/src/testing/apollo'
+
+import { SendTestTeamsWebhookResult, SendTestTeamsWebhookVariables } from '../../../../graphql-operations'
+import { mockAuthenticatedUser } from '../../testing/util'
+import { ActionProps, MonitorAction } from '../FormActionArea'
+
+import { SEND_TEST_TEAMS_WEBHOOK, TeamsWebhookAction } from './TeamsWebhookAction'
+
+const TEAMS_URL = 'https://example.webhook.office.com/webhookb2/00000000-0000-0000-0000-000000000000@00000000-0000-0000-0000-000000000000/IncomingWebhook/XXXXXXXXXXXXXXXXXXXXXXXX/00000000-0000-0000-0000-000000000000'
+
+describe('TeamsWebhookAction', () => {
+    const props: ActionProps = {
+        action: undefined,
+        setAction: sinon.stub(),
+        disabled: false,
+        monitorName: 'Test',
+        authenticatedUser: mockAuthenticatedUser,
+    }
+
+    test('open and submit', () => {
+        const setActionSpy = sinon.spy()
+        const { getByTestId } = render(
+            <MockedTestProvider>
+                <TeamsWebhookAction {...props} setAction={setActionSpy} />
+            </MockedTestProvider>
+        )
+
+        userEvent.click(getByTestId('form-action-toggle-teams-webhook'))
+
+        expect(getByTestId('submit-action-teams-webhook')).toBeDisabled()
+
+        userEvent.type(getByTestId('teams-webhook-url'), TEAMS_URL)
+        expect(getByTestId('submit-action-teams-webhook')).toBeEnabled()
+
+        userEvent.click(getByTestId('include-results-toggle-teams-webhook'))
+
+        userEvent.click(getByTestId('submit-action-teams-webhook'))
+
+        sinon.assert.calledOnceWithExactly(setActionSpy, {
+            __typename: 'MonitorTeamsWebhook',
+            enabled: true,
+            includeResults: true,
+            id: '',
+            url: TEAMS_URL,
+        })
+    })
+
+    test('open and edit', () => {
+        const setActionSpy = sinon.spy()
+        const { getByTestId } = render(
+            <MockedTestProvider>
+                <TeamsWebhookAction
+                    {...props}
+                    setAction={setActionSpy}
+                    action={{
+                        __typename: 'MonitorTeamsWebhook',
+                        enabled: true,
+                        includeResults: false,
+                        id: '1',
+                        url: TEAMS_URL,
+                    }}
+                />
+            </MockedTestProvider>
+        )
+
+        userEvent.click(getByTestId('form-action-toggle-teams-webhook'))
+        expect(getByTestId('submit-action-teams-webhook')).toBeEnabled()
+
+        userEvent.clear(getByTestId('teams-webhook-url'))
+        expect(getByTestId('submit-action-teams-webhook')).toBeDisabled()
+
+        userEvent.type(getByTestId('teams-webhook-url'), TEAMS_URL)
+        expect(getByTestId('submit-action-teams-webhook')).toBeEnabled()
+
+        userEvent.click(getByTestId('submit-action-teams-webhook'))
+
+        sinon.assert.calledOnceWithExactly(setActionSpy, {
+            __typename: 'MonitorTeamsWebhook',
+            enabled: true,
+            includeResults: false,
+            id: '1',
+            url: TEAMS_URL,
+        })
+    })
+
+    test('open and delete', () => {
+        const setActionSpy = sinon.spy()
+        const { getByTestId } = render(
+            <MockedTestProvider>
+                <TeamsWebhookAction
+                    {...props}
+                    action={{
+                        __typename: 'MonitorTeamsWebhook',
+                        enabled: true,
+                        includeResults: false,
+                        id: '2',
+                        url: TEAMS_URL,
+                    }}
+                    setAction={setActionSpy}
+                />
+            </MockedTestProvider>
+        )
+
+        userEvent.click(getByTestId('form-action-toggle-teams-webhook'))
+        userEvent.click(getByTestId('delete-action-teams-webhook'))
+
+        sinon.assert.calledOnceWithExactly(setActionSpy, undefined)
+    })
+
+    test('enable and disable', () => {
+        const setActionSpy = sinon.spy()
+        const { getByTestId } = render(
+            <MockedTestProvider>
+                <TeamsWebhookAction
+                    {...props}
+                    action={{
+                        __typename: 'MonitorTeamsWebhook',
+                        enabled: false,
+                        includeResults: false,
+                        id: '5',
+                        url: TEAMS_URL,
+                    }}
+                    setAction={setActionSpy}
+                />
+            </MockedTestProvider>
+        )
+
+        expect(getByTestId('enable-action-toggle-collapsed-teams-webhook')).not.toBeChecked()
+
+        userEvent.click(getByTestId('enable-action-toggle-collapsed-teams-webhook'))
+        expect(getByTestId('enable-action-toggle-collapsed-teams-webhook')).toBeChecked()
+        sinon.assert.calledOnceWithExactly(setActionSpy, {
+            __typename: 'MonitorTeamsWebhook',
+            enabled: true,
+            includeResults: false,
+            id: '5',
+            url: TEAMS_URL,
+        })
+
+        setActionSpy.resetHistory()
+
+        userEvent.click(getByTestId('enable-action-toggle-collapsed-teams-webhook'))
+        expect(getByTestId('enable-action-toggle-collapsed-teams-webhook')).not.toBeChecked()
+        sinon.assert.calledOnceWithExactly(setActionSpy, {
+            __typename: 'MonitorTeamsWebhook',
+            enabled: false,
+            includeResults: false,
+            id: '5',
+            url: TEAMS_URL,
+        })
+    })
+
+    test('open, edit, cancel, open again', () => {
+        const setActionSpy = sinon.spy()
+        const { getByTestId } = render(
+            <MockedTestProvider>
+                <TeamsWebhookAction
+                    {...props}
+                    action={{
+                        __typename: 'MonitorTeamsWebhook',
+                        enabled: true,
+                        includeResults: false,
+                        id: '5',
+                        url: 'https://example.com',
+                    }}
+                    setAction={setActionSpy}
+                />
+            </MockedTestProvider>
+        )
+
+        userEvent.click(getByTestId('form-action-toggle-teams-webhook'))
+
+        expect(getByTestId('enable-action-toggle-expanded-teams-webhook')).toBeChecked()
+        userEvent.click(getByTestId('enable-action-toggle-expanded-teams-webhook'))
+        expect(getByTestId('enable-action-toggle-expanded-teams-webhook')).not.toBeChecked()
+
+        userEvent.type(getByTestId('teams-webhook-url'), 'https://example2.com')
+
+        userEvent.click(getByTestId('cancel-action-teams-webhook'))
+
+        userEvent.click(getByTestId('form-action-toggle-teams-webhook'))
+        expect(getByTestId('teams-webhook-url')).toHaveValue('https://example.com')
+        expect(getByTestId('enable-action-toggle-expanded-teams-webhook')).toBeChecked()
+
+        sinon.assert.notCalled(setActionSpy)
+    })
+
+    describe('Send test message', () => {
+        const mockAction: MonitorAction = {
+            __typename: 'MonitorTeamsWebhook',
+            enabled: false,
+            includeResults: false,
+            id: '5',
+            url: TEAMS_URL,
+        }
+
+        const mockedVariables: SendTestTeamsWebhookVariables = {
+            namespace: props.authenticatedUser.id,
+            description: props.monitorName,
+            teamsWebhook: {
+                enabled: true,
+                includeResults: false,
+                url: mockAction.url,
+            },
+        }
+
+        test('disabled if no webhook url set', () => {
+            const { getByTestId } = render(
+                <MockedTestProvider>
+                    <TeamsWebhookAction {...props} />
+                </MockedTestProvider>
+            )
+
+            userEvent.click(getByTestId('form-action-toggle-teams-webhook'))
+            expect(getByTestId('send-test-teams-webhook')).toBeDisabled()
+        })
+
+        test('disabled if no monitor name set', () => {
+            const { getByTestId } = render(
+                <MockedTestProvider>
+                    <TeamsWebhookAction {...props} monitorName="" />
+                </MockedTestProvider>
+            )
+
+            userEvent.click(getByTestId('form-action-toggle-teams-webhook'))
+            expect(getByTestId('send-test-teams-webhook')).toBeDisabled()
+        })
+
+        test('send test message, success', async () => {
+            const mockedResponse: MockedResponse<SendTestTeamsWebhookResult> = {
+                request: {
+                    query: SEND_TEST_TEAMS_WEBHOOK,
+                    variables: mockedVariables,
+                },
+                result: { data: { triggerTestTeamsWebhookAction: { alwaysNil: null } } },
+            }
+
+            const { getByTestId, queryByTestId } = render(
+                <MockedTestProvider mocks={[mockedResponse]}>
+                    <TeamsWebhookAction {...props} action={mockAction} />
+                </MockedTestProvider>
+            )
+
+            userEvent.click(getByTestId('form-action-toggle-teams-webhook'))
+            expect(getByTestId('send-test-teams-webhook')).toHaveTextContent('Send test message')
+
+            userEvent.click(getByTestId('send-test-teams-webhook'))
+            expect(getByTestId('send-test-teams-webhook')).toHaveTextContent('Sending message...')
+
+            await waitForNextApolloResponse()
+
+            expect(getByTestId('send-test-teams-webhook')).toHaveTextContent('Test message sent!')
+            expect(getByTestId('send-test-teams-webhook')).toBeDisabled()
+
+            expect(queryByTestId('send-test-teams-webhook')).toBeInTheDocument()
+            expect(queryByTestId('test-email-teams-webhook')).not.toBeInTheDocument()
+        })
+
+        test('send test message, error', async () => {
+            const mockedResponse: MockedResponse<SendTestTeamsWebhookResult> = {
+                request: {
+                    query: SEND_TEST_TEAMS_WEBHOOK,
+                    variables: mockedVariables,
+                },
+                error: new Error('An error occurred'),
+            }
+
+            const { getByTestId, queryByTestId } = render(
+                <MockedTestProvider mocks={[mockedResponse]}>
+                    <TeamsWebhookAction {...props} action={mockAction} />
+                </MockedTestProvider>
+            )
+
+            userEvent.click(getByTestId('form-action-toggle-teams-webhook'))
+            expect(getByTestId('send-test-teams-webhook')).toHaveTextContent('Send test message')
+
+            userEvent.click(getByTestId('send-test-teams-webhook'))
+
+            await waitForNextApolloResponse()
+
+            expect(getByTestId('send-test-teams-webhook')).toHaveTextContent('Send test message')
+
+            expect(getByTestId('send-test-teams-webhook')).toBeEnabled()
+
+            expect(queryByTestId('send-test-teams-webhook-again')).not.toBeInTheDocument()
+            expect(queryByTestId('test-teams-webhook-error')).toBeInTheDocument()
+        })
+    })
+})
@@ -151,6 +151,10 @@ function getActionEventTitle(action: MonitorTriggerEventWithActions['actions']['
             return 'Email'
         case 'MonitorSlackWebhook':
             return 'Slack'
+        case 'MonitorTeamsWebhook':
+            return 'Microsoft Teams'
+        case 'MonitorIssue':
+            return 'Issue'
         case 'MonitorWebhook':
             return 'Webhook'
     }
@@ -115,6 +115,7 @@ const getTruncationFormatter = (
         // so we truncate their labels from the end
         case SearchAggregationMode.REPO:
         case SearchAggregationMode.PATH:
+        case SearchAggregationMode.DIRECTORY_DEPTH:
             return getTruncatedTickFromTheEnd(maxLength)
 
         default:
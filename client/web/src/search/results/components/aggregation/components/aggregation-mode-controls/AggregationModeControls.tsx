@@ -115,6 +115,66 @@ export const AggregationModeControls: FC<AggregationModeControlsProps> = props =
                     </Button>
                 </Tooltip>
             </div>
+
+            <div onMouseEnter={() => handleModeHover(SearchAggregationMode.LANGUAGE)}>
+                <Tooltip content={availabilityGroups[SearchAggregationMode.LANGUAGE]?.reasonUnavailable}>
+                    <Button
+                        variant="secondary"
+                        size={size}
+                        outline={mode !== SearchAggregationMode.LANGUAGE}
+                        disabled={!isModeAvailable(SearchAggregationMode.LANGUAGE)}
+                        data-testid="language-aggregation-mode"
+                        onClick={() => onModeChange(SearchAggregationMode.LANGUAGE)}
+                    >
+                        Language
+                    </Button>
+                </Tooltip>
+            </div>
+
+            <div onMouseEnter={() => handleModeHover(SearchAggregationMode.FILE_EXTENSION)}>
+                <Tooltip content={availabilityGroups[SearchAggregationMode.FILE_EXTENSION]?.reasonUnavailable}>
+                    <Button
+                        variant="secondary"
+                        size={size}
+                        outline={mode !== SearchAggregationMode.FILE_EXTENSION}
+                        disabled={!isModeAvailable(SearchAggregationMode.FILE_EXTENSION)}
+                        data-testid="fileExtension-aggregation-mode"
+                        onClick={() => onModeChange(SearchAggregationMode.FILE_EXTENSION)}
+                    >
+                        File extension
+                    </Button>
+                </Tooltip>
+            </div>
+
+            <div onMouseEnter={() => handleModeHover(SearchAggregationMode.DIRECTORY_DEPTH)}>
+                <Tooltip content={availabilityGroups[SearchAggregationMode.DIRECTORY_DEPTH]?.reasonUnavailable}>
+                    <Button
+                        variant="secondary"
+                        size={size}
+                        outline={mode !== SearchAggregationMode.DIRECTORY_DEPTH}
+                        disabled={!isModeAvailable(SearchAggregationMode.DIRECTORY_DEPTH)}
+                        data-testid="directory-aggregation-mode"
+                        onClick={() => onModeChange(SearchAggregationMode.DIRECTORY_DEPTH)}
+                    >
+                        Directory
+                    </Button>
+                </Tooltip>
+            </div>
+
+            <div onMouseEnter={() => handleModeHover(SearchAggregationMode.COMMIT_MONTH)}>
+                <Tooltip content={availabilityGroups[SearchAggregationMode.COMMIT_MONTH]?.reasonUnavailable}>
+                    <Button
+                        variant="secondary"
+                        size={size}
+                        outline={mode !== SearchAggregationMode.COMMIT_MONTH}
+                        disabled={!isModeAvailable(SearchAggregationMode.COMMIT_MONTH)}
+                        data-testid="commitMonth-aggregation-mode"
+                        onClick={() => onModeChange(SearchAggregationMode.COMMIT_MONTH)}
+                    >
+                        Commit month
+                    </Button>
+                </Tooltip>
+            </div>
         </div>
     )
 }
@@ -56,7 +56,16 @@ function useSyncedWithURLState<State, SerializedState>(
     return [queryParameter, setNextState]
 }
 
-type SerializedAggregationMode = 'repo' | 'path' | 'author' | 'group' | ''
+type SerializedAggregationMode =
+    | 'repo'
+    | 'path'
+    | 'author'
+    | 'group'
+    | 'language'
+    | 'extension'
+    | 'directory'
+    | 'month'
+    | ''
 
 const aggregationModeSerializer = (mode: SearchAggregationMode | null): SerializedAggregationMode => {
     switch (mode) {
@@ -68,6 +77,14 @@ const aggregationModeSerializer = (mode: SearchAggregationMode | null): Serializ
             return 'author'
         case SearchAggregationMode.CAPTURE_GROUP:
             return 'group'
+        case SearchAggregationMode.LANGUAGE:
+            return 'language'
+        case SearchAggregationMode.FILE_EXTENSION:
+            return 'extension'
+        case SearchAggregationMode.DIRECTORY_DEPTH:
+            return 'directory'
+        case SearchAggregationMode.COMMIT_MONTH:
+            return 'month'
 
         default:
             return ''
@@ -86,6 +103,14 @@ const aggregationModeDeserializer = (
             return SearchAggregationMode.AUTHOR
         case 'group':
             return SearchAggregationMode.CAPTURE_GROUP
+        case 'language':
+            return SearchAggregationMode.LANGUAGE
+        case 'extension':
+            return SearchAggregationMode.FILE_EXTENSION
+        case 'directory':
+            return SearchAggregationMode.DIRECTORY_DEPTH
+        case 'month':
+            return SearchAggregationMode.COMMIT_MONTH
 
         default:
             return null
@@ -229,6 +229,39 @@ type PublishChangesetsArgs struct {
 	Draft bool
 }
 
+type SetBatchChangeAutoMergePolicyArgs struct {
+	BatchChange graphql.ID
+	Policy      BatchChangeAutoMergePolicyInput
+}
+
+type BatchChangeAutoMergePolicyInput struct {
+	RequirePassingChecks bool
+	RequireApproval      bool
+	Squash               bool
+	MaintenanceWindows   *[]BatchChangeMaintenanceWindowInput
+	MaxMergesPerHour     int32
+}
+
+type BatchChangeMaintenanceWindowInput struct {
+	Days  *[]string
+	Start *string
+	End   *string
+}
+
+type DeleteBatchChangeAutoMergePolicyArgs struct {
+	BatchChange graphql.ID
+}
+
+type SetBatchChangeAutoRebaseArgs struct {
+	BatchChange graphql.ID
+	Enabled     bool
+}
+
+type ListBatchChangeAutoMergeEventsArgs struct {
+	First int32
+	After *string
+}
+
 type ResolveWorkspacesForBatchSpecArgs struct {
 	BatchSpec string
 }
@@ -279,6 +312,9 @@ type BatchChangesResolver interface {
 	MergeChangesets(ctx context.Context, args *MergeChangesetsArgs) (BulkOperationResolver, error)
 	CloseChangesets(ctx context.Context, args *CloseChangesetsArgs) (BulkOperationResolver, error)
 	PublishChangesets(ctx context.Context, args *PublishChangesetsArgs) (BulkOperationResolver, error)
+	SetBatchChangeAutoMergePolicy(ctx context.Context, args *SetBatchChangeAutoMergePolicyArgs) (BatchChangeAutoMergePolicyResolver, error)
+	DeleteBatchChangeAutoMergePolicy(ctx context.Context, args *DeleteBatchChangeAutoMergePolicyArgs) (*EmptyResponse, error)
+	SetBatchChangeAutoRebase(ctx context.Context, args *SetBatchChangeAutoRebaseArgs) (BatchChangeResolver, error)
 
 	// Queries
 	BatchChange(ctx context.Context, args *BatchChangeArgs) (BatchChangeResolver, error)
@@ -583,7 +619,9 @@ type ListChangesetsArgs struct {
 	// ReviewState is a value of type *btypes.ChangesetReviewState.
 	ReviewState *string
 	// CheckState is a value of type *btypes.ChangesetCheckState.
-	CheckState                     *string
+	CheckState *string
+	// BaseState is a value of type *btypes.ChangesetBaseState.
+	BaseState                      *string
 	OnlyPublishedByThisBatchChange *bool
 	Search                         *string
 
@@ -656,6 +694,46 @@ type BatchChangeResolver interface {
 	CurrentSpec(ctx context.Context) (BatchSpecResolver, error)
 	BulkOperations(ctx context.Context, args *ListBatchChangeBulkOperationArgs) (BulkOperationConnectionResolver, error)
 	BatchSpecs(ctx context.Context, args *ListBatchSpecArgs) (BatchSpecConnectionResolver, error)
+	AutoMergePolicy(ctx context.Context) (BatchChangeAutoMergePolicyResolver, error)
+	AutoMergeEvents(ctx context.Context, args *ListBatchChangeAutoMergeEventsArgs) (BatchChangeAutoMergeEventConnectionResolver, error)
+	AutoRebase(ctx context.Context) (BatchChangeAutoRebaseResolver, error)
+}
+
+type BatchChangeAutoRebaseResolver interface {
+	User(ctx context.Context) (*UserResolver, error)
+	LastBatchSpec(ctx context.Context) (BatchSpecResolver, error)
+	CreatedAt() gqlutil.DateTime
+	UpdatedAt() gqlutil.DateTime
+}
+
+type BatchChangeAutoMergePolicyResolver interface {
+	User(ctx context.Context) (*UserResolver, error)
+	RequirePassingChecks() bool
+	RequireApproval() bool
+	Squash() bool
+	MaintenanceWindows() []BatchChangeMaintenanceWindowResolver
+	MaxMergesPerHour() int32
+	CreatedAt() gqlutil.DateTime
+	UpdatedAt() gqlutil.DateTime
+}
+
+type BatchChangeMaintenanceWindowResolver interface {
+	Days() []string
+	Start() *string
+	End() *string
+}
+
+type BatchChangeAutoMergeEventConnectionResolver interface {
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+	Nodes(ctx context.Context) ([]BatchChangeAutoMergeEventResolver, error)
+}
+
+type BatchChangeAutoMergeEventResolver interface {
+	Changeset() ChangesetResolver
+	Decision() string
+	Reason() *string
+	CreatedAt() gqlutil.DateTime
 }
 
 type BatchChangesConnectionResolver interface {
@@ -779,6 +857,8 @@ type ExternalChangesetResolver interface {
 	ReviewState(context.Context) *string
 	// CheckState returns a value of type *btypes.ChangesetCheckState.
 	CheckState() *string
+	// BaseState returns a value of type *btypes.ChangesetBaseState.
+	BaseState() *string
 	Repository(ctx context.Context) *RepositoryResolver
 
 	Events(ctx context.Context, args *ChangesetEventsConnectionArgs) (ChangesetEventsConnectionResolver, error)
@@ -873,6 +953,7 @@ type VisibleBatchSpecWorkspaceResolver interface {
 	BatchSpecWorkspaceResolver
 
 	FailureMessage() *string
+	PolicyViolations() []ChangesetPolicyViolationResolver
 	Stages() BatchSpecWorkspaceStagesResolver
 	Repository(ctx context.Context) (*RepositoryResolver, error)
 	Branch(ctx context.Context) (*GitRefResolver, error)
@@ -894,6 +975,13 @@ type ResolvedBatchSpecWorkspaceResolver interface {
 	SearchResultPaths() []string
 }
 
+type ChangesetPolicyViolationResolver interface {
+	Check() string
+	Branch() string
+	Path() *string
+	Message() string
+}
+
 type BatchSpecWorkspaceStagesResolver interface {
 	Setup() []ExecutionLogEntryResolver
 	SrcExec() ExecutionLogEntryResolver
@@ -905,6 +993,10 @@ type BatchSpecWorkspaceStepResolver interface {
 	Run() string
 	Container() string
 	IfCondition() *string
+	MatrixValues() *JSONValue
+	Timeout() *string
+	Retries() int32
+	ContinueOnError() bool
 	CachedResultFound() bool
 	Skipped() bool
 	OutputLines(ctx context.Context, args *BatchSpecWorkspaceStepOutputLinesArgs) (*[]string, error)
@@ -918,6 +1010,17 @@ type BatchSpecWorkspaceStepResolver interface {
 
 	DiffStat(ctx context.Context) (*DiffStat, error)
 	Diff(ctx context.Context) (PreviewRepositoryComparisonResolver, error)
+
+	Attempts() []BatchSpecWorkspaceStepAttemptResolver
+}
+
+type BatchSpecWorkspaceStepAttemptResolver interface {
+	Number() int32
+	OutputLines(args *BatchSpecWorkspaceStepOutputLinesArgs) []string
+	StartedAt() *gqlutil.DateTime
+	FinishedAt() *gqlutil.DateTime
+	ExitCode() *int32
+	Error() *string
 }
 
 type BatchSpecWorkspaceEnvironmentVariableResolver interface {
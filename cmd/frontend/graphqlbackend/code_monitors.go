@@ -23,6 +23,7 @@ type CodeMonitorsResolver interface {
 	TriggerTestEmailAction(ctx context.Context, args *TriggerTestEmailActionArgs) (*EmptyResponse, error)
 	TriggerTestWebhookAction(ctx context.Context, args *TriggerTestWebhookActionArgs) (*EmptyResponse, error)
 	TriggerTestSlackWebhookAction(ctx context.Context, args *TriggerTestSlackWebhookActionArgs) (*EmptyResponse, error)
+	TriggerTestTeamsWebhookAction(ctx context.Context, args *TriggerTestTeamsWebhookActionArgs) (*EmptyResponse, error)
 
 	NodeResolvers() map[string]NodeByIDFunc
 }
@@ -81,6 +82,8 @@ type MonitorAction interface {
 	ToMonitorEmail() (MonitorEmailResolver, bool)
 	ToMonitorWebhook() (MonitorWebhookResolver, bool)
 	ToMonitorSlackWebhook() (MonitorSlackWebhookResolver, bool)
+	ToMonitorTeamsWebhook() (MonitorTeamsWebhookResolver, bool)
+	ToMonitorIssue() (MonitorIssueResolver, bool)
 }
 
 type MonitorEmailResolver interface {
@@ -109,6 +112,23 @@ type MonitorSlackWebhookResolver interface {
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
 
+type MonitorTeamsWebhookResolver interface {
+	ID() graphql.ID
+	Enabled() bool
+	IncludeResults() bool
+	URL() string
+	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
+}
+
+type MonitorIssueResolver interface {
+	ID() graphql.ID
+	Enabled() bool
+	IncludeResults() bool
+	Repository(ctx context.Context) (*RepositoryResolver, error)
+	IssueNumber() *int32
+	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
+}
+
 type MonitorEmailRecipient interface {
 	ToUser() (*UserResolver, bool)
 }
@@ -166,6 +186,8 @@ type CreateActionArgs struct {
 	Email        *CreateActionEmailArgs
 	Webhook      *CreateActionWebhookArgs
 	SlackWebhook *CreateActionSlackWebhookArgs
+	TeamsWebhook *CreateActionTeamsWebhookArgs
+	Issue        *CreateActionIssueArgs
 }
 
 type CreateActionEmailArgs struct {
@@ -188,6 +210,18 @@ type CreateActionSlackWebhookArgs struct {
 	URL            string
 }
 
+type CreateActionTeamsWebhookArgs struct {
+	Enabled        bool
+	IncludeResults bool
+	URL            string
+}
+
+type CreateActionIssueArgs struct {
+	Enabled        bool
+	IncludeResults bool
+	Repository     graphql.ID
+}
+
 type ToggleCodeMonitorArgs struct {
 	Id      graphql.ID
 	Enabled bool
@@ -219,6 +253,12 @@ type TriggerTestSlackWebhookActionArgs struct {
 	SlackWebhook *CreateActionSlackWebhookArgs
 }
 
+type TriggerTestTeamsWebhookActionArgs struct {
+	Namespace    graphql.ID
+	Description  string
+	TeamsWebhook *CreateActionTeamsWebhookArgs
+}
+
 type CreateMonitorArgs struct {
 	Namespace   graphql.ID
 	Description string
@@ -240,10 +280,22 @@ type EditActionSlackWebhookArgs struct {
 	Update *CreateActionSlackWebhookArgs
 }
 
+type EditActionTeamsWebhookArgs struct {
+	Id     *graphql.ID
+	Update *CreateActionTeamsWebhookArgs
+}
+
+type EditActionIssueArgs struct {
+	Id     *graphql.ID
+	Update *CreateActionIssueArgs
+}
+
 type EditActionArgs struct {
 	Email        *EditActionEmailArgs
 	Webhook      *EditActionWebhookArgs
 	SlackWebhook *EditActionSlackWebhookArgs
+	TeamsWebhook *EditActionTeamsWebhookArgs
+	Issue        *EditActionIssueArgs
 }
 
 type EditTriggerArgs struct {
@@ -342,6 +342,73 @@ func (r *symbolInfoResolver) Hover(ctx context.Context) (*string, error) {
 	return r.symbolInfo.Hover, nil
 }
 
+func (r *GitTreeEntryResolver) SymbolReferences(ctx context.Context, args *symbolInfoArgs) (*symbolReferencesResolver, error) {
+	if args == nil {
+		return nil, errors.New("expected arguments to symbolReferences")
+	}
+
+	repo, err := r.commit.repoResolver.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start := types.RepoCommitPathPoint{
+		RepoCommitPath: types.RepoCommitPath{
+			Repo:   string(repo.Name),
+			Commit: string(r.commit.oid),
+			Path:   r.Path(),
+		},
+		Point: types.Point{
+			Row:    int(args.Line),
+			Column: int(args.Character),
+		},
+	}
+
+	result, err := symbols.DefaultClient.SymbolReferences(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	return &symbolReferencesResolver{symbolReferences: result}, nil
+}
+
+type symbolReferencesResolver struct{ symbolReferences *types.SymbolReferences }
+
+func (r *symbolReferencesResolver) Definition(ctx context.Context) (*symbolLocationResolver, error) {
+	return &symbolLocationResolver{location: r.symbolReferences.Definition}, nil
+}
+
+func (r *symbolReferencesResolver) References(ctx context.Context) ([]*symbolReferenceResolver, error) {
+	resolvers := make([]*symbolReferenceResolver, 0, len(r.symbolReferences.References))
+	for _, reference := range r.symbolReferences.References {
+		resolvers = append(resolvers, &symbolReferenceResolver{reference: reference})
+	}
+	return resolvers, nil
+}
+
+type symbolReferenceResolver struct{ reference types.SymbolReference }
+
+func (r *symbolReferenceResolver) Location() *symbolLocationResolver {
+	rnge := r.reference.Range
+	return &symbolLocationResolver{location: types.RepoCommitPathMaybeRange{
+		RepoCommitPath: r.reference.RepoCommitPath,
+		Range:          &rnge,
+	}}
+}
+
+func (r *symbolReferenceResolver) Confidence() string {
+	switch r.reference.Confidence {
+	case types.ReferenceConfidenceScopeResolved:
+		return "SCOPE_RESOLVED"
+	default:
+		return "NAME_ONLY"
+	}
+}
+
 type symbolLocationResolver struct {
 	location types.RepoCommitPathMaybeRange
 }
@@ -39,6 +39,15 @@ type InsightsResolver interface {
 	// Admin Management
 	UpdateInsightSeries(ctx context.Context, args *UpdateInsightSeriesArgs) (InsightSeriesMetadataPayloadResolver, error)
 	InsightSeriesQueryStatus(ctx context.Context) ([]InsightSeriesQueryStatusResolver, error)
+
+	// Alerting
+	CreateInsightSeriesAlertRule(ctx context.Context, args *CreateInsightSeriesAlertRuleArgs) (InsightSeriesAlertRuleResolver, error)
+	DeleteInsightSeriesAlertRule(ctx context.Context, args *DeleteInsightSeriesAlertRuleArgs) (*EmptyResponse, error)
+	InsightSeriesAlertRules(ctx context.Context, args *InsightSeriesAlertRulesArgs) ([]InsightSeriesAlertRuleResolver, error)
+
+	// Dashboards as code
+	ExportInsightsDashboards(ctx context.Context, args *ExportInsightsDashboardsArgs) (string, error)
+	ApplyInsightsDashboards(ctx context.Context, args *ApplyInsightsDashboardsArgs) (ApplyInsightsDashboardsPayloadResolver, error)
 }
 
 type SearchInsightLivePreviewArgs struct {
@@ -289,6 +298,61 @@ type InsightSeriesMetadataPayloadResolver interface {
 	Series(ctx context.Context) InsightSeriesMetadataResolver
 }
 
+type CreateInsightSeriesAlertRuleArgs struct {
+	Input CreateInsightSeriesAlertRuleInput
+}
+
+type CreateInsightSeriesAlertRuleInput struct {
+	SeriesId        string
+	Kind            string
+	Threshold       *float64
+	NotifyEmail     bool
+	SlackWebhookURL *string
+	WebhookURL      *string
+}
+
+type DeleteInsightSeriesAlertRuleArgs struct {
+	Id graphql.ID
+}
+
+type InsightSeriesAlertRulesArgs struct {
+	SeriesId string
+}
+
+type InsightSeriesAlertRuleResolver interface {
+	ID() graphql.ID
+	SeriesId() string
+	Kind() string
+	Threshold() float64
+	NotifyEmail() bool
+	SlackWebhookURL() *string
+	WebhookURL() *string
+	LastTriggeredAt() *gqlutil.DateTime
+}
+
+type ExportInsightsDashboardsArgs struct {
+	Ids *[]graphql.ID
+}
+
+type ApplyInsightsDashboardsArgs struct {
+	Input ApplyInsightsDashboardsInput
+}
+
+type ApplyInsightsDashboardsInput struct {
+	Yaml   string
+	DryRun bool
+}
+
+type ApplyInsightsDashboardsPayloadResolver interface {
+	Changes() []InsightsDashboardsChangeResolver
+}
+
+type InsightsDashboardsChangeResolver interface {
+	Operation() string
+	Dashboard() string
+	Insight() *string
+}
+
 type InsightSeriesQueryStatusResolver interface {
 	SeriesId(ctx context.Context) (string, error)
 	Query(ctx context.Context) (string, error)
@@ -404,6 +468,7 @@ type LineChartSearchInsightDataSeriesInput struct {
 	Options                    LineChartDataSeriesOptionsInput
 	GeneratedFromCaptureGroups *bool
 	GroupBy                    *string
+	GeneratedFromRepoMetadata  *bool
 }
 
 type LineChartDataSeriesOptionsInput struct {
@@ -0,0 +1,182 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func (r savedSearchResolver) Schedule(ctx context.Context) (*savedSearchScheduleResolver, error) {
+	schedule, err := r.db.SavedSearches().GetSchedule(ctx, r.s.ID)
+	if err != nil || schedule == nil {
+		return nil, err
+	}
+	return &savedSearchScheduleResolver{schedule: schedule}, nil
+}
+
+func (r savedSearchResolver) ResultChanges(ctx context.Context, args *struct{ First int32 }) (*savedSearchResultChangesResolver, error) {
+	if args.First < 0 {
+		return nil, errors.New("first must be non-negative")
+	}
+
+	schedule, err := r.db.SavedSearches().GetSchedule(ctx, r.s.ID)
+	if err != nil || schedule == nil || schedule.LastRunAt == nil {
+		return nil, err
+	}
+
+	added, err := r.db.SavedSearches().ListResultChanges(ctx, r.s.ID, false, int(args.First))
+	if err != nil {
+		return nil, err
+	}
+	removed, err := r.db.SavedSearches().ListResultChanges(ctx, r.s.ID, true, int(args.First))
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: The results were recorded with the permissions of the owner
+	// of the saved search when it ran. Only return the results in repositories
+	// the current user still has access to.
+	repoIDs := make([]api.RepoID, 0, len(added)+len(removed))
+	for _, res := range append(append([]*types.SavedSearchResult{}, added...), removed...) {
+		repoIDs = append(repoIDs, res.RepoID)
+	}
+	repos, err := r.db.Repos().GetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+	repoResolvers := make(map[api.RepoID]*RepositoryResolver, len(repos))
+	gsClient := gitserver.NewClient(r.db)
+	for _, repo := range repos {
+		repoResolvers[repo.ID] = NewRepositoryResolver(r.db, gsClient, repo)
+	}
+
+	return &savedSearchResultChangesResolver{
+		added:   toSavedSearchResultResolvers(added, repoResolvers),
+		removed: toSavedSearchResultResolvers(removed, repoResolvers),
+	}, nil
+}
+
+type savedSearchScheduleResolver struct {
+	schedule *types.SavedSearchSchedule
+}
+
+func (r *savedSearchScheduleResolver) IntervalMinutes() int32 { return r.schedule.IntervalMinutes }
+
+func (r *savedSearchScheduleResolver) NextRunAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.schedule.NextRunAt}
+}
+
+func (r *savedSearchScheduleResolver) LastRunAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.schedule.LastRunAt)
+}
+
+func (r *savedSearchScheduleResolver) LastRunError() *string { return r.schedule.LastRunError }
+
+type savedSearchResultChangesResolver struct {
+	added, removed []*savedSearchResultResolver
+}
+
+func (r *savedSearchResultChangesResolver) Added() []*savedSearchResultResolver { return r.added }
+
+func (r *savedSearchResultChangesResolver) Removed() []*savedSearchResultResolver { return r.removed }
+
+func toSavedSearchResultResolvers(results []*types.SavedSearchResult, repos map[api.RepoID]*RepositoryResolver) []*savedSearchResultResolver {
+	resolvers := make([]*savedSearchResultResolver, 0, len(results))
+	for _, res := range results {
+		repo, ok := repos[res.RepoID]
+		if !ok {
+			continue
+		}
+		resolvers = append(resolvers, &savedSearchResultResolver{result: res, repo: repo})
+	}
+	return resolvers
+}
+
+type savedSearchResultResolver struct {
+	result *types.SavedSearchResult
+	repo   *RepositoryResolver
+}
+
+func (r *savedSearchResultResolver) Type() string { return strings.ToUpper(string(r.result.Type)) }
+
+func (r *savedSearchResultResolver) Repository() *RepositoryResolver { return r.repo }
+
+func (r *savedSearchResultResolver) Path() *string {
+	if r.result.Path == "" {
+		return nil
+	}
+	return &r.result.Path
+}
+
+func (r *savedSearchResultResolver) LineNumber() *int32 {
+	if r.result.LineNumber == 0 {
+		return nil
+	}
+	return &r.result.LineNumber
+}
+
+func (r *savedSearchResultResolver) Label() string { return r.result.Label }
+
+func (r *savedSearchResultResolver) FirstSeenAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.result.FirstSeenAt}
+}
+
+func (r *schemaResolver) ScheduleSavedSearch(ctx context.Context, args *struct {
+	ID              graphql.ID
+	IntervalMinutes *int32
+}) (*savedSearchResolver, error) {
+	id, err := unmarshalSavedSearchID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ss, err := r.db.SavedSearches().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Make sure the current user has permission to update the
+	// saved search. Scheduled saved searches are run as their owner, so only
+	// saved searches owned by a user can be scheduled.
+	if ss.Config.UserID == nil {
+		return nil, errors.New("failed to schedule saved search: only saved searches owned by a user can be scheduled")
+	}
+	if err := auth.CheckSiteAdminOrSameUser(ctx, r.db, *ss.Config.UserID); err != nil {
+		return nil, err
+	}
+
+	if args.IntervalMinutes == nil {
+		err = r.db.SavedSearches().DeleteSchedule(ctx, id)
+	} else if *args.IntervalMinutes < minSavedSearchIntervalMinutes {
+		return nil, errors.Errorf("intervalMinutes must be at least %d", minSavedSearchIntervalMinutes)
+	} else {
+		_, err = r.db.SavedSearches().UpsertSchedule(ctx, id, *args.IntervalMinutes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toSavedSearchResolver(types.SavedSearch{
+		ID:              id,
+		Description:     ss.Config.Description,
+		Query:           ss.Config.Query,
+		Notify:          ss.Config.Notify,
+		NotifySlack:     ss.Config.NotifySlack,
+		UserID:          ss.Config.UserID,
+		OrgID:           ss.Config.OrgID,
+		SlackWebhookURL: ss.Config.SlackWebhookURL,
+	}), nil
+}
+
+// minSavedSearchIntervalMinutes is the smallest interval at which a saved
+// search can be scheduled, so that scheduled saved searches do not put too
+// much load on the search backends.
+const minSavedSearchIntervalMinutes = 5
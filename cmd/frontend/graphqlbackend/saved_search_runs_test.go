@@ -0,0 +1,147 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestScheduleSavedSearch(t *testing.T) {
+	user := &types.User{ID: 42}
+	orgID := int32(1)
+
+	setup := func(ssUserID, ssOrgID *int32) (database.DB, *database.MockSavedSearchStore) {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(user, nil)
+
+		savedSearches := database.NewMockSavedSearchStore()
+		savedSearches.GetByIDFunc.SetDefaultReturn(&api.SavedQuerySpecAndConfig{
+			Config: api.ConfigSavedQuery{
+				Query:  "foo",
+				UserID: ssUserID,
+				OrgID:  ssOrgID,
+			},
+		}, nil)
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.SavedSearchesFunc.SetDefaultReturn(savedSearches)
+		return db, savedSearches
+	}
+
+	schedule := func(db database.DB, intervalMinutes *int32) (*savedSearchResolver, error) {
+		ctx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+		return newSchemaResolver(db, gitserver.NewClient(db)).ScheduleSavedSearch(ctx, &struct {
+			ID              graphql.ID
+			IntervalMinutes *int32
+		}{
+			ID:              marshalSavedSearchID(1),
+			IntervalMinutes: intervalMinutes,
+		})
+	}
+
+	interval := func(v int32) *int32 { return &v }
+
+	t.Run("schedule", func(t *testing.T) {
+		db, savedSearches := setup(&user.ID, nil)
+		ss, err := schedule(db, interval(60))
+		require.NoError(t, err)
+		require.Equal(t, "foo", ss.Query())
+		mockrequire.CalledOnceWith(t, savedSearches.UpsertScheduleFunc, mockrequire.Values(mockrequire.Skip, int32(1), int32(60)))
+	})
+
+	t.Run("unschedule", func(t *testing.T) {
+		db, savedSearches := setup(&user.ID, nil)
+		_, err := schedule(db, nil)
+		require.NoError(t, err)
+		mockrequire.CalledOnce(t, savedSearches.DeleteScheduleFunc)
+		mockrequire.NotCalled(t, savedSearches.UpsertScheduleFunc)
+	})
+
+	t.Run("interval too small", func(t *testing.T) {
+		db, savedSearches := setup(&user.ID, nil)
+		_, err := schedule(db, interval(1))
+		require.Error(t, err)
+		mockrequire.NotCalled(t, savedSearches.UpsertScheduleFunc)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		otherUserID := int32(43)
+		db, savedSearches := setup(&otherUserID, nil)
+		_, err := schedule(db, interval(60))
+		require.Error(t, err)
+		mockrequire.NotCalled(t, savedSearches.UpsertScheduleFunc)
+	})
+
+	t.Run("org saved search", func(t *testing.T) {
+		db, savedSearches := setup(nil, &orgID)
+		_, err := schedule(db, interval(60))
+		require.Error(t, err)
+		mockrequire.NotCalled(t, savedSearches.UpsertScheduleFunc)
+	})
+}
+
+func TestSavedSearchResultChanges(t *testing.T) {
+	ctx := context.Background()
+	lastRunAt := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	savedSearches := database.NewMockSavedSearchStore()
+	savedSearches.GetScheduleFunc.SetDefaultReturn(&types.SavedSearchSchedule{SavedSearchID: 1, IntervalMinutes: 60, LastRunAt: &lastRunAt}, nil)
+	savedSearches.ListResultChangesFunc.SetDefaultHook(func(_ context.Context, _ int32, removed bool, _ int) ([]*types.SavedSearchResult, error) {
+		if removed {
+			return []*types.SavedSearchResult{{Type: types.SavedSearchResultTypeRepo, RepoID: 1, Label: "a"}}, nil
+		}
+		return []*types.SavedSearchResult{
+			{Type: types.SavedSearchResultTypeContent, RepoID: 1, Path: "a.go", LineNumber: 3, Label: "foo()"},
+			// The current user has no access to this repository
+			{Type: types.SavedSearchResultTypePath, RepoID: 2, Path: "b.go", Label: "b.go"},
+		}, nil
+	})
+
+	repos := database.NewMockRepoStore()
+	repos.GetByIDsFunc.SetDefaultHook(func(_ context.Context, ids ...api.RepoID) ([]*types.Repo, error) {
+		var res []*types.Repo
+		for _, id := range ids {
+			if id == 1 {
+				res = append(res, &types.Repo{ID: 1, Name: "a"})
+			}
+		}
+		return res, nil
+	})
+
+	db := database.NewMockDB()
+	db.SavedSearchesFunc.SetDefaultReturn(savedSearches)
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	r := savedSearchResolver{db: db, s: types.SavedSearch{ID: 1}}
+	changes, err := r.ResultChanges(ctx, &struct{ First int32 }{First: 10})
+	require.NoError(t, err)
+
+	added := changes.Added()
+	require.Len(t, added, 1)
+	require.Equal(t, "CONTENT", added[0].Type())
+	require.Equal(t, "a", added[0].Repository().Name())
+	require.Equal(t, int32(3), *added[0].LineNumber())
+
+	removed := changes.Removed()
+	require.Len(t, removed, 1)
+	require.Equal(t, "REPO", removed[0].Type())
+	require.Nil(t, removed[0].Path())
+
+	t.Run("not run yet", func(t *testing.T) {
+		savedSearches.GetScheduleFunc.PushReturn(&types.SavedSearchSchedule{SavedSearchID: 1, IntervalMinutes: 60}, nil)
+		changes, err := r.ResultChanges(ctx, &struct{ First int32 }{First: 10})
+		require.NoError(t, err)
+		require.Nil(t, changes)
+	})
+}
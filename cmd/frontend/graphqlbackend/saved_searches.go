@@ -236,6 +236,14 @@ func (r *schemaResolver) UpdateSavedSearch(ctx context.Context, args *struct {
 		return nil, err
 	}
 
+	// The results of previous scheduled runs cannot be compared with the
+	// results of a different query.
+	if old.Config.Query != args.Query {
+		if err := r.db.SavedSearches().ResetResults(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
 	return r.toSavedSearchResolver(*ss), nil
 }
 
@@ -261,6 +261,8 @@ func TestUpdateSavedSearch(t *testing.T) {
 	}}
 
 	mockrequire.Called(t, ss.UpdateFunc)
+	// The query changed, so the results of previous scheduled runs are reset
+	mockrequire.Called(t, ss.ResetResultsFunc)
 
 	if !reflect.DeepEqual(savedSearches, want) {
 		t.Errorf("got %v+, want %v+", savedSearches, want)
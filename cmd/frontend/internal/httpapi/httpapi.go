@@ -90,6 +90,10 @@ func NewHandler(
 		ExternalServices: db.ExternalServices(),
 	}
 
+	azdo := webhooks.AzureDevOpsWebhook{
+		ExternalServices: db.ExternalServices(),
+	}
+
 	webhookhandlers.Init(db, &gh)
 	webhookMiddleware := webhooks.NewLogMiddleware(
 		db.WebhookLogs(keyring.Default().WebhookLogKey),
@@ -101,6 +105,7 @@ func NewHandler(
 	m.Get(apirouter.GitLabWebhooks).Handler(trace.Route(webhookMiddleware.Logger(handlers.GitLabWebhook)))
 	m.Get(apirouter.BitbucketServerWebhooks).Handler(trace.Route(webhookMiddleware.Logger(handlers.BitbucketServerWebhook)))
 	m.Get(apirouter.BitbucketCloudWebhooks).Handler(trace.Route(webhookMiddleware.Logger(handlers.BitbucketCloudWebhook)))
+	m.Get(apirouter.AzureDevOpsWebhooks).Handler(trace.Route(webhookMiddleware.Logger(&azdo)))
 	m.Get(apirouter.BatchesFileGet).Handler(trace.Route(handlers.BatchesChangesFileGetHandler))
 	m.Get(apirouter.BatchesFileExists).Handler(trace.Route(handlers.BatchesChangesFileExistsHandler))
 	m.Get(apirouter.BatchesFileUpload).Handler(trace.Route(handlers.BatchesChangesFileUploadHandler))
@@ -110,6 +115,9 @@ func NewHandler(
 	ghSync := repos.GitHubWebhookHandler{}
 	ghSync.Register(&gh)
 
+	azdoSync := repos.AzureDevOpsWebhookHandler{}
+	azdoSync.Register(&azdo)
+
 	if envvar.SourcegraphDotComMode() {
 		m.Path("/updates").Methods("GET", "POST").Name("updatecheck").Handler(trace.Route(http.HandlerFunc(updatecheck.HandlerWithLog(logger))))
 	}
@@ -117,6 +125,7 @@ func NewHandler(
 	m.Get(apirouter.GraphQL).Handler(trace.Route(handler(serveGraphQL(logger, schema, rateLimiter, false))))
 
 	m.Get(apirouter.SearchStream).Handler(trace.Route(frontendsearch.StreamHandler(db)))
+	m.Get(apirouter.SearchExport).Handler(trace.Route(frontendsearch.ExportHandler(db)))
 
 	// Return the minimum src-cli version that's compatible with this instance
 	m.Get(apirouter.SrcCli).Handler(trace.Route(newSrcCliVersionHandler(logger)))
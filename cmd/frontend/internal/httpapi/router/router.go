@@ -12,6 +12,7 @@ const (
 	GraphQL    = "graphql"
 
 	SearchStream  = "search.stream"
+	SearchExport  = "search.export"
 	ComputeStream = "compute.stream"
 
 	SrcCli             = "src-cli"
@@ -27,6 +28,7 @@ const (
 	GitLabWebhooks          = "gitlab.webhooks"
 	BitbucketServerWebhooks = "bitbucketServer.webhooks"
 	BitbucketCloudWebhooks  = "bitbucketCloud.webhooks"
+	AzureDevOpsWebhooks     = "azureDevOps.webhooks"
 
 	BatchesFileGet    = "batches.file.get"
 	BatchesFileExists = "batches.file.exists"
@@ -60,11 +62,13 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/gitlab-webhooks").Methods("POST").Name(GitLabWebhooks)
 	base.Path("/bitbucket-server-webhooks").Methods("POST").Name(BitbucketServerWebhooks)
 	base.Path("/bitbucket-cloud-webhooks").Methods("POST").Name(BitbucketCloudWebhooks)
+	base.Path("/azuredevops-webhooks").Methods("POST").Name(AzureDevOpsWebhooks)
 	base.Path("/files/batch-changes/{spec}/{file}").Methods("GET").Name(BatchesFileGet)
 	base.Path("/files/batch-changes/{spec}/{file}").Methods("HEAD").Name(BatchesFileExists)
 	base.Path("/files/batch-changes/{spec}").Methods("POST").Name(BatchesFileUpload)
 	base.Path("/lsif/upload").Methods("POST").Name(LSIFUpload)
 	base.Path("/search/stream").Methods("GET").Name(SearchStream)
+	base.Path("/search/export").Methods("GET").Name(SearchExport)
 	base.Path("/compute/stream").Methods("GET", "POST").Name(ComputeStream)
 	base.Path("/src-cli/versions/{rest:.*}").Methods("GET", "POST").Name(SrcCliVersionCache)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCli)
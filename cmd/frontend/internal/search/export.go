@@ -0,0 +1,406 @@
+package search
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ExportHandler is an http handler which runs a search to completion and
+// streams back every match as rows of newline delimited JSON or CSV.
+//
+// Rows are written in a stable order: by repository name, then by match within
+// a repository. Every row carries a cursor that can be passed back to resume
+// the export after that row, e.g. after a dropped connection.
+//
+// To produce that order, the search runs once and its matches are sorted before
+// they are written. Matches at or before the cursor are dropped as they stream
+// in, so resuming an export only keeps the remaining matches in memory.
+func ExportHandler(db database.DB) http.Handler {
+	logger := log.Scoped("searchExportHandler", "")
+	return &exportHandler{
+		logger:       logger,
+		db:           db,
+		searchClient: client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs()),
+	}
+}
+
+type exportHandler struct {
+	logger       log.Logger
+	db           database.DB
+	searchClient client.SearchClient
+}
+
+func (h *exportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tr, ctx := trace.New(r.Context(), "search.ServeExport", "")
+	defer tr.Finish()
+
+	args, err := parseExportURLQuery(r.URL.Query())
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tr.TagFields(
+		otlog.String("query", args.Query),
+		otlog.String("version", args.Version),
+		otlog.String("pattern_type", args.PatternType),
+		otlog.String("format", string(args.Format)),
+	)
+
+	settings, err := graphqlbackend.DecodedViewerFinalSettings(ctx, h.db)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	inputs, err := h.searchClient.Plan(
+		ctx,
+		args.Version,
+		strPtr(args.PatternType),
+		args.Query,
+		search.Precise,
+		search.Streaming,
+		settings,
+		envvar.SourcegraphDotComMode(),
+	)
+	if err != nil {
+		tr.SetError(err)
+		var queryErr *client.QueryError
+		if errors.As(err, &queryErr) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	exportWriter, err := streamhttp.NewExportWriter(w, args.Format)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = h.export(ctx, inputs, args, exportWriter)
+	if err != nil {
+		tr.SetError(err)
+		h.logger.Warn("search export failed", log.String("query", args.Query), log.Error(err))
+		if !exportWriter.Written() {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_ = exportWriter.Error(err)
+		return
+	}
+	_ = exportWriter.Flush()
+}
+
+func (h *exportHandler) export(ctx context.Context, inputs *search.Inputs, args *exportArgs, exportWriter *streamhttp.ExportWriter) error {
+	matches, err := h.searchMatches(ctx, inputs, args.Cursor)
+	if err != nil {
+		return err
+	}
+
+	for i, match := range matches {
+		for j, row := range exportRows(match.match) {
+			cursor := &exportCursor{
+				Query: args.fingerprint(),
+				Repo:  match.repo,
+				Match: match.key,
+				Row:   j,
+			}
+			if args.Cursor != nil && !args.Cursor.before(cursor) {
+				continue
+			}
+
+			row.Cursor = cursor.encode()
+			if err := exportWriter.Row(row); err != nil {
+				return err
+			}
+		}
+
+		// Flush once all the rows of a repository have been written.
+		if i == len(matches)-1 || matches[i+1].repo != match.repo {
+			if err := exportWriter.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+type exportMatch struct {
+	repo  api.RepoName
+	key   string
+	match result.Match
+}
+
+// searchMatches runs the search and returns the matches after the cursor,
+// sorted by repository name and then by match.
+func (h *exportHandler) searchMatches(ctx context.Context, inputs *search.Inputs, after *exportCursor) ([]exportMatch, error) {
+	var (
+		mu      sync.Mutex
+		matches []exportMatch
+		errs    error
+	)
+	stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		repoMetadata, err := getEventRepoMetadata(ctx, h.db, event)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil {
+			errs = errors.Append(errs, err)
+			return
+		}
+		for _, match := range event.Results {
+			repo := match.RepoName()
+
+			// Don't export matches which we cannot map to a repo the actor
+			// has access to, see eventHandler.Send.
+			if md, ok := repoMetadata[repo.ID]; !ok || md.Name != repo.Name {
+				continue
+			}
+
+			m := exportMatch{repo: repo.Name, key: exportMatchKey(match), match: match}
+			// The rows of the match at the cursor are skipped one by one
+			// when they are written.
+			if after != nil && (m.repo < after.Repo || (m.repo == after.Repo && m.key < after.Match)) {
+				continue
+			}
+			matches = append(matches, m)
+		}
+	})
+
+	_, err := h.searchClient.Execute(ctx, stream, inputs)
+	if err != nil {
+		return nil, err
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].repo != matches[j].repo {
+			return matches[i].repo < matches[j].repo
+		}
+		return matches[i].key < matches[j].key
+	})
+	return matches, nil
+}
+
+// exportMatchKey returns a key that orders the matches of a repository. Unlike
+// result.Key, it does not contain the commit of file matches, so that the
+// order of files doesn't change when new commits are pushed to the repository.
+func exportMatchKey(match result.Match) string {
+	key := match.Key()
+	if _, ok := match.(*result.FileMatch); ok {
+		key.Commit = ""
+	}
+	return fmt.Sprintf("%d\x00%s\x00%s\x00%s", key.TypeRank, key.Rev, key.Commit, key.Path)
+}
+
+// exportRows flattens a match into the rows of an export.
+func exportRows(match result.Match) []*streamhttp.ExportRow {
+	switch v := match.(type) {
+	case *result.FileMatch:
+		return exportFileMatchRows(v)
+	case *result.RepoMatch:
+		return []*streamhttp.ExportRow{{
+			Type:         streamhttp.RepoMatchType,
+			RepositoryID: int32(v.ID),
+			Repository:   string(v.Name),
+			Revision:     v.Rev,
+		}}
+	case *result.CommitMatch:
+		authorDate := v.Commit.Author.Date
+		return []*streamhttp.ExportRow{{
+			Type:         streamhttp.CommitMatchType,
+			RepositoryID: int32(v.Repo.ID),
+			Repository:   string(v.Repo.Name),
+			Commit:       string(v.Commit.ID),
+			AuthorName:   v.Commit.Author.Name,
+			AuthorDate:   &authorDate,
+			Message:      string(v.Commit.Message),
+		}}
+	default:
+		return nil
+	}
+}
+
+func exportFileMatchRows(fm *result.FileMatch) []*streamhttp.ExportRow {
+	newRow := func(typ streamhttp.MatchType) *streamhttp.ExportRow {
+		row := &streamhttp.ExportRow{
+			Type:         typ,
+			RepositoryID: int32(fm.Repo.ID),
+			Repository:   string(fm.Repo.Name),
+			Commit:       string(fm.CommitID),
+			Path:         fm.Path,
+		}
+		if fm.InputRev != nil {
+			row.Revision = *fm.InputRev
+		}
+		return row
+	}
+
+	var rows []*streamhttp.ExportRow
+	if len(fm.Symbols) > 0 {
+		for _, sym := range fm.Symbols {
+			kind := "UNKNOWN"
+			if k := sym.Symbol.LSPKind(); k != 0 {
+				kind = strings.ToUpper(k.String())
+			}
+
+			row := newRow(streamhttp.SymbolMatchType)
+			row.LineNumber = int32(sym.Symbol.Line)
+			row.SymbolName = sym.Symbol.Name
+			row.SymbolKind = kind
+			row.SymbolContainer = sym.Symbol.Parent
+			rows = append(rows, row)
+		}
+		return rows
+	}
+
+	for _, lm := range fm.ChunkMatches.AsLineMatches() {
+		if len(lm.OffsetAndLengths) == 0 {
+			continue
+		}
+
+		row := newRow(streamhttp.ContentMatchType)
+		row.LineNumber = lm.LineNumber + 1
+		row.Line = lm.Preview
+		for _, ol := range lm.OffsetAndLengths {
+			row.Ranges = append(row.Ranges, [2]int32{ol[0], ol[0] + ol[1]})
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) > 0 {
+		return rows
+	}
+
+	row := newRow(streamhttp.PathMatchType)
+	for _, r := range fm.PathMatches {
+		row.Ranges = append(row.Ranges, [2]int32{int32(r.Start.Column), int32(r.End.Column)})
+	}
+	return []*streamhttp.ExportRow{row}
+}
+
+// exportCursor is the position of a row in an export. It is encoded into the
+// opaque cursor of every row.
+type exportCursor struct {
+	// Query is the fingerprint of the exported query, so that a cursor cannot
+	// be used to resume the export of another query.
+	Query string       `json:"q"`
+	Repo  api.RepoName `json:"r"`
+	Match string       `json:"m"`
+	Row   int          `json:"i"`
+}
+
+// before returns whether c is positioned before other.
+func (c *exportCursor) before(other *exportCursor) bool {
+	if c.Repo != other.Repo {
+		return c.Repo < other.Repo
+	}
+	if c.Match != other.Match {
+		return c.Match < other.Match
+	}
+	return c.Row < other.Row
+}
+
+func (c *exportCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeExportCursor(s string) (*exportCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cursor")
+	}
+	var c exportCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, errors.Wrap(err, "invalid cursor")
+	}
+	return &c, nil
+}
+
+type exportArgs struct {
+	Query       string
+	Version     string
+	PatternType string
+	Format      streamhttp.ExportFormat
+	Cursor      *exportCursor
+}
+
+// fingerprint returns a hash of the arguments that determine the exported
+// rows.
+func (a *exportArgs) fingerprint() string {
+	h := sha256.Sum256([]byte(a.Version + "\x00" + a.PatternType + "\x00" + a.Query))
+	return hex.EncodeToString(h[:8])
+}
+
+func parseExportURLQuery(q url.Values) (*exportArgs, error) {
+	get := func(k, def string) string {
+		v := q.Get(k)
+		if v == "" {
+			return def
+		}
+		return v
+	}
+
+	a := exportArgs{
+		Query:       get("q", ""),
+		Version:     get("v", "V3"),
+		PatternType: get("t", ""),
+		Format:      streamhttp.ExportFormat(get("format", string(streamhttp.ExportFormatJSON))),
+	}
+
+	if a.Query == "" {
+		return nil, errors.New("no query found")
+	}
+
+	switch a.Format {
+	case streamhttp.ExportFormatJSON, streamhttp.ExportFormatCSV:
+	default:
+		return nil, errors.Errorf("format must be %q or %q, got %q", streamhttp.ExportFormatJSON, streamhttp.ExportFormatCSV, a.Format)
+	}
+
+	if cursor := get("cursor", ""); cursor != "" {
+		c, err := decodeExportCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if c.Query != a.fingerprint() {
+			return nil, errors.New("cursor belongs to the export of a different query")
+		}
+		a.Cursor = c
+	}
+
+	return &a, nil
+}
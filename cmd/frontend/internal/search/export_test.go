@@ -0,0 +1,175 @@
+package search
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	api2 "github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestServeExport(t *testing.T) {
+	graphqlbackend.MockDecodedViewerFinalSettings = &schema.Settings{}
+	t.Cleanup(func() { graphqlbackend.MockDecodedViewerFinalSettings = nil })
+
+	repos := map[api2.RepoID]api2.RepoName{1: "a", 2: "b"}
+	fileMatch := func(repoID api2.RepoID, path string, lines ...string) *result.FileMatch {
+		var chunks result.ChunkMatches
+		for i, line := range lines {
+			chunks = append(chunks, result.ChunkMatch{
+				Content:      line,
+				ContentStart: result.Location{Line: i},
+				Ranges: result.Ranges{{
+					Start: result.Location{Line: i, Column: 0},
+					End:   result.Location{Line: i, Column: 1},
+				}},
+			})
+		}
+		return &result.FileMatch{
+			File:         result.File{Repo: types.MinimalRepo{ID: repoID, Name: repos[repoID]}, CommitID: "abc", Path: path},
+			ChunkMatches: chunks,
+		}
+	}
+	// Matches are returned out of order to make sure the export sorts them.
+	matches := result.Matches{
+		fileMatch(2, "z.go", "z"),
+		fileMatch(1, "b.go", "b"),
+		fileMatch(2, "y.go", "y"),
+		fileMatch(1, "a.go", "a1", "a2"),
+	}
+
+	mock := client.NewMockSearchClient()
+	mock.PlanFunc.SetDefaultHook(func(_ context.Context, _ string, _ *string, q string, _ search.Mode, _ search.Protocol, _ *schema.Settings, _ bool) (*search.Inputs, error) {
+		plan, err := query.Pipeline(query.InitLiteral(q))
+		if err != nil {
+			return nil, err
+		}
+		return &search.Inputs{Plan: plan, Query: plan.ToQ()}, nil
+	})
+	mock.ExecuteFunc.SetDefaultHook(func(_ context.Context, s streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+		for _, m := range matches {
+			s.Send(streaming.SearchEvent{Results: result.Matches{m}})
+		}
+		return nil, nil
+	})
+
+	mockRepos := database.NewMockRepoStore()
+	mockRepos.MetadataFunc.SetDefaultHook(func(_ context.Context, ids ...api2.RepoID) ([]*types.SearchedRepo, error) {
+		out := make([]*types.SearchedRepo, 0, len(ids))
+		for _, id := range ids {
+			out = append(out, &types.SearchedRepo{ID: id, Name: repos[id]})
+		}
+		return out, nil
+	})
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(mockRepos)
+
+	ts := httptest.NewServer(&exportHandler{
+		logger:       logtest.Scoped(t),
+		db:           db,
+		searchClient: mock,
+	})
+	defer ts.Close()
+
+	get := func(t *testing.T, params url.Values) *http.Response {
+		t.Helper()
+		res, err := http.Get(ts.URL + "?" + params.Encode())
+		require.NoError(t, err)
+		t.Cleanup(func() { res.Body.Close() })
+		return res
+	}
+
+	readRows := func(t *testing.T, params url.Values) []streamhttp.ExportRow {
+		t.Helper()
+		res := get(t, params)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var rows []streamhttp.ExportRow
+		scanner := bufio.NewScanner(res.Body)
+		for scanner.Scan() {
+			var row streamhttp.ExportRow
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+			rows = append(rows, row)
+		}
+		require.NoError(t, scanner.Err())
+		return rows
+	}
+
+	type position struct {
+		repo string
+		path string
+		line int32
+	}
+	positions := func(rows []streamhttp.ExportRow) []position {
+		res := make([]position, 0, len(rows))
+		for _, row := range rows {
+			res = append(res, position{row.Repository, row.Path, row.LineNumber})
+		}
+		return res
+	}
+
+	var rows []streamhttp.ExportRow
+	t.Run("json", func(t *testing.T) {
+		calls := len(mock.ExecuteFunc.History())
+		rows = readRows(t, url.Values{"q": {"test count:all"}})
+		require.Len(t, mock.ExecuteFunc.History(), calls+1, "the export must run a single search")
+		require.Equal(t, []position{
+			{"a", "a.go", 1},
+			{"a", "a.go", 2},
+			{"a", "b.go", 1},
+			{"b", "y.go", 1},
+			{"b", "z.go", 1},
+		}, positions(rows))
+		require.Equal(t, [][2]int32{{0, 1}}, rows[0].Ranges)
+		require.Equal(t, "a1", rows[0].Line)
+	})
+
+	t.Run("resume with cursor", func(t *testing.T) {
+		for i, row := range rows {
+			resumed := readRows(t, url.Values{"q": {"test count:all"}, "cursor": {row.Cursor}})
+			require.Equal(t, positions(rows[i+1:]), positions(resumed))
+		}
+	})
+
+	t.Run("cursor of another query", func(t *testing.T) {
+		res := get(t, url.Values{"q": {"other"}, "cursor": {rows[0].Cursor}})
+		require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		res := get(t, url.Values{"q": {"test count:all"}, "format": {"csv"}})
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, "text/csv; charset=utf-8", res.Header.Get("Content-Type"))
+
+		scanner := bufio.NewScanner(res.Body)
+		var lines []string
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		require.Len(t, lines, len(rows)+1)
+		require.True(t, strings.HasPrefix(lines[1], "content,1,a,,abc,a.go,1,a1,0-1,"))
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		res := get(t, url.Values{"q": {"test"}, "format": {"xml"}})
+		require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
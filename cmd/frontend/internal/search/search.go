@@ -301,11 +301,20 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo,
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
 		return fromCommit(v, repoCache)
+	case *result.OwnerMatch:
+		return fromOwner(v)
 	default:
 		panic(fmt.Sprintf("unknown match type %T", v))
 	}
 }
 
+func fromOwner(om *result.OwnerMatch) *streamhttp.EventOwnerMatch {
+	return &streamhttp.EventOwnerMatch{
+		Type:   streamhttp.OwnerMatchType,
+		Handle: om.Handle,
+	}
+}
+
 func fromFileMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.SearchedRepo, enableChunkMatches bool) streamhttp.EventMatch {
 	if len(fm.Symbols) > 0 {
 		return fromSymbolMatch(fm, repoCache)
@@ -671,9 +680,13 @@ func (h *eventHandler) Send(event streaming.SearchEvent) {
 
 		// Don't send matches which we cannot map to a repo the actor has access to. This
 		// check is expected to always pass. Missing metadata is a sign that we have
-		// searched repos that user shouldn't have access to.
-		if md, ok := repoMetadata[repo.ID]; !ok || md.Name != repo.Name {
-			continue
+		// searched repos that user shouldn't have access to. Owner matches are not
+		// associated with a repo, the files they were selected from have already been
+		// checked.
+		if _, isOwner := match.(*result.OwnerMatch); !isOwner {
+			if md, ok := repoMetadata[repo.ID]; !ok || md.Name != repo.Name {
+				continue
+			}
 		}
 
 		eventMatch := fromMatch(match, repoMetadata, h.enableChunkMatches)
@@ -0,0 +1,150 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/subtle"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/inconshreveable/log15"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// AzureDevOpsWebhook is responsible for handling incoming http requests for
+// Azure DevOps service hooks and routing to any registered WebhookHandlers.
+// Events are routed by the eventType field of the payload, e.g. "git.push".
+type AzureDevOpsWebhook struct {
+	ExternalServices database.ExternalServiceStore
+
+	mu       sync.RWMutex
+	handlers map[string][]WebhookHandler
+}
+
+func (h *AzureDevOpsWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log15.Error("Error parsing azure devops webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// get external service and validate webhook credentials
+	extSvc, err := h.getExternalService(r)
+	if err != nil {
+		log15.Error("Could not find valid external service for webhook", "error", err)
+		http.Error(w, "External service not found", http.StatusUnauthorized)
+		return
+	}
+
+	SetExternalServiceID(r.Context(), extSvc.ID)
+
+	// 🚨 SECURITY: now that the shared secret has been validated, we can use an
+	// internal actor on the context.
+	ctx := actor.WithInternalActor(r.Context())
+
+	// parse event
+	eventType, e, err := azuredevops.ParseEvent(body)
+	if err != nil {
+		log15.Error("Error parsing azure devops webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// match event handlers
+	err = h.Dispatch(ctx, eventType, extSvc, e)
+	if err != nil {
+		log15.Error("Error handling azure devops webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// Dispatch accepts an event for a particular event type and dispatches it
+// to the appropriate stack of handlers, if any are configured.
+func (h *AzureDevOpsWebhook) Dispatch(ctx context.Context, eventType string, extSvc *types.ExternalService, e any) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	g := errgroup.Group{}
+	for _, handler := range h.handlers[eventType] {
+		// capture the handler variable within this loop
+		handler := handler
+		g.Go(func() error {
+			return handler(ctx, extSvc, e)
+		})
+	}
+	return g.Wait()
+}
+
+// Register associates a given event type(s) with the specified handler.
+func (h *AzureDevOpsWebhook) Register(handler WebhookHandler, eventTypes ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.handlers == nil {
+		h.handlers = make(map[string][]WebhookHandler)
+	}
+	for _, eventType := range eventTypes {
+		h.handlers[eventType] = append(h.handlers[eventType], handler)
+	}
+}
+
+func (h *AzureDevOpsWebhook) getExternalService(r *http.Request) (*types.ExternalService, error) {
+	rawID := r.FormValue(extsvc.IDParam)
+	if rawID == "" {
+		return nil, errors.Errorf("missing %s parameter", extsvc.IDParam)
+	}
+
+	externalServiceID, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	e, err := h.ExternalServices.GetByID(r.Context(), externalServiceID)
+	if err != nil {
+		return nil, err
+	}
+	c, err := e.Configuration(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	ac, ok := c.(*schema.AzureDevOpsConnection)
+	if !ok {
+		return nil, errors.Errorf("invalid configuration, received azure devops webhook for non-azure devops external service: %v", externalServiceID)
+	}
+
+	if err := validateAzureDevOpsSecret(ac, r); err != nil {
+		return nil, errors.Wrap(err, "validating webhook credentials")
+	}
+	return e, nil
+}
+
+// validateAzureDevOpsSecret validates the basic authentication credentials of
+// the service hook request against the configured webhook secret. Azure DevOps
+// doesn't sign payloads, so the secret is configured as the basic auth
+// password of the service hook subscription.
+func validateAzureDevOpsSecret(c *schema.AzureDevOpsConnection, r *http.Request) error {
+	// 🚨 SECURITY: If no secret is configured, we don't accept any hooks, since
+	// anybody could otherwise trigger repo updates.
+	if c.WebhookSecret == "" {
+		return errors.New("no webhook secret configured")
+	}
+
+	_, password, ok := r.BasicAuth()
+	if !ok {
+		return errors.New("missing basic auth credentials")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(password), []byte(c.WebhookSecret)) != 1 {
+		return errors.New("unable to validate webhook secret")
+	}
+
+	return nil
+}
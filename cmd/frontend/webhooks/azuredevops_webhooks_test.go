@@ -0,0 +1,112 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestAzureDevOpsWebhook(t *testing.T) {
+	config, err := json.Marshal(schema.AzureDevOpsConnection{
+		Url:           "https://dev.azure.com",
+		Username:      "admin",
+		Token:         "token",
+		Orgs:          []string{"fabrikam"},
+		WebhookSecret: "super-secret-value",
+	})
+	require.NoError(t, err)
+
+	extSvc := &types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindAzureDevOps,
+		Config: extsvc.NewUnencryptedConfig(string(config)),
+	}
+
+	externalServices := database.NewMockExternalServiceStore()
+	externalServices.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int64) (*types.ExternalService, error) {
+		if id != extSvc.ID {
+			return nil, errors.Newf("external service not found: %d", id)
+		}
+		return extSvc, nil
+	})
+
+	h := &AzureDevOpsWebhook{ExternalServices: externalServices}
+
+	var pushes []*azuredevops.PushEvent
+	h.Register(func(ctx context.Context, svc *types.ExternalService, payload any) error {
+		pushes = append(pushes, payload.(*azuredevops.PushEvent))
+		return nil
+	}, azuredevops.EventTypeGitPush)
+
+	payload := []byte(`{
+  "eventType": "git.push",
+  "resource": {
+    "pushId": 1,
+    "repository": {
+      "id": "278d5cd2-584d-4b63-824a-2ba458937249",
+      "name": "Fabrikam-Fiber-Git",
+      "remoteUrl": "https://fabrikam@dev.azure.com/fabrikam/Fabrikam-Fiber-Git/_git/Fabrikam-Fiber-Git"
+    }
+  }
+}`)
+
+	for _, tc := range []struct {
+		name       string
+		target     string
+		password   string
+		wantStatus int
+		wantPushes int
+	}{
+		{
+			name:       "valid secret",
+			target:     "/azuredevops-webhooks?externalServiceID=1",
+			password:   "super-secret-value",
+			wantStatus: http.StatusOK,
+			wantPushes: 1,
+		},
+		{
+			name:       "invalid secret",
+			target:     "/azuredevops-webhooks?externalServiceID=1",
+			password:   "wrong",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "missing external service ID",
+			target:     "/azuredevops-webhooks",
+			password:   "super-secret-value",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unknown external service",
+			target:     "/azuredevops-webhooks?externalServiceID=2",
+			password:   "super-secret-value",
+			wantStatus: http.StatusUnauthorized,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pushes = nil
+
+			req := httptest.NewRequest("POST", tc.target, bytes.NewReader(payload))
+			req.SetBasicAuth("sourcegraph", tc.password)
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			assert.Len(t, pushes, tc.wantPushes)
+		})
+	}
+}
@@ -268,7 +268,12 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 	}
 
 	if req.Push != nil {
-		cmd = exec.CommandContext(ctx, "git", "push", "--force", remoteURL.String(), fmt.Sprintf("%s:%s", cmtHash, ref))
+		pushRef := ref
+		if req.PushRef != "" {
+			pushRef = req.PushRef
+		}
+
+		cmd = exec.CommandContext(ctx, "git", "push", "--force", remoteURL.String(), fmt.Sprintf("%s:%s", cmtHash, pushRef))
 		cmd.Dir = repoGitDir
 
 		// If the protocol is SSH and a private key was given, we want to
@@ -28,6 +28,7 @@ func NewHandler(
 	mux.HandleFunc("/localCodeIntel", squirrel.LocalCodeIntelHandler(readFileFunc))
 	mux.HandleFunc("/debugLocalCodeIntel", squirrel.DebugLocalCodeIntelHandler)
 	mux.HandleFunc("/symbolInfo", squirrel.NewSymbolInfoHandler(searchFunc, readFileFunc))
+	mux.HandleFunc("/symbolReferences", squirrel.NewSymbolReferencesHandler(searchFunc, readFileFunc))
 	if handleStatus != nil {
 		mux.HandleFunc("/status", handleStatus)
 	}
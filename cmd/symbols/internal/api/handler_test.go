@@ -39,14 +39,18 @@ func TestHandler(t *testing.T) {
 		pathToEntries := map[string][]*ctags.Entry{
 			"a.js": {
 				{
-					Name: "x",
-					Path: "a.js",
-					Line: 1, // ctags line numbers are 1-based
+					Name:   "x",
+					Path:   "a.js",
+					Line:   1, // ctags line numbers are 1-based
+					Kind:   "variable",
+					Parent: "Foo",
 				},
 				{
-					Name: "y",
-					Path: "a.js",
-					Line: 2,
+					Name:   "y",
+					Path:   "a.js",
+					Line:   2,
+					Kind:   "method",
+					Parent: "Bar",
 				},
 			},
 		}
@@ -76,8 +80,8 @@ func TestHandler(t *testing.T) {
 		HTTPClient: httpcli.InternalDoer,
 	}
 
-	x := result.Symbol{Name: "x", Path: "a.js", Line: 0, Character: 4}
-	y := result.Symbol{Name: "y", Path: "a.js", Line: 1, Character: 4}
+	x := result.Symbol{Name: "x", Path: "a.js", Line: 0, Character: 4, Kind: "variable", Parent: "Foo"}
+	y := result.Symbol{Name: "y", Path: "a.js", Line: 1, Character: 4, Kind: "method", Parent: "Bar"}
 
 	testCases := map[string]struct {
 		args     search.SymbolsParameters
@@ -123,6 +127,30 @@ func TestHandler(t *testing.T) {
 			args:     search.SymbolsParameters{ExcludePattern: "a.js", IsCaseSensitive: true, First: 10},
 			expected: nil,
 		},
+		"kinds": {
+			args:     search.SymbolsParameters{Kinds: []string{"Method", "methodSpec"}, First: 10},
+			expected: []result.Symbol{y},
+		},
+		"nokinds": {
+			args:     search.SymbolsParameters{Kinds: []string{"class"}, First: 10},
+			expected: nil,
+		},
+		"caseinsensitivecontainer": {
+			args:     search.SymbolsParameters{Container: "foo", First: 10},
+			expected: []result.Symbol{x},
+		},
+		"casesensitivecontainer": {
+			args:     search.SymbolsParameters{Container: "Foo", IsCaseSensitive: true, First: 10},
+			expected: []result.Symbol{x},
+		},
+		"casesensitivenocontainer": {
+			args:     search.SymbolsParameters{Container: "foo", IsCaseSensitive: true, First: 10},
+			expected: nil,
+		},
+		"kindsandcontainer": {
+			args:     search.SymbolsParameters{Kinds: []string{"method"}, Container: "Foo", First: 10},
+			expected: nil,
+		},
 	}
 
 	for label, testCase := range testCases {
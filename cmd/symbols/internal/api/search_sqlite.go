@@ -35,6 +35,8 @@ func MakeSqliteSearchFunc(operations *sharedobservability.Operations, cachedData
 			log.Int("numIncludePatterns", len(args.IncludePatterns)),
 			log.String("includePatterns", strings.Join(args.IncludePatterns, ":")),
 			log.String("excludePattern", args.ExcludePattern),
+			log.String("kinds", strings.Join(args.Kinds, ",")),
+			log.String("container", args.Container),
 			log.Int("first", args.First),
 			log.Int("timeout", args.Timeout),
 		}})
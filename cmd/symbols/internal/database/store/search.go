@@ -68,12 +68,14 @@ func (s *store) Search(ctx context.Context, args search.SymbolsParameters) ([]re
 }
 
 func makeSearchConditions(args search.SymbolsParameters) []*sqlf.Query {
-	conditions := make([]*sqlf.Query, 0, 2+len(args.IncludePatterns))
+	conditions := make([]*sqlf.Query, 0, 4+len(args.IncludePatterns))
 	conditions = append(conditions, makeSearchCondition("name", args.Query, args.IsCaseSensitive))
 	conditions = append(conditions, negate(makeSearchCondition("path", args.ExcludePattern, args.IsCaseSensitive)))
 	for _, includePattern := range args.IncludePatterns {
 		conditions = append(conditions, makeSearchCondition("path", includePattern, args.IsCaseSensitive))
 	}
+	conditions = append(conditions, makeKindsCondition(args.Kinds))
+	conditions = append(conditions, makeContainerCondition(args.Container, args.IsCaseSensitive))
 
 	filtered := conditions[:0]
 	for _, condition := range conditions {
@@ -120,6 +122,29 @@ func makeSearchCondition(column string, regex string, isCaseSensitive bool) *sql
 	return sqlf.Sprintf(column+" REGEXP %s", regex)
 }
 
+func makeKindsCondition(kinds []string) *sqlf.Query {
+	if len(kinds) == 0 {
+		return nil
+	}
+
+	lowered := make([]*sqlf.Query, 0, len(kinds))
+	for _, kind := range kinds {
+		lowered = append(lowered, sqlf.Sprintf("%s", strings.ToLower(kind)))
+	}
+	return sqlf.Sprintf("lower(kind) IN (%s)", sqlf.Join(lowered, ","))
+}
+
+func makeContainerCondition(container string, isCaseSensitive bool) *sqlf.Query {
+	if container == "" {
+		return nil
+	}
+
+	if isCaseSensitive {
+		return sqlf.Sprintf("parent = %s", container)
+	}
+	return sqlf.Sprintf("lower(parent) = %s", strings.ToLower(container))
+}
+
 // isLiteralEquality returns true if the given regex matches literal strings exactly.
 // If so, this function returns true along with the literal search query. If not, this
 // function returns false.
@@ -37,6 +37,9 @@ func (s *store) CreateSymbolIndexes(ctx context.Context) error {
 		`CREATE INDEX idx_path ON symbols(path)`,
 		`CREATE INDEX idx_namelowercase ON symbols(namelowercase)`,
 		`CREATE INDEX idx_pathlowercase ON symbols(pathlowercase)`,
+		`CREATE INDEX idx_kindlowercase ON symbols(lower(kind))`,
+		`CREATE INDEX idx_parent ON symbols(parent)`,
+		`CREATE INDEX idx_parentlowercase ON symbols(lower(parent))`,
 	}
 
 	for _, query := range createIndexQueries {
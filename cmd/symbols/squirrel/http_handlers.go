@@ -131,6 +131,58 @@ func NewSymbolInfoHandler(symbolSearch symbolsTypes.SearchFunc, readFile ReadFil
 	}
 }
 
+// Responds to /symbolReferences
+func NewSymbolReferencesHandler(symbolSearch symbolsTypes.SearchFunc, readFile ReadFileFunc) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Read the args from the request body.
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			log15.Error("failed to read request body", "err", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		var args types.RepoCommitPathPoint
+		if err := json.NewDecoder(bytes.NewReader(body)).Decode(&args); err != nil {
+			log15.Error("failed to decode request body", "err", err, "body", string(body))
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Find the references.
+		squirrel := New(readFile, symbolSearch)
+		defer squirrel.Close()
+		result, err := squirrel.symbolReferences(r.Context(), args)
+		if os.Getenv("SQUIRREL_DEBUG") == "true" {
+			debugStringBuilder := &strings.Builder{}
+			fmt.Fprintln(debugStringBuilder, "👉 /symbolReferences repo:", args.Repo, "commit:", args.Commit, "path:", args.Path, "row:", args.Row, "column:", args.Column)
+			squirrel.breadcrumbs.pretty(debugStringBuilder, readFile)
+			if result == nil {
+				fmt.Fprintln(debugStringBuilder, "❌ no definition found")
+			} else {
+				fmt.Fprintln(debugStringBuilder, "✅ /symbolReferences", len(result.References), "references")
+			}
+
+			fmt.Println(" ")
+			fmt.Println(bracket(debugStringBuilder.String()))
+			fmt.Println(" ")
+		}
+		if err != nil {
+			_ = json.NewEncoder(w).Encode(nil)
+			log15.Error("failed to find references", "err", err)
+			return
+		}
+
+		// Write the response.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(result)
+		if err != nil {
+			log15.Error("failed to write response: %s", "error", err)
+			http.Error(w, fmt.Sprintf("failed to find references: %s", err), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
 // Response to /debugLocalCodeIntel.
 func DebugLocalCodeIntelHandler(w http.ResponseWriter, r *http.Request) {
 	// Read ?ext=<ext> from the request.
@@ -0,0 +1,910 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/regexp"
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (squirrel *SquirrelService) getDefGo(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		fallthrough
+	case "package_identifier":
+		fallthrough
+	case "field_identifier":
+		ident := node.Content(node.Contents)
+
+		cur := node.Node
+
+	outer:
+		for {
+			prev := cur
+			cur = cur.Parent()
+			if cur == nil {
+				squirrel.breadcrumb(node, "getDefGo: ran out of parents")
+				return nil, nil
+			}
+
+			switch cur.Type() {
+
+			case "source_file":
+				return squirrel.getDefInFileOrPackageGo(ctx, swapNode(node, cur), ident)
+
+			case "import_spec":
+				return squirrel.getImportDirGo(ctx, swapNode(node, cur))
+
+			// Check for field access
+			case "selector_expression":
+				operand := cur.ChildByFieldName("operand")
+				if operand == nil || nodeId(prev) == nodeId(operand) {
+					continue
+				}
+				return squirrel.getFieldGo(ctx, swapNode(node, operand), ident)
+
+			case "qualified_type":
+				pkg := cur.ChildByFieldName("package")
+				if pkg == nil || nodeId(prev) == nodeId(pkg) {
+					continue
+				}
+				return squirrel.getFieldGo(ctx, swapNode(node, pkg), ident)
+
+			case "keyed_element":
+				// T{Field: ...}
+				if node.Type() != "field_identifier" || cur.NamedChildCount() == 0 || nodeId(prev) != nodeId(cur.NamedChild(0)) {
+					continue
+				}
+				literalValue := cur.Parent()
+				if literalValue == nil {
+					continue
+				}
+				compositeLiteral := literalValue.Parent()
+				if compositeLiteral == nil || compositeLiteral.Type() != "composite_literal" {
+					continue
+				}
+				return squirrel.getFieldGo(ctx, swapNode(node, compositeLiteral), ident)
+
+			// Check nodes that might have bindings:
+			case "block":
+				blockChild := prev
+				for {
+					blockChild = blockChild.PrevNamedSibling()
+					if blockChild == nil {
+						continue outer
+					}
+					for _, name := range getDeclaredNamesGo(blockChild) {
+						if name.Content(node.Contents) == ident {
+							return swapNodePtr(node, name), nil
+						}
+					}
+				}
+
+			case "function_declaration":
+				fallthrough
+			case "method_declaration":
+				fallthrough
+			case "func_literal":
+				name := cur.ChildByFieldName("name")
+				if name != nil && nodeId(name) == nodeId(prev) {
+					return swapNodePtr(node, name), nil
+				}
+				for _, field := range []string{"receiver", "parameters", "result"} {
+					params := cur.ChildByFieldName(field)
+					if params == nil || params.Type() != "parameter_list" {
+						continue
+					}
+					for _, param := range children(params) {
+						for _, name := range getParameterNamesGo(param) {
+							if name.Content(node.Contents) == ident {
+								return swapNodePtr(node, name), nil
+							}
+						}
+					}
+				}
+				continue
+
+			case "short_var_declaration":
+				left := cur.ChildByFieldName("left")
+				if left != nil && nodeId(prev) == nodeId(left) {
+					return swapNodePtr(node, node.Node), nil
+				}
+				continue
+
+			case "type_spec":
+				fallthrough
+			case "type_alias":
+				fallthrough
+			case "var_spec":
+				fallthrough
+			case "const_spec":
+				fallthrough
+			case "field_declaration":
+				fallthrough
+			case "method_spec":
+				for _, name := range getNamesGo(cur) {
+					if nodeId(name) == nodeId(prev) {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			case "for_statement":
+				for _, clause := range children(cur) {
+					var names []*sitter.Node
+					switch clause.Type() {
+					case "range_clause":
+						names = getExpressionListIdentsGo(clause.ChildByFieldName("left"))
+					case "for_clause":
+						names = getDeclaredNamesGo(clause.ChildByFieldName("initializer"))
+					}
+					for _, name := range names {
+						if name.Content(node.Contents) == ident {
+							return swapNodePtr(node, name), nil
+						}
+					}
+				}
+				continue
+
+			case "if_statement":
+				fallthrough
+			case "expression_switch_statement":
+				fallthrough
+			case "type_switch_statement":
+				names := getDeclaredNamesGo(cur.ChildByFieldName("initializer"))
+				if cur.Type() == "type_switch_statement" {
+					names = append(names, getExpressionListIdentsGo(cur.ChildByFieldName("alias"))...)
+				}
+				for _, name := range names {
+					if name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			case "communication_case":
+				communication := cur.ChildByFieldName("communication")
+				if communication == nil || communication.Type() != "receive_statement" {
+					continue
+				}
+				for _, name := range getExpressionListIdentsGo(communication.ChildByFieldName("left")) {
+					if name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			// Skip all other nodes
+			default:
+				continue
+			}
+		}
+
+	case "interpreted_string_literal":
+		parent := node.Parent()
+		if parent == nil || parent.Type() != "import_spec" {
+			return nil, nil
+		}
+		return squirrel.getImportDirGo(ctx, swapNode(node, parent))
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInFileOrPackageGo looks for a package-scoped identifier in the given file, then in the
+// file's imports, then in the other files of the package.
+func (squirrel *SquirrelService) getDefInFileOrPackageGo(ctx context.Context, file Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(file, &Tuple{String(file.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	for _, decl := range children(file.Node) {
+		for _, name := range getDeclaredNamesGo(decl) {
+			if name.Content(file.Contents) == ident {
+				return swapNodePtr(file, name), nil
+			}
+		}
+	}
+
+	for _, importSpec := range getImportSpecsGo(file.Node) {
+		if getImportNameGo(swapNode(file, importSpec)) == ident {
+			return squirrel.getImportDirGo(ctx, swapNode(file, importSpec))
+		}
+	}
+
+	return squirrel.getDefInPackageGo(ctx, file, filepath.Dir(file.RepoCommitPath.Path), ident)
+}
+
+// getDefInPackageGo looks for a package-scoped identifier in the package in the given directory.
+func (squirrel *SquirrelService) getDefInPackageGo(ctx context.Context, node Node, dir string, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(node, &Tuple{String(dir), String(ident)}, lazyNodeStringer(&ret))()
+
+	found, err := squirrel.symbolSearchMany(
+		ctx,
+		node.RepoCommitPath.Repo,
+		node.RepoCommitPath.Commit,
+		[]string{packageFilesPatternGo(dir)},
+		ident,
+		10,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range found {
+		if isPackageScopedGo(candidate.Node) {
+			return &candidate, nil
+		}
+	}
+	return nil, nil
+}
+
+// getImportDirGo returns the directory of an imported package if it's in the same repository. The
+// module path is unknown, so suffixes of the import path are tried from longest to shortest.
+func (squirrel *SquirrelService) getImportDirGo(ctx context.Context, importSpec Node) (ret *Node, err error) {
+	defer squirrel.onCall(importSpec, String(importSpec.Type()), lazyNodeStringer(&ret))()
+
+	importPath := getImportPathGo(importSpec)
+	if importPath == "" {
+		return nil, nil
+	}
+
+	components := strings.Split(importPath, "/")
+	for i := range components {
+		dir := strings.Join(components[i:], "/")
+		found, err := squirrel.symbolSearchMany(
+			ctx,
+			importSpec.RepoCommitPath.Repo,
+			importSpec.RepoCommitPath.Commit,
+			[]string{packageFilesPatternGo(dir)},
+			".*",
+			1,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(found) == 0 {
+			continue
+		}
+		return &Node{
+			RepoCommitPath: types.RepoCommitPath{
+				Repo:   importSpec.RepoCommitPath.Repo,
+				Commit: importSpec.RepoCommitPath.Commit,
+				Path:   dir,
+			},
+			Node:     nil,
+			Contents: importSpec.Contents,
+			LangSpec: importSpec.LangSpec,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func (squirrel *SquirrelService) getFieldGo(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer squirrel.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	ty, err := squirrel.getTypeDefGo(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return squirrel.lookupFieldGo(ctx, ty, field)
+}
+
+func (squirrel *SquirrelService) lookupFieldGo(ctx context.Context, ty TypeGo, field string) (ret *Node, err error) {
+	defer squirrel.onCall(ty.node(), &Tuple{String(ty.variant()), String(field)}, lazyNodeStringer(&ret))()
+
+	switch ty2 := ty.(type) {
+	case PkgTypeGo:
+		return squirrel.getDefInPackageGo(ctx, ty2.noad, ty2.dir, field)
+	case NamedTypeGo:
+		underlying := ty2.def.ChildByFieldName("type")
+		if underlying == nil {
+			return nil, nil
+		}
+
+		// Fields and interface methods
+		embedded := []Node{}
+		switch underlying.Type() {
+		case "struct_type":
+			for _, fieldDeclarationList := range children(underlying) {
+				for _, fieldDeclaration := range children(fieldDeclarationList) {
+					if fieldDeclaration.Type() != "field_declaration" {
+						continue
+					}
+					names := getNamesGo(fieldDeclaration)
+					if len(names) == 0 {
+						// An embedded field is named after its type.
+						fieldTy := fieldDeclaration.ChildByFieldName("type")
+						if fieldTy == nil {
+							continue
+						}
+						if getTypeNameGo(swapNode(ty2.def, fieldTy)) == field {
+							return swapNodePtr(ty2.def, getTypeNameNodeGo(fieldTy)), nil
+						}
+						embedded = append(embedded, swapNode(ty2.def, fieldTy))
+						continue
+					}
+					for _, name := range names {
+						if name.Content(ty2.def.Contents) == field {
+							return swapNodePtr(ty2.def, name), nil
+						}
+					}
+				}
+			}
+		case "interface_type":
+			for _, methodSpecList := range children(underlying) {
+				for _, methodSpec := range children(methodSpecList) {
+					switch methodSpec.Type() {
+					case "method_spec":
+						name := methodSpec.ChildByFieldName("name")
+						if name != nil && name.Content(ty2.def.Contents) == field {
+							return swapNodePtr(ty2.def, name), nil
+						}
+					case "type_identifier":
+						fallthrough
+					case "qualified_type":
+						embedded = append(embedded, swapNode(ty2.def, methodSpec))
+					}
+				}
+			}
+		default:
+			// type A B has the fields of B, and A is looked up below for methods.
+			embedded = append(embedded, swapNode(ty2.def, underlying))
+		}
+
+		// Methods
+		found, err := squirrel.findMethodGo(ctx, ty2, field)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+
+		// Promoted fields and methods
+		for _, embed := range embedded {
+			found, err := squirrel.getFieldGo(ctx, embed, field)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+		return nil, nil
+	case FnTypeGo:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldGo: unexpected object type %s", ty.variant()))
+		return nil, nil
+	default:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldGo: unrecognized type variant %q", ty.variant()))
+		return nil, nil
+	}
+}
+
+// findMethodGo finds a method on a named type, which can be declared in any file of the package.
+func (squirrel *SquirrelService) findMethodGo(ctx context.Context, ty NamedTypeGo, method string) (ret *Node, err error) {
+	defer squirrel.onCall(ty.def, String(method), lazyNodeStringer(&ret))()
+
+	name := ty.def.ChildByFieldName("name")
+	if name == nil {
+		return nil, nil
+	}
+	typeName := name.Content(ty.def.Contents)
+
+	// Check the file of the type first (faster) before running symbol searches (slower)
+	for _, decl := range children(getRoot(ty.def.Node)) {
+		if decl.Type() != "method_declaration" || getReceiverTypeNameGo(swapNode(ty.def, decl)) != typeName {
+			continue
+		}
+		declName := decl.ChildByFieldName("name")
+		if declName != nil && declName.Content(ty.def.Contents) == method {
+			return swapNodePtr(ty.def, declName), nil
+		}
+	}
+
+	found, err := squirrel.symbolSearchMany(
+		ctx,
+		ty.def.RepoCommitPath.Repo,
+		ty.def.RepoCommitPath.Commit,
+		[]string{packageFilesPatternGo(filepath.Dir(ty.def.RepoCommitPath.Path))},
+		method,
+		10,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range found {
+		decl := candidate.Parent()
+		if decl == nil || decl.Type() != "method_declaration" {
+			continue
+		}
+		if getReceiverTypeNameGo(swapNode(candidate, decl)) == typeName {
+			return &candidate, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (squirrel *SquirrelService) getTypeDefGo(ctx context.Context, node Node) (ret TypeGo, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyTypeGoStringer(&ret))()
+
+	onIdent := func() (TypeGo, error) {
+		found, err := squirrel.getDefGo(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		if found.Node == nil {
+			return PkgTypeGo{dir: found.RepoCommitPath.Path, noad: node}, nil
+		}
+		if isRecursiveDefinitionGo(node, *found) {
+			return nil, nil
+		}
+		return squirrel.defToTypeGo(ctx, *found)
+	}
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		fallthrough
+	case "package_identifier":
+		fallthrough
+	case "field_identifier":
+		return onIdent()
+	case "pointer_type":
+		fallthrough
+	case "parenthesized_type":
+		fallthrough
+	case "parenthesized_expression":
+		for _, child := range children(node.Node) {
+			return squirrel.getTypeDefGo(ctx, swapNode(node, child))
+		}
+		return nil, nil
+	case "unary_expression":
+		operand := node.ChildByFieldName("operand")
+		if operand == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(node, operand))
+	case "composite_literal":
+		ty := node.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(node, ty))
+	case "qualified_type":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(node, name))
+	case "selector_expression":
+		operand := node.ChildByFieldName("operand")
+		if operand == nil {
+			return nil, nil
+		}
+		field := node.ChildByFieldName("field")
+		if field == nil {
+			return nil, nil
+		}
+		found, err := squirrel.getFieldGo(ctx, swapNode(node, operand), field.Content(node.Contents))
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return squirrel.defToTypeGo(ctx, *found)
+	case "call_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		ty, err := squirrel.getTypeDefGo(ctx, swapNode(node, fn))
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			return nil, nil
+		}
+		switch ty2 := ty.(type) {
+		case FnTypeGo:
+			return ty2.ret, nil
+		case NamedTypeGo:
+			// A conversion T(x)
+			return ty2, nil
+		default:
+			squirrel.breadcrumb(ty.node(), fmt.Sprintf("getTypeDefGo: expected function, got %q", ty.variant()))
+			return nil, nil
+		}
+	default:
+		squirrel.breadcrumb(node, fmt.Sprintf("getTypeDefGo: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+func (squirrel *SquirrelService) defToTypeGo(ctx context.Context, def Node) (TypeGo, error) {
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "type_spec":
+		return (TypeGo)(NamedTypeGo{def: swapNode(def, parent)}), nil
+	case "type_alias":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(def, ty))
+	case "function_declaration":
+		fallthrough
+	case "method_declaration":
+		fallthrough
+	case "method_spec":
+		result := parent.ChildByFieldName("result")
+		if result == nil {
+			return (TypeGo)(FnTypeGo{ret: nil, noad: swapNode(def, parent)}), nil
+		}
+		// Only the first result is tracked, which covers the common (T, error) case.
+		if result.Type() == "parameter_list" {
+			if result.NamedChildCount() == 0 {
+				return nil, nil
+			}
+			result = result.NamedChild(0).ChildByFieldName("type")
+			if result == nil {
+				return nil, nil
+			}
+		}
+		retTy, err := squirrel.getTypeDefGo(ctx, swapNode(def, result))
+		if err != nil {
+			return nil, err
+		}
+		return (TypeGo)(FnTypeGo{ret: retTy, noad: swapNode(def, parent)}), nil
+	case "parameter_declaration":
+		fallthrough
+	case "variadic_parameter_declaration":
+		fallthrough
+	case "field_declaration":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		if parent.Type() == "field_declaration" && nodeId(ty) == nodeId(def.Node) {
+			// An embedded field is its own type.
+			return squirrel.getTypeDefGo(ctx, def)
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(def, ty))
+	case "var_spec":
+		ty := parent.ChildByFieldName("type")
+		if ty != nil {
+			return squirrel.getTypeDefGo(ctx, swapNode(def, ty))
+		}
+		index := 0
+		for _, name := range getNamesGo(parent) {
+			if nodeId(name) == nodeId(def.Node) {
+				break
+			}
+			index++
+		}
+		value := parent.ChildByFieldName("value")
+		if value == nil || index >= int(value.NamedChildCount()) {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(def, value.NamedChild(index)))
+	case "expression_list":
+		grandparent := parent.Parent()
+		if grandparent == nil || grandparent.Type() != "short_var_declaration" {
+			squirrel.breadcrumb(swapNode(def, grandparent), "defToTypeGo: unsupported declaration")
+			return nil, nil
+		}
+		index := 0
+		for _, name := range children(parent) {
+			if nodeId(name) == nodeId(def.Node) {
+				break
+			}
+			index++
+		}
+		right := grandparent.ChildByFieldName("right")
+		if right == nil {
+			return nil, nil
+		}
+		if int(right.NamedChildCount()) != int(parent.NamedChildCount()) {
+			// x, err := f()
+			if index != 0 || right.NamedChildCount() != 1 {
+				return nil, nil
+			}
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(def, right.NamedChild(index)))
+	default:
+		squirrel.breadcrumb(swapNode(def, parent), fmt.Sprintf("unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+// getDeclaredNamesGo returns the names declared by a statement or a top-level declaration.
+func getDeclaredNamesGo(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	names := []*sitter.Node{}
+	switch node.Type() {
+	case "short_var_declaration":
+		names = append(names, getExpressionListIdentsGo(node.ChildByFieldName("left"))...)
+	case "var_declaration":
+		fallthrough
+	case "const_declaration":
+		fallthrough
+	case "type_declaration":
+		for _, spec := range children(node) {
+			names = append(names, getNamesGo(spec)...)
+		}
+	case "function_declaration":
+		name := node.ChildByFieldName("name")
+		if name != nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// getNamesGo returns the name fields of a node, e.g. both x and y in var x, y int.
+func getNamesGo(node *sitter.Node) []*sitter.Node {
+	names := []*sitter.Node{}
+	cursor := sitter.NewTreeCursor(node)
+	defer cursor.Close()
+	if !cursor.GoToFirstChild() {
+		return names
+	}
+	for {
+		if cursor.CurrentFieldName() == "name" {
+			names = append(names, cursor.CurrentNode())
+		}
+		if !cursor.GoToNextSibling() {
+			return names
+		}
+	}
+}
+
+func getParameterNamesGo(param *sitter.Node) []*sitter.Node {
+	switch param.Type() {
+	case "parameter_declaration":
+		fallthrough
+	case "variadic_parameter_declaration":
+		return getNamesGo(param)
+	default:
+		return nil
+	}
+}
+
+func getExpressionListIdentsGo(expressionList *sitter.Node) []*sitter.Node {
+	idents := []*sitter.Node{}
+	for _, child := range children(expressionList) {
+		if child.Type() == "identifier" {
+			idents = append(idents, child)
+		}
+	}
+	return idents
+}
+
+// isPackageScopedGo returns true if the name is declared at the top level of a file (excluding
+// methods, which are only accessible through their receiver).
+func isPackageScopedGo(name *sitter.Node) bool {
+	parent := name.Parent()
+	if parent == nil {
+		return false
+	}
+	switch parent.Type() {
+	case "function_declaration":
+		return true
+	case "type_spec":
+		fallthrough
+	case "type_alias":
+		fallthrough
+	case "var_spec":
+		fallthrough
+	case "const_spec":
+		decl := parent.Parent()
+		return decl != nil && decl.Parent() != nil && decl.Parent().Type() == "source_file"
+	default:
+		return false
+	}
+}
+
+func getImportSpecsGo(file *sitter.Node) []*sitter.Node {
+	specs := []*sitter.Node{}
+	for _, decl := range children(file) {
+		if decl.Type() != "import_declaration" {
+			continue
+		}
+		for _, child := range children(decl) {
+			switch child.Type() {
+			case "import_spec":
+				specs = append(specs, child)
+			case "import_spec_list":
+				for _, spec := range children(child) {
+					if spec.Type() == "import_spec" {
+						specs = append(specs, spec)
+					}
+				}
+			}
+		}
+	}
+	return specs
+}
+
+func getImportPathGo(importSpec Node) string {
+	path := importSpec.ChildByFieldName("path")
+	if path == nil {
+		return ""
+	}
+	unquoted, err := strconv.Unquote(path.Content(importSpec.Contents))
+	if err != nil {
+		return ""
+	}
+	return unquoted
+}
+
+// getImportNameGo returns the name an import is referred to by in the file. Packages are assumed
+// to be named after the last component of their import path, ignoring major version suffixes.
+func getImportNameGo(importSpec Node) string {
+	name := importSpec.ChildByFieldName("name")
+	if name != nil {
+		return name.Content(importSpec.Contents)
+	}
+	components := strings.Split(getImportPathGo(importSpec), "/")
+	last := components[len(components)-1]
+	if len(components) > 1 && majorVersionRegexGo.MatchString(last) {
+		last = components[len(components)-2]
+	}
+	return last
+}
+
+var majorVersionRegexGo = regexp.MustCompile(`^v[0-9]+$`)
+
+// getReceiverTypeNameGo returns the name of the receiver type of a method declaration.
+func getReceiverTypeNameGo(method Node) string {
+	receiver := method.ChildByFieldName("receiver")
+	if receiver == nil || receiver.NamedChildCount() == 0 {
+		return ""
+	}
+	ty := receiver.NamedChild(0).ChildByFieldName("type")
+	if ty == nil {
+		return ""
+	}
+	return getTypeNameGo(swapNode(method, ty))
+}
+
+// getTypeNameGo returns the name of a (possibly pointer or qualified) type.
+func getTypeNameGo(ty Node) string {
+	name := getTypeNameNodeGo(ty.Node)
+	if name == nil {
+		return ""
+	}
+	return name.Content(ty.Contents)
+}
+
+func getTypeNameNodeGo(ty *sitter.Node) *sitter.Node {
+	switch ty.Type() {
+	case "type_identifier":
+		return ty
+	case "pointer_type":
+		if ty.NamedChildCount() == 0 {
+			return nil
+		}
+		return getTypeNameNodeGo(ty.NamedChild(0))
+	case "qualified_type":
+		name := ty.ChildByFieldName("name")
+		if name == nil {
+			return nil
+		}
+		return getTypeNameNodeGo(name)
+	default:
+		return nil
+	}
+}
+
+// packageFilesPatternGo returns an include pattern that matches the files of the package in the
+// given directory, but not the files in subdirectories.
+func packageFilesPatternGo(dir string) string {
+	if dir == "." || dir == "" {
+		return `^[^/]+\.go$`
+	}
+	return fmt.Sprintf(`^%s/[^/]+\.go$`, regexp.QuoteMeta(dir))
+}
+
+type TypeGo interface {
+	variant() string
+	node() Node
+}
+
+type FnTypeGo struct {
+	ret  TypeGo
+	noad Node
+}
+
+func (t FnTypeGo) variant() string {
+	return "fn"
+}
+
+func (t FnTypeGo) node() Node {
+	return t.noad
+}
+
+// NamedTypeGo is a type declared with a type_spec.
+type NamedTypeGo struct {
+	def Node
+}
+
+func (t NamedTypeGo) variant() string {
+	return "named"
+}
+
+func (t NamedTypeGo) node() Node {
+	return t.def
+}
+
+// PkgTypeGo is an imported package, which is a directory. The node is the package name it was
+// referred to by.
+type PkgTypeGo struct {
+	dir  string
+	noad Node
+}
+
+func (t PkgTypeGo) variant() string {
+	return "pkg"
+}
+
+func (t PkgTypeGo) node() Node {
+	return t.noad
+}
+
+func lazyTypeGoStringer(ty *TypeGo) func() fmt.Stringer {
+	return func() fmt.Stringer {
+		if ty != nil && *ty != nil {
+			return String((*ty).variant())
+		} else {
+			return String("<nil>")
+		}
+	}
+}
+
+// isRecursiveDefinitionGo detects cases like `x := x.foo` in which the def is on the left side of
+// the statement the node is on the right side of.
+func isRecursiveDefinitionGo(node Node, def Node) bool {
+	if node.RepoCommitPath != def.RepoCommitPath {
+		return false
+	}
+	if def.Parent() == nil || def.Parent().Type() != "expression_list" {
+		return false
+	}
+	declaration := def.Parent().Parent()
+	if declaration == nil || declaration.Type() != "short_var_declaration" {
+		return false
+	}
+	for nodeAncestor := node.Parent(); nodeAncestor != nil; nodeAncestor = nodeAncestor.Parent() {
+		if nodeId(nodeAncestor) == nodeId(declaration) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,976 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (squirrel *SquirrelService) getDefRust(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		fallthrough
+	case "field_identifier":
+		ident := node.Content(node.Contents)
+
+		if ident == "Self" {
+			return squirrel.getSelfTypeRust(ctx, node)
+		}
+
+		cur := node.Node
+
+		for {
+			prev := cur
+			cur = cur.Parent()
+			if cur == nil {
+				squirrel.breadcrumb(node, "getDefRust: ran out of parents")
+				return nil, nil
+			}
+
+			switch cur.Type() {
+
+			case "source_file":
+				return squirrel.findItemRust(ctx, swapNode(node, cur), ident, true)
+
+			case "declaration_list":
+				// Inline modules don't see the items of their parent without super::
+				mod := cur.Parent()
+				if mod == nil || mod.Type() != "mod_item" {
+					continue
+				}
+				return squirrel.findItemRust(ctx, swapNode(node, cur), ident, true)
+
+			// Check for paths
+			case "use_declaration":
+				// The first component of a path in a use declaration is a module or an item in the
+				// current module (Rust 2018) or in the crate root (Rust 2015).
+				file := swapNode(node, getRoot(cur))
+				found, err := squirrel.findItemRust(ctx, file, ident, false)
+				if err != nil {
+					return nil, err
+				}
+				if found != nil {
+					return found, nil
+				}
+				crateRoot := squirrel.getModuleFileRust(ctx, node, []string{})
+				if crateRoot == nil || crateRoot.RepoCommitPath == node.RepoCommitPath {
+					return nil, nil
+				}
+				return squirrel.findItemRust(ctx, *crateRoot, ident, false)
+
+			case "use_list":
+				// use a::b::{c, d::e}
+				scopedUseList := cur.Parent()
+				if scopedUseList == nil || scopedUseList.Type() != "scoped_use_list" {
+					continue
+				}
+				prefix := scopedUseList.ChildByFieldName("path")
+				if prefix == nil {
+					continue
+				}
+				container, err := squirrel.resolvePathRust(ctx, swapNode(node, prefix))
+				if err != nil {
+					return nil, err
+				}
+				if container == nil {
+					return nil, nil
+				}
+				return squirrel.lookupItemRust(ctx, *container, ident)
+
+			case "use_as_clause":
+				// use a::b as c
+				path := cur.ChildByFieldName("path")
+				if path == nil || nodeId(path) == nodeId(prev) {
+					continue
+				}
+				return squirrel.resolvePathRust(ctx, swapNode(node, path))
+
+			case "scoped_identifier":
+				fallthrough
+			case "scoped_type_identifier":
+				path := cur.ChildByFieldName("path")
+				if path == nil || nodeId(path) == nodeId(prev) {
+					continue
+				}
+				container, err := squirrel.resolvePathRust(ctx, swapNode(node, path))
+				if err != nil {
+					return nil, err
+				}
+				if container == nil {
+					return nil, nil
+				}
+				return squirrel.lookupItemRust(ctx, *container, ident)
+
+			// Check for field access
+			case "field_expression":
+				value := cur.ChildByFieldName("value")
+				if value == nil || nodeId(value) == nodeId(prev) {
+					continue
+				}
+				return squirrel.getFieldRust(ctx, swapNode(node, value), ident)
+
+			case "field_initializer":
+				// Point { x: ... }
+				name := cur.ChildByFieldName("name")
+				if name == nil || nodeId(name) != nodeId(prev) {
+					continue
+				}
+				structExpression := getAncestorOfType(cur, "struct_expression")
+				if structExpression == nil {
+					return nil, nil
+				}
+				return squirrel.getFieldRust(ctx, swapNode(node, structExpression), ident)
+
+			// Check nodes that might have bindings:
+			case "block":
+				// Later let declarations shadow earlier ones.
+				for blockChild := prev.PrevNamedSibling(); blockChild != nil; blockChild = blockChild.PrevNamedSibling() {
+					if blockChild.Type() != "let_declaration" {
+						continue
+					}
+					for _, name := range getPatternNamesRust(blockChild.ChildByFieldName("pattern")) {
+						if name.Content(node.Contents) == ident {
+							return swapNodePtr(node, name), nil
+						}
+					}
+				}
+				for _, blockChild := range children(cur) {
+					name := getItemNameRust(blockChild)
+					if name != nil && name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			case "let_declaration":
+				pattern := cur.ChildByFieldName("pattern")
+				if pattern == nil || nodeId(pattern) != nodeId(prev) {
+					continue
+				}
+				for _, name := range getPatternNamesRust(pattern) {
+					if nodeId(name) == nodeId(node.Node) {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			case "function_item":
+				fallthrough
+			case "function_signature_item":
+				fallthrough
+			case "closure_expression":
+				name := cur.ChildByFieldName("name")
+				if name != nil && nodeId(name) == nodeId(prev) {
+					return swapNodePtr(node, name), nil
+				}
+				for _, param := range children(cur.ChildByFieldName("parameters")) {
+					var names []*sitter.Node
+					switch param.Type() {
+					case "parameter":
+						names = getPatternNamesRust(param.ChildByFieldName("pattern"))
+					default:
+						// |x, y| ...
+						names = getPatternNamesRust(param)
+					}
+					for _, name := range names {
+						if name.Content(node.Contents) == ident {
+							return swapNodePtr(node, name), nil
+						}
+					}
+				}
+				continue
+
+			case "for_expression":
+				fallthrough
+			case "if_let_expression":
+				fallthrough
+			case "while_let_expression":
+				fallthrough
+			case "match_arm":
+				value := cur.ChildByFieldName("value")
+				if value != nil && nodeId(value) == nodeId(prev) && cur.Type() != "match_arm" {
+					continue
+				}
+				for _, name := range getPatternNamesRust(cur.ChildByFieldName("pattern")) {
+					if name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			case "struct_item":
+				fallthrough
+			case "enum_item":
+				fallthrough
+			case "union_item":
+				fallthrough
+			case "trait_item":
+				fallthrough
+			case "type_item":
+				fallthrough
+			case "mod_item":
+				fallthrough
+			case "const_item":
+				fallthrough
+			case "static_item":
+				fallthrough
+			case "macro_definition":
+				fallthrough
+			case "enum_variant":
+				fallthrough
+			case "field_declaration":
+				name := cur.ChildByFieldName("name")
+				if name != nil && nodeId(name) == nodeId(prev) {
+					return swapNodePtr(node, name), nil
+				}
+				continue
+
+			// Skip all other nodes
+			default:
+				continue
+			}
+		}
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// findItemRust looks for an item in a module, which is either a file or the body of an inline
+// module. Use declarations are only followed when withUses is true.
+func (squirrel *SquirrelService) findItemRust(ctx context.Context, module Node, ident string, withUses bool) (ret *Node, err error) {
+	defer squirrel.onCall(module, &Tuple{String(module.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	for _, child := range children(module.Node) {
+		name := getItemNameRust(child)
+		if name != nil && name.Content(module.Contents) == ident {
+			return swapNodePtr(module, name), nil
+		}
+	}
+
+	if !withUses {
+		return nil, nil
+	}
+
+	wildcards := []*sitter.Node{}
+	for _, child := range children(module.Node) {
+		if child.Type() != "use_declaration" {
+			continue
+		}
+		argument := child.ChildByFieldName("argument")
+		if argument == nil {
+			continue
+		}
+		for _, binding := range getUseBindingsRust(argument, module.Contents) {
+			if binding.wildcard {
+				wildcards = append(wildcards, binding.target)
+				continue
+			}
+			if binding.name != ident {
+				continue
+			}
+			return squirrel.resolvePathRust(ctx, swapNode(module, binding.target))
+		}
+	}
+
+	// Glob imports have a lower priority than items and explicit imports.
+	for _, wildcard := range wildcards {
+		container, err := squirrel.resolvePathRust(ctx, swapNode(module, wildcard))
+		if err != nil {
+			return nil, err
+		}
+		if container == nil {
+			continue
+		}
+		found, err := squirrel.lookupItemRust(ctx, *container, ident)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// resolvePathRust finds the definition of a path such as crate::a::B, which is a module file, the
+// name of a mod item, or the name of some other item.
+func (squirrel *SquirrelService) resolvePathRust(ctx context.Context, path Node) (ret *Node, err error) {
+	defer squirrel.onCall(path, String(path.Type()), lazyNodeStringer(&ret))()
+
+	switch path.Type() {
+	case "crate":
+		return squirrel.getModuleFileRust(ctx, path, []string{}), nil
+	case "self":
+		// use a::b::{self}
+		if parent := path.Parent(); parent != nil && parent.Type() == "use_list" {
+			scopedUseList := parent.Parent()
+			if scopedUseList == nil || scopedUseList.ChildByFieldName("path") == nil {
+				return nil, nil
+			}
+			return squirrel.resolvePathRust(ctx, swapNode(path, scopedUseList.ChildByFieldName("path")))
+		}
+		return swapNodePtr(path, getRoot(path.Node)), nil
+	case "super":
+		module := getModulePathRust(path.RepoCommitPath.Path)
+		if len(module) == 0 {
+			return nil, nil
+		}
+		return squirrel.getModuleFileRust(ctx, path, module[:len(module)-1]), nil
+	case "scoped_identifier":
+		fallthrough
+	case "scoped_type_identifier":
+		name := path.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return squirrel.getDefRust(ctx, swapNode(path, name))
+	default:
+		return squirrel.getDefRust(ctx, path)
+	}
+}
+
+// lookupItemRust finds an item inside of a container returned by resolvePathRust.
+func (squirrel *SquirrelService) lookupItemRust(ctx context.Context, container Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(container, &Tuple{String(container.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	if container.Type() == "source_file" {
+		return squirrel.findItemRust(ctx, container, ident, true)
+	}
+
+	parent := container.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "mod_item":
+		body := parent.ChildByFieldName("body")
+		if body != nil {
+			return squirrel.findItemRust(ctx, swapNode(container, body), ident, true)
+		}
+		// mod foo; is in foo.rs or foo/mod.rs
+		module := append(getModulePathRust(container.RepoCommitPath.Path), container.Content(container.Contents))
+		file := squirrel.getModuleFileRust(ctx, container, module)
+		if file == nil {
+			return nil, nil
+		}
+		return squirrel.findItemRust(ctx, *file, ident, true)
+	case "enum_item":
+		body := parent.ChildByFieldName("body")
+		for _, variant := range children(body) {
+			name := variant.ChildByFieldName("name")
+			if variant.Type() == "enum_variant" && name != nil && name.Content(container.Contents) == ident {
+				return swapNodePtr(container, name), nil
+			}
+		}
+		return squirrel.findImplMemberRust(ctx, swapNode(container, parent), ident)
+	case "struct_item":
+		fallthrough
+	case "union_item":
+		fallthrough
+	case "trait_item":
+		return squirrel.findImplMemberRust(ctx, swapNode(container, parent), ident)
+	default:
+		squirrel.breadcrumb(swapNode(container, parent), fmt.Sprintf("lookupItemRust: unexpected container %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+func (squirrel *SquirrelService) getFieldRust(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer squirrel.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	ty, err := squirrel.getTypeDefRust(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return squirrel.lookupFieldRust(ctx, ty, field)
+}
+
+func (squirrel *SquirrelService) lookupFieldRust(ctx context.Context, ty TypeRust, field string) (ret *Node, err error) {
+	defer squirrel.onCall(ty.node(), &Tuple{String(ty.variant()), String(field)}, lazyNodeStringer(&ret))()
+
+	switch ty2 := ty.(type) {
+	case StructTypeRust:
+		body := ty2.def.ChildByFieldName("body")
+		if body != nil && body.Type() == "field_declaration_list" {
+			for _, fieldDeclaration := range children(body) {
+				name := fieldDeclaration.ChildByFieldName("name")
+				if fieldDeclaration.Type() == "field_declaration" && name != nil && name.Content(ty2.def.Contents) == field {
+					return swapNodePtr(ty2.def, name), nil
+				}
+			}
+		}
+		return squirrel.findImplMemberRust(ctx, ty2.def, field)
+	case FnTypeRust:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldRust: unexpected object type %s", ty.variant()))
+		return nil, nil
+	default:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldRust: unrecognized type variant %q", ty.variant()))
+		return nil, nil
+	}
+}
+
+// findImplMemberRust finds a method or an associated item of a type in its impl blocks, which can
+// be anywhere in the crate, or in the default methods of the traits it implements.
+func (squirrel *SquirrelService) findImplMemberRust(ctx context.Context, item Node, member string) (ret *Node, err error) {
+	defer squirrel.onCall(item, &Tuple{String(item.Type()), String(member)}, lazyNodeStringer(&ret))()
+
+	itemName := item.ChildByFieldName("name")
+	if itemName == nil {
+		return nil, nil
+	}
+	typeName := itemName.Content(item.Contents)
+
+	findInBody := func(body Node) *Node {
+		for _, child := range children(body.Node) {
+			name := getItemNameRust(child)
+			if name != nil && name.Content(body.Contents) == member {
+				return swapNodePtr(body, name)
+			}
+		}
+		return nil
+	}
+
+	// Traits declare methods in their body.
+	if item.Type() == "trait_item" {
+		body := item.ChildByFieldName("body")
+		if body == nil {
+			return nil, nil
+		}
+		return findInBody(swapNode(item, body)), nil
+	}
+
+	// Check the impl blocks in the file of the type first (faster) before running symbol searches
+	// (slower).
+	traits := []Node{}
+	impls, err := allCaptures(`(impl_item) @impl`, swapNode(item, getRoot(item.Node)))
+	if err != nil {
+		return nil, err
+	}
+	for _, impl := range impls {
+		if getImplTypeNameRust(impl) != typeName {
+			continue
+		}
+		if body := impl.ChildByFieldName("body"); body != nil {
+			if found := findInBody(swapNode(impl, body)); found != nil {
+				return found, nil
+			}
+		}
+		if trait := impl.ChildByFieldName("trait"); trait != nil {
+			traits = append(traits, swapNode(impl, trait))
+		}
+	}
+
+	candidates, err := squirrel.symbolSearchMany(
+		ctx,
+		item.RepoCommitPath.Repo,
+		item.RepoCommitPath.Commit,
+		[]string{`\.rs$`},
+		member,
+		10,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range candidates {
+		impl := getAncestorOfType(candidate.Node, "impl_item")
+		if impl == nil || getImplTypeNameRust(swapNode(candidate, impl)) != typeName {
+			continue
+		}
+		if candidate.Parent() != nil && getItemNameRust(candidate.Parent()) != nil {
+			return &candidate, nil
+		}
+	}
+
+	// Default methods of implemented traits
+	for _, trait := range traits {
+		name := getTypeNameNodeRust(trait.Node)
+		if name == nil {
+			continue
+		}
+		traitDef, err := squirrel.getDefRust(ctx, swapNode(trait, name))
+		if err != nil {
+			return nil, err
+		}
+		if traitDef == nil || traitDef.Parent() == nil || traitDef.Parent().Type() != "trait_item" {
+			continue
+		}
+		found, err := squirrel.findImplMemberRust(ctx, swapNode(*traitDef, traitDef.Parent()), member)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getSelfTypeRust finds the definition of the type that Self refers to.
+func (squirrel *SquirrelService) getSelfTypeRust(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	for cur := node.Node; cur != nil; cur = cur.Parent() {
+		switch cur.Type() {
+		case "impl_item":
+			ty := cur.ChildByFieldName("type")
+			if ty == nil {
+				return nil, nil
+			}
+			name := getTypeNameNodeRust(ty)
+			if name == nil {
+				return nil, nil
+			}
+			return squirrel.getDefRust(ctx, swapNode(node, name))
+		case "trait_item":
+			name := cur.ChildByFieldName("name")
+			if name == nil {
+				return nil, nil
+			}
+			return swapNodePtr(node, name), nil
+		}
+	}
+	return nil, nil
+}
+
+func (squirrel *SquirrelService) getTypeDefRust(ctx context.Context, node Node) (ret TypeRust, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyTypeRustStringer(&ret))()
+
+	onIdent := func() (TypeRust, error) {
+		found, err := squirrel.getDefRust(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return squirrel.defToTypeRust(ctx, *found)
+	}
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		fallthrough
+	case "field_identifier":
+		return onIdent()
+	case "self":
+		found, err := squirrel.getSelfTypeRust(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return squirrel.defToTypeRust(ctx, *found)
+	case "scoped_identifier":
+		fallthrough
+	case "scoped_type_identifier":
+		fallthrough
+	case "field_expression":
+		// The last component has the definition.
+		var name *sitter.Node
+		if node.Type() == "field_expression" {
+			name = node.ChildByFieldName("field")
+		} else {
+			name = node.ChildByFieldName("name")
+		}
+		if name == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefRust(ctx, swapNode(node, name))
+	case "reference_type":
+		fallthrough
+	case "pointer_type":
+		fallthrough
+	case "generic_type":
+		ty := node.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefRust(ctx, swapNode(node, ty))
+	case "reference_expression":
+		value := node.ChildByFieldName("value")
+		if value == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefRust(ctx, swapNode(node, value))
+	case "struct_expression":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefRust(ctx, swapNode(node, name))
+	case "parenthesized_expression":
+		for _, child := range children(node.Node) {
+			return squirrel.getTypeDefRust(ctx, swapNode(node, child))
+		}
+		return nil, nil
+	case "call_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		ty, err := squirrel.getTypeDefRust(ctx, swapNode(node, fn))
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			return nil, nil
+		}
+		switch ty2 := ty.(type) {
+		case FnTypeRust:
+			return ty2.ret, nil
+		case StructTypeRust:
+			// A tuple struct constructor Point(1, 2)
+			return ty2, nil
+		default:
+			squirrel.breadcrumb(ty.node(), fmt.Sprintf("getTypeDefRust: expected function, got %q", ty.variant()))
+			return nil, nil
+		}
+	default:
+		squirrel.breadcrumb(node, fmt.Sprintf("getTypeDefRust: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+func (squirrel *SquirrelService) defToTypeRust(ctx context.Context, def Node) (TypeRust, error) {
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "struct_item":
+		fallthrough
+	case "enum_item":
+		fallthrough
+	case "union_item":
+		fallthrough
+	case "trait_item":
+		return (TypeRust)(StructTypeRust{def: swapNode(def, parent)}), nil
+	case "function_item":
+		fallthrough
+	case "function_signature_item":
+		retTyNode := parent.ChildByFieldName("return_type")
+		if retTyNode == nil {
+			return (TypeRust)(FnTypeRust{ret: nil, noad: swapNode(def, parent)}), nil
+		}
+		retTy, err := squirrel.getTypeDefRust(ctx, swapNode(def, retTyNode))
+		if err != nil {
+			return nil, err
+		}
+		return (TypeRust)(FnTypeRust{ret: retTy, noad: swapNode(def, parent)}), nil
+	case "let_declaration":
+		ty := parent.ChildByFieldName("type")
+		if ty != nil {
+			return squirrel.getTypeDefRust(ctx, swapNode(def, ty))
+		}
+		value := parent.ChildByFieldName("value")
+		if value == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefRust(ctx, swapNode(def, value))
+	case "type_item":
+		fallthrough
+	case "parameter":
+		fallthrough
+	case "field_declaration":
+		fallthrough
+	case "const_item":
+		fallthrough
+	case "static_item":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefRust(ctx, swapNode(def, ty))
+	default:
+		squirrel.breadcrumb(swapNode(def, parent), fmt.Sprintf("unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+// getModuleFileRust parses the file of a module given its path relative to the crate root.
+func (squirrel *SquirrelService) getModuleFileRust(ctx context.Context, from Node, module []string) *Node {
+	root := getCrateRootRust(from.RepoCommitPath.Path)
+
+	candidates := []string{}
+	if len(module) == 0 {
+		candidates = append(candidates, filepath.Join(root, "lib.rs"), filepath.Join(root, "main.rs"))
+	} else {
+		path := filepath.Join(append([]string{root}, module...)...)
+		candidates = append(candidates, path+".rs", filepath.Join(path, "mod.rs"))
+	}
+
+	for _, candidate := range candidates {
+		file, err := squirrel.parse(ctx, types.RepoCommitPath{
+			Repo:   from.RepoCommitPath.Repo,
+			Commit: from.RepoCommitPath.Commit,
+			Path:   candidate,
+		})
+		if err != nil {
+			continue
+		}
+		return file
+	}
+
+	return nil
+}
+
+// getCrateRootRust returns the directory of the crate root, which is assumed to be the closest
+// src directory.
+func getCrateRootRust(path string) string {
+	components := strings.Split(filepath.Dir(path), "/")
+	for i := len(components) - 1; i >= 0; i-- {
+		if components[i] == "src" {
+			return filepath.Join(components[:i+1]...)
+		}
+	}
+	return filepath.Dir(path)
+}
+
+// getModulePathRust returns the module path of a file relative to the crate root, e.g. [a, b] for
+// src/a/b.rs and src/a/b/mod.rs.
+func getModulePathRust(path string) []string {
+	root := getCrateRootRust(path)
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return []string{}
+	}
+	components := strings.Split(strings.TrimSuffix(rel, ".rs"), "/")
+	last := components[len(components)-1]
+	if last == "mod" || (len(components) == 1 && (last == "lib" || last == "main")) {
+		components = components[:len(components)-1]
+	}
+	return components
+}
+
+// useBindingRust is a name brought into scope by a use declaration, and the path it refers to.
+type useBindingRust struct {
+	name     string
+	target   *sitter.Node
+	wildcard bool
+}
+
+func getUseBindingsRust(argument *sitter.Node, contents []byte) []useBindingRust {
+	switch argument.Type() {
+	case "identifier":
+		// use a;
+		return []useBindingRust{{name: argument.Content(contents), target: argument}}
+	case "scoped_identifier":
+		// use a::b;
+		name := argument.ChildByFieldName("name")
+		if name == nil {
+			return nil
+		}
+		return []useBindingRust{{name: name.Content(contents), target: argument}}
+	case "use_as_clause":
+		// use a::b as c;
+		path := argument.ChildByFieldName("path")
+		alias := argument.ChildByFieldName("alias")
+		if path == nil || alias == nil {
+			return nil
+		}
+		return []useBindingRust{{name: alias.Content(contents), target: path}}
+	case "use_wildcard":
+		// use a::*;
+		if argument.NamedChildCount() == 0 {
+			return nil
+		}
+		return []useBindingRust{{target: argument.NamedChild(0), wildcard: true}}
+	case "scoped_use_list":
+		// use a::{b, c};
+		list := argument.ChildByFieldName("list")
+		if list == nil {
+			return nil
+		}
+		bindings := []useBindingRust{}
+		for _, child := range children(list) {
+			if child.Type() == "self" {
+				// use a::{self};
+				path := argument.ChildByFieldName("path")
+				if path == nil {
+					continue
+				}
+				name := path
+				if path.Type() == "scoped_identifier" {
+					name = path.ChildByFieldName("name")
+				}
+				if name == nil {
+					continue
+				}
+				bindings = append(bindings, useBindingRust{name: name.Content(contents), target: child})
+				continue
+			}
+			bindings = append(bindings, getUseBindingsRust(child, contents)...)
+		}
+		return bindings
+	case "use_list":
+		bindings := []useBindingRust{}
+		for _, child := range children(argument) {
+			bindings = append(bindings, getUseBindingsRust(child, contents)...)
+		}
+		return bindings
+	default:
+		return nil
+	}
+}
+
+// getItemNameRust returns the name of an item declaration, or nil if the node is not an item.
+func getItemNameRust(node *sitter.Node) *sitter.Node {
+	switch node.Type() {
+	case "function_item":
+		fallthrough
+	case "function_signature_item":
+		fallthrough
+	case "struct_item":
+		fallthrough
+	case "enum_item":
+		fallthrough
+	case "union_item":
+		fallthrough
+	case "trait_item":
+		fallthrough
+	case "type_item":
+		fallthrough
+	case "const_item":
+		fallthrough
+	case "static_item":
+		fallthrough
+	case "mod_item":
+		fallthrough
+	case "macro_definition":
+		return node.ChildByFieldName("name")
+	default:
+		return nil
+	}
+}
+
+// getPatternNamesRust returns the identifiers bound by a pattern, skipping the paths of struct and
+// enum variant patterns.
+func getPatternNamesRust(pattern *sitter.Node) []*sitter.Node {
+	if pattern == nil {
+		return nil
+	}
+
+	switch pattern.Type() {
+	case "identifier":
+		return []*sitter.Node{pattern}
+	case "shorthand_field_identifier":
+		return []*sitter.Node{pattern}
+	case "scoped_identifier":
+		return nil
+	}
+
+	names := []*sitter.Node{}
+	cursor := sitter.NewTreeCursor(pattern)
+	defer cursor.Close()
+	if !cursor.GoToFirstChild() {
+		return names
+	}
+	for {
+		child := cursor.CurrentNode()
+		field := cursor.CurrentFieldName()
+		isPath := field == "type" || (field == "name" && child.Type() != "shorthand_field_identifier")
+		if child.IsNamed() && !isPath {
+			names = append(names, getPatternNamesRust(child)...)
+		}
+		if !cursor.GoToNextSibling() {
+			return names
+		}
+	}
+}
+
+// getImplTypeNameRust returns the name of the type an impl block is for.
+func getImplTypeNameRust(impl Node) string {
+	ty := impl.ChildByFieldName("type")
+	if ty == nil {
+		return ""
+	}
+	name := getTypeNameNodeRust(ty)
+	if name == nil {
+		return ""
+	}
+	return name.Content(impl.Contents)
+}
+
+func getTypeNameNodeRust(ty *sitter.Node) *sitter.Node {
+	switch ty.Type() {
+	case "type_identifier":
+		return ty
+	case "scoped_type_identifier":
+		name := ty.ChildByFieldName("name")
+		if name == nil {
+			return nil
+		}
+		return getTypeNameNodeRust(name)
+	case "generic_type":
+		fallthrough
+	case "reference_type":
+		inner := ty.ChildByFieldName("type")
+		if inner == nil {
+			return nil
+		}
+		return getTypeNameNodeRust(inner)
+	default:
+		return nil
+	}
+}
+
+type TypeRust interface {
+	variant() string
+	node() Node
+}
+
+type FnTypeRust struct {
+	ret  TypeRust
+	noad Node
+}
+
+func (t FnTypeRust) variant() string {
+	return "fn"
+}
+
+func (t FnTypeRust) node() Node {
+	return t.noad
+}
+
+// StructTypeRust is a struct, enum, union or trait.
+type StructTypeRust struct {
+	def Node
+}
+
+func (t StructTypeRust) variant() string {
+	return "struct"
+}
+
+func (t StructTypeRust) node() Node {
+	return t.def
+}
+
+func lazyTypeRustStringer(ty *TypeRust) func() fmt.Stringer {
+	return func() fmt.Stringer {
+		if ty != nil && *ty != nil {
+			return String((*ty).variant())
+		} else {
+			return String("<nil>")
+		}
+	}
+}
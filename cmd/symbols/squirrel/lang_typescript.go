@@ -0,0 +1,924 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (squirrel *SquirrelService) getDefTypeScript(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		fallthrough
+	case "property_identifier":
+		fallthrough
+	case "shorthand_property_identifier":
+		fallthrough
+	case "shorthand_property_identifier_pattern":
+		ident := node.Content(node.Contents)
+
+		cur := node.Node
+
+		for {
+			prev := cur
+			cur = cur.Parent()
+			if cur == nil {
+				squirrel.breadcrumb(node, "getDefTypeScript: ran out of parents")
+				return nil, nil
+			}
+
+			switch cur.Type() {
+
+			case "program":
+				return squirrel.findInScopeTypeScript(ctx, swapNode(node, cur), ident)
+
+			case "statement_block":
+				found, err := squirrel.findInScopeTypeScript(ctx, swapNode(node, cur), ident)
+				if err != nil {
+					return nil, err
+				}
+				if found != nil {
+					return found, nil
+				}
+				continue
+
+			case "import_specifier":
+				// Both the name and the alias refer to the export of the other module.
+				name := cur.ChildByFieldName("name")
+				importStatement := getAncestorOfType(cur, "import_statement")
+				if name == nil || importStatement == nil {
+					return nil, nil
+				}
+				module := squirrel.resolveModuleTypeScript(ctx, swapNode(node, importStatement))
+				if module == nil {
+					return swapNodePtr(node, prev), nil
+				}
+				return squirrel.findExportTypeScript(ctx, *module, name.Content(node.Contents))
+
+			case "export_specifier":
+				name := cur.ChildByFieldName("name")
+				if name == nil || nodeId(name) != nodeId(prev) {
+					return nil, nil
+				}
+				exportStatement := getAncestorOfType(cur, "export_statement")
+				if exportStatement == nil || getSourceTypeScript(exportStatement) == nil {
+					continue
+				}
+				module := squirrel.resolveModuleTypeScript(ctx, swapNode(node, exportStatement))
+				if module == nil {
+					return nil, nil
+				}
+				return squirrel.findExportTypeScript(ctx, *module, ident)
+
+			// Check for field access
+			case "member_expression":
+				object := cur.ChildByFieldName("object")
+				if object == nil || nodeId(prev) == nodeId(object) {
+					continue
+				}
+				return squirrel.getFieldTypeScript(ctx, swapNode(node, object), ident)
+
+			case "nested_type_identifier":
+				module := cur.ChildByFieldName("module")
+				if module == nil || nodeId(prev) == nodeId(module) {
+					continue
+				}
+				return squirrel.getFieldTypeScript(ctx, swapNode(node, module), ident)
+
+			case "pair":
+				fallthrough
+			case "pair_pattern":
+				// Object keys are not references.
+				key := cur.ChildByFieldName("key")
+				if key != nil && nodeId(key) == nodeId(prev) {
+					return nil, nil
+				}
+				continue
+
+			// Check nodes that might have bindings:
+			case "function":
+				fallthrough
+			case "generator_function":
+				fallthrough
+			case "function_declaration":
+				fallthrough
+			case "generator_function_declaration":
+				fallthrough
+			case "arrow_function":
+				fallthrough
+			case "method_definition":
+				name := cur.ChildByFieldName("name")
+				if name != nil && nodeId(name) == nodeId(prev) {
+					return swapNodePtr(node, name), nil
+				}
+				// Function expressions can refer to themselves.
+				if name != nil && (cur.Type() == "function" || cur.Type() == "generator_function") && name.Content(node.Contents) == ident {
+					return swapNodePtr(node, name), nil
+				}
+				parameter := cur.ChildByFieldName("parameter")
+				if parameter != nil && parameter.Content(node.Contents) == ident {
+					return swapNodePtr(node, parameter), nil
+				}
+				for _, param := range children(cur.ChildByFieldName("parameters")) {
+					for _, name := range getPatternNamesTypeScript(param) {
+						if name.Content(node.Contents) == ident {
+							return swapNodePtr(node, name), nil
+						}
+					}
+				}
+				continue
+
+			case "class":
+				fallthrough
+			case "class_declaration":
+				fallthrough
+			case "abstract_class_declaration":
+				fallthrough
+			case "interface_declaration":
+				fallthrough
+			case "type_alias_declaration":
+				fallthrough
+			case "enum_declaration":
+				fallthrough
+			case "public_field_definition":
+				fallthrough
+			case "property_signature":
+				fallthrough
+			case "method_signature":
+				name := cur.ChildByFieldName("name")
+				if name != nil && nodeId(name) == nodeId(prev) {
+					return swapNodePtr(node, name), nil
+				}
+				// Class expressions can refer to themselves.
+				if name != nil && cur.Type() == "class" && name.Content(node.Contents) == ident {
+					return swapNodePtr(node, name), nil
+				}
+				continue
+
+			case "for_statement":
+				for _, name := range getDeclaredNamesTypeScript(cur.ChildByFieldName("initializer")) {
+					if name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			case "for_in_statement":
+				for _, name := range getPatternNamesTypeScript(cur.ChildByFieldName("left")) {
+					if name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			case "catch_clause":
+				for _, name := range getPatternNamesTypeScript(cur.ChildByFieldName("parameter")) {
+					if name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			// Skip all other nodes
+			default:
+				continue
+			}
+		}
+
+	case "this":
+		for cur := node.Node; cur != nil; cur = cur.Parent() {
+			switch cur.Type() {
+			case "class":
+				fallthrough
+			case "class_declaration":
+				fallthrough
+			case "abstract_class_declaration":
+				name := cur.ChildByFieldName("name")
+				if name == nil {
+					return nil, nil
+				}
+				return swapNodePtr(node, name), nil
+			}
+		}
+		return nil, nil
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// findInScopeTypeScript looks for a declaration in a program or a block. Declarations are hoisted,
+// so they're checked regardless of whether they come before or after the reference.
+func (squirrel *SquirrelService) findInScopeTypeScript(ctx context.Context, scope Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(scope, &Tuple{String(scope.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	for _, child := range children(scope.Node) {
+		if child.Type() == "import_statement" {
+			found, err := squirrel.findInImportTypeScript(ctx, swapNode(scope, child), ident)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+			continue
+		}
+
+		for _, name := range getDeclaredNamesTypeScript(child) {
+			if name.Content(scope.Contents) == ident {
+				return swapNodePtr(scope, name), nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// findInImportTypeScript checks if the import statement binds the given identifier and finds the
+// export it refers to. Imports from modules that can't be found (e.g. npm packages) resolve to the
+// local binding.
+func (squirrel *SquirrelService) findInImportTypeScript(ctx context.Context, importStatement Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(importStatement, &Tuple{String(importStatement.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	for _, importClause := range children(importStatement.Node) {
+		if importClause.Type() != "import_clause" {
+			continue
+		}
+		for _, child := range children(importClause) {
+			var binding *sitter.Node
+			var exportName string
+			isNamespace := false
+			switch child.Type() {
+			case "identifier":
+				// import x from '...'
+				binding = child
+				exportName = "default"
+			case "namespace_import":
+				// import * as x from '...'
+				if child.NamedChildCount() == 0 {
+					continue
+				}
+				binding = child.NamedChild(0)
+				isNamespace = true
+			case "named_imports":
+				// import { x, y as z } from '...'
+				for _, specifier := range children(child) {
+					if specifier.Type() != "import_specifier" {
+						continue
+					}
+					name := specifier.ChildByFieldName("name")
+					if name == nil {
+						continue
+					}
+					local := specifier.ChildByFieldName("alias")
+					if local == nil {
+						local = name
+					}
+					if local.Content(importStatement.Contents) == ident {
+						binding = local
+						exportName = name.Content(importStatement.Contents)
+						break
+					}
+				}
+			}
+			if binding == nil || binding.Content(importStatement.Contents) != ident {
+				continue
+			}
+
+			module := squirrel.resolveModuleTypeScript(ctx, importStatement)
+			if module == nil {
+				return swapNodePtr(importStatement, binding), nil
+			}
+			if isNamespace {
+				return module, nil
+			}
+			found, err := squirrel.findExportTypeScript(ctx, *module, exportName)
+			if err != nil {
+				return nil, err
+			}
+			if found == nil {
+				return swapNodePtr(importStatement, binding), nil
+			}
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// findExportTypeScript finds the declaration of a module's export, following re-exports.
+func (squirrel *SquirrelService) findExportTypeScript(ctx context.Context, module Node, name string) (ret *Node, err error) {
+	defer squirrel.onCall(module, &Tuple{String(module.Type()), String(name)}, lazyNodeStringer(&ret))()
+
+	for _, stmt := range children(module.Node) {
+		if stmt.Type() != "export_statement" {
+			continue
+		}
+
+		// export default ...
+		if isDefaultExportTypeScript(stmt) {
+			if name != "default" {
+				continue
+			}
+			value := stmt.ChildByFieldName("value")
+			if value == nil {
+				value = stmt.ChildByFieldName("declaration")
+			}
+			if value == nil {
+				continue
+			}
+			if value.Type() == "identifier" {
+				return squirrel.findInScopeTypeScript(ctx, module, value.Content(module.Contents))
+			}
+			if valueName := value.ChildByFieldName("name"); valueName != nil {
+				return swapNodePtr(module, valueName), nil
+			}
+			return swapNodePtr(module, value), nil
+		}
+
+		// export const x = ...
+		for _, declName := range getDeclaredNamesTypeScript(stmt.ChildByFieldName("declaration")) {
+			if declName.Content(module.Contents) == name {
+				return swapNodePtr(module, declName), nil
+			}
+		}
+
+		source := getSourceTypeScript(stmt)
+		exportClause := (*sitter.Node)(nil)
+		for _, child := range children(stmt) {
+			if child.Type() == "export_clause" {
+				exportClause = child
+			}
+		}
+
+		// export * from '...'
+		if exportClause == nil {
+			if source == nil {
+				continue
+			}
+			target := squirrel.resolveModuleTypeScript(ctx, swapNode(module, stmt))
+			if target == nil {
+				continue
+			}
+			found, err := squirrel.findExportTypeScript(ctx, *target, name)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+			continue
+		}
+
+		// export { x, y as z } and export { x, y as z } from '...'
+		for _, specifier := range children(exportClause) {
+			if specifier.Type() != "export_specifier" {
+				continue
+			}
+			local := specifier.ChildByFieldName("name")
+			if local == nil {
+				continue
+			}
+			exported := specifier.ChildByFieldName("alias")
+			if exported == nil {
+				exported = local
+			}
+			if exported.Content(module.Contents) != name {
+				continue
+			}
+			if source == nil {
+				return squirrel.findInScopeTypeScript(ctx, module, local.Content(module.Contents))
+			}
+			target := squirrel.resolveModuleTypeScript(ctx, swapNode(module, stmt))
+			if target == nil {
+				return swapNodePtr(module, local), nil
+			}
+			return squirrel.findExportTypeScript(ctx, *target, local.Content(module.Contents))
+		}
+	}
+
+	return nil, nil
+}
+
+// resolveModuleTypeScript finds the module that an import or export statement refers to. Only
+// relative paths are supported, so packages and path mappings from tsconfig.json are not resolved.
+func (squirrel *SquirrelService) resolveModuleTypeScript(ctx context.Context, stmt Node) *Node {
+	source := getSourceTypeScript(stmt.Node)
+	if source == nil {
+		return nil
+	}
+	specifier := strings.Trim(source.Content(stmt.Contents), "\"'`")
+	if !strings.HasPrefix(specifier, ".") {
+		return nil
+	}
+
+	base := filepath.Join(filepath.Dir(stmt.RepoCommitPath.Path), specifier)
+	switch filepath.Ext(base) {
+	case ".js", ".jsx", ".mjs", ".cjs":
+		// ES modules import the compiled file.
+		base = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+
+	candidates := []string{
+		base + ".ts",
+		base + ".tsx",
+		base + ".d.ts",
+		filepath.Join(base, "index.ts"),
+		filepath.Join(base, "index.tsx"),
+	}
+	if ext := filepath.Ext(base); ext == ".ts" || ext == ".tsx" {
+		candidates = append([]string{base}, candidates...)
+	}
+
+	for _, candidate := range candidates {
+		module, err := squirrel.parse(ctx, types.RepoCommitPath{
+			Repo:   stmt.RepoCommitPath.Repo,
+			Commit: stmt.RepoCommitPath.Commit,
+			Path:   candidate,
+		})
+		if err != nil {
+			continue
+		}
+		return module
+	}
+
+	return nil
+}
+
+func (squirrel *SquirrelService) getFieldTypeScript(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer squirrel.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	ty, err := squirrel.getTypeDefTypeScript(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return squirrel.lookupFieldTypeScript(ctx, ty, field)
+}
+
+func (squirrel *SquirrelService) lookupFieldTypeScript(ctx context.Context, ty TypeTypeScript, field string) (ret *Node, err error) {
+	defer squirrel.onCall(ty.node(), &Tuple{String(ty.variant()), String(field)}, lazyNodeStringer(&ret))()
+
+	switch ty2 := ty.(type) {
+	case ModuleTypeTypeScript:
+		return squirrel.findExportTypeScript(ctx, ty2.module, field)
+	case ClassTypeTypeScript:
+		body := ty2.def.ChildByFieldName("body")
+		if ty2.def.Type() == "type_alias_declaration" {
+			body = ty2.def.ChildByFieldName("value")
+		}
+		for _, child := range children(body) {
+			switch child.Type() {
+			case "method_definition":
+				name := child.ChildByFieldName("name")
+				if name == nil {
+					continue
+				}
+				if name.Content(ty2.def.Contents) == field {
+					return swapNodePtr(ty2.def, name), nil
+				}
+				if name.Content(ty2.def.Contents) != "constructor" {
+					continue
+				}
+				// constructor(private x: number) declares the field x.
+				for _, param := range children(child.ChildByFieldName("parameters")) {
+					if !isParameterPropertyTypeScript(param) {
+						continue
+					}
+					for _, paramName := range getPatternNamesTypeScript(param) {
+						if paramName.Content(ty2.def.Contents) == field {
+							return swapNodePtr(ty2.def, paramName), nil
+						}
+					}
+				}
+			case "public_field_definition":
+				fallthrough
+			case "method_signature":
+				fallthrough
+			case "abstract_method_signature":
+				fallthrough
+			case "property_signature":
+				name := child.ChildByFieldName("name")
+				if name != nil && name.Content(ty2.def.Contents) == field {
+					return swapNodePtr(ty2.def, name), nil
+				}
+			}
+		}
+		for _, super := range getSuperclassesTypeScript(ty2.def) {
+			found, err := squirrel.getFieldTypeScript(ctx, super, field)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+		return nil, nil
+	case FnTypeTypeScript:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldTypeScript: unexpected object type %s", ty.variant()))
+		return nil, nil
+	default:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldTypeScript: unrecognized type variant %q", ty.variant()))
+		return nil, nil
+	}
+}
+
+func (squirrel *SquirrelService) getTypeDefTypeScript(ctx context.Context, node Node) (ret TypeTypeScript, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyTypeTypeScriptStringer(&ret))()
+
+	onIdent := func() (TypeTypeScript, error) {
+		found, err := squirrel.getDefTypeScript(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return squirrel.defToTypeTypeScript(ctx, *found)
+	}
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		fallthrough
+	case "property_identifier":
+		fallthrough
+	case "this":
+		return onIdent()
+	case "type_annotation":
+		fallthrough
+	case "generic_type":
+		fallthrough
+	case "parenthesized_expression":
+		fallthrough
+	case "parenthesized_type":
+		fallthrough
+	case "non_null_expression":
+		for _, child := range children(node.Node) {
+			return squirrel.getTypeDefTypeScript(ctx, swapNode(node, child))
+		}
+		return nil, nil
+	case "as_expression":
+		// x as T
+		if node.NamedChildCount() < 2 {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(node, node.NamedChild(int(node.NamedChildCount())-1)))
+	case "nested_type_identifier":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(node, name))
+	case "member_expression":
+		property := node.ChildByFieldName("property")
+		if property == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(node, property))
+	case "new_expression":
+		constructor := node.ChildByFieldName("constructor")
+		if constructor == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(node, constructor))
+	case "call_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		ty, err := squirrel.getTypeDefTypeScript(ctx, swapNode(node, fn))
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			return nil, nil
+		}
+		switch ty2 := ty.(type) {
+		case FnTypeTypeScript:
+			return ty2.ret, nil
+		default:
+			squirrel.breadcrumb(ty.node(), fmt.Sprintf("getTypeDefTypeScript: expected function, got %q", ty.variant()))
+			return nil, nil
+		}
+	default:
+		squirrel.breadcrumb(node, fmt.Sprintf("getTypeDefTypeScript: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+func (squirrel *SquirrelService) defToTypeTypeScript(ctx context.Context, def Node) (TypeTypeScript, error) {
+	if def.Node.Type() == "program" {
+		return (TypeTypeScript)(ModuleTypeTypeScript{module: def}), nil
+	}
+
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	onFn := func(fn *sitter.Node) (TypeTypeScript, error) {
+		retTyNode := fn.ChildByFieldName("return_type")
+		if retTyNode == nil {
+			return (TypeTypeScript)(FnTypeTypeScript{ret: nil, noad: swapNode(def, fn)}), nil
+		}
+		retTy, err := squirrel.getTypeDefTypeScript(ctx, swapNode(def, retTyNode))
+		if err != nil {
+			return nil, err
+		}
+		return (TypeTypeScript)(FnTypeTypeScript{ret: retTy, noad: swapNode(def, fn)}), nil
+	}
+
+	switch parent.Type() {
+	case "class":
+		fallthrough
+	case "class_declaration":
+		fallthrough
+	case "abstract_class_declaration":
+		fallthrough
+	case "interface_declaration":
+		return (TypeTypeScript)(ClassTypeTypeScript{def: swapNode(def, parent)}), nil
+	case "type_alias_declaration":
+		value := parent.ChildByFieldName("value")
+		if value == nil {
+			return nil, nil
+		}
+		if value.Type() == "object_type" {
+			return (TypeTypeScript)(ClassTypeTypeScript{def: swapNode(def, parent)}), nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(def, value))
+	case "function":
+		fallthrough
+	case "function_declaration":
+		fallthrough
+	case "generator_function":
+		fallthrough
+	case "generator_function_declaration":
+		fallthrough
+	case "function_signature":
+		fallthrough
+	case "method_definition":
+		fallthrough
+	case "method_signature":
+		fallthrough
+	case "abstract_method_signature":
+		return onFn(parent)
+	case "variable_declarator":
+		ty := parent.ChildByFieldName("type")
+		if ty != nil {
+			return squirrel.getTypeDefTypeScript(ctx, swapNode(def, ty))
+		}
+		value := parent.ChildByFieldName("value")
+		if value == nil {
+			return nil, nil
+		}
+		switch value.Type() {
+		case "arrow_function":
+			fallthrough
+		case "function":
+			return onFn(value)
+		default:
+			return squirrel.getTypeDefTypeScript(ctx, swapNode(def, value))
+		}
+	case "required_parameter":
+		fallthrough
+	case "optional_parameter":
+		for _, child := range children(parent) {
+			if child.Type() == "type_annotation" {
+				return squirrel.getTypeDefTypeScript(ctx, swapNode(def, child))
+			}
+		}
+		return nil, nil
+	case "public_field_definition":
+		fallthrough
+	case "property_signature":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(def, ty))
+	default:
+		squirrel.breadcrumb(swapNode(def, parent), fmt.Sprintf("unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+// getDeclaredNamesTypeScript returns the names declared by a statement.
+func getDeclaredNamesTypeScript(stmt *sitter.Node) []*sitter.Node {
+	if stmt == nil {
+		return nil
+	}
+
+	names := []*sitter.Node{}
+	switch stmt.Type() {
+	case "export_statement":
+		names = append(names, getDeclaredNamesTypeScript(stmt.ChildByFieldName("declaration"))...)
+		if value := stmt.ChildByFieldName("value"); value != nil && value.ChildByFieldName("name") != nil {
+			names = append(names, value.ChildByFieldName("name"))
+		}
+	case "lexical_declaration":
+		fallthrough
+	case "variable_declaration":
+		for _, declarator := range children(stmt) {
+			if declarator.Type() != "variable_declarator" {
+				continue
+			}
+			names = append(names, getPatternNamesTypeScript(declarator.ChildByFieldName("name"))...)
+		}
+	case "expression_statement":
+		for _, child := range children(stmt) {
+			names = append(names, getDeclaredNamesTypeScript(child)...)
+		}
+	case "function_declaration":
+		fallthrough
+	case "generator_function_declaration":
+		fallthrough
+	case "function_signature":
+		fallthrough
+	case "class_declaration":
+		fallthrough
+	case "abstract_class_declaration":
+		fallthrough
+	case "interface_declaration":
+		fallthrough
+	case "type_alias_declaration":
+		fallthrough
+	case "enum_declaration":
+		fallthrough
+	case "internal_module":
+		fallthrough
+	case "module":
+		name := stmt.ChildByFieldName("name")
+		if name != nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// getPatternNamesTypeScript returns the identifiers bound by a (possibly destructuring) pattern or
+// parameter.
+func getPatternNamesTypeScript(pattern *sitter.Node) []*sitter.Node {
+	if pattern == nil {
+		return nil
+	}
+
+	switch pattern.Type() {
+	case "identifier":
+		fallthrough
+	case "shorthand_property_identifier_pattern":
+		return []*sitter.Node{pattern}
+	case "pair_pattern":
+		return getPatternNamesTypeScript(pattern.ChildByFieldName("value"))
+	case "assignment_pattern":
+		fallthrough
+	case "object_assignment_pattern":
+		return getPatternNamesTypeScript(pattern.ChildByFieldName("left"))
+	case "required_parameter":
+		fallthrough
+	case "optional_parameter":
+		// The pattern is the first child that isn't a modifier.
+		for _, child := range children(pattern) {
+			if child.Type() == "accessibility_modifier" {
+				continue
+			}
+			return getPatternNamesTypeScript(child)
+		}
+		return nil
+	case "rest_pattern":
+		fallthrough
+	case "array_pattern":
+		fallthrough
+	case "object_pattern":
+		names := []*sitter.Node{}
+		for _, child := range children(pattern) {
+			names = append(names, getPatternNamesTypeScript(child)...)
+		}
+		return names
+	default:
+		return nil
+	}
+}
+
+// isParameterPropertyTypeScript returns true for constructor parameters that also declare a
+// field, e.g. constructor(private x: number).
+func isParameterPropertyTypeScript(param *sitter.Node) bool {
+	for i := 0; i < int(param.ChildCount()); i++ {
+		switch param.Child(i).Type() {
+		case "accessibility_modifier":
+			return true
+		case "readonly":
+			return true
+		}
+	}
+	return false
+}
+
+func isDefaultExportTypeScript(exportStatement *sitter.Node) bool {
+	for i := 0; i < int(exportStatement.ChildCount()); i++ {
+		if exportStatement.Child(i).Type() == "default" {
+			return true
+		}
+	}
+	return false
+}
+
+func getSuperclassesTypeScript(def Node) []Node {
+	supers := []Node{}
+	for _, child := range children(def.Node) {
+		switch child.Type() {
+		case "class_heritage":
+			for _, clause := range children(child) {
+				if clause.Type() != "extends_clause" {
+					continue
+				}
+				for _, super := range children(clause) {
+					supers = append(supers, swapNode(def, super))
+				}
+			}
+		case "extends_clause":
+			// interface I extends J, K { ... }
+			for _, super := range children(child) {
+				supers = append(supers, swapNode(def, super))
+			}
+		}
+	}
+	return supers
+}
+
+type TypeTypeScript interface {
+	variant() string
+	node() Node
+}
+
+type FnTypeTypeScript struct {
+	ret  TypeTypeScript
+	noad Node
+}
+
+func (t FnTypeTypeScript) variant() string {
+	return "fn"
+}
+
+func (t FnTypeTypeScript) node() Node {
+	return t.noad
+}
+
+// ClassTypeTypeScript is a class, an interface, or an object type alias.
+type ClassTypeTypeScript struct {
+	def Node
+}
+
+func (t ClassTypeTypeScript) variant() string {
+	return "class"
+}
+
+func (t ClassTypeTypeScript) node() Node {
+	return t.def
+}
+
+type ModuleTypeTypeScript struct {
+	module Node
+}
+
+func (t ModuleTypeTypeScript) variant() string {
+	return "module"
+}
+
+func (t ModuleTypeTypeScript) node() Node {
+	return t.module
+}
+
+func lazyTypeTypeScriptStringer(ty *TypeTypeScript) func() fmt.Stringer {
+	return func() fmt.Stringer {
+		if ty != nil && *ty != nil {
+			return String((*ty).variant())
+		} else {
+			return String("<nil>")
+		}
+	}
+}
+
+// getSourceTypeScript returns the module specifier string of an import or export statement. The
+// "source" field isn't reliably reported by the grammar, so this looks for the string child.
+func getSourceTypeScript(stmt *sitter.Node) *sitter.Node {
+	for _, child := range children(stmt) {
+		if child.Type() == "string" {
+			return child
+		}
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/smacker/go-tree-sitter/javascript"
 	"github.com/smacker/go-tree-sitter/python"
 	"github.com/smacker/go-tree-sitter/ruby"
+	"github.com/smacker/go-tree-sitter/rust"
 	"github.com/smacker/go-tree-sitter/typescript/tsx"
 )
 
@@ -124,6 +125,14 @@ var langToLangSpec = map[string]LangSpec{
 (short_var_declaration left: (expression_list (identifier) @definition)) ; x, y := ...
 (range_clause          left: (expression_list (identifier) @definition)) ; for i := range ... { ... }
 (receive_statement     left: (expression_list (identifier) @definition)) ; case x := <-ch: ...
+`,
+		topLevelSymbolsQuery: `
+(source_file (function_declaration name: (identifier) @symbol))
+(source_file (method_declaration name: (field_identifier) @symbol))
+(source_file (type_declaration (type_spec name: (type_identifier) @symbol)))
+(source_file (type_declaration (type_alias name: (type_identifier) @symbol)))
+(source_file (var_declaration (var_spec name: (identifier) @symbol)))
+(source_file (const_declaration (const_spec name: (identifier) @symbol)))
 `,
 	},
 	"csharp": {
@@ -227,6 +236,19 @@ var langToLangSpec = map[string]LangSpec{
 (arrow_function parameter: (identifier) @definition)            ; x => ...
 (for_in_statement left: (identifier) @definition)               ; for (const x of xs) ...
 (catch_clause parameter: (identifier) @definition)              ; catch (e) ...
+`,
+		topLevelSymbolsQuery: `
+(program (class_declaration                 name: (type_identifier) @symbol))
+(program (interface_declaration             name: (type_identifier) @symbol))
+(program (type_alias_declaration            name: (type_identifier) @symbol))
+(program (function_declaration              name: (identifier) @symbol))
+(program (lexical_declaration (variable_declarator name: (identifier) @symbol)))
+(program (export_statement (class_declaration      name: (type_identifier) @symbol)))
+(program (export_statement (interface_declaration  name: (type_identifier) @symbol)))
+(program (export_statement (type_alias_declaration name: (type_identifier) @symbol)))
+(program (export_statement (function_declaration   name: (identifier) @symbol)))
+(program (export_statement (function               name: (identifier) @symbol)))
+(program (export_statement (lexical_declaration (variable_declarator name: (identifier) @symbol))))
 `,
 	},
 	"cpp": {
@@ -277,6 +299,45 @@ var langToLangSpec = map[string]LangSpec{
 (assignment           left: (identifier) @definition)    ; x = ...
 (left_assignment_list (identifier) @definition)          ; x, y = ...
 (for                  pattern: (identifier) @definition) ; for i in 1..5 ...
+`,
+	},
+	"rust": {
+		name:     "rust",
+		language: rust.GetLanguage(),
+		commentStyle: CommentStyle{
+			nodeTypes:     []string{"line_comment", "block_comment"},
+			stripRegex:    regexp.MustCompile(`^//[/!]?|^\s*\*/?|^/\*[*!]?|\*/$`),
+			ignoreRegex:   javaStyleIgnoreRegex,
+			codeFenceName: "rust",
+			skipNodeTypes: []string{"attribute_item"},
+		},
+		localsQuery: `
+(block)                @scope ; { ... }
+(function_item)        @scope ; fn f() { ... }
+(closure_expression)   @scope ; |x| ...
+(for_expression)       @scope ; for x in xs { ... }
+(if_let_expression)    @scope ; if let Some(x) = ... { ... }
+(while_let_expression) @scope ; while let Some(x) = ... { ... }
+(match_arm)            @scope ; Some(x) => ...
+
+(let_declaration      pattern: (identifier) @definition)                                                  ; let x = ...
+(let_declaration      pattern: (tuple_pattern (identifier) @definition))                                  ; let (x, y) = ...
+(let_declaration      pattern: (mut_pattern (identifier) @definition))                                    ; let mut x = ...
+(parameter            pattern: (identifier) @definition)                                                  ; fn f(x: i32) { ... }
+(parameter            pattern: (mut_pattern (identifier) @definition))                                    ; fn f(mut x: i32) { ... }
+(closure_parameters   (identifier) @definition)                                                           ; |x| ...
+(for_expression       pattern: (identifier) @definition)                                                  ; for x in xs { ... }
+(for_expression       pattern: (tuple_pattern (identifier) @definition))                                  ; for (i, x) in xs { ... }
+(if_let_expression    pattern: (tuple_struct_pattern type: (_) (identifier) @definition))                 ; if let Some(x) = ... { ... }
+(while_let_expression pattern: (tuple_struct_pattern type: (_) (identifier) @definition))                 ; while let Some(x) = ... { ... }
+(match_arm            pattern: (match_pattern (tuple_struct_pattern type: (_) (identifier) @definition))) ; Some(x) => ...
+`,
+		topLevelSymbolsQuery: `
+(source_file (function_item name: (identifier) @symbol))
+(source_file (struct_item   name: (type_identifier) @symbol))
+(source_file (enum_item     name: (type_identifier) @symbol))
+(source_file (trait_item    name: (type_identifier) @symbol))
+(source_file (impl_item body: (declaration_list (function_item name: (identifier) @symbol))))
 `,
 	},
 	"starlark": {
@@ -334,6 +334,29 @@ def f(p1, p2 = 3, *p3, **p4)
 		puts e
 	end
 end
+`}, {
+		path: "test.rs",
+		contents: `
+fn f(p1: i32, mut p2: i32) -> i32 { // < "p1" f.p1 def < "p1" f.p1 ref < "p2" f.p2 def < "p2" f.p2 ref
+	let x = p1 + p2; // < "x" f.x def < "x" f.x ref < "p1" f.p1 ref < "p2" f.p2 ref
+
+	let (a, b) = (x, 2); // < "a" f.a def < "a" f.a ref < "b" f.b def < "b" f.b ref < "x" f.x ref
+
+	let c = |y: i32| y + a; // < "c" f.c def < "c" f.c ref < "y:" f.y def < "y:" f.y ref < "y +" f.y ref < "a;" f.a ref
+
+	for i in 0..b { // < "i" f.i def < "i" f.i ref < "b" f.b ref
+		c(i); // < "c" f.c ref < "i" f.i ref
+	}
+
+	if let Some(z) = g() { // < "z" f.z def < "z" f.z ref
+		c(z); // < "c" f.c ref < "z" f.z ref
+	}
+
+	match g() {
+		Some(w) => c(w), // < "w" f.w def < "w" f.w ref < "c(" f.c ref < "w)," f.w ref
+		None => 0,
+	}
+}
 `},
 	}
 
@@ -0,0 +1,258 @@
+package squirrel
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// How many symbols to request from the symbols service when looking for files that might contain
+// references.
+const maxReferenceCandidateSymbols = 10000
+
+// How many files to analyze when looking for references.
+const maxReferenceCandidateFiles = 500
+
+// symbolReferences finds the definition of the symbol at the given point and the references to it
+// in the same repository.
+//
+// Locals are resolved with the locals query of the definition's file. Other symbols are found by
+// looking for identifiers with the same name in candidate files and resolving their definitions
+// with getDef(). Identifiers whose definition can't be resolved are returned as name-only
+// references.
+func (squirrel *SquirrelService) symbolReferences(ctx context.Context, point types.RepoCommitPathPoint) (*types.SymbolReferences, error) {
+	// First, find the definition.
+	root, err := squirrel.parse(ctx, point.RepoCommitPath)
+	if err != nil {
+		return nil, err
+	}
+	startNode := root.NamedDescendantForPointRange(
+		sitter.Point{Row: uint32(point.Row), Column: uint32(point.Column)},
+		sitter.Point{Row: uint32(point.Row), Column: uint32(point.Column)},
+	)
+	if startNode == nil {
+		return nil, errors.New("node is nil")
+	}
+	def, err := squirrel.getDef(ctx, swapNode(*root, startNode))
+	if err != nil {
+		return nil, err
+	}
+	if def == nil {
+		return nil, nil
+	}
+
+	result := &types.SymbolReferences{
+		Definition: types.RepoCommitPathMaybeRange{RepoCommitPath: def.RepoCommitPath},
+		References: []types.SymbolReference{},
+	}
+
+	// Directories (e.g. Go packages) don't have a name to look for.
+	if def.Node == nil {
+		return result, nil
+	}
+
+	defRange := nodeToRange(def.Node)
+	result.Definition.Range = &defRange
+
+	// Locals can only be referenced in the file they're defined in.
+	local, err := squirrel.getLocalSymbol(ctx, *def)
+	if err != nil {
+		return nil, err
+	}
+	if local != nil {
+		for _, ref := range local.Refs {
+			if ref == defRange {
+				continue
+			}
+			result.References = append(result.References, types.SymbolReference{
+				RepoCommitPathRange: types.RepoCommitPathRange{RepoCommitPath: def.RepoCommitPath, Range: ref},
+				Confidence:          types.ReferenceConfidenceScopeResolved,
+			})
+		}
+		sortReferences(result.References)
+		return result, nil
+	}
+
+	// Otherwise, look for references in other files of the same language.
+	ident := def.Content(def.Contents)
+	paths, err := squirrel.getReferenceCandidatePaths(ctx, *def, point.RepoCommitPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		file, err := squirrel.parse(ctx, types.RepoCommitPath{
+			Repo:   def.RepoCommitPath.Repo,
+			Commit: def.RepoCommitPath.Commit,
+			Path:   path,
+		})
+		if err != nil {
+			// Skip files that can't be read or parsed rather than failing the whole request.
+			squirrel.breadcrumb(*def, "symbolReferences: failed to parse "+path+": "+err.Error())
+			continue
+		}
+		if !strings.Contains(string(file.Contents), ident) {
+			continue
+		}
+
+		result.References = append(result.References, squirrel.findReferencesInFile(ctx, *file, *def, ident)...)
+	}
+
+	sortReferences(result.References)
+	return result, nil
+}
+
+// getLocalSymbol returns the symbol from the local code intel payload of the definition's file
+// that's defined by def, or nil if def isn't a local.
+func (squirrel *SquirrelService) getLocalSymbol(ctx context.Context, def Node) (*types.Symbol, error) {
+	// Declarations like `function f() { ... }` are the scope of their own name, but the name is
+	// visible outside of them.
+	if parent := def.Node.Parent(); parent != nil {
+		if name := parent.ChildByFieldName("name"); name != nil && nodeId(name) == nodeId(def.Node) {
+			isScope := false
+			err := forEachCapture(def.LangSpec.localsQuery, swapNode(def, getRoot(def.Node)), func(nameToNode map[string]Node) {
+				if scope, ok := nameToNode["scope"]; ok && nodeId(scope.Node) == nodeId(parent) {
+					isScope = true
+				}
+			})
+			if err != nil {
+				return nil, err
+			}
+			if isScope {
+				return nil, nil
+			}
+		}
+	}
+
+	// TypeScript parameter properties like `constructor(private x: number)` are also class members.
+	if parent := def.Node.Parent(); def.LangSpec.name == "typescript" && parent != nil && parent.Type() == "required_parameter" && isParameterPropertyTypeScript(parent) {
+		return nil, nil
+	}
+
+	payload, err := squirrel.localCodeIntel(ctx, def.RepoCommitPath)
+	if err != nil {
+		return nil, err
+	}
+	defRange := nodeToRange(def.Node)
+	for _, symbol := range payload.Symbols {
+		if symbol.Def == defRange {
+			symbol := symbol
+			return &symbol, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// findReferencesInFile returns the identifiers in the file that are named ident and that either
+// resolve to def or can't be resolved at all.
+func (squirrel *SquirrelService) findReferencesInFile(ctx context.Context, file Node, def Node, ident string) []types.SymbolReference {
+	defRange := nodeToRange(def.Node)
+
+	candidates := []*sitter.Node{}
+	walk(file.Node, func(node *sitter.Node) {
+		if !strings.Contains(node.Type(), "identifier") || node.NamedChildCount() != 0 {
+			return
+		}
+		if node.Content(file.Contents) != ident {
+			return
+		}
+		if file.RepoCommitPath == def.RepoCommitPath && nodeToRange(node) == defRange {
+			return
+		}
+		candidates = append(candidates, node)
+	})
+
+	references := []types.SymbolReference{}
+	for _, candidate := range candidates {
+		found, err := squirrel.getDef(ctx, swapNode(file, candidate))
+		if err != nil {
+			// The definition couldn't be resolved, which makes this a name-only reference.
+			squirrel.breadcrumb(swapNode(file, candidate), "findReferencesInFile: "+err.Error())
+			found = nil
+		}
+
+		var confidence types.ReferenceConfidence
+		switch {
+		case found == nil:
+			confidence = types.ReferenceConfidenceNameOnly
+		case found.Node != nil && found.RepoCommitPath == def.RepoCommitPath && nodeToRange(found.Node) == defRange:
+			confidence = types.ReferenceConfidenceScopeResolved
+		default:
+			// It's a different symbol with the same name.
+			continue
+		}
+
+		references = append(references, types.SymbolReference{
+			RepoCommitPathRange: types.RepoCommitPathRange{RepoCommitPath: file.RepoCommitPath, Range: nodeToRange(candidate)},
+			Confidence:          confidence,
+		})
+	}
+
+	return references
+}
+
+// getReferenceCandidatePaths returns the paths of the files that might reference def: the file
+// with the definition, the file the request started in and the files of the same language that
+// the symbols service knows about.
+func (squirrel *SquirrelService) getReferenceCandidatePaths(ctx context.Context, def Node, start types.RepoCommitPath) ([]string, error) {
+	paths := []string{def.RepoCommitPath.Path}
+	seen := map[string]struct{}{def.RepoCommitPath.Path: {}}
+	add := func(path string) {
+		if _, ok := seen[path]; ok {
+			return
+		}
+		seen[path] = struct{}{}
+		paths = append(paths, path)
+	}
+
+	add(start.Path)
+
+	exts := langToExts[def.LangSpec.name]
+	if squirrel.symbolSearch == nil || len(exts) == 0 {
+		return paths, nil
+	}
+
+	quoted := []string{}
+	for _, ext := range exts {
+		quoted = append(quoted, regexp.QuoteMeta(ext))
+	}
+
+	symbols, err := squirrel.symbolSearch(ctx, search.SymbolsParameters{
+		Repo:            api.RepoName(def.RepoCommitPath.Repo),
+		CommitID:        api.CommitID(def.RepoCommitPath.Commit),
+		Query:           "",
+		IsRegExp:        true,
+		IsCaseSensitive: true,
+		IncludePatterns: []string{`\.(` + strings.Join(quoted, "|") + `)$`},
+		ExcludePattern:  "",
+		First:           maxReferenceCandidateSymbols,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, symbol := range symbols {
+		if len(paths) >= maxReferenceCandidateFiles {
+			break
+		}
+		add(symbol.Path)
+	}
+
+	return paths, nil
+}
+
+func sortReferences(references []types.SymbolReference) {
+	sort.Slice(references, func(i, j int) bool {
+		if references[i].Path != references[j].Path {
+			return references[i].Path < references[j].Path
+		}
+		return isLessRange(references[i].Range, references[j].Range)
+	})
+}
@@ -0,0 +1,114 @@
+package squirrel
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestReferences(t *testing.T) {
+	squirrel, annotations := newTestReposSquirrel(t)
+	defer squirrel.Close()
+
+	// The symbol search only knows about files with top-level symbols, so references in other
+	// files can't be found from the definition.
+	searchable := map[types.RepoCommitPath]struct{}{}
+	for _, a := range annotations {
+		root, err := squirrel.parse(context.Background(), a.repoCommitPathPoint.RepoCommitPath)
+		fatalIfErrorLabel(t, err, "parse")
+		if root.LangSpec.topLevelSymbolsQuery != "" {
+			searchable[a.repoCommitPathPoint.RepoCommitPath] = struct{}{}
+		}
+	}
+
+	testCount := 0
+
+	symbolToTagToAnnotations := groupBySymbolAndTag(annotations)
+	symbols := []string{}
+	for symbol := range symbolToTagToAnnotations {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	for _, symbol := range symbols {
+		m := symbolToTagToAnnotations[symbol]
+		if len(m["def"]) != 1 {
+			continue
+		}
+		def := m["def"][0].repoCommitPathPoint
+
+		// Start from a reference, like users do.
+		var start *types.RepoCommitPathPoint
+		for _, ref := range m["ref"] {
+			if !contains(ref.tags, "nodef") {
+				start = &ref.repoCommitPathPoint
+				break
+			}
+		}
+		if start == nil {
+			continue
+		}
+
+		squirrel.breadcrumbs = Breadcrumbs{}
+		got, err := squirrel.symbolReferences(context.Background(), *start)
+		fatalIfErrorLabel(t, err, "symbolReferences")
+		if got == nil || got.Definition.Range == nil {
+			squirrel.breadcrumbs.prettyPrint(squirrel.readFile)
+			t.Fatalf("no definition for symbol %s", symbol)
+		}
+		if got.Definition.RepoCommitPath != def.RepoCommitPath || got.Definition.Row != def.Row || got.Definition.Column != def.Column {
+			t.Fatalf("wrong definition for symbol %s: got %s:%d:%d", symbol, got.Definition.Path, got.Definition.Row, got.Definition.Column)
+		}
+		name := nameAtPoint(t, squirrel, def)
+
+		gotConfidence := map[types.RepoCommitPathPoint]types.ReferenceConfidence{}
+		for _, ref := range got.References {
+			point := types.RepoCommitPathPoint{
+				RepoCommitPath: ref.RepoCommitPath,
+				Point:          types.Point{Row: ref.Row, Column: ref.Column},
+			}
+			gotConfidence[point] = ref.Confidence
+		}
+
+		for _, ref := range m["ref"] {
+			if ref.repoCommitPathPoint == def {
+				continue
+			}
+			if _, ok := searchable[ref.repoCommitPathPoint.RepoCommitPath]; !ok && ref.repoCommitPathPoint.RepoCommitPath != def.RepoCommitPath && ref.repoCommitPathPoint.RepoCommitPath != start.RepoCommitPath {
+				continue
+			}
+			// References that don't spell out the name (e.g. `this`) can't be found by name.
+			if nameAtPoint(t, squirrel, ref.repoCommitPathPoint) != name {
+				continue
+			}
+
+			want := types.ReferenceConfidenceScopeResolved
+			if contains(ref.tags, "nodef") {
+				want = types.ReferenceConfidenceNameOnly
+			}
+
+			confidence, ok := gotConfidence[ref.repoCommitPathPoint]
+			if !ok {
+				t.Errorf("missing reference to %s at %s:%d:%d", symbol, ref.repoCommitPathPoint.Path, ref.repoCommitPathPoint.Row, ref.repoCommitPathPoint.Column)
+				continue
+			}
+			if confidence != want {
+				t.Errorf("wrong confidence for the reference to %s at %s:%d:%d: want %s, got %s", symbol, ref.repoCommitPathPoint.Path, ref.repoCommitPathPoint.Row, ref.repoCommitPathPoint.Column, want, confidence)
+			}
+
+			testCount += 1
+		}
+	}
+
+	t.Logf("%d tests in total", testCount)
+}
+
+func nameAtPoint(t *testing.T, squirrel *SquirrelService, point types.RepoCommitPathPoint) string {
+	root, err := squirrel.parse(context.Background(), point.RepoCommitPath)
+	fatalIfErrorLabel(t, err, "parse")
+	p := sitter.Point{Row: uint32(point.Row), Column: uint32(point.Column)}
+	return root.NamedDescendantForPointRange(p, p).Content(root.Contents)
+}
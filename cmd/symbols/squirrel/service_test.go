@@ -23,77 +23,7 @@ func init() {
 }
 
 func TestNonLocalDefinition(t *testing.T) {
-	repoDirs, err := os.ReadDir("test_repos")
-	fatalIfErrorLabel(t, err, "reading test_repos")
-
-	annotations := []annotation{}
-
-	readFile := func(ctx context.Context, path types.RepoCommitPath) ([]byte, error) {
-		return os.ReadFile(filepath.Join("test_repos", path.Repo, path.Path))
-	}
-
-	tempSquirrel := New(readFile, nil)
-	allSymbols := []result.Symbol{}
-
-	for _, repoDir := range repoDirs {
-		if !repoDir.IsDir() {
-			t.Fatalf("unexpected file %s", repoDir.Name())
-		}
-
-		base := filepath.Join("test_repos", repoDir.Name())
-		err := filepath.Walk(base, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
-
-			if info.IsDir() {
-				return nil
-			}
-
-			contents, err := os.ReadFile(path)
-			fatalIfErrorLabel(t, err, "reading annotations from a file")
-
-			rel, err := filepath.Rel(base, path)
-			fatalIfErrorLabel(t, err, "getting relative path")
-			repoCommitPath := types.RepoCommitPath{Repo: repoDir.Name(), Commit: "abc", Path: rel}
-
-			annotations = append(annotations, collectAnnotations(repoCommitPath, string(contents))...)
-
-			symbols, err := tempSquirrel.getSymbols(context.Background(), repoCommitPath)
-			fatalIfErrorLabel(t, err, "getSymbols")
-			allSymbols = append(allSymbols, symbols...)
-
-			return nil
-		})
-		fatalIfErrorLabel(t, err, "walking a repo dir")
-	}
-
-	ss := func(ctx context.Context, args search.SymbolsParameters) (result.Symbols, error) {
-		results := result.Symbols{}
-	nextSymbol:
-		for _, s := range allSymbols {
-			if args.IncludePatterns != nil {
-				for _, p := range args.IncludePatterns {
-					match, err := regexp.MatchString(p, s.Path)
-					fatalIfErrorLabel(t, err, "matching a pattern")
-					if !match {
-						continue nextSymbol
-					}
-				}
-			}
-			match, err := regexp.MatchString(args.Query, s.Name)
-			if err != nil {
-				return nil, err
-			}
-			if match {
-				results = append(results, s)
-			}
-		}
-		return results, nil
-	}
-
-	squirrel := New(readFile, ss)
-	squirrel.errorOnParseFailure = true
+	squirrel, annotations := newTestReposSquirrel(t)
 	defer squirrel.Close()
 
 	cwd, err := os.Getwd()
@@ -221,6 +151,85 @@ func TestNonLocalDefinition(t *testing.T) {
 	t.Logf("%d tests in total", testCount)
 }
 
+// newTestReposSquirrel returns a SquirrelService that reads files from the repos in test_repos
+// and searches their top-level symbols, along with the annotations in those files. Remember to
+// close it.
+func newTestReposSquirrel(t *testing.T) (*SquirrelService, []annotation) {
+	repoDirs, err := os.ReadDir("test_repos")
+	fatalIfErrorLabel(t, err, "reading test_repos")
+
+	annotations := []annotation{}
+
+	readFile := func(ctx context.Context, path types.RepoCommitPath) ([]byte, error) {
+		return os.ReadFile(filepath.Join("test_repos", path.Repo, path.Path))
+	}
+
+	tempSquirrel := New(readFile, nil)
+	allSymbols := []result.Symbol{}
+
+	for _, repoDir := range repoDirs {
+		if !repoDir.IsDir() {
+			t.Fatalf("unexpected file %s", repoDir.Name())
+		}
+
+		base := filepath.Join("test_repos", repoDir.Name())
+		err := filepath.Walk(base, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if info.IsDir() {
+				return nil
+			}
+
+			contents, err := os.ReadFile(path)
+			fatalIfErrorLabel(t, err, "reading annotations from a file")
+
+			rel, err := filepath.Rel(base, path)
+			fatalIfErrorLabel(t, err, "getting relative path")
+			repoCommitPath := types.RepoCommitPath{Repo: repoDir.Name(), Commit: "abc", Path: rel}
+
+			annotations = append(annotations, collectAnnotations(repoCommitPath, string(contents))...)
+
+			symbols, err := tempSquirrel.getSymbols(context.Background(), repoCommitPath)
+			fatalIfErrorLabel(t, err, "getSymbols")
+			allSymbols = append(allSymbols, symbols...)
+
+			return nil
+		})
+		fatalIfErrorLabel(t, err, "walking a repo dir")
+	}
+
+	ss := func(ctx context.Context, args search.SymbolsParameters) (result.Symbols, error) {
+		results := result.Symbols{}
+	nextSymbol:
+		for _, s := range allSymbols {
+			if args.IncludePatterns != nil {
+				for _, p := range args.IncludePatterns {
+					match, err := regexp.MatchString(p, s.Path)
+					fatalIfErrorLabel(t, err, "matching a pattern")
+					if !match {
+						continue nextSymbol
+					}
+				}
+			}
+			match, err := regexp.MatchString(args.Query, s.Name)
+			if err != nil {
+				return nil, err
+			}
+			if match {
+				results = append(results, s)
+			}
+		}
+		return results, nil
+	}
+
+	squirrel := New(readFile, ss)
+	squirrel.errorOnParseFailure = true
+
+	return squirrel, annotations
+}
+
 func groupBySymbolAndTag(annotations []annotation) map[string]map[string][]annotation {
 	grouped := map[string]map[string][]annotation{}
 
@@ -0,0 +1,14 @@
+//go:build ignore
+
+package main
+
+import "fmt"
+
+var radii = []float64{1, 2} // < "radii" go.radii def
+
+func describe(shape any) { // < "describe" go.describe def < "shape" go.describe.shape def
+	switch v := shape.(type) { // < "v" go.describe.v def < "shape" go.describe.shape ref
+	case fmt.Stringer:
+		fmt.Println(v.String()) // < "v." go.describe.v ref
+	}
+}
@@ -0,0 +1,14 @@
+//go:build ignore
+
+package lib
+
+import "math"
+
+func (c *Circle) Area() float64 { // < "Area" go.Circle.Area def
+	return math.Pi * c.R * c.R // < "R" go.Circle.R ref
+}
+
+// Unit returns a circle with a radius of 1.
+func Unit() *Circle {
+	return NewCircle(1) // < "NewCircle" go.NewCircle ref
+}
@@ -0,0 +1,12 @@
+//go:build ignore
+
+package lib
+
+type Counter struct { // < "Counter" go.Counter def
+	count int // < "count" go.Counter.count def
+}
+
+func (c *Counter) Incr() int { // < "Incr" go.Counter.Incr def
+	c.count++      // < "count" go.Counter.count ref
+	return c.count // < "count" go.Counter.count ref
+}
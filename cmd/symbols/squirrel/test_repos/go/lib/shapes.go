@@ -0,0 +1,18 @@
+//go:build ignore
+
+package lib
+
+// Shape is anything that has an area.
+type Shape interface { // < "Shape" go.Shape def
+	Area() float64 // < "Area" go.Shape.Area def
+}
+
+// Circle is a round shape that counts how often it's used.
+type Circle struct { // < "Circle" go.Circle def
+	R        float64 // < "R" go.Circle.R def
+	*Counter         // < "Counter" go.Circle.Counter def
+}
+
+func NewCircle(r float64) *Circle { // < "NewCircle" go.NewCircle def < "r float" go.NewCircle.r def
+	return &Circle{R: r, Counter: &Counter{}} // < "R" go.Circle.R ref < "r," go.NewCircle.r ref < "Counter:" go.Circle.Counter ref < "Counter{" go.Counter ref
+}
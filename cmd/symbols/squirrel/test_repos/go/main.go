@@ -0,0 +1,33 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"example.com/geometry"
+
+	"github.com/sourcegraph/sourcegraph/cmd/symbols/squirrel/test_repos/go/lib" // < "github.com" lib path
+)
+
+func main() {
+	c := lib.NewCircle(2) // < "c" go.main.c def < "lib" lib path < "NewCircle" go.NewCircle ref
+
+	fmt.Println(c.R, c.Area()) // < "c" go.main.c ref < "R" go.Circle.R ref < "Area" go.Circle.Area ref
+
+	fmt.Println(c.Incr()) // < "Incr" go.Counter.Incr ref
+
+	var s lib.Shape = c // < "s" go.main.s def < "Shape" go.Shape ref
+
+	fmt.Println(s.Area()) // < "Area" go.Shape.Area ref
+
+	describe(s) // < "describe" go.describe ref
+
+	// The types of other modules are unknown.
+	other := geometry.Unit()
+	fmt.Println(other.Area()) // < "Area" go.Circle.Area ref,nodef
+
+	for i, r := range radii { // < "i" go.main.i def < "r :=" go.main.r def < "radii" go.radii ref
+		fmt.Println(i, r) // < "i," go.main.i ref < "r)" go.main.r ref
+	}
+}
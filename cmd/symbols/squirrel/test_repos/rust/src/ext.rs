@@ -0,0 +1,7 @@
+use crate::shapes::Circle; // < "Circle" rs.Circle ref
+
+impl Circle {
+    pub fn scale(&self, factor: f64) -> Circle { // < "scale" rs.Circle.scale def < "factor" rs.Circle.scale.factor def
+        Circle::new(self.r * factor) // < "new" rs.Circle.new ref < "r *" rs.Circle.r ref < "factor" rs.Circle.scale.factor ref
+    }
+}
@@ -0,0 +1,15 @@
+mod ext;
+mod shapes; // < "shapes" rs.shapes def
+mod util;
+
+use crate::shapes::{Area, Circle}; // < "shapes" rs.shapes ref < "Area" rs.Area ref < "Circle" rs.Circle ref
+use util::helper as help; // < "helper" rs.helper ref
+
+pub fn compute(radius: f64) -> f64 { // < "compute" rs.compute def < "radius" rs.compute.radius def
+    let circle = Circle::new(radius); // < "circle" rs.compute.circle def < "Circle" rs.Circle ref < "new" rs.Circle.new ref < "radius)" rs.compute.radius ref
+    let double = |x: f64| x * 2.0; // < "double" rs.compute.double def < "x:" rs.compute.x def < "x *" rs.compute.x ref
+    let bigger = circle.scale(2.0); // < "bigger" rs.compute.bigger def < "circle" rs.compute.circle ref < "scale" rs.Circle.scale ref
+    let text = bigger.describe(); // < "describe" rs.Area.describe ref
+    println!("{}", text);
+    double(circle.area()) + help(&bigger) + bigger.r // < "double" rs.compute.double ref < "area" rs.Circle.area ref < "help" rs.helper ref < "r " rs.Circle.r ref
+}
@@ -0,0 +1,23 @@
+pub trait Area { // < "Area" rs.Area def
+    fn area(&self) -> f64;
+
+    fn describe(&self) -> String { // < "describe" rs.Area.describe def
+        format!("area {}", self.area())
+    }
+}
+
+pub struct Circle { // < "Circle" rs.Circle def
+    pub r: f64, // < "r" rs.Circle.r def
+}
+
+impl Circle {
+    pub fn new(r: f64) -> Self { // < "new" rs.Circle.new def < "r:" rs.Circle.new.r def < "Self" rs.Circle ref
+        Circle { r } // < "r }" rs.Circle.new.r ref
+    }
+}
+
+impl Area for Circle { // < "Area" rs.Area ref < "Circle" rs.Circle ref
+    fn area(&self) -> f64 { // < "area" rs.Circle.area def
+        std::f64::consts::PI * self.r * self.r // < "r *" rs.Circle.r ref
+    }
+}
@@ -0,0 +1,5 @@
+use super::shapes::Circle; // < "shapes" rs.shapes ref < "Circle" rs.Circle ref
+
+pub fn helper(c: &Circle) -> f64 { // < "helper" rs.helper def < "c:" rs.helper.c def
+    c.r // < "c" rs.helper.c ref < "r" rs.Circle.r ref
+}
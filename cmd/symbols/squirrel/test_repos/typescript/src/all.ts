@@ -0,0 +1,4 @@
+export * from './shapes'
+export { Labeled as Named } from './labeled' // < "Labeled" ts.Labeled ref
+
+export const modules = ['shapes', 'labeled']
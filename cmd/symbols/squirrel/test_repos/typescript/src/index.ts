@@ -0,0 +1,21 @@
+import describe, { Circle as C } from './shapes' // < "describe" ts.describe ref < "Circle" ts.Circle ref
+import * as shapes from './shapes'
+import { unitCircle, Named } from './all' // < "unitCircle" ts.unitCircle ref < "Named" ts.Labeled ref
+
+const circle = new C(2) // < "circle" ts.circle def < "C(" ts.Circle ref
+
+console.log(circle.scale(2).area()) // < "circle" ts.circle ref < "scale" ts.Circle.scale ref < "area" ts.Circle.area ref
+
+console.log(unitCircle.r) // < "unitCircle" ts.unitCircle ref < "r)" ts.Circle.r ref
+
+const other = new shapes.Circle(3) // < "Circle" ts.Circle ref
+
+describe(other) // < "describe" ts.describe ref
+
+const named = new Named(1) // < "Named" ts.Labeled ref
+
+console.log(named.label, named.area()) // < "label" ts.Labeled.label ref < "area" ts.Circle.area ref
+
+for (const [i, s] of [circle, other].entries()) { // < "i" ts.i def < "s]" ts.s def
+    console.log(i, s.area()) // < "i," ts.i ref < "s." ts.s ref
+}
@@ -0,0 +1,5 @@
+import { Circle } from './shapes' // < "Circle" ts.Circle ref
+
+export class Labeled extends Circle { // < "Labeled" ts.Labeled def < "Circle" ts.Circle ref
+    label = 'circle' // < "label" ts.Labeled.label def
+}
@@ -0,0 +1,22 @@
+export interface Shape { // < "Shape" ts.Shape def
+    area(): number // < "area" ts.Shape.area def
+}
+
+export class Circle implements Shape { // < "Circle" ts.Circle def < "Shape" ts.Shape ref
+    constructor(public readonly r: number) {} // < "r:" ts.Circle.r def
+
+    area(): number { // < "area" ts.Circle.area def
+        return Math.PI * this.r * this.r // < "r *" ts.Circle.r ref
+    }
+
+    scale(factor: number): Circle { // < "scale" ts.Circle.scale def < "factor" ts.Circle.scale.factor def
+        return new Circle(this.r * factor) // < "Circle" ts.Circle ref < "factor" ts.Circle.scale.factor ref
+    }
+}
+
+export const unitCircle = new Circle(1) // < "unitCircle" ts.unitCircle def
+
+export default function describe(shape: Shape): string { // < "describe" ts.describe def < "shape" ts.describe.shape def
+    const { r } = shape as Circle // < "r" ts.describe.r def
+    return `${shape.area()} ${r}` // < "area" ts.Shape.area ref < "r}" ts.describe.r ref
+}
@@ -49,6 +49,16 @@ func getRoot(node *sitter.Node) *sitter.Node {
 	return top
 }
 
+// getAncestorOfType returns the closest ancestor of the node (or the node itself) with the given type.
+func getAncestorOfType(node *sitter.Node, ty string) *sitter.Node {
+	for cur := node; cur != nil; cur = cur.Parent() {
+		if cur.Type() == ty {
+			return cur
+		}
+	}
+	return nil
+}
+
 // isLessRange compares ranges.
 func isLessRange(a, b types.Range) bool {
 	if a.Row == b.Row {
@@ -403,6 +413,19 @@ func lazyNodeStringer(node **Node) func() fmt.Stringer {
 }
 
 func (s *SquirrelService) symbolSearchOne(ctx context.Context, repo string, commit string, include []string, ident string) (*Node, error) {
+	nodes, err := s.symbolSearchMany(ctx, repo, commit, include, ident, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+	return &nodes[0], nil
+}
+
+// symbolSearchMany returns the nodes of up to first symbols named ident. Callers use it when the
+// first result might not be the right one, e.g. a method with the same name on another type.
+func (s *SquirrelService) symbolSearchMany(ctx context.Context, repo string, commit string, include []string, ident string, first int) ([]Node, error) {
 	symbols, err := s.symbolSearch(ctx, search.SymbolsParameters{
 		Repo:            api.RepoName(repo),
 		CommitID:        api.CommitID(commit),
@@ -411,31 +434,30 @@ func (s *SquirrelService) symbolSearchOne(ctx context.Context, repo string, comm
 		IsCaseSensitive: true,
 		IncludePatterns: include,
 		ExcludePattern:  "",
-		First:           1,
+		First:           first,
 	})
 	if err != nil {
 		return nil, err
 	}
-	if len(symbols) == 0 {
-		return nil, nil
-	}
-	symbol := symbols[0]
-	file, err := s.parse(ctx, types.RepoCommitPath{
-		Repo:   repo,
-		Commit: commit,
-		Path:   symbol.Path,
-	})
-	if err != nil {
-		return nil, err
-	}
-	point := sitter.Point{
-		Row:    uint32(symbol.Line),
-		Column: uint32(symbol.Character),
-	}
-	symbolNode := file.NamedDescendantForPointRange(point, point)
-	if symbolNode == nil {
-		return nil, nil
+	nodes := []Node{}
+	for _, symbol := range symbols {
+		file, err := s.parse(ctx, types.RepoCommitPath{
+			Repo:   repo,
+			Commit: commit,
+			Path:   symbol.Path,
+		})
+		if err != nil {
+			return nil, err
+		}
+		point := sitter.Point{
+			Row:    uint32(symbol.Line),
+			Column: uint32(symbol.Character),
+		}
+		symbolNode := file.NamedDescendantForPointRange(point, point)
+		if symbolNode == nil {
+			continue
+		}
+		nodes = append(nodes, swapNode(*file, symbolNode))
 	}
-	ret := swapNode(*file, symbolNode)
-	return &ret, nil
+	return nodes, nil
 }
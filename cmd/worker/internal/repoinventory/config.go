@@ -0,0 +1,21 @@
+package repoinventory
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+)
+
+type config struct {
+	env.BaseConfig
+
+	Interval  time.Duration
+	BatchSize int
+}
+
+var ConfigInst = &config{}
+
+func (c *config) Load() {
+	c.Interval = c.GetInterval("REPO_INVENTORY_INDEXER_INTERVAL", "1m", "How frequently to check for repositories whose inventory is missing or outdated.")
+	c.BatchSize = c.GetInt("REPO_INVENTORY_INDEXER_BATCH_SIZE", "50", "The maximum number of repository inventories computed at each interval.")
+}
@@ -0,0 +1,101 @@
+package repoinventory
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// computeFunc computes the inventory of the default branch of a repository.
+type computeFunc func(ctx context.Context, repo types.MinimalRepo) (*types.RepoInventory, error)
+
+type indexer struct {
+	store     database.RepoInventoryStore
+	compute   computeFunc
+	batchSize int
+	logger    log.Logger
+}
+
+var _ goroutine.Handler = &indexer{}
+var _ goroutine.ErrorHandler = &indexer{}
+
+func (i *indexer) Handle(ctx context.Context) error {
+	repos, err := i.store.ListStale(ctx, i.batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, repo := range repos {
+		inv, err := i.compute(ctx, repo)
+		if err != nil {
+			// The repository is retried once it changes, so that a repository
+			// whose inventory cannot be computed does not block the others.
+			i.logger.Warn("failed to compute repository inventory", log.String("repo", string(repo.Name)), log.Error(err))
+			if err := i.store.MarkFailed(ctx, repo.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := i.store.Upsert(ctx, inv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *indexer) HandleError(err error) {
+	i.logger.Error("error computing repository inventories", log.Error(err))
+}
+
+// inventoryGetter is the subset of backend.ReposService used to compute
+// inventories.
+type inventoryGetter interface {
+	GetInventory(ctx context.Context, repo *types.Repo, commitID api.CommitID, forceEnhancedLanguageDetection bool) (*inventory.Inventory, error)
+}
+
+// newInventoryComputer returns a computeFunc that computes the inventory of the
+// commit HEAD points to.
+func newInventoryComputer(gsClient gitserver.Client, repos inventoryGetter) computeFunc {
+	return func(ctx context.Context, repo types.MinimalRepo) (*types.RepoInventory, error) {
+		// The inventory describes the whole repository, regardless of the
+		// sub-repository permissions of any user.
+		ctx = actor.WithInternalActor(ctx)
+
+		commitID, err := gsClient.ResolveRevision(ctx, repo.Name, "HEAD", gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
+		if err != nil {
+			return nil, err
+		}
+		inv, err := repos.GetInventory(ctx, repo.ToRepo(), commitID, false)
+		if err != nil {
+			return nil, err
+		}
+		return newRepoInventory(repo.ID, commitID, inv), nil
+	}
+}
+
+func newRepoInventory(repoID api.RepoID, commitID api.CommitID, inv *inventory.Inventory) *types.RepoInventory {
+	res := &types.RepoInventory{
+		RepoID:    repoID,
+		CommitID:  commitID,
+		Languages: make([]types.RepoLanguage, 0, len(inv.Languages)),
+	}
+	for _, l := range inv.Languages {
+		res.FileCount += int64(l.TotalFiles)
+		res.TotalBytes += int64(l.TotalBytes)
+		res.Languages = append(res.Languages, types.RepoLanguage{
+			Name:       l.Name,
+			TotalBytes: int64(l.TotalBytes),
+			TotalLines: int64(l.TotalLines),
+			TotalFiles: int64(l.TotalFiles),
+		})
+	}
+	return res
+}
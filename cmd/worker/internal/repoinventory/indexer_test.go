@@ -0,0 +1,83 @@
+package repoinventory
+
+import (
+	"context"
+	"testing"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestIndexer(t *testing.T) {
+	repos := []types.MinimalRepo{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+
+	newIndexer := func(store database.RepoInventoryStore, compute computeFunc) *indexer {
+		return &indexer{
+			store:     store,
+			compute:   compute,
+			batchSize: 10,
+			logger:    logtest.Scoped(t),
+		}
+	}
+
+	t.Run("stores inventories", func(t *testing.T) {
+		store := database.NewMockRepoInventoryStore()
+		store.ListStaleFunc.SetDefaultReturn(repos, nil)
+
+		compute := func(_ context.Context, repo types.MinimalRepo) (*types.RepoInventory, error) {
+			if repo.ID == 2 {
+				return nil, errors.New("empty repository")
+			}
+			return &types.RepoInventory{RepoID: repo.ID, CommitID: "deadbeef"}, nil
+		}
+
+		require.NoError(t, newIndexer(store, compute).Handle(context.Background()))
+		require.Equal(t, 10, store.ListStaleFunc.History()[0].Arg1)
+
+		// A failed repository does not prevent the others from being indexed
+		mockassert.CalledOnce(t, store.UpsertFunc)
+		require.Equal(t, api.RepoID(1), store.UpsertFunc.History()[0].Arg1.RepoID)
+		mockassert.CalledOnce(t, store.MarkFailedFunc)
+		require.Equal(t, api.RepoID(2), store.MarkFailedFunc.History()[0].Arg1)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		want := errors.New("error")
+		store := database.NewMockRepoInventoryStore()
+		store.ListStaleFunc.SetDefaultReturn(repos, nil)
+		store.UpsertFunc.SetDefaultReturn(want)
+
+		compute := func(_ context.Context, repo types.MinimalRepo) (*types.RepoInventory, error) {
+			return &types.RepoInventory{RepoID: repo.ID}, nil
+		}
+
+		err := newIndexer(store, compute).Handle(context.Background())
+		require.ErrorIs(t, err, want)
+		mockassert.CalledOnce(t, store.UpsertFunc)
+	})
+}
+
+func TestNewRepoInventory(t *testing.T) {
+	inv := &inventory.Inventory{Languages: []inventory.Lang{
+		{Name: "Go", TotalBytes: 300, TotalLines: 30, TotalFiles: 3},
+		{Name: "Markdown", TotalBytes: 100, TotalLines: 10, TotalFiles: 1},
+	}}
+
+	require.Equal(t, &types.RepoInventory{
+		RepoID:     1,
+		CommitID:   "deadbeef",
+		FileCount:  4,
+		TotalBytes: 400,
+		Languages: []types.RepoLanguage{
+			{Name: "Go", TotalBytes: 300, TotalLines: 30, TotalFiles: 3},
+			{Name: "Markdown", TotalBytes: 100, TotalLines: 10, TotalFiles: 1},
+		},
+	}, newRepoInventory(1, "deadbeef", inv))
+}
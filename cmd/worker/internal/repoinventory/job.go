@@ -0,0 +1,54 @@
+package repoinventory
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+// indexerJob is a worker responsible for keeping the language statistics and
+// file counts of repositories up to date, so that they can be used to filter
+// repositories in search queries.
+type indexerJob struct{}
+
+var _ job.Job = &indexerJob{}
+
+func NewIndexerJob() job.Job {
+	return &indexerJob{}
+}
+
+func (j *indexerJob) Description() string {
+	return ""
+}
+
+func (j *indexerJob) Config() []env.Config {
+	return []env.Config{
+		ConfigInst,
+	}
+}
+
+func (j *indexerJob) Routines(startupCtx context.Context, logger log.Logger) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDBWithLogger(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	logger = logger.Scoped("indexer", "computes the inventory of repositories")
+	gsClient := gitserver.NewClient(db)
+
+	return []goroutine.BackgroundRoutine{
+		goroutine.NewPeriodicGoroutine(context.Background(), ConfigInst.Interval, &indexer{
+			store:     db.RepoInventories(),
+			compute:   newInventoryComputer(gsClient, backend.NewRepos(logger, db, gsClient)),
+			batchSize: ConfigInst.BatchSize,
+			logger:    logger,
+		}),
+	}, nil
+}
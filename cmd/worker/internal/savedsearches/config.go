@@ -0,0 +1,23 @@
+package savedsearches
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+)
+
+type config struct {
+	env.BaseConfig
+
+	Interval   time.Duration
+	BatchSize  int
+	MaxResults int
+}
+
+var ConfigInst = &config{}
+
+func (c *config) Load() {
+	c.Interval = c.GetInterval("SAVED_SEARCHES_RUNNER_INTERVAL", "1m", "How frequently to check for scheduled saved searches that are due to run.")
+	c.BatchSize = c.GetInt("SAVED_SEARCHES_RUNNER_BATCH_SIZE", "10", "The maximum number of scheduled saved searches run at each interval.")
+	c.MaxResults = c.GetInt("SAVED_SEARCHES_MAX_RESULTS", "10000", "The maximum number of results stored per run of a scheduled saved search.")
+}
@@ -0,0 +1,49 @@
+package savedsearches
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+// runnerJob is a worker responsible for running scheduled saved searches and
+// recording how their results changed since the previous run.
+type runnerJob struct{}
+
+var _ job.Job = &runnerJob{}
+
+func NewRunnerJob() job.Job {
+	return &runnerJob{}
+}
+
+func (j *runnerJob) Description() string {
+	return ""
+}
+
+func (j *runnerJob) Config() []env.Config {
+	return []env.Config{
+		ConfigInst,
+	}
+}
+
+func (j *runnerJob) Routines(startupCtx context.Context, logger log.Logger) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDBWithLogger(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return []goroutine.BackgroundRoutine{
+		goroutine.NewPeriodicGoroutine(context.Background(), ConfigInst.Interval, &runner{
+			store:      db.SavedSearches(),
+			search:     streamSearch,
+			batchSize:  ConfigInst.BatchSize,
+			maxResults: ConfigInst.MaxResults,
+			logger:     logger.Scoped("runner", "runs scheduled saved searches"),
+		}),
+	}, nil
+}
@@ -0,0 +1,134 @@
+package savedsearches
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	streamapi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// resultCollector flattens the matches of a search into the results stored
+// for a saved search. A result is identified by a key that is stable across
+// runs, so that the results of two runs can be compared.
+type resultCollector struct {
+	results []*types.SavedSearchResult
+	seen    map[string]struct{}
+
+	// maxResults is the maximum number of results that are collected. Results
+	// beyond it are dropped and the run is considered incomplete.
+	maxResults int
+
+	// complete is false if the search did not search everything it should
+	// have, which means that results that were not returned may still exist.
+	complete bool
+}
+
+func newResultCollector(maxResults int) *resultCollector {
+	return &resultCollector{
+		seen:       make(map[string]struct{}),
+		maxResults: maxResults,
+		complete:   true,
+	}
+}
+
+func (c *resultCollector) add(r *types.SavedSearchResult, keyParts ...string) {
+	r.Key = resultKey(r.Type, r.RepoID, keyParts...)
+	if _, ok := c.seen[r.Key]; ok {
+		return
+	}
+	if len(c.results) >= c.maxResults {
+		c.complete = false
+		return
+	}
+	c.seen[r.Key] = struct{}{}
+	c.results = append(c.results, r)
+}
+
+func (c *resultCollector) onMatches(matches []streamhttp.EventMatch) {
+	for _, match := range matches {
+		switch v := match.(type) {
+		case *streamhttp.EventRepoMatch:
+			c.add(&types.SavedSearchResult{
+				Type:   types.SavedSearchResultTypeRepo,
+				RepoID: api.RepoID(v.RepositoryID),
+				Label:  v.Repository,
+			}, branch(v.Branches))
+
+		case *streamhttp.EventPathMatch:
+			c.add(&types.SavedSearchResult{
+				Type:   types.SavedSearchResultTypePath,
+				RepoID: api.RepoID(v.RepositoryID),
+				Path:   v.Path,
+				Label:  v.Path,
+			}, branch(v.Branches), v.Path)
+
+		case *streamhttp.EventContentMatch:
+			for _, lm := range v.LineMatches {
+				// Lines are identified by their content rather than their line
+				// number, so that a line that moves is not reported as a new
+				// result.
+				c.add(&types.SavedSearchResult{
+					Type:       types.SavedSearchResultTypeContent,
+					RepoID:     api.RepoID(v.RepositoryID),
+					Path:       v.Path,
+					LineNumber: lm.LineNumber + 1,
+					Label:      lm.Line,
+				}, branch(v.Branches), v.Path, strings.TrimSpace(lm.Line))
+			}
+
+		case *streamhttp.EventSymbolMatch:
+			for _, sym := range v.Symbols {
+				c.add(&types.SavedSearchResult{
+					Type:       types.SavedSearchResultTypeSymbol,
+					RepoID:     api.RepoID(v.RepositoryID),
+					Path:       v.Path,
+					LineNumber: sym.Line,
+					Label:      sym.Name,
+				}, branch(v.Branches), v.Path, sym.Kind, sym.ContainerName, sym.Name)
+			}
+
+		case *streamhttp.EventCommitMatch:
+			subject, _, _ := strings.Cut(v.Message, "\n")
+			c.add(&types.SavedSearchResult{
+				Type:   types.SavedSearchResultTypeCommit,
+				RepoID: api.RepoID(v.RepositoryID),
+				Label:  subject,
+			}, v.OID)
+		}
+	}
+}
+
+func (c *resultCollector) onProgress(p *streamapi.Progress) {
+	for _, skipped := range p.Skipped {
+		switch skipped.Reason {
+		case streamapi.ExcludedFork, streamapi.ExcludedArchive:
+			// Excluded repositories are excluded on every run.
+		default:
+			c.complete = false
+		}
+	}
+}
+
+func branch(branches []string) string {
+	if len(branches) == 0 {
+		return ""
+	}
+	return branches[0]
+}
+
+func resultKey(resultType types.SavedSearchResultType, repoID api.RepoID, parts ...string) string {
+	h := sha256.New()
+	h.Write([]byte(resultType))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.Itoa(int(repoID))))
+	for _, part := range parts {
+		h.Write([]byte{0})
+		h.Write([]byte(part))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
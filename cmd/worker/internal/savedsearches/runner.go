@@ -0,0 +1,115 @@
+package savedsearches
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// searchFunc runs a streaming search for query and calls decoder with the
+// events of the search.
+type searchFunc func(ctx context.Context, query string, decoder streamhttp.FrontendStreamDecoder) error
+
+type runner struct {
+	store      database.SavedSearchStore
+	search     searchFunc
+	batchSize  int
+	maxResults int
+	logger     log.Logger
+}
+
+var _ goroutine.Handler = &runner{}
+var _ goroutine.ErrorHandler = &runner{}
+
+func (r *runner) Handle(ctx context.Context) error {
+	savedSearches, err := r.store.ListDueForRun(ctx, r.batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, ss := range savedSearches {
+		if err := r.run(ctx, ss); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *runner) HandleError(err error) {
+	r.logger.Error("error running scheduled saved searches", log.Error(err))
+}
+
+// run runs a single saved search and records its results. Errors of the search
+// itself are recorded on the schedule of the saved search, only errors of the
+// store are returned.
+func (r *runner) run(ctx context.Context, ss *types.SavedSearch) error {
+	if ss.UserID == nil {
+		return errors.Errorf("saved search %d is not owned by a user", ss.ID)
+	}
+
+	// 🚨 SECURITY: The search is run as the owner of the saved search, so that
+	// only results the owner has access to are recorded.
+	userCtx := actor.WithActor(ctx, actor.FromUser(*ss.UserID))
+
+	results, complete, err := r.collect(userCtx, ss.Query)
+	if err != nil {
+		r.logger.Warn("scheduled saved search failed", log.Int32("savedSearchID", ss.ID), log.Error(err))
+		return r.store.RecordRunError(ctx, ss.ID, err.Error())
+	}
+	return r.store.RecordRunResults(ctx, ss.ID, results, complete)
+}
+
+func (r *runner) collect(ctx context.Context, query string) (_ []*types.SavedSearchResult, complete bool, err error) {
+	c := newResultCollector(r.maxResults)
+	var searchErr error
+	decoder := streamhttp.FrontendStreamDecoder{
+		OnMatches:  c.onMatches,
+		OnProgress: c.onProgress,
+		OnError: func(ee *streamhttp.EventError) {
+			searchErr = errors.Append(searchErr, errors.New(ee.Message))
+		},
+	}
+	if err := r.search(ctx, query, decoder); err != nil {
+		return nil, false, err
+	}
+	if searchErr != nil {
+		return nil, false, searchErr
+	}
+	return c.results, c.complete, nil
+}
+
+const searchUserAgent = "saved-searches-runner"
+
+// streamSearch runs query against the streaming search API of the frontend
+// with the actor of ctx.
+func streamSearch(ctx context.Context, query string, decoder streamhttp.FrontendStreamDecoder) error {
+	req, err := streamhttp.NewRequest(internalapi.Client.URL+"/.internal", query)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", searchUserAgent)
+
+	resp, err := httpcli.InternalClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("search failed with status %d: %s", resp.StatusCode, body)
+	}
+	return decoder.ReadAll(resp.Body)
+}
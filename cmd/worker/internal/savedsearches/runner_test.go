@@ -0,0 +1,132 @@
+package savedsearches
+
+import (
+	"context"
+	"testing"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	streamapi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestRunner(t *testing.T) {
+	userID := int32(42)
+	savedSearch := &types.SavedSearch{ID: 1, Query: "foo", UserID: &userID}
+
+	newRunner := func(store database.SavedSearchStore, search searchFunc) *runner {
+		return &runner{
+			store:      store,
+			search:     search,
+			batchSize:  10,
+			maxResults: 3,
+			logger:     logtest.Scoped(t),
+		}
+	}
+
+	t.Run("records results", func(t *testing.T) {
+		store := database.NewMockSavedSearchStore()
+		store.ListDueForRunFunc.SetDefaultReturn([]*types.SavedSearch{savedSearch}, nil)
+
+		search := func(ctx context.Context, query string, decoder streamhttp.FrontendStreamDecoder) error {
+			require.Equal(t, userID, actor.FromContext(ctx).UID)
+			require.Equal(t, "foo", query)
+			decoder.OnMatches([]streamhttp.EventMatch{
+				&streamhttp.EventRepoMatch{RepositoryID: 1, Repository: "a"},
+				&streamhttp.EventContentMatch{RepositoryID: 1, Repository: "a", Path: "a.go", LineMatches: []streamhttp.EventLineMatch{
+					{Line: "foo()", LineNumber: 9},
+					{Line: "\tfoo()", LineNumber: 20},
+				}},
+			})
+			decoder.OnProgress(&streamapi.Progress{Skipped: []streamapi.Skipped{{Reason: streamapi.ExcludedFork}}})
+			return nil
+		}
+
+		require.NoError(t, newRunner(store, search).Handle(context.Background()))
+		mockassert.CalledOnce(t, store.RecordRunResultsFunc)
+		mockassert.NotCalled(t, store.RecordRunErrorFunc)
+
+		call := store.RecordRunResultsFunc.History()[0]
+		require.Equal(t, int32(1), call.Arg1)
+		require.True(t, call.Arg3)
+
+		// Lines that only differ in indentation are the same result
+		results := call.Arg2
+		require.Len(t, results, 2)
+		require.Equal(t, types.SavedSearchResultTypeRepo, results[0].Type)
+		require.Equal(t, types.SavedSearchResultTypeContent, results[1].Type)
+		require.Equal(t, int32(10), results[1].LineNumber)
+		require.Equal(t, "foo()", results[1].Label)
+	})
+
+	t.Run("incomplete results", func(t *testing.T) {
+		for name, search := range map[string]searchFunc{
+			"skipped": func(_ context.Context, _ string, decoder streamhttp.FrontendStreamDecoder) error {
+				decoder.OnMatches([]streamhttp.EventMatch{&streamhttp.EventPathMatch{RepositoryID: 1, Path: "a.go"}})
+				decoder.OnProgress(&streamapi.Progress{Skipped: []streamapi.Skipped{{Reason: streamapi.ShardMatchLimit}}})
+				return nil
+			},
+			"too many results": func(_ context.Context, _ string, decoder streamhttp.FrontendStreamDecoder) error {
+				decoder.OnMatches([]streamhttp.EventMatch{
+					&streamhttp.EventPathMatch{RepositoryID: 1, Path: "a.go"},
+					&streamhttp.EventPathMatch{RepositoryID: 1, Path: "b.go"},
+					&streamhttp.EventPathMatch{RepositoryID: 1, Path: "c.go"},
+					&streamhttp.EventPathMatch{RepositoryID: 1, Path: "d.go"},
+				})
+				return nil
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				store := database.NewMockSavedSearchStore()
+				store.ListDueForRunFunc.SetDefaultReturn([]*types.SavedSearch{savedSearch}, nil)
+
+				require.NoError(t, newRunner(store, search).Handle(context.Background()))
+				mockassert.CalledOnce(t, store.RecordRunResultsFunc)
+				require.False(t, store.RecordRunResultsFunc.History()[0].Arg3)
+			})
+		}
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		store := database.NewMockSavedSearchStore()
+		store.ListDueForRunFunc.SetDefaultReturn([]*types.SavedSearch{savedSearch}, nil)
+
+		search := func(_ context.Context, _ string, decoder streamhttp.FrontendStreamDecoder) error {
+			decoder.OnError(&streamhttp.EventError{Message: "invalid query"})
+			return nil
+		}
+
+		require.NoError(t, newRunner(store, search).Handle(context.Background()))
+		mockassert.NotCalled(t, store.RecordRunResultsFunc)
+		mockassert.CalledOnce(t, store.RecordRunErrorFunc)
+		require.Equal(t, "invalid query", store.RecordRunErrorFunc.History()[0].Arg2)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		want := errors.New("error")
+		store := database.NewMockSavedSearchStore()
+		store.ListDueForRunFunc.SetDefaultReturn(nil, want)
+
+		err := newRunner(store, nil).Handle(context.Background())
+		require.ErrorIs(t, err, want)
+	})
+}
+
+func TestResultKey(t *testing.T) {
+	// Keys of different types never collide
+	require.NotEqual(t,
+		resultKey(types.SavedSearchResultTypePath, 1, "", "a.go"),
+		resultKey(types.SavedSearchResultTypeContent, 1, "", "a.go"),
+	)
+	// Parts are separated
+	require.NotEqual(t,
+		resultKey(types.SavedSearchResultTypePath, 1, "ab", "c"),
+		resultKey(types.SavedSearchResultTypePath, 1, "a", "bc"),
+	)
+}
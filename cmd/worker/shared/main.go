@@ -16,7 +16,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/encryption"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/gitserver"
 	workermigrations "github.com/sourcegraph/sourcegraph/cmd/worker/internal/migrations"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/repoinventory"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/repostatistics"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/savedsearches"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/webhooks"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -47,6 +49,8 @@ func Start(logger log.Logger, additionalJobs map[string]job.Job, registerEnterpr
 		"gitserver-metrics":                     gitserver.NewMetricsJob(),
 		"record-encrypter":                      encryption.NewRecordEncrypterJob(),
 		"repo-statistics-compactor":             repostatistics.NewCompactor(),
+		"repo-inventory-indexer":                repoinventory.NewIndexerJob(),
+		"saved-searches-runner":                 savedsearches.NewRunnerJob(),
 	}
 
 	jobs := map[string]job.Job{}
@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"context"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ImageCache keeps track of the docker images used by jobs running on the host, keeps a
+// warm pool of images pulled ahead of the jobs that need them, and removes the least
+// recently used images when they take up too much space.
+//
+// Only images used since the executor started are tracked, so images pulled by a previous
+// executor process on the same host are never removed.
+type ImageCache struct {
+	warm    []string
+	metrics *metrics.CacheMetrics
+
+	// docker runs the docker CLI with the given arguments and returns its output. It is
+	// replaced in tests.
+	docker func(ctx context.Context, args ...string) (string, error)
+
+	mu       sync.Mutex
+	lastUsed map[string]time.Time
+}
+
+// NewImageCache creates an image cache that keeps the given images pulled.
+func NewImageCache(warm []string, metrics *metrics.CacheMetrics) *ImageCache {
+	return &ImageCache{
+		warm:     warm,
+		metrics:  metrics,
+		docker:   runDocker,
+		lastUsed: map[string]time.Time{},
+	}
+}
+
+func runDocker(ctx context.Context, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, "docker", args...).CombinedOutput()
+	if err != nil {
+		return "", errors.Wrapf(err, "docker %s: %s", strings.Join(args, " "), out)
+	}
+	return string(out), nil
+}
+
+// Use records that a job is about to run a container with the given image.
+func (c *ImageCache) Use(ctx context.Context, image string) {
+	if _, ok := c.size(ctx, image); ok {
+		c.metrics.Hits.Inc()
+	} else {
+		c.metrics.Misses.Inc()
+	}
+
+	c.mu.Lock()
+	c.lastUsed[image] = time.Now()
+	c.mu.Unlock()
+}
+
+// Warm pulls the images of the warm pool that are not present on the host.
+func (c *ImageCache) Warm(ctx context.Context) (err error) {
+	for _, image := range c.warm {
+		if _, ok := c.size(ctx, image); ok {
+			continue
+		}
+
+		log15.Info("Pulling image into the warm pool", "image", image)
+		if _, pullErr := c.docker(ctx, "pull", "--quiet", image); pullErr != nil {
+			err = errors.Append(err, pullErr)
+		}
+	}
+
+	return err
+}
+
+// Evict removes the least recently used images until the images used by jobs take up
+// less than maxCacheSizeBytes. Images of the warm pool are never removed, and images
+// used by a running container can't be removed. Layers shared by several images are
+// counted once per image, so the size of the cache is overestimated.
+func (c *ImageCache) Evict(ctx context.Context, maxCacheSizeBytes int64) (stats EvictStats, err error) {
+	c.mu.Lock()
+	images := make([]string, 0, len(c.lastUsed))
+	lastUsed := make(map[string]time.Time, len(c.lastUsed))
+	for image, t := range c.lastUsed {
+		images = append(images, image)
+		lastUsed[image] = t
+	}
+	c.mu.Unlock()
+
+	warm := make(map[string]struct{}, len(c.warm))
+	for _, image := range c.warm {
+		warm[image] = struct{}{}
+	}
+
+	sizes := make(map[string]int64, len(images))
+	for _, image := range images {
+		size, ok := c.size(ctx, image)
+		if !ok {
+			// The image was removed from the host by someone else.
+			c.forget(image, lastUsed[image])
+			continue
+		}
+		sizes[image] = size
+		stats.CacheSize += size
+	}
+
+	size := stats.CacheSize
+	defer func() { c.metrics.SizeBytes.Set(float64(size)) }()
+
+	// Nothing to evict
+	if size <= maxCacheSizeBytes {
+		return stats, nil
+	}
+
+	// Keep removing images until we are under the cache size. Remove the
+	// least recently used first.
+	sort.Slice(images, func(i, j int) bool {
+		return lastUsed[images[i]].Before(lastUsed[images[j]])
+	})
+	for _, image := range images {
+		if size <= maxCacheSizeBytes {
+			break
+		}
+		if _, ok := warm[image]; ok {
+			continue
+		}
+		if _, ok := sizes[image]; !ok {
+			continue
+		}
+
+		if _, err := c.docker(ctx, "image", "rm", image); err != nil {
+			log15.Warn("Failed to remove image", "image", image, "error", err)
+			continue
+		}
+
+		c.forget(image, lastUsed[image])
+		c.metrics.Evictions.Inc()
+		stats.Evicted++
+		size -= sizes[image]
+	}
+
+	return stats, nil
+}
+
+// forget stops tracking the given image, unless it has been used again since it was
+// last used at the given time.
+func (c *ImageCache) forget(image string, lastUsed time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.lastUsed[image].Equal(lastUsed) {
+		delete(c.lastUsed, image)
+	}
+}
+
+// size returns the size of the given image, and false if it is not present on the host.
+func (c *ImageCache) size(ctx context.Context, image string) (int64, bool) {
+	out, err := c.docker(ctx, "image", "inspect", "--format", "{{.Size}}", image)
+	if err != nil {
+		return 0, false
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return size, true
+}
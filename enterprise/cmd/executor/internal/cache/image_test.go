@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// fakeDocker emulates the docker commands used by the image cache for a set of images
+// present on the host, identified by their size.
+type fakeDocker struct {
+	images   map[string]int64
+	commands []string
+}
+
+func (d *fakeDocker) run(_ context.Context, args ...string) (string, error) {
+	image := args[len(args)-1]
+
+	switch strings.Join(args[:len(args)-1], " ") {
+	case "image inspect --format {{.Size}}":
+		size, ok := d.images[image]
+		if !ok {
+			return "", errors.Newf("no such image: %s", image)
+		}
+		return strconv.FormatInt(size, 10) + "\n", nil
+
+	case "pull --quiet":
+		d.images[image] = 100
+
+	case "image rm":
+		delete(d.images, image)
+	}
+
+	d.commands = append(d.commands, strings.Join(args, " "))
+	return "", nil
+}
+
+func TestImageCache(t *testing.T) {
+	docker := &fakeDocker{images: map[string]int64{
+		"alpine:3":    100,
+		"golang:1.19": 1000,
+		"node:18":     1000,
+	}}
+	cache := NewImageCache([]string{"alpine:3", "sourcegraph/lsif-go:latest"}, metrics.NewCacheMetrics(prometheus.NewRegistry(), "image"))
+	cache.docker = docker.run
+
+	ctx := context.Background()
+	if err := cache.Warm(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, image := range []string{"golang:1.19", "alpine:3", "node:18", "sourcegraph/lsif-go:latest", "ubuntu:22.04"} {
+		cache.Use(ctx, image)
+	}
+
+	stats, err := cache.Evict(ctx, 1500)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The images of the warm pool are kept even though they were used before node:18,
+	// and ubuntu:22.04 was never pulled.
+	if stats.CacheSize != 2200 || stats.Evicted != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	expectedCommands := []string{
+		"pull --quiet sourcegraph/lsif-go:latest",
+		"image rm golang:1.19",
+	}
+	if diff := cmp.Diff(expectedCommands, docker.commands); diff != "" {
+		t.Errorf("unexpected commands (-want +got):\n%s", diff)
+	}
+}
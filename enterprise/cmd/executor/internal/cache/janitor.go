@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type janitor struct {
+	repositories       *RepositoryCache
+	maxRepositoryBytes int64
+	images             *ImageCache
+	maxImageBytes      int64
+}
+
+var _ goroutine.Handler = &janitor{}
+var _ goroutine.ErrorHandler = &janitor{}
+
+// NewJanitor returns a background routine that periodically pulls the images of the warm
+// pool and evicts entries from the given caches until they are smaller than the given
+// sizes. Either cache may be nil, and a maximum image cache size of zero disables the
+// eviction of images.
+func NewJanitor(
+	repositories *RepositoryCache,
+	maxRepositoryBytes int64,
+	images *ImageCache,
+	maxImageBytes int64,
+	interval time.Duration,
+) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), interval, &janitor{
+		repositories:       repositories,
+		maxRepositoryBytes: maxRepositoryBytes,
+		images:             images,
+		maxImageBytes:      maxImageBytes,
+	})
+}
+
+func (j *janitor) Handle(ctx context.Context) (err error) {
+	if j.repositories != nil {
+		stats, evictErr := j.repositories.Evict(j.maxRepositoryBytes)
+		if evictErr != nil {
+			err = errors.Append(err, errors.Wrap(evictErr, "evicting repositories"))
+		} else if stats.Evicted > 0 {
+			log15.Info("Evicted repository mirrors", "evicted", stats.Evicted, "cacheSize", stats.CacheSize)
+		}
+	}
+
+	if j.images != nil {
+		if warmErr := j.images.Warm(ctx); warmErr != nil {
+			err = errors.Append(err, errors.Wrap(warmErr, "pulling warm images"))
+		}
+
+		if j.maxImageBytes > 0 {
+			stats, evictErr := j.images.Evict(ctx, j.maxImageBytes)
+			if evictErr != nil {
+				err = errors.Append(err, errors.Wrap(evictErr, "evicting images"))
+			} else if stats.Evicted > 0 {
+				log15.Info("Evicted images", "evicted", stats.Evicted, "cacheSize", stats.CacheSize)
+			}
+		}
+	}
+
+	return err
+}
+
+func (j *janitor) HandleError(err error) {
+	log15.Error("Failed to maintain executor caches", "error", err)
+}
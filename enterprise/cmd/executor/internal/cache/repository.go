@@ -0,0 +1,187 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// RepositoryCache keeps a bare mirror of each repository cloned by the executor, so that
+// subsequent jobs on the same repository only fetch the objects they don't have yet from
+// the Sourcegraph instance. Workspaces are cloned from the mirror, which makes them
+// self-contained so they can be mounted into containers and virtual machines.
+type RepositoryCache struct {
+	dir     string
+	metrics *metrics.CacheMetrics
+
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// EvictStats is information gathered during Evict.
+type EvictStats struct {
+	// CacheSize is the size of the cache before evicting.
+	CacheSize int64
+
+	// Evicted is the number of items evicted.
+	Evicted int
+}
+
+// NewRepositoryCache creates a repository cache that keeps its mirrors in the given directory.
+func NewRepositoryCache(dir string, metrics *metrics.CacheMetrics) *RepositoryCache {
+	return &RepositoryCache{
+		dir:     dir,
+		metrics: metrics,
+		locks:   map[string]*sync.Mutex{},
+	}
+}
+
+// Lock returns the directory of the mirror of the given repository, which may not exist
+// yet, and marks it as recently used. Shallow clones use a separate shallow mirror, as
+// cloning from a shallow mirror would make any clone shallow. Jobs on the same repository
+// update and clone from the mirror one at a time, and the mirror is not evicted until
+// unlock is called.
+func (c *RepositoryCache) Lock(repositoryName string, shallow bool) (dir string, unlock func()) {
+	key := mirrorKey(repositoryName, shallow)
+	lock := c.lock(key)
+	lock.Lock()
+
+	dir = filepath.Join(c.dir, key)
+	if _, err := os.Stat(filepath.Join(dir, "HEAD")); err == nil {
+		c.metrics.Hits.Inc()
+
+		now := time.Now()
+		_ = os.Chtimes(dir, now, now)
+	} else {
+		c.metrics.Misses.Inc()
+	}
+
+	return dir, lock.Unlock
+}
+
+func (c *RepositoryCache) lock(key string) *sync.Mutex {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lock, ok := c.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		c.locks[key] = lock
+	}
+	return lock
+}
+
+// mirrorKey returns the name of the mirror directory of the given repository.
+func mirrorKey(repositoryName string, shallow bool) string {
+	sum := sha256.Sum256([]byte(repositoryName))
+	if shallow {
+		return hex.EncodeToString(sum[:]) + "-shallow.git"
+	}
+	return hex.EncodeToString(sum[:]) + ".git"
+}
+
+// Evict removes the least recently used mirrors until the cache is smaller than
+// maxCacheSizeBytes. Mirrors that are in use by a job are skipped.
+func (c *RepositoryCache) Evict(maxCacheSizeBytes int64) (stats EvictStats, err error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return stats, nil
+		}
+		return stats, errors.Wrapf(err, "failed to ReadDir %s", c.dir)
+	}
+
+	type mirror struct {
+		key     string
+		size    int64
+		modTime time.Time
+	}
+	mirrors := make([]mirror, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			// The mirror was removed after listing the directory.
+			continue
+		}
+		size, err := dirSize(filepath.Join(c.dir, entry.Name()))
+		if err != nil {
+			return stats, err
+		}
+		mirrors = append(mirrors, mirror{key: entry.Name(), size: size, modTime: info.ModTime()})
+		stats.CacheSize += size
+	}
+
+	size := stats.CacheSize
+	defer func() { c.metrics.SizeBytes.Set(float64(size)) }()
+
+	// Nothing to evict
+	if size <= maxCacheSizeBytes {
+		return stats, nil
+	}
+
+	// Keep removing mirrors until we are under the cache size. Remove the
+	// least recently used first.
+	sort.Slice(mirrors, func(i, j int) bool {
+		return mirrors[i].modTime.Before(mirrors[j].modTime)
+	})
+	for _, m := range mirrors {
+		if size <= maxCacheSizeBytes {
+			break
+		}
+
+		lock := c.lock(m.key)
+		if !lock.TryLock() {
+			continue
+		}
+		err := os.RemoveAll(filepath.Join(c.dir, m.key))
+		lock.Unlock()
+		if err != nil {
+			log15.Error("Failed to remove repository mirror", "key", m.key, "error", err)
+			continue
+		}
+
+		c.metrics.Evictions.Inc()
+		stats.Evicted++
+		size -= m.size
+	}
+
+	return stats, nil
+}
+
+func dirSize(dir string) (size int64, err error) {
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				// We can race with git writing and renaming temporary files.
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, errors.Wrapf(err, "failed to compute size of %s", dir)
+}
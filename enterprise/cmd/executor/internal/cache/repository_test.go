@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/metrics"
+)
+
+func TestRepositoryCacheLock(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewRepositoryCache(dir, metrics.NewCacheMetrics(prometheus.NewRegistry(), "repository"))
+
+	mirrorDir, unlock := cache.Lock("github.com/sourcegraph/sourcegraph", false)
+	unlock()
+	shallowMirrorDir, unlock := cache.Lock("github.com/sourcegraph/sourcegraph", true)
+	unlock()
+	otherMirrorDir, unlock := cache.Lock("github.com/sourcegraph/src-cli", false)
+	unlock()
+
+	if filepath.Dir(mirrorDir) != dir {
+		t.Errorf("expected mirror in the cache directory, got %q", mirrorDir)
+	}
+	if mirrorDir == shallowMirrorDir || mirrorDir == otherMirrorDir {
+		t.Errorf("expected distinct mirrors, got %q, %q and %q", mirrorDir, shallowMirrorDir, otherMirrorDir)
+	}
+	if again, unlock := cache.Lock("github.com/sourcegraph/sourcegraph", false); again != mirrorDir {
+		t.Errorf("expected the same mirror, got %q and %q", mirrorDir, again)
+	} else {
+		unlock()
+	}
+}
+
+func TestRepositoryCacheEvict(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewRepositoryCache(dir, metrics.NewCacheMetrics(prometheus.NewRegistry(), "repository"))
+
+	now := time.Now()
+	mirrors := map[string]time.Time{
+		"a": now.Add(-3 * time.Hour),
+		"b": now.Add(-2 * time.Hour),
+		"c": now.Add(-1 * time.Hour),
+		"d": now,
+	}
+	dirs := map[string]string{}
+	for name, modTime := range mirrors {
+		mirrorDir, unlock := cache.Lock(name, false)
+		unlock()
+		dirs[name] = mirrorDir
+
+		if err := os.MkdirAll(filepath.Join(mirrorDir, "objects"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(mirrorDir, "objects", "pack"), make([]byte, 100), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(mirrorDir, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A mirror in use by a job is never evicted.
+	_, unlock := cache.Lock("a", false)
+	stats, err := cache.Evict(250)
+	unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.CacheSize != 400 || stats.Evicted != 2 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	for name, wantExists := range map[string]bool{"a": true, "b": false, "c": false, "d": true} {
+		if _, err := os.Stat(dirs[name]); (err == nil) != wantExists {
+			t.Errorf("unexpected existence of mirror %s: want %v, got error %v", name, wantExists, err)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/inconshreveable/log15"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	kubernetesContainerDir  = "/data"
+	kubernetesVolumeName    = "sg-executor-workspace"
+	kubernetesContainerName = "step"
+
+	// kubernetesExecutorLabel is set on every job created by an executor to the name of the
+	// executor job, so that jobs left behind by a canceled step can be found and deleted.
+	kubernetesExecutorLabel  = "sourcegraph.com/executor-name"
+	kubernetesManagedByLabel = "app.kubernetes.io/managed-by"
+)
+
+// NewKubernetesClientset creates a clientset for the cluster the executor runs in, or for
+// the cluster configured in the given kubeconfig file if a path is supplied.
+func NewKubernetesClientset(configPath string) (kubernetes.Interface, error) {
+	var restConfig *rest.Config
+	var err error
+	if configPath == "" {
+		restConfig, err = rest.InClusterConfig()
+	} else {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", configPath)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "loading kubernetes config")
+	}
+
+	return kubernetes.NewForConfig(restConfig)
+}
+
+type kubernetesRunner struct {
+	name      string
+	dir       string
+	logger    Logger
+	options   Options
+	clientset kubernetes.Interface
+	// steps is the number of jobs created so far, used to give each job a unique name.
+	steps int
+}
+
+var _ Runner = &kubernetesRunner{}
+
+func (r *kubernetesRunner) Setup(ctx context.Context) error {
+	return nil
+}
+
+// Teardown deletes any job that was not cleaned up after its step, for example because
+// the context of the step was canceled.
+func (r *kubernetesRunner) Teardown(ctx context.Context) error {
+	background := metav1.DeletePropagationBackground
+	err := r.clientset.BatchV1().Jobs(r.options.KubernetesOptions.Namespace).DeleteCollection(
+		ctx,
+		metav1.DeleteOptions{PropagationPolicy: &background},
+		metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", kubernetesExecutorLabel, kubernetesName(r.name, ""))},
+	)
+	return errors.Wrap(err, "deleting kubernetes jobs")
+}
+
+func (r *kubernetesRunner) Run(ctx context.Context, spec CommandSpec) error {
+	// Commands without an image, such as src-cli steps, run in the executor container.
+	if spec.Image == "" {
+		return runCommand(ctx, formatRawOrDockerCommand(spec, r.dir, r.options), r.logger)
+	}
+
+	r.steps++
+	job, err := formatKubernetesJob(spec, kubernetesName(r.name, fmt.Sprintf("-%d", r.steps)), r.name, r.dir, r.options)
+	if err != nil {
+		return err
+	}
+	return runKubernetesJob(ctx, r.clientset, job, spec, r.options.KubernetesOptions, r.logger)
+}
+
+// formatKubernetesJob constructs a job that runs the script of the given spec in a single
+// pod. The workspace is mounted from the persistent volume claim shared with the executor,
+// and the resource options are applied as both the requests and the limits of the container.
+func formatKubernetesJob(spec CommandSpec, name, executorName, dir string, options Options) (*batchv1.Job, error) {
+	resources, err := kubernetesResources(options.ResourceOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var env []corev1.EnvVar
+	for _, e := range spec.Env {
+		elems := strings.SplitN(e, "=", 2)
+		if len(elems) != 2 {
+			return nil, errors.Newf("invalid environment variable %q", e)
+		}
+		env = append(env, corev1.EnvVar{Name: elems[0], Value: elems[1]})
+	}
+
+	var backoffLimit int32
+	labels := map[string]string{
+		kubernetesExecutorLabel:  kubernetesName(executorName, ""),
+		kubernetesManagedByLabel: "sourcegraph-executor",
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: batchv1.JobSpec{
+			// The step is run exactly once, like a docker container would be.
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					NodeSelector:  options.KubernetesOptions.NodeSelector,
+					Containers: []corev1.Container{{
+						Name:            kubernetesContainerName,
+						Image:           spec.Image,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Command:         []string{"/bin/sh", filepath.Join(kubernetesContainerDir, ScriptsPath, spec.ScriptPath)},
+						WorkingDir:      filepath.Join(kubernetesContainerDir, spec.Dir),
+						Env:             env,
+						Resources:       resources,
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      kubernetesVolumeName,
+							MountPath: kubernetesContainerDir,
+							// Workspaces are created at the root of the volume, see the
+							// similar use of DockerHostMountPath.
+							SubPath: filepath.Base(dir),
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: kubernetesVolumeName,
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: options.KubernetesOptions.PersistentVolumeClaimName,
+							},
+						},
+					}},
+				},
+			},
+		},
+	}, nil
+}
+
+func kubernetesResources(options ResourceOptions) (corev1.ResourceRequirements, error) {
+	resources := corev1.ResourceList{}
+	if options.NumCPUs != 0 {
+		resources[corev1.ResourceCPU] = *resource.NewQuantity(int64(options.NumCPUs), resource.DecimalSI)
+	}
+	if options.Memory != "0" && options.Memory != "" {
+		// Parse the memory the same way as the other runners, where 1G is 1GiB.
+		memory, err := datasize.ParseString(options.Memory)
+		if err != nil {
+			return corev1.ResourceRequirements{}, errors.Wrapf(err, "invalid memory %q", options.Memory)
+		}
+		resources[corev1.ResourceMemory] = *resource.NewQuantity(int64(memory.Bytes()), resource.BinarySI)
+	}
+	if len(resources) == 0 {
+		return corev1.ResourceRequirements{}, nil
+	}
+
+	return corev1.ResourceRequirements{Requests: resources, Limits: resources}, nil
+}
+
+var invalidKubernetesNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// kubernetesName returns a valid object name and label value made of the given prefix and
+// suffix. The prefix is truncated so that the suffix is always kept.
+func kubernetesName(prefix, suffix string) string {
+	const maxLength = 63
+
+	name := invalidKubernetesNameChars.ReplaceAllString(strings.ToLower(prefix), "-")
+	if len(name)+len(suffix) > maxLength {
+		name = name[:maxLength-len(suffix)]
+	}
+	return strings.Trim(name+suffix, "-")
+}
+
+// kubernetesWaitingErrors are the reasons a container is waiting that won't resolve by
+// themselves, for which we fail the step instead of waiting for the job deadline.
+var kubernetesWaitingErrors = map[string]struct{}{
+	"ErrImagePull":               {},
+	"ImagePullBackOff":           {},
+	"InvalidImageName":           {},
+	"CreateContainerConfigError": {},
+}
+
+// runKubernetesJob creates the given job, streams the output of its pod to the given logger
+// and waits for it to complete. The job is deleted once it completes.
+func runKubernetesJob(ctx context.Context, clientset kubernetes.Interface, job *batchv1.Job, spec CommandSpec, options KubernetesOptions, logger Logger) (err error) {
+	ctx, _, endObservation := spec.Operation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	container := job.Spec.Template.Spec.Containers[0]
+	logCommand := flatten("kubernetes", "job", job.Name, "--image", container.Image, "--", container.Command)
+	log15.Info(fmt.Sprintf("Running command: %s", strings.Join(logCommand, " ")))
+
+	handle := logger.Log(spec.Key, logCommand)
+	defer handle.Close()
+
+	jobs := clientset.BatchV1().Jobs(options.Namespace)
+	if _, err := jobs.Create(ctx, job, metav1.CreateOptions{}); err != nil {
+		handle.Finalize(1)
+		return errors.Wrap(err, "creating kubernetes job")
+	}
+	defer func() {
+		// Delete the job even if the step was canceled.
+		background := metav1.DeletePropagationBackground
+		if deleteErr := jobs.Delete(context.Background(), job.Name, metav1.DeleteOptions{PropagationPolicy: &background}); deleteErr != nil {
+			err = errors.Append(err, errors.Wrap(deleteErr, "deleting kubernetes job"))
+		}
+	}()
+
+	pod, err := waitForKubernetesPod(ctx, clientset, job.Name, options, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase != corev1.PodPending
+	})
+	if err != nil {
+		handle.Finalize(1)
+		return err
+	}
+
+	if err := streamKubernetesPodLogs(ctx, clientset, pod, options, handle); err != nil {
+		handle.Finalize(1)
+		return err
+	}
+
+	pod, err = waitForKubernetesPod(ctx, clientset, job.Name, options, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+	})
+	if err != nil {
+		handle.Finalize(1)
+		return err
+	}
+
+	exitCode := kubernetesExitCode(pod)
+	handle.Finalize(exitCode)
+	if exitCode != 0 {
+		return errors.New("command failed")
+	}
+	return nil
+}
+
+// waitForKubernetesPod polls the pod of the given job until the given condition holds.
+func waitForKubernetesPod(ctx context.Context, clientset kubernetes.Interface, jobName string, options KubernetesOptions, done func(pod *corev1.Pod) bool) (*corev1.Pod, error) {
+	ticker := time.NewTicker(options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		pods, err := clientset.CoreV1().Pods(options.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("job-name=%s", jobName),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "listing kubernetes pods")
+		}
+		if len(pods.Items) > 0 {
+			pod := &pods.Items[0]
+			if done(pod) {
+				return pod, nil
+			}
+			for _, status := range pod.Status.ContainerStatuses {
+				if waiting := status.State.Waiting; waiting != nil {
+					if _, ok := kubernetesWaitingErrors[waiting.Reason]; ok {
+						return nil, errors.Newf("kubernetes pod %s: %s: %s", pod.Name, waiting.Reason, waiting.Message)
+					}
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// streamKubernetesPodLogs writes the output of the pod to the given writer until the
+// container exits. Kubernetes doesn't separate the output streams of a container, so all
+// output is written as stdout.
+func streamKubernetesPodLogs(ctx context.Context, clientset kubernetes.Interface, pod *corev1.Pod, options KubernetesOptions, w io.Writer) error {
+	stream, err := clientset.CoreV1().Pods(options.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: kubernetesContainerName,
+		Follow:    true,
+	}).Stream(ctx)
+	if err != nil {
+		return errors.Wrap(err, "streaming kubernetes pod logs")
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	// Use the same buffer sizes as when reading the output of a local process.
+	scanner.Buffer(make([]byte, 4*1024), 100*1024*1024)
+	for scanner.Scan() {
+		if _, err := fmt.Fprintf(w, "stdout: %s\n", scanner.Text()); err != nil {
+			return err
+		}
+	}
+	return errors.Wrap(scanner.Err(), "reading kubernetes pod logs")
+}
+
+func kubernetesExitCode(pod *corev1.Pod) int {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == kubernetesContainerName && status.State.Terminated != nil {
+			return int(status.State.Terminated.ExitCode)
+		}
+	}
+	if pod.Status.Phase == corev1.PodSucceeded {
+		return 0
+	}
+	return 1
+}
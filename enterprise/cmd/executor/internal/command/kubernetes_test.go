@@ -0,0 +1,220 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestFormatKubernetesJob(t *testing.T) {
+	job, err := formatKubernetesJob(
+		CommandSpec{
+			Key:        "step.docker.0",
+			Image:      "alpine:latest",
+			ScriptPath: "myscript.sh",
+			Dir:        "subdir",
+			Env:        []string{`TEST=true`, `CONTAINS_EQUALS=a=b`},
+			Operation:  makeTestOperation(),
+		},
+		"executor-1234-1",
+		"executor-1234",
+		"/scratch/workspace-42-1234",
+		Options{
+			KubernetesOptions: KubernetesOptions{
+				Enabled:                   true,
+				Namespace:                 "executors",
+				PersistentVolumeClaimName: "executor-workspaces",
+				NodeSelector:              map[string]string{"pool": "executors"},
+			},
+			ResourceOptions: ResourceOptions{
+				NumCPUs: 4,
+				Memory:  "20G",
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("20Gi"),
+	}
+	expected := corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		NodeSelector:  map[string]string{"pool": "executors"},
+		Containers: []corev1.Container{{
+			Name:            "step",
+			Image:           "alpine:latest",
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{"/bin/sh", "/data/.sourcegraph-executor/myscript.sh"},
+			WorkingDir:      "/data/subdir",
+			Env: []corev1.EnvVar{
+				{Name: "TEST", Value: "true"},
+				{Name: "CONTAINS_EQUALS", Value: "a=b"},
+			},
+			Resources: corev1.ResourceRequirements{Requests: resources, Limits: resources},
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "sg-executor-workspace",
+				MountPath: "/data",
+				SubPath:   "workspace-42-1234",
+			}},
+		}},
+		Volumes: []corev1.Volume{{
+			Name: "sg-executor-workspace",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "executor-workspaces"},
+			},
+		}},
+	}
+	quantityComparer := cmp.Comparer(func(x, y resource.Quantity) bool { return x.Cmp(y) == 0 })
+	if diff := cmp.Diff(expected, job.Spec.Template.Spec, quantityComparer); diff != "" {
+		t.Errorf("unexpected pod spec (-want +got):\n%s", diff)
+	}
+	if job.Name != "executor-1234-1" {
+		t.Errorf("unexpected job name %q", job.Name)
+	}
+	if *job.Spec.BackoffLimit != 0 {
+		t.Errorf("unexpected backoff limit %d", *job.Spec.BackoffLimit)
+	}
+	if job.Labels[kubernetesExecutorLabel] != "executor-1234" {
+		t.Errorf("unexpected labels %v", job.Labels)
+	}
+}
+
+func TestKubernetesName(t *testing.T) {
+	for _, tc := range []struct {
+		prefix, suffix, want string
+	}{
+		{"executor-1234", "-1", "executor-1234-1"},
+		{"My_Executor.1234", "", "my-executor-1234"},
+		{strings.Repeat("a", 70), "-12", strings.Repeat("a", 60) + "-12"},
+	} {
+		if got := kubernetesName(tc.prefix, tc.suffix); got != tc.want {
+			t.Errorf("unexpected name for %q %q: want %q, got %q", tc.prefix, tc.suffix, tc.want, got)
+		}
+	}
+}
+
+func TestKubernetesRunner(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		exitCode int32
+		wantErr  bool
+	}{
+		{name: "success", exitCode: 0},
+		{name: "failure", exitCode: 1, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			// The fake clientset doesn't run a job controller, so create the pod of a job
+			// as soon as the job is created.
+			clientset.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				job := action.(k8stesting.CreateAction).GetObject().(*batchv1.Job)
+				phase := corev1.PodSucceeded
+				if tc.exitCode != 0 {
+					phase = corev1.PodFailed
+				}
+				err := clientset.Tracker().Add(&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      job.Name + "-abcde",
+						Namespace: "executors",
+						Labels:    map[string]string{"job-name": job.Name},
+					},
+					Status: corev1.PodStatus{
+						Phase: phase,
+						ContainerStatuses: []corev1.ContainerStatus{{
+							Name:  "step",
+							State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: tc.exitCode}},
+						}},
+					},
+				})
+				return false, nil, err
+			})
+
+			var logs bytes.Buffer
+			entry := NewMockLogEntry()
+			entry.WriteFunc.SetDefaultHook(logs.Write)
+			logger := NewMockLogger()
+			logger.LogFunc.SetDefaultReturn(entry)
+
+			runner := NewRunner("/scratch/workspace-42-1234", logger, Options{
+				ExecutorName: "executor-1234",
+				KubernetesOptions: KubernetesOptions{
+					Enabled:                   true,
+					Clientset:                 clientset,
+					Namespace:                 "executors",
+					PersistentVolumeClaimName: "executor-workspaces",
+					PollInterval:              time.Millisecond,
+				},
+			}, nil)
+
+			err := runner.Run(context.Background(), CommandSpec{
+				Key:        "step.docker.0",
+				Image:      "alpine:latest",
+				ScriptPath: "myscript.sh",
+				Operation:  makeTestOperation(),
+			})
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// The fake clientset returns a fixed body for logs.
+			if want := "stdout: fake logs\n"; logs.String() != want {
+				t.Errorf("unexpected logs: want %q, got %q", want, logs.String())
+			}
+			if history := entry.FinalizeFunc.History(); len(history) != 1 || history[0].Arg0 != int(tc.exitCode) {
+				t.Errorf("unexpected exit code: %v", history)
+			}
+
+			jobs, err := clientset.BatchV1().Jobs("executors").List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(jobs.Items) != 0 {
+				t.Errorf("expected job to be deleted, found %d jobs", len(jobs.Items))
+			}
+
+			var created bool
+			for _, action := range clientset.Actions() {
+				if action.Matches("create", "jobs") {
+					created = true
+					job := action.(k8stesting.CreateAction).GetObject().(*batchv1.Job)
+					if job.Name != "executor-1234-1" {
+						t.Errorf("unexpected job name %q", job.Name)
+					}
+				}
+			}
+			if !created {
+				t.Error("expected a job to be created")
+			}
+		})
+	}
+}
+
+func TestKubernetesRunnerRawCommand(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	runner := NewRunner(t.TempDir(), NewMockLogger(), Options{
+		KubernetesOptions: KubernetesOptions{Enabled: true, Clientset: clientset},
+	}, nil)
+
+	// Commands without an image run on the host, where only allowed binaries can be run.
+	err := runner.Run(context.Background(), CommandSpec{Command: []string{"ls"}, Operation: makeTestOperation()})
+	if err != ErrIllegalCommand {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clientset.Actions()) != 0 {
+		t.Errorf("expected no kubernetes requests, got %v", clientset.Actions())
+	}
+}
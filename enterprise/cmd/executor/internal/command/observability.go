@@ -18,6 +18,8 @@ type Operations struct {
 	SetupGitSparseCheckoutSet    *observation.Operation
 	SetupGitCheckout             *observation.Operation
 	SetupGitSetRemoteUrl         *observation.Operation
+	SetupGitCacheInit            *observation.Operation
+	SetupGitCacheFetch           *observation.Operation
 	SetupFirecrackerStart        *observation.Operation
 	SetupStartupScript           *observation.Operation
 	TeardownFirecrackerRemove    *observation.Operation
@@ -64,6 +66,8 @@ func NewOperations(observationContext *observation.Context) *Operations {
 		SetupGitSparseCheckoutSet:    op("setup.git.sparse-checkout-set"),
 		SetupGitCheckout:             op("setup.git.checkout"),
 		SetupGitSetRemoteUrl:         op("setup.git.set-remote"),
+		SetupGitCacheInit:            op("setup.git.cache-init"),
+		SetupGitCacheFetch:           op("setup.git.cache-fetch"),
 		SetupFirecrackerStart:        op("setup.firecracker.start"),
 		SetupStartupScript:           op("setup.startup-script"),
 		TeardownFirecrackerRemove:    op("teardown.firecracker.remove"),
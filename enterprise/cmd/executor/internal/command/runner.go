@@ -3,6 +3,9 @@ package command
 import (
 	"context"
 	"os"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -11,7 +14,7 @@ import (
 // Runner is the interface between an executor and the host on which commands
 // are invoked. Having this interface at this level allows us to use the same
 // code paths for local development (via shell + docker) as well as production
-// usage (via Firecracker).
+// usage (via Firecracker or Kubernetes).
 type Runner interface {
 	// Setup prepares the runner to invoke a series of commands.
 	Setup(ctx context.Context) error
@@ -43,6 +46,9 @@ type Options struct {
 	// FirecrackerOptions configures the behavior of Firecracker virtual machine creation.
 	FirecrackerOptions FirecrackerOptions
 
+	// KubernetesOptions configures the behavior of Kubernetes job creation.
+	KubernetesOptions KubernetesOptions
+
 	// ResourceOptions configures the resource limits of docker container and Firecracker
 	// virtual machines running on the executor.
 	ResourceOptions ResourceOptions
@@ -73,6 +79,28 @@ type FirecrackerOptions struct {
 	DockerRegistryMirrorURLs []string
 }
 
+type KubernetesOptions struct {
+	// Enabled determines if commands with an image will be run as Kubernetes jobs.
+	Enabled bool
+
+	// Clientset is the client used to create jobs and read their logs.
+	Clientset kubernetes.Interface
+
+	// Namespace is the namespace in which jobs are created.
+	Namespace string
+
+	// PersistentVolumeClaimName is the name of the persistent volume claim that is
+	// mounted as the workspace of each job. The same volume must be mounted in the
+	// executor, at the directory in which workspaces are created.
+	PersistentVolumeClaimName string
+
+	// NodeSelector is an optional node selector for the pods of jobs.
+	NodeSelector map[string]string
+
+	// PollInterval is the interval at which the status of a job is checked.
+	PollInterval time.Duration
+}
+
 type ResourceOptions struct {
 	// NumCPUs is the number of virtual CPUs a container or VM can use.
 	NumCPUs int
@@ -100,6 +128,19 @@ type ResourceOptions struct {
 
 // NewRunner creates a new runner with the given options.
 func NewRunner(dir string, logger Logger, options Options, operations *Operations) Runner {
+	if options.KubernetesOptions.Enabled {
+		if options.KubernetesOptions.PollInterval == 0 {
+			options.KubernetesOptions.PollInterval = time.Second
+		}
+		return &kubernetesRunner{
+			name:      options.ExecutorName,
+			dir:       dir,
+			logger:    logger,
+			options:   options,
+			clientset: options.KubernetesOptions.Clientset,
+		}
+	}
+
 	if !options.FirecrackerOptions.Enabled {
 		return &dockerRunner{dir: dir, logger: logger, options: options}
 	}
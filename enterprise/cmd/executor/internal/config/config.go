@@ -3,6 +3,7 @@ package config
 import (
 	"runtime"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/c2h5oh/datasize"
@@ -16,32 +17,41 @@ import (
 type Config struct {
 	env.BaseConfig
 
-	FrontendURL                   string
-	FrontendAuthorizationToken    string
-	QueueName                     string
-	QueuePollInterval             time.Duration
-	MaximumNumJobs                int
-	FirecrackerImage              string
-	FirecrackerKernelImage        string
-	FirecrackerSandboxImage       string
-	VMStartupScriptPath           string
-	VMPrefix                      string
-	KeepWorkspaces                bool
-	DockerHostMountPath           string
-	UseFirecracker                bool
-	JobNumCPUs                    int
-	JobMemory                     string
-	FirecrackerDiskSpace          string
-	FirecrackerBandwidthIngress   int
-	FirecrackerBandwidthEgress    int
-	MaximumRuntimePerJob          time.Duration
-	CleanupTaskInterval           time.Duration
-	NumTotalJobs                  int
-	MaxActiveTime                 time.Duration
-	NodeExporterURL               string
-	DockerRegistryNodeExporterURL string
-	WorkerHostname                string
-	DockerRegistryMirrorURL       string
+	FrontendURL                          string
+	FrontendAuthorizationToken           string
+	QueueName                            string
+	QueuePollInterval                    time.Duration
+	MaximumNumJobs                       int
+	FirecrackerImage                     string
+	FirecrackerKernelImage               string
+	FirecrackerSandboxImage              string
+	VMStartupScriptPath                  string
+	VMPrefix                             string
+	KeepWorkspaces                       bool
+	DockerHostMountPath                  string
+	UseFirecracker                       bool
+	UseKubernetes                        bool
+	KubernetesNamespace                  string
+	KubernetesPersistenceVolumeClaimName string
+	KubernetesNodeSelector               string
+	KubernetesConfigPath                 string
+	JobNumCPUs                           int
+	JobMemory                            string
+	FirecrackerDiskSpace                 string
+	FirecrackerBandwidthIngress          int
+	FirecrackerBandwidthEgress           int
+	MaximumRuntimePerJob                 time.Duration
+	CleanupTaskInterval                  time.Duration
+	NumTotalJobs                         int
+	MaxActiveTime                        time.Duration
+	NodeExporterURL                      string
+	DockerRegistryNodeExporterURL        string
+	WorkerHostname                       string
+	DockerRegistryMirrorURL              string
+	RepositoryCacheDir                   string
+	RepositoryCacheSize                  string
+	DockerWarmImages                     string
+	DockerImageCacheSize                 string
 }
 
 func (c *Config) Load() {
@@ -50,7 +60,12 @@ func (c *Config) Load() {
 	c.QueueName = c.Get("EXECUTOR_QUEUE_NAME", "", "The name of the queue to listen to.")
 	c.QueuePollInterval = c.GetInterval("EXECUTOR_QUEUE_POLL_INTERVAL", "1s", "Interval between dequeue requests.")
 	c.MaximumNumJobs = c.GetInt("EXECUTOR_MAXIMUM_NUM_JOBS", "1", "Number of virtual machines or containers that can be running at once.")
-	c.UseFirecracker = c.GetBool("EXECUTOR_USE_FIRECRACKER", strconv.FormatBool(runtime.GOOS == "linux"), "Whether to isolate commands in virtual machines. Requires ignite and firecracker. Linux hosts only.")
+	c.UseKubernetes = c.GetBool("EXECUTOR_USE_KUBERNETES", "false", "Whether to run commands as Kubernetes jobs. Requires the executor to run in a Kubernetes cluster, or EXECUTOR_KUBERNETES_CONFIG_PATH to be set.")
+	c.UseFirecracker = c.GetBool("EXECUTOR_USE_FIRECRACKER", strconv.FormatBool(runtime.GOOS == "linux" && !c.UseKubernetes), "Whether to isolate commands in virtual machines. Requires ignite and firecracker. Linux hosts only.")
+	c.KubernetesNamespace = c.Get("EXECUTOR_KUBERNETES_NAMESPACE", "default", "The namespace in which Kubernetes jobs are created.")
+	c.KubernetesPersistenceVolumeClaimName = c.GetOptional("EXECUTOR_KUBERNETES_PERSISTENCE_VOLUME_CLAIM_NAME", "The name of the persistent volume claim mounted as the workspace of Kubernetes jobs. The same volume must be mounted in the executor at TMPDIR.")
+	c.KubernetesNodeSelector = c.GetOptional("EXECUTOR_KUBERNETES_NODE_SELECTOR", "A comma separated list of key=value labels that the nodes running Kubernetes jobs must have.")
+	c.KubernetesConfigPath = c.GetOptional("EXECUTOR_KUBERNETES_CONFIG_PATH", "The path to a kubeconfig file, used instead of the in-cluster configuration.")
 	c.FirecrackerImage = c.Get("EXECUTOR_FIRECRACKER_IMAGE", DefaultFirecrackerImage, "The base image to use for virtual machines.")
 	c.FirecrackerKernelImage = c.Get("EXECUTOR_FIRECRACKER_KERNEL_IMAGE", DefaultFirecrackerKernelImage, "The base image containing the kernel binary to use for virtual machines.")
 	c.FirecrackerSandboxImage = c.Get("EXECUTOR_FIRECRACKER_SANDBOX_IMAGE", DefaultFirecrackerSandboxImage, "The OCI image for the ignite VM sandbox.")
@@ -71,6 +86,11 @@ func (c *Config) Load() {
 	c.MaxActiveTime = c.GetInterval("EXECUTOR_MAX_ACTIVE_TIME", "0", "The maximum time that can be spent by the worker dequeueing records to be handled.")
 	c.DockerRegistryMirrorURL = c.GetOptional("EXECUTOR_DOCKER_REGISTRY_MIRROR_URL", "The address of a docker registry mirror to use in firecracker VMs. Supports multiple values, separated with a comma.")
 
+	c.RepositoryCacheDir = c.GetOptional("EXECUTOR_REPOSITORY_CACHE_DIR", "A directory in which a mirror of each cloned repository is kept, to clone repositories of later jobs faster. Disabled when empty.")
+	c.RepositoryCacheSize = c.Get("EXECUTOR_REPOSITORY_CACHE_SIZE", "10G", "The maximum size of the repository cache. The least recently used repositories are removed first.")
+	c.DockerWarmImages = c.GetOptional("EXECUTOR_DOCKER_WARM_IMAGES", "A comma separated list of docker images to keep pulled on the host, so that jobs don't have to pull them. Not supported with Firecracker or Kubernetes.")
+	c.DockerImageCacheSize = c.Get("EXECUTOR_DOCKER_IMAGE_CACHE_SIZE", "0", "The maximum total size of the docker images used by jobs on the host. The least recently used images are removed first. A value of zero disables the removal of images. Not supported with Firecracker or Kubernetes.")
+
 	hn := hostname.Get()
 	// Be unique but also descriptive.
 	c.WorkerHostname = hn + "-" + uuid.New().String()
@@ -81,6 +101,33 @@ func (c *Config) Validate() error {
 		c.AddError(errors.New("EXECUTOR_QUEUE_NAME must be set to 'batches' or 'codeintel'"))
 	}
 
+	if c.UseKubernetes {
+		if c.UseFirecracker {
+			c.AddError(errors.New("EXECUTOR_USE_KUBERNETES and EXECUTOR_USE_FIRECRACKER cannot both be enabled"))
+		}
+		if c.KubernetesPersistenceVolumeClaimName == "" {
+			c.AddError(errors.New("EXECUTOR_KUBERNETES_PERSISTENCE_VOLUME_CLAIM_NAME must be set when EXECUTOR_USE_KUBERNETES is enabled"))
+		}
+		if _, err := c.KubernetesNodeSelectorLabels(); err != nil {
+			c.AddError(err)
+		}
+	}
+
+	if c.RepositoryCacheDir != "" {
+		if _, err := datasize.ParseString(c.RepositoryCacheSize); err != nil {
+			c.AddError(errors.Wrapf(err, "invalid size provided for EXECUTOR_REPOSITORY_CACHE_SIZE: %q", c.RepositoryCacheSize))
+		}
+	}
+
+	if c.DockerWarmImages != "" || c.DockerImageCacheSize != "0" {
+		if c.UseFirecracker || c.UseKubernetes {
+			c.AddError(errors.New("EXECUTOR_DOCKER_WARM_IMAGES and EXECUTOR_DOCKER_IMAGE_CACHE_SIZE are not supported with Firecracker or Kubernetes"))
+		}
+		if _, err := datasize.ParseString(c.DockerImageCacheSize); err != nil {
+			c.AddError(errors.Wrapf(err, "invalid size provided for EXECUTOR_DOCKER_IMAGE_CACHE_SIZE: %q", c.DockerImageCacheSize))
+		}
+	}
+
 	if c.UseFirecracker {
 		// Validate that firecracker can work on this host.
 		if runtime.GOOS != "linux" {
@@ -104,3 +151,31 @@ func (c *Config) Validate() error {
 
 	return c.BaseConfig.Validate()
 }
+
+// DockerWarmImageNames parses EXECUTOR_DOCKER_WARM_IMAGES into a list of images.
+func (c *Config) DockerWarmImageNames() []string {
+	var images []string
+	for _, image := range strings.Split(c.DockerWarmImages, ",") {
+		if image = strings.TrimSpace(image); image != "" {
+			images = append(images, image)
+		}
+	}
+	return images
+}
+
+// KubernetesNodeSelectorLabels parses EXECUTOR_KUBERNETES_NODE_SELECTOR into a set of labels.
+func (c *Config) KubernetesNodeSelectorLabels() (map[string]string, error) {
+	if c.KubernetesNodeSelector == "" {
+		return nil, nil
+	}
+
+	labels := map[string]string{}
+	for _, pair := range strings.Split(c.KubernetesNodeSelector, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, errors.Newf("invalid EXECUTOR_KUBERNETES_NODE_SELECTOR label %q, expected key=value", pair)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CacheMetrics are the metrics of a cache kept by the executor across jobs, such as
+// the repository or the docker image cache.
+type CacheMetrics struct {
+	Hits      prometheus.Counter
+	Misses    prometheus.Counter
+	Evictions prometheus.Counter
+	SizeBytes prometheus.Gauge
+}
+
+// NewCacheMetrics creates and registers the metrics of the cache with the given name,
+// which is attached to each metric as the cache label.
+func NewCacheMetrics(registerer prometheus.Registerer, cache string) *CacheMetrics {
+	labels := prometheus.Labels{"cache": cache}
+
+	counter := func(name, help string) prometheus.Counter {
+		counter := prometheus.NewCounter(prometheus.CounterOpts{
+			Name:        name,
+			Help:        help,
+			ConstLabels: labels,
+		})
+
+		registerer.MustRegister(counter)
+		return counter
+	}
+
+	sizeBytes := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:        "src_executor_cache_size_bytes",
+		Help:        "The size of the cache after the last eviction.",
+		ConstLabels: labels,
+	})
+	registerer.MustRegister(sizeBytes)
+
+	return &CacheMetrics{
+		Hits: counter(
+			"src_executor_cache_hits_total",
+			"The number of jobs that found their repository or image in the cache.",
+		),
+		Misses: counter(
+			"src_executor_cache_misses_total",
+			"The number of jobs that didn't find their repository or image in the cache.",
+		),
+		Evictions: counter(
+			"src_executor_cache_evictions_total",
+			"The number of entries evicted from the cache.",
+		),
+		SizeBytes: sizeBytes,
+	}
+}
@@ -39,16 +39,19 @@ func RunRun(cliCtx *cli.Context, logger log.Logger, cfg *config.Config) error {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		return newQueueTelemetryOptions(ctx, cfg.UseFirecracker, logger)
+		return newQueueTelemetryOptions(ctx, cfg.UseFirecracker, cfg.UseKubernetes, logger)
 	}()
 	logger.Info("Telemetry information gathered", log.String("info", fmt.Sprintf("%+v", queueTelemetryOptions)))
 
 	opts := apiWorkerOptions(cfg, queueTelemetryOptions)
+	repositoryCache, imageCache, cacheJanitor := newCaches(cfg, observationContext)
+	opts.RepositoryCache = repositoryCache
+	opts.ImageCache = imageCache
 
 	// TODO: This is too similar to the RunValidate func. Make it share even more code.
 	if cliCtx.Bool("verify") {
 		// Then, validate all tools that are required are installed.
-		if err := validateToolsRequired(cfg.UseFirecracker); err != nil {
+		if err := validateToolsRequired(cfg.UseFirecracker, cfg.UseKubernetes); err != nil {
 			return err
 		}
 
@@ -107,6 +110,10 @@ func RunRun(cliCtx *cli.Context, logger log.Logger, cfg *config.Config) error {
 		mustRegisterVMCountMetric(logger, observationContext, cfg.VMPrefix)
 	}
 
+	if cacheJanitor != nil {
+		routines = append(routines, cacheJanitor)
+	}
+
 	go func() {
 		// Block until the worker has exited. The executor worker is unique
 		// in that we want a maximum runtime and/or number of jobs to be
@@ -8,22 +8,26 @@ import (
 	"strings"
 	"time"
 
+	"github.com/c2h5oh/datasize"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sourcegraph/log"
 	"go.opentelemetry.io/otel"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient/queue"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/cache"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/config"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/metrics"
 	apiworker "github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/version"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 )
 
-func newQueueTelemetryOptions(ctx context.Context, useFirecracker bool, logger log.Logger) queue.TelemetryOptions {
+func newQueueTelemetryOptions(ctx context.Context, useFirecracker, useKubernetes bool, logger log.Logger) queue.TelemetryOptions {
 	t := queue.TelemetryOptions{
 		OS:              runtime.GOOS,
 		Architecture:    runtime.GOARCH,
@@ -42,9 +46,12 @@ func newQueueTelemetryOptions(ctx context.Context, useFirecracker bool, logger l
 		logger.Error("Failed to get src-cli version", log.Error(err))
 	}
 
-	t.DockerVersion, err = getDockerVersion(ctx)
-	if err != nil {
-		logger.Error("Failed to get docker version", log.Error(err))
+	// Docker isn't required when steps run as Kubernetes jobs.
+	if !useKubernetes {
+		t.DockerVersion, err = getDockerVersion(ctx)
+		if err != nil {
+			logger.Error("Failed to get docker version", log.Error(err))
+		}
 	}
 
 	if useFirecracker {
@@ -95,15 +102,17 @@ func getIgniteVersion(ctx context.Context) (string, error) {
 
 func apiWorkerOptions(c *config.Config, queueTelemetryOptions queue.TelemetryOptions) apiworker.Options {
 	return apiworker.Options{
-		VMPrefix:           c.VMPrefix,
-		KeepWorkspaces:     c.KeepWorkspaces,
-		QueueName:          c.QueueName,
-		WorkerOptions:      workerOptions(c),
-		FirecrackerOptions: firecrackerOptions(c),
-		ResourceOptions:    resourceOptions(c),
-		GitServicePath:     "/.executors/git",
-		QueueOptions:       queueOptions(c, queueTelemetryOptions),
-		FilesOptions:       filesOptions(c),
+		VMPrefix:             c.VMPrefix,
+		KeepWorkspaces:       c.KeepWorkspaces,
+		QueueName:            c.QueueName,
+		WorkerOptions:        workerOptions(c),
+		FirecrackerOptions:   firecrackerOptions(c),
+		KubernetesOptions:    kubernetesOptions(c),
+		KubernetesConfigPath: c.KubernetesConfigPath,
+		ResourceOptions:      resourceOptions(c),
+		GitServicePath:       "/.executors/git",
+		QueueOptions:         queueOptions(c, queueTelemetryOptions),
+		FilesOptions:         filesOptions(c),
 		RedactedValues: map[string]string{
 			// 🚨 SECURITY: Catch uses of the shared frontend token used to clone
 			// git repositories that make it into commands or stdout/stderr streams.
@@ -115,6 +124,39 @@ func apiWorkerOptions(c *config.Config, queueTelemetryOptions queue.TelemetryOpt
 	}
 }
 
+// newCaches creates the repository and image caches enabled in the config, as well as the
+// background routine that maintains them. The routine is nil when no cache is enabled.
+func newCaches(c *config.Config, observationContext *observation.Context) (*cache.RepositoryCache, *cache.ImageCache, goroutine.BackgroundRoutine) {
+	var (
+		repositoryCache *cache.RepositoryCache
+		imageCache      *cache.ImageCache
+	)
+
+	// The sizes are validated when the config is loaded.
+	repositoryCacheSize, _ := datasize.ParseString(c.RepositoryCacheSize)
+	imageCacheSize, _ := datasize.ParseString(c.DockerImageCacheSize)
+
+	if c.RepositoryCacheDir != "" {
+		repositoryCache = cache.NewRepositoryCache(c.RepositoryCacheDir, metrics.NewCacheMetrics(observationContext.Registerer, "repository"))
+	}
+	if !c.UseFirecracker && !c.UseKubernetes && (c.DockerWarmImages != "" || imageCacheSize > 0) {
+		imageCache = cache.NewImageCache(c.DockerWarmImageNames(), metrics.NewCacheMetrics(observationContext.Registerer, "image"))
+	}
+
+	if repositoryCache == nil && imageCache == nil {
+		return nil, nil, nil
+	}
+
+	janitor := cache.NewJanitor(
+		repositoryCache,
+		int64(repositoryCacheSize.Bytes()),
+		imageCache,
+		int64(imageCacheSize.Bytes()),
+		c.CleanupTaskInterval,
+	)
+	return repositoryCache, imageCache, janitor
+}
+
 func workerOptions(c *config.Config) workerutil.WorkerOptions {
 	return workerutil.WorkerOptions{
 		Name:                 fmt.Sprintf("executor_%s_worker", c.QueueName),
@@ -141,6 +183,18 @@ func firecrackerOptions(c *config.Config) command.FirecrackerOptions {
 	}
 }
 
+func kubernetesOptions(c *config.Config) command.KubernetesOptions {
+	// The node selector is validated when the config is loaded.
+	nodeSelector, _ := c.KubernetesNodeSelectorLabels()
+
+	return command.KubernetesOptions{
+		Enabled:                   c.UseKubernetes,
+		Namespace:                 c.KubernetesNamespace,
+		PersistentVolumeClaimName: c.KubernetesPersistenceVolumeClaimName,
+		NodeSelector:              nodeSelector,
+	}
+}
+
 func resourceOptions(c *config.Config) command.ResourceOptions {
 	return command.ResourceOptions{
 		NumCPUs:             c.JobNumCPUs,
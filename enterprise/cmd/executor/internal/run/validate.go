@@ -25,7 +25,7 @@ func RunValidate(cliCtx *cli.Context, logger log.Logger, config *config.Config)
 	}
 
 	// Then, validate all tools that are required are installed.
-	if err := validateToolsRequired(config.UseFirecracker); err != nil {
+	if err := validateToolsRequired(config.UseFirecracker, config.UseKubernetes); err != nil {
 		return err
 	}
 
@@ -34,7 +34,7 @@ func RunValidate(cliCtx *cli.Context, logger log.Logger, config *config.Config)
 		return err
 	}
 
-	telemetryOptions := newQueueTelemetryOptions(cliCtx.Context, config.UseFirecracker, logger)
+	telemetryOptions := newQueueTelemetryOptions(cliCtx.Context, config.UseFirecracker, config.UseKubernetes, logger)
 	copts := queueOptions(config, telemetryOptions)
 	client, err := apiclient.NewBaseClient(copts.BaseClientOptions)
 	if err != nil {
@@ -134,9 +134,13 @@ func latestSrcCLIVersion(ctx context.Context, client *apiclient.BaseClient, opti
 	return v.Version, nil
 }
 
-func validateToolsRequired(useFirecracker bool) error {
+func validateToolsRequired(useFirecracker, useKubernetes bool) error {
 	notFoundTools := []string{}
 	for tool := range config.RequiredCLITools {
+		// Docker isn't required when steps run as Kubernetes jobs.
+		if tool == "docker" && useKubernetes {
+			continue
+		}
 		if found, err := existsPath(tool); err != nil {
 			return err
 		} else if !found {
@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -125,6 +126,7 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, record workerut
 	options := command.Options{
 		ExecutorName:       name,
 		FirecrackerOptions: h.options.FirecrackerOptions,
+		KubernetesOptions:  h.options.KubernetesOptions,
 		ResourceOptions:    h.options.ResourceOptions,
 	}
 	runner := h.runnerFactory(workspace.Path(), commandLogger, options, h.operations)
@@ -161,10 +163,18 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, record workerut
 			Operation:  h.operations.Exec,
 		}
 
+		// Images are pulled inside the virtual machine or by the Kubernetes node otherwise.
+		if h.options.ImageCache != nil && !h.options.FirecrackerOptions.Enabled && !h.options.KubernetesOptions.Enabled {
+			h.options.ImageCache.Use(ctx, dockerStep.Image)
+		}
+
 		logger.Info(fmt.Sprintf("Running docker step #%d", i))
 
-		if err := runner.Run(ctx, dockerStepCommand); err != nil {
-			return errors.Wrap(err, "failed to perform docker step")
+		if err := runStep(ctx, runner, dockerStepCommand, dockerStep.Timeout, dockerStep.Retries); err != nil {
+			if !dockerStep.ContinueOnError {
+				return errors.Wrap(err, "failed to perform docker step")
+			}
+			logger.Warn(fmt.Sprintf("Docker step #%d failed, continuing", i), log.Error(err))
 		}
 	}
 
@@ -187,14 +197,33 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, record workerut
 
 		logger.Info(fmt.Sprintf("Running src-cli step #%d", i))
 
-		if err := runner.Run(ctx, cliStepCommand); err != nil {
-			return errors.Wrap(err, "failed to perform src-cli step")
+		if err := runStep(ctx, runner, cliStepCommand, cliStep.Timeout, cliStep.Retries); err != nil {
+			if !cliStep.ContinueOnError {
+				return errors.Wrap(err, "failed to perform src-cli step")
+			}
+			logger.Warn(fmt.Sprintf("src-cli step #%d failed, continuing", i), log.Error(err))
 		}
 	}
 
 	return nil
 }
 
+// runStep runs the given command until it succeeds, retrying it at most the given
+// number of times. Every attempt is canceled once the timeout passed. The log
+// entries of the attempts after the first one have the attempt number appended
+// to their key.
+func runStep(ctx context.Context, runner command.Runner, spec command.CommandSpec, timeout string, retries int) error {
+	key := spec.Key
+	step := &batcheslib.Step{Timeout: timeout, Retries: retries}
+
+	return batcheslib.RunStep(ctx, step, func(ctx context.Context, attempt int) error {
+		if attempt > 1 {
+			spec.Key = fmt.Sprintf("%s.attempt.%d", key, attempt)
+		}
+		return runner.Run(ctx, spec)
+	})
+}
+
 func union(a, b map[string]string) map[string]string {
 	c := make(map[string]string, len(a)+len(b))
 
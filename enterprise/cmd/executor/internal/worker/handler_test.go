@@ -19,6 +19,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/workspace"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestHandle(t *testing.T) {
@@ -276,3 +278,129 @@ func TestHandle_WorkspaceFile(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "\nset -x\n\n\nyarn\ninstall\n", string(dockerScriptFile2Content))
 }
+
+func TestHandle_StepTimeout(t *testing.T) {
+	runner := NewMockRunner()
+	runner.RunFunc.SetDefaultHook(func(ctx context.Context, _ command.CommandSpec) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	job := executor.Job{
+		ID:             42,
+		Commit:         "deadbeef",
+		RepositoryName: "linux",
+		CliSteps: []executor.CliStep{
+			{Commands: []string{"batch", "exec"}, Timeout: "10ms"},
+		},
+	}
+
+	err := handleTestJob(t, runner, job)
+	if !errors.Is(err, batcheslib.ErrStepTimeout) {
+		t.Fatalf("unexpected error. want=%q have=%v", batcheslib.ErrStepTimeout, err)
+	}
+	if value := len(runner.RunFunc.History()); value != 1 {
+		t.Errorf("unexpected number of Run calls. want=%d have=%d", 1, value)
+	}
+}
+
+func TestHandle_StepRetry(t *testing.T) {
+	runner := NewMockRunner()
+	runner.RunFunc.PushReturn(errors.New("flaky"))
+	runner.RunFunc.PushReturn(errors.New("flaky"))
+
+	job := executor.Job{
+		ID:             42,
+		Commit:         "deadbeef",
+		RepositoryName: "linux",
+		CliSteps: []executor.CliStep{
+			{Key: "batch-exec", Commands: []string{"batch", "exec"}, Retries: 2},
+		},
+	}
+
+	if err := handleTestJob(t, runner, job); err != nil {
+		t.Fatalf("unexpected error handling record: %s", err)
+	}
+
+	var keys []string
+	for _, call := range runner.RunFunc.History() {
+		keys = append(keys, call.Arg1.Key)
+	}
+	expectedKeys := []string{
+		"step.src.batch-exec",
+		"step.src.batch-exec.attempt.2",
+		"step.src.batch-exec.attempt.3",
+	}
+	if diff := cmp.Diff(expectedKeys, keys); diff != "" {
+		t.Errorf("unexpected keys (-want +got):\n%s", diff)
+	}
+}
+
+func TestHandle_StepContinueOnError(t *testing.T) {
+	runner := NewMockRunner()
+	runner.RunFunc.PushReturn(errors.New("broken"))
+
+	job := executor.Job{
+		ID:             42,
+		Commit:         "deadbeef",
+		RepositoryName: "linux",
+		DockerSteps: []executor.DockerStep{
+			{Image: "alpine", Commands: []string{"false"}, ContinueOnError: true},
+			{Image: "alpine", Commands: []string{"true"}},
+		},
+		CliSteps: []executor.CliStep{
+			{Commands: []string{"batch", "exec"}},
+		},
+	}
+
+	if err := handleTestJob(t, runner, job); err != nil {
+		t.Fatalf("unexpected error handling record: %s", err)
+	}
+	if value := len(runner.RunFunc.History()); value != 3 {
+		t.Errorf("unexpected number of Run calls. want=%d have=%d", 3, value)
+	}
+
+	// Without continueOnError, the job fails at the first step.
+	runner = NewMockRunner()
+	runner.RunFunc.PushReturn(errors.New("broken"))
+	job.DockerSteps[0].ContinueOnError = false
+
+	if err := handleTestJob(t, runner, job); err == nil {
+		t.Fatal("expected error handling record")
+	}
+	if value := len(runner.RunFunc.History()); value != 1 {
+		t.Errorf("unexpected number of Run calls. want=%d have=%d", 1, value)
+	}
+}
+
+// handleTestJob handles the given job in a temporary workspace, running the
+// steps of the job with the given runner.
+func handleTestJob(t *testing.T, runner *MockRunner, job executor.Job) error {
+	t.Helper()
+
+	testDir := t.TempDir()
+	workspace.MakeTempDirectory = func(string) (string, error) { return testDir, nil }
+	t.Cleanup(func() {
+		workspace.MakeTempDirectory = workspace.MakeTemporaryDirectory
+	})
+
+	if err := os.MkdirAll(filepath.Join(testDir, command.ScriptsPath), os.ModePerm); err != nil {
+		t.Fatalf("unexpected error creating workspace: %s", err)
+	}
+
+	h := &handler{
+		store:      NewMockStore(),
+		filesStore: NewMockFilesStore(),
+		nameSet:    janitor.NewNameSet(),
+		options:    Options{KeepWorkspaces: true},
+		operations: command.NewOperations(&observation.TestContext),
+		runnerFactory: func(dir string, logger command.Logger, options command.Options, operations *command.Operations) command.Runner {
+			if dir == "" {
+				return NewMockRunner()
+			}
+			return runner
+		},
+	}
+
+	return h.Handle(context.Background(), logtest.Scoped(t), job)
+}
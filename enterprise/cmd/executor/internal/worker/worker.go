@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient/files"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient/queue"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/cache"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/janitor"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/metrics"
@@ -63,6 +64,20 @@ type Options struct {
 	// FirecrackerOptions configures the behavior of Firecracker virtual machine creation.
 	FirecrackerOptions command.FirecrackerOptions
 
+	// KubernetesOptions configures the behavior of Kubernetes job creation.
+	KubernetesOptions command.KubernetesOptions
+
+	// KubernetesConfigPath is an optional path to a kubeconfig file used instead of the
+	// in-cluster configuration to create Kubernetes jobs.
+	KubernetesConfigPath string
+
+	// RepositoryCache, when set, keeps a mirror of cloned repositories that is used to
+	// clone repositories of later jobs.
+	RepositoryCache *cache.RepositoryCache
+
+	// ImageCache, when set, tracks the docker images used by jobs running on the host.
+	ImageCache *cache.ImageCache
+
 	// ResourceOptions configures the resource limits of docker container and Firecracker
 	// virtual machines running on the executor.
 	ResourceOptions command.ResourceOptions
@@ -93,6 +108,14 @@ func NewWorker(nameSet *janitor.NameSet, options Options, observationContext *ob
 	}
 	shim := &store.QueueShim{Name: options.QueueName, Store: queueStore}
 
+	if options.KubernetesOptions.Enabled && options.KubernetesOptions.Clientset == nil {
+		clientset, err := command.NewKubernetesClientset(options.KubernetesConfigPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "building kubernetes clientset")
+		}
+		options.KubernetesOptions.Clientset = clientset
+	}
+
 	if !connectToFrontend(queueStore, options) {
 		os.Exit(1)
 	}
@@ -28,9 +28,10 @@ func (h *handler) prepareWorkspace(
 			commandRunner,
 			commandLogger,
 			workspace.CloneOptions{
-				EndpointURL:    h.options.QueueOptions.BaseClientOptions.EndpointOptions.URL,
-				GitServicePath: h.options.GitServicePath,
-				ExecutorToken:  h.options.QueueOptions.BaseClientOptions.EndpointOptions.Token,
+				EndpointURL:     h.options.QueueOptions.BaseClientOptions.EndpointOptions.URL,
+				GitServicePath:  h.options.GitServicePath,
+				ExecutorToken:   h.options.QueueOptions.BaseClientOptions.EndpointOptions.Token,
+				RepositoryCache: h.options.RepositoryCache,
 			},
 			h.operations,
 		)
@@ -43,9 +44,10 @@ func (h *handler) prepareWorkspace(
 		commandRunner,
 		commandLogger,
 		workspace.CloneOptions{
-			EndpointURL:    h.options.QueueOptions.BaseClientOptions.EndpointOptions.URL,
-			GitServicePath: h.options.GitServicePath,
-			ExecutorToken:  h.options.QueueOptions.BaseClientOptions.EndpointOptions.Token,
+			EndpointURL:     h.options.QueueOptions.BaseClientOptions.EndpointOptions.URL,
+			GitServicePath:  h.options.GitServicePath,
+			ExecutorToken:   h.options.QueueOptions.BaseClientOptions.EndpointOptions.Token,
+			RepositoryCache: h.options.RepositoryCache,
 		},
 		h.operations,
 	)
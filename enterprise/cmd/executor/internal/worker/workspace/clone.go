@@ -21,6 +21,9 @@ const SchemeExecutorToken = "token-executor"
 // These env vars should be set for git commands. We want to make sure it never hangs on interactive input.
 var gitStdEnv = []string{"GIT_TERMINAL_PROMPT=0"}
 
+// mirrorRef is the ref of the commit last fetched into a repository mirror.
+const mirrorRef = "refs/executor/head"
+
 func cloneRepo(
 	ctx context.Context,
 	workspaceDir string,
@@ -55,48 +58,72 @@ func cloneRepo(
 		return err
 	}
 
-	fetchCommand := []string{
-		"git",
-		"-C", repoPath,
-		"-c", "protocol.version=2",
-		"fetch",
-		"--progress",
-		"--no-recurse-submodules",
-		"origin",
-		job.Commit,
-	}
-
-	appendFetchArg := func(arg string) {
-		l := len(fetchCommand)
-		insertPos := l - 2
-		fetchCommand = append(fetchCommand[:insertPos+1], fetchCommand[insertPos:]...)
-		fetchCommand[insertPos] = arg
-	}
-
+	var fetchArgs []string
 	if job.FetchTags {
-		appendFetchArg("--tags")
+		fetchArgs = append(fetchArgs, "--tags")
 	}
 
 	if job.ShallowClone {
 		if !job.FetchTags {
-			appendFetchArg("--no-tags")
+			fetchArgs = append(fetchArgs, "--no-tags")
 		}
-		appendFetchArg("--depth=1")
+		fetchArgs = append(fetchArgs, "--depth=1")
 	}
 
 	// For a sparse checkout, we want to add a blob filter so we only fetch the minimum set of files initially.
 	if len(job.SparseCheckout) > 0 {
-		appendFetchArg("--filter=blob:none")
+		fetchArgs = append(fetchArgs, "--filter=blob:none")
 	}
 
-	gitCommands := []command.CommandSpec{
-		{Key: "setup.git.init", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "init"}, Operation: operations.SetupGitInit},
-		{Key: "setup.git.add-remote", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "remote", "add", "origin", cloneURL.String()}, Operation: operations.SetupAddRemote},
-		// Disable gc, this can improve performance and should never run for executor clones.
-		{Key: "setup.git.disable-gc", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "config", "--local", "gc.auto", "0"}, Operation: operations.SetupGitDisableGC},
-		{Key: "setup.git.fetch", Env: gitStdEnv, Command: fetchCommand, Operation: operations.SetupGitFetch},
+	var gitCommands []command.CommandSpec
+
+	// When the repository cache is enabled, we first update the mirror of the repository
+	// from the Sourcegraph instance, and then clone the workspace from the mirror. Sparse
+	// checkouts fetch missing blobs lazily from their remote, so they can't be cloned from
+	// a mirror that doesn't have them either.
+	remoteURL := cloneURL.String()
+	if options.RepositoryCache != nil && len(job.SparseCheckout) == 0 {
+		mirrorDir, unlock := options.RepositoryCache.Lock(job.RepositoryName, job.ShallowClone)
+		defer unlock()
+
+		cacheFetchCommand := append([]string{
+			"git",
+			"-C", mirrorDir,
+			"-c", "protocol.version=2",
+			"fetch",
+			"--progress",
+			"--no-recurse-submodules",
+		}, fetchArgs...)
+		// Keep a ref to the fetched commit, so that the next fetch only downloads the
+		// objects the mirror doesn't have yet, and the workspace can fetch it by its hash.
+		cacheFetchCommand = append(cacheFetchCommand, cloneURL.String(), fmt.Sprintf("+%s:%s", job.Commit, mirrorRef))
+
+		gitCommands = append(gitCommands,
+			// Initializing an existing repository is a no-op.
+			command.CommandSpec{Key: "setup.git.cache-init", Env: gitStdEnv, Command: []string{"git", "init", "--bare", "--quiet", mirrorDir}, Operation: operations.SetupGitCacheInit},
+			command.CommandSpec{Key: "setup.git.cache-fetch", Env: gitStdEnv, Command: cacheFetchCommand, Operation: operations.SetupGitCacheFetch},
+		)
+		remoteURL = mirrorDir
 	}
 
+	fetchCommand := append([]string{
+		"git",
+		"-C", repoPath,
+		"-c", "protocol.version=2",
+		"fetch",
+		"--progress",
+		"--no-recurse-submodules",
+	}, fetchArgs...)
+	fetchCommand = append(fetchCommand, "origin", job.Commit)
+
+	gitCommands = append(gitCommands,
+		command.CommandSpec{Key: "setup.git.init", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "init"}, Operation: operations.SetupGitInit},
+		command.CommandSpec{Key: "setup.git.add-remote", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "remote", "add", "origin", remoteURL}, Operation: operations.SetupAddRemote},
+		// Disable gc, this can improve performance and should never run for executor clones.
+		command.CommandSpec{Key: "setup.git.disable-gc", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "config", "--local", "gc.auto", "0"}, Operation: operations.SetupGitDisableGC},
+		command.CommandSpec{Key: "setup.git.fetch", Env: gitStdEnv, Command: fetchCommand, Operation: operations.SetupGitFetch},
+	)
+
 	if len(job.SparseCheckout) > 0 {
 		gitCommands = append(gitCommands, command.CommandSpec{
 			Key:       "setup.git.sparse-checkout-config",
@@ -1,11 +1,19 @@
 package workspace
 
-import "context"
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/cache"
+)
 
 type CloneOptions struct {
 	EndpointURL    string
 	GitServicePath string
 	ExecutorToken  string
+
+	// RepositoryCache, when set, keeps a mirror of cloned repositories that is used to
+	// clone repositories of later jobs.
+	RepositoryCache *cache.RepositoryCache
 }
 
 type Workspace interface {
@@ -9,10 +9,13 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient/queue"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/cache"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
@@ -173,6 +176,57 @@ func TestPrepareWorkspace_ShallowClone(t *testing.T) {
 	}
 }
 
+func TestPrepareWorkspace_RepositoryCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	options := Options{
+		QueueOptions: queue.Options{
+			BaseClientOptions: apiclient.BaseClientOptions{
+				EndpointOptions: apiclient.EndpointOptions{
+					URL:   "https://test.io",
+					Token: "hunter2",
+				},
+			},
+		},
+		GitServicePath:  "/internal/git",
+		RepositoryCache: cache.NewRepositoryCache(cacheDir, metrics.NewCacheMetrics(prometheus.NewRegistry(), "repository")),
+	}
+	runner := NewMockRunner()
+	handler := &handler{
+		options:    options,
+		operations: command.NewOperations(&observation.TestContext),
+	}
+
+	workspace, err := handler.prepareWorkspace(context.Background(), runner, executor.Job{
+		RepositoryName: "torvalds/linux",
+		Commit:         "deadbeef",
+		ShallowClone:   true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error preparing workspace: %s", err)
+	}
+	defer os.RemoveAll(workspace.Path())
+
+	var commands [][]string
+	for _, call := range runner.RunFunc.History() {
+		commands = append(commands, call.Arg1.Command)
+	}
+
+	mirrorDir := filepath.Join(cacheDir, "6d2d5b87602bc0c67fb7209ff10f135d07dbd834bf8cd031db966014bcd21136-shallow.git")
+	expectedCommands := [][]string{
+		{"git", "init", "--bare", "--quiet", mirrorDir},
+		{"git", "-C", mirrorDir, "-c", "protocol.version=2", "fetch", "--progress", "--no-recurse-submodules", "--no-tags", "--depth=1", "http://127.0.0.1:port/torvalds/linux", "+deadbeef:refs/executor/head"},
+		{"git", "-C", workspace.Path(), "init"},
+		{"git", "-C", workspace.Path(), "remote", "add", "origin", mirrorDir},
+		{"git", "-C", workspace.Path(), "config", "--local", "gc.auto", "0"},
+		{"git", "-C", workspace.Path(), "-c", "protocol.version=2", "fetch", "--progress", "--no-recurse-submodules", "--no-tags", "--depth=1", "origin", "deadbeef"},
+		{"git", "-C", workspace.Path(), "checkout", "--progress", "--force", "deadbeef"},
+		{"git", "-C", workspace.Path(), "remote", "set-url", "origin", "torvalds/linux"},
+	}
+	if diff := cmp.Diff(expectedCommands, commands, ignorePort); diff != "" {
+		t.Errorf("unexpected commands (-want +got):\n%s", diff)
+	}
+}
+
 func TestPrepareWorkspace_SparseCheckout(t *testing.T) {
 	options := Options{
 		QueueOptions: queue.Options{
@@ -7,6 +7,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/httpapi"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/resolvers"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/webhooks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel"
@@ -34,6 +35,9 @@ func Init(
 		if _, err := window.NewConfiguration(c.SiteConfig().BatchChangesRolloutWindows); err != nil {
 			problems = append(problems, conf.NewSiteProblem(err.Error()))
 		}
+		if err := service.ValidateChangesetPolicy(c.SiteConfig().BatchChangesChangesetPolicy); err != nil {
+			problems = append(problems, conf.NewSiteProblem(err.Error()))
+		}
 
 		return
 	})
@@ -0,0 +1,248 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type autoMergePolicyResolver struct {
+	store  *store.Store
+	policy *btypes.AutoMergePolicy
+}
+
+var _ graphqlbackend.BatchChangeAutoMergePolicyResolver = &autoMergePolicyResolver{}
+
+func (r *autoMergePolicyResolver) User(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DatabaseDB(), r.policy.UserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *autoMergePolicyResolver) RequirePassingChecks() bool {
+	return r.policy.RequirePassingChecks
+}
+
+func (r *autoMergePolicyResolver) RequireApproval() bool {
+	return r.policy.RequireApproval
+}
+
+func (r *autoMergePolicyResolver) Squash() bool {
+	return r.policy.Squash
+}
+
+func (r *autoMergePolicyResolver) MaintenanceWindows() []graphqlbackend.BatchChangeMaintenanceWindowResolver {
+	resolvers := make([]graphqlbackend.BatchChangeMaintenanceWindowResolver, 0, len(r.policy.Windows))
+	for _, w := range r.policy.Windows {
+		resolvers = append(resolvers, &maintenanceWindowResolver{window: w})
+	}
+	return resolvers
+}
+
+func (r *autoMergePolicyResolver) MaxMergesPerHour() int32 {
+	return r.policy.MaxMergesPerHour
+}
+
+func (r *autoMergePolicyResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.policy.CreatedAt}
+}
+
+func (r *autoMergePolicyResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.policy.UpdatedAt}
+}
+
+type maintenanceWindowResolver struct {
+	window *schema.BatchChangeRolloutWindow
+}
+
+var _ graphqlbackend.BatchChangeMaintenanceWindowResolver = &maintenanceWindowResolver{}
+
+func (r *maintenanceWindowResolver) Days() []string {
+	if r.window.Days == nil {
+		return []string{}
+	}
+	return r.window.Days
+}
+
+func (r *maintenanceWindowResolver) Start() *string {
+	if r.window.Start == "" {
+		return nil
+	}
+	return &r.window.Start
+}
+
+func (r *maintenanceWindowResolver) End() *string {
+	if r.window.End == "" {
+		return nil
+	}
+	return &r.window.End
+}
+
+// maintenanceWindowsFromInput converts the given GraphQL input to rollout
+// windows. The rate of the windows is unused by auto-merge policies, so they
+// are all unlimited.
+func maintenanceWindowsFromInput(input *[]graphqlbackend.BatchChangeMaintenanceWindowInput) []*schema.BatchChangeRolloutWindow {
+	if input == nil {
+		return nil
+	}
+
+	windows := make([]*schema.BatchChangeRolloutWindow, 0, len(*input))
+	for _, w := range *input {
+		window := &schema.BatchChangeRolloutWindow{Rate: "unlimited"}
+		if w.Days != nil {
+			window.Days = *w.Days
+		}
+		if w.Start != nil {
+			window.Start = *w.Start
+		}
+		if w.End != nil {
+			window.End = *w.End
+		}
+		windows = append(windows, window)
+	}
+	return windows
+}
+
+type autoMergeEventConnectionResolver struct {
+	store           *store.Store
+	gitserverClient gitserver.Client
+	opts            store.ListAutoMergeEventsOpts
+
+	// Cache results because they are used by multiple fields
+	once   sync.Once
+	events []*btypes.AutoMergeEvent
+	next   int64
+	err    error
+}
+
+var _ graphqlbackend.BatchChangeAutoMergeEventConnectionResolver = &autoMergeEventConnectionResolver{}
+
+func (r *autoMergeEventConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.store.CountAutoMergeEvents(ctx, store.CountAutoMergeEventsOpts{
+		BatchChangeID: r.opts.BatchChangeID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int32(count), nil
+}
+
+func (r *autoMergeEventConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.Itoa(int(next))), nil
+	}
+
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *autoMergeEventConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.BatchChangeAutoMergeEventResolver, error) {
+	events, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	changesetIDs := make([]int64, 0, len(events))
+	seen := make(map[int64]struct{}, len(events))
+	for _, e := range events {
+		if _, ok := seen[e.ChangesetID]; !ok {
+			seen[e.ChangesetID] = struct{}{}
+			changesetIDs = append(changesetIDs, e.ChangesetID)
+		}
+	}
+
+	changesetsByID := map[int64]*btypes.Changeset{}
+	reposByID := map[api.RepoID]*types.Repo{}
+	if len(changesetIDs) > 0 {
+		// Load all changesets and repos at once, to avoid N+1 queries.
+		changesets, _, err := r.store.ListChangesets(ctx, store.ListChangesetsOpts{IDs: changesetIDs})
+		if err != nil {
+			return nil, err
+		}
+		for _, ch := range changesets {
+			changesetsByID[ch.ID] = ch
+		}
+		// 🚨 SECURITY: database.Repos.GetReposSetByIDs uses the authzFilter under the hood and
+		// filters out repositories that the user doesn't have access to.
+		reposByID, err = r.store.Repos().GetReposSetByIDs(ctx, changesets.RepoIDs()...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resolvers := make([]graphqlbackend.BatchChangeAutoMergeEventResolver, 0, len(events))
+	for _, e := range events {
+		ch, ok := changesetsByID[e.ChangesetID]
+		if !ok {
+			// The repository of the changeset was deleted.
+			continue
+		}
+		resolvers = append(resolvers, &autoMergeEventResolver{
+			store:           r.store,
+			gitserverClient: r.gitserverClient,
+			event:           e,
+			changeset:       ch,
+			repo:            reposByID[ch.RepoID],
+		})
+	}
+
+	return resolvers, nil
+}
+
+func (r *autoMergeEventConnectionResolver) compute(ctx context.Context) ([]*btypes.AutoMergeEvent, int64, error) {
+	r.once.Do(func() {
+		r.events, r.next, r.err = r.store.ListAutoMergeEvents(ctx, r.opts)
+	})
+
+	return r.events, r.next, r.err
+}
+
+type autoMergeEventResolver struct {
+	store           *store.Store
+	gitserverClient gitserver.Client
+	event           *btypes.AutoMergeEvent
+	changeset       *btypes.Changeset
+	// repo is nil if the repository of the changeset is not accessible by the
+	// requesting user.
+	repo *types.Repo
+}
+
+var _ graphqlbackend.BatchChangeAutoMergeEventResolver = &autoMergeEventResolver{}
+
+func (r *autoMergeEventResolver) Changeset() graphqlbackend.ChangesetResolver {
+	return NewChangesetResolver(r.store, r.gitserverClient, r.changeset, r.repo)
+}
+
+func (r *autoMergeEventResolver) Decision() string {
+	return string(r.event.Decision)
+}
+
+func (r *autoMergeEventResolver) Reason() *string {
+	// We only show the reason when the changeset is visible to the requesting
+	// user, as it can contain details of the changeset.
+	if r.repo == nil {
+		return nil
+	}
+	return &r.event.Reason
+}
+
+func (r *autoMergeEventResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.event.CreatedAt}
+}
@@ -0,0 +1,49 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
+
+type autoRebaseResolver struct {
+	store  *store.Store
+	rebase *btypes.AutoRebase
+}
+
+var _ graphqlbackend.BatchChangeAutoRebaseResolver = &autoRebaseResolver{}
+
+func (r *autoRebaseResolver) User(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DatabaseDB(), r.rebase.UserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *autoRebaseResolver) LastBatchSpec(ctx context.Context) (graphqlbackend.BatchSpecResolver, error) {
+	if r.rebase.BatchSpecID == 0 {
+		return nil, nil
+	}
+
+	batchSpec, err := r.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: r.rebase.BatchSpecID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &batchSpecResolver{store: r.store, batchSpec: batchSpec}, nil
+}
+
+func (r *autoRebaseResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.rebase.CreatedAt}
+}
+
+func (r *autoRebaseResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.rebase.UpdatedAt}
+}
@@ -286,6 +286,56 @@ func (r *batchChangeResolver) CurrentSpec(ctx context.Context) (graphqlbackend.B
 	return &batchSpecResolver{store: r.store, batchSpec: batchSpec}, nil
 }
 
+func (r *batchChangeResolver) AutoMergePolicy(ctx context.Context) (graphqlbackend.BatchChangeAutoMergePolicyResolver, error) {
+	policy, err := r.store.GetAutoMergePolicy(ctx, r.batchChange.ID)
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &autoMergePolicyResolver{store: r.store, policy: policy}, nil
+}
+
+func (r *batchChangeResolver) AutoRebase(ctx context.Context) (graphqlbackend.BatchChangeAutoRebaseResolver, error) {
+	rebase, err := r.store.GetAutoRebase(ctx, r.batchChange.ID)
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &autoRebaseResolver{store: r.store, rebase: rebase}, nil
+}
+
+func (r *batchChangeResolver) AutoMergeEvents(
+	ctx context.Context,
+	args *graphqlbackend.ListBatchChangeAutoMergeEventsArgs,
+) (graphqlbackend.BatchChangeAutoMergeEventConnectionResolver, error) {
+	if err := validateFirstParamDefaults(args.First); err != nil {
+		return nil, err
+	}
+	opts := store.ListAutoMergeEventsOpts{
+		LimitOpts: store.LimitOpts{
+			Limit: int(args.First),
+		},
+		BatchChangeID: r.batchChange.ID,
+	}
+	if args.After != nil {
+		id, err := strconv.Atoi(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = int64(id)
+	}
+
+	return &autoMergeEventConnectionResolver{
+		store:           r.store,
+		gitserverClient: r.gitserverClient,
+		opts:            opts,
+	}, nil
+}
+
 func (r *batchChangeResolver) BulkOperations(
 	ctx context.Context,
 	args *graphqlbackend.ListBatchChangeBulkOperationArgs,
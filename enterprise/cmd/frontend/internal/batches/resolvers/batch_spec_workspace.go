@@ -298,6 +298,14 @@ func (r *batchSpecWorkspaceResolver) FailureMessage() *string {
 	return r.execution.FailureMessage
 }
 
+func (r *batchSpecWorkspaceResolver) PolicyViolations() []graphqlbackend.ChangesetPolicyViolationResolver {
+	resolvers := make([]graphqlbackend.ChangesetPolicyViolationResolver, 0, len(r.workspace.PolicyViolations))
+	for _, v := range r.workspace.PolicyViolations {
+		resolvers = append(resolvers, &changesetPolicyViolationResolver{violation: v})
+	}
+	return resolvers
+}
+
 func (r *batchSpecWorkspaceResolver) State() string {
 	if r.CachedResultFound() {
 		return "COMPLETED"
@@ -502,3 +510,21 @@ func findExecutionLogEntry(execution *btypes.BatchSpecWorkspaceExecutionJob, key
 
 	return workerutil.ExecutionLogEntry{}, false
 }
+
+type changesetPolicyViolationResolver struct {
+	violation btypes.ChangesetPolicyViolation
+}
+
+var _ graphqlbackend.ChangesetPolicyViolationResolver = &changesetPolicyViolationResolver{}
+
+func (r *changesetPolicyViolationResolver) Check() string  { return r.violation.Check }
+func (r *changesetPolicyViolationResolver) Branch() string { return r.violation.Branch }
+
+func (r *changesetPolicyViolationResolver) Path() *string {
+	if r.violation.Path == "" {
+		return nil
+	}
+	return &r.violation.Path
+}
+
+func (r *changesetPolicyViolationResolver) Message() string { return r.violation.Message }
@@ -43,6 +43,28 @@ func (r *batchSpecWorkspaceStepResolver) IfCondition() *string {
 	return &cond
 }
 
+func (r *batchSpecWorkspaceStepResolver) MatrixValues() *graphqlbackend.JSONValue {
+	if r.step.MatrixValues == nil {
+		return nil
+	}
+	return &graphqlbackend.JSONValue{Value: r.step.MatrixValues}
+}
+
+func (r *batchSpecWorkspaceStepResolver) Timeout() *string {
+	if r.step.Timeout == "" {
+		return nil
+	}
+	return &r.step.Timeout
+}
+
+func (r *batchSpecWorkspaceStepResolver) Retries() int32 {
+	return int32(r.step.Retries)
+}
+
+func (r *batchSpecWorkspaceStepResolver) ContinueOnError() bool {
+	return r.step.ContinueOnError
+}
+
 func (r *batchSpecWorkspaceStepResolver) CachedResultFound() bool {
 	return r.stepInfo.StartedAt.IsZero() && r.cachedResult != nil
 }
@@ -52,15 +74,22 @@ func (r *batchSpecWorkspaceStepResolver) Skipped() bool {
 }
 
 func (r *batchSpecWorkspaceStepResolver) OutputLines(ctx context.Context, args *graphqlbackend.BatchSpecWorkspaceStepOutputLinesArgs) (*[]string, error) {
-	lines := r.stepInfo.OutputLines
+	lines := paginateOutputLines(r.stepInfo.OutputLines, args)
+	// TODO: Return nil when execution not yet started.
+	return &lines, nil
+}
+
+func paginateOutputLines(lines []string, args *graphqlbackend.BatchSpecWorkspaceStepOutputLinesArgs) []string {
 	if args.After != nil {
+		if int(*args.After) >= len(lines) {
+			return []string{}
+		}
 		lines = lines[*args.After:]
 	}
 	if int(args.First) < len(lines) {
 		lines = lines[:args.First]
 	}
-	// TODO: Return nil when execution not yet started.
-	return &lines, nil
+	return lines
 }
 
 func (r *batchSpecWorkspaceStepResolver) StartedAt() *gqlutil.DateTime {
@@ -154,6 +183,57 @@ func (r *batchSpecWorkspaceStepResolver) Diff(ctx context.Context) (graphqlbacke
 	return nil, nil
 }
 
+func (r *batchSpecWorkspaceStepResolver) Attempts() []graphqlbackend.BatchSpecWorkspaceStepAttemptResolver {
+	resolvers := make([]graphqlbackend.BatchSpecWorkspaceStepAttemptResolver, 0, len(r.stepInfo.Attempts))
+	for _, a := range r.stepInfo.Attempts {
+		resolvers = append(resolvers, &batchSpecWorkspaceStepAttemptResolver{attempt: a})
+	}
+	return resolvers
+}
+
+type batchSpecWorkspaceStepAttemptResolver struct {
+	attempt *btypes.StepAttempt
+}
+
+var _ graphqlbackend.BatchSpecWorkspaceStepAttemptResolver = &batchSpecWorkspaceStepAttemptResolver{}
+
+func (r *batchSpecWorkspaceStepAttemptResolver) Number() int32 {
+	return int32(r.attempt.Number)
+}
+
+func (r *batchSpecWorkspaceStepAttemptResolver) OutputLines(args *graphqlbackend.BatchSpecWorkspaceStepOutputLinesArgs) []string {
+	return paginateOutputLines(r.attempt.OutputLines, args)
+}
+
+func (r *batchSpecWorkspaceStepAttemptResolver) StartedAt() *gqlutil.DateTime {
+	if r.attempt.StartedAt.IsZero() {
+		return nil
+	}
+	return &gqlutil.DateTime{Time: r.attempt.StartedAt}
+}
+
+func (r *batchSpecWorkspaceStepAttemptResolver) FinishedAt() *gqlutil.DateTime {
+	if r.attempt.FinishedAt.IsZero() {
+		return nil
+	}
+	return &gqlutil.DateTime{Time: r.attempt.FinishedAt}
+}
+
+func (r *batchSpecWorkspaceStepAttemptResolver) ExitCode() *int32 {
+	if r.attempt.ExitCode == nil {
+		return nil
+	}
+	code := int32(*r.attempt.ExitCode)
+	return &code
+}
+
+func (r *batchSpecWorkspaceStepAttemptResolver) Error() *string {
+	if r.attempt.Error == "" {
+		return nil
+	}
+	return &r.attempt.Error
+}
+
 type batchSpecWorkspaceEnvironmentVariableResolver struct {
 	key   string
 	value string
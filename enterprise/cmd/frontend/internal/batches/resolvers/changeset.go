@@ -351,6 +351,15 @@ func (r *changesetResolver) CheckState() *string {
 	return &state
 }
 
+func (r *changesetResolver) BaseState() *string {
+	if !r.changeset.Published() || r.changeset.BaseState == "" {
+		return nil
+	}
+
+	state := string(r.changeset.BaseState)
+	return &state
+}
+
 func (r *changesetResolver) Error() *string { return r.changeset.FailureMessage }
 
 func (r *changesetResolver) SyncerError() *string { return r.changeset.SyncErrorMessage }
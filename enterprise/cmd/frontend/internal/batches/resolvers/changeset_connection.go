@@ -69,6 +69,7 @@ func (r *changesetsConnectionResolver) TotalCount(ctx context.Context) (int32, e
 		ExternalStates:       r.opts.ExternalStates,
 		ExternalReviewState:  r.opts.ExternalReviewState,
 		ExternalCheckState:   r.opts.ExternalCheckState,
+		BaseStates:           r.opts.BaseStates,
 		ReconcilerStates:     r.opts.ReconcilerStates,
 		OwnedByBatchChangeID: r.opts.OwnedByBatchChangeID,
 		PublicationState:     r.opts.PublicationState,
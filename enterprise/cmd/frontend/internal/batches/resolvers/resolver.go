@@ -929,6 +929,16 @@ func listChangesetOptsFromArgs(args *graphqlbackend.ListChangesetsArgs, batchCha
 		// changesets, since that would leak information.
 		safe = false
 	}
+	if args.BaseState != nil {
+		state := btypes.ChangesetBaseState(*args.BaseState)
+		if !state.Valid() {
+			return opts, false, errors.New("changeset base state not valid")
+		}
+		opts.BaseStates = []btypes.ChangesetBaseState{state}
+		// If the user filters by BaseState we cannot include hidden
+		// changesets, since that would leak information.
+		safe = false
+	}
 	if args.OnlyPublishedByThisBatchChange != nil {
 		published := btypes.ChangesetPublicationStatePublished
 
@@ -1181,7 +1191,10 @@ func (r *Resolver) generateAuthenticatorForCredential(ctx context.Context, exter
 			PublicKey:  keypair.PublicKey,
 			Passphrase: keypair.Passphrase,
 		}
-	} else if externalServiceType == extsvc.TypeBitbucketCloud {
+	} else if externalServiceType == extsvc.TypeBitbucketCloud || externalServiceType == extsvc.TypeGerrit {
+		if username == nil {
+			return nil, errors.Errorf("a username is required for %s credentials", externalServiceType)
+		}
 		a = &extsvcauth.BasicAuthWithSSH{
 			BasicAuth:  extsvcauth.BasicAuth{Username: *username, Password: credential},
 			PrivateKey: keypair.PrivateKey,
@@ -1485,6 +1498,105 @@ func (r *Resolver) PublishChangesets(ctx context.Context, args *graphqlbackend.P
 	return r.bulkOperationByIDString(ctx, bulkGroupID)
 }
 
+func (r *Resolver) SetBatchChangeAutoMergePolicy(ctx context.Context, args *graphqlbackend.SetBatchChangeAutoMergePolicyArgs) (_ graphqlbackend.BatchChangeAutoMergePolicyResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetBatchChangeAutoMergePolicy", fmt.Sprintf("BatchChange: %q", args.BatchChange))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	policy := &btypes.AutoMergePolicy{
+		BatchChangeID:        batchChangeID,
+		RequirePassingChecks: args.Policy.RequirePassingChecks,
+		RequireApproval:      args.Policy.RequireApproval,
+		Squash:               args.Policy.Squash,
+		Windows:              maintenanceWindowsFromInput(args.Policy.MaintenanceWindows),
+		MaxMergesPerHour:     args.Policy.MaxMergesPerHour,
+	}
+
+	// 🚨 SECURITY: SetAutoMergePolicy checks whether current user is authorized.
+	svc := service.New(r.store)
+	if err := svc.SetAutoMergePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return &autoMergePolicyResolver{store: r.store, policy: policy}, nil
+}
+
+func (r *Resolver) DeleteBatchChangeAutoMergePolicy(ctx context.Context, args *graphqlbackend.DeleteBatchChangeAutoMergePolicyArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteBatchChangeAutoMergePolicy", fmt.Sprintf("BatchChange: %q", args.BatchChange))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: DeleteAutoMergePolicy checks whether current user is authorized.
+	svc := service.New(r.store)
+	if err := svc.DeleteAutoMergePolicy(ctx, batchChangeID); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) SetBatchChangeAutoRebase(ctx context.Context, args *graphqlbackend.SetBatchChangeAutoRebaseArgs) (_ graphqlbackend.BatchChangeResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetBatchChangeAutoRebase", fmt.Sprintf("BatchChange: %q, Enabled: %t", args.BatchChange, args.Enabled))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: EnableAutoRebase and DisableAutoRebase check whether current
+	// user is authorized.
+	svc := service.New(r.store)
+	if args.Enabled {
+		err = svc.EnableAutoRebase(ctx, batchChangeID)
+	} else {
+		err = svc.DisableAutoRebase(ctx, batchChangeID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.batchChangeByID(ctx, args.BatchChange)
+}
+
 func (r *Resolver) BatchSpecs(ctx context.Context, args *graphqlbackend.ListBatchSpecArgs) (_ graphqlbackend.BatchSpecConnectionResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.BatchSpecs", fmt.Sprintf("First: %d, After: %v", args.First, args.After))
 	defer func() {
@@ -59,6 +59,8 @@ type Action struct {
 	Email        *ActionEmail
 	Webhook      *ActionWebhook
 	SlackWebhook *ActionSlackWebhook
+	TeamsWebhook *ActionTeamsWebhook
+	Issue        *ActionIssue
 }
 
 func (a *Action) UnmarshalJSON(b []byte) error {
@@ -80,6 +82,12 @@ func (a *Action) UnmarshalJSON(b []byte) error {
 	case "MonitorSlackWebhook":
 		a.SlackWebhook = &ActionSlackWebhook{}
 		return json.Unmarshal(b, &a.SlackWebhook)
+	case "MonitorTeamsWebhook":
+		a.TeamsWebhook = &ActionTeamsWebhook{}
+		return json.Unmarshal(b, &a.TeamsWebhook)
+	case "MonitorIssue":
+		a.Issue = &ActionIssue{}
+		return json.Unmarshal(b, &a.Issue)
 	default:
 		return errors.Errorf("unexpected typename %q", t.TypeName)
 	}
@@ -108,6 +116,21 @@ type ActionSlackWebhook struct {
 	Events  ActionEventConnection
 }
 
+type ActionTeamsWebhook struct {
+	Id      string
+	Enabled bool
+	URL     string
+	Events  ActionEventConnection
+}
+
+type ActionIssue struct {
+	Id          string
+	Enabled     bool
+	Repository  struct{ Name string }
+	IssueNumber *int
+	Events      ActionEventConnection
+}
+
 type RecipientsConnection struct {
 	Nodes      []UserOrg
 	TotalCount int
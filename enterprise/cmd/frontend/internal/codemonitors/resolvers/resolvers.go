@@ -3,6 +3,7 @@ package resolvers
 import (
 	"context"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/graph-gophers/graphql-go"
@@ -14,9 +15,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/background"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -290,15 +294,32 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 			if err != nil {
 				return err
 			}
+		case a.TeamsWebhook != nil:
+			if err := validateTeamsURL(a.TeamsWebhook.URL); err != nil {
+				return err
+			}
+			_, err := r.db.CodeMonitors().CreateTeamsWebhookAction(ctx, monitorID, a.TeamsWebhook.Enabled, a.TeamsWebhook.IncludeResults, a.TeamsWebhook.URL)
+			if err != nil {
+				return err
+			}
+		case a.Issue != nil:
+			repoID, err := r.validateIssueAction(ctx, a.Issue)
+			if err != nil {
+				return err
+			}
+			_, err = r.db.CodeMonitors().CreateIssueAction(ctx, monitorID, a.Issue.Enabled, a.Issue.IncludeResults, repoID)
+			if err != nil {
+				return err
+			}
 		default:
-			return errors.New("exactly one of Email, Webhook, or SlackWebhook must be set")
+			return errors.New("exactly one of Email, Webhook, SlackWebhook, TeamsWebhook, or Issue must be set")
 		}
 	}
 	return nil
 }
 
 func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []graphql.ID) error {
-	var email, webhook, slackWebhook []int64
+	var email, webhook, slackWebhook, teamsWebhook, issue []int64
 	for _, id := range ids {
 		var intID int64
 		err := relay.UnmarshalSpec(id, &intID)
@@ -313,8 +334,12 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 			webhook = append(webhook, intID)
 		case monitorActionSlackWebhookKind:
 			slackWebhook = append(slackWebhook, intID)
+		case monitorActionTeamsWebhookKind:
+			teamsWebhook = append(teamsWebhook, intID)
+		case monitorActionIssueKind:
+			issue = append(issue, intID)
 		default:
-			return errors.New("action IDs must be exactly one of email, webhook, or slack webhook")
+			return errors.New("action IDs must be exactly one of email, webhook, slack webhook, teams webhook, or issue")
 		}
 	}
 
@@ -330,6 +355,14 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 		return err
 	}
 
+	if err := r.db.CodeMonitors().DeleteTeamsWebhookActions(ctx, monitorID, teamsWebhook...); err != nil {
+		return err
+	}
+
+	if err := r.db.CodeMonitors().DeleteIssueActions(ctx, monitorID, issue...); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -410,6 +443,23 @@ func (r *Resolver) TriggerTestSlackWebhookAction(ctx context.Context, args *grap
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+func (r *Resolver) TriggerTestTeamsWebhookAction(ctx context.Context, args *graphqlbackend.TriggerTestTeamsWebhookActionArgs) (*graphqlbackend.EmptyResponse, error) {
+	err := r.isAllowedToCreate(ctx, args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateTeamsURL(args.TeamsWebhook.URL); err != nil {
+		return nil, err
+	}
+
+	if err := background.SendTestTeamsWebhook(ctx, httpcli.ExternalDoer, args.Description, args.TeamsWebhook.URL); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
 func sendTestEmail(ctx context.Context, db database.DB, recipient graphql.ID, description string) error {
 	var (
 		userID int32
@@ -441,7 +491,15 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	if err != nil {
 		return nil, err
 	}
-	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions))
+	teamsWebhookActions, err := r.db.CodeMonitors().ListTeamsWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	issueActions, err := r.db.CodeMonitors().ListIssueActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions)+len(teamsWebhookActions)+len(issueActions))
 	for _, emailAction := range emailActions {
 		ids = append(ids, (&monitorEmail{EmailAction: emailAction}).ID())
 	}
@@ -451,6 +509,12 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	for _, slackWebhookAction := range slackWebhookActions {
 		ids = append(ids, (&monitorSlackWebhook{SlackWebhookAction: slackWebhookAction}).ID())
 	}
+	for _, teamsWebhookAction := range teamsWebhookActions {
+		ids = append(ids, (&monitorTeamsWebhook{TeamsWebhookAction: teamsWebhookAction}).ID())
+	}
+	for _, issueAction := range issueActions {
+		ids = append(ids, (&monitorIssue{IssueAction: issueAction}).ID())
+	}
 	return ids, nil
 }
 
@@ -495,6 +559,26 @@ func splitActionIDs(args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []grap
 			}
 			toUpdateActions = append(toUpdateActions, a)
 			delete(aMap, *a.SlackWebhook.Id)
+		case a.TeamsWebhook != nil:
+			if a.TeamsWebhook.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{TeamsWebhook: a.TeamsWebhook.Update})
+				continue
+			}
+			if _, ok := aMap[*a.TeamsWebhook.Id]; !ok {
+				return nil, nil, errors.Errorf("unknown ID=%s for action", *a.TeamsWebhook.Id)
+			}
+			toUpdateActions = append(toUpdateActions, a)
+			delete(aMap, *a.TeamsWebhook.Id)
+		case a.Issue != nil:
+			if a.Issue.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{Issue: a.Issue.Update})
+				continue
+			}
+			if _, ok := aMap[*a.Issue.Id]; !ok {
+				return nil, nil, errors.Errorf("unknown ID=%s for action", *a.Issue.Id)
+			}
+			toUpdateActions = append(toUpdateActions, a)
+			delete(aMap, *a.Issue.Id)
 		}
 	}
 
@@ -579,8 +663,15 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 				return nil, err
 			}
 			err = r.updateSlackWebhookAction(ctx, *action.SlackWebhook)
+		case action.TeamsWebhook != nil:
+			if err := validateTeamsURL(action.TeamsWebhook.Update.URL); err != nil {
+				return nil, err
+			}
+			err = r.updateTeamsWebhookAction(ctx, *action.TeamsWebhook)
+		case action.Issue != nil:
+			err = r.updateIssueAction(ctx, *action.Issue)
 		default:
-			err = errors.New("action must be one of email, webhook, or slack webhook")
+			err = errors.New("action must be one of email, webhook, slack webhook, teams webhook, or issue")
 		}
 		if err != nil {
 			return nil, err
@@ -636,6 +727,71 @@ func (r *Resolver) updateSlackWebhookAction(ctx context.Context, args graphqlbac
 	return err
 }
 
+func (r *Resolver) updateTeamsWebhookAction(ctx context.Context, args graphqlbackend.EditActionTeamsWebhookArgs) error {
+	var id int64
+	err := relay.UnmarshalSpec(*args.Id, &id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateTeamsWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL)
+	return err
+}
+
+func (r *Resolver) updateIssueAction(ctx context.Context, args graphqlbackend.EditActionIssueArgs) error {
+	var id int64
+	err := relay.UnmarshalSpec(*args.Id, &id)
+	if err != nil {
+		return err
+	}
+
+	// Monitors are saved with all of their actions, so an unchanged issue action
+	// must not prevent its owner from editing the rest of the monitor.
+	current, err := r.db.CodeMonitors().GetIssueAction(ctx, id)
+	if err != nil {
+		return err
+	}
+	if current.Enabled == args.Update.Enabled &&
+		current.IncludeResults == args.Update.IncludeResults &&
+		graphqlbackend.MarshalRepositoryID(current.RepoID) == args.Update.Repository {
+		return nil
+	}
+
+	repoID, err := r.validateIssueAction(ctx, args.Update)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateIssueAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, repoID)
+	return err
+}
+
+// validateIssueAction checks that the actor is allowed to configure an issue
+// action for the given repository and returns the ID of the repository. Issue
+// actions open issues with the token of the code host connection syncing the
+// repository, so only site admins can configure them.
+func (r *Resolver) validateIssueAction(ctx context.Context, args *graphqlbackend.CreateActionIssueArgs) (api.RepoID, error) {
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return 0, errors.Wrap(err, "only site admins can configure issue actions")
+	}
+
+	repoID, err := graphqlbackend.UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return 0, err
+	}
+
+	repo, err := r.db.Repos().Get(ctx, repoID)
+	if err != nil {
+		return 0, err
+	}
+	switch repo.ExternalRepo.ServiceType {
+	case extsvc.TypeGitHub, extsvc.TypeGitLab:
+	default:
+		return 0, errors.Errorf("issue actions are only supported for GitHub and GitLab repositories, but %s is hosted on %s", repo.Name, extsvc.TypeToKind(repo.ExternalRepo.ServiceType))
+	}
+	return repoID, nil
+}
+
 func (r *Resolver) transact(ctx context.Context) (*Resolver, error) {
 	tx, err := r.db.Transact(ctx)
 	if err != nil {
@@ -721,9 +877,12 @@ const (
 	monitorActionEmailKind             = "CodeMonitorActionEmail"
 	monitorActionWebhookKind           = "CodeMonitorActionWebhook"
 	monitorActionSlackWebhookKind      = "CodeMonitorActionSlackWebhook"
+	monitorActionTeamsWebhookKind      = "CodeMonitorActionTeamsWebhook"
+	monitorActionIssueKind             = "CodeMonitorActionIssue"
 	monitorActionEmailEventKind        = "CodeMonitorActionEmailEvent"
 	monitorActionWebhookEventKind      = "CodeMonitorActionWebhookEvent"
 	monitorActionSlackWebhookEventKind = "CodeMonitorActionSlackWebhookEvent"
+	monitorActionTeamsWebhookEventKind = "CodeMonitorActionTeamsWebhookEvent"
 	monitorActionEmailRecipientKind    = "CodeMonitorActionEmailRecipient"
 )
 
@@ -816,7 +975,17 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 		return nil, err
 	}
 
-	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws))
+	tws, err := r.db.CodeMonitors().ListTeamsWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	is, err := r.db.CodeMonitors().ListIssueActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws)+len(tws)+len(is))
 	for _, e := range es {
 		actions = append(actions, &action{
 			email: &monitorEmail{
@@ -844,6 +1013,24 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 			},
 		})
 	}
+	for _, tw := range tws {
+		actions = append(actions, &action{
+			teamsWebhook: &monitorTeamsWebhook{
+				Resolver:           r,
+				TeamsWebhookAction: tw,
+				triggerEventID:     triggerEventID,
+			},
+		})
+	}
+	for _, i := range is {
+		actions = append(actions, &action{
+			issue: &monitorIssue{
+				Resolver:       r,
+				IssueAction:    i,
+				triggerEventID: triggerEventID,
+			},
+		})
+	}
 
 	totalCount := len(actions)
 	if args.After != nil {
@@ -1020,6 +1207,8 @@ type action struct {
 	email        graphqlbackend.MonitorEmailResolver
 	webhook      graphqlbackend.MonitorWebhookResolver
 	slackWebhook graphqlbackend.MonitorSlackWebhookResolver
+	teamsWebhook graphqlbackend.MonitorTeamsWebhookResolver
+	issue        graphqlbackend.MonitorIssueResolver
 }
 
 func (a *action) ID() graphql.ID {
@@ -1030,6 +1219,10 @@ func (a *action) ID() graphql.ID {
 		return a.webhook.ID()
 	case a.slackWebhook != nil:
 		return a.slackWebhook.ID()
+	case a.teamsWebhook != nil:
+		return a.teamsWebhook.ID()
+	case a.issue != nil:
+		return a.issue.ID()
 	default:
 		panic("action must have a type")
 	}
@@ -1047,6 +1240,14 @@ func (a *action) ToMonitorSlackWebhook() (graphqlbackend.MonitorSlackWebhookReso
 	return a.slackWebhook, a.slackWebhook != nil
 }
 
+func (a *action) ToMonitorTeamsWebhook() (graphqlbackend.MonitorTeamsWebhookResolver, bool) {
+	return a.teamsWebhook, a.teamsWebhook != nil
+}
+
+func (a *action) ToMonitorIssue() (graphqlbackend.MonitorIssueResolver, bool) {
+	return a.issue, a.issue != nil
+}
+
 // Email
 type monitorEmail struct {
 	*Resolver
@@ -1262,6 +1463,130 @@ func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.L
 	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
 }
 
+type monitorTeamsWebhook struct {
+	*Resolver
+	*edb.TeamsWebhookAction
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int32
+}
+
+func (m *monitorTeamsWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionTeamsWebhookKind, m.TeamsWebhookAction.ID)
+}
+
+func (m *monitorTeamsWebhook) Enabled() bool {
+	return m.TeamsWebhookAction.Enabled
+}
+
+func (m *monitorTeamsWebhook) IncludeResults() bool {
+	return m.TeamsWebhookAction.IncludeResults
+}
+
+func (m *monitorTeamsWebhook) URL() string {
+	return m.TeamsWebhookAction.URL
+}
+
+func (m *monitorTeamsWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	ajs, err := m.db.CodeMonitors().ListActionJobs(ctx, edb.ListActionJobsOpts{
+		TeamsWebhookID: intPtr(int(m.TeamsWebhookAction.ID)),
+		TriggerEventID: m.triggerEventID,
+		First:          intPtr(int(args.First)),
+		After:          after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := m.db.CodeMonitors().CountActionJobs(ctx, edb.ListActionJobsOpts{
+		TeamsWebhookID: intPtr(int(m.TeamsWebhookAction.ID)),
+		TriggerEventID: m.triggerEventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
+}
+
+type monitorIssue struct {
+	*Resolver
+	*edb.IssueAction
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int32
+}
+
+func (m *monitorIssue) ID() graphql.ID {
+	return relay.MarshalID(monitorActionIssueKind, m.IssueAction.ID)
+}
+
+func (m *monitorIssue) Enabled() bool {
+	return m.IssueAction.Enabled
+}
+
+func (m *monitorIssue) IncludeResults() bool {
+	return m.IssueAction.IncludeResults
+}
+
+func (m *monitorIssue) Repository(ctx context.Context) (*graphqlbackend.RepositoryResolver, error) {
+	repo, err := m.db.Repos().Get(ctx, m.IssueAction.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlbackend.NewRepositoryResolver(m.db, gitserver.NewClient(m.db), repo), nil
+}
+
+func (m *monitorIssue) IssueNumber() *int32 {
+	if m.IssueAction.IssueNumber == nil {
+		return nil
+	}
+	n := int32(*m.IssueAction.IssueNumber)
+	return &n
+}
+
+func (m *monitorIssue) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	ajs, err := m.db.CodeMonitors().ListActionJobs(ctx, edb.ListActionJobsOpts{
+		IssueID:        intPtr(int(m.IssueAction.ID)),
+		TriggerEventID: m.triggerEventID,
+		First:          intPtr(int(args.First)),
+		After:          after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := m.db.CodeMonitors().CountActionJobs(ctx, edb.ListActionJobsOpts{
+		IssueID:        intPtr(int(m.IssueAction.ID)),
+		TriggerEventID: m.triggerEventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
+}
+
 func intPtr(i int) *int { return &i }
 func intPtrToInt64Ptr(i *int) *int64 {
 	if i == nil {
@@ -1355,3 +1680,29 @@ func validateSlackURL(urlString string) error {
 	}
 	return nil
 }
+
+// teamsWebhookHostSuffixes are the hosts that serve Microsoft Teams incoming
+// webhooks, either directly or through Power Automate workflows.
+var teamsWebhookHostSuffixes = []string{
+	".webhook.office.com",
+	".logic.azure.com",
+	".api.powerplatform.com",
+}
+
+func validateTeamsURL(urlString string) error {
+	u, err := url.Parse(urlString)
+	if err != nil {
+		return err
+	}
+
+	// Restrict Teams webhooks to only canonical hosts and HTTPS. Power Automate
+	// workflow URLs include the default port explicitly.
+	if u.Scheme == "https" && (u.Port() == "" || u.Port() == "443") {
+		for _, suffix := range teamsWebhookHostSuffixes {
+			if strings.HasSuffix(u.Hostname(), suffix) {
+				return nil
+			}
+		}
+	}
+	return errors.New("Microsoft Teams webhook URL must be an HTTPS URL on webhook.office.com, logic.azure.com or api.powerplatform.com")
+}
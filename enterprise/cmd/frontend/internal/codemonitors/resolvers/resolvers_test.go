@@ -85,6 +85,36 @@ func TestCreateCodeMonitor(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("invalid teams webhook", func(t *testing.T) {
+		namespace := relay.MarshalID("User", user.ID)
+		_, err := r.CreateCodeMonitor(ctx, &graphqlbackend.CreateCodeMonitorArgs{
+			Monitor: &graphqlbackend.CreateMonitorArgs{Namespace: namespace},
+			Trigger: &graphqlbackend.CreateTriggerArgs{Query: "repo:."},
+			Actions: []*graphqlbackend.CreateActionArgs{{
+				TeamsWebhook: &graphqlbackend.CreateActionTeamsWebhookArgs{
+					URL: "https://internal:3443",
+				},
+			}},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("issue action requires site admin", func(t *testing.T) {
+		nonAdmin := insertTestUser(t, db, "cm-user-issue", false)
+		nonAdminCtx := actor.WithActor(ctx, actor.FromUser(nonAdmin.ID))
+		namespace := relay.MarshalID("User", nonAdmin.ID)
+		_, err := r.CreateCodeMonitor(nonAdminCtx, &graphqlbackend.CreateCodeMonitorArgs{
+			Monitor: &graphqlbackend.CreateMonitorArgs{Namespace: namespace},
+			Trigger: &graphqlbackend.CreateTriggerArgs{Query: "repo:."},
+			Actions: []*graphqlbackend.CreateActionArgs{{
+				Issue: &graphqlbackend.CreateActionIssueArgs{
+					Repository: graphqlbackend.MarshalRepositoryID(1),
+				},
+			}},
+		})
+		require.Error(t, err)
+	})
+
 	t.Run("invalid query", func(t *testing.T) {
 		namespace := relay.MarshalID("User", user.ID)
 		_, err := r.CreateCodeMonitor(ctx, &graphqlbackend.CreateCodeMonitorArgs{
@@ -1323,3 +1353,25 @@ func TestValidateSlackURL(t *testing.T) {
 		require.Error(t, validateSlackURL(url))
 	}
 }
+
+func TestValidateTeamsURL(t *testing.T) {
+	valid := []string{
+		"https://contoso.webhook.office.com/webhookb2/8d8d8@8dd88d/IncomingWebhook/838383/8d8d8",
+		"https://prod-01.westus.logic.azure.com:443/workflows/8d8d8/triggers/manual/paths/invoke",
+	}
+
+	for _, url := range valid {
+		require.NoError(t, validateTeamsURL(url))
+	}
+
+	invalid := []string{
+		"http://contoso.webhook.office.com/webhookb2/8d8d8",
+		"https://contoso.webhook.office.com:3443/webhookb2/8d8d8",
+		"https://webhook.office.com.internal/webhookb2/8d8d8",
+		"https://internal:8989",
+	}
+
+	for _, url := range invalid {
+		require.Error(t, validateTeamsURL(url))
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/handler"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	apiclient "github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 )
@@ -21,7 +23,8 @@ func QueueOptions(db database.DB, _ func() string, observationContext *observati
 		return transformRecord(ctx, logger, batchesStore, record.(*btypes.BatchSpecWorkspaceExecutionJob))
 	}
 
-	store := store.NewBatchSpecWorkspaceExecutionWorkerStore(db.Handle(), observationContext)
+	validator := service.NewChangesetSpecValidator(gitserver.NewClient(db))
+	store := store.NewBatchSpecWorkspaceExecutionWorkerStore(db.Handle(), validator, observationContext)
 	return handler.QueueOptions{
 		Name:              "batches",
 		Store:             store,
@@ -1,6 +1,7 @@
 package batches
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"testing"
@@ -264,3 +265,120 @@ func TestTransformRecord(t *testing.T) {
 		}
 	})
 }
+
+func TestTransformRecord_Matrix(t *testing.T) {
+	db := database.NewMockDB()
+	repos := database.NewMockRepoStore()
+	repos.GetFunc.SetDefaultHook(func(ctx context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id, Name: "github.com/sourcegraph/sourcegraph"}, nil
+	})
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	spec, err := batcheslib.ParseBatchSpec([]byte(`
+name: matrix
+steps:
+  - run: go test ./... > ${{ repository.name }}-go${{ matrix.go }}.log
+    container: golang:${{ matrix.go }}
+    env:
+      GOFLAGS: -tags=go${{ matrix.go }}
+    files:
+      /tmp/version: ${{ matrix.go }}
+    outputs:
+      version:
+        value: ${{ matrix.go }}-${{ step.stdout }}
+    matrix:
+      go: ["1.18", "1.19"]
+changesetTemplate:
+  title: Test
+  body: Test
+  branch: test
+  commit:
+    message: Test
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	batchSpec := &btypes.BatchSpec{UserID: 123, Spec: spec}
+	workspace := &btypes.BatchSpecWorkspace{RepoID: 5678, Branch: "refs/heads/main", Commit: "d34db33f", StepCacheResults: map[int]btypes.StepCacheResult{}}
+
+	store := NewMockBatchesStore()
+	store.GetBatchSpecFunc.SetDefaultReturn(batchSpec, nil)
+	store.GetBatchSpecWorkspaceFunc.SetDefaultReturn(workspace, nil)
+	store.DatabaseDBFunc.SetDefaultReturn(db)
+
+	job, err := transformRecord(context.Background(), logtest.Scoped(t), store, &btypes.BatchSpecWorkspaceExecutionJob{ID: 42, UserID: 123})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var input batcheslib.WorkspacesExecutionInput
+	if err := json.Unmarshal([]byte(job.VirtualMachineFiles[srcInputPath].Content), &input); err != nil {
+		t.Fatal(err)
+	}
+
+	// The step runner renders the templates of the steps without knowing about
+	// the matrix, so the matrix values have to be rendered in the input.
+	type renderedStep struct {
+		Run       string
+		Container string
+		Env       map[string]string
+		Files     map[string]string
+		Output    string
+	}
+	var have []renderedStep
+	for _, step := range input.Steps {
+		stepCtx := &template.StepContext{
+			Repository: template.Repository{Name: input.Repository.Name},
+			Step:       execution.AfterStepResult{Stdout: "ok"},
+		}
+
+		var run bytes.Buffer
+		if err := template.RenderStepTemplate("run", step.Run, &run, stepCtx); err != nil {
+			t.Fatal(err)
+		}
+		env, err := step.Env.Resolve(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = template.RenderStepMap(env, stepCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files, err := template.RenderStepMap(step.Files, stepCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var output bytes.Buffer
+		if err := template.RenderStepTemplate("output", step.Outputs["version"].Value, &output, stepCtx); err != nil {
+			t.Fatal(err)
+		}
+
+		have = append(have, renderedStep{
+			Run:       run.String(),
+			Container: step.Container,
+			Env:       env,
+			Files:     files,
+			Output:    output.String(),
+		})
+	}
+
+	want := []renderedStep{
+		{
+			Run:       "go test ./... > github.com/sourcegraph/sourcegraph-go1.18.log",
+			Container: "golang:1.18",
+			Env:       map[string]string{"GOFLAGS": "-tags=go1.18"},
+			Files:     map[string]string{"/tmp/version": "1.18"},
+			Output:    "1.18-ok",
+		},
+		{
+			Run:       "go test ./... > github.com/sourcegraph/sourcegraph-go1.19.log",
+			Container: "golang:1.19",
+			Env:       map[string]string{"GOFLAGS": "-tags=go1.19"},
+			Files:     map[string]string{"/tmp/version": "1.19"},
+			Output:    "1.19-ok",
+		},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("wrong rendered steps (-want +have):\n%s", diff)
+	}
+}
@@ -100,7 +100,7 @@ func SetupRockskip(observationContext *observation.Context, gitserverClient symb
 	createParser := func() (ctags.Parser, error) {
 		return symbolsParser.SpawnCtags(log.Scoped("parser", "ctags parser"), config.Ctags)
 	}
-	server, err := rockskip.NewService(codeintelDB, gitserverClient, repositoryFetcher, createParser, config.MaxConcurrentlyIndexing, config.MaxRepos, config.LogQueries, config.IndexRequestsQueueSize, config.SymbolsCacheSize, config.PathSymbolsCacheSize)
+	server, err := rockskip.NewService(codeintelDB, gitserverClient, repositoryFetcher, createParser, config.MaxConcurrentlyIndexing, config.MaxRepos, config.MaxRefsPerRepo, config.LogQueries, config.IndexRequestsQueueSize, config.SymbolsCacheSize, config.PathSymbolsCacheSize)
 	if err != nil {
 		return nil, nil, nil, config.Ctags.Command, err
 	}
@@ -112,6 +112,7 @@ type RockskipConfig struct {
 	Ctags                   types.CtagsConfig
 	RepositoryFetcher       types.RepositoryFetcherConfig
 	MaxRepos                int
+	MaxRefsPerRepo          int
 	LogQueries              bool
 	IndexRequestsQueueSize  int
 	MaxConcurrentlyIndexing int
@@ -124,6 +125,7 @@ func LoadRockskipConfig(baseConfig env.BaseConfig) RockskipConfig {
 		Ctags:                   types.LoadCtagsConfig(baseConfig),
 		RepositoryFetcher:       types.LoadRepositoryFetcherConfig(baseConfig),
 		MaxRepos:                baseConfig.GetInt("MAX_REPOS", "1000", "maximum number of repositories to store in Postgres, with LRU eviction"),
+		MaxRefsPerRepo:          baseConfig.GetInt("MAX_REFS_PER_REPO", "10", "maximum number of refs (branches, tags or commits) to keep indexed per repository, with LRU eviction"),
 		LogQueries:              baseConfig.GetBool("LOG_QUERIES", "false", "print search queries to stdout"),
 		IndexRequestsQueueSize:  baseConfig.GetInt("INDEX_REQUESTS_QUEUE_SIZE", "1000", "how many index requests can be queued at once, at which point new requests will be rejected"),
 		MaxConcurrentlyIndexing: baseConfig.GetInt("MAX_CONCURRENTLY_INDEXING", "4", "maximum number of repositories being indexed at a time (also limits ctags processes)"),
@@ -0,0 +1,42 @@
+package batches
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/automerge"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+type autoMergerJob struct{}
+
+func NewAutoMergerJob() job.Job {
+	return &autoMergerJob{}
+}
+
+func (j *autoMergerJob) Description() string {
+	return ""
+}
+
+func (j *autoMergerJob) Config() []env.Config {
+	return []env.Config{}
+}
+
+func (j *autoMergerJob) Routines(_ context.Context, logger log.Logger) ([]goroutine.BackgroundRoutine, error) {
+	workCtx := actor.WithInternalActor(context.Background())
+
+	bstore, err := InitStore()
+	if err != nil {
+		return nil, err
+	}
+
+	routines := []goroutine.BackgroundRoutine{
+		automerge.NewMerger(workCtx, logger.Scoped("AutoMerger", "merges changesets according to batch change auto-merge policies"), bstore),
+	}
+
+	return routines, nil
+}
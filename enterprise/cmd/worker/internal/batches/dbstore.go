@@ -6,8 +6,10 @@ import (
 	"go.opentelemetry.io/otel"
 
 	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/memo"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -92,7 +94,8 @@ var initBatchSpecWorkspaceExecutionWorkerStore = memo.NewMemoizedConstructor(fun
 		return nil, err
 	}
 
-	return store.NewBatchSpecWorkspaceExecutionWorkerStore(db.Handle(), observationContext), nil
+	validator := service.NewChangesetSpecValidator(gitserver.NewClient(db))
+	return store.NewBatchSpecWorkspaceExecutionWorkerStore(db.Handle(), validator, observationContext), nil
 })
 
 // InitBatchSpecResolutionWorkerStore initializes and returns a dbworker.Store instance for the batch spec workspace resolution worker.
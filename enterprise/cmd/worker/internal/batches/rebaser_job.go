@@ -0,0 +1,42 @@
+package batches
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/rebaser"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+type rebaserJob struct{}
+
+func NewRebaserJob() job.Job {
+	return &rebaserJob{}
+}
+
+func (j *rebaserJob) Description() string {
+	return ""
+}
+
+func (j *rebaserJob) Config() []env.Config {
+	return []env.Config{}
+}
+
+func (j *rebaserJob) Routines(_ context.Context, logger log.Logger) ([]goroutine.BackgroundRoutine, error) {
+	workCtx := actor.WithInternalActor(context.Background())
+
+	bstore, err := InitStore()
+	if err != nil {
+		return nil, err
+	}
+
+	routines := []goroutine.BackgroundRoutine{
+		rebaser.NewRebaser(workCtx, logger.Scoped("Rebaser", "re-executes batch changes whose changesets are outdated"), bstore),
+	}
+
+	return routines, nil
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
@@ -22,8 +24,9 @@ func NewBatchSpecResolutionWorker(
 	observationContext *observation.Context,
 ) *workerutil.Worker {
 	e := &batchSpecWorkspaceCreator{
-		store:  s,
-		logger: log.Scoped("batch-spec-workspace-creator", "The background worker running workspace resolutions for batch changes"),
+		store:     s,
+		validator: service.NewChangesetSpecValidator(gitserver.NewClient(s.DatabaseDB())),
+		logger:    log.Scoped("batch-spec-workspace-creator", "The background worker running workspace resolutions for batch changes"),
 	}
 
 	options := workerutil.WorkerOptions{
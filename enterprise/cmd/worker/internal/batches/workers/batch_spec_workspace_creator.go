@@ -17,6 +17,7 @@ import (
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
@@ -28,8 +29,9 @@ import (
 // batchSpecWorkspaceCreator takes in BatchSpecs, resolves them into
 // RepoWorkspaces and then persists those as pending BatchSpecWorkspaces.
 type batchSpecWorkspaceCreator struct {
-	store  *store.Store
-	logger log.Logger
+	store     *store.Store
+	validator store.ChangesetSpecValidator
+	logger    log.Logger
 }
 
 // HandlerFunc returns a workerutil.HandlerFunc that can be passed to a
@@ -49,6 +51,7 @@ type stepCacheKey struct {
 
 type workspaceCacheKey struct {
 	dbWorkspace   *btypes.BatchSpecWorkspace
+	dbRepo        *types.Repo
 	repo          batcheslib.Repository
 	stepCacheKeys []stepCacheKey
 	skippedSteps  map[int32]struct{}
@@ -167,6 +170,7 @@ func (r *batchSpecWorkspaceCreator) process(
 
 		cacheKeyWorkspaces = append(cacheKeyWorkspaces, workspaceCacheKey{
 			dbWorkspace:   workspace,
+			dbRepo:        w.Repo,
 			repo:          repo,
 			stepCacheKeys: stepCacheKeys,
 			skippedSteps:  skippedSteps,
@@ -242,8 +246,6 @@ func (r *batchSpecWorkspaceCreator) process(
 			continue
 		}
 
-		workspace.dbWorkspace.CachedResultFound = true
-
 		rawSpecs, err := cache.ChangesetSpecsFromCache(spec.Spec, workspace.repo, *res.Value, workspace.dbWorkspace.Path)
 		if err != nil {
 			return err
@@ -262,6 +264,18 @@ func (r *batchSpecWorkspaceCreator) process(
 			specs = append(specs, changesetSpec)
 		}
 
+		violations, err := r.validator.ValidateChangesetSpecs(ctx, workspace.dbRepo, specs)
+		if err != nil {
+			return err
+		}
+		if len(violations) > 0 {
+			// Don't use the cached result, so that the workspace is executed
+			// and the violations are recorded when it's marked as complete.
+			// The step cache results still make that execution fast.
+			continue
+		}
+
+		workspace.dbWorkspace.CachedResultFound = true
 		cs = append(cs, specs...)
 		changesetsByWorkspace[workspace.dbWorkspace] = specs
 	}
@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
@@ -107,7 +108,7 @@ func TestBatchSpecWorkspaceCreatorProcess(t *testing.T) {
 		},
 	}
 
-	creator := &batchSpecWorkspaceCreator{store: s, logger: logtest.Scoped(t)}
+	creator := &batchSpecWorkspaceCreator{store: s, validator: service.NewChangesetSpecValidator(gitserver.NewMockClient()), logger: logtest.Scoped(t)}
 	if err := creator.process(context.Background(), resolver.DummyBuilder, job); err != nil {
 		t.Fatalf("proces failed: %s", err)
 	}
@@ -183,7 +184,7 @@ func TestBatchSpecWorkspaceCreatorProcess_Caching(t *testing.T) {
 	clock := func() time.Time { return now }
 	s := store.NewWithClock(db, &observation.TestContext, nil, clock)
 
-	creator := &batchSpecWorkspaceCreator{store: s, logger: logtest.Scoped(t)}
+	creator := &batchSpecWorkspaceCreator{store: s, validator: service.NewChangesetSpecValidator(gitserver.NewMockClient()), logger: logtest.Scoped(t)}
 
 	buildWorkspace := func(commit string) *service.RepoWorkspace {
 		return &service.RepoWorkspace{
@@ -812,7 +813,7 @@ importChangesets:
 
 	resolver := &dummyWorkspaceResolver{}
 
-	creator := &batchSpecWorkspaceCreator{store: s, logger: logtest.Scoped(t)}
+	creator := &batchSpecWorkspaceCreator{store: s, validator: service.NewChangesetSpecValidator(gitserver.NewMockClient()), logger: logtest.Scoped(t)}
 	if err := creator.process(context.Background(), resolver.DummyBuilder, job); err != nil {
 		t.Fatalf("proces failed: %s", err)
 	}
@@ -870,7 +871,7 @@ importChangesets:
 
 	resolver := &dummyWorkspaceResolver{}
 
-	creator := &batchSpecWorkspaceCreator{store: s, logger: logtest.Scoped(t)}
+	creator := &batchSpecWorkspaceCreator{store: s, validator: service.NewChangesetSpecValidator(gitserver.NewMockClient()), logger: logtest.Scoped(t)}
 	if err := creator.process(context.Background(), resolver.DummyBuilder, job); err != nil {
 		t.Fatalf("proces failed: %s", err)
 	}
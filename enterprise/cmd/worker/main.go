@@ -46,6 +46,8 @@ func main() {
 		"insights-query-runner-job":     workerinsights.NewInsightsQueryRunnerJob(),
 		"batches-janitor":               batches.NewJanitorJob(),
 		"batches-scheduler":             batches.NewSchedulerJob(),
+		"batches-auto-merger":           batches.NewAutoMergerJob(),
+		"batches-rebaser":               batches.NewRebaserJob(),
 		"batches-reconciler":            batches.NewReconcilerJob(),
 		"batches-bulk-processor":        batches.NewBulkOperationProcessorJob(),
 		"batches-workspace-resolver":    batches.NewWorkspaceResolverJob(),
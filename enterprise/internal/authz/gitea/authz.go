@@ -0,0 +1,55 @@
+package gitea
+
+import (
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewAuthzProviders returns the set of Gitea authz providers derived from the connections.
+//
+// It also returns any simple validation problems with the config, separating these into "serious problems"
+// and "warnings". "Serious problems" are those that should make Sourcegraph set authz.allowAccessByDefault
+// to false. "Warnings" are all other validation problems.
+//
+// This constructor does not and should not directly check connectivity to external services - if
+// desired, callers should use `(*Provider).ValidateConnection` directly to get warnings related
+// to connection issues.
+func NewAuthzProviders(
+	conns []*types.GiteaConnection,
+) (ps []authz.Provider, problems []string, warnings []string, invalidConnections []string) {
+	for _, c := range conns {
+		p, err := newAuthzProvider(c)
+		if err != nil {
+			invalidConnections = append(invalidConnections, extsvc.TypeGitea)
+			problems = append(problems, err.Error())
+		} else if p != nil {
+			ps = append(ps, p)
+		}
+	}
+
+	return ps, problems, warnings, invalidConnections
+}
+
+func newAuthzProvider(c *types.GiteaConnection) (authz.Provider, error) {
+	if c.Authorization == nil {
+		return nil, nil
+	}
+
+	if errLicense := licensing.Check(licensing.FeatureACLs); errLicense != nil {
+		return nil, errLicense
+	}
+
+	switch idp := c.Authorization.IdentityProvider; idp.Type {
+	case "username":
+		p, err := NewProvider(c)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	default:
+		return nil, errors.Errorf("No identityProvider was specified")
+	}
+}
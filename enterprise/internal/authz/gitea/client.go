@@ -0,0 +1,30 @@
+package gitea
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+)
+
+type client interface {
+	GetUser(ctx context.Context, username string) (*gitea.User, error)
+	GetAuthenticatedUser(ctx context.Context) (*gitea.User, error)
+	ListReposForUser(ctx context.Context, username string) ([]*gitea.Repository, error)
+	GetRepoByID(ctx context.Context, id int64) (*gitea.Repository, error)
+	ListCollaborators(ctx context.Context, owner, name string) ([]*gitea.User, error)
+	ListRepoTeams(ctx context.Context, owner, name string) ([]*gitea.Team, error)
+	ListTeamMembers(ctx context.Context, teamID int64) ([]*gitea.User, error)
+}
+
+var _ client = (*ClientAdapter)(nil)
+
+// ClientAdapter is an adapter for Gitea API client.
+type ClientAdapter struct {
+	*gitea.Client
+}
+
+// ListReposForUser returns all the repositories the user with the given
+// username has access to, by impersonating the user.
+func (c *ClientAdapter) ListReposForUser(ctx context.Context, username string) ([]*gitea.Repository, error) {
+	return c.WithSudo(username).ListAuthenticatedUserRepos(ctx)
+}
@@ -0,0 +1,211 @@
+// Package gitea contains an authorization provider for Gitea and Forgejo.
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Provider is an implementation of AuthzProvider that provides repository permissions as
+// determined from a Gitea instance API.
+type Provider struct {
+	urn      string
+	client   client
+	codeHost *extsvc.CodeHost
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Gitea authorization provider for the given
+// connection. It assumes usernames of Sourcegraph accounts match 1-1 with
+// usernames of Gitea users.
+func NewProvider(conn *types.GiteaConnection) (*Provider, error) {
+	cli, err := gitea.NewClient(conn.URN, conn.GiteaConnection, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{
+		urn:      conn.URN,
+		client:   &ClientAdapter{Client: cli},
+		codeHost: extsvc.NewCodeHost(cli.URL, extsvc.TypeGitea),
+	}, nil
+}
+
+// ValidateConnection validates that the Provider has access to the Gitea API
+// with a token that belongs to a site admin, which is required to list the
+// repositories of other users.
+func (p *Provider) ValidateConnection(ctx context.Context) []string {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	user, err := p.client.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	if !user.IsAdmin {
+		return []string{"Gitea token must belong to a site admin to enforce repository permissions"}
+	}
+
+	return nil
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ServiceID returns the absolute URL that identifies the Gitea instance this
+// provider is configured with.
+func (p *Provider) ServiceID() string { return p.codeHost.ServiceID }
+
+// ServiceType returns the type of this Provider, namely, "gitea".
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+
+// FetchAccount satisfies the authz.Provider interface. It returns the Gitea
+// account with the same username as the given user, if any.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account, _ []string) (*extsvc.Account, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	giteaUser, err := p.client.GetUser(ctx, user.Username)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	accountData, err := json.Marshal(giteaUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   strconv.FormatInt(giteaUser.ID, 10),
+		},
+		AccountData: extsvc.AccountData{
+			Data: extsvc.NewUnencryptedData(accountData),
+		},
+	}, nil
+}
+
+// FetchUserPerms returns a list of repository IDs (on code host) that the given account
+// has read access on the code host. The repository ID has the same value as it would be
+// used as api.ExternalRepoSpec.ID. The returned list only includes private repository IDs.
+//
+// API docs: https://try.gitea.io/api/swagger#/user/userCurrentListRepos
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case account.Data == nil:
+		return nil, errors.New("no account data provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	var user gitea.User
+	if err := encryption.DecryptJSON(ctx, account.Data, &user); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling account data")
+	}
+
+	repos, err := p.client.ListReposForUser(ctx, user.Login)
+	if err != nil {
+		return nil, err
+	}
+
+	extIDs := make([]extsvc.RepoID, 0, len(repos))
+	for _, r := range repos {
+		if r.Private {
+			extIDs = append(extIDs, extsvc.RepoID(strconv.FormatInt(r.ID, 10)))
+		}
+	}
+
+	return &authz.ExternalUserPermissions{
+		Exacts: extIDs,
+	}, nil
+}
+
+// FetchRepoPerms returns a list of user IDs (on code host) who have read access to
+// the given repo on the code host. The user ID has the same value as it would
+// be used as extsvc.Account.AccountID. The returned list includes the owner of the
+// repository, its collaborators and the members of the teams with access to it.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+//
+// API docs: https://try.gitea.io/api/swagger#/repository/repoListCollaborators
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	switch {
+	case repo == nil:
+		return nil, errors.New("no repo provided")
+	case !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec):
+		return nil, errors.Errorf("not a code host of the repo: want %q but have %q",
+			p.codeHost.ServiceID, repo.ServiceID)
+	}
+
+	id, err := strconv.ParseInt(repo.ID, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing repo ID %q", repo.ID)
+	}
+
+	r, err := p.client.GetRepoByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int64]bool)
+	var ids []extsvc.AccountID
+	add := func(users ...*gitea.User) {
+		for _, u := range users {
+			if !seen[u.ID] {
+				seen[u.ID] = true
+				ids = append(ids, extsvc.AccountID(strconv.FormatInt(u.ID, 10)))
+			}
+		}
+	}
+
+	// The owner of a repository is either a user, who has access to it, or an
+	// organization, whose ID never matches that of a user account.
+	add(&r.Owner)
+
+	collaborators, err := p.client.ListCollaborators(ctx, r.Owner.Login, r.Name)
+	if err != nil {
+		return ids, errors.Wrap(err, "list collaborators")
+	}
+	add(collaborators...)
+
+	teams, err := p.client.ListRepoTeams(ctx, r.Owner.Login, r.Name)
+	if err != nil {
+		return ids, errors.Wrap(err, "list teams")
+	}
+	for _, t := range teams {
+		if t.Permission == "none" {
+			continue
+		}
+
+		members, err := p.client.ListTeamMembers(ctx, t.ID)
+		if err != nil {
+			return ids, errors.Wrapf(err, "list members of team %d", t.ID)
+		}
+		add(members...)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,243 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type mockClient struct {
+	users         map[string]*gitea.User
+	userRepos     map[string][]*gitea.Repository
+	repos         map[int64]*gitea.Repository
+	collaborators map[string][]*gitea.User
+	teams         map[string][]*gitea.Team
+	teamMembers   map[int64][]*gitea.User
+}
+
+type notFoundError struct{}
+
+func (notFoundError) Error() string  { return "not found" }
+func (notFoundError) NotFound() bool { return true }
+
+func (m *mockClient) GetUser(_ context.Context, username string) (*gitea.User, error) {
+	if u, ok := m.users[username]; ok {
+		return u, nil
+	}
+	return nil, notFoundError{}
+}
+
+func (m *mockClient) GetAuthenticatedUser(_ context.Context) (*gitea.User, error) {
+	return m.users["admin"], nil
+}
+
+func (m *mockClient) ListReposForUser(_ context.Context, username string) ([]*gitea.Repository, error) {
+	return m.userRepos[username], nil
+}
+
+func (m *mockClient) GetRepoByID(_ context.Context, id int64) (*gitea.Repository, error) {
+	if r, ok := m.repos[id]; ok {
+		return r, nil
+	}
+	return nil, notFoundError{}
+}
+
+func (m *mockClient) ListCollaborators(_ context.Context, owner, name string) ([]*gitea.User, error) {
+	return m.collaborators[owner+"/"+name], nil
+}
+
+func (m *mockClient) ListRepoTeams(_ context.Context, owner, name string) ([]*gitea.Team, error) {
+	return m.teams[owner+"/"+name], nil
+}
+
+func (m *mockClient) ListTeamMembers(_ context.Context, teamID int64) ([]*gitea.User, error) {
+	return m.teamMembers[teamID], nil
+}
+
+var (
+	alice = &gitea.User{ID: 1, Login: "alice"}
+	bob   = &gitea.User{ID: 2, Login: "bob"}
+	carol = &gitea.User{ID: 3, Login: "carol"}
+	acme  = gitea.User{ID: 100, Login: "acme"}
+)
+
+func newTestProvider(t *testing.T, cli client) *Provider {
+	t.Helper()
+
+	u, err := url.Parse("https://gitea.example.com")
+	require.NoError(t, err)
+
+	return &Provider{
+		urn:      "extsvc:gitea:1",
+		client:   cli,
+		codeHost: extsvc.NewCodeHost(u, extsvc.TypeGitea),
+	}
+}
+
+func TestNewAuthzProviders(t *testing.T) {
+	conn := func(authorization *schema.GiteaAuthorization) []*types.GiteaConnection {
+		return []*types.GiteaConnection{{
+			URN: "extsvc:gitea:1",
+			GiteaConnection: &schema.GiteaConnection{
+				Url:           "https://gitea.example.com",
+				Token:         "secret-token",
+				Authorization: authorization,
+			},
+		}}
+	}
+
+	t.Run("no authorization", func(t *testing.T) {
+		ps, problems, warnings, invalid := NewAuthzProviders(conn(nil))
+		assert.Empty(t, ps)
+		assert.Empty(t, problems)
+		assert.Empty(t, warnings)
+		assert.Empty(t, invalid)
+	})
+
+	t.Run("username identity", func(t *testing.T) {
+		licensing.MockCheckFeatureError("")
+		ps, problems, _, invalid := NewAuthzProviders(conn(&schema.GiteaAuthorization{
+			IdentityProvider: schema.GiteaIdentityProvider{Type: "username"},
+		}))
+		require.Len(t, ps, 1)
+		assert.Empty(t, problems)
+		assert.Empty(t, invalid)
+		assert.Equal(t, "https://gitea.example.com/", ps[0].ServiceID())
+		assert.Equal(t, extsvc.TypeGitea, ps[0].ServiceType())
+	})
+
+	t.Run("missing license", func(t *testing.T) {
+		licensing.MockCheckFeatureError("failed")
+		t.Cleanup(func() { licensing.MockCheckFeatureError("") })
+
+		ps, problems, _, invalid := NewAuthzProviders(conn(&schema.GiteaAuthorization{
+			IdentityProvider: schema.GiteaIdentityProvider{Type: "username"},
+		}))
+		assert.Empty(t, ps)
+		assert.Len(t, problems, 1)
+		assert.Equal(t, []string{extsvc.TypeGitea}, invalid)
+	})
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	p := newTestProvider(t, &mockClient{
+		users: map[string]*gitea.User{"alice": alice},
+	})
+	ctx := context.Background()
+
+	acct, err := p.FetchAccount(ctx, &types.User{ID: 42, Username: "alice"}, nil, nil)
+	require.NoError(t, err)
+	require.NotNil(t, acct)
+	assert.Equal(t, int32(42), acct.UserID)
+	assert.Equal(t, "1", acct.AccountID)
+	assert.Equal(t, extsvc.TypeGitea, acct.ServiceType)
+	assert.Equal(t, "https://gitea.example.com/", acct.ServiceID)
+
+	// Users without a Gitea account have no external account.
+	acct, err = p.FetchAccount(ctx, &types.User{ID: 43, Username: "mallory"}, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, acct)
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	p := newTestProvider(t, &mockClient{
+		userRepos: map[string][]*gitea.Repository{
+			"alice": {
+				{ID: 10, Private: true},
+				{ID: 11},
+				{ID: 12, Private: true},
+			},
+		},
+	})
+	ctx := context.Background()
+
+	data, err := json.Marshal(alice)
+	require.NoError(t, err)
+
+	account := &extsvc.Account{
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: extsvc.TypeGitea,
+			ServiceID:   "https://gitea.example.com/",
+			AccountID:   "1",
+		},
+		AccountData: extsvc.AccountData{Data: extsvc.NewUnencryptedData(data)},
+	}
+
+	perms, err := p.FetchUserPerms(ctx, account, authz.FetchPermsOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []extsvc.RepoID{"10", "12"}, perms.Exacts)
+
+	t.Run("other code host", func(t *testing.T) {
+		other := *account
+		other.ServiceID = "https://gitea.com/"
+		_, err := p.FetchUserPerms(ctx, &other, authz.FetchPermsOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	p := newTestProvider(t, &mockClient{
+		repos: map[int64]*gitea.Repository{
+			10: {ID: 10, Name: "api", Owner: acme},
+		},
+		collaborators: map[string][]*gitea.User{
+			"acme/api": {alice},
+		},
+		teams: map[string][]*gitea.Team{
+			"acme/api": {
+				{ID: 1, Name: "Owners", Permission: "owner"},
+				{ID: 2, Name: "Readers", Permission: "read"},
+				{ID: 3, Name: "Nobody", Permission: "none"},
+			},
+		},
+		teamMembers: map[int64][]*gitea.User{
+			1: {bob},
+			2: {alice, carol},
+			3: {{ID: 4, Login: "dave"}},
+		},
+	})
+	ctx := context.Background()
+
+	repo := &extsvc.Repository{
+		URI: "gitea.example.com/acme/api",
+		ExternalRepoSpec: api.ExternalRepoSpec{
+			ID:          "10",
+			ServiceType: extsvc.TypeGitea,
+			ServiceID:   "https://gitea.example.com/",
+		},
+	}
+
+	ids, err := p.FetchRepoPerms(ctx, repo, authz.FetchPermsOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []extsvc.AccountID{"100", "1", "2", "3"}, ids)
+
+	t.Run("unknown repo", func(t *testing.T) {
+		unknown := *repo
+		unknown.ID = "11"
+		_, err := p.FetchRepoPerms(ctx, &unknown, authz.FetchPermsOptions{})
+		assert.True(t, errcode.IsNotFound(err))
+	})
+}
+
+func TestProvider_ValidateConnection(t *testing.T) {
+	ctx := context.Background()
+
+	p := newTestProvider(t, &mockClient{users: map[string]*gitea.User{"admin": {ID: 1, Login: "admin", IsAdmin: true}}})
+	assert.Empty(t, p.ValidateConnection(ctx))
+
+	p = newTestProvider(t, &mockClient{users: map[string]*gitea.User{"admin": {ID: 1, Login: "admin"}}})
+	assert.Len(t, p.ValidateConnection(ctx), 1)
+}
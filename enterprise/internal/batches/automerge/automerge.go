@@ -0,0 +1,161 @@
+package automerge
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const evaluationInterval = 1 * time.Minute
+
+// NewMerger returns a background routine that periodically evaluates the
+// auto-merge policies of open batch changes, and enqueues merge changeset jobs
+// for the changesets that satisfy them. The changeset jobs are then processed
+// by the bulk operation worker, like manual merges. Every change in the
+// decision for a changeset is recorded as an auto-merge event.
+func NewMerger(ctx context.Context, logger log.Logger, s *store.Store) goroutine.BackgroundRoutine {
+	m := &merger{logger: logger, store: s}
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		evaluationInterval,
+		goroutine.NewHandlerWithErrorMessage("evaluating batch change auto-merge policies", m.evaluateAll),
+	)
+}
+
+type merger struct {
+	logger log.Logger
+	store  *store.Store
+}
+
+func (m *merger) evaluateAll(ctx context.Context) error {
+	policies, err := m.store.ListAutoMergePolicies(ctx)
+	if err != nil {
+		return errors.Wrap(err, "listing auto-merge policies")
+	}
+
+	var errs error
+	for _, policy := range policies {
+		if err := m.evaluate(ctx, policy); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "batch change %d", policy.BatchChangeID))
+		}
+	}
+	return errs
+}
+
+func (m *merger) evaluate(ctx context.Context, policy *btypes.AutoMergePolicy) (err error) {
+	now := m.store.Clock()()
+
+	tx, err := m.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// Merge events are only recorded when the decision for a changeset changes,
+	// so the merges of the last hour are counted from the merge jobs instead.
+	recentMerges, err := tx.CountChangesetJobs(ctx, store.CountChangesetJobsOpts{
+		BatchChangeID: policy.BatchChangeID,
+		JobType:       btypes.ChangesetJobTypeMerge,
+		CreatedAfter:  now.Add(-1 * time.Hour),
+	})
+	if err != nil {
+		return errors.Wrap(err, "counting recent merges")
+	}
+
+	e, err := newEvaluator(policy, recentMerges)
+	if err != nil {
+		return err
+	}
+
+	lastMergeJobs, err := tx.ListLatestChangesetJobs(ctx, policy.BatchChangeID, btypes.ChangesetJobTypeMerge)
+	if err != nil {
+		return errors.Wrap(err, "listing merge jobs")
+	}
+	lastMerges := make(map[int64]*btypes.ChangesetJob, len(lastMergeJobs))
+	for _, job := range lastMergeJobs {
+		lastMerges[job.ChangesetID] = job
+	}
+
+	published := btypes.ChangesetPublicationStatePublished
+	cs, _, err := tx.ListChangesets(ctx, store.ListChangesetsOpts{
+		BatchChangeID:    policy.BatchChangeID,
+		PublicationState: &published,
+		ExternalStates: []btypes.ChangesetExternalState{
+			btypes.ChangesetExternalStateOpen,
+			btypes.ChangesetExternalStateDraft,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changesets")
+	}
+
+	var bulkGroupID string
+	var jobs []*btypes.ChangesetJob
+	for _, changeset := range cs {
+		lastMerge := lastMerges[changeset.ID]
+		if lastMerge != nil && isPending(lastMerge) {
+			// The merge was already decided on, and it is yet to be carried out.
+			continue
+		}
+
+		decision, reason := e.decide(changeset, lastMerge, now)
+		if _, err := tx.CreateAutoMergeEvent(ctx, &btypes.AutoMergeEvent{
+			BatchChangeID: policy.BatchChangeID,
+			ChangesetID:   changeset.ID,
+			Decision:      decision,
+			Reason:        reason,
+		}); err != nil {
+			return errors.Wrap(err, "recording auto-merge event")
+		}
+
+		if decision != btypes.AutoMergeDecisionMerge {
+			continue
+		}
+
+		if bulkGroupID == "" {
+			if bulkGroupID, err = store.RandomID(); err != nil {
+				return errors.Wrap(err, "creating bulk group ID")
+			}
+		}
+		jobs = append(jobs, &btypes.ChangesetJob{
+			BulkGroup:     bulkGroupID,
+			ChangesetID:   changeset.ID,
+			BatchChangeID: policy.BatchChangeID,
+			UserID:        policy.UserID,
+			State:         btypes.ChangesetJobStateQueued,
+			JobType:       btypes.ChangesetJobTypeMerge,
+			Payload:       &btypes.ChangesetJobMergePayload{Squash: policy.Squash},
+		})
+	}
+
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	if err := tx.CreateChangesetJob(ctx, jobs...); err != nil {
+		return errors.Wrap(err, "creating changeset jobs")
+	}
+	m.logger.Info("enqueued auto-merges",
+		log.Int64("batchChangeID", policy.BatchChangeID),
+		log.Int("count", len(jobs)),
+	)
+
+	return nil
+}
+
+func isPending(job *btypes.ChangesetJob) bool {
+	switch jobState(job) {
+	case btypes.ChangesetJobStateQueued,
+		btypes.ChangesetJobStateProcessing,
+		btypes.ChangesetJobStateErrored:
+		return true
+	default:
+		return false
+	}
+}
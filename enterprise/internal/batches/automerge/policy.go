@@ -0,0 +1,90 @@
+package automerge
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// evaluator decides which changesets of a batch change are merged according to
+// its auto-merge policy. It counts the merges it decides on, so that the rate
+// limit of the policy also applies within a single evaluation.
+type evaluator struct {
+	policy  *btypes.AutoMergePolicy
+	windows *window.Configuration
+
+	// merges is the number of merges in the last hour.
+	merges int
+}
+
+func newEvaluator(policy *btypes.AutoMergePolicy, recentMerges int) (*evaluator, error) {
+	windows, err := window.NewConfiguration(&policy.Windows)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing maintenance windows")
+	}
+
+	return &evaluator{
+		policy:  policy,
+		windows: windows,
+		merges:  recentMerges,
+	}, nil
+}
+
+// decide returns whether the given changeset should be merged at the given
+// time, along with a human readable reason. lastMerge is the most recent merge
+// job of the changeset, if any, which must not be pending.
+func (e *evaluator) decide(cs *btypes.Changeset, lastMerge *btypes.ChangesetJob, now time.Time) (btypes.AutoMergeDecision, string) {
+	skip := func(format string, args ...any) (btypes.AutoMergeDecision, string) {
+		return btypes.AutoMergeDecisionSkip, fmt.Sprintf(format, args...)
+	}
+
+	switch cs.ExternalState {
+	case btypes.ChangesetExternalStateOpen:
+	case btypes.ChangesetExternalStateDraft:
+		return skip("changeset is a draft")
+	default:
+		return skip("changeset is %s", strings.ToLower(string(cs.ExternalState)))
+	}
+
+	if cs.ReconcilerState != btypes.ReconcilerStateCompleted {
+		return skip("changeset is being updated on the code host")
+	}
+
+	// A merge that failed is only retried once the changeset has changed since,
+	// as it would most likely fail again otherwise.
+	if lastMerge != nil && jobState(lastMerge) == btypes.ChangesetJobStateFailed && lastMerge.FinishedAt.After(cs.UpdatedAt) {
+		if lastMerge.FailureMessage != nil {
+			return skip("last merge attempt failed: %s", *lastMerge.FailureMessage)
+		}
+		return skip("last merge attempt failed")
+	}
+
+	if e.policy.RequirePassingChecks && cs.ExternalCheckState != btypes.ChangesetCheckStatePassed {
+		return skip("checks are %s", strings.ToLower(string(cs.ExternalCheckState)))
+	}
+
+	if e.policy.RequireApproval && cs.ExternalReviewState != btypes.ChangesetReviewStateApproved {
+		return skip("changeset is not approved (review state: %s)", strings.ToLower(string(cs.ExternalReviewState)))
+	}
+
+	if !e.windows.IsOpen(now) {
+		return skip("outside of the maintenance windows")
+	}
+
+	if e.policy.MaxMergesPerHour > 0 && e.merges >= int(e.policy.MaxMergesPerHour) {
+		return skip("limit of %d merges per hour reached", e.policy.MaxMergesPerHour)
+	}
+
+	e.merges++
+	return btypes.AutoMergeDecisionMerge, "all conditions of the auto-merge policy are met"
+}
+
+// jobState returns the state of the given changeset job in the application
+// representation, as it is scanned in its database representation.
+func jobState(job *btypes.ChangesetJob) btypes.ChangesetJobState {
+	return btypes.ChangesetJobState(strings.ToUpper(string(job.State)))
+}
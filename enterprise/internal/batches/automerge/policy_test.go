@@ -0,0 +1,154 @@
+package automerge
+
+import (
+	"testing"
+	"time"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestEvaluatorDecide(t *testing.T) {
+	// 2022-10-25 is a Tuesday.
+	now := time.Date(2022, 10, 25, 12, 30, 0, 0, time.UTC)
+	failureMessage := "merge conflict"
+
+	mergeable := func() *btypes.Changeset {
+		return &btypes.Changeset{
+			ExternalState:       btypes.ChangesetExternalStateOpen,
+			ExternalCheckState:  btypes.ChangesetCheckStatePassed,
+			ExternalReviewState: btypes.ChangesetReviewStateApproved,
+			ReconcilerState:     btypes.ReconcilerStateCompleted,
+			UpdatedAt:           now.Add(-2 * time.Hour),
+		}
+	}
+	with := func(f func(cs *btypes.Changeset)) *btypes.Changeset {
+		cs := mergeable()
+		f(cs)
+		return cs
+	}
+
+	for name, tc := range map[string]struct {
+		policy       btypes.AutoMergePolicy
+		recentMerges int
+		changeset    *btypes.Changeset
+		lastMerge    *btypes.ChangesetJob
+		wantDecision btypes.AutoMergeDecision
+		wantReason   string
+	}{
+		"mergeable": {
+			policy:       btypes.AutoMergePolicy{RequirePassingChecks: true, RequireApproval: true},
+			changeset:    mergeable(),
+			wantDecision: btypes.AutoMergeDecisionMerge,
+			wantReason:   "all conditions of the auto-merge policy are met",
+		},
+		"draft": {
+			changeset:    with(func(cs *btypes.Changeset) { cs.ExternalState = btypes.ChangesetExternalStateDraft }),
+			wantDecision: btypes.AutoMergeDecisionSkip,
+			wantReason:   "changeset is a draft",
+		},
+		"closed": {
+			changeset:    with(func(cs *btypes.Changeset) { cs.ExternalState = btypes.ChangesetExternalStateClosed }),
+			wantDecision: btypes.AutoMergeDecisionSkip,
+			wantReason:   "changeset is closed",
+		},
+		"being reconciled": {
+			changeset:    with(func(cs *btypes.Changeset) { cs.ReconcilerState = btypes.ReconcilerStateQueued }),
+			wantDecision: btypes.AutoMergeDecisionSkip,
+			wantReason:   "changeset is being updated on the code host",
+		},
+		"pending checks": {
+			policy:       btypes.AutoMergePolicy{RequirePassingChecks: true},
+			changeset:    with(func(cs *btypes.Changeset) { cs.ExternalCheckState = btypes.ChangesetCheckStatePending }),
+			wantDecision: btypes.AutoMergeDecisionSkip,
+			wantReason:   "checks are pending",
+		},
+		"pending checks not required": {
+			changeset:    with(func(cs *btypes.Changeset) { cs.ExternalCheckState = btypes.ChangesetCheckStatePending }),
+			wantDecision: btypes.AutoMergeDecisionMerge,
+			wantReason:   "all conditions of the auto-merge policy are met",
+		},
+		"changes requested": {
+			policy:       btypes.AutoMergePolicy{RequireApproval: true},
+			changeset:    with(func(cs *btypes.Changeset) { cs.ExternalReviewState = btypes.ChangesetReviewStateChangesRequested }),
+			wantDecision: btypes.AutoMergeDecisionSkip,
+			wantReason:   "changeset is not approved (review state: changes_requested)",
+		},
+		"outside of the maintenance windows": {
+			policy: btypes.AutoMergePolicy{Windows: []*schema.BatchChangeRolloutWindow{
+				{Days: []string{"saturday", "sunday"}, Rate: "unlimited"},
+			}},
+			changeset:    mergeable(),
+			wantDecision: btypes.AutoMergeDecisionSkip,
+			wantReason:   "outside of the maintenance windows",
+		},
+		"inside a maintenance window": {
+			policy: btypes.AutoMergePolicy{Windows: []*schema.BatchChangeRolloutWindow{
+				{Days: []string{"tuesday"}, Start: "12:00", End: "13:00", Rate: "unlimited"},
+			}},
+			changeset:    mergeable(),
+			wantDecision: btypes.AutoMergeDecisionMerge,
+			wantReason:   "all conditions of the auto-merge policy are met",
+		},
+		"rate limited": {
+			policy:       btypes.AutoMergePolicy{MaxMergesPerHour: 3},
+			recentMerges: 3,
+			changeset:    mergeable(),
+			wantDecision: btypes.AutoMergeDecisionSkip,
+			wantReason:   "limit of 3 merges per hour reached",
+		},
+		"failed merge": {
+			changeset:    mergeable(),
+			lastMerge:    &btypes.ChangesetJob{State: "failed", FailureMessage: &failureMessage, FinishedAt: now.Add(-1 * time.Hour)},
+			wantDecision: btypes.AutoMergeDecisionSkip,
+			wantReason:   "last merge attempt failed: merge conflict",
+		},
+		"failed merge before the last update": {
+			changeset:    mergeable(),
+			lastMerge:    &btypes.ChangesetJob{State: "failed", FailureMessage: &failureMessage, FinishedAt: now.Add(-3 * time.Hour)},
+			wantDecision: btypes.AutoMergeDecisionMerge,
+			wantReason:   "all conditions of the auto-merge policy are met",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e, err := newEvaluator(&tc.policy, tc.recentMerges)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decision, reason := e.decide(tc.changeset, tc.lastMerge, now)
+			if decision != tc.wantDecision {
+				t.Errorf("unexpected decision: have=%q want=%q", decision, tc.wantDecision)
+			}
+			if reason != tc.wantReason {
+				t.Errorf("unexpected reason: have=%q want=%q", reason, tc.wantReason)
+			}
+		})
+	}
+
+	t.Run("rate limit within an evaluation", func(t *testing.T) {
+		e, err := newEvaluator(&btypes.AutoMergePolicy{MaxMergesPerHour: 2}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var merged int
+		for i := 0; i < 3; i++ {
+			if decision, _ := e.decide(mergeable(), nil, now); decision == btypes.AutoMergeDecisionMerge {
+				merged++
+			}
+		}
+		if merged != 1 {
+			t.Errorf("unexpected number of merges: have=%d want=1", merged)
+		}
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		_, err := newEvaluator(&btypes.AutoMergePolicy{Windows: []*schema.BatchChangeRolloutWindow{
+			{Days: []string{"someday"}, Rate: "unlimited"},
+		}}, 0)
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+}
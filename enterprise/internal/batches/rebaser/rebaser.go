@@ -0,0 +1,228 @@
+package rebaser
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	evaluationInterval = 1 * time.Minute
+
+	// minRebaseInterval is the minimum time between two rebases of the same
+	// batch change, so that a base branch that moves frequently doesn't cause
+	// a constant stream of executions and force-pushes.
+	minRebaseInterval = 1 * time.Hour
+)
+
+// NewRebaser returns a background routine that periodically re-executes the
+// batch specs of open batch changes with automatic rebases enabled, once some
+// of their changesets are outdated or conflicting with their base branch.
+//
+// A rebase creates a new batch spec from the raw spec of the applied one,
+// which resolves the workspaces on the current base commits. Workspaces whose
+// steps and base commit are unchanged are served from the execution cache.
+// Once the execution completed, the batch spec is applied, which makes the
+// reconciler force-push the changesets whose base commit changed.
+func NewRebaser(ctx context.Context, logger log.Logger, s *store.Store) goroutine.BackgroundRoutine {
+	r := &rebaser{logger: logger, store: s}
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		evaluationInterval,
+		goroutine.NewHandlerWithErrorMessage("rebasing batch changes", r.rebaseAll),
+	)
+}
+
+type rebaser struct {
+	logger log.Logger
+	store  *store.Store
+}
+
+func (r *rebaser) rebaseAll(ctx context.Context) error {
+	rebases, err := r.store.ListAutoRebases(ctx)
+	if err != nil {
+		return errors.Wrap(err, "listing auto-rebases")
+	}
+
+	var errs error
+	for _, rebase := range rebases {
+		if err := r.rebase(ctx, rebase); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "batch change %d", rebase.BatchChangeID))
+		}
+	}
+	return errs
+}
+
+func (r *rebaser) rebase(ctx context.Context, rebase *btypes.AutoRebase) error {
+	batchChange, err := r.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: rebase.BatchChangeID})
+	if err != nil {
+		return errors.Wrap(err, "getting batch change")
+	}
+	appliedSpec, err := r.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return errors.Wrap(err, "getting applied batch spec")
+	}
+	// Batch specs that were executed with src-cli cannot be re-executed
+	// server-side.
+	if !appliedSpec.CreatedFromRaw {
+		return nil
+	}
+
+	// Everything is done on behalf of the user that enabled the rebases, so
+	// that the same permission checks apply as if they re-executed the batch
+	// spec themselves.
+	ctx = actor.WithActor(ctx, actor.FromUser(rebase.UserID))
+	svc := service.New(r.store)
+
+	// Batch spec IDs are increasing, so a rebase batch spec with a higher ID
+	// than the applied one is still in progress.
+	if rebase.BatchSpecID > appliedSpec.ID {
+		done, err := r.advance(ctx, svc, batchChange, rebase.BatchSpecID)
+		if err != nil || !done {
+			return err
+		}
+	}
+
+	if rebase.BatchSpecID != 0 && r.store.Clock()().Sub(rebase.UpdatedAt) < minRebaseInterval {
+		return nil
+	}
+
+	stale, err := r.needsRebase(ctx, batchChange.ID)
+	if err != nil || !stale {
+		return err
+	}
+
+	spec, err := svc.CreateBatchSpecFromRaw(ctx, service.CreateBatchSpecFromRawOpts{
+		RawSpec:          appliedSpec.RawSpec,
+		NamespaceUserID:  appliedSpec.NamespaceUserID,
+		NamespaceOrgID:   appliedSpec.NamespaceOrgID,
+		AllowIgnored:     appliedSpec.AllowIgnored,
+		AllowUnsupported: appliedSpec.AllowUnsupported,
+		BatchChange:      batchChange.ID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "creating batch spec")
+	}
+
+	rebase.BatchSpecID = spec.ID
+	if err := r.store.UpsertAutoRebase(ctx, rebase); err != nil {
+		return errors.Wrap(err, "updating auto-rebase")
+	}
+	r.logger.Info("started rebase",
+		log.Int64("batchChangeID", batchChange.ID),
+		log.Int64("batchSpecID", spec.ID),
+	)
+
+	return nil
+}
+
+// needsRebase returns whether the changesets of the given batch change need
+// to be rebased, which is the case if some of its published changesets are
+// outdated or conflicting and none of them are being updated on the code
+// host.
+func (r *rebaser) needsRebase(ctx context.Context, batchChangeID int64) (bool, error) {
+	reconciling, err := r.store.CountChangesets(ctx, store.CountChangesetsOpts{
+		BatchChangeID:        batchChangeID,
+		OwnedByBatchChangeID: batchChangeID,
+		ReconcilerStates: []btypes.ReconcilerState{
+			btypes.ReconcilerStateScheduled,
+			btypes.ReconcilerStateQueued,
+			btypes.ReconcilerStateProcessing,
+			btypes.ReconcilerStateErrored,
+		},
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "counting changesets being reconciled")
+	}
+	if reconciling > 0 {
+		return false, nil
+	}
+
+	published := btypes.ChangesetPublicationStatePublished
+	stale, err := r.store.CountChangesets(ctx, store.CountChangesetsOpts{
+		BatchChangeID:        batchChangeID,
+		OwnedByBatchChangeID: batchChangeID,
+		PublicationState:     &published,
+		ExternalStates: []btypes.ChangesetExternalState{
+			btypes.ChangesetExternalStateOpen,
+			btypes.ChangesetExternalStateDraft,
+		},
+		BaseStates: []btypes.ChangesetBaseState{
+			btypes.ChangesetBaseStateOutdated,
+			btypes.ChangesetBaseStateConflicting,
+		},
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "counting stale changesets")
+	}
+	return stale > 0, nil
+}
+
+// advance moves the rebase of the given batch change forward by executing or
+// applying the batch spec that was created for it. It returns true if the
+// rebase is over, either because the batch spec was applied or because it
+// cannot be applied.
+func (r *rebaser) advance(ctx context.Context, svc *service.Service, batchChange *btypes.BatchChange, batchSpecID int64) (bool, error) {
+	spec, err := r.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchSpecID})
+	if err != nil {
+		return false, errors.Wrap(err, "getting batch spec")
+	}
+	resolutionJob, err := r.store.GetBatchSpecResolutionJob(ctx, store.GetBatchSpecResolutionJobOpts{BatchSpecID: spec.ID})
+	if err != nil {
+		return false, errors.Wrap(err, "getting resolution job")
+	}
+	stats, err := r.store.GetBatchSpecStats(ctx, []int64{spec.ID})
+	if err != nil {
+		return false, errors.Wrap(err, "getting batch spec stats")
+	}
+
+	next := decideStep(spec, resolutionJob.State, stats[spec.ID])
+	if next == stepExecute {
+		if _, err := svc.ExecuteBatchSpec(ctx, service.ExecuteBatchSpecOpts{BatchSpecRandID: spec.RandID}); err != nil {
+			return false, errors.Wrap(err, "executing batch spec")
+		}
+		stats, err := r.store.GetBatchSpecStats(ctx, []int64{spec.ID})
+		if err != nil {
+			return false, errors.Wrap(err, "getting batch spec stats")
+		}
+		// If all workspaces have a cached result, no execution is needed and
+		// the batch spec can be applied right away.
+		if stats[spec.ID].Executions > 0 {
+			return false, nil
+		}
+		next = stepApply
+	}
+
+	switch next {
+	case stepApply:
+		if _, err := svc.ApplyBatchChange(ctx, service.ApplyBatchChangeOpts{
+			BatchSpecRandID:     spec.RandID,
+			EnsureBatchChangeID: batchChange.ID,
+		}); err != nil {
+			return false, errors.Wrap(err, "applying batch spec")
+		}
+		r.logger.Info("applied rebase",
+			log.Int64("batchChangeID", batchChange.ID),
+			log.Int64("batchSpecID", spec.ID),
+		)
+		return true, nil
+
+	case stepAbandon:
+		r.logger.Debug("abandoned rebase",
+			log.Int64("batchChangeID", batchChange.ID),
+			log.Int64("batchSpecID", spec.ID),
+		)
+		return true, nil
+
+	default:
+		return false, nil
+	}
+}
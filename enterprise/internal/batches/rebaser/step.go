@@ -0,0 +1,45 @@
+package rebaser
+
+import (
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+// step is the next step of a rebase.
+type step int
+
+const (
+	// stepWait means the batch spec is still being resolved or executed.
+	stepWait step = iota
+	// stepExecute means the workspaces are resolved, but not executed yet.
+	// Workspaces with a cached result are not executed, so there might be
+	// nothing to execute at all.
+	stepExecute
+	// stepApply means the batch spec is ready to be applied.
+	stepApply
+	// stepAbandon means the batch spec cannot be applied, because resolving
+	// or executing its workspaces failed or was canceled.
+	stepAbandon
+)
+
+// decideStep returns the next step of a rebase, based on the state of the
+// batch spec that was created for it.
+func decideStep(spec *btypes.BatchSpec, resolution btypes.BatchSpecResolutionJobState, stats btypes.BatchSpecStats) step {
+	switch resolution {
+	case btypes.BatchSpecResolutionJobStateCompleted:
+	case btypes.BatchSpecResolutionJobStateFailed:
+		return stepAbandon
+	default:
+		return stepWait
+	}
+
+	switch btypes.ComputeBatchSpecState(spec, stats) {
+	case btypes.BatchSpecStatePending:
+		return stepExecute
+	case btypes.BatchSpecStateCompleted:
+		return stepApply
+	case btypes.BatchSpecStateFailed, btypes.BatchSpecStateCanceled:
+		return stepAbandon
+	default:
+		return stepWait
+	}
+}
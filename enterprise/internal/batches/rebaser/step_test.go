@@ -0,0 +1,67 @@
+package rebaser
+
+import (
+	"testing"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+func TestDecideStep(t *testing.T) {
+	spec := &btypes.BatchSpec{CreatedFromRaw: true}
+
+	for name, tc := range map[string]struct {
+		resolution btypes.BatchSpecResolutionJobState
+		stats      btypes.BatchSpecStats
+		want       step
+	}{
+		"resolving": {
+			resolution: btypes.BatchSpecResolutionJobStateProcessing,
+			want:       stepWait,
+		},
+		"resolution errored": {
+			resolution: btypes.BatchSpecResolutionJobStateErrored,
+			want:       stepWait,
+		},
+		"resolution failed": {
+			resolution: btypes.BatchSpecResolutionJobStateFailed,
+			stats:      btypes.BatchSpecStats{ResolutionDone: true},
+			want:       stepAbandon,
+		},
+		"resolved": {
+			resolution: btypes.BatchSpecResolutionJobStateCompleted,
+			stats:      btypes.BatchSpecStats{ResolutionDone: true, Workspaces: 3, CachedWorkspaces: 1},
+			want:       stepExecute,
+		},
+		"no workspaces": {
+			resolution: btypes.BatchSpecResolutionJobStateCompleted,
+			stats:      btypes.BatchSpecStats{ResolutionDone: true},
+			want:       stepApply,
+		},
+		"executing": {
+			resolution: btypes.BatchSpecResolutionJobStateCompleted,
+			stats:      btypes.BatchSpecStats{ResolutionDone: true, Workspaces: 3, Executions: 2, Completed: 1, Processing: 1},
+			want:       stepWait,
+		},
+		"executed": {
+			resolution: btypes.BatchSpecResolutionJobStateCompleted,
+			stats:      btypes.BatchSpecStats{ResolutionDone: true, Workspaces: 3, Executions: 2, Completed: 2},
+			want:       stepApply,
+		},
+		"execution failed": {
+			resolution: btypes.BatchSpecResolutionJobStateCompleted,
+			stats:      btypes.BatchSpecStats{ResolutionDone: true, Workspaces: 3, Executions: 2, Completed: 1, Failed: 1},
+			want:       stepAbandon,
+		},
+		"execution canceled": {
+			resolution: btypes.BatchSpecResolutionJobStateCompleted,
+			stats:      btypes.BatchSpecStats{ResolutionDone: true, Workspaces: 3, Executions: 2, Canceled: 2},
+			want:       stepAbandon,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := decideStep(spec, tc.resolution, tc.stats); have != tc.want {
+				t.Fatalf("unexpected step: have=%d want=%d", have, tc.want)
+			}
+		})
+	}
+}
@@ -39,6 +39,7 @@ func executePlan(ctx context.Context, logger log.Logger, client gitserver.Client
 		tx:                tx,
 		ch:                plan.Changeset,
 		spec:              plan.ChangesetSpec,
+		delta:             plan.Delta,
 	}
 
 	return e.Run(ctx, plan)
@@ -52,6 +53,7 @@ type executor struct {
 	tx                *store.Store
 	ch                *btypes.Changeset
 	spec              *btypes.ChangesetSpec
+	delta             *ChangesetSpecDelta
 
 	// targetRepo represents the repo where the changeset should be opened.
 	targetRepo *types.Repo
@@ -192,6 +194,27 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 		return err
 	}
 
+	// Code hosts like Gerrit identify a changeset by its commit, which means
+	// the commit carries its title and body, and is pushed to a review ref.
+	if rcss, ok := css.(sources.ReviewRefChangesetSource); ok {
+		body, err := e.decorateChangesetBody(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "decorating body for changeset %d", e.ch.ID)
+		}
+
+		cs := &sources.Changeset{
+			Title:      e.spec.Title,
+			Body:       body,
+			BaseRef:    e.spec.BaseRef,
+			HeadRef:    e.spec.HeadRef,
+			RemoteRepo: remoteRepo,
+			TargetRepo: e.targetRepo,
+			Changeset:  e.ch,
+		}
+		opts.PushRef = rcss.PushRef(cs)
+		opts.CommitInfo.Message = rcss.CommitMessage(cs)
+	}
+
 	err = e.pushCommit(ctx, opts)
 	var pce pushCommitError
 	if errors.As(err, &pce) {
@@ -234,7 +257,19 @@ func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (err erro
 		HeadRef:    e.spec.HeadRef,
 		RemoteRepo: remoteRepo,
 		TargetRepo: e.targetRepo,
-		Changeset:  e.ch,
+		Attributes: sources.ChangesetAttributes{
+			Labels:    e.spec.Labels,
+			Reviewers: e.spec.Reviewers,
+			Assignees: e.spec.Assignees,
+			Milestone: e.spec.Milestone,
+		},
+		Changeset: e.ch,
+	}
+
+	// Fail before the changeset is created if the code host can't apply the
+	// attributes of the changeset template.
+	if err := sources.ValidateChangesetAttributes(css, cs.Attributes); err != nil {
+		return err
 	}
 
 	var exists bool
@@ -272,6 +307,11 @@ func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (err erro
 			}
 		}
 	}
+
+	if err := updateChangesetAttributes(ctx, css, cs); err != nil {
+		return err
+	}
+
 	// Set the changeset to published.
 	e.ch.PublicationState = btypes.ChangesetPublicationStatePublished
 	return nil
@@ -352,19 +392,51 @@ func (e *executor) updateChangeset(ctx context.Context) (err error) {
 		HeadRef:    e.spec.HeadRef,
 		RemoteRepo: remoteRepo,
 		TargetRepo: e.targetRepo,
+		Attributes: e.updatedChangesetAttributes(),
 		Changeset:  e.ch,
 	}
 
+	if err := sources.ValidateChangesetAttributes(css, cs.Attributes); err != nil {
+		return err
+	}
+
 	if err := css.UpdateChangeset(ctx, &cs); err != nil {
 		if errcode.IsArchived(err) {
-			if err := e.handleArchivedRepo(ctx); err != nil {
-				return err
-			}
-		} else {
-			return errors.Wrap(err, "updating changeset")
+			return e.handleArchivedRepo(ctx)
 		}
+		return errors.Wrap(err, "updating changeset")
 	}
 
+	return updateChangesetAttributes(ctx, css, &cs)
+}
+
+// updatedChangesetAttributes returns the attributes to apply when updating the
+// changeset: those of the spec that are missing on the code host, see
+// driftedAttributes, and the labels and assignees that were removed from the
+// spec.
+func (e *executor) updatedChangesetAttributes() sources.ChangesetAttributes {
+	attrs := driftedAttributes(e.spec, e.ch)
+	if e.delta != nil {
+		attrs.RemovedLabels = e.delta.RemovedLabels
+		attrs.RemovedAssignees = e.delta.RemovedAssignees
+	}
+	return attrs
+}
+
+// updateChangesetAttributes applies the attributes of the given changeset,
+// which must have been validated with sources.ValidateChangesetAttributes.
+func updateChangesetAttributes(ctx context.Context, css sources.ChangesetSource, cs *sources.Changeset) error {
+	if cs.Attributes.IsEmpty() {
+		return nil
+	}
+	acss, ok := css.(sources.AttributesChangesetSource)
+	if !ok {
+		return sources.ValidateChangesetAttributes(css, cs.Attributes)
+	}
+
+	if err := acss.UpdateChangesetAttributes(ctx, cs); err != nil {
+		return errors.Wrap(err, "updating changeset attributes")
+	}
 	return nil
 }
 
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -248,6 +249,12 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 			}
 		}
 
+		// Attributes that were changed on the code host are applied again,
+		// unless an update is already planned, which applies them anyway.
+		if !delta.NeedCodeHostUpdate() && attributesDrifted(currentSpec, wantedChangeset) {
+			pl.AddOp(btypes.ReconcilerOperationUpdate)
+		}
+
 	default:
 		return pl, errors.Errorf("unknown changeset publication state: %s", wantedChangeset.PublicationState)
 	}
@@ -255,6 +262,70 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 	return pl, nil
 }
 
+// attributesDrifted returns true if the changeset on the code host is missing
+// labels, assignees or reviewers of the changeset spec, or has a different
+// milestone.
+func attributesDrifted(spec *btypes.ChangesetSpec, ch *btypes.Changeset) bool {
+	// Only open changesets are updated on the code host.
+	if ch.ExternalState != btypes.ChangesetExternalStateOpen && ch.ExternalState != btypes.ChangesetExternalStateDraft {
+		return false
+	}
+	return !driftedAttributes(spec, ch).IsEmpty()
+}
+
+// driftedAttributes returns the labels, assignees and reviewers of the
+// changeset spec that the changeset on the code host is missing, and the
+// milestone of the spec if the changeset has a different one. Reviewers that
+// already reviewed the changeset are not missing, so that their review isn't
+// requested again. If the metadata of the changeset doesn't hold an attribute,
+// all its values in the spec are returned.
+func driftedAttributes(spec *btypes.ChangesetSpec, ch *btypes.Changeset) sources.ChangesetAttributes {
+	attrs := sources.ChangesetAttributes{
+		Labels:    spec.Labels,
+		Assignees: spec.Assignees,
+		Reviewers: spec.Reviewers,
+		Milestone: spec.Milestone,
+	}
+
+	if ch.SupportsLabels() {
+		labels := ch.Labels()
+		names := make([]string, len(labels))
+		for i, l := range labels {
+			names[i] = l.Name
+		}
+		attrs.Labels = missingAttributeValues(spec.Labels, names)
+	}
+	if assignees, ok := ch.Assignees(); ok {
+		attrs.Assignees = missingAttributeValues(spec.Assignees, assignees)
+	}
+	if reviewers, ok := ch.Reviewers(); ok {
+		attrs.Reviewers = missingAttributeValues(spec.Reviewers, reviewers)
+	}
+	if milestone, ok := ch.Milestone(); ok && strings.EqualFold(milestone, spec.Milestone) {
+		attrs.Milestone = ""
+	}
+
+	return attrs
+}
+
+// missingAttributeValues returns the wanted values that are not in current.
+// Code hosts treat names of users and labels case-insensitively, so they are
+// compared case-insensitively.
+func missingAttributeValues(wanted, current []string) []string {
+	have := make(map[string]struct{}, len(current))
+	for _, v := range current {
+		have[strings.ToLower(v)] = struct{}{}
+	}
+
+	var missing []string
+	for _, v := range wanted {
+		if _, ok := have[strings.ToLower(v)]; !ok {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
+
 func reopenAfterDetach(ch *btypes.Changeset) bool {
 	closed := ch.ExternalState == btypes.ChangesetExternalStateClosed ||
 		ch.ExternalState == btypes.ChangesetExternalStateReadOnly
@@ -293,6 +364,20 @@ func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicatio
 	if previous.BaseRef != current.BaseRef {
 		delta.BaseRefChanged = true
 	}
+	if !stringSetsEqual(previous.Labels, current.Labels) {
+		delta.LabelsChanged = true
+		delta.RemovedLabels = stringSetDifference(previous.Labels, current.Labels)
+	}
+	if !stringSetsEqual(previous.Reviewers, current.Reviewers) {
+		delta.ReviewersChanged = true
+	}
+	if !stringSetsEqual(previous.Assignees, current.Assignees) {
+		delta.AssigneesChanged = true
+		delta.RemovedAssignees = stringSetDifference(previous.Assignees, current.Assignees)
+	}
+	if previous.Milestone != current.Milestone {
+		delta.MilestoneChanged = true
+	}
 
 	// If was set to "draft" and now "true", need to undraft the changeset.
 	// We currently ignore going from "true" to "draft".
@@ -309,6 +394,12 @@ func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicatio
 		delta.DiffChanged = true
 	}
 
+	// The diff was computed against a different base commit, for example
+	// because the batch spec was re-executed after the base branch moved on.
+	if previous.BaseRev != current.BaseRev {
+		delta.BaseRevChanged = true
+	}
+
 	// CommitMessage
 	currentCommitMessage := current.CommitMessage
 	previousCommitMessage := previous.CommitMessage
@@ -339,21 +430,59 @@ type ChangesetSpecDelta struct {
 	Undraft              bool
 	BaseRefChanged       bool
 	DiffChanged          bool
+	BaseRevChanged       bool
 	CommitMessageChanged bool
 	AuthorNameChanged    bool
 	AuthorEmailChanged   bool
+	LabelsChanged        bool
+	ReviewersChanged     bool
+	AssigneesChanged     bool
+	MilestoneChanged     bool
+
+	// RemovedLabels and RemovedAssignees are the labels and assignees of the
+	// previous spec that are not in the current spec.
+	RemovedLabels    []string
+	RemovedAssignees []string
 }
 
 func (d *ChangesetSpecDelta) String() string { return fmt.Sprintf("%#v", d) }
 
 func (d *ChangesetSpecDelta) NeedCommitUpdate() bool {
-	return d.DiffChanged || d.CommitMessageChanged || d.AuthorNameChanged || d.AuthorEmailChanged
+	return d.DiffChanged || d.BaseRevChanged || d.CommitMessageChanged || d.AuthorNameChanged || d.AuthorEmailChanged
 }
 
 func (d *ChangesetSpecDelta) NeedCodeHostUpdate() bool {
-	return d.TitleChanged || d.BodyChanged || d.BaseRefChanged
+	return d.TitleChanged || d.BodyChanged || d.BaseRefChanged || d.NeedAttributesUpdate()
+}
+
+// NeedAttributesUpdate returns true if the labels, reviewers, assignees or
+// milestone of the changeset need to be updated on the code host.
+func (d *ChangesetSpecDelta) NeedAttributesUpdate() bool {
+	return d.LabelsChanged || d.ReviewersChanged || d.AssigneesChanged || d.MilestoneChanged
 }
 
 func (d *ChangesetSpecDelta) AttributesChanged() bool {
 	return d.NeedCommitUpdate() || d.NeedCodeHostUpdate()
 }
+
+// stringSetsEqual returns true if a and b contain the same strings, ignoring
+// order and duplicates.
+func stringSetsEqual(a, b []string) bool {
+	return len(stringSetDifference(a, b)) == 0 && len(stringSetDifference(b, a)) == 0
+}
+
+// stringSetDifference returns the strings of a that are not in b.
+func stringSetDifference(a, b []string) []string {
+	inB := make(map[string]struct{}, len(b))
+	for _, s := range b {
+		inB[s] = struct{}{}
+	}
+
+	var diff []string
+	for _, s := range a {
+		if _, ok := inB[s]; !ok {
+			diff = append(diff, s)
+		}
+	}
+	return diff
+}
@@ -6,6 +6,9 @@ import (
 	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 )
 
 func TestDetermineReconcilerPlan(t *testing.T) {
@@ -229,6 +232,176 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 			// We expect a no-op here.
 			wantOperations: Operations{},
 		},
+		{
+			name:         "labels changed on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, Labels: []string{"before"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Labels: []string{"after"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "reviewers changed on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice", "bob"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "labels removed on the code host",
+			previousSpec: &bt.TestSpecOpts{Published: true, Labels: []string{"batch-change", "frontend"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Labels: []string{"frontend", "batch-change"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				Metadata: &github.PullRequest{
+					Labels: struct{ Nodes []github.Label }{Nodes: []github.Label{{Name: "frontend"}}},
+				},
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "labels present on the code host",
+			previousSpec: &bt.TestSpecOpts{Published: true, Labels: []string{"frontend"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Labels: []string{"frontend"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				Metadata: &github.PullRequest{
+					Labels: struct{ Nodes []github.Label }{Nodes: []github.Label{{Name: "frontend"}, {Name: "needs-review"}}},
+				},
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "labels removed on a merged changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, Labels: []string{"frontend"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Labels: []string{"frontend"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateMerged,
+				Metadata:         &github.PullRequest{},
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "assignees removed on the code host",
+			previousSpec: &bt.TestSpecOpts{Published: true, Assignees: []string{"alice", "bob"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Assignees: []string{"alice", "bob"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				Metadata: &github.PullRequest{
+					Assignees: struct{ Nodes []github.Actor }{Nodes: []github.Actor{{Login: "alice"}}},
+				},
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "assignees present on the code host",
+			previousSpec: &bt.TestSpecOpts{Published: true, Assignees: []string{"alice"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Assignees: []string{"alice"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				Metadata: &gitlab.MergeRequest{
+					Assignees: []gitlab.User{{Username: "Alice"}, {Username: "carol"}},
+				},
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "review request removed on the code host",
+			previousSpec: &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice", "org/team"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice", "org/team"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				Metadata: &github.PullRequest{
+					TimelineItems: []github.TimelineItem{
+						{Type: "ReviewRequestedEvent", Item: &github.ReviewRequestedEvent{RequestedReviewer: github.Actor{Login: "alice"}}},
+						{Type: "ReviewRequestedEvent", Item: &github.ReviewRequestedEvent{RequestedTeam: github.Team{Slug: "team", Organization: &github.Org{Login: "org"}}}},
+						{Type: "ReviewRequestRemovedEvent", Item: &github.ReviewRequestRemovedEvent{RequestedReviewer: github.Actor{Login: "alice"}}},
+					},
+				},
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "reviewers requested or reviewed on the code host",
+			previousSpec: &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice", "bob", "org/team"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice", "bob", "org/team"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				Metadata: &github.PullRequest{
+					TimelineItems: []github.TimelineItem{
+						{Type: "ReviewRequestedEvent", Item: &github.ReviewRequestedEvent{RequestedReviewer: github.Actor{Login: "alice"}}},
+						{Type: "ReviewRequestedEvent", Item: &github.ReviewRequestedEvent{RequestedTeam: github.Team{Slug: "team", Organization: &github.Org{Login: "org"}}}},
+						// GitHub removes the review request of bob once he
+						// reviewed, without a ReviewRequestRemovedEvent.
+						{Type: "PullRequestReview", Item: &github.PullRequestReview{Author: github.Actor{Login: "bob"}}},
+					},
+				},
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "reviewers removed on a Bitbucket Server pull request",
+			previousSpec: &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice", "bob"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice", "bob"}},
+			changeset: bt.TestChangesetOpts{
+				ExternalServiceType: extsvc.TypeBitbucketServer,
+				PublicationState:    btypes.ChangesetPublicationStatePublished,
+				ExternalState:       btypes.ChangesetExternalStateOpen,
+				Metadata: &bitbucketserver.PullRequest{
+					Reviewers: []bitbucketserver.Reviewer{{User: &bitbucketserver.User{Name: "alice"}}},
+				},
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "milestone changed on the code host",
+			previousSpec: &bt.TestSpecOpts{Published: true, Milestone: "v1.0"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Milestone: "v1.0"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				Metadata: &github.PullRequest{
+					Milestone: &github.Milestone{Title: "v2.0"},
+				},
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "milestone removed on the code host",
+			previousSpec: &bt.TestSpecOpts{Published: true, Milestone: "v1.0"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Milestone: "v1.0"},
+			changeset: bt.TestChangesetOpts{
+				ExternalServiceType: extsvc.TypeGitLab,
+				PublicationState:    btypes.ChangesetPublicationStatePublished,
+				ExternalState:       btypes.ChangesetExternalStateOpen,
+				Metadata:            &gitlab.MergeRequest{},
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "milestone present on the code host",
+			previousSpec: &bt.TestSpecOpts{Published: true, Milestone: "v1.0"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Milestone: "v1.0"},
+			changeset: bt.TestChangesetOpts{
+				ExternalServiceType: extsvc.TypeGitLab,
+				PublicationState:    btypes.ChangesetPublicationStatePublished,
+				ExternalState:       btypes.ChangesetExternalStateOpen,
+				Metadata: &gitlab.MergeRequest{
+					Milestone: &gitlab.Milestone{Title: "v1.0"},
+				},
+			},
+			wantOperations: Operations{},
+		},
 		{
 			name:         "commit diff changed on published changeset",
 			previousSpec: &bt.TestSpecOpts{Published: true, CommitDiff: "testDiff"},
@@ -255,6 +428,19 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 				btypes.ReconcilerOperationSync,
 			},
 		},
+		{
+			name:         "base rev changed on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, BaseRev: "old-base"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, BaseRev: "new-base"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
 		{
 			name:         "commit diff changed on merge changeset",
 			previousSpec: &bt.TestSpecOpts{Published: true, CommitDiff: "testDiff"},
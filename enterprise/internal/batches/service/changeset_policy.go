@@ -0,0 +1,358 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/grafana/regexp"
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// ChangesetPolicyCheck is a single check of the changeset policy, which
+// changeset specs have to pass before they are created.
+type ChangesetPolicyCheck interface {
+	// Name is the name of the check, which is recorded with its violations.
+	Name() string
+	// Check returns the violations of the check in the given changeset spec.
+	// The Check and Branch fields of the violations are set by the caller.
+	Check(ctx context.Context, c *ChangesetPolicyCheckContext) ([]btypes.ChangesetPolicyViolation, error)
+}
+
+// ChangesetPolicyCheckContext is the changeset spec a ChangesetPolicyCheck is
+// run for.
+type ChangesetPolicyCheckContext struct {
+	Repo *types.Repo
+	Spec *btypes.ChangesetSpec
+	// FileDiffs is the parsed diff of Spec.
+	FileDiffs []*diff.FileDiff
+}
+
+// ChangesetSpecValidator is the validation stage between the execution of
+// batch spec workspaces and the creation of their changeset specs. It runs the
+// checks of the changeset policy in the site configuration, followed by the
+// additional checks it was created with.
+type ChangesetSpecValidator struct {
+	gitserverClient gitserver.Client
+	checks          []ChangesetPolicyCheck
+}
+
+var _ store.ChangesetSpecValidator = &ChangesetSpecValidator{}
+
+// NewChangesetSpecValidator returns a ChangesetSpecValidator that runs the
+// given checks in addition to the ones configured in the site configuration.
+func NewChangesetSpecValidator(gitserverClient gitserver.Client, checks ...ChangesetPolicyCheck) *ChangesetSpecValidator {
+	return &ChangesetSpecValidator{gitserverClient: gitserverClient, checks: checks}
+}
+
+// ValidateChangesetSpecs returns the violations of the changeset policy in
+// the given changeset specs of the given repo.
+func (v *ChangesetSpecValidator) ValidateChangesetSpecs(ctx context.Context, repo *types.Repo, specs []*btypes.ChangesetSpec) ([]btypes.ChangesetPolicyViolation, error) {
+	checks, err := newChangesetPolicyChecks(conf.Get().BatchChangesChangesetPolicy, v.gitserverClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid changeset policy")
+	}
+	checks = append(checks, v.checks...)
+	if len(checks) == 0 {
+		return nil, nil
+	}
+
+	var violations []btypes.ChangesetPolicyViolation
+	for _, spec := range specs {
+		// Imported changesets aren't created by batch changes, so there's
+		// nothing to check.
+		if spec.Type != btypes.ChangesetSpecTypeBranch {
+			continue
+		}
+
+		fileDiffs, err := diff.ParseMultiFileDiff(spec.Diff)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing changeset spec diff")
+		}
+
+		c := &ChangesetPolicyCheckContext{Repo: repo, Spec: spec, FileDiffs: fileDiffs}
+		for _, check := range checks {
+			found, err := check.Check(ctx, c)
+			if err != nil {
+				return nil, errors.Wrapf(err, "running changeset policy check %q", check.Name())
+			}
+			for _, violation := range found {
+				violation.Check = check.Name()
+				violation.Branch = spec.HeadRef
+				violations = append(violations, violation)
+			}
+		}
+	}
+	return violations, nil
+}
+
+// ValidateChangesetPolicy returns an error if the given changeset policy of the
+// site configuration is invalid.
+func ValidateChangesetPolicy(policy *schema.BatchChangesChangesetPolicy) error {
+	_, err := newChangesetPolicyChecks(policy, nil)
+	return err
+}
+
+// newChangesetPolicyChecks returns the checks configured by the given
+// changeset policy.
+func newChangesetPolicyChecks(policy *schema.BatchChangesChangesetPolicy, gitserverClient gitserver.Client) ([]ChangesetPolicyCheck, error) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	var checks []ChangesetPolicyCheck
+	if len(policy.AllowedPaths) > 0 {
+		check := &allowedPathsCheck{}
+		for _, pattern := range policy.AllowedPaths {
+			g, err := glob.Compile(pattern, '/')
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid allowed path %q", pattern)
+			}
+			check.globs = append(check.globs, g)
+		}
+		checks = append(checks, check)
+	}
+	if policy.MaxChangedLines > 0 {
+		checks = append(checks, &maxChangedLinesCheck{max: policy.MaxChangedLines})
+	}
+	if len(policy.ProtectedCodeOwners) > 0 {
+		checks = append(checks, &codeOwnersCheck{
+			gitserverClient: gitserverClient,
+			owners:          policy.ProtectedCodeOwners,
+			rulesets:        make(map[codeOwnersKey]*codeowners.Ruleset),
+		})
+	}
+	if policy.DetectSecrets {
+		check := &secretsCheck{patterns: defaultSecretPatterns}
+		for _, pattern := range policy.SecretPatterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid secret pattern %q", pattern)
+			}
+			check.patterns = append(check.patterns, secretPattern{name: fmt.Sprintf("pattern %q", pattern), pattern: re})
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// changedPaths returns the paths of the files changed by the given file diff.
+// Renamed files have two paths.
+func changedPaths(fd *diff.FileDiff) []string {
+	var paths []string
+	for _, name := range []string{fd.OrigName, fd.NewName} {
+		if name == "" || name == "/dev/null" {
+			continue
+		}
+		if len(paths) == 1 && paths[0] == name {
+			continue
+		}
+		paths = append(paths, name)
+	}
+	return paths
+}
+
+// allowedPathsCheck fails changesets that change files outside of the allowed
+// paths.
+type allowedPathsCheck struct {
+	globs []glob.Glob
+}
+
+func (c *allowedPathsCheck) Name() string { return "allowedPaths" }
+
+func (c *allowedPathsCheck) Check(_ context.Context, cc *ChangesetPolicyCheckContext) (violations []btypes.ChangesetPolicyViolation, _ error) {
+	for _, fd := range cc.FileDiffs {
+		for _, path := range changedPaths(fd) {
+			if !c.allowed(path) {
+				violations = append(violations, btypes.ChangesetPolicyViolation{
+					Path:    path,
+					Message: "file is outside of the allowed paths",
+				})
+			}
+		}
+	}
+	return violations, nil
+}
+
+func (c *allowedPathsCheck) allowed(path string) bool {
+	for _, g := range c.globs {
+		if g.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxChangedLinesCheck fails changesets that add and delete more lines in
+// total than allowed.
+type maxChangedLinesCheck struct {
+	max int
+}
+
+func (c *maxChangedLinesCheck) Name() string { return "maxChangedLines" }
+
+func (c *maxChangedLinesCheck) Check(_ context.Context, cc *ChangesetPolicyCheckContext) ([]btypes.ChangesetPolicyViolation, error) {
+	changed := int(cc.Spec.DiffStatAdded) + int(cc.Spec.DiffStatDeleted)
+	if changed <= c.max {
+		return nil, nil
+	}
+	return []btypes.ChangesetPolicyViolation{{
+		Message: fmt.Sprintf("changeset changes %d lines, but at most %d are allowed", changed, c.max),
+	}}, nil
+}
+
+// codeOwnersCheck fails changesets that change files owned by one of the
+// protected code owners, according to the CODEOWNERS file of the repository
+// at the base revision of the changeset.
+type codeOwnersCheck struct {
+	gitserverClient gitserver.Client
+	owners          []string
+
+	rulesets map[codeOwnersKey]*codeowners.Ruleset
+}
+
+type codeOwnersKey struct {
+	repo   api.RepoName
+	commit api.CommitID
+}
+
+func (c *codeOwnersCheck) Name() string { return "protectedCodeOwners" }
+
+func (c *codeOwnersCheck) Check(ctx context.Context, cc *ChangesetPolicyCheckContext) (violations []btypes.ChangesetPolicyViolation, _ error) {
+	ruleset, err := c.ruleset(ctx, cc.Repo.Name, api.CommitID(cc.Spec.BaseRev))
+	if err != nil {
+		return nil, err
+	}
+	if ruleset == nil {
+		return nil, nil
+	}
+
+	for _, fd := range cc.FileDiffs {
+		for _, path := range changedPaths(fd) {
+			if owner, ok := c.protectedOwner(ruleset, path); ok {
+				violations = append(violations, btypes.ChangesetPolicyViolation{
+					Path:    path,
+					Message: fmt.Sprintf("file is owned by the protected code owner %s", owner),
+				})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// protectedOwner returns the first owner of the file at path that is
+// protected.
+func (c *codeOwnersCheck) protectedOwner(ruleset *codeowners.Ruleset, path string) (string, bool) {
+	owners := ruleset.Match(path)
+	for _, protected := range c.owners {
+		for _, owner := range owners {
+			if protected == "*" || codeowners.SameOwner(owner, protected) {
+				return owner, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ruleset returns the CODEOWNERS ruleset of the repository at the given
+// commit, or nil if the repository has no CODEOWNERS file.
+func (c *codeOwnersCheck) ruleset(ctx context.Context, repo api.RepoName, commit api.CommitID) (*codeowners.Ruleset, error) {
+	key := codeOwnersKey{repo: repo, commit: commit}
+	if ruleset, ok := c.rulesets[key]; ok {
+		return ruleset, nil
+	}
+
+	var ruleset *codeowners.Ruleset
+	for _, path := range codeowners.Paths {
+		content, err := c.gitserverClient.ReadFile(ctx, repo, commit, path, authz.DefaultSubRepoPermsChecker)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s in %s", path, repo)
+		}
+
+		ruleset, err = codeowners.Parse(bytes.NewReader(content))
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s in %s", path, repo)
+		}
+		break
+	}
+	c.rulesets[key] = ruleset
+	return ruleset, nil
+}
+
+type secretPattern struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// defaultSecretPatterns are the patterns of well-known kinds of secrets that
+// are always detected by secretsCheck.
+var defaultSecretPatterns = []secretPattern{
+	{name: "private key", pattern: regexp.MustCompile(`-----BEGIN ([A-Z]+ )?PRIVATE KEY( BLOCK)?-----`)},
+	{name: "AWS access key", pattern: regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{name: "GitHub token", pattern: regexp.MustCompile(`\b(gh[pousr]_[0-9A-Za-z]{36}|github_pat_[0-9A-Za-z_]{82})\b`)},
+	{name: "GitLab token", pattern: regexp.MustCompile(`\bglpat-[0-9A-Za-z_-]{20}\b`)},
+	{name: "Slack token", pattern: regexp.MustCompile(`\bxox[abposr]-[0-9A-Za-z-]{10,}`)},
+	{name: "Google API key", pattern: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{name: "Sourcegraph access token", pattern: regexp.MustCompile(`\bsgp_[0-9a-f]{40}\b`)},
+}
+
+// secretsCheck fails changesets that add lines that look like they contain
+// secrets. Only added lines are checked, so that changesets can still remove
+// secrets. The secrets themselves are not included in the violations.
+type secretsCheck struct {
+	patterns []secretPattern
+}
+
+func (c *secretsCheck) Name() string { return "secrets" }
+
+func (c *secretsCheck) Check(_ context.Context, cc *ChangesetPolicyCheckContext) (violations []btypes.ChangesetPolicyViolation, _ error) {
+	for _, fd := range cc.FileDiffs {
+		for _, hunk := range fd.Hunks {
+			line := int(hunk.NewStartLine)
+			for _, l := range strings.Split(strings.TrimSuffix(string(hunk.Body), "\n"), "\n") {
+				if l == "" {
+					continue
+				}
+				switch l[0] {
+				case '+':
+					if name, ok := c.match(l[1:]); ok {
+						violations = append(violations, btypes.ChangesetPolicyViolation{
+							Path:    fd.NewName,
+							Message: fmt.Sprintf("line %d adds what looks like a secret (%s)", line, name),
+						})
+					}
+					line++
+				case ' ':
+					line++
+				}
+			}
+		}
+	}
+	return violations, nil
+}
+
+func (c *secretsCheck) match(line string) (string, bool) {
+	for _, p := range c.patterns {
+		if p.pattern.MatchString(line) {
+			return p.name, true
+		}
+	}
+	return "", false
+}
@@ -16,12 +16,14 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	extsvcauth "github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -51,6 +53,7 @@ func NewWithClock(store *store.Store, clock func() time.Time) *Service {
 		sourcer: sources.NewSourcer(httpcli.NewExternalClientFactory(
 			httpcli.NewLoggingMiddleware(logger.Scoped("sourcer", "batches sourcer")),
 		)),
+		validator:  NewChangesetSpecValidator(gitserver.NewClient(store.DatabaseDB())),
 		clock:      clock,
 		operations: newOperations(store.ObservationContext()),
 	}
@@ -62,6 +65,7 @@ type Service struct {
 	logger     sglog.Logger
 	store      *store.Store
 	sourcer    sources.Sourcer
+	validator  store.ChangesetSpecValidator
 	operations *operations
 	clock      func() time.Time
 }
@@ -87,6 +91,10 @@ type operations struct {
 	fetchUsernameForBitbucketServerToken *observation.Operation
 	validateAuthenticator                *observation.Operation
 	createChangesetJobs                  *observation.Operation
+	setAutoMergePolicy                   *observation.Operation
+	deleteAutoMergePolicy                *observation.Operation
+	enableAutoRebase                     *observation.Operation
+	disableAutoRebase                    *observation.Operation
 	applyBatchChange                     *observation.Operation
 	reconcileBatchChange                 *observation.Operation
 	validateChangesetSpecs               *observation.Operation
@@ -137,6 +145,10 @@ func newOperations(observationContext *observation.Context) *operations {
 			fetchUsernameForBitbucketServerToken: op("FetchUsernameForBitbucketServerToken"),
 			validateAuthenticator:                op("ValidateAuthenticator"),
 			createChangesetJobs:                  op("CreateChangesetJobs"),
+			setAutoMergePolicy:                   op("SetAutoMergePolicy"),
+			deleteAutoMergePolicy:                op("DeleteAutoMergePolicy"),
+			enableAutoRebase:                     op("EnableAutoRebase"),
+			disableAutoRebase:                    op("DisableAutoRebase"),
 			applyBatchChange:                     op("ApplyBatchChange"),
 			reconcileBatchChange:                 op("ReconcileBatchChange"),
 			validateChangesetSpecs:               op("ValidateChangesetSpecs"),
@@ -149,7 +161,7 @@ func newOperations(observationContext *observation.Context) *operations {
 // WithStore returns a copy of the Service with its store attribute set to the
 // given Store.
 func (s *Service) WithStore(store *store.Store) *Service {
-	return &Service{logger: s.logger, store: store, sourcer: s.sourcer, clock: s.clock, operations: s.operations}
+	return &Service{logger: s.logger, store: store, sourcer: s.sourcer, validator: s.validator, clock: s.clock, operations: s.operations}
 }
 
 type CreateEmptyBatchChangeOpts struct {
@@ -792,10 +804,21 @@ func (s *Service) CreateChangesetSpec(ctx context.Context, rawSpec string, userI
 
 	// 🚨 SECURITY: We use database.Repos.Get to check whether the user has access to
 	// the repository or not.
-	if _, err = s.store.Repos().Get(ctx, spec.BaseRepoID); err != nil {
+	repo, err := s.store.Repos().Get(ctx, spec.BaseRepoID)
+	if err != nil {
 		return nil, err
 	}
 
+	// Changeset specs uploaded by src-cli have to pass the changeset policy,
+	// just like the ones built from server-side executions.
+	violations, err := s.validator.ValidateChangesetSpecs(ctx, repo, []*btypes.ChangesetSpec{spec})
+	if err != nil {
+		return nil, errors.Wrap(err, "validating changeset spec")
+	}
+	if len(violations) > 0 {
+		return nil, errors.New(btypes.ChangesetPolicyViolationsMessage(violations))
+	}
+
 	return spec, s.store.CreateChangesetSpec(ctx, spec)
 }
 
@@ -1282,6 +1305,119 @@ func (s *Service) CreateChangesetJobs(ctx context.Context, batchChangeID int64,
 	return bulkGroupID, nil
 }
 
+// SetAutoMergePolicy validates the given auto-merge policy and sets it on its
+// batch change, replacing any existing policy. The changesets of the batch
+// change are merged with the credentials of the actor in the context.
+func (s *Service) SetAutoMergePolicy(ctx context.Context, policy *btypes.AutoMergePolicy) (err error) {
+	ctx, _, endObservation := s.operations.setAutoMergePolicy.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: policy.BatchChangeID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch change")
+	}
+
+	// 🚨 SECURITY: Only the author of the batch change can set its auto-merge
+	// policy, as it is equivalent to merging its changesets.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+		return err
+	}
+
+	if batchChange.Closed() {
+		return errors.New("cannot set the auto-merge policy of a closed batch change")
+	}
+	if policy.MaxMergesPerHour < 0 {
+		return errors.New("the maximum number of merges per hour cannot be negative")
+	}
+	if _, err := window.NewConfiguration(&policy.Windows); err != nil {
+		return errors.Wrap(err, "invalid maintenance windows")
+	}
+
+	policy.UserID = actor.FromContext(ctx).UID
+	return s.store.UpsertAutoMergePolicy(ctx, policy)
+}
+
+// DeleteAutoMergePolicy deletes the auto-merge policy of the given batch
+// change, if any.
+func (s *Service) DeleteAutoMergePolicy(ctx context.Context, batchChangeID int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteAutoMergePolicy.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChangeID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch change")
+	}
+
+	// 🚨 SECURITY: Only the author of the batch change can delete its
+	// auto-merge policy.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+		return err
+	}
+
+	return s.store.DeleteAutoMergePolicy(ctx, batchChangeID)
+}
+
+// EnableAutoRebase enables automatic rebases for the given batch change. Once
+// its changesets are outdated or conflicting with their base branch, its batch
+// spec is re-executed and applied on behalf of the actor in the context.
+func (s *Service) EnableAutoRebase(ctx context.Context, batchChangeID int64) (err error) {
+	ctx, _, endObservation := s.operations.enableAutoRebase.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChangeID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch change")
+	}
+
+	// 🚨 SECURITY: Only the author of the batch change can enable automatic
+	// rebases, as it is equivalent to applying new batch specs to it.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+		return err
+	}
+
+	if batchChange.Closed() {
+		return errors.New("cannot enable automatic rebases for a closed batch change")
+	}
+
+	batchSpec, err := s.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch spec")
+	}
+	if !batchSpec.CreatedFromRaw {
+		return errors.New("automatic rebases are only supported for batch changes that are executed server-side")
+	}
+
+	rebase, err := s.store.GetAutoRebase(ctx, batchChangeID)
+	if err == store.ErrNoResults {
+		rebase = &btypes.AutoRebase{BatchChangeID: batchChangeID}
+	} else if err != nil {
+		return errors.Wrap(err, "loading auto-rebase")
+	}
+
+	rebase.UserID = actor.FromContext(ctx).UID
+	return s.store.UpsertAutoRebase(ctx, rebase)
+}
+
+// DisableAutoRebase disables automatic rebases for the given batch change. A
+// rebase that is in progress is not applied.
+func (s *Service) DisableAutoRebase(ctx context.Context, batchChangeID int64) (err error) {
+	ctx, _, endObservation := s.operations.disableAutoRebase.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChangeID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch change")
+	}
+
+	// 🚨 SECURITY: Only the author of the batch change can disable automatic
+	// rebases.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+		return err
+	}
+
+	return s.store.DeleteAutoRebase(ctx, batchChangeID)
+}
+
 // ValidateChangesetSpecs checks whether the given BachSpec has ChangesetSpecs
 // that would publish to the same branch in the same repository.
 // If the return value is nil, then the BatchSpec is valid.
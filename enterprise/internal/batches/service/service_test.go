@@ -33,6 +33,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestServicePermissionLevels(t *testing.T) {
@@ -1186,6 +1187,123 @@ index e5af166..d44c3fc 100644
 		})
 	})
 
+	t.Run("SetAutoMergePolicy", func(t *testing.T) {
+		spec := testBatchSpec(admin.ID)
+		if err := s.CreateBatchSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+
+		batchChange := testBatchChange(admin.ID, spec)
+		if err := s.CreateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("sets the policy", func(t *testing.T) {
+			policy := &btypes.AutoMergePolicy{
+				BatchChangeID:        batchChange.ID,
+				RequirePassingChecks: true,
+				MaxMergesPerHour:     10,
+			}
+			if err := svc.SetAutoMergePolicy(adminCtx, policy); err != nil {
+				t.Fatal(err)
+			}
+
+			have, err := s.GetAutoMergePolicy(ctx, batchChange.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have.UserID != admin.ID || have.MaxMergesPerHour != 10 {
+				t.Fatalf("unexpected policy: %+v", have)
+			}
+		})
+
+		t.Run("invalid maintenance windows", func(t *testing.T) {
+			err := svc.SetAutoMergePolicy(adminCtx, &btypes.AutoMergePolicy{
+				BatchChangeID: batchChange.ID,
+				Windows: []*schema.BatchChangeRolloutWindow{
+					{Days: []string{"someday"}, Rate: "unlimited"},
+				},
+			})
+			if err == nil {
+				t.Fatal("unexpected nil error")
+			}
+		})
+
+		t.Run("unauthorized user", func(t *testing.T) {
+			err := svc.SetAutoMergePolicy(userCtx, &btypes.AutoMergePolicy{BatchChangeID: batchChange.ID})
+			if !errcode.IsUnauthorized(err) {
+				t.Fatalf("expected unauthorized error, got %+v", err)
+			}
+		})
+
+		t.Run("deletes the policy", func(t *testing.T) {
+			if err := svc.DeleteAutoMergePolicy(adminCtx, batchChange.ID); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.GetAutoMergePolicy(ctx, batchChange.ID); err != store.ErrNoResults {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	t.Run("EnableAutoRebase", func(t *testing.T) {
+		spec := testBatchSpec(admin.ID)
+		spec.CreatedFromRaw = true
+		if err := s.CreateBatchSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+
+		batchChange := testBatchChange(admin.ID, spec)
+		if err := s.CreateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("enables rebases", func(t *testing.T) {
+			if err := svc.EnableAutoRebase(adminCtx, batchChange.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			have, err := s.GetAutoRebase(ctx, batchChange.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have.UserID != admin.ID {
+				t.Fatalf("unexpected auto-rebase: %+v", have)
+			}
+		})
+
+		t.Run("batch change not executed server-side", func(t *testing.T) {
+			spec := testBatchSpec(admin.ID)
+			if err := s.CreateBatchSpec(ctx, spec); err != nil {
+				t.Fatal(err)
+			}
+			batchChange := testBatchChange(admin.ID, spec)
+			if err := s.CreateBatchChange(ctx, batchChange); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := svc.EnableAutoRebase(adminCtx, batchChange.ID); err == nil {
+				t.Fatal("unexpected nil error")
+			}
+		})
+
+		t.Run("unauthorized user", func(t *testing.T) {
+			err := svc.EnableAutoRebase(userCtx, batchChange.ID)
+			if !errcode.IsUnauthorized(err) {
+				t.Fatalf("expected unauthorized error, got %+v", err)
+			}
+		})
+
+		t.Run("disables rebases", func(t *testing.T) {
+			if err := svc.DisableAutoRebase(adminCtx, batchChange.ID); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.GetAutoRebase(ctx, batchChange.ID); err != store.ErrNoResults {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
 	t.Run("ExecuteBatchSpec", func(t *testing.T) {
 		adminCtx := actor.WithActor(ctx, actor.FromUser(admin.ID))
 		t.Run("success", func(t *testing.T) {
@@ -767,6 +767,7 @@ func stepsForRepo(spec *batcheslib.BatchSpec, repo template.Repository) ([]batch
 		stepCtx := &template.StepContext{
 			Repository:  repo,
 			BatchChange: batchChange,
+			Matrix:      step.MatrixValues,
 		}
 		static, boolVal, err := template.IsStaticBool(step.IfCondition(), stepCtx)
 		if err != nil {
@@ -3,6 +3,7 @@ package sources
 import (
 	"context"
 	"strconv"
+	"strings"
 
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
@@ -22,7 +23,8 @@ type BitbucketCloudSource struct {
 }
 
 var (
-	_ ForkableChangesetSource = BitbucketCloudSource{}
+	_ ForkableChangesetSource   = BitbucketCloudSource{}
+	_ AttributesChangesetSource = BitbucketCloudSource{}
 )
 
 func NewBitbucketCloudSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*BitbucketCloudSource, error) {
@@ -153,6 +155,61 @@ func (s BitbucketCloudSource) UpdateChangeset(ctx context.Context, cs *Changeset
 	return s.setChangesetMetadata(ctx, targetRepo, updated, cs)
 }
 
+// SupportsChangesetAttribute returns true for reviewers, since Bitbucket Cloud
+// doesn't support labels, assignees and milestones.
+func (s BitbucketCloudSource) SupportsChangesetAttribute(attr ChangesetAttribute) bool {
+	return attr == ChangesetAttributeReviewers
+}
+
+// UpdateChangesetAttributes adds the reviewers of the given *Changeset to the
+// pull request. Bitbucket Cloud identifies users by their UUID, e.g.
+// "{b5a1d3e0-...}", or their Atlassian account ID, so reviewers must be given
+// as either of them.
+func (s BitbucketCloudSource) UpdateChangesetAttributes(ctx context.Context, cs *Changeset) error {
+	pr, ok := cs.Metadata.(*bbcs.AnnotatedPullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Cloud pull request")
+	}
+
+	if len(cs.Attributes.Reviewers) == 0 {
+		return nil
+	}
+
+	opts := s.changesetToPullRequestInput(cs)
+
+	// Updating the reviewers replaces them, so the current reviewers are kept.
+	// Bitbucket Cloud rejects the author of the pull request as a reviewer.
+	seen := map[string]bool{pr.Author.UUID: true}
+	for _, r := range pr.Reviewers {
+		seen[r.UUID] = true
+		opts.Reviewers = append(opts.Reviewers, bitbucketcloud.ReviewerInput{UUID: r.UUID})
+	}
+	for _, reviewer := range cs.Attributes.Reviewers {
+		if seen[reviewer] {
+			continue
+		}
+		seen[reviewer] = true
+
+		if strings.HasPrefix(reviewer, "{") {
+			opts.Reviewers = append(opts.Reviewers, bitbucketcloud.ReviewerInput{UUID: reviewer})
+		} else {
+			opts.Reviewers = append(opts.Reviewers, bitbucketcloud.ReviewerInput{AccountID: reviewer})
+		}
+	}
+	if len(opts.Reviewers) == len(pr.Reviewers) {
+		// All reviewers have been added already.
+		return nil
+	}
+
+	targetRepo := cs.TargetRepo.Metadata.(*bitbucketcloud.Repo)
+	updated, err := s.client.UpdatePullRequest(ctx, targetRepo, pr.ID, opts)
+	if err != nil {
+		return errors.Wrap(err, "updating pull request reviewers")
+	}
+
+	return s.setChangesetMetadata(ctx, targetRepo, updated, cs)
+}
+
 // ReopenChangeset will reopen the Changeset on the source, if it's closed.
 // If not, it's a noop.
 func (s BitbucketCloudSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
@@ -428,6 +428,52 @@ func TestBitbucketCloudSource_UpdateChangeset(t *testing.T) {
 	})
 }
 
+func TestBitbucketCloudSource_UpdateChangesetAttributes(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no new reviewers", func(t *testing.T) {
+		cs, _, bbRepo := mockBitbucketCloudChangeset()
+		s, _ := mockBitbucketCloudSource()
+
+		pr := mockBitbucketCloudPullRequest(bbRepo)
+		pr.Author = bitbucketcloud.Account{UUID: "{author}"}
+		pr.Reviewers = []bitbucketcloud.Account{{UUID: "{alice}"}}
+		annotateChangesetWithPullRequest(cs, pr)
+		cs.Attributes.Reviewers = []string{"{alice}", "{author}"}
+
+		// The strict mock client fails the test if the pull request is updated.
+		assert.Nil(t, s.UpdateChangesetAttributes(ctx, cs))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		cs, _, bbRepo := mockBitbucketCloudChangeset()
+		s, client := mockBitbucketCloudSource()
+		mockAnnotatePullRequestSuccess(client)
+
+		pr := mockBitbucketCloudPullRequest(bbRepo)
+		pr.Author = bitbucketcloud.Account{UUID: "{author}"}
+		pr.Reviewers = []bitbucketcloud.Account{{UUID: "{alice}"}}
+		annotateChangesetWithPullRequest(cs, pr)
+		cs.Title = "title"
+		cs.Attributes.Reviewers = []string{"{alice}", "{author}", "{bob}", "557058:carol"}
+
+		client.UpdatePullRequestFunc.SetDefaultHook(func(ctx context.Context, r *bitbucketcloud.Repo, i int64, pri bitbucketcloud.PullRequestInput) (*bitbucketcloud.PullRequest, error) {
+			assert.Same(t, bbRepo, r)
+			assert.EqualValues(t, 420, i)
+			assert.Equal(t, cs.Title, pri.Title)
+			assert.Equal(t, []bitbucketcloud.ReviewerInput{
+				{UUID: "{alice}"},
+				{UUID: "{bob}"},
+				{AccountID: "557058:carol"},
+			}, pri.Reviewers)
+			return pr, nil
+		})
+
+		assert.Nil(t, s.UpdateChangesetAttributes(ctx, cs))
+		assertChangesetMatchesPullRequest(t, cs, pr)
+	})
+}
+
 func TestBitbucketCloudSource_CreateComment(t *testing.T) {
 	ctx := context.Background()
 
@@ -23,6 +23,7 @@ type BitbucketServerSource struct {
 }
 
 var _ ForkableChangesetSource = BitbucketServerSource{}
+var _ AttributesChangesetSource = BitbucketServerSource{}
 
 // NewBitbucketServerSource returns a new BitbucketServerSource from the given external service.
 func NewBitbucketServerSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*BitbucketServerSource, error) {
@@ -211,17 +212,88 @@ func (s BitbucketServerSource) UpdateChangeset(ctx context.Context, c *Changeset
 	update.ToRef.Repository.Slug = pr.ToRef.Repository.Slug
 	update.ToRef.Repository.Project.Key = pr.ToRef.Repository.Project.Key
 
+	updated, err := s.updatePullRequest(ctx, pr, update)
+	if err != nil {
+		return err
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
+// SupportsChangesetAttribute returns true for reviewers, since Bitbucket Server
+// doesn't support labels, assignees and milestones.
+func (s BitbucketServerSource) SupportsChangesetAttribute(attr ChangesetAttribute) bool {
+	return attr == ChangesetAttributeReviewers
+}
+
+// UpdateChangesetAttributes adds the reviewers of the given *Changeset to the
+// pull request.
+func (s BitbucketServerSource) UpdateChangesetAttributes(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	if len(c.Attributes.Reviewers) == 0 {
+		return nil
+	}
+
+	update := &bitbucketserver.UpdatePullRequestInput{
+		PullRequestID: strconv.Itoa(pr.ID),
+		Title:         pr.Title,
+		Description:   pr.Description,
+		Version:       pr.Version,
+		ToRef:         pr.ToRef,
+	}
+
+	// Bitbucket Server rejects the author of the pull request as a reviewer.
+	seen := map[string]bool{}
+	if pr.Author.User != nil {
+		seen[pr.Author.User.Name] = true
+	}
+	addReviewer := func(name string) {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+
+		var r bitbucketserver.ReviewerInput
+		r.User.Name = name
+		update.Reviewers = append(update.Reviewers, r)
+	}
+	for _, r := range pr.Reviewers {
+		if r.User != nil {
+			addReviewer(r.User.Name)
+		}
+	}
+	for _, name := range c.Attributes.Reviewers {
+		addReviewer(name)
+	}
+	if len(update.Reviewers) == len(pr.Reviewers) {
+		// All reviewers have been added already.
+		return nil
+	}
+
+	updated, err := s.updatePullRequest(ctx, pr, update)
+	if err != nil {
+		return err
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
+func (s BitbucketServerSource) updatePullRequest(ctx context.Context, pr *bitbucketserver.PullRequest, update *bitbucketserver.UpdatePullRequestInput) (*bitbucketserver.PullRequest, error) {
 	updated, err := s.client.UpdatePullRequest(ctx, update)
 	if err != nil {
 		if !bitbucketserver.IsPullRequestOutOfDate(err) {
-			return err
+			return nil, err
 		}
 
 		// If we have an outdated version of the pull request we extract the
 		// pull request that was returned with the error...
 		newestPR, err2 := bitbucketserver.ExtractPullRequest(err)
 		if err2 != nil {
-			return errors.Wrap(err, "failed to extract pull request after receiving error")
+			return nil, errors.Wrap(err, "failed to extract pull request after receiving error")
 		}
 
 		log15.Info("Updating Bitbucket Server PR failed because it's outdated. Retrying with newer version", "ID", pr.ID, "oldVersion", pr.Version, "newestVerssion", newestPR.Version)
@@ -231,11 +303,11 @@ func (s BitbucketServerSource) UpdateChangeset(ctx context.Context, c *Changeset
 		updated, err = s.client.UpdatePullRequest(ctx, update)
 		if err != nil {
 			// If that didn't work, we bail out
-			return err
+			return nil, err
 		}
 	}
 
-	return c.Changeset.SetMetadata(updated)
+	return updated, nil
 }
 
 // ReopenChangeset reopens the *Changeset on the code host and updates the
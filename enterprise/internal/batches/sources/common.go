@@ -3,6 +3,7 @@ package sources
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
@@ -26,6 +27,22 @@ func (e ChangesetNotFoundError) Error() string {
 
 func (e ChangesetNotFoundError) NonRetryable() bool { return true }
 
+// UnsupportedChangesetAttributesError is returned when a changeset template
+// sets attributes that the code host of the changeset doesn't support.
+type UnsupportedChangesetAttributesError struct {
+	Attributes []ChangesetAttribute
+}
+
+func (e UnsupportedChangesetAttributesError) Error() string {
+	attrs := make([]string, 0, len(e.Attributes))
+	for _, attr := range e.Attributes {
+		attrs = append(attrs, string(attr))
+	}
+	return fmt.Sprintf("the code host doesn't support changeset %s", strings.Join(attrs, ", "))
+}
+
+func (e UnsupportedChangesetAttributesError) NonRetryable() bool { return true }
+
 // ArchivableChangesetSource represents a changeset source that has a
 // concept of archived repositories.
 type ArchivableChangesetSource interface {
@@ -48,6 +65,35 @@ type DraftChangesetSource interface {
 	UndraftChangeset(context.Context, *Changeset) error
 }
 
+// A ReviewRefChangesetSource creates and updates changesets by pushing a
+// single commit to a special review ref, rather than by opening a pull request
+// from a branch. The commit message identifies the changeset, and therefore
+// determines its title and body.
+type ReviewRefChangesetSource interface {
+	ChangesetSource
+
+	// PushRef returns the ref on the code host to which the commit of the
+	// given changeset must be pushed.
+	PushRef(*Changeset) string
+	// CommitMessage returns the commit message for the given changeset,
+	// including whatever the code host needs to identify the changeset.
+	CommitMessage(*Changeset) string
+}
+
+// An AttributesChangesetSource can apply the labels, reviewers, assignees and
+// milestone of a changeset template to changesets.
+type AttributesChangesetSource interface {
+	ChangesetSource
+
+	// SupportsChangesetAttribute returns true if the code host can apply the
+	// given attribute to changesets.
+	SupportsChangesetAttribute(ChangesetAttribute) bool
+	// UpdateChangesetAttributes applies the Attributes of the Changeset to the
+	// changeset on the source. The Attributes must have been validated with
+	// ValidateChangesetAttributes.
+	UpdateChangesetAttributes(context.Context, *Changeset) error
+}
+
 type ForkableChangesetSource interface {
 	ChangesetSource
 
@@ -128,9 +174,81 @@ type Changeset struct {
 	// opened.
 	TargetRepo *types.Repo
 
+	// Attributes are applied by sources implementing AttributesChangesetSource.
+	Attributes ChangesetAttributes
+
 	*btypes.Changeset
 }
 
+// ChangesetAttributes are the labels, reviewers, assignees and milestone a
+// changeset is created or updated with.
+type ChangesetAttributes struct {
+	Labels    []string
+	Reviewers []string
+	Assignees []string
+	// Milestone is the title of the milestone. An empty Milestone leaves the
+	// milestone of the changeset unchanged.
+	Milestone string
+
+	// RemovedLabels and RemovedAssignees were set by a previous changeset spec
+	// and are removed from the changeset.
+	RemovedLabels    []string
+	RemovedAssignees []string
+}
+
+// IsEmpty returns true if there is nothing to apply to the changeset.
+func (a ChangesetAttributes) IsEmpty() bool {
+	return len(a.Labels) == 0 && len(a.Reviewers) == 0 && len(a.Assignees) == 0 && a.Milestone == "" &&
+		len(a.RemovedLabels) == 0 && len(a.RemovedAssignees) == 0
+}
+
+// ChangesetAttribute is an attribute of ChangesetAttributes that a code host
+// may not support.
+type ChangesetAttribute string
+
+const (
+	ChangesetAttributeLabels    ChangesetAttribute = "labels"
+	ChangesetAttributeReviewers ChangesetAttribute = "reviewers"
+	ChangesetAttributeAssignees ChangesetAttribute = "assignees"
+	ChangesetAttributeMilestone ChangesetAttribute = "milestone"
+)
+
+// attributes returns the ChangesetAttribute values that are set.
+func (a ChangesetAttributes) attributes() []ChangesetAttribute {
+	var attrs []ChangesetAttribute
+	if len(a.Labels) > 0 || len(a.RemovedLabels) > 0 {
+		attrs = append(attrs, ChangesetAttributeLabels)
+	}
+	if len(a.Reviewers) > 0 {
+		attrs = append(attrs, ChangesetAttributeReviewers)
+	}
+	if len(a.Assignees) > 0 || len(a.RemovedAssignees) > 0 {
+		attrs = append(attrs, ChangesetAttributeAssignees)
+	}
+	if a.Milestone != "" {
+		attrs = append(attrs, ChangesetAttributeMilestone)
+	}
+	return attrs
+}
+
+// ValidateChangesetAttributes returns an UnsupportedChangesetAttributesError
+// if the given attributes can't all be applied to changesets by the given
+// ChangesetSource.
+func ValidateChangesetAttributes(css ChangesetSource, attrs ChangesetAttributes) error {
+	acss, ok := css.(AttributesChangesetSource)
+
+	var unsupported []ChangesetAttribute
+	for _, attr := range attrs.attributes() {
+		if !ok || !acss.SupportsChangesetAttribute(attr) {
+			unsupported = append(unsupported, attr)
+		}
+	}
+	if len(unsupported) > 0 {
+		return UnsupportedChangesetAttributesError{Attributes: unsupported}
+	}
+	return nil
+}
+
 // IsOutdated returns true when the attributes of the nested
 // batches.Changeset do not match the attributes (title, body, ...) set on
 // the Changeset.
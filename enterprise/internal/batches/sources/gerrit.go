@@ -0,0 +1,235 @@
+package sources
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GerritSource creates changesets as Gerrit changes. Gerrit has no pull
+// requests: a change is created or updated by pushing a commit with a Change-Id
+// footer to refs/for/<branch>. The head ref of the changeset is used as the
+// topic of the change.
+type GerritSource struct {
+	client *gerrit.Client
+}
+
+var _ ReviewRefChangesetSource = GerritSource{}
+
+func NewGerritSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := gerrit.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Gerrit client")
+	}
+
+	return &GerritSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GerritSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GerritSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GerritSource", a)
+	}
+
+	return &GerritSource{client: s.client.WithAuthenticator(a)}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GerritSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedAccount(ctx)
+	return err
+}
+
+// PushRef returns the ref the commit of the changeset is pushed to, which
+// creates or updates the change in the base branch, with the head ref of the
+// changeset as its topic.
+func (s GerritSource) PushRef(cs *Changeset) string {
+	return fmt.Sprintf("refs/for/%s%%topic=%s", gitdomain.AbbreviateRef(cs.BaseRef), gitdomain.AbbreviateRef(cs.HeadRef))
+}
+
+// CommitMessage returns the commit message of the changeset: its title and
+// body, followed by the Change-Id footer that identifies the change.
+func (s GerritSource) CommitMessage(cs *Changeset) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimSpace(cs.Title))
+	b.WriteString("\n\n")
+	if body := strings.TrimSpace(cs.Body); body != "" {
+		b.WriteString(body)
+		b.WriteString("\n\n")
+	}
+	b.WriteString("Change-Id: ")
+	b.WriteString(gerritChangeID(cs))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GerritSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	change, err := s.client.GetChange(ctx, cs.ExternalID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting change")
+	}
+
+	return cs.SetMetadata(change)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s GerritSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	project, err := gerritProjectName(cs.TargetRepo)
+	if err != nil {
+		return false, err
+	}
+
+	// The change has already been created by pushing the commit, so we only
+	// have to look it up.
+	changes, err := s.client.QueryChanges(ctx, fmt.Sprintf("change:%s project:%s", gerritChangeID(cs), project))
+	if err != nil {
+		return false, errors.Wrap(err, "querying changes")
+	}
+	if len(changes) == 0 {
+		return false, errors.Errorf("no change found for Change-Id %s in project %q", gerritChangeID(cs), project)
+	}
+
+	if err := cs.SetMetadata(changes[0]); err != nil {
+		return false, errors.Wrap(err, "setting changeset metadata")
+	}
+
+	// Since pushing creates the change, we can't tell if it existed before.
+	// We say it did, so that the caller goes through the IsOutdated check.
+	return true, nil
+}
+
+// CloseChangeset will close the Changeset on the source, where "close" means
+// abandoning the change.
+func (s GerritSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	if err := s.client.AbandonChange(ctx, cs.ExternalID); err != nil {
+		return errors.Wrap(err, "abandoning change")
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// UpdateChangeset can update Changesets. The title and body of a change are
+// part of its commit message, so changing them creates a new patch set.
+func (s GerritSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerrit.Change)
+
+	if branch := gitdomain.AbbreviateRef(cs.BaseRef); change.Branch != branch {
+		if err := s.client.MoveChange(ctx, cs.ExternalID, branch); err != nil {
+			return errors.Wrap(err, "moving change")
+		}
+	}
+
+	message := s.CommitMessage(cs)
+	if current, ok := change.Current(); !ok || strings.TrimSpace(current.Commit.Message) != strings.TrimSpace(message) {
+		if err := s.client.SetCommitMessage(ctx, cs.ExternalID, message); err != nil {
+			return errors.Wrap(err, "updating commit message")
+		}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s GerritSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerrit.Change)
+	if change.Status != gerrit.ChangeStatusAbandoned {
+		return nil
+	}
+
+	if err := s.client.RestoreChange(ctx, cs.ExternalID); err != nil {
+		return errors.Wrap(err, "restoring change")
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GerritSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	return s.client.SetReview(ctx, cs.ExternalID, gerrit.ReviewInput{Message: comment})
+}
+
+// MergeChangeset submits the change on the code host, if it is submittable.
+// Since a change is always a single commit, squash is ignored.
+func (s GerritSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	if err := s.client.SubmitChange(ctx, cs.ExternalID); err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Wrap(err, "submitting change")
+		}
+		return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// gerritChangeID returns the Change-Id of the change for the given changeset.
+// It is derived from the target repository and the head ref, so that pushing
+// a new commit for the same changeset updates the existing change.
+func gerritChangeID(cs *Changeset) string {
+	h := sha1.Sum([]byte(cs.TargetRepo.ExternalRepo.ID + "\x00" + gitdomain.EnsureRefPrefix(cs.HeadRef)))
+	return "I" + hex.EncodeToString(h[:])
+}
+
+// gerritProjectName returns the name of the Gerrit project of the given repo.
+func gerritProjectName(repo *types.Repo) (string, error) {
+	project, ok := repo.Metadata.(*gerrit.Project)
+	if !ok {
+		return "", errors.Errorf("repo %q is not a Gerrit project", repo.Name)
+	}
+
+	// The ID of a project is its URL encoded name.
+	return url.PathUnescape(project.ID)
+}
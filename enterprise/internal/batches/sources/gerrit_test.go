@@ -0,0 +1,303 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// fakeGerrit is a minimal Gerrit REST API serving a single change.
+type fakeGerrit struct {
+	change   *gerrit.Change
+	queries  []string
+	review   *gerrit.ReviewInput
+	submitOK bool
+}
+
+func (f *fakeGerrit) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	write := func(v any) {
+		data, _ := json.Marshal(v)
+		_, _ = w.Write(append([]byte(")]}'\n"), data...))
+	}
+
+	prefix := fmt.Sprintf("/a/changes/%d", f.change.Number)
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/a/changes/":
+		f.queries = append(f.queries, r.URL.Query().Get("q"))
+		if strings.Contains(r.URL.Query().Get("q"), f.change.ChangeID) {
+			write([]*gerrit.Change{f.change})
+		} else {
+			write([]*gerrit.Change{})
+		}
+	case r.Method == "GET" && r.URL.Path == prefix:
+		write(f.change)
+	case r.Method == "POST" && r.URL.Path == prefix+"/abandon":
+		f.change.Status = gerrit.ChangeStatusAbandoned
+		write(f.change)
+	case r.Method == "POST" && r.URL.Path == prefix+"/restore":
+		f.change.Status = gerrit.ChangeStatusNew
+		write(f.change)
+	case r.Method == "POST" && r.URL.Path == prefix+"/submit":
+		if !f.submitOK {
+			http.Error(w, "change is not submittable", http.StatusConflict)
+			return
+		}
+		f.change.Status = gerrit.ChangeStatusMerged
+		write(f.change)
+	case r.Method == "POST" && r.URL.Path == prefix+"/move":
+		var input struct {
+			DestinationBranch string `json:"destination_branch"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		f.change.Branch = input.DestinationBranch
+		write(f.change)
+	case r.Method == "POST" && r.URL.Path == prefix+"/revisions/current/review":
+		f.review = &gerrit.ReviewInput{}
+		_ = json.NewDecoder(r.Body).Decode(f.review)
+		write(struct{}{})
+	case r.Method == "PUT" && r.URL.Path == prefix+"/message":
+		var input struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		rev := f.change.Revisions[f.change.CurrentRevision]
+		rev.Commit.Message = input.Message
+		f.change.Subject, _, _ = strings.Cut(input.Message, "\n")
+		f.change.Revisions[f.change.CurrentRevision] = rev
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "Not found", http.StatusNotFound)
+	}
+}
+
+func newTestGerritSource(t *testing.T, f *fakeGerrit) *GerritSource {
+	t.Helper()
+
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	cli, err := gerrit.NewClient("extsvc:gerrit:1", &schema.GerritConnection{
+		Url:      srv.URL + "/",
+		Username: "admin",
+		Password: "secret",
+	}, nil)
+	require.NoError(t, err)
+
+	return &GerritSource{client: cli}
+}
+
+func testGerritChangeset(t *testing.T, f *fakeGerrit) *Changeset {
+	t.Helper()
+
+	cs := &Changeset{
+		Title:   "Update dependencies",
+		Body:    "Bumps all the things.",
+		HeadRef: "refs/heads/batch/update-deps",
+		BaseRef: "refs/heads/main",
+		TargetRepo: &types.Repo{
+			Name: "gerrit.example.com/my/project",
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "my%2Fproject",
+				ServiceType: extsvc.TypeGerrit,
+				ServiceID:   "https://gerrit.example.com/",
+			},
+			Metadata: &gerrit.Project{ID: "my%2Fproject"},
+		},
+		Changeset: &btypes.Changeset{},
+	}
+	cs.RemoteRepo = cs.TargetRepo
+
+	changeID := gerritChangeID(cs)
+	f.change = &gerrit.Change{
+		ID:              "my%2Fproject~main~" + changeID,
+		Number:          3965,
+		ChangeID:        changeID,
+		Project:         "my/project",
+		Branch:          "main",
+		Topic:           "batch/update-deps",
+		Subject:         "Update dependencies",
+		Status:          gerrit.ChangeStatusNew,
+		CurrentRevision: "184ebe53805e102605d11f6b143486d15c23a09c",
+		Revisions: map[string]gerrit.RevisionInfo{
+			"184ebe53805e102605d11f6b143486d15c23a09c": {
+				Number: 1,
+				Ref:    "refs/changes/65/3965/1",
+				Commit: gerrit.CommitInfo{Message: "Update dependencies\n\nBumps all the things.\n\nChange-Id: " + changeID + "\n"},
+			},
+		},
+	}
+
+	return cs
+}
+
+func TestGerritSource_PushRefAndCommitMessage(t *testing.T) {
+	s := &GerritSource{}
+	cs := testGerritChangeset(t, &fakeGerrit{})
+
+	assert.Equal(t, "refs/for/main%topic=batch/update-deps", s.PushRef(cs))
+
+	changeID := gerritChangeID(cs)
+	assert.Regexp(t, "^I[0-9a-f]{40}$", changeID)
+	assert.Equal(t, "Update dependencies\n\nBumps all the things.\n\nChange-Id: "+changeID+"\n", s.CommitMessage(cs))
+
+	cs.Body = ""
+	assert.Equal(t, "Update dependencies\n\nChange-Id: "+changeID+"\n", s.CommitMessage(cs))
+
+	// The Change-Id only depends on the repo and the head ref, so that
+	// pushing again updates the same change.
+	other := *cs
+	other.Title = "Something else"
+	assert.Equal(t, changeID, gerritChangeID(&other))
+	other.HeadRef = "refs/heads/batch/other"
+	assert.NotEqual(t, changeID, gerritChangeID(&other))
+}
+
+func TestGerritSource_WithAuthenticator(t *testing.T) {
+	s := newTestGerritSource(t, &fakeGerrit{})
+
+	for _, au := range []auth.Authenticator{
+		&auth.OAuthBearerToken{},
+		&auth.OAuthBearerTokenWithSSH{},
+	} {
+		t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+			newSource, err := s.WithAuthenticator(au)
+			assert.Nil(t, newSource)
+			assert.ErrorAs(t, err, &UnsupportedAuthenticatorError{})
+		})
+	}
+
+	for _, au := range []auth.Authenticator{
+		&auth.BasicAuth{},
+		&auth.BasicAuthWithSSH{},
+	} {
+		t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+			newSource, err := s.WithAuthenticator(au)
+			require.NoError(t, err)
+			assert.Same(t, au, newSource.(*GerritSource).client.Authenticator())
+		})
+	}
+}
+
+func TestGerritSource_CreateAndLoadChangeset(t *testing.T) {
+	ctx := context.Background()
+	f := &fakeGerrit{}
+	s := newTestGerritSource(t, f)
+	cs := testGerritChangeset(t, f)
+
+	exists, err := s.CreateChangeset(ctx, cs)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, []string{"change:" + f.change.ChangeID + " project:my/project"}, f.queries)
+	assert.Equal(t, "3965", cs.ExternalID)
+	assert.Equal(t, extsvc.TypeGerrit, cs.ExternalServiceType)
+	assert.Equal(t, "refs/heads/batch/update-deps", cs.ExternalBranch)
+
+	outdated, err := cs.IsOutdated()
+	require.NoError(t, err)
+	assert.False(t, outdated)
+
+	require.NoError(t, s.LoadChangeset(ctx, cs))
+
+	t.Run("not found", func(t *testing.T) {
+		missing := &Changeset{Changeset: &btypes.Changeset{ExternalID: "1"}}
+		err := s.LoadChangeset(ctx, missing)
+		assert.ErrorAs(t, err, &ChangesetNotFoundError{})
+	})
+
+	t.Run("no change pushed", func(t *testing.T) {
+		other := *cs
+		other.HeadRef = "refs/heads/batch/other"
+		_, err := s.CreateChangeset(ctx, &other)
+		assert.Error(t, err)
+	})
+}
+
+func TestGerritSource_CloseAndReopenChangeset(t *testing.T) {
+	ctx := context.Background()
+	f := &fakeGerrit{}
+	s := newTestGerritSource(t, f)
+	cs := testGerritChangeset(t, f)
+	require.NoError(t, cs.SetMetadata(f.change))
+
+	require.NoError(t, s.CloseChangeset(ctx, cs))
+	assert.Equal(t, gerrit.ChangeStatusAbandoned, cs.Metadata.(*gerrit.Change).Status)
+
+	require.NoError(t, s.ReopenChangeset(ctx, cs))
+	assert.Equal(t, gerrit.ChangeStatusNew, cs.Metadata.(*gerrit.Change).Status)
+
+	// Reopening an open change is a noop.
+	f.change.Status = gerrit.ChangeStatusMerged
+	require.NoError(t, s.ReopenChangeset(ctx, cs))
+	assert.Equal(t, gerrit.ChangeStatusNew, cs.Metadata.(*gerrit.Change).Status)
+}
+
+func TestGerritSource_UpdateChangeset(t *testing.T) {
+	ctx := context.Background()
+	f := &fakeGerrit{}
+	s := newTestGerritSource(t, f)
+	cs := testGerritChangeset(t, f)
+	require.NoError(t, cs.SetMetadata(f.change))
+
+	cs.Title = "Update all dependencies"
+	cs.BaseRef = "refs/heads/release"
+	require.NoError(t, s.UpdateChangeset(ctx, cs))
+
+	change := cs.Metadata.(*gerrit.Change)
+	assert.Equal(t, "release", change.Branch)
+	assert.Equal(t, "Update all dependencies", change.Subject)
+	assert.Equal(t, "Bumps all the things.", change.Description())
+
+	outdated, err := cs.IsOutdated()
+	require.NoError(t, err)
+	assert.False(t, outdated)
+}
+
+func TestGerritSource_CreateComment(t *testing.T) {
+	ctx := context.Background()
+	f := &fakeGerrit{}
+	s := newTestGerritSource(t, f)
+	cs := testGerritChangeset(t, f)
+	require.NoError(t, cs.SetMetadata(f.change))
+
+	require.NoError(t, s.CreateComment(ctx, cs, "hello"))
+	assert.Equal(t, &gerrit.ReviewInput{Message: "hello"}, f.review)
+}
+
+func TestGerritSource_MergeChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not submittable", func(t *testing.T) {
+		f := &fakeGerrit{}
+		s := newTestGerritSource(t, f)
+		cs := testGerritChangeset(t, f)
+		require.NoError(t, cs.SetMetadata(f.change))
+
+		err := s.MergeChangeset(ctx, cs, false)
+		assert.ErrorAs(t, err, &ChangesetNotMergeableError{})
+	})
+
+	t.Run("submittable", func(t *testing.T) {
+		f := &fakeGerrit{submitOK: true}
+		s := newTestGerritSource(t, f)
+		cs := testGerritChangeset(t, f)
+		require.NoError(t, cs.SetMetadata(f.change))
+
+		require.NoError(t, s.MergeChangeset(ctx, cs, true))
+		assert.Equal(t, gerrit.ChangeStatusMerged, cs.Metadata.(*gerrit.Change).Status)
+	})
+}
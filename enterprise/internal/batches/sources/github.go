@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sourcegraph/log"
+
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -22,9 +24,14 @@ import (
 type GithubSource struct {
 	client *github.V4Client
 	au     auth.Authenticator
+
+	// v3Client is used for the changeset attributes that are not supported by
+	// the GraphQL API.
+	v3Client *github.V3Client
 }
 
 var _ ForkableChangesetSource = GithubSource{}
+var _ AttributesChangesetSource = GithubSource{}
 
 func NewGithubSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GithubSource, error) {
 	rawConfig, err := svc.Config.Decrypt(ctx)
@@ -68,8 +75,9 @@ func newGithubSource(urn string, c *schema.GitHubConnection, cf *httpcli.Factory
 	}
 
 	return &GithubSource{
-		au:     authr,
-		client: github.NewV4Client(urn, apiURL, authr, cli),
+		au:       authr,
+		client:   github.NewV4Client(urn, apiURL, authr, cli),
+		v3Client: github.NewV3Client(log.Scoped("GithubSource", "github v3 client for batch changes"), urn, apiURL, authr, cli),
 	}, nil
 }
 
@@ -90,6 +98,7 @@ func (s GithubSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource,
 	sc := s
 	sc.au = a
 	sc.client = sc.client.WithAuthenticator(a)
+	sc.v3Client = sc.v3Client.WithAuthenticator(a)
 
 	return &sc, nil
 }
@@ -250,6 +259,99 @@ func (s GithubSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
 	return c.Changeset.SetMetadata(updated)
 }
 
+// SupportsChangesetAttribute returns true, since GitHub supports all changeset
+// attributes.
+func (s GithubSource) SupportsChangesetAttribute(ChangesetAttribute) bool { return true }
+
+// UpdateChangesetAttributes adds the labels and assignees of the given
+// *Changeset to the pull request, requests reviews from its reviewers, adds it
+// to its milestone and removes the labels and assignees that are no longer
+// wanted. Reviewers of the form "org/team-slug" are requested as teams.
+func (s GithubSource) UpdateChangesetAttributes(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	repo := c.TargetRepo.Metadata.(*github.Repository)
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return errors.Wrap(err, "getting repo owner and name")
+	}
+
+	attrs := c.Attributes
+	if len(attrs.Labels) > 0 {
+		if err := s.v3Client.AddIssueLabels(ctx, owner, name, pr.Number, attrs.Labels); err != nil {
+			return errors.Wrap(err, "adding labels")
+		}
+	}
+	for _, label := range attrs.RemovedLabels {
+		if err := s.v3Client.RemoveIssueLabel(ctx, owner, name, pr.Number, label); err != nil {
+			return errors.Wrapf(err, "removing label %q", label)
+		}
+	}
+
+	if len(attrs.Assignees) > 0 {
+		if err := s.v3Client.AddIssueAssignees(ctx, owner, name, pr.Number, attrs.Assignees); err != nil {
+			return errors.Wrap(err, "adding assignees")
+		}
+	}
+	if len(attrs.RemovedAssignees) > 0 {
+		if err := s.v3Client.RemoveIssueAssignees(ctx, owner, name, pr.Number, attrs.RemovedAssignees); err != nil {
+			return errors.Wrap(err, "removing assignees")
+		}
+	}
+
+	var reviewers, teamReviewers []string
+	for _, reviewer := range attrs.Reviewers {
+		if _, team, ok := strings.Cut(reviewer, "/"); ok {
+			teamReviewers = append(teamReviewers, team)
+		} else if !strings.EqualFold(reviewer, pr.Author.Login) {
+			// GitHub rejects requesting a review from the author of the pull request.
+			reviewers = append(reviewers, reviewer)
+		}
+	}
+	if len(reviewers) > 0 || len(teamReviewers) > 0 {
+		if err := s.v3Client.RequestPullRequestReviewers(ctx, owner, name, pr.Number, reviewers, teamReviewers); err != nil {
+			return errors.Wrap(err, "requesting reviewers")
+		}
+	}
+
+	if attrs.Milestone != "" {
+		milestone, err := s.findMilestone(ctx, owner, name, attrs.Milestone)
+		if err != nil {
+			return err
+		}
+		if err := s.v3Client.SetIssueMilestone(ctx, owner, name, pr.Number, milestone.Number); err != nil {
+			return errors.Wrap(err, "setting milestone")
+		}
+	}
+
+	// Reload the pull request, so that the changeset reflects the new labels.
+	if err := s.client.LoadPullRequest(ctx, pr); err != nil {
+		return err
+	}
+
+	return c.Changeset.SetMetadata(pr)
+}
+
+func (s GithubSource) findMilestone(ctx context.Context, owner, name, title string) (*github.Milestone, error) {
+	for page := 1; ; page++ {
+		milestones, hasNextPage, err := s.v3Client.ListMilestones(ctx, owner, name, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing milestones")
+		}
+		for _, m := range milestones {
+			if m.Title == title {
+				return m, nil
+			}
+		}
+		if !hasNextPage {
+			return nil, errors.Newf("milestone %q not found in %s/%s", title, owner, name)
+		}
+	}
+}
+
 // ReopenChangeset reopens the given *Changeset on the code host.
 func (s GithubSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
 	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
@@ -29,6 +29,7 @@ type GitLabSource struct {
 var _ ChangesetSource = &GitLabSource{}
 var _ DraftChangesetSource = &GitLabSource{}
 var _ ForkableChangesetSource = &GitLabSource{}
+var _ AttributesChangesetSource = &GitLabSource{}
 
 // NewGitLabSource returns a new GitLabSource from the given external service.
 func NewGitLabSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GitLabSource, error) {
@@ -487,6 +488,104 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 	return c.Changeset.SetMetadata(updated)
 }
 
+// SupportsChangesetAttribute returns true, since GitLab supports all changeset
+// attributes.
+func (s *GitLabSource) SupportsChangesetAttribute(ChangesetAttribute) bool { return true }
+
+// UpdateChangesetAttributes adds the labels, assignees and reviewers of the
+// given *Changeset to the merge request, sets its milestone and removes the
+// labels and assignees that are no longer wanted.
+func (s *GitLabSource) UpdateChangesetAttributes(ctx context.Context, c *Changeset) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	attrs := c.Attributes
+	opts := gitlab.UpdateMergeRequestOpts{
+		AddLabels:    strings.Join(attrs.Labels, ","),
+		RemoveLabels: strings.Join(attrs.RemovedLabels, ","),
+	}
+
+	if len(attrs.Assignees) > 0 || len(attrs.RemovedAssignees) > 0 {
+		ids, err := s.mergeUserIDs(ctx, mr.Assignees, attrs.Assignees, attrs.RemovedAssignees)
+		if err != nil {
+			return errors.Wrap(err, "resolving assignees")
+		}
+		if len(ids) == 0 {
+			// GitLab unassigns all users when given the ID 0.
+			ids = []int32{0}
+		}
+		opts.AssigneeIDs = ids
+	}
+
+	if len(attrs.Reviewers) > 0 {
+		ids, err := s.mergeUserIDs(ctx, mr.Reviewers, attrs.Reviewers, nil)
+		if err != nil {
+			return errors.Wrap(err, "resolving reviewers")
+		}
+		opts.ReviewerIDs = ids
+	}
+
+	if attrs.Milestone != "" {
+		milestone, err := s.client.GetProjectMilestoneByTitle(ctx, project, attrs.Milestone)
+		if err != nil {
+			return err
+		}
+		if milestone == nil {
+			return errors.Newf("milestone %q not found in %s", attrs.Milestone, project.PathWithNamespace)
+		}
+		opts.MilestoneID = milestone.ID
+	}
+
+	updated, err := s.client.UpdateMergeRequest(ctx, project, mr, opts)
+	if err != nil {
+		return errors.Wrap(err, "updating GitLab merge request")
+	}
+
+	if err := s.decorateMergeRequestData(ctx, project, updated); err != nil {
+		return errors.Wrapf(err, "retrieving additional data for merge request %d", updated.IID)
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
+// mergeUserIDs returns the IDs of the current users of a merge request, without
+// the removed users and with the added users.
+func (s *GitLabSource) mergeUserIDs(ctx context.Context, current []gitlab.User, added, removed []string) ([]int32, error) {
+	isRemoved := make(map[string]bool, len(removed))
+	for _, username := range removed {
+		isRemoved[username] = true
+	}
+
+	var ids []int32
+	seen := map[string]bool{}
+	for _, u := range current {
+		if !isRemoved[u.Username] {
+			ids = append(ids, u.ID)
+			seen[u.Username] = true
+		}
+	}
+
+	for _, username := range added {
+		if seen[username] {
+			continue
+		}
+		u, err := s.client.GetUserByUsername(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		if u == nil {
+			return nil, errors.Newf("user %q not found", username)
+		}
+		ids = append(ids, u.ID)
+		seen[username] = true
+	}
+
+	return ids, nil
+}
+
 // UndraftChangeset marks the changeset as *not* work in progress anymore.
 func (s *GitLabSource) UndraftChangeset(ctx context.Context, c *Changeset) error {
 	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
@@ -592,3 +592,60 @@ func newMockSourcer(css ChangesetSource) Sourcer {
 		return css, nil
 	})
 }
+
+func TestValidateChangesetAttributes(t *testing.T) {
+	all := ChangesetAttributes{
+		Labels:    []string{"label"},
+		Reviewers: []string{"alice"},
+		Assignees: []string{"bob"},
+		Milestone: "v1",
+	}
+
+	for name, tc := range map[string]struct {
+		css   ChangesetSource
+		attrs ChangesetAttributes
+		want  []ChangesetAttribute
+	}{
+		"no attributes": {
+			css: &GerritSource{},
+		},
+		"all supported": {
+			css:   GithubSource{},
+			attrs: all,
+		},
+		"reviewers only": {
+			css:   BitbucketCloudSource{},
+			attrs: all,
+			want: []ChangesetAttribute{
+				ChangesetAttributeLabels,
+				ChangesetAttributeAssignees,
+				ChangesetAttributeMilestone,
+			},
+		},
+		"removed labels": {
+			css:   BitbucketServerSource{},
+			attrs: ChangesetAttributes{RemovedLabels: []string{"label"}},
+			want:  []ChangesetAttribute{ChangesetAttributeLabels},
+		},
+		"no attributes supported": {
+			css:   &GerritSource{},
+			attrs: ChangesetAttributes{Reviewers: []string{"alice"}},
+			want:  []ChangesetAttribute{ChangesetAttributeReviewers},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateChangesetAttributes(tc.css, tc.attrs)
+			if tc.want == nil {
+				assert.Nil(t, err)
+				return
+			}
+
+			var e UnsupportedChangesetAttributesError
+			if !errors.As(err, &e) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assert.Equal(t, tc.want, e.Attributes)
+			assert.True(t, errcode.IsNonRetryable(err))
+		})
+	}
+}
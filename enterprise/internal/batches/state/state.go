@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -94,6 +95,16 @@ func SetDerivedState(ctx context.Context, repoStore database.RepoStore, client g
 			c.SetDiffStat(stat)
 		}
 	}
+
+	// The base branch can move without the changeset changing, so the base
+	// state needs to be computed on every sync of an open changeset.
+	if !c.Complete() && c.SyncState.HeadRefOid != "" {
+		if state, err := computeBaseState(ctx, client, c, repo.Name); err != nil {
+			logger.Warn("Computing base state", log.Error(err))
+		} else {
+			c.BaseState = state
+		}
+	}
 }
 
 // computeCheckState computes the overall check state based on the current
@@ -112,6 +123,9 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 
 	case *bbcs.AnnotatedPullRequest:
 		return computeBitbucketCloudBuildState(c.UpdatedAt, m, events)
+
+	case *gerrit.Change:
+		return computeGerritVerifiedState(m)
 	}
 
 	return btypes.ChangesetCheckStateUnknown
@@ -207,6 +221,31 @@ func parseBitbucketServerBuildState(s string) btypes.ChangesetCheckState {
 	}
 }
 
+// computeGerritVerifiedState computes the check state of a Gerrit change from
+// the votes on its Verified label, which is where CI systems usually report
+// their results.
+func computeGerritVerifiedState(change *gerrit.Change) btypes.ChangesetCheckState {
+	label, ok := change.Labels[gerrit.LabelVerified]
+	if !ok {
+		return btypes.ChangesetCheckStateUnknown
+	}
+
+	states := []btypes.ChangesetCheckState{}
+	for _, vote := range label.All {
+		switch {
+		case vote.Value < 0:
+			states = append(states, btypes.ChangesetCheckStateFailed)
+		case vote.Value > 0:
+			states = append(states, btypes.ChangesetCheckStatePassed)
+		}
+	}
+	if len(states) == 0 {
+		return btypes.ChangesetCheckStatePending
+	}
+
+	return combineCheckStates(states)
+}
+
 func computeBitbucketCloudBuildState(lastSynced time.Time, apr *bbcs.AnnotatedPullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
 	stateMap := make(map[string]btypes.ChangesetCheckState)
 
@@ -515,6 +554,21 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown Bitbucket Cloud pull request state: %s", m.State)
 		}
+	case *gerrit.Change:
+		switch m.Status {
+		case gerrit.ChangeStatusAbandoned:
+			s = btypes.ChangesetExternalStateClosed
+		case gerrit.ChangeStatusMerged:
+			s = btypes.ChangesetExternalStateMerged
+		case gerrit.ChangeStatusNew:
+			if m.WorkInProgress {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Gerrit change status: %s", m.Status)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -584,6 +638,23 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 			}
 		}
 
+	case *gerrit.Change:
+		// A change is approved once it has a Code-Review +2. Any negative
+		// vote on Code-Review or Verified means changes are requested.
+		for name, label := range m.Labels {
+			if name != gerrit.LabelCodeReview && name != gerrit.LabelVerified {
+				continue
+			}
+			for _, vote := range label.All {
+				switch {
+				case vote.Value < 0:
+					states[btypes.ChangesetReviewStateChangesRequested] = true
+				case name == gerrit.LabelCodeReview && vote.Value >= 2:
+					states[btypes.ChangesetReviewStateApproved] = true
+				}
+			}
+		}
+
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -642,6 +713,92 @@ func computeDiffStat(ctx context.Context, client gitserver.Client, c *btypes.Cha
 	return stat, nil
 }
 
+// computeBaseState computes whether the base branch of the changeset has moved
+// since the changeset was created and, if so, whether the base branch changed
+// files that the changeset changes as well. The latter is what usually causes
+// merge conflicts, but it doesn't guarantee them.
+//
+// The sync state of the changeset must be up to date.
+func computeBaseState(ctx context.Context, client gitserver.Client, c *btypes.Changeset, repo api.RepoName) (btypes.ChangesetBaseState, error) {
+	baseRef, err := c.BaseRef()
+	if err != nil {
+		return "", err
+	}
+
+	// The base revision in the sync state is the one the code host associates
+	// with the changeset, which isn't necessarily the current head of the base
+	// branch, so we resolve the branch itself.
+	base, err := client.ResolveRevision(ctx, repo, baseRef, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return "", err
+	}
+	head := api.CommitID(c.SyncState.HeadRefOid)
+
+	mergeBase, err := client.MergeBase(ctx, repo, base, head)
+	if err != nil {
+		return "", err
+	}
+	if mergeBase == base {
+		return btypes.ChangesetBaseStateUpToDate, nil
+	}
+
+	changed, err := computeChangedFiles(ctx, client, repo, string(base), string(head))
+	if err != nil {
+		return "", err
+	}
+	if len(changed) == 0 {
+		return btypes.ChangesetBaseStateOutdated, nil
+	}
+
+	// Now check whether the base branch changed any of the same files since
+	// the changeset branched off.
+	changedOnBase, err := computeChangedFiles(ctx, client, repo, string(head), string(base), changed...)
+	if err != nil {
+		return "", err
+	}
+	if len(changedOnBase) > 0 {
+		return btypes.ChangesetBaseStateConflicting, nil
+	}
+	return btypes.ChangesetBaseStateOutdated, nil
+}
+
+// computeChangedFiles returns the paths of the files that were changed on head
+// since it diverged from base, optionally limited to the given paths.
+func computeChangedFiles(ctx context.Context, client gitserver.Client, repo api.RepoName, base, head string, paths ...string) ([]string, error) {
+	iter, err := client.Diff(ctx, gitserver.DiffOptions{
+		Repo:      repo,
+		Base:      base,
+		Head:      head,
+		RangeType: "...",
+		Paths:     paths,
+	}, authz.DefaultSubRepoPermsChecker)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var files []string
+	for {
+		file, err := iter.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		// Both names are relevant for renames, and one of them is /dev/null for
+		// added and deleted files.
+		if file.OrigName != "/dev/null" {
+			files = append(files, file.OrigName)
+		}
+		if file.NewName != "/dev/null" && file.NewName != file.OrigName {
+			files = append(files, file.NewName)
+		}
+	}
+
+	return files, nil
+}
+
 // computeSyncState computes the up to date sync state based on the changeset as
 // it currently exists on the external provider.
 func computeSyncState(ctx context.Context, client gitserver.Client, c *btypes.Changeset, repo api.RepoName) (*btypes.ChangesetSyncState, error) {
@@ -1,6 +1,10 @@
 package state
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -8,10 +12,14 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -407,6 +415,50 @@ func TestComputeGitLabCheckState(t *testing.T) {
 	})
 }
 
+func TestComputeGerritVerifiedState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		votes map[string][]int
+		want  btypes.ChangesetCheckState
+	}{
+		{
+			name: "no verified label",
+			want: btypes.ChangesetCheckStateUnknown,
+		},
+		{
+			name:  "no votes",
+			votes: map[string][]int{gerrit.LabelVerified: {}},
+			want:  btypes.ChangesetCheckStatePending,
+		},
+		{
+			name:  "verified",
+			votes: map[string][]int{gerrit.LabelVerified: {1}},
+			want:  btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name:  "verified and failed",
+			votes: map[string][]int{gerrit.LabelVerified: {1, -1}},
+			want:  btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name:  "code review votes are ignored",
+			votes: map[string][]int{gerrit.LabelCodeReview: {-2}},
+			want:  btypes.ChangesetCheckStateUnknown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gerritChangeset(timeutil.Now(), gerrit.ChangeStatusNew, tc.votes)
+			if have := computeCheckState(c, nil); have != tc.want {
+				t.Errorf("wrong check state. have=%s, want=%s", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestComputeReviewState(t *testing.T) {
 	t.Parallel()
 
@@ -536,6 +588,54 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "gerrit - no votes",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "gerrit - code review +1",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string][]int{
+				gerrit.LabelCodeReview: {1},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "gerrit - code review +2",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string][]int{
+				gerrit.LabelCodeReview: {1, 2},
+				gerrit.LabelVerified:   {1},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "gerrit - code review +2 and -1",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string][]int{
+				gerrit.LabelCodeReview: {2, -1},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateChangesRequested,
+		},
+		{
+			name: "gerrit - verified -1",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string][]int{
+				gerrit.LabelCodeReview: {2},
+				gerrit.LabelVerified:   {-1},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateChangesRequested,
+		},
+		{
+			name: "gerrit - other labels are ignored",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string][]int{
+				"Library-Compliance": {-1},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStatePending,
+		},
 	}
 
 	for i, tc := range tests {
@@ -744,6 +844,30 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: btypes.ChangesetExternalStateReadOnly,
 		},
+		{
+			name:      "gerrit new - no events",
+			changeset: gerritChangeset(daysAgo(10), gerrit.ChangeStatusNew, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "gerrit work in progress - no events",
+			changeset: setGerritWorkInProgress(gerritChangeset(daysAgo(10), gerrit.ChangeStatusNew, nil)),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateDraft,
+		},
+		{
+			name:      "gerrit abandoned - no events",
+			changeset: gerritChangeset(daysAgo(10), gerrit.ChangeStatusAbandoned, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "gerrit merged - no events",
+			changeset: gerritChangeset(daysAgo(10), gerrit.ChangeStatusMerged, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
 	}
 
 	for i, tc := range tests {
@@ -767,6 +891,78 @@ func TestComputeExternalState(t *testing.T) {
 	}
 }
 
+func TestComputeBaseState(t *testing.T) {
+	ctx := context.Background()
+
+	diffForFiles := func(files ...string) string {
+		var b strings.Builder
+		for _, f := range files {
+			fmt.Fprintf(&b, "diff --git %[1]s %[1]s\n--- %[1]s\n+++ %[1]s\n@@ -1 +1 @@\n-a\n+b\n", f)
+		}
+		return b.String()
+	}
+
+	for name, tc := range map[string]struct {
+		mergeBase     api.CommitID
+		changedOnHead []string
+		changedOnBase []string
+		want          btypes.ChangesetBaseState
+	}{
+		"up to date": {
+			mergeBase: "base",
+			want:      btypes.ChangesetBaseStateUpToDate,
+		},
+		"outdated": {
+			mergeBase:     "old-base",
+			changedOnHead: []string{"README.md"},
+			want:          btypes.ChangesetBaseStateOutdated,
+		},
+		"outdated without changes": {
+			mergeBase: "old-base",
+			want:      btypes.ChangesetBaseStateOutdated,
+		},
+		"conflicting": {
+			mergeBase:     "old-base",
+			changedOnHead: []string{"README.md", "main.go"},
+			changedOnBase: []string{"main.go"},
+			want:          btypes.ChangesetBaseStateConflicting,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := gitserver.NewMockClient()
+			client.ResolveRevisionFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, spec string, _ gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+				if spec != "refs/heads/main" {
+					t.Fatalf("unexpected revision %q", spec)
+				}
+				return "base", nil
+			})
+			client.MergeBaseFunc.SetDefaultReturn(tc.mergeBase, nil)
+			client.DiffFunc.SetDefaultHook(func(_ context.Context, opts gitserver.DiffOptions, _ authz.SubRepoPermissionChecker) (*gitserver.DiffFileIterator, error) {
+				files := tc.changedOnHead
+				if opts.Head == "base" {
+					if diff := cmp.Diff(tc.changedOnHead, opts.Paths); diff != "" {
+						t.Fatalf("unexpected paths (-want +got):\n%s", diff)
+					}
+					files = tc.changedOnBase
+				}
+				return gitserver.NewDiffFileIterator(io.NopCloser(strings.NewReader(diffForFiles(files...)))), nil
+			})
+
+			c := &btypes.Changeset{
+				Metadata:  &github.PullRequest{BaseRefName: "main"},
+				SyncState: btypes.ChangesetSyncState{HeadRefOid: "head"},
+			}
+			have, err := computeBaseState(ctx, client, c, "github.com/sourcegraph/sourcegraph")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tc.want {
+				t.Fatalf("unexpected base state: have=%q want=%q", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestComputeLabels(t *testing.T) {
 	t.Parallel()
 
@@ -900,6 +1096,34 @@ func gitLabChangeset(updatedAt time.Time, state gitlab.MergeRequestState, notes
 	}
 }
 
+func gerritChangeset(updatedAt time.Time, status gerrit.ChangeStatus, votes map[string][]int) *btypes.Changeset {
+	labels := make(map[string]gerrit.LabelInfo, len(votes))
+	for name, values := range votes {
+		var label gerrit.LabelInfo
+		for i, v := range values {
+			label.All = append(label.All, gerrit.ApprovalInfo{
+				Account: gerrit.Account{ID: int32(i + 1)},
+				Value:   v,
+			})
+		}
+		labels[name] = label
+	}
+
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeGerrit,
+		UpdatedAt:           updatedAt,
+		Metadata: &gerrit.Change{
+			Status: status,
+			Labels: labels,
+		},
+	}
+}
+
+func setGerritWorkInProgress(c *btypes.Changeset) *btypes.Changeset {
+	c.Metadata.(*gerrit.Change).WorkInProgress = true
+	return c
+}
+
 func setDeletedAt(c *btypes.Changeset, deletedAt time.Time) *btypes.Changeset {
 	c.ExternalDeletedAt = deletedAt
 	return c
@@ -0,0 +1,367 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// autoMergePolicyColumns are used by the auto-merge policy related Store
+// methods to insert, update and query auto-merge policies.
+var autoMergePolicyColumns = SQLColumns{
+	"batch_change_auto_merge_policies.batch_change_id",
+	"batch_change_auto_merge_policies.user_id",
+	"batch_change_auto_merge_policies.require_passing_checks",
+	"batch_change_auto_merge_policies.require_approval",
+	"batch_change_auto_merge_policies.squash",
+	"batch_change_auto_merge_policies.windows",
+	"batch_change_auto_merge_policies.max_merges_per_hour",
+	"batch_change_auto_merge_policies.created_at",
+	"batch_change_auto_merge_policies.updated_at",
+}
+
+// UpsertAutoMergePolicy creates the auto-merge policy of a batch change, or
+// replaces it if the batch change already has one.
+func (s *Store) UpsertAutoMergePolicy(ctx context.Context, p *btypes.AutoMergePolicy) (err error) {
+	ctx, _, endObservation := s.operations.upsertAutoMergePolicy.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(p.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q, err := s.upsertAutoMergePolicyQuery(p)
+	if err != nil {
+		return err
+	}
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanAutoMergePolicy(p, sc)
+	})
+}
+
+var upsertAutoMergePolicyQueryFmtstr = `
+INSERT INTO batch_change_auto_merge_policies (
+	batch_change_id,
+	user_id,
+	require_passing_checks,
+	require_approval,
+	squash,
+	windows,
+	max_merges_per_hour,
+	created_at,
+	updated_at
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (batch_change_id)
+DO UPDATE SET
+	user_id = EXCLUDED.user_id,
+	require_passing_checks = EXCLUDED.require_passing_checks,
+	require_approval = EXCLUDED.require_approval,
+	squash = EXCLUDED.squash,
+	windows = EXCLUDED.windows,
+	max_merges_per_hour = EXCLUDED.max_merges_per_hour,
+	updated_at = EXCLUDED.updated_at
+RETURNING %s
+`
+
+func (s *Store) upsertAutoMergePolicyQuery(p *btypes.AutoMergePolicy) (*sqlf.Query, error) {
+	windows := p.Windows
+	if windows == nil {
+		windows = []*schema.BatchChangeRolloutWindow{}
+	}
+	rawWindows, err := json.Marshal(windows)
+	if err != nil {
+		return nil, err
+	}
+
+	p.UpdatedAt = s.now()
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = p.UpdatedAt
+	}
+
+	return sqlf.Sprintf(
+		upsertAutoMergePolicyQueryFmtstr,
+		p.BatchChangeID,
+		p.UserID,
+		p.RequirePassingChecks,
+		p.RequireApproval,
+		p.Squash,
+		rawWindows,
+		p.MaxMergesPerHour,
+		p.CreatedAt,
+		p.UpdatedAt,
+		sqlf.Join(autoMergePolicyColumns.ToSqlf(), ", "),
+	), nil
+}
+
+// GetAutoMergePolicy gets the auto-merge policy of the given batch change. It
+// returns ErrNoResults if the batch change has no policy.
+func (s *Store) GetAutoMergePolicy(ctx context.Context, batchChangeID int64) (p *btypes.AutoMergePolicy, err error) {
+	ctx, _, endObservation := s.operations.getAutoMergePolicy.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		getAutoMergePolicyQueryFmtstr,
+		sqlf.Join(autoMergePolicyColumns.ToSqlf(), ", "),
+		batchChangeID,
+	)
+
+	var policy btypes.AutoMergePolicy
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanAutoMergePolicy(&policy, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if policy.BatchChangeID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &policy, nil
+}
+
+var getAutoMergePolicyQueryFmtstr = `
+SELECT %s FROM batch_change_auto_merge_policies
+WHERE batch_change_id = %s
+`
+
+// DeleteAutoMergePolicy deletes the auto-merge policy of the given batch
+// change, if any.
+func (s *Store) DeleteAutoMergePolicy(ctx context.Context, batchChangeID int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteAutoMergePolicy.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(deleteAutoMergePolicyQueryFmtstr, batchChangeID))
+}
+
+var deleteAutoMergePolicyQueryFmtstr = `
+DELETE FROM batch_change_auto_merge_policies WHERE batch_change_id = %s
+`
+
+// ListAutoMergePolicies lists the auto-merge policies of all batch changes
+// that are still open.
+func (s *Store) ListAutoMergePolicies(ctx context.Context) (ps []*btypes.AutoMergePolicy, err error) {
+	ctx, _, endObservation := s.operations.listAutoMergePolicies.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listAutoMergePoliciesQueryFmtstr,
+		sqlf.Join(autoMergePolicyColumns.ToSqlf(), ", "),
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var p btypes.AutoMergePolicy
+		if err := scanAutoMergePolicy(&p, sc); err != nil {
+			return err
+		}
+		ps = append(ps, &p)
+		return nil
+	})
+	return ps, err
+}
+
+var listAutoMergePoliciesQueryFmtstr = `
+SELECT %s FROM batch_change_auto_merge_policies
+JOIN batch_changes ON batch_changes.id = batch_change_auto_merge_policies.batch_change_id
+WHERE batch_changes.closed_at IS NULL
+ORDER BY batch_change_auto_merge_policies.batch_change_id ASC
+`
+
+func scanAutoMergePolicy(p *btypes.AutoMergePolicy, s dbutil.Scanner) error {
+	var rawWindows json.RawMessage
+	if err := s.Scan(
+		&p.BatchChangeID,
+		&p.UserID,
+		&p.RequirePassingChecks,
+		&p.RequireApproval,
+		&p.Squash,
+		&rawWindows,
+		&p.MaxMergesPerHour,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(rawWindows, &p.Windows)
+}
+
+// autoMergeEventColumns are used by the auto-merge event related Store methods
+// to insert and query auto-merge events.
+var autoMergeEventColumns = SQLColumns{
+	"changeset_auto_merge_events.id",
+	"changeset_auto_merge_events.batch_change_id",
+	"changeset_auto_merge_events.changeset_id",
+	"changeset_auto_merge_events.decision",
+	"changeset_auto_merge_events.reason",
+	"changeset_auto_merge_events.created_at",
+}
+
+// CreateAutoMergeEvent records the given auto-merge decision, unless it is
+// the same as the last decision recorded for the changeset. This keeps the
+// audit trail to the changes in the decisions, rather than one entry per
+// evaluation. It returns whether the event was recorded.
+func (s *Store) CreateAutoMergeEvent(ctx context.Context, e *btypes.AutoMergeEvent) (recorded bool, err error) {
+	ctx, _, endObservation := s.operations.createAutoMergeEvent.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("changesetID", int(e.ChangesetID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = s.now()
+	}
+
+	q := sqlf.Sprintf(
+		createAutoMergeEventQueryFmtstr,
+		e.BatchChangeID,
+		e.ChangesetID,
+		e.Decision,
+		e.Reason,
+		e.CreatedAt,
+		e.BatchChangeID,
+		e.ChangesetID,
+		e.Decision,
+		e.Reason,
+		sqlf.Join(autoMergeEventColumns.ToSqlf(), ", "),
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		recorded = true
+		return scanAutoMergeEvent(e, sc)
+	})
+	return recorded, err
+}
+
+var createAutoMergeEventQueryFmtstr = `
+INSERT INTO changeset_auto_merge_events (batch_change_id, changeset_id, decision, reason, created_at)
+SELECT %s, %s, %s, %s, %s
+WHERE NOT EXISTS (
+	SELECT 1 FROM (
+		SELECT decision, reason
+		FROM changeset_auto_merge_events
+		WHERE batch_change_id = %s AND changeset_id = %s
+		ORDER BY id DESC
+		LIMIT 1
+	) AS latest
+	WHERE latest.decision = %s AND latest.reason = %s
+)
+RETURNING %s
+`
+
+// ListAutoMergeEventsOpts captures the query options needed for listing
+// auto-merge events.
+type ListAutoMergeEventsOpts struct {
+	LimitOpts
+	Cursor        int64
+	BatchChangeID int64
+	ChangesetID   int64
+}
+
+// ListAutoMergeEvents lists auto-merge events with the given filters, most
+// recent first.
+func (s *Store) ListAutoMergeEvents(ctx context.Context, opts ListAutoMergeEventsOpts) (es []*btypes.AutoMergeEvent, next int64, err error) {
+	ctx, _, endObservation := s.operations.listAutoMergeEvents.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	preds := autoMergeEventsPredicates(opts.BatchChangeID, opts.ChangesetID)
+	if opts.Cursor > 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_auto_merge_events.id <= %s", opts.Cursor))
+	}
+
+	q := sqlf.Sprintf(
+		listAutoMergeEventsQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(autoMergeEventColumns.ToSqlf(), ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	es = make([]*btypes.AutoMergeEvent, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var e btypes.AutoMergeEvent
+		if err := scanAutoMergeEvent(&e, sc); err != nil {
+			return err
+		}
+		es = append(es, &e)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(es) == opts.DBLimit() {
+		next = es[len(es)-1].ID
+		es = es[:len(es)-1]
+	}
+
+	return es, next, err
+}
+
+var listAutoMergeEventsQueryFmtstr = `
+SELECT %s FROM changeset_auto_merge_events
+WHERE %s
+ORDER BY changeset_auto_merge_events.id DESC
+`
+
+// CountAutoMergeEventsOpts captures the query options needed for counting
+// auto-merge events.
+type CountAutoMergeEventsOpts struct {
+	BatchChangeID int64
+	ChangesetID   int64
+	Decision      btypes.AutoMergeDecision
+	CreatedAfter  time.Time
+}
+
+// CountAutoMergeEvents returns the number of auto-merge events with the given
+// filters.
+func (s *Store) CountAutoMergeEvents(ctx context.Context, opts CountAutoMergeEventsOpts) (count int, err error) {
+	ctx, _, endObservation := s.operations.countAutoMergeEvents.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	preds := autoMergeEventsPredicates(opts.BatchChangeID, opts.ChangesetID)
+	if opts.Decision != "" {
+		preds = append(preds, sqlf.Sprintf("changeset_auto_merge_events.decision = %s", opts.Decision))
+	}
+	if !opts.CreatedAfter.IsZero() {
+		preds = append(preds, sqlf.Sprintf("changeset_auto_merge_events.created_at > %s", opts.CreatedAfter))
+	}
+
+	return s.queryCount(ctx, sqlf.Sprintf(
+		countAutoMergeEventsQueryFmtstr,
+		sqlf.Join(preds, "\n AND "),
+	))
+}
+
+var countAutoMergeEventsQueryFmtstr = `
+SELECT COUNT(*) FROM changeset_auto_merge_events
+WHERE %s
+`
+
+func autoMergeEventsPredicates(batchChangeID, changesetID int64) []*sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if batchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_auto_merge_events.batch_change_id = %s", batchChangeID))
+	}
+	if changesetID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_auto_merge_events.changeset_id = %s", changesetID))
+	}
+	return preds
+}
+
+func scanAutoMergeEvent(e *btypes.AutoMergeEvent, s dbutil.Scanner) error {
+	return s.Scan(
+		&e.ID,
+		&e.BatchChangeID,
+		&e.ChangesetID,
+		&e.Decision,
+		&e.Reason,
+		&e.CreatedAt,
+	)
+}
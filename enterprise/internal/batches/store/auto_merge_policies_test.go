@@ -0,0 +1,147 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func testStoreAutoMergePolicies(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	batchChange := bt.CreateBatchChange(t, ctx, s, "auto-merge", 1234, 0)
+	closedBatchChange := bt.CreateBatchChange(t, ctx, s, "closed-auto-merge", 1234, 0)
+	closedBatchChange.ClosedAt = clock.Now()
+	if err := s.UpdateBatchChange(ctx, closedBatchChange); err != nil {
+		t.Fatal(err)
+	}
+
+	policy := &btypes.AutoMergePolicy{
+		BatchChangeID:        batchChange.ID,
+		UserID:               1234,
+		RequirePassingChecks: true,
+		RequireApproval:      true,
+		Windows: []*schema.BatchChangeRolloutWindow{
+			{Days: []string{"saturday"}, Start: "08:00", End: "10:00", Rate: "unlimited"},
+		},
+		MaxMergesPerHour: 5,
+	}
+
+	t.Run("Upsert", func(t *testing.T) {
+		if err := s.UpsertAutoMergePolicy(ctx, policy); err != nil {
+			t.Fatal(err)
+		}
+		if policy.CreatedAt.IsZero() || policy.UpdatedAt.IsZero() {
+			t.Fatal("timestamps should be set")
+		}
+
+		clock.Add(1 * time.Minute)
+		policy.Squash = true
+		if err := s.UpsertAutoMergePolicy(ctx, policy); err != nil {
+			t.Fatal(err)
+		}
+		if !policy.UpdatedAt.After(policy.CreatedAt) {
+			t.Fatalf("UpdatedAt should be after CreatedAt, got %s and %s", policy.UpdatedAt, policy.CreatedAt)
+		}
+
+		if err := s.UpsertAutoMergePolicy(ctx, &btypes.AutoMergePolicy{
+			BatchChangeID: closedBatchChange.ID,
+			UserID:        1234,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		have, err := s.GetAutoMergePolicy(ctx, batchChange.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(policy, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		if _, err := s.GetAutoMergePolicy(ctx, 0xdeadbeef); err != ErrNoResults {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListAutoMergePolicies(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]*btypes.AutoMergePolicy{policy}, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Events", func(t *testing.T) {
+		changeset := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			BatchChanges: []btypes.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+		})
+
+		for _, tc := range []struct {
+			decision btypes.AutoMergeDecision
+			reason   string
+			recorded bool
+		}{
+			{btypes.AutoMergeDecisionSkip, "checks are pending", true},
+			{btypes.AutoMergeDecisionSkip, "checks are pending", false},
+			{btypes.AutoMergeDecisionMerge, "all conditions are met", true},
+		} {
+			clock.Add(1 * time.Minute)
+			recorded, err := s.CreateAutoMergeEvent(ctx, &btypes.AutoMergeEvent{
+				BatchChangeID: batchChange.ID,
+				ChangesetID:   changeset.ID,
+				Decision:      tc.decision,
+				Reason:        tc.reason,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if recorded != tc.recorded {
+				t.Fatalf("unexpected recorded value for %q: have=%v want=%v", tc.reason, recorded, tc.recorded)
+			}
+		}
+
+		events, next, err := s.ListAutoMergeEvents(ctx, ListAutoMergeEventsOpts{
+			LimitOpts:     LimitOpts{Limit: 1},
+			BatchChangeID: batchChange.ID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != 1 || events[0].Decision != btypes.AutoMergeDecisionMerge {
+			t.Fatalf("unexpected events: %+v", events)
+		}
+		if next == 0 {
+			t.Fatal("expected a next cursor")
+		}
+
+		count, err := s.CountAutoMergeEvents(ctx, CountAutoMergeEventsOpts{
+			BatchChangeID: batchChange.ID,
+			Decision:      btypes.AutoMergeDecisionMerge,
+			CreatedAfter:  clock.Now().Add(-1 * time.Hour),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 1 {
+			t.Fatalf("unexpected count: %d", count)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := s.DeleteAutoMergePolicy(ctx, batchChange.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.GetAutoMergePolicy(ctx, batchChange.ID); err != ErrNoResults {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
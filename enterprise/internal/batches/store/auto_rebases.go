@@ -0,0 +1,156 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// autoRebaseColumns are used by the auto-rebase related Store methods to
+// insert, update and query auto-rebases.
+var autoRebaseColumns = SQLColumns{
+	"batch_change_auto_rebases.batch_change_id",
+	"batch_change_auto_rebases.user_id",
+	"batch_change_auto_rebases.batch_spec_id",
+	"batch_change_auto_rebases.created_at",
+	"batch_change_auto_rebases.updated_at",
+}
+
+// UpsertAutoRebase enables automatic rebases for a batch change, or updates
+// the existing auto-rebase of the batch change.
+func (s *Store) UpsertAutoRebase(ctx context.Context, r *btypes.AutoRebase) (err error) {
+	ctx, _, endObservation := s.operations.upsertAutoRebase.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(r.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.query(ctx, s.upsertAutoRebaseQuery(r), func(sc dbutil.Scanner) error {
+		return scanAutoRebase(r, sc)
+	})
+}
+
+var upsertAutoRebaseQueryFmtstr = `
+INSERT INTO batch_change_auto_rebases (
+	batch_change_id,
+	user_id,
+	batch_spec_id,
+	created_at,
+	updated_at
+)
+VALUES (%s, %s, %s, %s, %s)
+ON CONFLICT (batch_change_id)
+DO UPDATE SET
+	user_id = EXCLUDED.user_id,
+	batch_spec_id = EXCLUDED.batch_spec_id,
+	updated_at = EXCLUDED.updated_at
+RETURNING %s
+`
+
+func (s *Store) upsertAutoRebaseQuery(r *btypes.AutoRebase) *sqlf.Query {
+	r.UpdatedAt = s.now()
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = r.UpdatedAt
+	}
+
+	return sqlf.Sprintf(
+		upsertAutoRebaseQueryFmtstr,
+		r.BatchChangeID,
+		r.UserID,
+		dbutil.NullInt64Column(r.BatchSpecID),
+		r.CreatedAt,
+		r.UpdatedAt,
+		sqlf.Join(autoRebaseColumns.ToSqlf(), ", "),
+	)
+}
+
+// GetAutoRebase gets the auto-rebase of the given batch change. It returns
+// ErrNoResults if automatic rebases are not enabled for the batch change.
+func (s *Store) GetAutoRebase(ctx context.Context, batchChangeID int64) (r *btypes.AutoRebase, err error) {
+	ctx, _, endObservation := s.operations.getAutoRebase.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		getAutoRebaseQueryFmtstr,
+		sqlf.Join(autoRebaseColumns.ToSqlf(), ", "),
+		batchChangeID,
+	)
+
+	var rebase btypes.AutoRebase
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanAutoRebase(&rebase, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if rebase.BatchChangeID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &rebase, nil
+}
+
+var getAutoRebaseQueryFmtstr = `
+SELECT %s FROM batch_change_auto_rebases
+WHERE batch_change_id = %s
+`
+
+// DeleteAutoRebase disables automatic rebases for the given batch change.
+func (s *Store) DeleteAutoRebase(ctx context.Context, batchChangeID int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteAutoRebase.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(deleteAutoRebaseQueryFmtstr, batchChangeID))
+}
+
+var deleteAutoRebaseQueryFmtstr = `
+DELETE FROM batch_change_auto_rebases WHERE batch_change_id = %s
+`
+
+// ListAutoRebases lists the auto-rebases of all batch changes that are still
+// open.
+func (s *Store) ListAutoRebases(ctx context.Context) (rs []*btypes.AutoRebase, err error) {
+	ctx, _, endObservation := s.operations.listAutoRebases.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listAutoRebasesQueryFmtstr,
+		sqlf.Join(autoRebaseColumns.ToSqlf(), ", "),
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var r btypes.AutoRebase
+		if err := scanAutoRebase(&r, sc); err != nil {
+			return err
+		}
+		rs = append(rs, &r)
+		return nil
+	})
+	return rs, err
+}
+
+var listAutoRebasesQueryFmtstr = `
+SELECT %s FROM batch_change_auto_rebases
+JOIN batch_changes ON batch_changes.id = batch_change_auto_rebases.batch_change_id
+WHERE batch_changes.closed_at IS NULL
+ORDER BY batch_change_auto_rebases.batch_change_id ASC
+`
+
+func scanAutoRebase(r *btypes.AutoRebase, s dbutil.Scanner) error {
+	return s.Scan(
+		&r.BatchChangeID,
+		&r.UserID,
+		&dbutil.NullInt64{N: &r.BatchSpecID},
+		&r.CreatedAt,
+		&r.UpdatedAt,
+	)
+}
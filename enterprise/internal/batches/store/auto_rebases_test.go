@@ -0,0 +1,85 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+func testStoreAutoRebases(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	batchChange := bt.CreateBatchChange(t, ctx, s, "auto-rebase", 1234, 0)
+	closedBatchChange := bt.CreateBatchChange(t, ctx, s, "closed-auto-rebase", 1234, 0)
+	closedBatchChange.ClosedAt = clock.Now()
+	if err := s.UpdateBatchChange(ctx, closedBatchChange); err != nil {
+		t.Fatal(err)
+	}
+
+	rebase := &btypes.AutoRebase{
+		BatchChangeID: batchChange.ID,
+		UserID:        1234,
+	}
+
+	t.Run("Upsert", func(t *testing.T) {
+		if err := s.UpsertAutoRebase(ctx, rebase); err != nil {
+			t.Fatal(err)
+		}
+		if rebase.CreatedAt.IsZero() || rebase.UpdatedAt.IsZero() {
+			t.Fatal("timestamps should be set")
+		}
+
+		clock.Add(1 * time.Minute)
+		batchSpec := bt.CreateBatchSpec(t, ctx, s, "auto-rebase", 1234, batchChange.ID)
+		rebase.BatchSpecID = batchSpec.ID
+		if err := s.UpsertAutoRebase(ctx, rebase); err != nil {
+			t.Fatal(err)
+		}
+		if !rebase.UpdatedAt.After(rebase.CreatedAt) {
+			t.Fatalf("UpdatedAt should be after CreatedAt, got %s and %s", rebase.UpdatedAt, rebase.CreatedAt)
+		}
+
+		if err := s.UpsertAutoRebase(ctx, &btypes.AutoRebase{
+			BatchChangeID: closedBatchChange.ID,
+			UserID:        1234,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		have, err := s.GetAutoRebase(ctx, batchChange.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(rebase, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		if _, err := s.GetAutoRebase(ctx, 0xdeadbeef); err != ErrNoResults {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListAutoRebases(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]*btypes.AutoRebase{rebase}, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := s.DeleteAutoRebase(ctx, batchChange.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.GetAutoRebase(ctx, batchChange.ID); err != ErrNoResults {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
@@ -37,6 +37,7 @@ var batchSpecWorkspaceInsertColumns = []string{
 	"skipped",
 	"cached_result_found",
 	"step_cache_results",
+	"policy_violations",
 
 	"created_at",
 	"updated_at",
@@ -61,6 +62,7 @@ var BatchSpecWorkspaceColums = SQLColumns{
 	"batch_spec_workspaces.skipped",
 	"batch_spec_workspaces.cached_result_found",
 	"batch_spec_workspaces.step_cache_results",
+	"batch_spec_workspaces.policy_violations",
 
 	"batch_spec_workspaces.created_at",
 	"batch_spec_workspaces.updated_at",
@@ -102,6 +104,15 @@ func (s *Store) CreateBatchSpecWorkspace(ctx context.Context, ws ...*btypes.Batc
 				return err
 			}
 
+			if wj.PolicyViolations == nil {
+				wj.PolicyViolations = []btypes.ChangesetPolicyViolation{}
+			}
+
+			marshaledPolicyViolations, err := json.Marshal(wj.PolicyViolations)
+			if err != nil {
+				return err
+			}
+
 			if err := inserter.Insert(
 				ctx,
 				wj.BatchSpecID,
@@ -117,6 +128,7 @@ func (s *Store) CreateBatchSpecWorkspace(ctx context.Context, ws ...*btypes.Batc
 				wj.Skipped,
 				wj.CachedResultFound,
 				marshaledStepCacheResults,
+				marshaledPolicyViolations,
 				wj.CreatedAt,
 				wj.UpdatedAt,
 			); err != nil {
@@ -449,7 +461,7 @@ WHERE %s
 `
 
 func scanBatchSpecWorkspace(wj *btypes.BatchSpecWorkspace, s dbutil.Scanner) error {
-	var stepCacheResults json.RawMessage
+	var stepCacheResults, policyViolations json.RawMessage
 
 	if err := s.Scan(
 		&wj.ID,
@@ -466,6 +478,7 @@ func scanBatchSpecWorkspace(wj *btypes.BatchSpecWorkspace, s dbutil.Scanner) err
 		&wj.Skipped,
 		&wj.CachedResultFound,
 		&stepCacheResults,
+		&policyViolations,
 		&wj.CreatedAt,
 		&wj.UpdatedAt,
 	); err != nil {
@@ -476,6 +489,10 @@ func scanBatchSpecWorkspace(wj *btypes.BatchSpecWorkspace, s dbutil.Scanner) err
 		return errors.Wrap(err, "scanBatchSpecWorkspace: failed to unmarshal StepCacheResults")
 	}
 
+	if err := json.Unmarshal(policyViolations, &wj.PolicyViolations); err != nil {
+		return errors.Wrap(err, "scanBatchSpecWorkspace: failed to unmarshal PolicyViolations")
+	}
+
 	return nil
 }
 
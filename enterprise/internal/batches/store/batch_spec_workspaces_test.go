@@ -53,6 +53,11 @@ func testStoreBatchSpecWorkspaces(t *testing.T, ctx context.Context, s *Store, c
 			Skipped:            i == 1,
 			CachedResultFound:  i == 1,
 		}
+		if i == 2 {
+			job.PolicyViolations = []btypes.ChangesetPolicyViolation{
+				{Check: "maxChangedLines", Branch: "my-branch", Message: "changeset changes 600 lines, but at most 500 are allowed"},
+			}
+		}
 
 		workspaces = append(workspaces, job)
 	}
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/opentracing/opentracing-go/log"
@@ -167,6 +168,80 @@ func getChangesetJobQuery(opts *GetChangesetJobOpts) *sqlf.Query {
 	)
 }
 
+// ListLatestChangesetJobs returns the most recent changeset job of the given
+// type for each changeset of the given batch change.
+func (s *Store) ListLatestChangesetJobs(ctx context.Context, batchChangeID int64, jobType btypes.ChangesetJobType) (jobs []*btypes.ChangesetJob, err error) {
+	ctx, _, endObservation := s.operations.listLatestChangesetJobs.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+		log.String("jobType", string(jobType)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listLatestChangesetJobsQueryFmtstr,
+		sqlf.Join(changesetJobColumns.ToSqlf(), ", "),
+		batchChangeID,
+		jobType,
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var c btypes.ChangesetJob
+		if err := scanChangesetJob(&c, sc); err != nil {
+			return err
+		}
+		jobs = append(jobs, &c)
+		return nil
+	})
+	return jobs, err
+}
+
+var listLatestChangesetJobsQueryFmtstr = `
+SELECT DISTINCT ON (changeset_jobs.changeset_id) %s FROM changeset_jobs
+WHERE
+	changeset_jobs.batch_change_id = %s
+	AND changeset_jobs.job_type = %s
+ORDER BY changeset_jobs.changeset_id ASC, changeset_jobs.id DESC
+`
+
+// CountChangesetJobsOpts captures the query options needed for counting
+// changeset jobs.
+type CountChangesetJobsOpts struct {
+	BatchChangeID int64
+	JobType       btypes.ChangesetJobType
+	CreatedAfter  time.Time
+}
+
+// CountChangesetJobs returns the number of changeset jobs with the given
+// filters.
+func (s *Store) CountChangesetJobs(ctx context.Context, opts CountChangesetJobsOpts) (count int, err error) {
+	ctx, _, endObservation := s.operations.countChangesetJobs.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(opts.BatchChangeID)),
+		log.String("jobType", string(opts.JobType)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.BatchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.batch_change_id = %s", opts.BatchChangeID))
+	}
+	if opts.JobType != "" {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.job_type = %s", opts.JobType))
+	}
+	if !opts.CreatedAfter.IsZero() {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.created_at > %s", opts.CreatedAfter))
+	}
+
+	return s.queryCount(ctx, sqlf.Sprintf(
+		countChangesetJobsQueryFmtstr,
+		sqlf.Join(preds, "\n AND "),
+	))
+}
+
+var countChangesetJobsQueryFmtstr = `
+SELECT COUNT(*) FROM changeset_jobs
+WHERE %s
+`
+
 func scanChangesetJob(c *btypes.ChangesetJob, s dbutil.Scanner) error {
 	var raw json.RawMessage
 	if err := s.Scan(
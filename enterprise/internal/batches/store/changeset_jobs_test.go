@@ -4,6 +4,7 @@ import (
 	"context"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -110,4 +111,51 @@ func testStoreChangesetJobs(t *testing.T, ctx context.Context, s *Store, clock b
 			}
 		})
 	})
+	t.Run("ListLatest", func(t *testing.T) {
+		latest := &btypes.ChangesetJob{
+			UserID:        1234,
+			BatchChangeID: jobs[0].BatchChangeID,
+			ChangesetID:   changeset.ID,
+			JobType:       btypes.ChangesetJobTypeMerge,
+		}
+		older := *latest
+		if err := s.CreateChangesetJob(ctx, &older, latest); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.ListLatestChangesetJobs(ctx, latest.BatchChangeID, btypes.ChangesetJobTypeMerge)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]*btypes.ChangesetJob{latest}, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		count, err := s.CountChangesetJobs(ctx, CountChangesetJobsOpts{
+			BatchChangeID: jobs[0].BatchChangeID,
+			JobType:       btypes.ChangesetJobTypeMerge,
+			CreatedAfter:  clock.Now().Add(-1 * time.Hour),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The two merge jobs created in ListLatest.
+		if have, want := count, 2; have != want {
+			t.Fatalf("have count %d, want %d", have, want)
+		}
+
+		count, err = s.CountChangesetJobs(ctx, CountChangesetJobsOpts{
+			BatchChangeID: jobs[0].BatchChangeID,
+			JobType:       btypes.ChangesetJobTypeMerge,
+			CreatedAfter:  clock.Now().Add(1 * time.Hour),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := count, 0; have != want {
+			t.Fatalf("have count %d, want %d", have, want)
+		}
+	})
 }
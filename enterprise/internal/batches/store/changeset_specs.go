@@ -45,6 +45,10 @@ var changesetSpecInsertColumns = []string{
 	"commit_author_name",
 	"commit_author_email",
 	"type",
+	"labels",
+	"reviewers",
+	"assignees",
+	"milestone",
 }
 
 // changesetSpecColumns are used by the changeset spec related Store methods to
@@ -72,6 +76,10 @@ var changesetSpecColumns = SQLColumns{
 	"changeset_specs.commit_author_name",
 	"changeset_specs.commit_author_email",
 	"changeset_specs.type",
+	"changeset_specs.labels",
+	"changeset_specs.reviewers",
+	"changeset_specs.assignees",
+	"changeset_specs.milestone",
 }
 
 var oneGigabyte = 1000000000
@@ -137,6 +145,10 @@ func (s *Store) CreateChangesetSpec(ctx context.Context, cs ...*btypes.Changeset
 				dbutil.NewNullString(c.CommitAuthorName),
 				dbutil.NewNullString(c.CommitAuthorEmail),
 				c.Type,
+				pq.Array(c.Labels),
+				pq.Array(c.Reviewers),
+				pq.Array(c.Assignees),
+				dbutil.NewNullString(c.Milestone),
 			); err != nil {
 				return err
 			}
@@ -562,6 +574,10 @@ func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
 		&dbutil.NullString{S: &c.CommitAuthorName},
 		&dbutil.NullString{S: &c.CommitAuthorEmail},
 		&typ,
+		pq.Array(&c.Labels),
+		pq.Array(&c.Reviewers),
+		pq.Array(&c.Assignees),
+		&dbutil.NullString{S: &c.Milestone},
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset spec")
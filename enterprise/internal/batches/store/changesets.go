@@ -24,6 +24,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -68,6 +69,7 @@ var changesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.closing"),
 	sqlf.Sprintf("changesets.syncer_error"),
 	sqlf.Sprintf("changesets.detached_at"),
+	sqlf.Sprintf("changesets.base_state"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -105,6 +107,7 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("num_failures"),
 	sqlf.Sprintf("closing"),
 	sqlf.Sprintf("syncer_error"),
+	sqlf.Sprintf("base_state"),
 	// We additionally store the result of changeset.Title() in a column, so
 	// the business logic for determining it is in one place and the field is
 	// indexable for searching.
@@ -126,6 +129,7 @@ var changesetCodeHostStateInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("diff_stat_deleted"),
 	sqlf.Sprintf("sync_state"),
 	sqlf.Sprintf("syncer_error"),
+	sqlf.Sprintf("base_state"),
 	// We additionally store the result of changeset.Title() in a column, so
 	// the business logic for determining it is in one place and the field is
 	// indexable for searching.
@@ -187,6 +191,7 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		c.NumFailures,
 		c.Closing,
 		c.SyncErrorMessage,
+		dbutil.NullStringColumn(string(c.BaseState)),
 		dbutil.NullStringColumn(title),
 	}
 
@@ -230,7 +235,7 @@ func (s *Store) CreateChangeset(ctx context.Context, c *btypes.Changeset) (err e
 
 var createChangesetQueryFmtstr = `
 INSERT INTO changesets (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -257,6 +262,7 @@ type CountChangesetsOpts struct {
 	ExternalStates       []btypes.ChangesetExternalState
 	ExternalReviewState  *btypes.ChangesetReviewState
 	ExternalCheckState   *btypes.ChangesetCheckState
+	BaseStates           []btypes.ChangesetBaseState
 	ReconcilerStates     []btypes.ReconcilerState
 	OwnedByBatchChangeID int64
 	PublicationState     *btypes.ChangesetPublicationState
@@ -314,6 +320,9 @@ func countChangesetsQuery(opts *CountChangesetsOpts, authzConds *sqlf.Query) *sq
 	if opts.ExternalCheckState != nil {
 		preds = append(preds, sqlf.Sprintf("changesets.external_check_state = %s", *opts.ExternalCheckState))
 	}
+	if len(opts.BaseStates) > 0 {
+		preds = append(preds, sqlf.Sprintf("changesets.base_state = ANY (%s)", pq.Array(opts.BaseStates)))
+	}
 	if len(opts.ReconcilerStates) != 0 {
 		// TODO: Would be nice if we could use this with pq.Array.
 		states := make([]*sqlf.Query, len(opts.ReconcilerStates))
@@ -522,6 +531,7 @@ type ListChangesetsOpts struct {
 	ExternalStates       []btypes.ChangesetExternalState
 	ExternalReviewState  *btypes.ChangesetReviewState
 	ExternalCheckState   *btypes.ChangesetCheckState
+	BaseStates           []btypes.ChangesetBaseState
 	OwnedByBatchChangeID int64
 	TextSearch           []search.TextSearchTerm
 	EnforceAuthz         bool
@@ -609,6 +619,9 @@ func listChangesetsQuery(opts *ListChangesetsOpts, authzConds *sqlf.Query) *sqlf
 	if opts.ExternalCheckState != nil {
 		preds = append(preds, sqlf.Sprintf("changesets.external_check_state = %s", *opts.ExternalCheckState))
 	}
+	if len(opts.BaseStates) > 0 {
+		preds = append(preds, sqlf.Sprintf("changesets.base_state = ANY (%s)", pq.Array(opts.BaseStates)))
+	}
 	if opts.OwnedByBatchChangeID != 0 {
 		preds = append(preds, sqlf.Sprintf("changesets.owned_by_batch_change_id = %s", opts.OwnedByBatchChangeID))
 	}
@@ -846,6 +859,7 @@ func updateChangesetCodeHostStateQuery(c *btypes.Changeset) (*sqlf.Query, error)
 		c.DiffStatDeleted,
 		syncState,
 		c.SyncErrorMessage,
+		dbutil.NullStringColumn(string(c.BaseState)),
 		dbutil.NullStringColumn(title),
 		c.ID,
 		sqlf.Join(changesetColumns, ", "),
@@ -856,7 +870,7 @@ func updateChangesetCodeHostStateQuery(c *btypes.Changeset) (*sqlf.Query, error)
 
 var updateChangesetCodeHostStateQueryFmtstr = `
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -1104,6 +1118,7 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		externalState       string
 		externalReviewState string
 		externalCheckState  string
+		baseState           string
 		failureMessage      string
 		syncErrorMessage    string
 		reconcilerState     string
@@ -1143,6 +1158,7 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		&t.Closing,
 		&dbutil.NullString{S: &syncErrorMessage},
 		&dbutil.NullTime{Time: &t.DetachedAt},
+		&dbutil.NullString{S: &baseState},
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -1151,6 +1167,7 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 	t.ExternalState = btypes.ChangesetExternalState(externalState)
 	t.ExternalReviewState = btypes.ChangesetReviewState(externalReviewState)
 	t.ExternalCheckState = btypes.ChangesetCheckState(externalCheckState)
+	t.BaseState = btypes.ChangesetBaseState(baseState)
 	if failureMessage != "" {
 		t.FailureMessage = &failureMessage
 	}
@@ -1171,6 +1188,8 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		// Ensure the inner PR is initialized, it should never be nil.
 		m.PullRequest = &bitbucketcloud.PullRequest{}
 		t.Metadata = m
+	case extsvc.TypeGerrit:
+		t.Metadata = new(gerrit.Change)
 	default:
 		return errors.New("unknown external service type")
 	}
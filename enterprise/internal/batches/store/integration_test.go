@@ -40,6 +40,8 @@ func TestIntegration(t *testing.T) {
 		t.Run("CodeHosts", storeTest(db, nil, testStoreCodeHost))
 		t.Run("UserDeleteCascades", storeTest(db, nil, testUserDeleteCascades))
 		t.Run("ChangesetJobs", storeTest(db, nil, testStoreChangesetJobs))
+		t.Run("AutoMergePolicies", storeTest(db, nil, testStoreAutoMergePolicies))
+		t.Run("AutoRebases", storeTest(db, nil, testStoreAutoRebases))
 		t.Run("BulkOperations", storeTest(db, nil, testStoreBulkOperations))
 		t.Run("BatchSpecWorkspaces", storeTest(db, nil, testStoreBatchSpecWorkspaces))
 		t.Run("BatchSpecWorkspaceExecutionJobs", storeTest(db, nil, testStoreBatchSpecWorkspaceExecutionJobs))
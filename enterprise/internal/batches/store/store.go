@@ -212,8 +212,23 @@ type operations struct {
 	countChangesetEvents  *observation.Operation
 	upsertChangesetEvents *observation.Operation
 
-	createChangesetJob *observation.Operation
-	getChangesetJob    *observation.Operation
+	createChangesetJob      *observation.Operation
+	getChangesetJob         *observation.Operation
+	listLatestChangesetJobs *observation.Operation
+	countChangesetJobs      *observation.Operation
+
+	upsertAutoMergePolicy *observation.Operation
+	getAutoMergePolicy    *observation.Operation
+	deleteAutoMergePolicy *observation.Operation
+	listAutoMergePolicies *observation.Operation
+	createAutoMergeEvent  *observation.Operation
+	listAutoMergeEvents   *observation.Operation
+	countAutoMergeEvents  *observation.Operation
+
+	upsertAutoRebase *observation.Operation
+	getAutoRebase    *observation.Operation
+	deleteAutoRebase *observation.Operation
+	listAutoRebases  *observation.Operation
 
 	createChangesetSpec                      *observation.Operation
 	updateChangesetSpecBatchSpecID           *observation.Operation
@@ -355,8 +370,23 @@ func newOperations(observationContext *observation.Context) *operations {
 			countChangesetEvents:  op("CountChangesetEvents"),
 			upsertChangesetEvents: op("UpsertChangesetEvents"),
 
-			createChangesetJob: op("CreateChangesetJob"),
-			getChangesetJob:    op("GetChangesetJob"),
+			createChangesetJob:      op("CreateChangesetJob"),
+			getChangesetJob:         op("GetChangesetJob"),
+			listLatestChangesetJobs: op("ListLatestChangesetJobs"),
+			countChangesetJobs:      op("CountChangesetJobs"),
+
+			upsertAutoMergePolicy: op("UpsertAutoMergePolicy"),
+			getAutoMergePolicy:    op("GetAutoMergePolicy"),
+			deleteAutoMergePolicy: op("DeleteAutoMergePolicy"),
+			listAutoMergePolicies: op("ListAutoMergePolicies"),
+			createAutoMergeEvent:  op("CreateAutoMergeEvent"),
+			listAutoMergeEvents:   op("ListAutoMergeEvents"),
+			countAutoMergeEvents:  op("CountAutoMergeEvents"),
+
+			upsertAutoRebase: op("UpsertAutoRebase"),
+			getAutoRebase:    op("GetAutoRebase"),
+			deleteAutoRebase: op("DeleteAutoRebase"),
+			listAutoRebases:  op("ListAutoRebases"),
 
 			createChangesetSpec:                      op("CreateChangesetSpec"),
 			updateChangesetSpecBatchSpecID:           op("UpdateChangesetSpecBatchSpecID"),
@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
@@ -51,11 +52,21 @@ var batchSpecWorkspaceExecutionWorkerStoreOptions = dbworkerstore.Options{
 	ViewName: "batch_spec_workspace_execution_jobs_with_rank batch_spec_workspace_execution_jobs",
 }
 
+// ChangesetSpecValidator validates the changeset specs built from the result
+// of a workspace before they are created.
+type ChangesetSpecValidator interface {
+	// ValidateChangesetSpecs returns the violations of the site-wide changeset
+	// policy in the given changeset specs for the given repo. Violations are
+	// not returned as an error, so that they can be recorded on the workspace.
+	ValidateChangesetSpecs(ctx context.Context, repo *types.Repo, specs []*btypes.ChangesetSpec) ([]btypes.ChangesetPolicyViolation, error)
+}
+
 // NewBatchSpecWorkspaceExecutionWorkerStore creates a dbworker store that
 // wraps the batch_spec_workspace_execution_jobs table.
-func NewBatchSpecWorkspaceExecutionWorkerStore(handle basestore.TransactableHandle, observationContext *observation.Context) dbworkerstore.Store {
+func NewBatchSpecWorkspaceExecutionWorkerStore(handle basestore.TransactableHandle, validator ChangesetSpecValidator, observationContext *observation.Context) dbworkerstore.Store {
 	return &batchSpecWorkspaceExecutionWorkerStore{
 		Store:              dbworkerstore.NewWithMetrics(handle, batchSpecWorkspaceExecutionWorkerStoreOptions, observationContext),
+		validator:          validator,
 		observationContext: observationContext,
 		logger:             log.Scoped("batch-spec-workspace-execution-worker-store", "The worker store backing the executor queue for Batch Changes"),
 	}
@@ -70,6 +81,8 @@ var _ dbworkerstore.Store = &batchSpecWorkspaceExecutionWorkerStore{}
 type batchSpecWorkspaceExecutionWorkerStore struct {
 	dbworkerstore.Store
 
+	validator ChangesetSpecValidator
+
 	logger log.Logger
 
 	observationContext *observation.Context
@@ -124,6 +137,12 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) markFinal(ctx context.Context,
 		return false, err
 	}
 
+	// Violations found in a previous execution of a retried workspace don't
+	// apply anymore.
+	if err := s.setPolicyViolations(ctx, tx, workspace.ID, nil); err != nil {
+		return false, errors.Wrap(err, "setPolicyViolations")
+	}
+
 	return fn(ctx, s.Store.With(tx))
 }
 
@@ -239,6 +258,22 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 		specs = append(specs, changesetSpec)
 	}
 
+	violations, err := s.validator.ValidateChangesetSpecs(ctx, repo, specs)
+	if err != nil {
+		return false, errors.Wrap(err, "validating changeset specs")
+	}
+	if err := s.setPolicyViolations(ctx, tx, workspace.ID, violations); err != nil {
+		return false, errors.Wrap(err, "setPolicyViolations")
+	}
+	if len(violations) > 0 {
+		// The changeset specs are not created, so that changesets violating
+		// the policy can't be published. Failing the job fails the batch spec.
+		if err = s.setChangesetSpecIDs(ctx, tx, job.BatchSpecWorkspaceID, []int64{}); err != nil {
+			return false, errors.Wrap(err, "setChangesetSpecIDs")
+		}
+		return s.Store.With(tx).MarkFailed(ctx, id, btypes.ChangesetPolicyViolationsMessage(violations), options)
+	}
+
 	changesetSpecIDs := []int64{}
 	if len(specs) > 0 {
 		if err := tx.CreateChangesetSpec(ctx, specs...); err != nil {
@@ -293,6 +328,26 @@ SET
 WHERE id = %s
 `
 
+func (s *batchSpecWorkspaceExecutionWorkerStore) setPolicyViolations(ctx context.Context, tx *Store, batchSpecWorkspaceID int64, violations []btypes.ChangesetPolicyViolation) error {
+	if violations == nil {
+		violations = []btypes.ChangesetPolicyViolation{}
+	}
+	marshaled, err := json.Marshal(violations)
+	if err != nil {
+		return err
+	}
+
+	return tx.Exec(ctx, sqlf.Sprintf(setPolicyViolationsOnBatchSpecWorkspaceQueryFmtstr, marshaled, batchSpecWorkspaceID))
+}
+
+const setPolicyViolationsOnBatchSpecWorkspaceQueryFmtstr = `
+UPDATE
+	batch_spec_workspaces
+SET
+	policy_violations = %s
+WHERE id = %s
+`
+
 // storeCacheResults builds DB cache entries for all the results and store them using the given tx.
 func storeCacheResults(ctx context.Context, tx *Store, results []*batcheslib.CacheAfterStepResultMetadata, userID int32) error {
 	for _, result := range results {
@@ -67,8 +67,10 @@ stdout: {"operation":"CACHE_AFTER_STEP_RESULT","timestamp":"2021-11-04T12:43:19.
 		DurationMs: intptr(200),
 	}
 
+	validator := &fakeChangesetSpecValidator{}
 	executionStore := &batchSpecWorkspaceExecutionWorkerStore{
 		Store:              workStore,
+		validator:          validator,
 		observationContext: &observation.TestContext,
 		logger:             logtest.Scoped(t),
 	}
@@ -216,6 +218,44 @@ stdout: {"operation":"CACHE_AFTER_STEP_RESULT","timestamp":"2021-11-04T12:43:19.
 
 		assertNoChangesetSpecsCreated(t)
 	})
+
+	t.Run("policy violations", func(t *testing.T) {
+		job, workspace := setupEntities(t)
+		setProcessing(t, job)
+
+		violations := []btypes.ChangesetPolicyViolation{
+			{Check: "allowedPaths", Branch: "my-branch", Path: "README.md", Message: "file is outside of the allowed paths"},
+		}
+		validator.violations = violations
+		t.Cleanup(func() { validator.violations = nil })
+
+		ok, err := executionStore.MarkComplete(context.Background(), int(job.ID), opts)
+		if !ok || err != nil {
+			t.Fatalf("MarkComplete failed. ok=%t, err=%s", ok, err)
+		}
+
+		assertJobState(t, job, btypes.BatchSpecWorkspaceExecutionJobStateFailed)
+
+		assertWorkspaceChangesets(t, job, []int64{})
+
+		assertNoChangesetSpecsCreated(t)
+
+		reloadedWorkspace, err := s.GetBatchSpecWorkspace(ctx, GetBatchSpecWorkspaceOpts{ID: workspace.ID})
+		if err != nil {
+			t.Fatalf("failed to reload workspace: %s", err)
+		}
+		if diff := cmp.Diff(violations, reloadedWorkspace.PolicyViolations); diff != "" {
+			t.Fatalf("reloaded workspace has wrong policy violations: %s", diff)
+		}
+	})
+}
+
+type fakeChangesetSpecValidator struct {
+	violations []btypes.ChangesetPolicyViolation
+}
+
+func (v *fakeChangesetSpecValidator) ValidateChangesetSpecs(context.Context, *types.Repo, []*btypes.ChangesetSpec) ([]btypes.ChangesetPolicyViolation, error) {
+	return v.violations, nil
 }
 
 func TestBatchSpecWorkspaceExecutionWorkerStore_MarkFailed(t *testing.T) {
@@ -39,6 +39,11 @@ type TestSpecOpts struct {
 	BaseRev string
 	BaseRef string
 
+	Labels    []string
+	Reviewers []string
+	Assignees []string
+	Milestone string
+
 	Typ btypes.ChangesetSpecType
 }
 
@@ -75,6 +80,10 @@ func BuildChangesetSpec(t *testing.T, opts TestSpecOpts) *btypes.ChangesetSpec {
 		DiffStatAdded:     TestChangsetSpecDiffStat.Added,
 		DiffStatDeleted:   TestChangsetSpecDiffStat.Deleted,
 		Type:              opts.Typ,
+		Labels:            opts.Labels,
+		Reviewers:         opts.Reviewers,
+		Assignees:         opts.Assignees,
+		Milestone:         opts.Milestone,
 	}
 
 	return spec
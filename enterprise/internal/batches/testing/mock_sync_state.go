@@ -67,6 +67,9 @@ index 884601b..c4886d5 100644
 	gitserverClient.ResolveRevisionFunc.SetDefaultHook(func(context.Context, api.RepoName, string, gitserver.ResolveRevisionOptions) (api.CommitID, error) {
 		return "mockcommitid", nil
 	})
+	// The merge base matches the resolved base revision, so that changesets
+	// are considered up to date with their base branch.
+	gitserverClient.MergeBaseFunc.SetDefaultReturn("mockcommitid", nil)
 
 	state.MockClient = gitserverClient
 	return state
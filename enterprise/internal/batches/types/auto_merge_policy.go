@@ -0,0 +1,52 @@
+package types
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// AutoMergePolicy describes when the changesets of a batch change are merged
+// automatically by the auto-merge worker.
+type AutoMergePolicy struct {
+	BatchChangeID int64
+	// UserID is the user whose credentials are used to merge the changesets.
+	UserID int32
+
+	RequirePassingChecks bool
+	RequireApproval      bool
+	Squash               bool
+
+	// Windows are the maintenance windows during which changesets may be
+	// merged, in the same format as the batchChanges.rolloutWindows site
+	// configuration. No windows means changesets can be merged at any time.
+	// The rate of the windows is ignored in favour of MaxMergesPerHour.
+	Windows []*schema.BatchChangeRolloutWindow
+	// MaxMergesPerHour limits how many changesets are merged in a rolling
+	// hour. Zero means no limit.
+	MaxMergesPerHour int32
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// AutoMergeDecision defines the possible outcomes of evaluating an
+// AutoMergePolicy against a changeset.
+type AutoMergeDecision string
+
+// AutoMergeDecision constants.
+const (
+	AutoMergeDecisionMerge AutoMergeDecision = "MERGE"
+	AutoMergeDecisionSkip  AutoMergeDecision = "SKIP"
+)
+
+// AutoMergeEvent records why the auto-merge worker did or didn't merge a
+// changeset.
+type AutoMergeEvent struct {
+	ID            int64
+	BatchChangeID int64
+	ChangesetID   int64
+	Decision      AutoMergeDecision
+	Reason        string
+	CreatedAt     time.Time
+}
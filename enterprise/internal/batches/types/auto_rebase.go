@@ -0,0 +1,19 @@
+package types
+
+import "time"
+
+// AutoRebase describes that the changesets of a batch change are updated
+// automatically by the rebaser worker, once their base branch moved on.
+type AutoRebase struct {
+	BatchChangeID int64
+	// UserID is the user on whose behalf the batch spec is re-executed and
+	// applied.
+	UserID int32
+	// BatchSpecID is the batch spec that was last created to rebase the
+	// changesets. It is zero if no rebase happened yet, or if the batch spec
+	// was deleted.
+	BatchSpecID int64
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
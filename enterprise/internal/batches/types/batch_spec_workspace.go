@@ -39,6 +39,11 @@ type BatchSpecWorkspace struct {
 	// and used for creating the attached changeset specs.
 	CachedResultFound bool
 
+	// PolicyViolations are the violations of the site-wide changeset policy
+	// found in the changeset specs built from the execution result. No
+	// changeset specs are created for workspaces with violations.
+	PolicyViolations []ChangesetPolicyViolation
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
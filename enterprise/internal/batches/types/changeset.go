@@ -1,6 +1,7 @@
 package types
 
 import (
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
@@ -235,6 +237,34 @@ func (s ChangesetCheckState) Valid() bool {
 	}
 }
 
+// ChangesetBaseState defines the possible states of a changeset relative to
+// the current head of its base branch.
+type ChangesetBaseState string
+
+const (
+	// ChangesetBaseStateUpToDate means the changeset contains the current head
+	// of its base branch.
+	ChangesetBaseStateUpToDate ChangesetBaseState = "UP_TO_DATE"
+	// ChangesetBaseStateOutdated means the base branch has moved since the
+	// changeset was created, but not in the files the changeset changes.
+	ChangesetBaseStateOutdated ChangesetBaseState = "OUTDATED"
+	// ChangesetBaseStateConflicting means the base branch has moved since the
+	// changeset was created, and changes files that the changeset changes too.
+	ChangesetBaseStateConflicting ChangesetBaseState = "CONFLICTING"
+)
+
+// Valid returns true if the given Changeset base state is valid.
+func (s ChangesetBaseState) Valid() bool {
+	switch s {
+	case ChangesetBaseStateUpToDate,
+		ChangesetBaseStateOutdated,
+		ChangesetBaseStateConflicting:
+		return true
+	default:
+		return false
+	}
+}
+
 // BatchChangeAssoc stores the details of a association to a BatchChange.
 type BatchChangeAssoc struct {
 	BatchChangeID int64 `json:"-"`
@@ -266,6 +296,9 @@ type Changeset struct {
 	DiffStatAdded         *int32
 	DiffStatDeleted       *int32
 	SyncState             ChangesetSyncState
+	// BaseState is computed from the git history of the changeset when it is
+	// synced. It is empty if it hasn't been computed yet.
+	BaseState ChangesetBaseState
 
 	// The batch change that "owns" this changeset: it can create/close
 	// it on code host. If this is 0, it is imported/tracked by a batch change.
@@ -420,6 +453,19 @@ func (c *Changeset) SetMetadata(meta any) error {
 		} else {
 			c.ExternalForkNamespace = ""
 		}
+	case *gerrit.Change:
+		c.Metadata = pr
+		c.ExternalID = strconv.FormatInt(pr.Number, 10)
+		c.ExternalServiceType = extsvc.TypeGerrit
+		// Gerrit changes don't have a branch, so we use the topic the change
+		// was pushed with.
+		if pr.Topic != "" {
+			c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.Topic)
+		} else {
+			c.ExternalBranch = ""
+		}
+		c.ExternalUpdatedAt = pr.Updated.Time
+		c.ExternalForkNamespace = ""
 	default:
 		return errors.New("unknown changeset type")
 	}
@@ -447,6 +493,8 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Title, nil
+	case *gerrit.Change:
+		return m.Subject, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -466,6 +514,8 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.Author.Username, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Author.Username, nil
+	case *gerrit.Change:
+		return m.Owner.Username, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -493,6 +543,8 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		// Bitbucket Cloud does not provide the e-mail of the author under any
 		// circumstances.
 		return "", nil
+	case *gerrit.Change:
+		return m.Owner.Email, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -511,6 +563,8 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedAt.Time
 	case *bbcs.AnnotatedPullRequest:
 		return m.CreatedOn
+	case *gerrit.Change:
+		return m.Created.Time
 	default:
 		return time.Time{}
 	}
@@ -527,6 +581,8 @@ func (c *Changeset) Body() (string, error) {
 		return m.Description, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Rendered.Description.Raw, nil
+	case *gerrit.Change:
+		return m.Description(), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -572,6 +628,8 @@ func (c *Changeset) URL() (s string, err error) {
 		// pull request ID, but since the link _should_ be there, we'll error
 		// instead.
 		return "", errors.New("Bitbucket Cloud pull request does not have a html link")
+	case *gerrit.Change:
+		return m.URL, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -761,6 +819,8 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.DiffRefs.HeadSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Source.Commit.Hash, nil
+	case *gerrit.Change:
+		return m.CurrentRevision, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -778,6 +838,12 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.SourceBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Source.Branch.Name, nil
+	case *gerrit.Change:
+		// The ref of the current patch set, e.g. refs/changes/45/12345/2.
+		if r, ok := m.Current(); ok {
+			return r.Ref, nil
+		}
+		return "", errors.New("Gerrit change has no current revision")
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -796,6 +862,11 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.DiffRefs.BaseSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Destination.Commit.Hash, nil
+	case *gerrit.Change:
+		if r, ok := m.Current(); ok && len(r.Commit.Parents) > 0 {
+			return r.Commit.Parents[0].Commit, nil
+		}
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -813,6 +884,8 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.TargetBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Destination.Branch.Name, nil
+	case *gerrit.Change:
+		return "refs/heads/" + m.Branch, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -928,6 +1001,118 @@ func (c *Changeset) Labels() []ChangesetLabel {
 	}
 }
 
+// Assignees returns the usernames of the users the changeset is assigned to.
+// The second return value is false if the metadata of the changeset doesn't
+// hold its assignees.
+func (c *Changeset) Assignees() ([]string, bool) {
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		assignees := make([]string, len(m.Assignees.Nodes))
+		for i, a := range m.Assignees.Nodes {
+			assignees[i] = a.Login
+		}
+		return assignees, true
+	case *gitlab.MergeRequest:
+		assignees := make([]string, len(m.Assignees))
+		for i, a := range m.Assignees {
+			assignees[i] = a.Username
+		}
+		return assignees, true
+	default:
+		return nil, false
+	}
+}
+
+// Reviewers returns the reviewers of the changeset: the users and teams a
+// review is requested from, and the users that already reviewed it. Bitbucket
+// Cloud reviewers are returned by both their UUID and their account ID. The
+// second return value is false if the metadata of the changeset doesn't hold
+// its reviewers.
+func (c *Changeset) Reviewers() ([]string, bool) {
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		// Review requests are removed by GitHub when the review is submitted,
+		// so the reviewers are computed from the timeline, in which only
+		// requests removed by a user are undone.
+		reviewers := map[string]struct{}{}
+		for _, item := range m.TimelineItems {
+			switch e := item.Item.(type) {
+			case *github.ReviewRequestedEvent:
+				reviewers[githubRequestedReviewer(e.RequestedReviewer, e.RequestedTeam)] = struct{}{}
+			case *github.ReviewRequestRemovedEvent:
+				delete(reviewers, githubRequestedReviewer(e.RequestedReviewer, e.RequestedTeam))
+			case *github.PullRequestReview:
+				reviewers[e.Author.Login] = struct{}{}
+			}
+		}
+		delete(reviewers, "")
+
+		names := make([]string, 0, len(reviewers))
+		for name := range reviewers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names, true
+	case *gitlab.MergeRequest:
+		reviewers := make([]string, len(m.Reviewers))
+		for i, r := range m.Reviewers {
+			reviewers[i] = r.Username
+		}
+		return reviewers, true
+	case *bitbucketserver.PullRequest:
+		reviewers := make([]string, 0, len(m.Reviewers))
+		for _, r := range m.Reviewers {
+			if r.User != nil {
+				reviewers = append(reviewers, r.User.Name)
+			}
+		}
+		return reviewers, true
+	case *bbcs.AnnotatedPullRequest:
+		reviewers := make([]string, 0, 2*len(m.Reviewers))
+		for _, r := range m.Reviewers {
+			reviewers = append(reviewers, r.UUID)
+			if r.AccountID != "" {
+				reviewers = append(reviewers, r.AccountID)
+			}
+		}
+		return reviewers, true
+	default:
+		return nil, false
+	}
+}
+
+// githubRequestedReviewer returns the name of the user or team a review was
+// requested from, with teams named "org/team-slug" as in changeset specs.
+func githubRequestedReviewer(user github.Actor, team github.Team) string {
+	if user.Login != "" {
+		return user.Login
+	}
+	if team.Organization != nil && team.Slug != "" {
+		return team.Organization.Login + "/" + team.Slug
+	}
+	return ""
+}
+
+// Milestone returns the title of the milestone of the changeset, or an empty
+// string if it has none. The second return value is false if the metadata of
+// the changeset doesn't hold its milestone.
+func (c *Changeset) Milestone() (string, bool) {
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		if m.Milestone == nil {
+			return "", true
+		}
+		return m.Milestone.Title, true
+	case *gitlab.MergeRequest:
+		if m.Milestone == nil {
+			return "", true
+		}
+		return m.Milestone.Title, true
+	default:
+		return "", false
+	}
+}
+
 // ResetReconcilerState resets the failure message and reset count and sets the
 // changeset's ReconcilerState to the given value.
 func (c *Changeset) ResetReconcilerState(state ReconcilerState) {
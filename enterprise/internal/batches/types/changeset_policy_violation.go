@@ -0,0 +1,34 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChangesetPolicyViolation is a violation of the site-wide changeset policy,
+// found in a changeset spec before it is created.
+type ChangesetPolicyViolation struct {
+	// Check is the name of the check that found the violation.
+	Check string `json:"check"`
+	// Branch is the head ref of the changeset spec that violates the policy.
+	Branch string `json:"branch"`
+	// Path is the file the violation was found in. It's empty for violations
+	// of the changeset spec as a whole.
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+// ChangesetPolicyViolationsMessage returns a message listing the given
+// violations, to be shown to users.
+func ChangesetPolicyViolationsMessage(violations []ChangesetPolicyViolation) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "found %d violation(s) of the changeset policy:", len(violations))
+	for _, v := range violations {
+		fmt.Fprintf(&b, "\n- %s", v.Branch)
+		if v.Path != "" {
+			fmt.Fprintf(&b, ": %s", v.Path)
+		}
+		fmt.Fprintf(&b, ": %s", v.Message)
+	}
+	return b.String()
+}
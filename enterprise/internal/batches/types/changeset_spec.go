@@ -72,6 +72,10 @@ func NewChangesetSpecFromSpec(spec *batcheslib.ChangesetSpec) (*ChangesetSpec, e
 		c.CommitMessage = commitMsg
 		c.CommitAuthorName = authorName
 		c.CommitAuthorEmail = authorEmail
+		c.Labels = spec.Labels
+		c.Reviewers = spec.Reviewers
+		c.Assignees = spec.Assignees
+		c.Milestone = spec.Milestone
 	}
 
 	c.computeForkNamespace()
@@ -113,6 +117,13 @@ type ChangesetSpec struct {
 	CommitAuthorName  string
 	CommitAuthorEmail string
 
+	// Labels, Reviewers, Assignees and Milestone are the metadata that is applied
+	// to the changeset on the code host in addition to the title and body.
+	Labels    []string
+	Reviewers []string
+	Assignees []string
+	Milestone string
+
 	ForkNamespace *string
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -175,6 +176,19 @@ func TestChangeset_SetMetadata(t *testing.T) {
 				ExternalUpdatedAt:   time.Unix(10, 0),
 			},
 		},
+		"Gerrit": {
+			meta: &gerrit.Change{
+				Number:  12345,
+				Topic:   "branch",
+				Updated: gerrit.Timestamp{Time: time.Unix(10, 0)},
+			},
+			want: &Changeset{
+				ExternalID:          "12345",
+				ExternalServiceType: extsvc.TypeGerrit,
+				ExternalBranch:      "refs/heads/branch",
+				ExternalUpdatedAt:   time.Unix(10, 0),
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			have := &Changeset{}
@@ -206,6 +220,9 @@ func TestChangeset_Title(t *testing.T) {
 		"GitLab": &gitlab.MergeRequest{
 			Title: want,
 		},
+		"Gerrit": &gerrit.Change{
+			Subject: want,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: meta}
@@ -242,6 +259,9 @@ func TestChangeset_ExternalCreatedAt(t *testing.T) {
 		"GitLab": &gitlab.MergeRequest{
 			CreatedAt: gitlab.Time{Time: want},
 		},
+		"Gerrit": &gerrit.Change{
+			Created: gerrit.Timestamp{Time: want},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: meta}
@@ -279,6 +299,12 @@ func TestChangeset_Body(t *testing.T) {
 		"GitLab": &gitlab.MergeRequest{
 			Description: want,
 		},
+		"Gerrit": &gerrit.Change{
+			CurrentRevision: "abc",
+			Revisions: map[string]gerrit.RevisionInfo{
+				"abc": {Commit: gerrit.CommitInfo{Message: "title\n\n" + want + "\n\nChange-Id: I1234\n"}},
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: meta}
@@ -327,6 +353,9 @@ func TestChangeset_URL(t *testing.T) {
 		"GitLab": &gitlab.MergeRequest{
 			WebURL: want,
 		},
+		"Gerrit": &gerrit.Change{
+			URL: want,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: meta}
@@ -377,6 +406,10 @@ func TestChangeset_HeadRefOid(t *testing.T) {
 			},
 			want: "foo",
 		},
+		"Gerrit": {
+			meta: &gerrit.Change{CurrentRevision: "foo"},
+			want: "foo",
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: tc.meta}
@@ -429,6 +462,15 @@ func TestChangeset_HeadRef(t *testing.T) {
 			},
 			want: "refs/heads/foo",
 		},
+		"Gerrit": {
+			meta: &gerrit.Change{
+				CurrentRevision: "abc",
+				Revisions: map[string]gerrit.RevisionInfo{
+					"abc": {Ref: "refs/changes/45/12345/2"},
+				},
+			},
+			want: "refs/changes/45/12345/2",
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: tc.meta}
@@ -479,6 +521,15 @@ func TestChangeset_BaseRefOid(t *testing.T) {
 			},
 			want: "foo",
 		},
+		"Gerrit": {
+			meta: &gerrit.Change{
+				CurrentRevision: "abc",
+				Revisions: map[string]gerrit.RevisionInfo{
+					"abc": {Commit: gerrit.CommitInfo{Parents: []gerrit.CommitParent{{Commit: "foo"}}}},
+				},
+			},
+			want: "foo",
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: tc.meta}
@@ -531,6 +582,10 @@ func TestChangeset_BaseRef(t *testing.T) {
 			},
 			want: "refs/heads/foo",
 		},
+		"Gerrit": {
+			meta: &gerrit.Change{Branch: "foo"},
+			want: "refs/heads/foo",
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: tc.meta}
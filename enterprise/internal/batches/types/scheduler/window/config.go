@@ -82,6 +82,18 @@ func (cfg *Configuration) HasRolloutWindows() bool {
 	return len(cfg.windows) != 0
 }
 
+// IsOpen returns true if no windows have been defined, or if one of them is
+// open at the given time. The rate of the open window is not taken into
+// account.
+func (cfg *Configuration) IsOpen(at time.Time) bool {
+	if !cfg.HasRolloutWindows() {
+		return true
+	}
+
+	window, _ := cfg.windowFor(at)
+	return window != nil
+}
+
 // Schedule returns the currently active schedule.
 func (cfg *Configuration) Schedule() *Schedule {
 	// If there are no rollout windows, then we return an unlimited schedule and
@@ -197,6 +197,40 @@ func TestConfiguration_Schedule(t *testing.T) {
 	}
 }
 
+func TestConfiguration_IsOpen(t *testing.T) {
+	// 2021-04-06 is a Tuesday.
+	at := time.Date(2021, 4, 6, 12, 30, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		cfg  *Configuration
+		want bool
+	}{
+		"no rollout windows": {
+			cfg:  &Configuration{windows: []Window{}},
+			want: true,
+		},
+		"open window": {
+			cfg: &Configuration{windows: []Window{
+				{days: newWeekdaySet(time.Tuesday), start: timeOfDayPtr(12, 0), end: timeOfDayPtr(13, 0), rate: rate{n: 0}},
+			}},
+			want: true,
+		},
+		"closed window": {
+			cfg: &Configuration{windows: []Window{
+				{days: newWeekdaySet(time.Tuesday), start: timeOfDayPtr(13, 0), end: timeOfDayPtr(14, 0), rate: rate{n: -1}},
+				{days: newWeekdaySet(time.Monday), rate: rate{n: -1}},
+			}},
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := tc.cfg.IsOpen(at); have != tc.want {
+				t.Errorf("unexpected result: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestConfiguration_currentFor(t *testing.T) {
 	// Let's set up some common windows to simplify defining the test cases.
 
@@ -44,6 +44,37 @@ type StepInfo struct {
 	OutputVariables map[string]any
 	Diff            *string
 	ExitCode        *int
+	// Attempts are the attempts to run the step, in order. The fields above
+	// reflect the last attempt, except OutputLines, which holds the output of
+	// all attempts.
+	Attempts []*StepAttempt
+}
+
+// StepAttempt holds the information about a single attempt to run a step.
+type StepAttempt struct {
+	Number      int
+	OutputLines []string
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	ExitCode    *int
+	Error       string
+}
+
+// attempt returns the attempt with the given number, creating it if it doesn't
+// exist yet. Events of src-cli versions that don't retry steps have no attempt
+// number, and belong to the first attempt.
+func (si *StepInfo) attempt(number int) *StepAttempt {
+	if number == 0 {
+		number = 1
+	}
+	for _, a := range si.Attempts {
+		if a.Number == number {
+			return a
+		}
+	}
+	a := &StepAttempt{Number: number}
+	si.Attempts = append(si.Attempts, a)
+	return a
 }
 
 // ParseLogLines looks at all given log lines and determines the derived *StepInfo
@@ -89,6 +120,11 @@ func ParseLogLines(entry workerutil.ExecutionLogEntry, lines []*batcheslib.LogEv
 				setSafe(m.Step, func(si *StepInfo) {
 					si.FinishedAt = l.Timestamp
 					si.ExitCode = &m.ExitCode
+
+					a := si.attempt(m.Attempt)
+					a.FinishedAt = l.Timestamp
+					a.ExitCode = &m.ExitCode
+					a.Error = m.Error
 					if l.Status == batcheslib.LogEventStatusSuccess {
 						outputs := m.Outputs
 						if outputs == nil {
@@ -105,12 +141,21 @@ func ParseLogLines(entry workerutil.ExecutionLogEntry, lines []*batcheslib.LogEv
 						env = make(map[string]string)
 					}
 					si.Environment = env
+
+					// A retried step isn't finished anymore, even though
+					// its previous attempt is.
+					si.FinishedAt = time.Time{}
+					si.ExitCode = nil
+					si.attempt(m.Attempt).StartedAt = l.Timestamp
 				})
 			} else if l.Status == batcheslib.LogEventStatusProgress {
 				if m.Out != "" {
 					setSafe(m.Step, func(si *StepInfo) {
 						lines := strings.Split(strings.TrimSuffix(m.Out, "\n"), "\n")
 						si.OutputLines = append(si.OutputLines, lines...)
+
+						a := si.attempt(m.Attempt)
+						a.OutputLines = append(a.OutputLines, lines...)
 					})
 				}
 			}
@@ -132,6 +177,12 @@ func ParseLogLines(entry workerutil.ExecutionLogEntry, lines []*batcheslib.LogEv
 		if !si.StartedAt.IsZero() && si.FinishedAt.IsZero() && si.ExitCode == nil {
 			si.ExitCode = entry.ExitCode
 			si.FinishedAt = entry.StartTime.Add(time.Duration(*entry.DurationMs) * time.Millisecond)
+			if len(si.Attempts) > 0 {
+				if a := si.Attempts[len(si.Attempts)-1]; a.FinishedAt.IsZero() {
+					a.ExitCode = si.ExitCode
+					a.FinishedAt = si.FinishedAt
+				}
+			}
 
 			break
 		}
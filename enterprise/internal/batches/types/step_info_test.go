@@ -206,6 +206,7 @@ func TestParseLogLines(t *testing.T) {
 				1: {
 					StartedAt:   time1,
 					Environment: map[string]string{"env": "var"},
+					Attempts:    []*StepAttempt{{Number: 1, StartedAt: time2}},
 				},
 			},
 		},
@@ -257,6 +258,11 @@ func TestParseLogLines(t *testing.T) {
 					StartedAt:   time1,
 					Environment: map[string]string{},
 					OutputLines: []string{"stdout: log1", "stdout: log2", "stderr: log3", "stdout: log4"},
+					Attempts: []*StepAttempt{{
+						Number:      1,
+						StartedAt:   time2,
+						OutputLines: []string{"stdout: log1", "stdout: log2", "stderr: log3", "stdout: log4"},
+					}},
 				},
 			},
 		},
@@ -289,6 +295,12 @@ func TestParseLogLines(t *testing.T) {
 					FinishedAt:  time1.Add(500 * time.Millisecond),
 					ExitCode:    intPtr(-1),
 					Environment: map[string]string{"env": "var"},
+					Attempts: []*StepAttempt{{
+						Number:     1,
+						StartedAt:  time2,
+						FinishedAt: time1.Add(500 * time.Millisecond),
+						ExitCode:   intPtr(-1),
+					}},
 				},
 			},
 		},
@@ -326,6 +338,13 @@ func TestParseLogLines(t *testing.T) {
 					FinishedAt:  time3,
 					Environment: make(map[string]string),
 					ExitCode:    &nonZero,
+					Attempts: []*StepAttempt{{
+						Number:     1,
+						StartedAt:  time2,
+						FinishedAt: time3,
+						ExitCode:   &nonZero,
+						Error:      "very bad error",
+					}},
 				},
 			},
 		},
@@ -365,6 +384,7 @@ func TestParseLogLines(t *testing.T) {
 					OutputVariables: map[string]any{"test": 1},
 					ExitCode:        &zero,
 					Diff:            &diff,
+					Attempts:        []*StepAttempt{{Number: 1, StartedAt: time2, FinishedAt: time3, ExitCode: &zero}},
 				},
 			},
 		},
@@ -462,6 +482,13 @@ func TestParseLogLines(t *testing.T) {
 					OutputLines:     []string{"stdout: log1", "stdout: log2", "stderr: log3"},
 					ExitCode:        &zero,
 					Diff:            &diff,
+					Attempts: []*StepAttempt{{
+						Number:      1,
+						StartedAt:   time2,
+						FinishedAt:  time3,
+						ExitCode:    &zero,
+						OutputLines: []string{"stdout: log1", "stdout: log2", "stderr: log3"},
+					}},
 				},
 				2: {
 					StartedAt:   time1,
@@ -469,7 +496,71 @@ func TestParseLogLines(t *testing.T) {
 					Environment: make(map[string]string),
 					OutputLines: []string{"stdout: log1", "stdout: log2", "stderr: log3"},
 					ExitCode:    &nonZero,
-					// TODO: Where do we expose error?
+					Attempts: []*StepAttempt{{
+						Number:      1,
+						StartedAt:   time2,
+						FinishedAt:  time3,
+						ExitCode:    &nonZero,
+						Error:       "very bad error",
+						OutputLines: []string{"stdout: log1", "stdout: log2", "stderr: log3"},
+					}},
+				},
+			},
+		},
+		{
+			name: "Retried",
+			lines: []*batcheslib.LogEvent{
+				{
+					Timestamp: time1,
+					Status:    batcheslib.LogEventStatusStarted,
+					Metadata:  &batcheslib.TaskPreparingStepMetadata{Step: 1},
+				},
+				{
+					Timestamp: time1,
+					Status:    batcheslib.LogEventStatusStarted,
+					Metadata:  &batcheslib.TaskStepMetadata{Step: 1, Attempt: 1},
+				},
+				{
+					Timestamp: time1,
+					Status:    batcheslib.LogEventStatusProgress,
+					Metadata:  &batcheslib.TaskStepMetadata{Step: 1, Attempt: 1, Out: "stderr: flaky\n"},
+				},
+				{
+					Timestamp: time2,
+					Status:    batcheslib.LogEventStatusFailure,
+					Metadata:  &batcheslib.TaskStepMetadata{Step: 1, Attempt: 1, ExitCode: nonZero, Error: "step timed out"},
+				},
+				{
+					Timestamp: time2,
+					Status:    batcheslib.LogEventStatusStarted,
+					Metadata:  &batcheslib.TaskStepMetadata{Step: 1, Attempt: 2},
+				},
+				{
+					Timestamp: time2,
+					Status:    batcheslib.LogEventStatusProgress,
+					Metadata:  &batcheslib.TaskStepMetadata{Step: 1, Attempt: 2, Out: "stdout: done\n"},
+				},
+			},
+			want: map[int]*StepInfo{
+				1: {
+					StartedAt:   time1,
+					Environment: make(map[string]string),
+					OutputLines: []string{"stderr: flaky", "stdout: done"},
+					Attempts: []*StepAttempt{
+						{
+							Number:      1,
+							StartedAt:   time1,
+							FinishedAt:  time2,
+							ExitCode:    &nonZero,
+							Error:       "step timed out",
+							OutputLines: []string{"stderr: flaky"},
+						},
+						{
+							Number:      2,
+							StartedAt:   time2,
+							OutputLines: []string{"stdout: done"},
+						},
+					},
 				},
 			},
 		},
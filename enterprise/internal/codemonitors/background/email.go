@@ -32,7 +32,7 @@ func SendEmailForNewSearchResult(ctx context.Context, db database.DB, userID int
 	if MockSendEmailForNewSearchResult != nil {
 		return MockSendEmailForNewSearchResult(ctx, db, userID, data)
 	}
-	return sendEmail(ctx, db, userID, newSearchResultsEmailTemplates, data)
+	return SendEmail(ctx, db, userID, newSearchResultsEmailTemplates, data)
 }
 
 var (
@@ -121,7 +121,10 @@ func NewTestTemplateDataForNewSearchResults(monitorDescription string) *Template
 	}
 }
 
-func sendEmail(ctx context.Context, db database.DB, userID int32, template txtypes.Templates, data any) error {
+// SendEmail renders template with data and sends it to the primary email
+// address of the user. Other features that notify users the same way code
+// monitors do, like code insights alerts, share it.
+func SendEmail(ctx context.Context, db database.DB, userID int32, template txtypes.Templates, data any) error {
 	email, _, err := db.UserEmails().GetPrimaryEmail(ctx, userID)
 	if err != nil {
 		if errcode.IsNotFound(err) {
@@ -140,15 +143,15 @@ func sendEmail(ctx context.Context, db database.DB, userID int32, template txtyp
 }
 
 func getSearchURL(externalURL *url.URL, query, utmSource string) string {
-	return sourcegraphURL(externalURL, "search", query, utmSource)
+	return SourcegraphURL(externalURL, "search", query, utmSource)
 }
 
 func getCodeMonitorURL(externalURL *url.URL, monitorID int64, utmSource string) string {
-	return sourcegraphURL(externalURL, fmt.Sprintf("code-monitoring/%s", relay.MarshalID(MonitorKind, monitorID)), "", utmSource)
+	return SourcegraphURL(externalURL, fmt.Sprintf("code-monitoring/%s", relay.MarshalID(MonitorKind, monitorID)), "", utmSource)
 }
 
 func getCommitURL(externalURL *url.URL, repoName, oid, utmSource string) string {
-	return sourcegraphURL(externalURL, fmt.Sprintf("%s/-/commit/%s", repoName, oid), "", utmSource)
+	return SourcegraphURL(externalURL, fmt.Sprintf("%s/-/commit/%s", repoName, oid), "", utmSource)
 }
 
 var (
@@ -157,7 +160,9 @@ var (
 	externalURLError error
 )
 
-func getExternalURL(ctx context.Context) (*url.URL, error) {
+// GetExternalURL returns the external URL of the Sourcegraph instance. The
+// value is fetched from the frontend once and cached.
+func GetExternalURL(ctx context.Context) (*url.URL, error) {
 	if MockExternalURL != nil {
 		return MockExternalURL(), nil
 	}
@@ -173,7 +178,9 @@ func getExternalURL(ctx context.Context) (*url.URL, error) {
 	return externalURLValue, externalURLError
 }
 
-func sourcegraphURL(externalURL *url.URL, path, query, utmSource string) string {
+// SourcegraphURL returns a link to path on the instance at externalURL, tagged
+// with utmSource. If query is non-empty it is set as the q parameter.
+func SourcegraphURL(externalURL *url.URL, path, query, utmSource string) string {
 	// Construct URL to the search query.
 	u := externalURL.ResolveReference(&url.URL{Path: path})
 	q := u.Query()
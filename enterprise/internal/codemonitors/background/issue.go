@@ -0,0 +1,259 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// issueClient opens and comments on issues of a single repository on a code
+// host.
+type issueClient interface {
+	// IsOpen returns whether the issue with the given number is still open.
+	IsOpen(ctx context.Context, number int64) (bool, error)
+	// CreateIssue opens a new issue and returns its number.
+	CreateIssue(ctx context.Context, title, body string) (int64, error)
+	// CreateComment adds a comment to the issue with the given number.
+	CreateComment(ctx context.Context, number int64, body string) error
+}
+
+// newIssueClient returns an issueClient for the given repository that is
+// authenticated with the token of one of the external services syncing it.
+// Site-wide external services are preferred over user-owned ones.
+func newIssueClient(ctx context.Context, db database.DB, repo *types.Repo) (issueClient, error) {
+	switch repo.ExternalRepo.ServiceType {
+	case extsvc.TypeGitHub, extsvc.TypeGitLab:
+	default:
+		return nil, errors.Errorf("opening issues on %s repositories is not supported", extsvc.TypeToKind(repo.ExternalRepo.ServiceType))
+	}
+
+	svcs, err := db.ExternalServices().List(ctx, database.ExternalServicesListOptions{IDs: repo.ExternalServiceIDs()})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing external services")
+	}
+	sort.SliceStable(svcs, func(i, j int) bool {
+		return svcs[i].NamespaceUserID == 0 && svcs[j].NamespaceUserID != 0
+	})
+
+	for _, svc := range svcs {
+		cfg, err := svc.Configuration(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "loading configuration of external service %d", svc.ID)
+		}
+
+		switch c := cfg.(type) {
+		case *schema.GitHubConnection:
+			if c.Token == "" {
+				continue
+			}
+			return newGitHubIssueClient(svc.URN(), c, repo)
+		case *schema.GitLabConnection:
+			if c.Token == "" {
+				continue
+			}
+			return newGitLabIssueClient(svc.URN(), c, repo)
+		}
+	}
+
+	return nil, errors.Errorf("no external service with a token found for repository %q", repo.Name)
+}
+
+type githubIssueClient struct {
+	client      *github.V3Client
+	owner, name string
+}
+
+func newGitHubIssueClient(urn string, c *schema.GitHubConnection, repo *types.Repo) (*githubIssueClient, error) {
+	meta, ok := repo.Metadata.(*github.Repository)
+	if !ok {
+		return nil, errors.Errorf("unexpected metadata %T for GitHub repository %q", repo.Metadata, repo.Name)
+	}
+	owner, name, err := github.SplitRepositoryNameWithOwner(meta.NameWithOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+	apiURL, _ := github.APIRoot(extsvc.NormalizeBaseURL(baseURL))
+
+	return &githubIssueClient{
+		client: github.NewV3Client(log.Scoped("codemonitors.issues", "code monitor issue action"), urn, apiURL, &auth.OAuthBearerToken{Token: c.Token}, httpcli.ExternalDoer),
+		owner:  owner,
+		name:   name,
+	}, nil
+}
+
+func (c *githubIssueClient) IsOpen(ctx context.Context, number int64) (bool, error) {
+	issue, err := c.client.GetIssue(ctx, c.owner, c.name, number)
+	if err != nil {
+		return false, err
+	}
+	return issue.State == "open", nil
+}
+
+func (c *githubIssueClient) CreateIssue(ctx context.Context, title, body string) (int64, error) {
+	issue, err := c.client.CreateIssue(ctx, c.owner, c.name, &github.CreateIssueInput{Title: title, Body: body})
+	if err != nil {
+		return 0, err
+	}
+	return issue.Number, nil
+}
+
+func (c *githubIssueClient) CreateComment(ctx context.Context, number int64, body string) error {
+	return c.client.CreateIssueComment(ctx, c.owner, c.name, number, body)
+}
+
+type gitlabIssueClient struct {
+	client  *gitlab.Client
+	project *gitlab.Project
+}
+
+func newGitLabIssueClient(urn string, c *schema.GitLabConnection, repo *types.Repo) (*gitlabIssueClient, error) {
+	project, ok := repo.Metadata.(*gitlab.Project)
+	if !ok {
+		return nil, errors.Errorf("unexpected metadata %T for GitLab repository %q", repo.Metadata, repo.Name)
+	}
+
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	var a auth.Authenticator
+	switch c.TokenType {
+	case "oauth":
+		a = &auth.OAuthBearerToken{Token: c.Token}
+	default:
+		a = &gitlab.SudoableToken{Token: c.Token}
+	}
+
+	provider := gitlab.NewClientProvider(urn, extsvc.NormalizeBaseURL(baseURL), httpcli.ExternalDoer)
+	return &gitlabIssueClient{
+		client:  provider.GetAuthenticatorClient(a),
+		project: project,
+	}, nil
+}
+
+func (c *gitlabIssueClient) IsOpen(ctx context.Context, number int64) (bool, error) {
+	issue, err := c.client.GetIssue(ctx, c.project, gitlab.ID(number))
+	if err != nil {
+		return false, err
+	}
+	return issue.State == gitlab.IssueStateOpened, nil
+}
+
+func (c *gitlabIssueClient) CreateIssue(ctx context.Context, title, body string) (int64, error) {
+	issue, err := c.client.CreateIssue(ctx, c.project, gitlab.CreateIssueOpts{Title: title, Description: body})
+	if err != nil {
+		return 0, err
+	}
+	return int64(issue.IID), nil
+}
+
+func (c *gitlabIssueClient) CreateComment(ctx context.Context, number int64, body string) error {
+	return c.client.CreateIssueNote(ctx, c.project, &gitlab.Issue{IID: gitlab.ID(number)}, body)
+}
+
+// openOrCommentOnIssue comments on the issue previously opened for the code
+// monitor if it is still open, and opens a new issue otherwise, so that
+// repeated events of a monitor don't each open a new issue. It returns the
+// number of the issue that was commented on or opened.
+func openOrCommentOnIssue(ctx context.Context, client issueClient, issueNumber *int64, args actionArgs) (int64, error) {
+	if issueNumber != nil {
+		open, err := client.IsOpen(ctx, *issueNumber)
+		if err != nil {
+			return 0, errors.Wrap(err, "getting issue")
+		}
+		if open {
+			if err := client.CreateComment(ctx, *issueNumber, issueBody(args)); err != nil {
+				return 0, errors.Wrap(err, "commenting on issue")
+			}
+			return *issueNumber, nil
+		}
+	}
+
+	number, err := client.CreateIssue(ctx, issueTitle(args), issueBody(args))
+	if err != nil {
+		return 0, errors.Wrap(err, "creating issue")
+	}
+	return number, nil
+}
+
+func issueTitle(args actionArgs) string {
+	return fmt.Sprintf("Sourcegraph code monitor %q detected new matches", args.MonitorDescription)
+}
+
+// issueBody renders the Markdown body of an issue or comment. The leading HTML
+// comment is invisible when rendered and identifies the code monitor that
+// opened the issue.
+func issueBody(args actionArgs) string {
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	searchURL := getSearchURL(args.ExternalURL, args.Query, args.UTMSource)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<!-- sourcegraph-code-monitor: %d -->\n", args.MonitorID)
+	fmt.Fprintf(&b, "%s's Sourcegraph code monitor, **%s**, detected **%d** new matches.\n",
+		args.MonitorOwnerName,
+		args.MonitorDescription,
+		totalCount,
+	)
+
+	if args.IncludeResults {
+		for _, result := range truncatedResults {
+			resultType, lang, content := "Message", "", ""
+			if result.DiffPreview != nil {
+				resultType, lang, content = "Diff", "diff", result.DiffPreview.Content
+			} else {
+				content = result.MessagePreview.Content
+			}
+			fmt.Fprintf(&b, "\n%s match: [%s@%s](%s)\n\n",
+				resultType,
+				result.Repo.Name,
+				result.Commit.ID.Short(),
+				getCommitURL(args.ExternalURL, string(result.Repo.Name), string(result.Commit.ID), args.UTMSource),
+			)
+			b.WriteString(formatFencedCodeBlock(lang, truncateString(content, 10)))
+		}
+		if truncatedCount > 0 {
+			fmt.Fprintf(&b, "\n...and [%d more matches](%s).\n", truncatedCount, searchURL)
+		}
+	} else {
+		fmt.Fprintf(&b, "\n[View results](%s)\n", searchURL)
+	}
+
+	fmt.Fprintf(&b, "\nIf you are %s, you can [edit your code monitor](%s).\n",
+		args.MonitorOwnerName,
+		getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+	)
+	return b.String()
+}
+
+// formatFencedCodeBlock wraps s in a Markdown code fence that is longer than
+// any run of backticks in s.
+func formatFencedCodeBlock(lang, s string) string {
+	fence := "```"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	return fence + lang + "\n" + s + fence + "\n"
+}
@@ -0,0 +1,99 @@
+package background
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/hexops/autogold"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+type fakeIssueClient struct {
+	open     map[int64]bool
+	next     int64
+	comments map[int64][]string
+}
+
+func (c *fakeIssueClient) IsOpen(_ context.Context, number int64) (bool, error) {
+	return c.open[number], nil
+}
+
+func (c *fakeIssueClient) CreateIssue(_ context.Context, _, _ string) (int64, error) {
+	c.next++
+	c.open[c.next] = true
+	return c.next, nil
+}
+
+func (c *fakeIssueClient) CreateComment(_ context.Context, number int64, body string) error {
+	c.comments[number] = append(c.comments[number], body)
+	return nil
+}
+
+func TestIssueBody(t *testing.T) {
+	t.Parallel()
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	action := actionArgs{
+		MonitorDescription: "My test monitor",
+		MonitorID:          42,
+		MonitorOwnerName:   "Camden Cheek",
+		ExternalURL:        eu,
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+		IncludeResults:     false,
+	}
+
+	t.Run("golden", func(t *testing.T) {
+		autogold.Equal(t, autogold.Raw(issueBody(action)))
+	})
+
+	t.Run("golden with results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		autogold.Equal(t, autogold.Raw(issueBody(actionCopy)))
+	})
+
+	t.Run("golden with truncated results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		// quadruple the number of results
+		actionCopy.Results = append(actionCopy.Results, actionCopy.Results...)
+		actionCopy.Results = append(actionCopy.Results, actionCopy.Results...)
+		autogold.Equal(t, autogold.Raw(issueBody(actionCopy)))
+	})
+}
+
+func TestFormatFencedCodeBlock(t *testing.T) {
+	assert.Equal(t, "```diff\n+foo\n```\n", formatFencedCodeBlock("diff", "+foo\n"))
+	assert.Equal(t, "```\nfoo\n```\n", formatFencedCodeBlock("", "foo"))
+	assert.Equal(t, "````\na ``` b\n````\n", formatFencedCodeBlock("", "a ``` b"))
+}
+
+func TestOpenOrCommentOnIssue(t *testing.T) {
+	ctx := context.Background()
+	args := actionArgs{MonitorDescription: "test", MonitorID: 1, ExternalURL: externalURLMock}
+	client := &fakeIssueClient{open: map[int64]bool{}, comments: map[int64][]string{}}
+
+	// The first event opens an issue.
+	number, err := openOrCommentOnIssue(ctx, client, nil, args)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), number)
+
+	// Subsequent events comment on it while it is open.
+	number, err = openOrCommentOnIssue(ctx, client, &number, args)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), number)
+	require.Len(t, client.comments[1], 1)
+
+	// Once it is closed, a new issue is opened.
+	client.open[1] = false
+	number, err = openOrCommentOnIssue(ctx, client, &number, args)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), number)
+	require.Len(t, client.comments[1], 1)
+}
@@ -45,7 +45,7 @@ func TestSlackWebhook(t *testing.T) {
 		defer s.Close()
 
 		client := s.Client()
-		err := postSlackWebhook(context.Background(), client, s.URL, slackPayload(action))
+		err := PostSlackWebhook(context.Background(), client, s.URL, slackPayload(action))
 		require.NoError(t, err)
 	})
 
@@ -59,7 +59,7 @@ func TestSlackWebhook(t *testing.T) {
 		defer s.Close()
 
 		client := s.Client()
-		err := postSlackWebhook(context.Background(), client, s.URL, slackPayload(action))
+		err := PostSlackWebhook(context.Background(), client, s.URL, slackPayload(action))
 		require.Error(t, err)
 	})
 
@@ -0,0 +1,185 @@
+package background
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func sendTeamsNotification(ctx context.Context, url string, args actionArgs) error {
+	return postTeamsWebhook(ctx, httpcli.ExternalDoer, url, teamsPayload(args))
+}
+
+// teamsMessage is the payload accepted by Microsoft Teams incoming webhooks. It
+// wraps a single Adaptive Card.
+//
+// See https://learn.microsoft.com/en-us/microsoftteams/platform/webhooks-and-connectors/how-to/connectors-using#send-adaptive-cards-using-an-incoming-webhook
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string            `json:"contentType"`
+	Content     teamsAdaptiveCard `json:"content"`
+}
+
+type teamsAdaptiveCard struct {
+	Schema  string               `json:"$schema"`
+	Type    string               `json:"type"`
+	Version string               `json:"version"`
+	Body    []teamsElement       `json:"body"`
+	Actions []teamsOpenURLAction `json:"actions,omitempty"`
+	MSTeams teamsCardOptions     `json:"msteams"`
+}
+
+// teamsElement is either a TextBlock, which renders a subset of Markdown, or a
+// CodeBlock, which renders its snippet verbatim.
+type teamsElement struct {
+	Type        string `json:"type"`
+	Text        string `json:"text,omitempty"`
+	Wrap        bool   `json:"wrap,omitempty"`
+	CodeSnippet string `json:"codeSnippet,omitempty"`
+	Language    string `json:"language,omitempty"`
+	Separator   bool   `json:"separator,omitempty"`
+}
+
+type teamsOpenURLAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+type teamsCardOptions struct {
+	Width string `json:"width"`
+}
+
+func newTeamsText(s string) teamsElement {
+	return teamsElement{Type: "TextBlock", Text: s, Wrap: true}
+}
+
+func newTeamsCode(s string) teamsElement {
+	return teamsElement{Type: "CodeBlock", CodeSnippet: s, Language: "PlainText"}
+}
+
+func newTeamsMessage(body []teamsElement, actions ...teamsOpenURLAction) *teamsMessage {
+	return &teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: teamsAdaptiveCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.5",
+				Body:    body,
+				Actions: actions,
+				MSTeams: teamsCardOptions{Width: "Full"},
+			},
+		}},
+	}
+}
+
+func teamsPayload(args actionArgs) *teamsMessage {
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+
+	body := []teamsElement{
+		newTeamsText(fmt.Sprintf(
+			"%s's Sourcegraph Code monitor, **%s**, detected **%d** new matches.",
+			args.MonitorOwnerName,
+			args.MonitorDescription,
+			totalCount,
+		)),
+	}
+
+	searchURL := getSearchURL(args.ExternalURL, args.Query, args.UTMSource)
+	if args.IncludeResults {
+		for _, result := range truncatedResults {
+			resultType := "Message"
+			if result.DiffPreview != nil {
+				resultType = "Diff"
+			}
+			header := newTeamsText(fmt.Sprintf(
+				"%s match: [%s@%s](%s)",
+				resultType,
+				result.Repo.Name,
+				result.Commit.ID.Short(),
+				getCommitURL(args.ExternalURL, string(result.Repo.Name), string(result.Commit.ID), args.UTMSource),
+			))
+			header.Separator = true
+			body = append(body, header)
+
+			var contentRaw string
+			if result.DiffPreview != nil {
+				contentRaw = truncateString(result.DiffPreview.Content, 10)
+			} else {
+				contentRaw = truncateString(result.MessagePreview.Content, 10)
+			}
+			body = append(body, newTeamsCode(contentRaw))
+		}
+		if truncatedCount > 0 {
+			body = append(body, newTeamsText(fmt.Sprintf(
+				"...and [%d more matches](%s).",
+				truncatedCount,
+				searchURL,
+			)))
+		}
+	}
+
+	body = append(body, newTeamsText(fmt.Sprintf(
+		"If you are %s, you can [edit your code monitor](%s).",
+		args.MonitorOwnerName,
+		getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+	)))
+
+	return newTeamsMessage(body, teamsOpenURLAction{
+		Type:  "Action.OpenUrl",
+		Title: "View results",
+		URL:   searchURL,
+	})
+}
+
+func postTeamsWebhook(ctx context.Context, doer httpcli.Doer, url string, msg *teamsMessage) error {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "marshal failed")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(raw))
+	if err != nil {
+		return errors.Wrap(err, "failed new request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to post webhook")
+	}
+	defer resp.Body.Close()
+
+	// Incoming webhooks respond with 200, webhooks created through Power
+	// Automate workflows respond with 202.
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(resp.Body)
+		return StatusCodeError{
+			Code:   resp.StatusCode,
+			Status: resp.Status,
+			Body:   string(body),
+		}
+	}
+
+	return nil
+}
+
+func SendTestTeamsWebhook(ctx context.Context, doer httpcli.Doer, description, url string) error {
+	testMessage := newTeamsMessage([]teamsElement{
+		newTeamsText(fmt.Sprintf("Test message for Code Monitor '%s'", description)),
+	})
+
+	return postTeamsWebhook(ctx, doer, url, testMessage)
+}
@@ -14,10 +14,12 @@ import (
 )
 
 func sendWebhookNotification(ctx context.Context, url string, args actionArgs) error {
-	return postWebhook(ctx, httpcli.ExternalDoer, url, generateWebhookPayload(args))
+	return PostWebhook(ctx, httpcli.ExternalDoer, url, generateWebhookPayload(args))
 }
 
-func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload webhookPayload) error {
+// PostWebhook posts payload, encoded as JSON, to url. Any response other than
+// 200 OK is returned as a StatusCodeError.
+func PostWebhook(ctx context.Context, doer httpcli.Doer, url string, payload any) error {
 	raw, err := json.Marshal(payload)
 	if err != nil {
 		return errors.Wrap(err, "marshal failed")
@@ -53,7 +55,7 @@ func SendTestWebhook(ctx context.Context, doer httpcli.Doer, description string,
 		MonitorDescription: description,
 		Query:              "test query",
 	}
-	return postWebhook(ctx, httpcli.ExternalDoer, u, generateWebhookPayload(args))
+	return PostWebhook(ctx, httpcli.ExternalDoer, u, generateWebhookPayload(args))
 }
 
 type webhookPayload struct {
@@ -229,8 +229,12 @@ func (r *actionRunner) Handle(ctx context.Context, logger log.Logger, record wor
 		return r.handleWebhook(ctx, j)
 	case j.SlackWebhook != nil:
 		return r.handleSlackWebhook(ctx, j)
+	case j.TeamsWebhook != nil:
+		return r.handleTeamsWebhook(ctx, j)
+	case j.Issue != nil:
+		return r.handleIssue(ctx, j)
 	default:
-		return errors.New("job must be one of type email, webhook, or slack webhook")
+		return errors.New("job must be one of type email, webhook, slack webhook, teams webhook, or issue")
 	}
 }
 
@@ -256,7 +260,7 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *edb.ActionJob) error
 		return errors.Wrap(err, "ListRecipients")
 	}
 
-	externalURL, err := getExternalURL(ctx)
+	externalURL, err := GetExternalURL(ctx)
 	if err != nil {
 		return err
 	}
@@ -309,7 +313,7 @@ func (r *actionRunner) handleWebhook(ctx context.Context, j *edb.ActionJob) erro
 		return errors.Wrap(err, "GetWebhookAction")
 	}
 
-	externalURL, err := getExternalURL(ctx)
+	externalURL, err := GetExternalURL(ctx)
 	if err != nil {
 		return err
 	}
@@ -345,7 +349,7 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob)
 		return errors.Wrap(err, "GetSlackWebhookAction")
 	}
 
-	externalURL, err := getExternalURL(ctx)
+	externalURL, err := GetExternalURL(ctx)
 	if err != nil {
 		return err
 	}
@@ -364,6 +368,99 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob)
 	return sendSlackNotification(ctx, w.URL, args)
 }
 
+func (r *actionRunner) handleTeamsWebhook(ctx context.Context, j *edb.ActionJob) error {
+	s, err := r.CodeMonitorStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = s.Done(err) }()
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	w, err := s.GetTeamsWebhookAction(ctx, *j.TeamsWebhook)
+	if err != nil {
+		return errors.Wrap(err, "GetTeamsWebhookAction")
+	}
+
+	externalURL, err := GetExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          w.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          "code-monitor-teams-webhook",
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		IncludeResults:     w.IncludeResults,
+	}
+
+	return sendTeamsNotification(ctx, w.URL, args)
+}
+
+func (r *actionRunner) handleIssue(ctx context.Context, j *edb.ActionJob) error {
+	s, err := r.CodeMonitorStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = s.Done(err) }()
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	a, err := s.GetIssueAction(ctx, *j.Issue)
+	if err != nil {
+		return errors.Wrap(err, "GetIssueAction")
+	}
+
+	// The repository was visible to the owner of the monitor when the action
+	// was configured, and the issue is opened with the credentials of the
+	// external service anyway, so we look it up as the internal actor.
+	db := database.NewDBWith(log.Scoped("handleIssue", ""), r.CodeMonitorStore)
+	repo, err := db.Repos().Get(actor.WithInternalActor(ctx), a.RepoID)
+	if err != nil {
+		return errors.Wrap(err, "getting repository")
+	}
+
+	client, err := newIssueClient(ctx, db, repo)
+	if err != nil {
+		return err
+	}
+
+	externalURL, err := GetExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          a.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          "code-monitor-issue",
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		IncludeResults:     a.IncludeResults,
+	}
+
+	number, err := openOrCommentOnIssue(ctx, client, a.IssueNumber, args)
+	if err != nil {
+		return err
+	}
+	if a.IssueNumber == nil || *a.IssueNumber != number {
+		return s.SetIssueActionIssueNumber(ctx, a.ID, number)
+	}
+	return nil
+}
+
 type StatusCodeError struct {
 	Code   int
 	Status string
@@ -0,0 +1,273 @@
+package codemonitors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/search/zoekt"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// isContentSearch returns whether the job searches file contents rather than
+// commits. Code monitors over file content queries fire for matches that were
+// not present the last time the monitor ran, rather than for new commits.
+func isContentSearch(j job.Job) bool {
+	if job.HasDescendent[*commit.SearchJob](j) {
+		return false
+	}
+	return job.HasDescendent[*zoekt.GlobalTextSearchJob](j) ||
+		job.HasDescendent[*zoekt.RepoSubsetTextSearchJob](j) ||
+		job.HasDescendent[*searcher.TextSearchJob](j)
+}
+
+// validateContentSearch returns an error if the job contains atom jobs that do
+// not produce file content matches, e.g. a repo search job for a query without
+// a type:file filter.
+func validateContentSearch(in job.Job) (err error) {
+	job.Visit(in, func(j job.Describer) {
+		switch j.(type) {
+		case *zoekt.GlobalTextSearchJob, *zoekt.RepoSubsetTextSearchJob, *searcher.TextSearchJob:
+		case *repos.ComputeExcludedJob, *jobutil.NoopJob:
+		default:
+			if len(j.Children()) == 0 && err == nil {
+				err = errors.Errorf("found invalid atom job type %T for code monitor search; the query must contain a type:diff, type:commit or type:file filter", j)
+			}
+		}
+	})
+	return err
+}
+
+// searchContent runs a file content search and compares the keys of the
+// matches to the keys stored by the previous run of the monitor. It returns
+// one commit match per repository that contains the matches that were not
+// found by the previous run. If snapshot is true, only the keys are stored and
+// no matches are returned.
+//
+// Repositories are compared against an empty set of keys if the previous run
+// did not store any keys for them, so the first snapshot must be taken when
+// the monitor is created or its query changes.
+func searchContent(ctx context.Context, cm edb.CodeMonitorStore, clients job.RuntimeClients, planJob job.Job, monitorID int64, snapshot bool) ([]*result.CommitMatch, error) {
+	agg := streaming.NewAggregatingStream()
+	_, err := planJob.Run(ctx, clients, agg)
+	if err != nil {
+		return nil, err
+	}
+
+	fileMatches := make(map[api.RepoID][]*result.FileMatch)
+	seen := make(map[result.Key]struct{}, len(agg.Results))
+	for _, res := range agg.Results {
+		fm, ok := res.(*result.FileMatch)
+		if !ok {
+			return nil, errors.Errorf("expected search to only return file matches, but got type %T", res)
+		}
+		if _, ok := seen[fm.Key()]; ok {
+			continue
+		}
+		seen[fm.Key()] = struct{}{}
+		fileMatches[fm.Repo.ID] = append(fileMatches[fm.Repo.ID], fm)
+	}
+
+	previous, err := cm.GetContentMatchKeys(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Repos that no longer have any matches are included so that their keys
+	// are cleared, otherwise matches that are removed and later added back
+	// would not be reported.
+	repoIDs := make([]api.RepoID, 0, len(fileMatches)+len(previous))
+	for repoID := range fileMatches {
+		repoIDs = append(repoIDs, repoID)
+	}
+	for repoID := range previous {
+		if _, ok := fileMatches[repoID]; !ok {
+			repoIDs = append(repoIDs, repoID)
+		}
+	}
+	sort.Slice(repoIDs, func(i, j int) bool { return repoIDs[i] < repoIDs[j] })
+
+	var results []*result.CommitMatch
+	for _, repoID := range repoIDs {
+		status := agg.Stats.Status.Get(repoID)
+		if status&(search.RepoStatusCloning|search.RepoStatusMissing|search.RepoStatusTimedout) != 0 {
+			// The repo was not searched, so we don't know anything new about it
+			continue
+		}
+
+		previousKeys := make(map[string]struct{}, len(previous[repoID]))
+		for _, key := range previous[repoID] {
+			previousKeys[key] = struct{}{}
+		}
+		isNew := func(key string) bool {
+			_, ok := previousKeys[key]
+			return !ok
+		}
+
+		if !snapshot {
+			if match := contentCommitMatch(fileMatches[repoID], isNew); match != nil {
+				results = append(results, match)
+			}
+		}
+
+		keys := make(map[string]struct{})
+		for _, fm := range fileMatches[repoID] {
+			for _, m := range contentMatches(fm) {
+				keys[m.key] = struct{}{}
+			}
+		}
+		if agg.Stats.IsLimitHit || status&search.RepoStatusLimitHit != 0 {
+			// Not all matches were returned, so keep the previous keys to
+			// avoid reporting the matches we did not see this time as new
+			// the next time they are returned.
+			for key := range previousKeys {
+				keys[key] = struct{}{}
+			}
+		}
+
+		keyList := make([]string, 0, len(keys))
+		for key := range keys {
+			keyList = append(keyList, key)
+		}
+		if stringsEqual(keyList, previous[repoID]) {
+			continue
+		}
+		if err := cm.UpsertContentMatchKeys(ctx, monitorID, repoID, keyList); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// contentMatch is a single matched line of a file. line is nil if only the
+// path of the file matched.
+type contentMatch struct {
+	key  string
+	line *result.LineMatch
+}
+
+// contentMatches splits a file match into the matched lines of the file. Each
+// line is keyed by the path of the file and the content of the line, so that
+// lines that move within the file are not reported as new.
+func contentMatches(fm *result.FileMatch) []contentMatch {
+	var matches []contentMatch
+	for _, lm := range fm.ChunkMatches.AsLineMatches() {
+		if len(lm.OffsetAndLengths) == 0 {
+			continue
+		}
+		matches = append(matches, contentMatch{
+			key:  contentMatchKey(fm.Path, lm.Preview),
+			line: lm,
+		})
+	}
+	if len(matches) == 0 {
+		matches = append(matches, contentMatch{key: contentMatchKey(fm.Path, "")})
+	}
+	return matches
+}
+
+func contentMatchKey(path, line string) string {
+	h := sha256.New()
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.TrimSpace(line)))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// contentCommitMatch converts the new matches of the given file matches, which
+// all belong to the same repository, into a commit match whose diff preview
+// adds the matched lines. This lets code monitor actions render content
+// matches the same way as diff matches. It returns nil if none of the matches
+// are new.
+func contentCommitMatch(fms []*result.FileMatch, isNew func(key string) bool) *result.CommitMatch {
+	var (
+		cm      *result.CommitMatch
+		buf     strings.Builder
+		lineNum int
+	)
+	for _, fm := range fms {
+		var matches []contentMatch
+		for _, m := range contentMatches(fm) {
+			if isNew(m.key) {
+				matches = append(matches, m)
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+
+		if cm == nil {
+			cm = &result.CommitMatch{
+				Commit:      gitdomain.Commit{ID: fm.CommitID},
+				Repo:        fm.Repo,
+				DiffPreview: &result.MatchedString{},
+			}
+		}
+
+		diffFile := result.DiffFile{OrigName: fm.Path, NewName: fm.Path}
+		fmt.Fprintf(&buf, "%s %s\n", fm.Path, fm.Path)
+		lineNum++
+		for _, m := range matches {
+			if m.line == nil {
+				continue
+			}
+
+			hunk := result.Hunk{
+				OldStart: int(m.line.LineNumber) + 1,
+				NewStart: int(m.line.LineNumber) + 1,
+				NewCount: 1,
+				Lines:    []string{"+" + m.line.Preview},
+			}
+			diffFile.Hunks = append(diffFile.Hunks, hunk)
+			fmt.Fprintf(&buf, "@@ -%d,%d +%d,%d @@\n", hunk.OldStart, hunk.OldCount, hunk.NewStart, hunk.NewCount)
+			lineNum++
+
+			lineStart := buf.Len() + len("+")
+			for _, ol := range m.line.OffsetAndLengths {
+				start, end := int(ol[0])+1, int(ol[0]+ol[1])+1
+				cm.DiffPreview.MatchedRanges = append(cm.DiffPreview.MatchedRanges, result.Range{
+					Start: result.Location{Offset: lineStart + runeOffset(m.line.Preview, int(ol[0])), Line: lineNum, Column: start},
+					End:   result.Location{Offset: lineStart + runeOffset(m.line.Preview, int(ol[0]+ol[1])), Line: lineNum, Column: end},
+				})
+			}
+			buf.WriteString(hunk.Lines[0])
+			buf.WriteByte('\n')
+			lineNum++
+		}
+
+		cm.Diff = append(cm.Diff, diffFile)
+		cm.ModifiedFiles = append(cm.ModifiedFiles, fm.Path)
+	}
+	if cm == nil {
+		return nil
+	}
+
+	cm.DiffPreview.Content = buf.String()
+	return cm
+}
+
+// runeOffset returns the byte offset of the n-th rune of s.
+func runeOffset(s string, n int) int {
+	offset := 0
+	for i := 0; i < n && offset < len(s); i++ {
+		_, size := utf8.DecodeRuneInString(s[offset:])
+		offset += size
+	}
+	return offset
+}
@@ -0,0 +1,226 @@
+package codemonitors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestIsContentSearch(t *testing.T) {
+	t.Parallel()
+
+	planJob := func(t *testing.T, input string) job.Job {
+		plan, err := query.Pipeline(query.InitRegexp(input))
+		require.NoError(t, err)
+		inputs := &search.Inputs{
+			UserSettings:        &schema.Settings{},
+			PatternType:         query.SearchTypeLiteral,
+			Protocol:            search.Streaming,
+			Features:            &search.Features{},
+			OnSourcegraphDotCom: true,
+		}
+		j, err := jobutil.NewPlanJob(inputs, plan)
+		require.NoError(t, err)
+		return j
+	}
+
+	t.Run("commit searches", func(t *testing.T) {
+		queries := []string{
+			"type:diff a",
+			"type:commit a or b repo:c",
+		}
+
+		for _, q := range queries {
+			t.Run(q, func(t *testing.T) {
+				require.False(t, isContentSearch(planJob(t, q)))
+			})
+		}
+	})
+
+	t.Run("valid content searches", func(t *testing.T) {
+		queries := []string{
+			"type:file a",
+			"type:file a or b",
+			"type:file a repo:c file:d",
+			"type:file a repo:c context:global",
+		}
+
+		for _, q := range queries {
+			t.Run(q, func(t *testing.T) {
+				j := planJob(t, q)
+				require.True(t, isContentSearch(j))
+				require.NoError(t, validateContentSearch(j))
+			})
+		}
+	})
+
+	t.Run("invalid content searches", func(t *testing.T) {
+		queries := []string{
+			"a",
+			"a repo:c",
+		}
+
+		for _, q := range queries {
+			t.Run(q, func(t *testing.T) {
+				j := planJob(t, q)
+				require.True(t, isContentSearch(j))
+				require.Error(t, validateContentSearch(j))
+			})
+		}
+	})
+}
+
+func TestContentCommitMatch(t *testing.T) {
+	t.Parallel()
+
+	repo := types.MinimalRepo{ID: 1, Name: "test"}
+	fms := []*result.FileMatch{{
+		File: result.File{Repo: repo, CommitID: "abc", Path: "a.go"},
+		ChunkMatches: result.ChunkMatches{{
+			Content:      "\tfoo()\n\tbar(foo)",
+			ContentStart: result.Location{Line: 9},
+			Ranges: result.Ranges{{
+				Start: result.Location{Line: 9, Column: 1},
+				End:   result.Location{Line: 9, Column: 4},
+			}, {
+				Start: result.Location{Line: 10, Column: 5},
+				End:   result.Location{Line: 10, Column: 8},
+			}},
+		}},
+	}, {
+		File: result.File{Repo: repo, CommitID: "abc", Path: "ü.go"},
+		ChunkMatches: result.ChunkMatches{{
+			Content:      "// ü foo",
+			ContentStart: result.Location{Line: 0},
+			Ranges: result.Ranges{{
+				Start: result.Location{Line: 0, Column: 5},
+				End:   result.Location{Line: 0, Column: 8},
+			}},
+		}},
+	}}
+
+	t.Run("all new", func(t *testing.T) {
+		cm := contentCommitMatch(fms, func(string) bool { return true })
+		require.NotNil(t, cm)
+		require.Equal(t, repo, cm.Repo)
+		require.Equal(t, "abc", string(cm.Commit.ID))
+		require.Equal(t, []string{"a.go", "ü.go"}, cm.ModifiedFiles)
+		require.Equal(t, "a.go a.go\n"+
+			"@@ -10,0 +10,1 @@\n"+
+			"+\tfoo()\n"+
+			"@@ -11,0 +11,1 @@\n"+
+			"+\tbar(foo)\n"+
+			"ü.go ü.go\n"+
+			"@@ -1,0 +1,1 @@\n"+
+			"+// ü foo\n",
+			cm.DiffPreview.Content,
+		)
+		require.Equal(t, result.FormatDiffFiles(cm.Diff), cm.DiffPreview.Content)
+
+		// Each range should highlight the matched text in the diff preview
+		require.Len(t, cm.DiffPreview.MatchedRanges, 3)
+		for _, r := range cm.DiffPreview.MatchedRanges {
+			require.Equal(t, "foo", cm.DiffPreview.Content[r.Start.Offset:r.End.Offset])
+		}
+		require.Equal(t, 3, cm.ResultCount())
+	})
+
+	t.Run("only new lines", func(t *testing.T) {
+		oldKey := contentMatchKey("a.go", "foo()")
+		cm := contentCommitMatch(fms[:1], func(key string) bool { return key != oldKey })
+		require.NotNil(t, cm)
+		require.Equal(t, "a.go a.go\n@@ -11,0 +11,1 @@\n+\tbar(foo)\n", cm.DiffPreview.Content)
+	})
+
+	t.Run("nothing new", func(t *testing.T) {
+		require.Nil(t, contentCommitMatch(fms, func(string) bool { return false }))
+	})
+}
+
+func TestSearchContent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := types.MinimalRepo{ID: 1, Name: "test"}
+
+	stored := make(map[api.RepoID][]string)
+	cm := edb.NewMockCodeMonitorStore()
+	cm.GetContentMatchKeysFunc.SetDefaultHook(func(context.Context, int64) (map[api.RepoID][]string, error) {
+		res := make(map[api.RepoID][]string, len(stored))
+		for repoID, keys := range stored {
+			res[repoID] = append([]string(nil), keys...)
+		}
+		return res, nil
+	})
+	cm.UpsertContentMatchKeysFunc.SetDefaultHook(func(_ context.Context, _ int64, repoID api.RepoID, keys []string) error {
+		stored[repoID] = keys
+		return nil
+	})
+
+	// run searches for a file whose lines are the given lines, each of which
+	// matches the query.
+	run := func(t *testing.T, snapshot, limitHit bool, lines ...string) []*result.CommitMatch {
+		var chunks result.ChunkMatches
+		for i, line := range lines {
+			chunks = append(chunks, result.ChunkMatch{
+				Content:      line,
+				ContentStart: result.Location{Line: i},
+				Ranges: result.Ranges{{
+					Start: result.Location{Line: i, Column: 0},
+					End:   result.Location{Line: i, Column: len(line)},
+				}},
+			})
+		}
+
+		j := mockjob.NewMockJob()
+		j.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			var matches []result.Match
+			if len(chunks) > 0 {
+				matches = append(matches, &result.FileMatch{
+					File:         result.File{Repo: repo, CommitID: "abc", Path: "a.go"},
+					ChunkMatches: chunks,
+				})
+			}
+			s.Send(streaming.SearchEvent{Results: matches, Stats: streaming.Stats{IsLimitHit: limitHit}})
+			return nil, nil
+		})
+
+		results, err := searchContent(ctx, cm, job.RuntimeClients{}, j, 1, snapshot)
+		require.NoError(t, err)
+		return results
+	}
+
+	// The snapshot does not report the existing matches
+	require.Empty(t, run(t, true, false, "foo(1)"))
+	require.Empty(t, run(t, false, false, "foo(1)"))
+
+	// A new line is reported once
+	results := run(t, false, false, "foo(1)", "foo(2)")
+	require.Len(t, results, 1)
+	require.Equal(t, "a.go a.go\n@@ -2,0 +2,1 @@\n+foo(2)\n", results[0].DiffPreview.Content)
+	require.Empty(t, run(t, false, false, "foo(1)", "foo(2)"))
+
+	// Moving a line does not report it again
+	require.Empty(t, run(t, false, false, "foo(2)", "  foo(1)"))
+
+	// Matches that were not returned because of a limit are kept
+	require.Empty(t, run(t, false, true, "foo(2)"))
+	require.Empty(t, run(t, false, false, "foo(1)", "foo(2)"))
+
+	// Removed matches are reported again when they come back
+	require.Empty(t, run(t, false, false))
+	require.Len(t, run(t, false, false, "foo(1)"), 1)
+}
@@ -130,6 +130,13 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		return nil, errcode.MakeNonRetryable(err)
 	}
 
+	if isContentSearch(planJob) {
+		if err := validateContentSearch(planJob); err != nil {
+			return nil, errcode.MakeNonRetryable(err)
+		}
+		return searchContent(ctx, edb.NewEnterpriseDB(db).CodeMonitors(), clients, planJob, monitorID, false)
+	}
+
 	if featureflag.FromContext(ctx).GetBoolOr("cc-repo-aware-monitors", true) {
 		hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, doSearch commit.DoSearchFunc) error {
 			return hookWithID(ctx, db, gs, monitorID, repoID, args, doSearch)
@@ -186,7 +193,8 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 
 // Snapshot runs a dummy search that just saves the current state of the searched repos in the database.
 // On subsequent runs, this allows us to treat all new repos or sets of args as something new that should
-// be searched from the beginning.
+// be searched from the beginning. For file content queries, the current matches are saved instead so that
+// only matches that appear later are reported.
 func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) error {
 	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
 	inputs, err := searchClient.Plan(
@@ -209,6 +217,22 @@ func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query stri
 		return err
 	}
 
+	if isContentSearch(planJob) {
+		if err := validateContentSearch(planJob); err != nil {
+			return err
+		}
+
+		// Clear the keys stored for a previous query of the monitor so that
+		// the current matches form the baseline rather than being compared
+		// to matches of a different query.
+		cm := edb.NewEnterpriseDB(db).CodeMonitors()
+		if err := cm.DeleteContentMatchKeys(ctx, monitorID); err != nil {
+			return err
+		}
+		_, err = searchContent(ctx, cm, clients, planJob, monitorID, true)
+		return err
+	}
+
 	hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, _ commit.DoSearchFunc) error {
 		return snapshotHook(ctx, db, gs, args, monitorID, repoID)
 	}
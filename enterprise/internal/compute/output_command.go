@@ -100,6 +100,8 @@ func resultChunks(r result.Match, kind string, onlyPath bool) []string {
 			content = string(m.Commit.Message)
 		}
 		return []string{content}
+	case *result.OwnerMatch:
+		return []string{m.Handle}
 	default:
 		panic("unsupported result kind in compute output command")
 	}
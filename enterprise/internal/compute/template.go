@@ -300,6 +300,10 @@ func NewMetaEnvironment(r result.Match, content string) *MetaEnvironment {
 			Lang:    lang,
 			Content: content,
 		}
+	case *result.OwnerMatch:
+		return &MetaEnvironment{
+			Content: m.Handle,
+		}
 	}
 	return &MetaEnvironment{}
 }
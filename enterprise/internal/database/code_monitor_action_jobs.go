@@ -17,6 +17,8 @@ type ActionJob struct {
 	Email        *int64
 	Webhook      *int64
 	SlackWebhook *int64
+	TeamsWebhook *int64
+	Issue        *int64
 	TriggerEvent int32
 
 	// Fields demanded by any dbworker.
@@ -51,6 +53,8 @@ var ActionJobColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_action_jobs.email"),
 	sqlf.Sprintf("cm_action_jobs.webhook"),
 	sqlf.Sprintf("cm_action_jobs.slack_webhook"),
+	sqlf.Sprintf("cm_action_jobs.teams_webhook"),
+	sqlf.Sprintf("cm_action_jobs.issue"),
 	sqlf.Sprintf("cm_action_jobs.trigger_event"),
 	sqlf.Sprintf("cm_action_jobs.state"),
 	sqlf.Sprintf("cm_action_jobs.failure_message"),
@@ -82,6 +86,15 @@ type ListActionJobsOpts struct {
 	// the given slack webhook action. Refers to cm_slack_webhooks(id)
 	SlackWebhookID *int
 
+	// TeamsWebhookID, if set, will filter to only actions jobs that are
+	// executing the given Microsoft Teams webhook action. Refers to
+	// cm_teams_webhooks(id)
+	TeamsWebhookID *int
+
+	// IssueID, if set, will filter to only actions jobs that are executing the
+	// given issue action. Refers to cm_issues(id)
+	IssueID *int
+
 	// First, if defined, limits the operation to only the first n results
 	First *int
 
@@ -105,6 +118,12 @@ func (o ListActionJobsOpts) Conds() *sqlf.Query {
 	if o.SlackWebhookID != nil {
 		conds = append(conds, sqlf.Sprintf("slack_webhook = %s", *o.SlackWebhookID))
 	}
+	if o.TeamsWebhookID != nil {
+		conds = append(conds, sqlf.Sprintf("teams_webhook = %s", *o.TeamsWebhookID))
+	}
+	if o.IssueID != nil {
+		conds = append(conds, sqlf.Sprintf("issue = %s", *o.IssueID))
+	}
 	if o.After != nil {
 		conds = append(conds, sqlf.Sprintf("id > %s", *o.After))
 	}
@@ -192,14 +211,36 @@ WITH due_emails AS (
 	SELECT DISTINCT slack_webhook as id FROM cm_action_jobs
 	WHERE state = 'queued'
 		OR state = 'processing'
+), due_teams_webhooks AS (
+	SELECT id
+	FROM cm_teams_webhooks
+	WHERE monitor = %s
+		AND enabled = true
+	EXCEPT
+	SELECT DISTINCT teams_webhook as id FROM cm_action_jobs
+	WHERE state = 'queued'
+		OR state = 'processing'
+), due_issues AS (
+	SELECT id
+	FROM cm_issues
+	WHERE monitor = %s
+		AND enabled = true
+	EXCEPT
+	SELECT DISTINCT issue as id FROM cm_action_jobs
+	WHERE state = 'queued'
+		OR state = 'processing'
 )
-INSERT INTO cm_action_jobs (email, webhook, slack_webhook, trigger_event)
-SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_emails
+INSERT INTO cm_action_jobs (email, webhook, slack_webhook, teams_webhook, issue, trigger_event)
+SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_emails
+UNION
+SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_webhooks
 UNION
-SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer from due_webhooks
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_slack_webhooks
 UNION
-SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer from due_slack_webhooks
-ORDER BY 1, 2, 3
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer from due_teams_webhooks
+UNION
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer from due_issues
+ORDER BY 1, 2, 3, 4, 5
 RETURNING %s
 `
 
@@ -209,6 +250,10 @@ func (s *codeMonitorStore) EnqueueActionJobsForMonitor(ctx context.Context, moni
 		monitorID,
 		monitorID,
 		monitorID,
+		monitorID,
+		monitorID,
+		triggerJobID,
+		triggerJobID,
 		triggerJobID,
 		triggerJobID,
 		triggerJobID,
@@ -283,6 +328,8 @@ func ScanActionJob(row dbutil.Scanner) (*ActionJob, error) {
 		&aj.Email,
 		&aj.Webhook,
 		&aj.SlackWebhook,
+		&aj.TeamsWebhook,
+		&aj.Issue,
 		&aj.TriggerEvent,
 		&aj.State,
 		&aj.FailureMessage,
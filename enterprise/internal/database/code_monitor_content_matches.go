@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// UpsertContentMatchKeys stores the set of keys of the file content matches
+// found in the given repository by the latest run of a code monitor.
+func (s *codeMonitorStore) UpsertContentMatchKeys(ctx context.Context, monitorID int64, repoID api.RepoID, keys []string) error {
+	rawQuery := `
+	INSERT INTO cm_content_matches (monitor_id, repo_id, match_keys)
+	VALUES (%s, %s, %s)
+	ON CONFLICT (monitor_id, repo_id) DO UPDATE
+	SET match_keys = %s
+	`
+
+	// Appease non-null constraint on column
+	if keys == nil {
+		keys = []string{}
+	}
+	q := sqlf.Sprintf(rawQuery, monitorID, int64(repoID), pq.StringArray(keys), pq.StringArray(keys))
+	return s.Exec(ctx, q)
+}
+
+// GetContentMatchKeys returns the keys previously stored for each repository
+// searched by the code monitor.
+func (s *codeMonitorStore) GetContentMatchKeys(ctx context.Context, monitorID int64) (map[api.RepoID][]string, error) {
+	rawQuery := `
+	SELECT repo_id, match_keys
+	FROM cm_content_matches
+	WHERE monitor_id = %s
+	`
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(rawQuery, monitorID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[api.RepoID][]string)
+	for rows.Next() {
+		var (
+			repoID api.RepoID
+			keys   []string
+		)
+		if err := rows.Scan(&repoID, (*pq.StringArray)(&keys)); err != nil {
+			return nil, err
+		}
+		res[repoID] = keys
+	}
+	return res, rows.Err()
+}
+
+// DeleteContentMatchKeys deletes all keys stored for a code monitor. This is
+// used when the query of the monitor changes, since the stored keys then no
+// longer describe the matches of the query.
+func (s *codeMonitorStore) DeleteContentMatchKeys(ctx context.Context, monitorID int64) error {
+	rawQuery := `
+	DELETE FROM cm_content_matches
+	WHERE monitor_id = %s
+	`
+
+	return s.Exec(ctx, sqlf.Sprintf(rawQuery, monitorID))
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestCodeMonitorStoreContentMatchKeys(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	t.Run("insert get upsert get", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		// Insert
+		insertKeys := []string{"key1", "key2"}
+		err := cm.UpsertContentMatchKeys(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, insertKeys)
+		require.NoError(t, err)
+
+		// Get
+		keys, err := cm.GetContentMatchKeys(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, map[api.RepoID][]string{fixtures.Repo.ID: insertKeys}, keys)
+
+		// Update
+		updateKeys := []string{"key3"}
+		err = cm.UpsertContentMatchKeys(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, updateKeys)
+		require.NoError(t, err)
+
+		// Get
+		keys, err = cm.GetContentMatchKeys(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, map[api.RepoID][]string{fixtures.Repo.ID: updateKeys}, keys)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		err := cm.UpsertContentMatchKeys(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, []string{"key1"})
+		require.NoError(t, err)
+
+		err = cm.DeleteContentMatchKeys(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+
+		keys, err := cm.GetContentMatchKeys(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Empty(t, keys)
+	})
+}
@@ -0,0 +1,216 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func insertTestIssueRepos(ctx context.Context, t *testing.T, db database.DB) (api.RepoID, api.RepoID) {
+	t.Helper()
+
+	repo1 := &types.Repo{Name: "github.com/sourcegraph/issues1"}
+	repo2 := &types.Repo{Name: "github.com/sourcegraph/issues2"}
+	require.NoError(t, db.Repos().Create(ctx, repo1, repo2))
+	return repo1.ID, repo2.ID
+}
+
+func TestCodeMonitorStoreIssues(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+
+	t.Run("CreateThenGet", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		repo1, _ := insertTestIssueRepos(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, true, false, repo1)
+		require.NoError(t, err)
+		require.Nil(t, action.IssueNumber)
+
+		got, err := s.GetIssueAction(ctx, action.ID)
+		require.NoError(t, err)
+
+		require.Equal(t, action, got)
+	})
+
+	t.Run("CreateUpdateGet", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		repo1, repo2 := insertTestIssueRepos(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, true, false, repo1)
+		require.NoError(t, err)
+
+		updated, err := s.UpdateIssueAction(ctx, action.ID, false, false, repo2)
+		require.NoError(t, err)
+		require.Equal(t, false, updated.Enabled)
+		require.Equal(t, repo2, updated.RepoID)
+
+		got, err := s.GetIssueAction(ctx, action.ID)
+		require.NoError(t, err)
+		require.Equal(t, updated, got)
+	})
+
+	t.Run("SetIssueNumber", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		repo1, repo2 := insertTestIssueRepos(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, true, false, repo1)
+		require.NoError(t, err)
+
+		err = s.SetIssueActionIssueNumber(ctx, action.ID, 42)
+		require.NoError(t, err)
+
+		got, err := s.GetIssueAction(ctx, action.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.IssueNumber)
+		require.Equal(t, int64(42), *got.IssueNumber)
+
+		// Updating without changing the repo keeps the issue.
+		updated, err := s.UpdateIssueAction(ctx, action.ID, true, true, repo1)
+		require.NoError(t, err)
+		require.NotNil(t, updated.IssueNumber)
+		require.Equal(t, int64(42), *updated.IssueNumber)
+
+		// Moving the action to another repo forgets it.
+		updated, err = s.UpdateIssueAction(ctx, action.ID, true, true, repo2)
+		require.NoError(t, err)
+		require.Nil(t, updated.IssueNumber)
+	})
+
+	t.Run("ErrorOnUpdateNonexistent", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		_, repo2 := insertTestIssueRepos(ctx, t, db)
+		s := CodeMonitors(db)
+
+		_, err := s.UpdateIssueAction(ctx, 383838, false, false, repo2)
+		require.Error(t, err)
+	})
+
+	t.Run("CreateDeleteGet", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		repo1, _ := insertTestIssueRepos(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action1, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, true, false, repo1)
+		require.NoError(t, err)
+
+		action2, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, true, false, repo1)
+		require.NoError(t, err)
+
+		err = s.DeleteIssueActions(ctx, fixtures.monitor.ID, action1.ID)
+		require.NoError(t, err)
+
+		_, err = s.GetIssueAction(ctx, action1.ID)
+		require.Error(t, err)
+
+		_, err = s.GetIssueAction(ctx, action2.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("CountCreateCount", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		repo1, _ := insertTestIssueRepos(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		count, err := s.CountIssueActions(ctx, fixtures.monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+
+		_, err = s.CreateIssueAction(ctx, fixtures.monitor.ID, true, false, repo1)
+		require.NoError(t, err)
+
+		count, err = s.CountIssueActions(ctx, fixtures.monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("ListCreateList", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		repo1, repo2 := insertTestIssueRepos(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		actions, err := s.ListIssueActions(ctx, ListActionsOpts{MonitorID: &fixtures.monitor.ID})
+		require.NoError(t, err)
+		require.Len(t, actions, 0)
+
+		_, err = s.CreateIssueAction(ctx, fixtures.monitor.ID, true, false, repo1)
+		require.NoError(t, err)
+
+		_, err = s.CreateIssueAction(ctx, fixtures.monitor.ID, true, false, repo2)
+		require.NoError(t, err)
+
+		actions2, err := s.ListIssueActions(ctx, ListActionsOpts{MonitorID: &fixtures.monitor.ID})
+		require.NoError(t, err)
+		require.Len(t, actions2, 2)
+
+		first := 1
+		actions3, err := s.ListIssueActions(ctx, ListActionsOpts{MonitorID: &fixtures.monitor.ID, First: &first})
+		require.NoError(t, err)
+		require.Len(t, actions3, 1)
+	})
+
+	t.Run("Update permissions", func(t *testing.T) {
+		ctx, db, s := newTestStore(t)
+		repo1, repo2 := insertTestIssueRepos(ctx, t, db)
+		uid1 := insertTestUser(ctx, t, db, "u1", false)
+		ctx1 := actor.WithActor(ctx, actor.FromUser(uid1))
+		uid2 := insertTestUser(ctx, t, db, "u2", false)
+		ctx2 := actor.WithActor(ctx, actor.FromUser(uid2))
+		fixtures := s.insertTestMonitor(ctx1, t)
+		_ = s.insertTestMonitor(ctx2, t)
+
+		ia, err := s.CreateIssueAction(ctx1, fixtures.monitor.ID, true, true, repo1)
+		require.NoError(t, err)
+
+		// User1 can update it
+		_, err = s.UpdateIssueAction(ctx1, ia.ID, true, true, repo2)
+		require.NoError(t, err)
+
+		// User2 cannot update it
+		_, err = s.UpdateIssueAction(ctx2, ia.ID, true, true, repo1)
+		require.Error(t, err)
+
+		ia, err = s.GetIssueAction(ctx1, ia.ID)
+		require.NoError(t, err)
+		require.Equal(t, repo2, ia.RepoID)
+	})
+}
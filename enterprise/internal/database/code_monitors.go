@@ -72,6 +72,21 @@ type CodeMonitorStore interface {
 	GetSlackWebhookAction(ctx context.Context, id int64) (*SlackWebhookAction, error)
 	ListSlackWebhookActions(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)
 
+	UpdateTeamsWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url string) (*TeamsWebhookAction, error)
+	CreateTeamsWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*TeamsWebhookAction, error)
+	DeleteTeamsWebhookActions(ctx context.Context, monitorID int64, ids ...int64) error
+	CountTeamsWebhookActions(ctx context.Context, monitorID int64) (int, error)
+	GetTeamsWebhookAction(ctx context.Context, id int64) (*TeamsWebhookAction, error)
+	ListTeamsWebhookActions(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error)
+
+	UpdateIssueAction(_ context.Context, id int64, enabled, includeResults bool, repoID api.RepoID) (*IssueAction, error)
+	CreateIssueAction(ctx context.Context, monitorID int64, enabled, includeResults bool, repoID api.RepoID) (*IssueAction, error)
+	SetIssueActionIssueNumber(ctx context.Context, id, issueNumber int64) error
+	DeleteIssueActions(ctx context.Context, monitorID int64, ids ...int64) error
+	CountIssueActions(ctx context.Context, monitorID int64) (int, error)
+	GetIssueAction(ctx context.Context, id int64) (*IssueAction, error)
+	ListIssueActions(context.Context, ListActionsOpts) ([]*IssueAction, error)
+
 	CreateRecipient(ctx context.Context, emailID int64, userID, orgID *int32) (*Recipient, error)
 	DeleteRecipients(ctx context.Context, emailID int64) error
 	ListRecipients(context.Context, ListRecipientsOpts) ([]*Recipient, error)
@@ -90,6 +105,10 @@ type CodeMonitorStore interface {
 	HasAnyLastSearched(ctx context.Context, monitorID int64) (bool, error)
 	UpsertLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID, lastSearched []string) error
 	GetLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error)
+
+	UpsertContentMatchKeys(ctx context.Context, monitorID int64, repoID api.RepoID, keys []string) error
+	GetContentMatchKeys(ctx context.Context, monitorID int64) (map[api.RepoID][]string, error)
+	DeleteContentMatchKeys(ctx context.Context, monitorID int64) error
 }
 
 // codeMonitorStore exposes methods to read and write codemonitors domain models
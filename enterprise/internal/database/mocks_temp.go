@@ -34,6 +34,9 @@ type MockCodeMonitorStore struct {
 	// CountActionJobsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountActionJobs.
 	CountActionJobsFunc *CodeMonitorStoreCountActionJobsFunc
+	// CountIssueActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method CountIssueActions.
+	CountIssueActionsFunc *CodeMonitorStoreCountIssueActionsFunc
 	// CountMonitorsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountMonitors.
 	CountMonitorsFunc *CodeMonitorStoreCountMonitorsFunc
@@ -46,12 +49,18 @@ type MockCodeMonitorStore struct {
 	// CountSlackWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountSlackWebhookActions.
 	CountSlackWebhookActionsFunc *CodeMonitorStoreCountSlackWebhookActionsFunc
+	// CountTeamsWebhookActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method CountTeamsWebhookActions.
+	CountTeamsWebhookActionsFunc *CodeMonitorStoreCountTeamsWebhookActionsFunc
 	// CountWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountWebhookActions.
 	CountWebhookActionsFunc *CodeMonitorStoreCountWebhookActionsFunc
 	// CreateEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateEmailAction.
 	CreateEmailActionFunc *CodeMonitorStoreCreateEmailActionFunc
+	// CreateIssueActionFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateIssueAction.
+	CreateIssueActionFunc *CodeMonitorStoreCreateIssueActionFunc
 	// CreateMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateMonitor.
 	CreateMonitorFunc *CodeMonitorStoreCreateMonitorFunc
@@ -64,12 +73,21 @@ type MockCodeMonitorStore struct {
 	// CreateSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateSlackWebhookAction.
 	CreateSlackWebhookActionFunc *CodeMonitorStoreCreateSlackWebhookActionFunc
+	// CreateTeamsWebhookActionFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateTeamsWebhookAction.
+	CreateTeamsWebhookActionFunc *CodeMonitorStoreCreateTeamsWebhookActionFunc
 	// CreateWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateWebhookAction.
 	CreateWebhookActionFunc *CodeMonitorStoreCreateWebhookActionFunc
+	// DeleteContentMatchKeysFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteContentMatchKeys.
+	DeleteContentMatchKeysFunc *CodeMonitorStoreDeleteContentMatchKeysFunc
 	// DeleteEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteEmailActions.
 	DeleteEmailActionsFunc *CodeMonitorStoreDeleteEmailActionsFunc
+	// DeleteIssueActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteIssueActions.
+	DeleteIssueActionsFunc *CodeMonitorStoreDeleteIssueActionsFunc
 	// DeleteMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteMonitor.
 	DeleteMonitorFunc *CodeMonitorStoreDeleteMonitorFunc
@@ -83,6 +101,10 @@ type MockCodeMonitorStore struct {
 	// object controlling the behavior of the method
 	// DeleteSlackWebhookActions.
 	DeleteSlackWebhookActionsFunc *CodeMonitorStoreDeleteSlackWebhookActionsFunc
+	// DeleteTeamsWebhookActionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DeleteTeamsWebhookActions.
+	DeleteTeamsWebhookActionsFunc *CodeMonitorStoreDeleteTeamsWebhookActionsFunc
 	// DeleteWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteWebhookActions.
 	DeleteWebhookActionsFunc *CodeMonitorStoreDeleteWebhookActionsFunc
@@ -105,9 +127,15 @@ type MockCodeMonitorStore struct {
 	// GetActionJobMetadataFunc is an instance of a mock function object
 	// controlling the behavior of the method GetActionJobMetadata.
 	GetActionJobMetadataFunc *CodeMonitorStoreGetActionJobMetadataFunc
+	// GetContentMatchKeysFunc is an instance of a mock function object
+	// controlling the behavior of the method GetContentMatchKeys.
+	GetContentMatchKeysFunc *CodeMonitorStoreGetContentMatchKeysFunc
 	// GetEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetEmailAction.
 	GetEmailActionFunc *CodeMonitorStoreGetEmailActionFunc
+	// GetIssueActionFunc is an instance of a mock function object
+	// controlling the behavior of the method GetIssueAction.
+	GetIssueActionFunc *CodeMonitorStoreGetIssueActionFunc
 	// GetLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method GetLastSearched.
 	GetLastSearchedFunc *CodeMonitorStoreGetLastSearchedFunc
@@ -124,6 +152,9 @@ type MockCodeMonitorStore struct {
 	// GetSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetSlackWebhookAction.
 	GetSlackWebhookActionFunc *CodeMonitorStoreGetSlackWebhookActionFunc
+	// GetTeamsWebhookActionFunc is an instance of a mock function object
+	// controlling the behavior of the method GetTeamsWebhookAction.
+	GetTeamsWebhookActionFunc *CodeMonitorStoreGetTeamsWebhookActionFunc
 	// GetWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetWebhookAction.
 	GetWebhookActionFunc *CodeMonitorStoreGetWebhookActionFunc
@@ -139,6 +170,9 @@ type MockCodeMonitorStore struct {
 	// ListEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListEmailActions.
 	ListEmailActionsFunc *CodeMonitorStoreListEmailActionsFunc
+	// ListIssueActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListIssueActions.
+	ListIssueActionsFunc *CodeMonitorStoreListIssueActionsFunc
 	// ListMonitorsFunc is an instance of a mock function object controlling
 	// the behavior of the method ListMonitors.
 	ListMonitorsFunc *CodeMonitorStoreListMonitorsFunc
@@ -151,6 +185,9 @@ type MockCodeMonitorStore struct {
 	// ListSlackWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListSlackWebhookActions.
 	ListSlackWebhookActionsFunc *CodeMonitorStoreListSlackWebhookActionsFunc
+	// ListTeamsWebhookActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListTeamsWebhookActions.
+	ListTeamsWebhookActionsFunc *CodeMonitorStoreListTeamsWebhookActionsFunc
 	// ListWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListWebhookActions.
 	ListWebhookActionsFunc *CodeMonitorStoreListWebhookActionsFunc
@@ -161,6 +198,10 @@ type MockCodeMonitorStore struct {
 	// object controlling the behavior of the method
 	// ResetQueryTriggerTimestamps.
 	ResetQueryTriggerTimestampsFunc *CodeMonitorStoreResetQueryTriggerTimestampsFunc
+	// SetIssueActionIssueNumberFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// SetIssueActionIssueNumber.
+	SetIssueActionIssueNumberFunc *CodeMonitorStoreSetIssueActionIssueNumberFunc
 	// SetQueryTriggerNextRunFunc is an instance of a mock function object
 	// controlling the behavior of the method SetQueryTriggerNextRun.
 	SetQueryTriggerNextRunFunc *CodeMonitorStoreSetQueryTriggerNextRunFunc
@@ -170,6 +211,9 @@ type MockCodeMonitorStore struct {
 	// UpdateEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateEmailAction.
 	UpdateEmailActionFunc *CodeMonitorStoreUpdateEmailActionFunc
+	// UpdateIssueActionFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateIssueAction.
+	UpdateIssueActionFunc *CodeMonitorStoreUpdateIssueActionFunc
 	// UpdateMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateMonitor.
 	UpdateMonitorFunc *CodeMonitorStoreUpdateMonitorFunc
@@ -182,6 +226,9 @@ type MockCodeMonitorStore struct {
 	// UpdateSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateSlackWebhookAction.
 	UpdateSlackWebhookActionFunc *CodeMonitorStoreUpdateSlackWebhookActionFunc
+	// UpdateTeamsWebhookActionFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateTeamsWebhookAction.
+	UpdateTeamsWebhookActionFunc *CodeMonitorStoreUpdateTeamsWebhookActionFunc
 	// UpdateTriggerJobWithResultsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// UpdateTriggerJobWithResults.
@@ -189,6 +236,9 @@ type MockCodeMonitorStore struct {
 	// UpdateWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateWebhookAction.
 	UpdateWebhookActionFunc *CodeMonitorStoreUpdateWebhookActionFunc
+	// UpsertContentMatchKeysFunc is an instance of a mock function object
+	// controlling the behavior of the method UpsertContentMatchKeys.
+	UpsertContentMatchKeysFunc *CodeMonitorStoreUpsertContentMatchKeysFunc
 	// UpsertLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method UpsertLastSearched.
 	UpsertLastSearchedFunc *CodeMonitorStoreUpsertLastSearchedFunc
@@ -209,6 +259,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CountIssueActionsFunc: &CodeMonitorStoreCountIssueActionsFunc{
+			defaultHook: func(context.Context, int64) (r0 int, r1 error) {
+				return
+			},
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: func(context.Context, int32) (r0 int32, r1 error) {
 				return
@@ -229,6 +284,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CountTeamsWebhookActionsFunc: &CodeMonitorStoreCountTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, int64) (r0 int, r1 error) {
+				return
+			},
+		},
 		CountWebhookActionsFunc: &CodeMonitorStoreCountWebhookActionsFunc{
 			defaultHook: func(context.Context, int64) (r0 int, r1 error) {
 				return
@@ -239,6 +299,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CreateIssueActionFunc: &CodeMonitorStoreCreateIssueActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, api.RepoID) (r0 *IssueAction, r1 error) {
+				return
+			},
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: func(context.Context, MonitorArgs) (r0 *Monitor, r1 error) {
 				return
@@ -259,16 +324,31 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CreateTeamsWebhookActionFunc: &CodeMonitorStoreCreateTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *TeamsWebhookAction, r1 error) {
+				return
+			},
+		},
 		CreateWebhookActionFunc: &CodeMonitorStoreCreateWebhookActionFunc{
 			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *WebhookAction, r1 error) {
 				return
 			},
 		},
+		DeleteContentMatchKeysFunc: &CodeMonitorStoreDeleteContentMatchKeysFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
+			},
+		},
 		DeleteEmailActionsFunc: &CodeMonitorStoreDeleteEmailActionsFunc{
 			defaultHook: func(context.Context, []int64, int64) (r0 error) {
 				return
 			},
 		},
+		DeleteIssueActionsFunc: &CodeMonitorStoreDeleteIssueActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
+				return
+			},
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: func(context.Context, int64) (r0 error) {
 				return
@@ -289,6 +369,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		DeleteTeamsWebhookActionsFunc: &CodeMonitorStoreDeleteTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
+				return
+			},
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
 				return
@@ -324,11 +409,21 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetContentMatchKeysFunc: &CodeMonitorStoreGetContentMatchKeysFunc{
+			defaultHook: func(context.Context, int64) (r0 map[api.RepoID][]string, r1 error) {
+				return
+			},
+		},
 		GetEmailActionFunc: &CodeMonitorStoreGetEmailActionFunc{
 			defaultHook: func(context.Context, int64) (r0 *EmailAction, r1 error) {
 				return
 			},
 		},
+		GetIssueActionFunc: &CodeMonitorStoreGetIssueActionFunc{
+			defaultHook: func(context.Context, int64) (r0 *IssueAction, r1 error) {
+				return
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) (r0 []string, r1 error) {
 				return
@@ -354,6 +449,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetTeamsWebhookActionFunc: &CodeMonitorStoreGetTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64) (r0 *TeamsWebhookAction, r1 error) {
+				return
+			},
+		},
 		GetWebhookActionFunc: &CodeMonitorStoreGetWebhookActionFunc{
 			defaultHook: func(context.Context, int64) (r0 *WebhookAction, r1 error) {
 				return
@@ -379,6 +479,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		ListIssueActionsFunc: &CodeMonitorStoreListIssueActionsFunc{
+			defaultHook: func(context.Context, ListActionsOpts) (r0 []*IssueAction, r1 error) {
+				return
+			},
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: func(context.Context, ListMonitorsOpts) (r0 []*Monitor, r1 error) {
 				return
@@ -399,6 +504,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		ListTeamsWebhookActionsFunc: &CodeMonitorStoreListTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, ListActionsOpts) (r0 []*TeamsWebhookAction, r1 error) {
+				return
+			},
+		},
 		ListWebhookActionsFunc: &CodeMonitorStoreListWebhookActionsFunc{
 			defaultHook: func(context.Context, ListActionsOpts) (r0 []*WebhookAction, r1 error) {
 				return
@@ -414,6 +524,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		SetIssueActionIssueNumberFunc: &CodeMonitorStoreSetIssueActionIssueNumberFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
 		SetQueryTriggerNextRunFunc: &CodeMonitorStoreSetQueryTriggerNextRunFunc{
 			defaultHook: func(context.Context, int64, time.Time, time.Time) (r0 error) {
 				return
@@ -429,6 +544,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpdateIssueActionFunc: &CodeMonitorStoreUpdateIssueActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, api.RepoID) (r0 *IssueAction, r1 error) {
+				return
+			},
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: func(context.Context, int64, MonitorArgs) (r0 *Monitor, r1 error) {
 				return
@@ -449,6 +569,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpdateTeamsWebhookActionFunc: &CodeMonitorStoreUpdateTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *TeamsWebhookAction, r1 error) {
+				return
+			},
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) (r0 error) {
 				return
@@ -459,6 +584,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpsertContentMatchKeysFunc: &CodeMonitorStoreUpsertContentMatchKeysFunc{
+			defaultHook: func(context.Context, int64, api.RepoID, []string) (r0 error) {
+				return
+			},
+		},
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID, []string) (r0 error) {
 				return
@@ -481,6 +611,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CountActionJobs")
 			},
 		},
+		CountIssueActionsFunc: &CodeMonitorStoreCountIssueActionsFunc{
+			defaultHook: func(context.Context, int64) (int, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CountIssueActions")
+			},
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: func(context.Context, int32) (int32, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CountMonitors")
@@ -501,6 +636,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CountSlackWebhookActions")
 			},
 		},
+		CountTeamsWebhookActionsFunc: &CodeMonitorStoreCountTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, int64) (int, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CountTeamsWebhookActions")
+			},
+		},
 		CountWebhookActionsFunc: &CodeMonitorStoreCountWebhookActionsFunc{
 			defaultHook: func(context.Context, int64) (int, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CountWebhookActions")
@@ -511,6 +651,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateEmailAction")
 			},
 		},
+		CreateIssueActionFunc: &CodeMonitorStoreCreateIssueActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CreateIssueAction")
+			},
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: func(context.Context, MonitorArgs) (*Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateMonitor")
@@ -531,16 +676,31 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateSlackWebhookAction")
 			},
 		},
+		CreateTeamsWebhookActionFunc: &CodeMonitorStoreCreateTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CreateTeamsWebhookAction")
+			},
+		},
 		CreateWebhookActionFunc: &CodeMonitorStoreCreateWebhookActionFunc{
 			defaultHook: func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateWebhookAction")
 			},
 		},
+		DeleteContentMatchKeysFunc: &CodeMonitorStoreDeleteContentMatchKeysFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteContentMatchKeys")
+			},
+		},
 		DeleteEmailActionsFunc: &CodeMonitorStoreDeleteEmailActionsFunc{
 			defaultHook: func(context.Context, []int64, int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteEmailActions")
 			},
 		},
+		DeleteIssueActionsFunc: &CodeMonitorStoreDeleteIssueActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteIssueActions")
+			},
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: func(context.Context, int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteMonitor")
@@ -561,6 +721,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteSlackWebhookActions")
 			},
 		},
+		DeleteTeamsWebhookActionsFunc: &CodeMonitorStoreDeleteTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteTeamsWebhookActions")
+			},
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: func(context.Context, int64, ...int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteWebhookActions")
@@ -596,11 +761,21 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetActionJobMetadata")
 			},
 		},
+		GetContentMatchKeysFunc: &CodeMonitorStoreGetContentMatchKeysFunc{
+			defaultHook: func(context.Context, int64) (map[api.RepoID][]string, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetContentMatchKeys")
+			},
+		},
 		GetEmailActionFunc: &CodeMonitorStoreGetEmailActionFunc{
 			defaultHook: func(context.Context, int64) (*EmailAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetEmailAction")
 			},
 		},
+		GetIssueActionFunc: &CodeMonitorStoreGetIssueActionFunc{
+			defaultHook: func(context.Context, int64) (*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetIssueAction")
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) ([]string, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetLastSearched")
@@ -626,6 +801,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetSlackWebhookAction")
 			},
 		},
+		GetTeamsWebhookActionFunc: &CodeMonitorStoreGetTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64) (*TeamsWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetTeamsWebhookAction")
+			},
+		},
 		GetWebhookActionFunc: &CodeMonitorStoreGetWebhookActionFunc{
 			defaultHook: func(context.Context, int64) (*WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetWebhookAction")
@@ -651,6 +831,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ListEmailActions")
 			},
 		},
+		ListIssueActionsFunc: &CodeMonitorStoreListIssueActionsFunc{
+			defaultHook: func(context.Context, ListActionsOpts) ([]*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListIssueActions")
+			},
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.ListMonitors")
@@ -671,6 +856,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ListSlackWebhookActions")
 			},
 		},
+		ListTeamsWebhookActionsFunc: &CodeMonitorStoreListTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListTeamsWebhookActions")
+			},
+		},
 		ListWebhookActionsFunc: &CodeMonitorStoreListWebhookActionsFunc{
 			defaultHook: func(context.Context, ListActionsOpts) ([]*WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.ListWebhookActions")
@@ -686,6 +876,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ResetQueryTriggerTimestamps")
 			},
 		},
+		SetIssueActionIssueNumberFunc: &CodeMonitorStoreSetIssueActionIssueNumberFunc{
+			defaultHook: func(context.Context, int64, int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.SetIssueActionIssueNumber")
+			},
+		},
 		SetQueryTriggerNextRunFunc: &CodeMonitorStoreSetQueryTriggerNextRunFunc{
 			defaultHook: func(context.Context, int64, time.Time, time.Time) error {
 				panic("unexpected invocation of MockCodeMonitorStore.SetQueryTriggerNextRun")
@@ -701,6 +896,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateEmailAction")
 			},
 		},
+		UpdateIssueActionFunc: &CodeMonitorStoreUpdateIssueActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateIssueAction")
+			},
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: func(context.Context, int64, MonitorArgs) (*Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateMonitor")
@@ -721,6 +921,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateSlackWebhookAction")
 			},
 		},
+		UpdateTeamsWebhookActionFunc: &CodeMonitorStoreUpdateTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateTeamsWebhookAction")
+			},
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithResults")
@@ -731,6 +936,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateWebhookAction")
 			},
 		},
+		UpsertContentMatchKeysFunc: &CodeMonitorStoreUpsertContentMatchKeysFunc{
+			defaultHook: func(context.Context, int64, api.RepoID, []string) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpsertContentMatchKeys")
+			},
+		},
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID, []string) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastSearched")
@@ -750,6 +960,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		CountActionJobsFunc: &CodeMonitorStoreCountActionJobsFunc{
 			defaultHook: i.CountActionJobs,
 		},
+		CountIssueActionsFunc: &CodeMonitorStoreCountIssueActionsFunc{
+			defaultHook: i.CountIssueActions,
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: i.CountMonitors,
 		},
@@ -762,12 +975,18 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		CountSlackWebhookActionsFunc: &CodeMonitorStoreCountSlackWebhookActionsFunc{
 			defaultHook: i.CountSlackWebhookActions,
 		},
+		CountTeamsWebhookActionsFunc: &CodeMonitorStoreCountTeamsWebhookActionsFunc{
+			defaultHook: i.CountTeamsWebhookActions,
+		},
 		CountWebhookActionsFunc: &CodeMonitorStoreCountWebhookActionsFunc{
 			defaultHook: i.CountWebhookActions,
 		},
 		CreateEmailActionFunc: &CodeMonitorStoreCreateEmailActionFunc{
 			defaultHook: i.CreateEmailAction,
 		},
+		CreateIssueActionFunc: &CodeMonitorStoreCreateIssueActionFunc{
+			defaultHook: i.CreateIssueAction,
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: i.CreateMonitor,
 		},
@@ -780,12 +999,21 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		CreateSlackWebhookActionFunc: &CodeMonitorStoreCreateSlackWebhookActionFunc{
 			defaultHook: i.CreateSlackWebhookAction,
 		},
+		CreateTeamsWebhookActionFunc: &CodeMonitorStoreCreateTeamsWebhookActionFunc{
+			defaultHook: i.CreateTeamsWebhookAction,
+		},
 		CreateWebhookActionFunc: &CodeMonitorStoreCreateWebhookActionFunc{
 			defaultHook: i.CreateWebhookAction,
 		},
+		DeleteContentMatchKeysFunc: &CodeMonitorStoreDeleteContentMatchKeysFunc{
+			defaultHook: i.DeleteContentMatchKeys,
+		},
 		DeleteEmailActionsFunc: &CodeMonitorStoreDeleteEmailActionsFunc{
 			defaultHook: i.DeleteEmailActions,
 		},
+		DeleteIssueActionsFunc: &CodeMonitorStoreDeleteIssueActionsFunc{
+			defaultHook: i.DeleteIssueActions,
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: i.DeleteMonitor,
 		},
@@ -798,6 +1026,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		DeleteSlackWebhookActionsFunc: &CodeMonitorStoreDeleteSlackWebhookActionsFunc{
 			defaultHook: i.DeleteSlackWebhookActions,
 		},
+		DeleteTeamsWebhookActionsFunc: &CodeMonitorStoreDeleteTeamsWebhookActionsFunc{
+			defaultHook: i.DeleteTeamsWebhookActions,
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: i.DeleteWebhookActions,
 		},
@@ -819,9 +1050,15 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		GetActionJobMetadataFunc: &CodeMonitorStoreGetActionJobMetadataFunc{
 			defaultHook: i.GetActionJobMetadata,
 		},
+		GetContentMatchKeysFunc: &CodeMonitorStoreGetContentMatchKeysFunc{
+			defaultHook: i.GetContentMatchKeys,
+		},
 		GetEmailActionFunc: &CodeMonitorStoreGetEmailActionFunc{
 			defaultHook: i.GetEmailAction,
 		},
+		GetIssueActionFunc: &CodeMonitorStoreGetIssueActionFunc{
+			defaultHook: i.GetIssueAction,
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: i.GetLastSearched,
 		},
@@ -837,6 +1074,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		GetSlackWebhookActionFunc: &CodeMonitorStoreGetSlackWebhookActionFunc{
 			defaultHook: i.GetSlackWebhookAction,
 		},
+		GetTeamsWebhookActionFunc: &CodeMonitorStoreGetTeamsWebhookActionFunc{
+			defaultHook: i.GetTeamsWebhookAction,
+		},
 		GetWebhookActionFunc: &CodeMonitorStoreGetWebhookActionFunc{
 			defaultHook: i.GetWebhookAction,
 		},
@@ -852,6 +1092,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		ListEmailActionsFunc: &CodeMonitorStoreListEmailActionsFunc{
 			defaultHook: i.ListEmailActions,
 		},
+		ListIssueActionsFunc: &CodeMonitorStoreListIssueActionsFunc{
+			defaultHook: i.ListIssueActions,
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: i.ListMonitors,
 		},
@@ -864,6 +1107,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		ListSlackWebhookActionsFunc: &CodeMonitorStoreListSlackWebhookActionsFunc{
 			defaultHook: i.ListSlackWebhookActions,
 		},
+		ListTeamsWebhookActionsFunc: &CodeMonitorStoreListTeamsWebhookActionsFunc{
+			defaultHook: i.ListTeamsWebhookActions,
+		},
 		ListWebhookActionsFunc: &CodeMonitorStoreListWebhookActionsFunc{
 			defaultHook: i.ListWebhookActions,
 		},
@@ -873,6 +1119,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		ResetQueryTriggerTimestampsFunc: &CodeMonitorStoreResetQueryTriggerTimestampsFunc{
 			defaultHook: i.ResetQueryTriggerTimestamps,
 		},
+		SetIssueActionIssueNumberFunc: &CodeMonitorStoreSetIssueActionIssueNumberFunc{
+			defaultHook: i.SetIssueActionIssueNumber,
+		},
 		SetQueryTriggerNextRunFunc: &CodeMonitorStoreSetQueryTriggerNextRunFunc{
 			defaultHook: i.SetQueryTriggerNextRun,
 		},
@@ -882,6 +1131,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		UpdateEmailActionFunc: &CodeMonitorStoreUpdateEmailActionFunc{
 			defaultHook: i.UpdateEmailAction,
 		},
+		UpdateIssueActionFunc: &CodeMonitorStoreUpdateIssueActionFunc{
+			defaultHook: i.UpdateIssueAction,
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: i.UpdateMonitor,
 		},
@@ -894,12 +1146,18 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		UpdateSlackWebhookActionFunc: &CodeMonitorStoreUpdateSlackWebhookActionFunc{
 			defaultHook: i.UpdateSlackWebhookAction,
 		},
+		UpdateTeamsWebhookActionFunc: &CodeMonitorStoreUpdateTeamsWebhookActionFunc{
+			defaultHook: i.UpdateTeamsWebhookAction,
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: i.UpdateTriggerJobWithResults,
 		},
 		UpdateWebhookActionFunc: &CodeMonitorStoreUpdateWebhookActionFunc{
 			defaultHook: i.UpdateWebhookAction,
 		},
+		UpsertContentMatchKeysFunc: &CodeMonitorStoreUpsertContentMatchKeysFunc{
+			defaultHook: i.UpsertContentMatchKeys,
+		},
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: i.UpsertLastSearched,
 		},
@@ -1116,6 +1374,117 @@ func (c CodeMonitorStoreCountActionJobsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreCountIssueActionsFunc describes the behavior when the
+// CountIssueActions method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreCountIssueActionsFunc struct {
+	defaultHook func(context.Context, int64) (int, error)
+	hooks       []func(context.Context, int64) (int, error)
+	history     []CodeMonitorStoreCountIssueActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// CountIssueActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CountIssueActions(v0 context.Context, v1 int64) (int, error) {
+	r0, r1 := m.CountIssueActionsFunc.nextHook()(v0, v1)
+	m.CountIssueActionsFunc.appendCall(CodeMonitorStoreCountIssueActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CountIssueActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreCountIssueActionsFunc) SetDefaultHook(hook func(context.Context, int64) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountIssueActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreCountIssueActionsFunc) PushHook(hook func(context.Context, int64) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCountIssueActionsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCountIssueActionsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCountIssueActionsFunc) nextHook() func(context.Context, int64) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCountIssueActionsFunc) appendCall(r0 CodeMonitorStoreCountIssueActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreCountIssueActionsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreCountIssueActionsFunc) History() []CodeMonitorStoreCountIssueActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCountIssueActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCountIssueActionsFuncCall is an object that describes an
+// invocation of method CountIssueActions on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreCountIssueActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCountIssueActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCountIssueActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreCountMonitorsFunc describes the behavior when the
 // CountMonitors method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -1561,37 +1930,37 @@ func (c CodeMonitorStoreCountSlackWebhookActionsFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreCountWebhookActionsFunc describes the behavior when the
-// CountWebhookActions method of the parent MockCodeMonitorStore instance is
-// invoked.
-type CodeMonitorStoreCountWebhookActionsFunc struct {
+// CodeMonitorStoreCountTeamsWebhookActionsFunc describes the behavior when
+// the CountTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreCountTeamsWebhookActionsFunc struct {
 	defaultHook func(context.Context, int64) (int, error)
 	hooks       []func(context.Context, int64) (int, error)
-	history     []CodeMonitorStoreCountWebhookActionsFuncCall
+	history     []CodeMonitorStoreCountTeamsWebhookActionsFuncCall
 	mutex       sync.Mutex
 }
 
-// CountWebhookActions delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) CountWebhookActions(v0 context.Context, v1 int64) (int, error) {
-	r0, r1 := m.CountWebhookActionsFunc.nextHook()(v0, v1)
-	m.CountWebhookActionsFunc.appendCall(CodeMonitorStoreCountWebhookActionsFuncCall{v0, v1, r0, r1})
+// CountTeamsWebhookActions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CountTeamsWebhookActions(v0 context.Context, v1 int64) (int, error) {
+	r0, r1 := m.CountTeamsWebhookActionsFunc.nextHook()(v0, v1)
+	m.CountTeamsWebhookActionsFunc.appendCall(CodeMonitorStoreCountTeamsWebhookActionsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the CountWebhookActions
-// method of the parent MockCodeMonitorStore instance is invoked and the
-// hook queue is empty.
-func (f *CodeMonitorStoreCountWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64) (int, error)) {
+// SetDefaultHook sets function that is called when the
+// CountTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64) (int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CountWebhookActions method of the parent MockCodeMonitorStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *CodeMonitorStoreCountWebhookActionsFunc) PushHook(hook func(context.Context, int64) (int, error)) {
+// CountTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) PushHook(hook func(context.Context, int64) (int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1599,20 +1968,20 @@ func (f *CodeMonitorStoreCountWebhookActionsFunc) PushHook(hook func(context.Con
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreCountWebhookActionsFunc) SetDefaultReturn(r0 int, r1 error) {
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) SetDefaultReturn(r0 int, r1 error) {
 	f.SetDefaultHook(func(context.Context, int64) (int, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreCountWebhookActionsFunc) PushReturn(r0 int, r1 error) {
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) PushReturn(r0 int, r1 error) {
 	f.PushHook(func(context.Context, int64) (int, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreCountWebhookActionsFunc) nextHook() func(context.Context, int64) (int, error) {
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) nextHook() func(context.Context, int64) (int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1625,27 +1994,28 @@ func (f *CodeMonitorStoreCountWebhookActionsFunc) nextHook() func(context.Contex
 	return hook
 }
 
-func (f *CodeMonitorStoreCountWebhookActionsFunc) appendCall(r0 CodeMonitorStoreCountWebhookActionsFuncCall) {
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) appendCall(r0 CodeMonitorStoreCountTeamsWebhookActionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreCountWebhookActionsFuncCall
-// objects describing the invocations of this function.
-func (f *CodeMonitorStoreCountWebhookActionsFunc) History() []CodeMonitorStoreCountWebhookActionsFuncCall {
+// History returns a sequence of
+// CodeMonitorStoreCountTeamsWebhookActionsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) History() []CodeMonitorStoreCountTeamsWebhookActionsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreCountWebhookActionsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreCountTeamsWebhookActionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreCountWebhookActionsFuncCall is an object that describes
-// an invocation of method CountWebhookActions on an instance of
-// MockCodeMonitorStore.
-type CodeMonitorStoreCountWebhookActionsFuncCall struct {
+// CodeMonitorStoreCountTeamsWebhookActionsFuncCall is an object that
+// describes an invocation of method CountTeamsWebhookActions on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreCountTeamsWebhookActionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -1662,30 +2032,141 @@ type CodeMonitorStoreCountWebhookActionsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreCountWebhookActionsFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreCountTeamsWebhookActionsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreCountWebhookActionsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreCountTeamsWebhookActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreCreateEmailActionFunc describes the behavior when the
-// CreateEmailAction method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreCountWebhookActionsFunc describes the behavior when the
+// CountWebhookActions method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreCreateEmailActionFunc struct {
-	defaultHook func(context.Context, int64, *EmailActionArgs) (*EmailAction, error)
-	hooks       []func(context.Context, int64, *EmailActionArgs) (*EmailAction, error)
-	history     []CodeMonitorStoreCreateEmailActionFuncCall
+type CodeMonitorStoreCountWebhookActionsFunc struct {
+	defaultHook func(context.Context, int64) (int, error)
+	hooks       []func(context.Context, int64) (int, error)
+	history     []CodeMonitorStoreCountWebhookActionsFuncCall
 	mutex       sync.Mutex
 }
 
-// CreateEmailAction delegates to the next hook function in the queue and
+// CountWebhookActions delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) CreateEmailAction(v0 context.Context, v1 int64, v2 *EmailActionArgs) (*EmailAction, error) {
-	r0, r1 := m.CreateEmailActionFunc.nextHook()(v0, v1, v2)
+func (m *MockCodeMonitorStore) CountWebhookActions(v0 context.Context, v1 int64) (int, error) {
+	r0, r1 := m.CountWebhookActionsFunc.nextHook()(v0, v1)
+	m.CountWebhookActionsFunc.appendCall(CodeMonitorStoreCountWebhookActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CountWebhookActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreCountWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountWebhookActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreCountWebhookActionsFunc) PushHook(hook func(context.Context, int64) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCountWebhookActionsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCountWebhookActionsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCountWebhookActionsFunc) nextHook() func(context.Context, int64) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCountWebhookActionsFunc) appendCall(r0 CodeMonitorStoreCountWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreCountWebhookActionsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreCountWebhookActionsFunc) History() []CodeMonitorStoreCountWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCountWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCountWebhookActionsFuncCall is an object that describes
+// an invocation of method CountWebhookActions on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreCountWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCountWebhookActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCountWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreCreateEmailActionFunc describes the behavior when the
+// CreateEmailAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreCreateEmailActionFunc struct {
+	defaultHook func(context.Context, int64, *EmailActionArgs) (*EmailAction, error)
+	hooks       []func(context.Context, int64, *EmailActionArgs) (*EmailAction, error)
+	history     []CodeMonitorStoreCreateEmailActionFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateEmailAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateEmailAction(v0 context.Context, v1 int64, v2 *EmailActionArgs) (*EmailAction, error) {
+	r0, r1 := m.CreateEmailActionFunc.nextHook()(v0, v1, v2)
 	m.CreateEmailActionFunc.appendCall(CodeMonitorStoreCreateEmailActionFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
@@ -1786,6 +2267,126 @@ func (c CodeMonitorStoreCreateEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreCreateIssueActionFunc describes the behavior when the
+// CreateIssueAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreCreateIssueActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)
+	hooks       []func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)
+	history     []CodeMonitorStoreCreateIssueActionFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateIssueAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateIssueAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 api.RepoID) (*IssueAction, error) {
+	r0, r1 := m.CreateIssueActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.CreateIssueActionFunc.appendCall(CodeMonitorStoreCreateIssueActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateIssueAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreCreateIssueActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateIssueAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreCreateIssueActionFunc) PushHook(hook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCreateIssueActionFunc) SetDefaultReturn(r0 *IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCreateIssueActionFunc) PushReturn(r0 *IssueAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCreateIssueActionFunc) nextHook() func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCreateIssueActionFunc) appendCall(r0 CodeMonitorStoreCreateIssueActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreCreateIssueActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreCreateIssueActionFunc) History() []CodeMonitorStoreCreateIssueActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCreateIssueActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCreateIssueActionFuncCall is an object that describes an
+// invocation of method CreateIssueAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreCreateIssueActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *IssueAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCreateIssueActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCreateIssueActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreCreateMonitorFunc describes the behavior when the
 // CreateMonitor method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -2248,37 +2849,37 @@ func (c CodeMonitorStoreCreateSlackWebhookActionFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreCreateWebhookActionFunc describes the behavior when the
-// CreateWebhookAction method of the parent MockCodeMonitorStore instance is
-// invoked.
-type CodeMonitorStoreCreateWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
-	history     []CodeMonitorStoreCreateWebhookActionFuncCall
+// CodeMonitorStoreCreateTeamsWebhookActionFunc describes the behavior when
+// the CreateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreCreateTeamsWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error)
+	history     []CodeMonitorStoreCreateTeamsWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
-// CreateWebhookAction delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) CreateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*WebhookAction, error) {
-	r0, r1 := m.CreateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.CreateWebhookActionFunc.appendCall(CodeMonitorStoreCreateWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+// CreateTeamsWebhookAction delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateTeamsWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*TeamsWebhookAction, error) {
+	r0, r1 := m.CreateTeamsWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.CreateTeamsWebhookActionFunc.appendCall(CodeMonitorStoreCreateTeamsWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the CreateWebhookAction
-// method of the parent MockCodeMonitorStore instance is invoked and the
-// hook queue is empty.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)) {
+// SetDefaultHook sets function that is called when the
+// CreateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CreateWebhookAction method of the parent MockCodeMonitorStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)) {
+// CreateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2286,20 +2887,20 @@ func (f *CodeMonitorStoreCreateWebhookActionFunc) PushHook(hook func(context.Con
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) SetDefaultReturn(r0 *WebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) SetDefaultReturn(r0 *TeamsWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) PushReturn(r0 *WebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) PushReturn(r0 *TeamsWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreCreateWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2312,27 +2913,28 @@ func (f *CodeMonitorStoreCreateWebhookActionFunc) nextHook() func(context.Contex
 	return hook
 }
 
-func (f *CodeMonitorStoreCreateWebhookActionFunc) appendCall(r0 CodeMonitorStoreCreateWebhookActionFuncCall) {
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) appendCall(r0 CodeMonitorStoreCreateTeamsWebhookActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreCreateWebhookActionFuncCall
-// objects describing the invocations of this function.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) History() []CodeMonitorStoreCreateWebhookActionFuncCall {
+// History returns a sequence of
+// CodeMonitorStoreCreateTeamsWebhookActionFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) History() []CodeMonitorStoreCreateTeamsWebhookActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreCreateWebhookActionFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreCreateTeamsWebhookActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreCreateWebhookActionFuncCall is an object that describes
-// an invocation of method CreateWebhookAction on an instance of
-// MockCodeMonitorStore.
-type CodeMonitorStoreCreateWebhookActionFuncCall struct {
+// CodeMonitorStoreCreateTeamsWebhookActionFuncCall is an object that
+// describes an invocation of method CreateTeamsWebhookAction on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreCreateTeamsWebhookActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -2350,7 +2952,7 @@ type CodeMonitorStoreCreateWebhookActionFuncCall struct {
 	Arg4 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *WebhookAction
+	Result0 *TeamsWebhookAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -2358,20 +2960,249 @@ type CodeMonitorStoreCreateWebhookActionFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreCreateWebhookActionFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreCreateTeamsWebhookActionFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreCreateWebhookActionFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreCreateTeamsWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreDeleteEmailActionsFunc describes the behavior when the
-// DeleteEmailActions method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreCreateWebhookActionFunc describes the behavior when the
+// CreateWebhookAction method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreDeleteEmailActionsFunc struct {
+type CodeMonitorStoreCreateWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
+	history     []CodeMonitorStoreCreateWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateWebhookAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*WebhookAction, error) {
+	r0, r1 := m.CreateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.CreateWebhookActionFunc.appendCall(CodeMonitorStoreCreateWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateWebhookAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreCreateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateWebhookAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreCreateWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCreateWebhookActionFunc) SetDefaultReturn(r0 *WebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCreateWebhookActionFunc) PushReturn(r0 *WebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCreateWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCreateWebhookActionFunc) appendCall(r0 CodeMonitorStoreCreateWebhookActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreCreateWebhookActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreCreateWebhookActionFunc) History() []CodeMonitorStoreCreateWebhookActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCreateWebhookActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCreateWebhookActionFuncCall is an object that describes
+// an invocation of method CreateWebhookAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreCreateWebhookActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *WebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCreateWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCreateWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreDeleteContentMatchKeysFunc describes the behavior when
+// the DeleteContentMatchKeys method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreDeleteContentMatchKeysFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []CodeMonitorStoreDeleteContentMatchKeysFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteContentMatchKeys delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteContentMatchKeys(v0 context.Context, v1 int64) error {
+	r0 := m.DeleteContentMatchKeysFunc.nextHook()(v0, v1)
+	m.DeleteContentMatchKeysFunc.appendCall(CodeMonitorStoreDeleteContentMatchKeysFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteContentMatchKeys method of the parent MockCodeMonitorStore instance
+// is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreDeleteContentMatchKeysFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteContentMatchKeys method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreDeleteContentMatchKeysFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteContentMatchKeysFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteContentMatchKeysFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteContentMatchKeysFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteContentMatchKeysFunc) appendCall(r0 CodeMonitorStoreDeleteContentMatchKeysFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreDeleteContentMatchKeysFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreDeleteContentMatchKeysFunc) History() []CodeMonitorStoreDeleteContentMatchKeysFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteContentMatchKeysFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteContentMatchKeysFuncCall is an object that
+// describes an invocation of method DeleteContentMatchKeys on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreDeleteContentMatchKeysFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreDeleteContentMatchKeysFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteContentMatchKeysFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreDeleteEmailActionsFunc describes the behavior when the
+// DeleteEmailActions method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreDeleteEmailActionsFunc struct {
 	defaultHook func(context.Context, []int64, int64) error
 	hooks       []func(context.Context, []int64, int64) error
 	history     []CodeMonitorStoreDeleteEmailActionsFuncCall
@@ -2479,6 +3310,124 @@ func (c CodeMonitorStoreDeleteEmailActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreDeleteIssueActionsFunc describes the behavior when the
+// DeleteIssueActions method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreDeleteIssueActionsFunc struct {
+	defaultHook func(context.Context, int64, ...int64) error
+	hooks       []func(context.Context, int64, ...int64) error
+	history     []CodeMonitorStoreDeleteIssueActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteIssueActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteIssueActions(v0 context.Context, v1 int64, v2 ...int64) error {
+	r0 := m.DeleteIssueActionsFunc.nextHook()(v0, v1, v2...)
+	m.DeleteIssueActionsFunc.appendCall(CodeMonitorStoreDeleteIssueActionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteIssueActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteIssueActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) nextHook() func(context.Context, int64, ...int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) appendCall(r0 CodeMonitorStoreDeleteIssueActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreDeleteIssueActionsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) History() []CodeMonitorStoreDeleteIssueActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteIssueActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteIssueActionsFuncCall is an object that describes an
+// invocation of method DeleteIssueActions on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreDeleteIssueActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg2 []int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c CodeMonitorStoreDeleteIssueActionsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg2 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteIssueActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreDeleteMonitorFunc describes the behavior when the
 // DeleteMonitor method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -2746,7 +3695,115 @@ func (f *CodeMonitorStoreDeleteRecipientsFunc) PushReturn(r0 error) {
 	})
 }
 
-func (f *CodeMonitorStoreDeleteRecipientsFunc) nextHook() func(context.Context, int64) error {
+func (f *CodeMonitorStoreDeleteRecipientsFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteRecipientsFunc) appendCall(r0 CodeMonitorStoreDeleteRecipientsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreDeleteRecipientsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreDeleteRecipientsFunc) History() []CodeMonitorStoreDeleteRecipientsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteRecipientsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteRecipientsFuncCall is an object that describes an
+// invocation of method DeleteRecipients on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreDeleteRecipientsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreDeleteRecipientsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteRecipientsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreDeleteSlackWebhookActionsFunc describes the behavior when
+// the DeleteSlackWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreDeleteSlackWebhookActionsFunc struct {
+	defaultHook func(context.Context, int64, ...int64) error
+	hooks       []func(context.Context, int64, ...int64) error
+	history     []CodeMonitorStoreDeleteSlackWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteSlackWebhookActions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteSlackWebhookActions(v0 context.Context, v1 int64, v2 ...int64) error {
+	r0 := m.DeleteSlackWebhookActionsFunc.nextHook()(v0, v1, v2...)
+	m.DeleteSlackWebhookActionsFunc.appendCall(CodeMonitorStoreDeleteSlackWebhookActionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteSlackWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteSlackWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) nextHook() func(context.Context, int64, ...int64) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2759,81 +3816,92 @@ func (f *CodeMonitorStoreDeleteRecipientsFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *CodeMonitorStoreDeleteRecipientsFunc) appendCall(r0 CodeMonitorStoreDeleteRecipientsFuncCall) {
+func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) appendCall(r0 CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreDeleteRecipientsFuncCall
-// objects describing the invocations of this function.
-func (f *CodeMonitorStoreDeleteRecipientsFunc) History() []CodeMonitorStoreDeleteRecipientsFuncCall {
+// History returns a sequence of
+// CodeMonitorStoreDeleteSlackWebhookActionsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) History() []CodeMonitorStoreDeleteSlackWebhookActionsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreDeleteRecipientsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreDeleteSlackWebhookActionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreDeleteRecipientsFuncCall is an object that describes an
-// invocation of method DeleteRecipients on an instance of
-// MockCodeMonitorStore.
-type CodeMonitorStoreDeleteRecipientsFuncCall struct {
+// CodeMonitorStoreDeleteSlackWebhookActionsFuncCall is an object that
+// describes an invocation of method DeleteSlackWebhookActions on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreDeleteSlackWebhookActionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int64
+	// Arg2 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg2 []int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation.
-func (c CodeMonitorStoreDeleteRecipientsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg2 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1}, trailing...)
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreDeleteRecipientsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// CodeMonitorStoreDeleteSlackWebhookActionsFunc describes the behavior when
-// the DeleteSlackWebhookActions method of the parent MockCodeMonitorStore
+// CodeMonitorStoreDeleteTeamsWebhookActionsFunc describes the behavior when
+// the DeleteTeamsWebhookActions method of the parent MockCodeMonitorStore
 // instance is invoked.
-type CodeMonitorStoreDeleteSlackWebhookActionsFunc struct {
+type CodeMonitorStoreDeleteTeamsWebhookActionsFunc struct {
 	defaultHook func(context.Context, int64, ...int64) error
 	hooks       []func(context.Context, int64, ...int64) error
-	history     []CodeMonitorStoreDeleteSlackWebhookActionsFuncCall
+	history     []CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall
 	mutex       sync.Mutex
 }
 
-// DeleteSlackWebhookActions delegates to the next hook function in the
+// DeleteTeamsWebhookActions delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) DeleteSlackWebhookActions(v0 context.Context, v1 int64, v2 ...int64) error {
-	r0 := m.DeleteSlackWebhookActionsFunc.nextHook()(v0, v1, v2...)
-	m.DeleteSlackWebhookActionsFunc.appendCall(CodeMonitorStoreDeleteSlackWebhookActionsFuncCall{v0, v1, v2, r0})
+func (m *MockCodeMonitorStore) DeleteTeamsWebhookActions(v0 context.Context, v1 int64, v2 ...int64) error {
+	r0 := m.DeleteTeamsWebhookActionsFunc.nextHook()(v0, v1, v2...)
+	m.DeleteTeamsWebhookActionsFunc.appendCall(CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall{v0, v1, v2, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the
-// DeleteSlackWebhookActions method of the parent MockCodeMonitorStore
+// DeleteTeamsWebhookActions method of the parent MockCodeMonitorStore
 // instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// DeleteSlackWebhookActions method of the parent MockCodeMonitorStore
+// DeleteTeamsWebhookActions method of the parent MockCodeMonitorStore
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2841,20 +3909,20 @@ func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) PushHook(hook func(conte
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) SetDefaultReturn(r0 error) {
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) SetDefaultReturn(r0 error) {
 	f.SetDefaultHook(func(context.Context, int64, ...int64) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) PushReturn(r0 error) {
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) PushReturn(r0 error) {
 	f.PushHook(func(context.Context, int64, ...int64) error {
 		return r0
 	})
 }
 
-func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) nextHook() func(context.Context, int64, ...int64) error {
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) nextHook() func(context.Context, int64, ...int64) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2867,28 +3935,28 @@ func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) nextHook() func(context.
 	return hook
 }
 
-func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) appendCall(r0 CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) {
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) appendCall(r0 CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// CodeMonitorStoreDeleteSlackWebhookActionsFuncCall objects describing the
+// CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall objects describing the
 // invocations of this function.
-func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) History() []CodeMonitorStoreDeleteSlackWebhookActionsFuncCall {
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) History() []CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreDeleteSlackWebhookActionsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreDeleteSlackWebhookActionsFuncCall is an object that
-// describes an invocation of method DeleteSlackWebhookActions on an
+// CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall is an object that
+// describes an invocation of method DeleteTeamsWebhookActions on an
 // instance of MockCodeMonitorStore.
-type CodeMonitorStoreDeleteSlackWebhookActionsFuncCall struct {
+type CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -2907,7 +3975,7 @@ type CodeMonitorStoreDeleteSlackWebhookActionsFuncCall struct {
 // invocation. The variadic slice argument is flattened in this array such
 // that one positional argument and three variadic arguments would result in
 // a slice of four, not two.
-func (c CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall) Args() []interface{} {
 	trailing := []interface{}{}
 	for _, val := range c.Arg2 {
 		trailing = append(trailing, val)
@@ -2918,7 +3986,7 @@ func (c CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) Args() []interface{}
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
@@ -3619,20 +4687,242 @@ func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushHook(hook func(context.Co
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) SetDefaultReturn(r0 *ActionJobMetadata, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) (*ActionJobMetadata, error) {
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) SetDefaultReturn(r0 *ActionJobMetadata, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*ActionJobMetadata, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushReturn(r0 *ActionJobMetadata, r1 error) {
+	f.PushHook(func(context.Context, int32) (*ActionJobMetadata, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) nextHook() func(context.Context, int32) (*ActionJobMetadata, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) appendCall(r0 CodeMonitorStoreGetActionJobMetadataFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreGetActionJobMetadataFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) History() []CodeMonitorStoreGetActionJobMetadataFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetActionJobMetadataFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetActionJobMetadataFuncCall is an object that describes
+// an invocation of method GetActionJobMetadata on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetActionJobMetadataFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *ActionJobMetadata
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreGetContentMatchKeysFunc describes the behavior when the
+// GetContentMatchKeys method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreGetContentMatchKeysFunc struct {
+	defaultHook func(context.Context, int64) (map[api.RepoID][]string, error)
+	hooks       []func(context.Context, int64) (map[api.RepoID][]string, error)
+	history     []CodeMonitorStoreGetContentMatchKeysFuncCall
+	mutex       sync.Mutex
+}
+
+// GetContentMatchKeys delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetContentMatchKeys(v0 context.Context, v1 int64) (map[api.RepoID][]string, error) {
+	r0, r1 := m.GetContentMatchKeysFunc.nextHook()(v0, v1)
+	m.GetContentMatchKeysFunc.appendCall(CodeMonitorStoreGetContentMatchKeysFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetContentMatchKeys
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreGetContentMatchKeysFunc) SetDefaultHook(hook func(context.Context, int64) (map[api.RepoID][]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetContentMatchKeys method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreGetContentMatchKeysFunc) PushHook(hook func(context.Context, int64) (map[api.RepoID][]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetContentMatchKeysFunc) SetDefaultReturn(r0 map[api.RepoID][]string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (map[api.RepoID][]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetContentMatchKeysFunc) PushReturn(r0 map[api.RepoID][]string, r1 error) {
+	f.PushHook(func(context.Context, int64) (map[api.RepoID][]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetContentMatchKeysFunc) nextHook() func(context.Context, int64) (map[api.RepoID][]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetContentMatchKeysFunc) appendCall(r0 CodeMonitorStoreGetContentMatchKeysFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreGetContentMatchKeysFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreGetContentMatchKeysFunc) History() []CodeMonitorStoreGetContentMatchKeysFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetContentMatchKeysFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetContentMatchKeysFuncCall is an object that describes
+// an invocation of method GetContentMatchKeys on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetContentMatchKeysFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoID][]string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetContentMatchKeysFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetContentMatchKeysFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreGetEmailActionFunc describes the behavior when the
+// GetEmailAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreGetEmailActionFunc struct {
+	defaultHook func(context.Context, int64) (*EmailAction, error)
+	hooks       []func(context.Context, int64) (*EmailAction, error)
+	history     []CodeMonitorStoreGetEmailActionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetEmailAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetEmailAction(v0 context.Context, v1 int64) (*EmailAction, error) {
+	r0, r1 := m.GetEmailActionFunc.nextHook()(v0, v1)
+	m.GetEmailActionFunc.appendCall(CodeMonitorStoreGetEmailActionFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetEmailAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreGetEmailActionFunc) SetDefaultHook(hook func(context.Context, int64) (*EmailAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetEmailAction method of the parent MockCodeMonitorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeMonitorStoreGetEmailActionFunc) PushHook(hook func(context.Context, int64) (*EmailAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetEmailActionFunc) SetDefaultReturn(r0 *EmailAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*EmailAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushReturn(r0 *ActionJobMetadata, r1 error) {
-	f.PushHook(func(context.Context, int32) (*ActionJobMetadata, error) {
+func (f *CodeMonitorStoreGetEmailActionFunc) PushReturn(r0 *EmailAction, r1 error) {
+	f.PushHook(func(context.Context, int64) (*EmailAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) nextHook() func(context.Context, int32) (*ActionJobMetadata, error) {
+func (f *CodeMonitorStoreGetEmailActionFunc) nextHook() func(context.Context, int64) (*EmailAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3645,37 +4935,36 @@ func (f *CodeMonitorStoreGetActionJobMetadataFunc) nextHook() func(context.Conte
 	return hook
 }
 
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) appendCall(r0 CodeMonitorStoreGetActionJobMetadataFuncCall) {
+func (f *CodeMonitorStoreGetEmailActionFunc) appendCall(r0 CodeMonitorStoreGetEmailActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// CodeMonitorStoreGetActionJobMetadataFuncCall objects describing the
-// invocations of this function.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) History() []CodeMonitorStoreGetActionJobMetadataFuncCall {
+// History returns a sequence of CodeMonitorStoreGetEmailActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreGetEmailActionFunc) History() []CodeMonitorStoreGetEmailActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreGetActionJobMetadataFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreGetEmailActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreGetActionJobMetadataFuncCall is an object that describes
-// an invocation of method GetActionJobMetadata on an instance of
+// CodeMonitorStoreGetEmailActionFuncCall is an object that describes an
+// invocation of method GetEmailAction on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreGetActionJobMetadataFuncCall struct {
+type CodeMonitorStoreGetEmailActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *ActionJobMetadata
+	Result0 *EmailAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -3683,46 +4972,46 @@ type CodeMonitorStoreGetActionJobMetadataFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreGetEmailActionFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreGetEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreGetEmailActionFunc describes the behavior when the
-// GetEmailAction method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreGetIssueActionFunc describes the behavior when the
+// GetIssueAction method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreGetEmailActionFunc struct {
-	defaultHook func(context.Context, int64) (*EmailAction, error)
-	hooks       []func(context.Context, int64) (*EmailAction, error)
-	history     []CodeMonitorStoreGetEmailActionFuncCall
+type CodeMonitorStoreGetIssueActionFunc struct {
+	defaultHook func(context.Context, int64) (*IssueAction, error)
+	hooks       []func(context.Context, int64) (*IssueAction, error)
+	history     []CodeMonitorStoreGetIssueActionFuncCall
 	mutex       sync.Mutex
 }
 
-// GetEmailAction delegates to the next hook function in the queue and
+// GetIssueAction delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) GetEmailAction(v0 context.Context, v1 int64) (*EmailAction, error) {
-	r0, r1 := m.GetEmailActionFunc.nextHook()(v0, v1)
-	m.GetEmailActionFunc.appendCall(CodeMonitorStoreGetEmailActionFuncCall{v0, v1, r0, r1})
+func (m *MockCodeMonitorStore) GetIssueAction(v0 context.Context, v1 int64) (*IssueAction, error) {
+	r0, r1 := m.GetIssueActionFunc.nextHook()(v0, v1)
+	m.GetIssueActionFunc.appendCall(CodeMonitorStoreGetIssueActionFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetEmailAction
+// SetDefaultHook sets function that is called when the GetIssueAction
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreGetEmailActionFunc) SetDefaultHook(hook func(context.Context, int64) (*EmailAction, error)) {
+func (f *CodeMonitorStoreGetIssueActionFunc) SetDefaultHook(hook func(context.Context, int64) (*IssueAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetEmailAction method of the parent MockCodeMonitorStore instance invokes
+// GetIssueAction method of the parent MockCodeMonitorStore instance invokes
 // the hook at the front of the queue and discards it. After the queue is
 // empty, the default hook function is invoked for any future action.
-func (f *CodeMonitorStoreGetEmailActionFunc) PushHook(hook func(context.Context, int64) (*EmailAction, error)) {
+func (f *CodeMonitorStoreGetIssueActionFunc) PushHook(hook func(context.Context, int64) (*IssueAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3730,20 +5019,20 @@ func (f *CodeMonitorStoreGetEmailActionFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreGetEmailActionFunc) SetDefaultReturn(r0 *EmailAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) (*EmailAction, error) {
+func (f *CodeMonitorStoreGetIssueActionFunc) SetDefaultReturn(r0 *IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*IssueAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreGetEmailActionFunc) PushReturn(r0 *EmailAction, r1 error) {
-	f.PushHook(func(context.Context, int64) (*EmailAction, error) {
+func (f *CodeMonitorStoreGetIssueActionFunc) PushReturn(r0 *IssueAction, r1 error) {
+	f.PushHook(func(context.Context, int64) (*IssueAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreGetEmailActionFunc) nextHook() func(context.Context, int64) (*EmailAction, error) {
+func (f *CodeMonitorStoreGetIssueActionFunc) nextHook() func(context.Context, int64) (*IssueAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3756,27 +5045,27 @@ func (f *CodeMonitorStoreGetEmailActionFunc) nextHook() func(context.Context, in
 	return hook
 }
 
-func (f *CodeMonitorStoreGetEmailActionFunc) appendCall(r0 CodeMonitorStoreGetEmailActionFuncCall) {
+func (f *CodeMonitorStoreGetIssueActionFunc) appendCall(r0 CodeMonitorStoreGetIssueActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreGetEmailActionFuncCall
+// History returns a sequence of CodeMonitorStoreGetIssueActionFuncCall
 // objects describing the invocations of this function.
-func (f *CodeMonitorStoreGetEmailActionFunc) History() []CodeMonitorStoreGetEmailActionFuncCall {
+func (f *CodeMonitorStoreGetIssueActionFunc) History() []CodeMonitorStoreGetIssueActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreGetEmailActionFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreGetIssueActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreGetEmailActionFuncCall is an object that describes an
-// invocation of method GetEmailAction on an instance of
+// CodeMonitorStoreGetIssueActionFuncCall is an object that describes an
+// invocation of method GetIssueAction on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreGetEmailActionFuncCall struct {
+type CodeMonitorStoreGetIssueActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -3785,7 +5074,7 @@ type CodeMonitorStoreGetEmailActionFuncCall struct {
 	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *EmailAction
+	Result0 *IssueAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -3793,13 +5082,13 @@ type CodeMonitorStoreGetEmailActionFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreGetEmailActionFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreGetIssueActionFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreGetEmailActionFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreGetIssueActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -4361,6 +5650,118 @@ func (c CodeMonitorStoreGetSlackWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreGetTeamsWebhookActionFunc describes the behavior when the
+// GetTeamsWebhookAction method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreGetTeamsWebhookActionFunc struct {
+	defaultHook func(context.Context, int64) (*TeamsWebhookAction, error)
+	hooks       []func(context.Context, int64) (*TeamsWebhookAction, error)
+	history     []CodeMonitorStoreGetTeamsWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetTeamsWebhookAction delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetTeamsWebhookAction(v0 context.Context, v1 int64) (*TeamsWebhookAction, error) {
+	r0, r1 := m.GetTeamsWebhookActionFunc.nextHook()(v0, v1)
+	m.GetTeamsWebhookActionFunc.appendCall(CodeMonitorStoreGetTeamsWebhookActionFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetTeamsWebhookAction method of the parent MockCodeMonitorStore instance
+// is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64) (*TeamsWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetTeamsWebhookAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) PushHook(hook func(context.Context, int64) (*TeamsWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) SetDefaultReturn(r0 *TeamsWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) PushReturn(r0 *TeamsWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64) (*TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) nextHook() func(context.Context, int64) (*TeamsWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) appendCall(r0 CodeMonitorStoreGetTeamsWebhookActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreGetTeamsWebhookActionFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) History() []CodeMonitorStoreGetTeamsWebhookActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetTeamsWebhookActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetTeamsWebhookActionFuncCall is an object that describes
+// an invocation of method GetTeamsWebhookAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetTeamsWebhookActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *TeamsWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetTeamsWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetTeamsWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreGetWebhookActionFunc describes the behavior when the
 // GetWebhookAction method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -4708,10 +6109,121 @@ func (f *CodeMonitorStoreListActionJobsFunc) SetDefaultHook(hook func(context.Co
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListActionJobs method of the parent MockCodeMonitorStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *CodeMonitorStoreListActionJobsFunc) PushHook(hook func(context.Context, ListActionJobsOpts) ([]*ActionJob, error)) {
+// ListActionJobs method of the parent MockCodeMonitorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeMonitorStoreListActionJobsFunc) PushHook(hook func(context.Context, ListActionJobsOpts) ([]*ActionJob, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListActionJobsFunc) SetDefaultReturn(r0 []*ActionJob, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListActionJobsOpts) ([]*ActionJob, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListActionJobsFunc) PushReturn(r0 []*ActionJob, r1 error) {
+	f.PushHook(func(context.Context, ListActionJobsOpts) ([]*ActionJob, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListActionJobsFunc) nextHook() func(context.Context, ListActionJobsOpts) ([]*ActionJob, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListActionJobsFunc) appendCall(r0 CodeMonitorStoreListActionJobsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreListActionJobsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreListActionJobsFunc) History() []CodeMonitorStoreListActionJobsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListActionJobsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListActionJobsFuncCall is an object that describes an
+// invocation of method ListActionJobs on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreListActionJobsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 ListActionJobsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*ActionJob
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListActionJobsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListActionJobsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreListEmailActionsFunc describes the behavior when the
+// ListEmailActions method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreListEmailActionsFunc struct {
+	defaultHook func(context.Context, ListActionsOpts) ([]*EmailAction, error)
+	hooks       []func(context.Context, ListActionsOpts) ([]*EmailAction, error)
+	history     []CodeMonitorStoreListEmailActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListEmailActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListEmailActions(v0 context.Context, v1 ListActionsOpts) ([]*EmailAction, error) {
+	r0, r1 := m.ListEmailActionsFunc.nextHook()(v0, v1)
+	m.ListEmailActionsFunc.appendCall(CodeMonitorStoreListEmailActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListEmailActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreListEmailActionsFunc) SetDefaultHook(hook func(context.Context, ListActionsOpts) ([]*EmailAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListEmailActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreListEmailActionsFunc) PushHook(hook func(context.Context, ListActionsOpts) ([]*EmailAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4719,20 +6231,20 @@ func (f *CodeMonitorStoreListActionJobsFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreListActionJobsFunc) SetDefaultReturn(r0 []*ActionJob, r1 error) {
-	f.SetDefaultHook(func(context.Context, ListActionJobsOpts) ([]*ActionJob, error) {
+func (f *CodeMonitorStoreListEmailActionsFunc) SetDefaultReturn(r0 []*EmailAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListActionsOpts) ([]*EmailAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreListActionJobsFunc) PushReturn(r0 []*ActionJob, r1 error) {
-	f.PushHook(func(context.Context, ListActionJobsOpts) ([]*ActionJob, error) {
+func (f *CodeMonitorStoreListEmailActionsFunc) PushReturn(r0 []*EmailAction, r1 error) {
+	f.PushHook(func(context.Context, ListActionsOpts) ([]*EmailAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreListActionJobsFunc) nextHook() func(context.Context, ListActionJobsOpts) ([]*ActionJob, error) {
+func (f *CodeMonitorStoreListEmailActionsFunc) nextHook() func(context.Context, ListActionsOpts) ([]*EmailAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4745,36 +6257,36 @@ func (f *CodeMonitorStoreListActionJobsFunc) nextHook() func(context.Context, Li
 	return hook
 }
 
-func (f *CodeMonitorStoreListActionJobsFunc) appendCall(r0 CodeMonitorStoreListActionJobsFuncCall) {
+func (f *CodeMonitorStoreListEmailActionsFunc) appendCall(r0 CodeMonitorStoreListEmailActionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreListActionJobsFuncCall
+// History returns a sequence of CodeMonitorStoreListEmailActionsFuncCall
 // objects describing the invocations of this function.
-func (f *CodeMonitorStoreListActionJobsFunc) History() []CodeMonitorStoreListActionJobsFuncCall {
+func (f *CodeMonitorStoreListEmailActionsFunc) History() []CodeMonitorStoreListEmailActionsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreListActionJobsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreListEmailActionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreListActionJobsFuncCall is an object that describes an
-// invocation of method ListActionJobs on an instance of
+// CodeMonitorStoreListEmailActionsFuncCall is an object that describes an
+// invocation of method ListEmailActions on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreListActionJobsFuncCall struct {
+type CodeMonitorStoreListEmailActionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 ListActionJobsOpts
+	Arg1 ListActionsOpts
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*ActionJob
+	Result0 []*EmailAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -4782,47 +6294,47 @@ type CodeMonitorStoreListActionJobsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreListActionJobsFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreListEmailActionsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreListActionJobsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreListEmailActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreListEmailActionsFunc describes the behavior when the
-// ListEmailActions method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreListIssueActionsFunc describes the behavior when the
+// ListIssueActions method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreListEmailActionsFunc struct {
-	defaultHook func(context.Context, ListActionsOpts) ([]*EmailAction, error)
-	hooks       []func(context.Context, ListActionsOpts) ([]*EmailAction, error)
-	history     []CodeMonitorStoreListEmailActionsFuncCall
+type CodeMonitorStoreListIssueActionsFunc struct {
+	defaultHook func(context.Context, ListActionsOpts) ([]*IssueAction, error)
+	hooks       []func(context.Context, ListActionsOpts) ([]*IssueAction, error)
+	history     []CodeMonitorStoreListIssueActionsFuncCall
 	mutex       sync.Mutex
 }
 
-// ListEmailActions delegates to the next hook function in the queue and
+// ListIssueActions delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) ListEmailActions(v0 context.Context, v1 ListActionsOpts) ([]*EmailAction, error) {
-	r0, r1 := m.ListEmailActionsFunc.nextHook()(v0, v1)
-	m.ListEmailActionsFunc.appendCall(CodeMonitorStoreListEmailActionsFuncCall{v0, v1, r0, r1})
+func (m *MockCodeMonitorStore) ListIssueActions(v0 context.Context, v1 ListActionsOpts) ([]*IssueAction, error) {
+	r0, r1 := m.ListIssueActionsFunc.nextHook()(v0, v1)
+	m.ListIssueActionsFunc.appendCall(CodeMonitorStoreListIssueActionsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ListEmailActions
+// SetDefaultHook sets function that is called when the ListIssueActions
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreListEmailActionsFunc) SetDefaultHook(hook func(context.Context, ListActionsOpts) ([]*EmailAction, error)) {
+func (f *CodeMonitorStoreListIssueActionsFunc) SetDefaultHook(hook func(context.Context, ListActionsOpts) ([]*IssueAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListEmailActions method of the parent MockCodeMonitorStore instance
+// ListIssueActions method of the parent MockCodeMonitorStore instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *CodeMonitorStoreListEmailActionsFunc) PushHook(hook func(context.Context, ListActionsOpts) ([]*EmailAction, error)) {
+func (f *CodeMonitorStoreListIssueActionsFunc) PushHook(hook func(context.Context, ListActionsOpts) ([]*IssueAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4830,20 +6342,20 @@ func (f *CodeMonitorStoreListEmailActionsFunc) PushHook(hook func(context.Contex
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreListEmailActionsFunc) SetDefaultReturn(r0 []*EmailAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, ListActionsOpts) ([]*EmailAction, error) {
+func (f *CodeMonitorStoreListIssueActionsFunc) SetDefaultReturn(r0 []*IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListActionsOpts) ([]*IssueAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreListEmailActionsFunc) PushReturn(r0 []*EmailAction, r1 error) {
-	f.PushHook(func(context.Context, ListActionsOpts) ([]*EmailAction, error) {
+func (f *CodeMonitorStoreListIssueActionsFunc) PushReturn(r0 []*IssueAction, r1 error) {
+	f.PushHook(func(context.Context, ListActionsOpts) ([]*IssueAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreListEmailActionsFunc) nextHook() func(context.Context, ListActionsOpts) ([]*EmailAction, error) {
+func (f *CodeMonitorStoreListIssueActionsFunc) nextHook() func(context.Context, ListActionsOpts) ([]*IssueAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4856,27 +6368,27 @@ func (f *CodeMonitorStoreListEmailActionsFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *CodeMonitorStoreListEmailActionsFunc) appendCall(r0 CodeMonitorStoreListEmailActionsFuncCall) {
+func (f *CodeMonitorStoreListIssueActionsFunc) appendCall(r0 CodeMonitorStoreListIssueActionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreListEmailActionsFuncCall
+// History returns a sequence of CodeMonitorStoreListIssueActionsFuncCall
 // objects describing the invocations of this function.
-func (f *CodeMonitorStoreListEmailActionsFunc) History() []CodeMonitorStoreListEmailActionsFuncCall {
+func (f *CodeMonitorStoreListIssueActionsFunc) History() []CodeMonitorStoreListIssueActionsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreListEmailActionsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreListIssueActionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreListEmailActionsFuncCall is an object that describes an
-// invocation of method ListEmailActions on an instance of
+// CodeMonitorStoreListIssueActionsFuncCall is an object that describes an
+// invocation of method ListIssueActions on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreListEmailActionsFuncCall struct {
+type CodeMonitorStoreListIssueActionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -4885,7 +6397,7 @@ type CodeMonitorStoreListEmailActionsFuncCall struct {
 	Arg1 ListActionsOpts
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*EmailAction
+	Result0 []*IssueAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -4893,13 +6405,13 @@ type CodeMonitorStoreListEmailActionsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreListEmailActionsFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreListIssueActionsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreListEmailActionsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreListIssueActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -5346,6 +6858,118 @@ func (c CodeMonitorStoreListSlackWebhookActionsFuncCall) Results() []interface{}
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreListTeamsWebhookActionsFunc describes the behavior when
+// the ListTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreListTeamsWebhookActionsFunc struct {
+	defaultHook func(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error)
+	hooks       []func(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error)
+	history     []CodeMonitorStoreListTeamsWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListTeamsWebhookActions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListTeamsWebhookActions(v0 context.Context, v1 ListActionsOpts) ([]*TeamsWebhookAction, error) {
+	r0, r1 := m.ListTeamsWebhookActionsFunc.nextHook()(v0, v1)
+	m.ListTeamsWebhookActionsFunc.appendCall(CodeMonitorStoreListTeamsWebhookActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) SetDefaultHook(hook func(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) PushHook(hook func(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) SetDefaultReturn(r0 []*TeamsWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) PushReturn(r0 []*TeamsWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) nextHook() func(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) appendCall(r0 CodeMonitorStoreListTeamsWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreListTeamsWebhookActionsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) History() []CodeMonitorStoreListTeamsWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListTeamsWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListTeamsWebhookActionsFuncCall is an object that
+// describes an invocation of method ListTeamsWebhookActions on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreListTeamsWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 ListActionsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*TeamsWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListTeamsWebhookActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListTeamsWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreListWebhookActionsFunc describes the behavior when the
 // ListWebhookActions method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -5529,64 +7153,173 @@ func (f *CodeMonitorStoreNowFunc) appendCall(r0 CodeMonitorStoreNowFuncCall) {
 // describing the invocations of this function.
 func (f *CodeMonitorStoreNowFunc) History() []CodeMonitorStoreNowFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreNowFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreNowFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreNowFuncCall is an object that describes an invocation of
+// method Now on an instance of MockCodeMonitorStore.
+type CodeMonitorStoreNowFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 time.Time
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreNowFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreNowFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreResetQueryTriggerTimestampsFunc describes the behavior
+// when the ResetQueryTriggerTimestamps method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreResetQueryTriggerTimestampsFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []CodeMonitorStoreResetQueryTriggerTimestampsFuncCall
+	mutex       sync.Mutex
+}
+
+// ResetQueryTriggerTimestamps delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ResetQueryTriggerTimestamps(v0 context.Context, v1 int64) error {
+	r0 := m.ResetQueryTriggerTimestampsFunc.nextHook()(v0, v1)
+	m.ResetQueryTriggerTimestampsFunc.appendCall(CodeMonitorStoreResetQueryTriggerTimestampsFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// ResetQueryTriggerTimestamps method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreResetQueryTriggerTimestampsFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ResetQueryTriggerTimestamps method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreResetQueryTriggerTimestampsFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreResetQueryTriggerTimestampsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreResetQueryTriggerTimestampsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreResetQueryTriggerTimestampsFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreResetQueryTriggerTimestampsFunc) appendCall(r0 CodeMonitorStoreResetQueryTriggerTimestampsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreResetQueryTriggerTimestampsFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreResetQueryTriggerTimestampsFunc) History() []CodeMonitorStoreResetQueryTriggerTimestampsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreResetQueryTriggerTimestampsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreNowFuncCall is an object that describes an invocation of
-// method Now on an instance of MockCodeMonitorStore.
-type CodeMonitorStoreNowFuncCall struct {
+// CodeMonitorStoreResetQueryTriggerTimestampsFuncCall is an object that
+// describes an invocation of method ResetQueryTriggerTimestamps on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreResetQueryTriggerTimestampsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 time.Time
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreNowFuncCall) Args() []interface{} {
-	return []interface{}{}
+func (c CodeMonitorStoreResetQueryTriggerTimestampsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreNowFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreResetQueryTriggerTimestampsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// CodeMonitorStoreResetQueryTriggerTimestampsFunc describes the behavior
-// when the ResetQueryTriggerTimestamps method of the parent
-// MockCodeMonitorStore instance is invoked.
-type CodeMonitorStoreResetQueryTriggerTimestampsFunc struct {
-	defaultHook func(context.Context, int64) error
-	hooks       []func(context.Context, int64) error
-	history     []CodeMonitorStoreResetQueryTriggerTimestampsFuncCall
+// CodeMonitorStoreSetIssueActionIssueNumberFunc describes the behavior when
+// the SetIssueActionIssueNumber method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreSetIssueActionIssueNumberFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []CodeMonitorStoreSetIssueActionIssueNumberFuncCall
 	mutex       sync.Mutex
 }
 
-// ResetQueryTriggerTimestamps delegates to the next hook function in the
+// SetIssueActionIssueNumber delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) ResetQueryTriggerTimestamps(v0 context.Context, v1 int64) error {
-	r0 := m.ResetQueryTriggerTimestampsFunc.nextHook()(v0, v1)
-	m.ResetQueryTriggerTimestampsFunc.appendCall(CodeMonitorStoreResetQueryTriggerTimestampsFuncCall{v0, v1, r0})
+func (m *MockCodeMonitorStore) SetIssueActionIssueNumber(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.SetIssueActionIssueNumberFunc.nextHook()(v0, v1, v2)
+	m.SetIssueActionIssueNumberFunc.appendCall(CodeMonitorStoreSetIssueActionIssueNumberFuncCall{v0, v1, v2, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the
-// ResetQueryTriggerTimestamps method of the parent MockCodeMonitorStore
+// SetIssueActionIssueNumber method of the parent MockCodeMonitorStore
 // instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreResetQueryTriggerTimestampsFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+func (f *CodeMonitorStoreSetIssueActionIssueNumberFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ResetQueryTriggerTimestamps method of the parent MockCodeMonitorStore
+// SetIssueActionIssueNumber method of the parent MockCodeMonitorStore
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *CodeMonitorStoreResetQueryTriggerTimestampsFunc) PushHook(hook func(context.Context, int64) error) {
+func (f *CodeMonitorStoreSetIssueActionIssueNumberFunc) PushHook(hook func(context.Context, int64, int64) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -5594,20 +7327,20 @@ func (f *CodeMonitorStoreResetQueryTriggerTimestampsFunc) PushHook(hook func(con
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreResetQueryTriggerTimestampsFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64) error {
+func (f *CodeMonitorStoreSetIssueActionIssueNumberFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreResetQueryTriggerTimestampsFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64) error {
+func (f *CodeMonitorStoreSetIssueActionIssueNumberFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
 		return r0
 	})
 }
 
-func (f *CodeMonitorStoreResetQueryTriggerTimestampsFunc) nextHook() func(context.Context, int64) error {
+func (f *CodeMonitorStoreSetIssueActionIssueNumberFunc) nextHook() func(context.Context, int64, int64) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -5620,34 +7353,37 @@ func (f *CodeMonitorStoreResetQueryTriggerTimestampsFunc) nextHook() func(contex
 	return hook
 }
 
-func (f *CodeMonitorStoreResetQueryTriggerTimestampsFunc) appendCall(r0 CodeMonitorStoreResetQueryTriggerTimestampsFuncCall) {
+func (f *CodeMonitorStoreSetIssueActionIssueNumberFunc) appendCall(r0 CodeMonitorStoreSetIssueActionIssueNumberFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// CodeMonitorStoreResetQueryTriggerTimestampsFuncCall objects describing
-// the invocations of this function.
-func (f *CodeMonitorStoreResetQueryTriggerTimestampsFunc) History() []CodeMonitorStoreResetQueryTriggerTimestampsFuncCall {
+// CodeMonitorStoreSetIssueActionIssueNumberFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreSetIssueActionIssueNumberFunc) History() []CodeMonitorStoreSetIssueActionIssueNumberFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreResetQueryTriggerTimestampsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreSetIssueActionIssueNumberFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreResetQueryTriggerTimestampsFuncCall is an object that
-// describes an invocation of method ResetQueryTriggerTimestamps on an
+// CodeMonitorStoreSetIssueActionIssueNumberFuncCall is an object that
+// describes an invocation of method SetIssueActionIssueNumber on an
 // instance of MockCodeMonitorStore.
-type CodeMonitorStoreResetQueryTriggerTimestampsFuncCall struct {
+type CodeMonitorStoreSetIssueActionIssueNumberFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -5655,13 +7391,13 @@ type CodeMonitorStoreResetQueryTriggerTimestampsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreResetQueryTriggerTimestampsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c CodeMonitorStoreSetIssueActionIssueNumberFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreResetQueryTriggerTimestampsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreSetIssueActionIssueNumberFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
@@ -5999,6 +7735,126 @@ func (c CodeMonitorStoreUpdateEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreUpdateIssueActionFunc describes the behavior when the
+// UpdateIssueAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreUpdateIssueActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)
+	hooks       []func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)
+	history     []CodeMonitorStoreUpdateIssueActionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateIssueAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateIssueAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 api.RepoID) (*IssueAction, error) {
+	r0, r1 := m.UpdateIssueActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateIssueActionFunc.appendCall(CodeMonitorStoreUpdateIssueActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UpdateIssueAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateIssueAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) PushHook(hook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) SetDefaultReturn(r0 *IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) PushReturn(r0 *IssueAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreUpdateIssueActionFunc) nextHook() func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateIssueActionFunc) appendCall(r0 CodeMonitorStoreUpdateIssueActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreUpdateIssueActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) History() []CodeMonitorStoreUpdateIssueActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateIssueActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateIssueActionFuncCall is an object that describes an
+// invocation of method UpdateIssueAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreUpdateIssueActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *IssueAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateIssueActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateIssueActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreUpdateMonitorFunc describes the behavior when the
 // UpdateMonitor method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -6265,20 +8121,131 @@ func (f *CodeMonitorStoreUpdateQueryTriggerFunc) PushHook(hook func(context.Cont
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreUpdateQueryTriggerFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64, string) error {
-		return r0
+func (f *CodeMonitorStoreUpdateQueryTriggerFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateQueryTriggerFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, string) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpdateQueryTriggerFunc) nextHook() func(context.Context, int64, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateQueryTriggerFunc) appendCall(r0 CodeMonitorStoreUpdateQueryTriggerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreUpdateQueryTriggerFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreUpdateQueryTriggerFunc) History() []CodeMonitorStoreUpdateQueryTriggerFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateQueryTriggerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateQueryTriggerFuncCall is an object that describes an
+// invocation of method UpdateQueryTrigger on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreUpdateQueryTriggerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateQueryTriggerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateQueryTriggerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreUpdateSlackWebhookActionFunc describes the behavior when
+// the UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreUpdateSlackWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)
+	history     []CodeMonitorStoreUpdateSlackWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateSlackWebhookAction delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateSlackWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*SlackWebhookAction, error) {
+	r0, r1 := m.UpdateSlackWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateSlackWebhookActionFunc.appendCall(CodeMonitorStoreUpdateSlackWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultReturn(r0 *SlackWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreUpdateQueryTriggerFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64, string) error {
-		return r0
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushReturn(r0 *SlackWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreUpdateQueryTriggerFunc) nextHook() func(context.Context, int64, string) error {
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -6291,27 +8258,28 @@ func (f *CodeMonitorStoreUpdateQueryTriggerFunc) nextHook() func(context.Context
 	return hook
 }
 
-func (f *CodeMonitorStoreUpdateQueryTriggerFunc) appendCall(r0 CodeMonitorStoreUpdateQueryTriggerFuncCall) {
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) appendCall(r0 CodeMonitorStoreUpdateSlackWebhookActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreUpdateQueryTriggerFuncCall
-// objects describing the invocations of this function.
-func (f *CodeMonitorStoreUpdateQueryTriggerFunc) History() []CodeMonitorStoreUpdateQueryTriggerFuncCall {
+// History returns a sequence of
+// CodeMonitorStoreUpdateSlackWebhookActionFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) History() []CodeMonitorStoreUpdateSlackWebhookActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreUpdateQueryTriggerFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreUpdateSlackWebhookActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreUpdateQueryTriggerFuncCall is an object that describes an
-// invocation of method UpdateQueryTrigger on an instance of
-// MockCodeMonitorStore.
-type CodeMonitorStoreUpdateQueryTriggerFuncCall struct {
+// CodeMonitorStoreUpdateSlackWebhookActionFuncCall is an object that
+// describes an invocation of method UpdateSlackWebhookAction on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreUpdateSlackWebhookActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -6320,55 +8288,64 @@ type CodeMonitorStoreUpdateQueryTriggerFuncCall struct {
 	Arg1 int64
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 string
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 *SlackWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreUpdateQueryTriggerFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreUpdateQueryTriggerFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreUpdateSlackWebhookActionFunc describes the behavior when
-// the UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
+// CodeMonitorStoreUpdateTeamsWebhookActionFunc describes the behavior when
+// the UpdateTeamsWebhookAction method of the parent MockCodeMonitorStore
 // instance is invoked.
-type CodeMonitorStoreUpdateSlackWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)
-	history     []CodeMonitorStoreUpdateSlackWebhookActionFuncCall
+type CodeMonitorStoreUpdateTeamsWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error)
+	history     []CodeMonitorStoreUpdateTeamsWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
-// UpdateSlackWebhookAction delegates to the next hook function in the queue
+// UpdateTeamsWebhookAction delegates to the next hook function in the queue
 // and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateSlackWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*SlackWebhookAction, error) {
-	r0, r1 := m.UpdateSlackWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.UpdateSlackWebhookActionFunc.appendCall(CodeMonitorStoreUpdateSlackWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+func (m *MockCodeMonitorStore) UpdateTeamsWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*TeamsWebhookAction, error) {
+	r0, r1 := m.UpdateTeamsWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateTeamsWebhookActionFunc.appendCall(CodeMonitorStoreUpdateTeamsWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
-// UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
+// UpdateTeamsWebhookAction method of the parent MockCodeMonitorStore
 // instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
+// UpdateTeamsWebhookAction method of the parent MockCodeMonitorStore
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -6376,20 +8353,20 @@ func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushHook(hook func(contex
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultReturn(r0 *SlackWebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) SetDefaultReturn(r0 *TeamsWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushReturn(r0 *SlackWebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) PushReturn(r0 *TeamsWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -6402,28 +8379,28 @@ func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) nextHook() func(context.C
 	return hook
 }
 
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) appendCall(r0 CodeMonitorStoreUpdateSlackWebhookActionFuncCall) {
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) appendCall(r0 CodeMonitorStoreUpdateTeamsWebhookActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// CodeMonitorStoreUpdateSlackWebhookActionFuncCall objects describing the
+// CodeMonitorStoreUpdateTeamsWebhookActionFuncCall objects describing the
 // invocations of this function.
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) History() []CodeMonitorStoreUpdateSlackWebhookActionFuncCall {
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) History() []CodeMonitorStoreUpdateTeamsWebhookActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreUpdateSlackWebhookActionFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreUpdateTeamsWebhookActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreUpdateSlackWebhookActionFuncCall is an object that
-// describes an invocation of method UpdateSlackWebhookAction on an instance
+// CodeMonitorStoreUpdateTeamsWebhookActionFuncCall is an object that
+// describes an invocation of method UpdateTeamsWebhookAction on an instance
 // of MockCodeMonitorStore.
-type CodeMonitorStoreUpdateSlackWebhookActionFuncCall struct {
+type CodeMonitorStoreUpdateTeamsWebhookActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -6441,7 +8418,7 @@ type CodeMonitorStoreUpdateSlackWebhookActionFuncCall struct {
 	Arg4 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *SlackWebhookAction
+	Result0 *TeamsWebhookAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -6449,13 +8426,13 @@ type CodeMonitorStoreUpdateSlackWebhookActionFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreUpdateTeamsWebhookActionFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreUpdateTeamsWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -6694,6 +8671,121 @@ func (c CodeMonitorStoreUpdateWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreUpsertContentMatchKeysFunc describes the behavior when
+// the UpsertContentMatchKeys method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreUpsertContentMatchKeysFunc struct {
+	defaultHook func(context.Context, int64, api.RepoID, []string) error
+	hooks       []func(context.Context, int64, api.RepoID, []string) error
+	history     []CodeMonitorStoreUpsertContentMatchKeysFuncCall
+	mutex       sync.Mutex
+}
+
+// UpsertContentMatchKeys delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpsertContentMatchKeys(v0 context.Context, v1 int64, v2 api.RepoID, v3 []string) error {
+	r0 := m.UpsertContentMatchKeysFunc.nextHook()(v0, v1, v2, v3)
+	m.UpsertContentMatchKeysFunc.appendCall(CodeMonitorStoreUpsertContentMatchKeysFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpsertContentMatchKeys method of the parent MockCodeMonitorStore instance
+// is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpsertContentMatchKeysFunc) SetDefaultHook(hook func(context.Context, int64, api.RepoID, []string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpsertContentMatchKeys method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreUpsertContentMatchKeysFunc) PushHook(hook func(context.Context, int64, api.RepoID, []string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpsertContentMatchKeysFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, api.RepoID, []string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpsertContentMatchKeysFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, api.RepoID, []string) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpsertContentMatchKeysFunc) nextHook() func(context.Context, int64, api.RepoID, []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpsertContentMatchKeysFunc) appendCall(r0 CodeMonitorStoreUpsertContentMatchKeysFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpsertContentMatchKeysFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreUpsertContentMatchKeysFunc) History() []CodeMonitorStoreUpsertContentMatchKeysFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpsertContentMatchKeysFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpsertContentMatchKeysFuncCall is an object that
+// describes an invocation of method UpsertContentMatchKeys on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreUpsertContentMatchKeysFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoID
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpsertContentMatchKeysFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpsertContentMatchKeysFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreUpsertLastSearchedFunc describes the behavior when the
 // UpsertLastSearched method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -6886,6 +8978,9 @@ type MockEnterpriseDB struct {
 	// QueryRowContextFunc is an instance of a mock function object
 	// controlling the behavior of the method QueryRowContext.
 	QueryRowContextFunc *EnterpriseDBQueryRowContextFunc
+	// RepoInventoriesFunc is an instance of a mock function object
+	// controlling the behavior of the method RepoInventories.
+	RepoInventoriesFunc *EnterpriseDBRepoInventoriesFunc
 	// RepoKVPsFunc is an instance of a mock function object controlling the
 	// behavior of the method RepoKVPs.
 	RepoKVPsFunc *EnterpriseDBRepoKVPsFunc
@@ -7063,6 +9158,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		RepoInventoriesFunc: &EnterpriseDBRepoInventoriesFunc{
+			defaultHook: func() (r0 database.RepoInventoryStore) {
+				return
+			},
+		},
 		RepoKVPsFunc: &EnterpriseDBRepoKVPsFunc{
 			defaultHook: func() (r0 database.RepoKVPStore) {
 				return
@@ -7275,6 +9375,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.QueryRowContext")
 			},
 		},
+		RepoInventoriesFunc: &EnterpriseDBRepoInventoriesFunc{
+			defaultHook: func() database.RepoInventoryStore {
+				panic("unexpected invocation of MockEnterpriseDB.RepoInventories")
+			},
+		},
 		RepoKVPsFunc: &EnterpriseDBRepoKVPsFunc{
 			defaultHook: func() database.RepoKVPStore {
 				panic("unexpected invocation of MockEnterpriseDB.RepoKVPs")
@@ -7440,6 +9545,9 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		QueryRowContextFunc: &EnterpriseDBQueryRowContextFunc{
 			defaultHook: i.QueryRowContext,
 		},
+		RepoInventoriesFunc: &EnterpriseDBRepoInventoriesFunc{
+			defaultHook: i.RepoInventories,
+		},
 		RepoKVPsFunc: &EnterpriseDBRepoKVPsFunc{
 			defaultHook: i.RepoKVPs,
 		},
@@ -9930,6 +12038,106 @@ func (c EnterpriseDBQueryRowContextFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBRepoInventoriesFunc describes the behavior when the
+// RepoInventories method of the parent MockEnterpriseDB instance is
+// invoked.
+type EnterpriseDBRepoInventoriesFunc struct {
+	defaultHook func() database.RepoInventoryStore
+	hooks       []func() database.RepoInventoryStore
+	history     []EnterpriseDBRepoInventoriesFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoInventories delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) RepoInventories() database.RepoInventoryStore {
+	r0 := m.RepoInventoriesFunc.nextHook()()
+	m.RepoInventoriesFunc.appendCall(EnterpriseDBRepoInventoriesFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RepoInventories
+// method of the parent MockEnterpriseDB instance is invoked and the hook
+// queue is empty.
+func (f *EnterpriseDBRepoInventoriesFunc) SetDefaultHook(hook func() database.RepoInventoryStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoInventories method of the parent MockEnterpriseDB instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *EnterpriseDBRepoInventoriesFunc) PushHook(hook func() database.RepoInventoryStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBRepoInventoriesFunc) SetDefaultReturn(r0 database.RepoInventoryStore) {
+	f.SetDefaultHook(func() database.RepoInventoryStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBRepoInventoriesFunc) PushReturn(r0 database.RepoInventoryStore) {
+	f.PushHook(func() database.RepoInventoryStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBRepoInventoriesFunc) nextHook() func() database.RepoInventoryStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBRepoInventoriesFunc) appendCall(r0 EnterpriseDBRepoInventoriesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBRepoInventoriesFuncCall objects
+// describing the invocations of this function.
+func (f *EnterpriseDBRepoInventoriesFunc) History() []EnterpriseDBRepoInventoriesFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBRepoInventoriesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBRepoInventoriesFuncCall is an object that describes an
+// invocation of method RepoInventories on an instance of MockEnterpriseDB.
+type EnterpriseDBRepoInventoriesFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.RepoInventoryStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBRepoInventoriesFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBRepoInventoriesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBRepoKVPsFunc describes the behavior when the RepoKVPs method
 // of the parent MockEnterpriseDB instance is invoked.
 type EnterpriseDBRepoKVPsFunc struct {
@@ -109,6 +109,10 @@ func (j *Job) UnmarshalJSON(bytes []byte) error {
 				Commands: toStringSlice(step["commands"]),
 				Dir:      toString(step["dir"]),
 				Env:      toStringSlice(step["env"]),
+
+				Timeout:         toString(step["timeout"]),
+				Retries:         toInt(step["retries"]),
+				ContinueOnError: toBool(step["continueOnError"]),
 			}
 		}
 		j.DockerSteps = jobDockerSteps
@@ -124,6 +128,10 @@ func (j *Job) UnmarshalJSON(bytes []byte) error {
 				Commands: toStringSlice(step["command"]),
 				Dir:      toString(step["dir"]),
 				Env:      toStringSlice(step["env"]),
+
+				Timeout:         toString(step["timeout"]),
+				Retries:         toInt(step["retries"]),
+				ContinueOnError: toBool(step["continueOnError"]),
 			}
 		}
 		j.CliSteps = jobCliSteps
@@ -168,6 +176,13 @@ func toBool(v interface{}) bool {
 	return v.(bool)
 }
 
+func toInt(v interface{}) int {
+	if v == nil {
+		return 0
+	}
+	return int(v.(float64))
+}
+
 func toTime(v interface{}) (time.Time, error) {
 	if v == nil {
 		return time.Time{}, nil
@@ -213,6 +228,17 @@ type DockerStep struct {
 
 	// Env specifies a set of NAME=value pairs to supply to the docker command.
 	Env []string `json:"env"`
+
+	// Timeout is the maximum duration of a single attempt to run the step, in
+	// the format accepted by time.ParseDuration. If empty, no timeout applies.
+	Timeout string `json:"timeout,omitempty"`
+
+	// Retries is the number of times the step is retried after a failed attempt.
+	Retries int `json:"retries,omitempty"`
+
+	// ContinueOnError, when true, runs the following steps even if all attempts
+	// to run this step failed.
+	ContinueOnError bool `json:"continueOnError,omitempty"`
 }
 
 type CliStep struct {
@@ -228,6 +254,17 @@ type CliStep struct {
 
 	// Env specifies a set of NAME=value pairs to supply to the src command.
 	Env []string `json:"env"`
+
+	// Timeout is the maximum duration of a single attempt to run the step, in
+	// the format accepted by time.ParseDuration. If empty, no timeout applies.
+	Timeout string `json:"timeout,omitempty"`
+
+	// Retries is the number of times the step is retried after a failed attempt.
+	Retries int `json:"retries,omitempty"`
+
+	// ContinueOnError, when true, runs the following steps even if all attempts
+	// to run this step failed.
+	ContinueOnError bool `json:"continueOnError,omitempty"`
 }
 
 type DequeueRequest struct {
@@ -44,12 +44,15 @@ func TestJob_UnmarshalJSON(t *testing.T) {
 		"image": "my-image",
 		"commands": ["run"],
 		"dir": "faz/baz",
-		"env": ["FOO=BAR"]
+		"env": ["FOO=BAR"],
+		"timeout": "10m",
+		"retries": 2
 	}],
 	"cliSteps": [{
 		"command": ["x", "y", "z"],
 		"dir": "raz/daz",
-		"env": ["BAZ=FAZ"]
+		"env": ["BAZ=FAZ"],
+		"continueOnError": true
 	}],
 	"redactedValues": {
 		"password": "foo"
@@ -79,13 +82,16 @@ func TestJob_UnmarshalJSON(t *testing.T) {
 						Commands: []string{"run"},
 						Dir:      "faz/baz",
 						Env:      []string{"FOO=BAR"},
+						Timeout:  "10m",
+						Retries:  2,
 					},
 				},
 				CliSteps: []executor.CliStep{
 					{
-						Commands: []string{"x", "y", "z"},
-						Dir:      "raz/daz",
-						Env:      []string{"BAZ=FAZ"},
+						Commands:        []string{"x", "y", "z"},
+						Dir:             "raz/daz",
+						Env:             []string{"BAZ=FAZ"},
+						ContinueOnError: true,
 					},
 				},
 				RedactedValues: map[string]string{
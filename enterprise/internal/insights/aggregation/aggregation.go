@@ -2,7 +2,9 @@ package aggregation
 
 import (
 	"context"
+	"path"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 
@@ -86,6 +88,86 @@ func countPath(r result.Match) (map[MatchKey]int, error) {
 	return nil, nil
 }
 
+func countFileExtension(r result.Match) (map[MatchKey]int, error) {
+	var extension string
+	switch match := r.(type) {
+	case *result.FileMatch:
+		extension = path.Ext(match.Path)
+	default:
+	}
+	if extension != "" {
+		return map[MatchKey]int{{
+			RepoID: int32(r.RepoName().ID),
+			Repo:   string(r.RepoName().Name),
+			Group:  extension,
+		}: r.ResultCount()}, nil
+	}
+	return nil, nil
+}
+
+// RootDirectoryGroup is the group of files at the root of a repository when
+// aggregating by directory.
+const RootDirectoryGroup = "/"
+
+func countDirectoryFunc(depth int) AggregationCountFunc {
+	return func(r result.Match) (map[MatchKey]int, error) {
+		var directory string
+		switch match := r.(type) {
+		case *result.FileMatch:
+			directory = truncateDirectory(path.Dir(match.Path), depth)
+		default:
+		}
+		if directory != "" {
+			return map[MatchKey]int{{
+				RepoID: int32(r.RepoName().ID),
+				Repo:   string(r.RepoName().Name),
+				Group:  directory,
+			}: r.ResultCount()}, nil
+		}
+		return nil, nil
+	}
+}
+
+// truncateDirectory returns the first depth components of directory, or
+// RootDirectoryGroup for the root of the repository.
+func truncateDirectory(directory string, depth int) string {
+	if directory == "." || directory == "/" || directory == "" {
+		return RootDirectoryGroup
+	}
+	parts := strings.Split(strings.TrimPrefix(directory, "/"), "/")
+	if len(parts) > depth {
+		parts = parts[:depth]
+	}
+	return strings.Join(parts, "/")
+}
+
+// CommitMonthLayout is the layout of the groups when aggregating by commit month.
+const CommitMonthLayout = "2006-01"
+
+func countCommitMonth(r result.Match) (map[MatchKey]int, error) {
+	var month string
+	switch match := r.(type) {
+	case *result.CommitMatch:
+		// Group by committer date since it is the date after: and before: filter on.
+		date := match.Commit.Author.Date
+		if match.Commit.Committer != nil && !match.Commit.Committer.Date.IsZero() {
+			date = match.Commit.Committer.Date
+		}
+		if !date.IsZero() {
+			month = date.UTC().Format(CommitMonthLayout)
+		}
+	default:
+	}
+	if month != "" {
+		return map[MatchKey]int{{
+			RepoID: int32(r.RepoName().ID),
+			Repo:   string(r.RepoName().Name),
+			Group:  month,
+		}: r.ResultCount()}, nil
+	}
+	return nil, nil
+}
+
 func countAuthor(r result.Match) (map[MatchKey]int, error) {
 	var author string
 	switch match := r.(type) {
@@ -166,11 +248,24 @@ func matchContent(event result.Match) []string {
 	}
 }
 
-func GetCountFuncForMode(query, patternType string, mode types.SearchAggregationMode) (AggregationCountFunc, error) {
+// GetCountFuncForMode returns the function counting search results for mode.
+// directoryDepth is the number of path components to group by and is only used
+// by the DIRECTORY_DEPTH mode.
+func GetCountFuncForMode(query, patternType string, mode types.SearchAggregationMode, directoryDepth int) (AggregationCountFunc, error) {
 	modeCountTypes := map[types.SearchAggregationMode]AggregationCountFunc{
-		types.REPO_AGGREGATION_MODE:   countRepo,
-		types.PATH_AGGREGATION_MODE:   countPath,
-		types.AUTHOR_AGGREGATION_MODE: countAuthor,
+		types.REPO_AGGREGATION_MODE:           countRepo,
+		types.PATH_AGGREGATION_MODE:           countPath,
+		types.AUTHOR_AGGREGATION_MODE:         countAuthor,
+		types.LANGUAGE_AGGREGATION_MODE:       countLang,
+		types.FILE_EXTENSION_AGGREGATION_MODE: countFileExtension,
+		types.COMMIT_MONTH_AGGREGATION_MODE:   countCommitMonth,
+	}
+
+	if mode == types.DIRECTORY_DEPTH_AGGREGATION_MODE {
+		if directoryDepth < 1 {
+			return nil, errors.Newf("invalid directory depth: %d, must be at least 1", directoryDepth)
+		}
+		modeCountTypes[types.DIRECTORY_DEPTH_AGGREGATION_MODE] = countDirectoryFunc(directoryDepth)
 	}
 
 	if mode == types.CAPTURE_GROUP_AGGREGATION_MODE {
@@ -94,8 +94,8 @@ func commitMatch(repo, author string, date time.Time, repoID, numRanges int32, c
 
 	return &result.CommitMatch{
 		Commit: gitdomain.Commit{
-			Author:    gitdomain.Signature{Name: author},
-			Committer: &gitdomain.Signature{},
+			Author:    gitdomain.Signature{Name: author, Date: date},
+			Committer: &gitdomain.Signature{Date: date},
 			Message:   gitdomain.Message(content),
 		},
 		Repo: internaltypes.MinimalRepo{Name: api.RepoName(repo), ID: api.RepoID(repoID)},
@@ -240,7 +240,7 @@ func TestRepoAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode, 0)
 			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
 			sra.Send(tc.searchEvent)
 			tc.want.Equal(t, aggregator.results)
@@ -302,7 +302,7 @@ func TestAuthorAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode, 0)
 			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
 			sra.Send(tc.searchEvent)
 			tc.want.Equal(t, aggregator.results)
@@ -399,7 +399,161 @@ func TestPathAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode, 0)
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
+func TestLanguageAggregation(t *testing.T) {
+	testCases := []struct {
+		mode        types.SearchAggregationMode
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{types.LANGUAGE_AGGREGATION_MODE, streaming.SearchEvent{}, autogold.Want("No results", map[string]int{})},
+		{
+			types.LANGUAGE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					repoMatch("myRepo", 1),
+					commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+				},
+			},
+			autogold.Want("no language for repo and commit", map[string]int{}),
+		},
+		{
+			types.LANGUAGE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "file.go", 1, "a", "b"),
+					pathMatch("myRepo", "cmd/main.go", 1),
+					symbolMatch("myRepo", "src/index.ts", 1, "a"),
+					pathMatch("myRepo", "LICENSE", 1),
+				},
+			},
+			autogold.Want("Count languages", map[string]int{"Go": 3, "TypeScript": 1}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode, 0)
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
+func TestFileExtensionAggregation(t *testing.T) {
+	testCases := []struct {
+		mode        types.SearchAggregationMode
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{types.FILE_EXTENSION_AGGREGATION_MODE, streaming.SearchEvent{}, autogold.Want("No results", map[string]int{})},
+		{
+			types.FILE_EXTENSION_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "file.go", 1, "a", "b"),
+					pathMatch("myRepo", "cmd/main.go", 1),
+					pathMatch("myRepo", "client/.eslintrc.js", 1),
+					pathMatch("myRepo", "Makefile", 1),
+					repoMatch("myRepo", 1),
+				},
+			},
+			autogold.Want("Count extensions", map[string]int{".go": 3, ".js": 1}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode, 0)
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
+func TestDirectoryDepthAggregation(t *testing.T) {
+	results := []result.Match{
+		contentMatch("myRepo", "README.md", 1, "a"),
+		contentMatch("myRepo", "cmd/frontend/main.go", 1, "a", "b"),
+		pathMatch("myRepo", "cmd/frontend/internal/app.go", 1),
+		pathMatch("myRepo", "cmd/gitserver/main.go", 1),
+		symbolMatch("myRepo", "lib/errors.go", 1, "a"),
+		repoMatch("myRepo", 1),
+	}
+	testCases := []struct {
+		depth int
+		want  autogold.Value
+	}{
+		{1, autogold.Want("depth 1", map[string]int{"/": 1, "cmd": 4, "lib": 1})},
+		{2, autogold.Want("depth 2", map[string]int{"/": 1, "cmd/frontend": 3, "cmd/gitserver": 1, "lib": 1})},
+		{3, autogold.Want("depth 3", map[string]int{
+			"/": 1, "cmd/frontend": 2, "cmd/frontend/internal": 1,
+			"cmd/gitserver": 1, "lib": 1,
+		})},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, err := GetCountFuncForMode("", "", types.DIRECTORY_DEPTH_AGGREGATION_MODE, tc.depth)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(streaming.SearchEvent{Results: results})
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+
+	t.Run("invalid depth", func(t *testing.T) {
+		if _, err := GetCountFuncForMode("", "", types.DIRECTORY_DEPTH_AGGREGATION_MODE, 0); err == nil {
+			t.Error("expected error for depth 0")
+		}
+	})
+}
+
+func TestCommitMonthAggregation(t *testing.T) {
+	testCases := []struct {
+		mode        types.SearchAggregationMode
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{types.COMMIT_MONTH_AGGREGATION_MODE, streaming.SearchEvent{}, autogold.Want("No results", map[string]int{})},
+		{
+			types.COMMIT_MONTH_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "file.go", 1, "a", "b"),
+					pathMatch("myRepo", "file.go", 1),
+				},
+			},
+			autogold.Want("no month for file matches", map[string]int{}),
+		},
+		{
+			types.COMMIT_MONTH_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+					commitMatch("repoA", "Author B", sampleDate.AddDate(0, 0, 20), 1, 2, "a"),
+					commitMatch("repoB", "Author B", sampleDate.AddDate(0, 1, 0), 2, 2, "a"),
+					commitMatch("repoB", "Author C", sampleDate.Add(-time.Hour), 2, 2, "a"),
+				},
+			},
+			autogold.Want("counts by month", map[string]int{"2022-03": 2, "2022-04": 4, "2022-05": 2}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode, 0)
 			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
 			sra.Send(tc.searchEvent)
 			tc.want.Equal(t, aggregator.results)
@@ -588,7 +742,7 @@ func TestCaptureGroupAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, err := GetCountFuncForMode(tc.query, "regexp", tc.mode)
+			countFunc, err := GetCountFuncForMode(tc.query, "regexp", tc.mode, 0)
 			if err != nil {
 				t.Errorf("expected test not to error, got %v", err)
 				t.FailNow()
@@ -624,7 +778,7 @@ func TestAggregationCancelation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, err := GetCountFuncForMode(tc.query, "regexp", tc.mode)
+			countFunc, err := GetCountFuncForMode(tc.query, "regexp", tc.mode, 0)
 			if err != nil {
 				t.Errorf("expected test not to error, got %v", err)
 				t.FailNow()
@@ -0,0 +1,127 @@
+// Package alerts evaluates the threshold alert rules of insight series when new data points are recorded,
+// and notifies users through the same email, Slack and webhook delivery code monitors use.
+package alerts
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Evaluator evaluates the alert rules of a series against its recorded data points.
+type Evaluator struct {
+	logger          log.Logger
+	db              database.DB
+	alertStore      *store.AlertStore
+	timeseriesStore *store.Store
+	doer            httpcli.Doer
+}
+
+func NewEvaluator(logger log.Logger, db database.DB, insightsDB edb.InsightsDB, timeseriesStore *store.Store) *Evaluator {
+	return &Evaluator{
+		logger:          logger,
+		db:              db,
+		alertStore:      store.NewAlertStore(insightsDB),
+		timeseriesStore: timeseriesStore,
+		doer:            httpcli.ExternalDoer,
+	}
+}
+
+// maxEvaluatedPoints is the maximum number of the most recent data points of a series that are evaluated
+// at once. A backfill records a data point per interval of the series, which are far fewer.
+const maxEvaluatedPoints = 100
+
+// EvaluateSeries evaluates every alert rule of the series against the data points recorded since the rule
+// was last evaluated, oldest first, and sends notifications for the rules that fire. Each rule is evaluated
+// at most once per data point, so calling this for data points that were already evaluated is a no-op.
+func (e *Evaluator) EvaluateSeries(ctx context.Context, series *types.InsightSeries) error {
+	rules, err := e.alertStore.ListAlertRules(ctx, series.ID)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	points, err := e.timeseriesStore.LatestRecordedPoints(ctx, series.SeriesID, maxEvaluatedPoints)
+	if err != nil {
+		return errors.Wrap(err, "LatestRecordedPoints")
+	}
+	// The points are returned newest first.
+	for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+		points[i], points[j] = points[j], points[i]
+	}
+
+	var errs error
+	for _, rule := range rules {
+		if err := e.evaluateRule(ctx, rule, series, points); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "alert rule %d", rule.ID))
+		}
+	}
+	return errs
+}
+
+// evaluateRule evaluates the rule against the given points, oldest first, that are more recent than the last
+// point it was evaluated against. It stops at the first point for which the notification couldn't be sent,
+// so that this point and the following ones are evaluated again on the next call.
+func (e *Evaluator) evaluateRule(ctx context.Context, rule *types.AlertRule, series *types.InsightSeries, points []store.SeriesPoint) error {
+	for i, point := range points {
+		if rule.LastPointTime != nil && !point.Time.After(*rule.LastPointTime) {
+			continue
+		}
+
+		var previous *float64
+		if i > 0 {
+			previous = &points[i-1].Value
+		} else if len(points) == maxEvaluatedPoints {
+			// The point before this one wasn't loaded, so it can't be evaluated.
+			continue
+		}
+
+		if err := e.evaluateRulePoint(ctx, rule, series, previous, point); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evaluateRulePoint evaluates the rule against a single data point and sends a notification if it fires.
+// The point is claimed in the same transaction in which the notification is sent, so the claim is rolled
+// back if sending the notification failed.
+func (e *Evaluator) evaluateRulePoint(ctx context.Context, rule *types.AlertRule, series *types.InsightSeries, previous *float64, point store.SeriesPoint) (err error) {
+	tx, err := e.alertStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	claimed, err := tx.ClaimAlertRulePoint(ctx, rule.ID, point.Time)
+	if err != nil || !claimed || !Evaluate(*rule, previous, point.Value) {
+		return err
+	}
+
+	e.logger.Debug("insight series alert rule triggered",
+		log.Int("ruleID", rule.ID),
+		log.String("seriesID", series.SeriesID),
+		log.String("kind", string(rule.Kind)),
+	)
+	if err := tx.MarkAlertRuleTriggered(ctx, rule.ID); err != nil {
+		return err
+	}
+	if err := e.notify(ctx, alert{
+		Rule:     rule,
+		Series:   series,
+		Previous: previous,
+		Latest:   point,
+	}); err != nil {
+		return errors.Wrapf(err, "notify for data point at %s", point.Time)
+	}
+	return nil
+}
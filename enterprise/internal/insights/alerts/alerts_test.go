@@ -0,0 +1,137 @@
+package alerts
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+
+	cmbackground "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/background"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+func TestEvaluateSeries_NotificationFailed(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	now := time.Now().Truncate(time.Second)
+
+	cmbackground.MockExternalURL = func() *url.URL {
+		return &url.URL{Scheme: "https", Host: "sourcegraph.example.com"}
+	}
+	t.Cleanup(func() { cmbackground.MockExternalURL = nil })
+
+	series, err := store.NewInsightStore(insightsDB).CreateSeries(ctx, types.InsightSeries{
+		SeriesID:            "series1",
+		Query:               "query1",
+		CreatedAt:           now,
+		OldestHistoricalAt:  now,
+		LastRecordedAt:      now,
+		NextRecordingAfter:  now,
+		LastSnapshotAt:      now,
+		NextSnapshotAfter:   now,
+		SampleIntervalUnit:  string(types.Month),
+		SampleIntervalValue: 1,
+		GenerationMethod:    types.Search,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timeseriesStore := store.New(insightsDB, store.NewInsightPermissionStore(postgres))
+	if err := timeseriesStore.RecordSeriesPoints(ctx, []store.RecordSeriesPointArgs{
+		{SeriesID: series.SeriesID, Point: store.SeriesPoint{Time: now.Add(-time.Hour), Value: 5}, PersistMode: store.RecordMode},
+		{SeriesID: series.SeriesID, Point: store.SeriesPoint{Time: now, Value: 15}, PersistMode: store.RecordMode},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	alertStore := store.NewAlertStore(insightsDB)
+	webhookURL := "https://example.com/webhook"
+	rule, err := alertStore.CreateAlertRule(ctx, store.CreateAlertRuleArgs{
+		SeriesID:   series.ID,
+		Kind:       types.AlertAbove,
+		Threshold:  10,
+		WebhookURL: &webhookURL,
+		CreatedBy:  1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var webhookStatus, webhookCalls int
+	evaluator := NewEvaluator(logger, postgres, insightsDB, timeseriesStore)
+	evaluator.doer = httpcli.DoerFunc(func(*http.Request) (*http.Response, error) {
+		webhookCalls++
+		return &http.Response{
+			StatusCode: webhookStatus,
+			Status:     http.StatusText(webhookStatus),
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil
+	})
+
+	getRule := func() *types.AlertRule {
+		t.Helper()
+		r, err := alertStore.GetAlertRule(ctx, rule.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+
+	// The first point doesn't fire, so it is claimed. The second one fires, but the
+	// notification fails, so it must not be claimed.
+	webhookStatus = http.StatusInternalServerError
+	if err := evaluator.EvaluateSeries(ctx, &series); err == nil {
+		t.Fatal("expected error when the notification fails")
+	}
+	if webhookCalls != 1 {
+		t.Fatalf("unexpected number of webhook calls. want=%d have=%d", 1, webhookCalls)
+	}
+	r := getRule()
+	if r.LastPointTime == nil || !r.LastPointTime.Equal(now.Add(-time.Hour)) {
+		t.Fatalf("unexpected last point time. want=%s have=%v", now.Add(-time.Hour), r.LastPointTime)
+	}
+	if r.LastTriggeredAt != nil {
+		t.Fatalf("unexpected last triggered time %s", *r.LastTriggeredAt)
+	}
+
+	// The failed point is evaluated again, and claimed once the notification is sent.
+	webhookStatus = http.StatusOK
+	if err := evaluator.EvaluateSeries(ctx, &series); err != nil {
+		t.Fatal(err)
+	}
+	if webhookCalls != 2 {
+		t.Fatalf("unexpected number of webhook calls. want=%d have=%d", 2, webhookCalls)
+	}
+	r = getRule()
+	if r.LastPointTime == nil || !r.LastPointTime.Equal(now) {
+		t.Fatalf("unexpected last point time. want=%s have=%v", now, r.LastPointTime)
+	}
+	if r.LastTriggeredAt == nil {
+		t.Fatal("expected rule to be marked as triggered")
+	}
+
+	// All points have been evaluated now.
+	if err := evaluator.EvaluateSeries(ctx, &series); err != nil {
+		t.Fatal(err)
+	}
+	if webhookCalls != 2 {
+		t.Fatalf("unexpected number of webhook calls. want=%d have=%d", 2, webhookCalls)
+	}
+}
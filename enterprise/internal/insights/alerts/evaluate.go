@@ -0,0 +1,97 @@
+package alerts
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ValidateRule returns an error if a rule of the given kind can't be evaluated with threshold.
+func ValidateRule(kind types.AlertRuleKind, threshold float64) error {
+	if math.IsNaN(threshold) || math.IsInf(threshold, 0) {
+		return errors.New("threshold must be a finite number")
+	}
+	switch kind {
+	case types.AlertAbove, types.AlertBelow, types.AlertCrossesZero:
+		return nil
+	case types.AlertPercentChange:
+		if threshold <= 0 {
+			return errors.New("threshold of a percentage change rule must be greater than zero")
+		}
+		return nil
+	default:
+		return errors.Newf("unknown alert rule kind %q", kind)
+	}
+}
+
+// Evaluate returns true if the rule fires for the latest data point of a series. previous is the value of
+// the data point before it, or nil if latest is the first data point of the series.
+//
+// ABOVE and BELOW rules only fire when the value crosses the threshold, so a series that stays above the
+// threshold notifies once rather than on every data point.
+func Evaluate(rule types.AlertRule, previous *float64, latest float64) bool {
+	switch rule.Kind {
+	case types.AlertAbove:
+		return latest > rule.Threshold && (previous == nil || *previous <= rule.Threshold)
+	case types.AlertBelow:
+		return latest < rule.Threshold && (previous == nil || *previous >= rule.Threshold)
+	case types.AlertPercentChange:
+		if previous == nil {
+			return false
+		}
+		return percentChange(*previous, latest) >= rule.Threshold
+	case types.AlertCrossesZero:
+		if previous == nil {
+			return false
+		}
+		return sign(*previous) != sign(latest)
+	}
+	return false
+}
+
+// Describe returns a human readable description of why the rule fired.
+func Describe(rule types.AlertRule, previous *float64, latest float64) string {
+	switch rule.Kind {
+	case types.AlertAbove:
+		return fmt.Sprintf("rose to %s, above the threshold of %s", formatValue(latest), formatValue(rule.Threshold))
+	case types.AlertBelow:
+		return fmt.Sprintf("dropped to %s, below the threshold of %s", formatValue(latest), formatValue(rule.Threshold))
+	case types.AlertPercentChange:
+		if previous != nil {
+			return fmt.Sprintf("changed by more than %s%% from %s to %s", formatValue(rule.Threshold), formatValue(*previous), formatValue(latest))
+		}
+	case types.AlertCrossesZero:
+		if previous != nil {
+			return fmt.Sprintf("crossed zero from %s to %s", formatValue(*previous), formatValue(latest))
+		}
+	}
+	return fmt.Sprintf("is %s", formatValue(latest))
+}
+
+// percentChange returns the absolute change from previous to latest in percent of previous. A change
+// from zero to any other value is an infinite change.
+func percentChange(previous, latest float64) float64 {
+	if previous == latest {
+		return 0
+	}
+	if previous == 0 {
+		return math.Inf(1)
+	}
+	return math.Abs(latest-previous) / math.Abs(previous) * 100
+}
+
+func sign(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
+
+func formatValue(v float64) string {
+	return fmt.Sprintf("%g", v)
+}
@@ -0,0 +1,73 @@
+package alerts
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestEvaluate(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	testCases := []struct {
+		name      string
+		kind      types.AlertRuleKind
+		threshold float64
+		previous  *float64
+		latest    float64
+		want      bool
+	}{
+		{name: "above first point", kind: types.AlertAbove, threshold: 10, latest: 11, want: true},
+		{name: "above crossing", kind: types.AlertAbove, threshold: 10, previous: f(10), latest: 11, want: true},
+		{name: "above staying above", kind: types.AlertAbove, threshold: 10, previous: f(12), latest: 11, want: false},
+		{name: "above at threshold", kind: types.AlertAbove, threshold: 10, previous: f(5), latest: 10, want: false},
+		{name: "below crossing", kind: types.AlertBelow, threshold: 10, previous: f(10), latest: 9, want: true},
+		{name: "below staying below", kind: types.AlertBelow, threshold: 10, previous: f(8), latest: 9, want: false},
+		{name: "below rising", kind: types.AlertBelow, threshold: 10, previous: f(8), latest: 12, want: false},
+		{name: "percent change first point", kind: types.AlertPercentChange, threshold: 10, latest: 100, want: false},
+		{name: "percent change increase", kind: types.AlertPercentChange, threshold: 10, previous: f(100), latest: 110, want: true},
+		{name: "percent change decrease", kind: types.AlertPercentChange, threshold: 10, previous: f(100), latest: 85, want: true},
+		{name: "percent change too small", kind: types.AlertPercentChange, threshold: 10, previous: f(100), latest: 105, want: false},
+		{name: "percent change from zero", kind: types.AlertPercentChange, threshold: 50, previous: f(0), latest: 1, want: true},
+		{name: "percent change zero to zero", kind: types.AlertPercentChange, threshold: 50, previous: f(0), latest: 0, want: false},
+		{name: "crosses zero first point", kind: types.AlertCrossesZero, latest: 3, want: false},
+		{name: "crosses zero from zero", kind: types.AlertCrossesZero, previous: f(0), latest: 3, want: true},
+		{name: "crosses zero to zero", kind: types.AlertCrossesZero, previous: f(3), latest: 0, want: true},
+		{name: "crosses zero negative", kind: types.AlertCrossesZero, previous: f(2), latest: -1, want: true},
+		{name: "crosses zero stays positive", kind: types.AlertCrossesZero, previous: f(2), latest: 5, want: false},
+		{name: "unknown kind", kind: types.AlertRuleKind("NOPE"), previous: f(2), latest: 5, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := types.AlertRule{Kind: tc.kind, Threshold: tc.threshold}
+			if got := Evaluate(rule, tc.previous, tc.latest); got != tc.want {
+				t.Errorf("unexpected result: want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateRule(t *testing.T) {
+	testCases := []struct {
+		kind      types.AlertRuleKind
+		threshold float64
+		wantErr   bool
+	}{
+		{kind: types.AlertAbove, threshold: -5},
+		{kind: types.AlertBelow, threshold: 0},
+		{kind: types.AlertCrossesZero},
+		{kind: types.AlertPercentChange, threshold: 25},
+		{kind: types.AlertPercentChange, threshold: 0, wantErr: true},
+		{kind: types.AlertRuleKind("NOPE"), wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.kind), func(t *testing.T) {
+			err := ValidateRule(tc.kind, tc.threshold)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("unexpected error: want error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
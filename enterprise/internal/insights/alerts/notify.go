@@ -0,0 +1,127 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	cmbackground "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/background"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const utmSource = "code-insights-alert"
+
+// alert is a rule that fired for a data point of a series. Latest is the data point, and Previous the
+// value of the data point before it.
+type alert struct {
+	Rule     *types.AlertRule
+	Series   *types.InsightSeries
+	Previous *float64
+	Latest   store.SeriesPoint
+}
+
+func (e *Evaluator) notify(ctx context.Context, a alert) error {
+	externalURL, err := cmbackground.GetExternalURL(ctx)
+	if err != nil {
+		return errors.Wrap(err, "GetExternalURL")
+	}
+	insightsURL := cmbackground.SourcegraphURL(externalURL, "insights", "", utmSource)
+	description := Describe(*a.Rule, a.Previous, a.Latest.Value)
+
+	var errs error
+	if a.Rule.NotifyEmail {
+		data := templateData{
+			Query:       a.Series.Query,
+			Description: description,
+			InsightsURL: insightsURL,
+		}
+		if err := cmbackground.SendEmail(ctx, e.db, a.Rule.CreatedBy, emailTemplates, data); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "email"))
+		}
+	}
+	if a.Rule.SlackWebhookURL != nil && *a.Rule.SlackWebhookURL != "" {
+		msg := slackPayload(a.Series.Query, description, insightsURL)
+		if err := cmbackground.PostSlackWebhook(ctx, e.doer, *a.Rule.SlackWebhookURL, msg); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "Slack webhook"))
+		}
+	}
+	if a.Rule.WebhookURL != nil && *a.Rule.WebhookURL != "" {
+		if err := cmbackground.PostWebhook(ctx, e.doer, *a.Rule.WebhookURL, generateWebhookPayload(a, insightsURL)); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "webhook"))
+		}
+	}
+	return errs
+}
+
+type templateData struct {
+	Query       string
+	Description string
+	InsightsURL string
+}
+
+var emailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Sourcegraph code insight series {{.Query}} {{.Description}}`,
+	Text: `
+The Sourcegraph code insight series {{.Query}} {{.Description}}.
+
+View your code insights: {{.InsightsURL}}
+
+__
+You are receiving this notification because you created an alert rule on this code insight series.
+`,
+	HTML: `
+<p>
+  The Sourcegraph code insight series <code>{{.Query}}</code> {{.Description}}.
+</p>
+
+<p>
+  <a href="{{.InsightsURL}}">View your code insights</a>
+</p>
+
+<p style="color: #5E6E8C">
+  You are receiving this notification because you created an alert rule on this code insight series.
+</p>
+`,
+})
+
+func slackPayload(query, description, insightsURL string) *slack.WebhookMessage {
+	newMarkdownSection := func(s string) slack.Block {
+		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
+	}
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: []slack.Block{
+		newMarkdownSection(fmt.Sprintf("The Sourcegraph code insight series `%s` %s.", query, description)),
+		newMarkdownSection(fmt.Sprintf("<%s|View your code insights>", insightsURL)),
+	}}}
+}
+
+type webhookPayload struct {
+	SeriesID    string    `json:"seriesId"`
+	Query       string    `json:"query"`
+	Rule        string    `json:"rule"`
+	Threshold   float64   `json:"threshold"`
+	Previous    *float64  `json:"previousValue,omitempty"`
+	Value       float64   `json:"value"`
+	Time        time.Time `json:"time"`
+	Description string    `json:"description"`
+	InsightsURL string    `json:"insightsURL"`
+}
+
+func generateWebhookPayload(a alert, insightsURL string) webhookPayload {
+	return webhookPayload{
+		SeriesID:    a.Series.SeriesID,
+		Query:       a.Series.Query,
+		Rule:        string(a.Rule.Kind),
+		Threshold:   a.Rule.Threshold,
+		Previous:    a.Previous,
+		Value:       a.Latest.Value,
+		Time:        a.Latest.Time,
+		Description: Describe(*a.Rule, a.Previous, a.Latest.Value),
+		InsightsURL: insightsURL,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/keegancsmith/sqlf"
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/background/queryrunner"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -15,37 +16,60 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-// Periodically check for series that have been backfilled since the last check and set timestamps for them.
-func NewBackfillCompletedCheckJob(ctx context.Context, postgres database.DB, insightsdb edb.InsightsDB) goroutine.BackgroundRoutine {
+// Periodically check for series that have been backfilled since the last check, set timestamps for them
+// and evaluate their alert rules against the backfilled data points.
+func NewBackfillCompletedCheckJob(ctx context.Context, postgres database.DB, insightsdb edb.InsightsDB, alertEvaluator queryrunner.AlertEvaluator) goroutine.BackgroundRoutine {
 	interval := time.Hour * 2
 
 	return goroutine.NewPeriodicGoroutine(ctx, interval,
 		goroutine.NewHandlerWithErrorMessage("insights_backill_completed_check", func(ctx context.Context) (err error) {
-			return checkBackfillCompleted(ctx, postgres, insightsdb)
+			return checkBackfillCompleted(ctx, postgres, insightsdb, alertEvaluator)
 		}))
 }
 
-func checkBackfillCompleted(ctx context.Context, postgres database.DB, insightsdb edb.InsightsDB) (err error) {
+func checkBackfillCompleted(ctx context.Context, postgres database.DB, insightsdb edb.InsightsDB, alertEvaluator queryrunner.AlertEvaluator) error {
+	completed, err := stampBackfillCompleted(ctx, postgres, insightsdb)
+	if err != nil {
+		return err
+	}
+	if alertEvaluator == nil {
+		return nil
+	}
+
+	// The data points recorded by the historical jobs of a series are partial sums until all of them
+	// completed, so the alert rules of the series are evaluated against them only now.
+	var errs error
+	for i := range completed {
+		if err := alertEvaluator.EvaluateSeries(ctx, &completed[i]); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "evaluating alert rules of series %s", completed[i].SeriesID))
+		}
+	}
+	return errs
+}
+
+// stampBackfillCompleted sets the backfill completed timestamp of the series whose historical jobs all
+// completed, and returns these series.
+func stampBackfillCompleted(ctx context.Context, postgres database.DB, insightsdb edb.InsightsDB) (_ []types.InsightSeries, err error) {
 	insightStore := store.NewInsightStore(insightsdb)
 	insightTx, err := insightStore.Transact(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() { err = insightTx.Done(err) }()
 
 	// First select all series ids for which backfill has not yet been marked as complete.
 	series, err := insightStore.GetDataSeries(ctx, store.GetDataSeriesArgs{BackfillNotComplete: true})
 	if err != nil {
-		return errors.Wrap(err, "GetSeriesIdsBackfillNotComplete")
+		return nil, errors.Wrap(err, "GetSeriesIdsBackfillNotComplete")
 	}
 	if len(series) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	// Query the jobs queue to find out the status of the series.
 	statusRows, err := getStatusRows(ctx, series, postgres)
 	if err != nil {
-		return errors.Wrap(err, "getStatusRows")
+		return nil, errors.Wrap(err, "getStatusRows")
 	}
 
 	lastCompletedJob := make(map[string]time.Time)
@@ -61,17 +85,23 @@ func checkBackfillCompleted(ctx context.Context, postgres database.DB, insightsd
 	}
 
 	// For each series that has completed jobs and is not stil in progress, stamp it.
-	for seriesId, timestamp := range lastCompletedJob {
-		if _, ok := inProgressSeries[seriesId]; ok {
+	var completed []types.InsightSeries
+	for _, s := range series {
+		timestamp, ok := lastCompletedJob[s.SeriesID]
+		if !ok {
+			continue
+		}
+		if _, ok := inProgressSeries[s.SeriesID]; ok {
 			continue
 		}
-		err = insightTx.SetSeriesBackfillComplete(ctx, seriesId, timestamp)
+		err = insightTx.SetSeriesBackfillComplete(ctx, s.SeriesID, timestamp)
 		if err != nil {
-			return errors.Wrap(err, "SetSeriesBackfillComplete")
+			return nil, errors.Wrap(err, "SetSeriesBackfillComplete")
 		}
+		completed = append(completed, s)
 	}
 
-	return nil
+	return completed, nil
 }
 
 func getStatusRows(ctx context.Context, series []types.InsightSeries, postgres database.DB) ([]JobStatus, error) {
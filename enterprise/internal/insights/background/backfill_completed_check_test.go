@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/log/logtest"
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 )
@@ -57,7 +58,7 @@ func TestCheckBackfillCompleted(t *testing.T) {
 	}
 
 	t.Run("Does nothing if there are no series", func(t *testing.T) {
-		err := checkBackfillCompleted(ctx, postgres, insightsDB)
+		err := checkBackfillCompleted(ctx, postgres, insightsDB, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -78,7 +79,7 @@ func TestCheckBackfillCompleted(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		err = checkBackfillCompleted(ctx, postgres, insightsDB)
+		err = checkBackfillCompleted(ctx, postgres, insightsDB, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -106,7 +107,7 @@ func TestCheckBackfillCompleted(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		err = checkBackfillCompleted(ctx, postgres, insightsDB)
+		err = checkBackfillCompleted(ctx, postgres, insightsDB, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -142,7 +143,8 @@ func TestCheckBackfillCompleted(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		err = checkBackfillCompleted(ctx, postgres, insightsDB)
+		evaluator := &recordingAlertEvaluator{}
+		err = checkBackfillCompleted(ctx, postgres, insightsDB, evaluator)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -158,6 +160,10 @@ func TestCheckBackfillCompleted(t *testing.T) {
 			{SeriesId: "1", BackfillCompletedAt: now.UTC()},
 			{SeriesId: "2", BackfillCompletedAt: now.Add(time.Minute * 10).UTC()},
 		})
+
+		// The alert rules of the series are evaluated once their backfill completed.
+		sort.Strings(evaluator.seriesIDs)
+		autogold.Want("EvaluatedSeries", evaluator.seriesIDs).Equal(t, []string{"1", "2"})
 	})
 	t.Run("Does not panic if finished_at is null on a completed job", func(t *testing.T) {
 		resetDatabase()
@@ -172,7 +178,7 @@ func TestCheckBackfillCompleted(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		err = checkBackfillCompleted(ctx, postgres, insightsDB)
+		err = checkBackfillCompleted(ctx, postgres, insightsDB, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -185,6 +191,16 @@ func TestCheckBackfillCompleted(t *testing.T) {
 	})
 }
 
+// recordingAlertEvaluator records the series whose alert rules are evaluated.
+type recordingAlertEvaluator struct {
+	seriesIDs []string
+}
+
+func (e *recordingAlertEvaluator) EvaluateSeries(_ context.Context, series *types.InsightSeries) error {
+	e.seriesIDs = append(e.seriesIDs, series.SeriesID)
+	return nil
+}
+
 type SeriesBackfillStatus struct {
 	SeriesId            string
 	BackfillCompletedAt time.Time
@@ -12,10 +12,13 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/alerts"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/background/pings"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/background/queryrunner"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/compression"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/discovery"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/pipeline"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/scheduler"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -45,6 +48,7 @@ func GetBackgroundJobs(ctx context.Context, logger log.Logger, mainAppDB databas
 
 	insightsMetadataStore := store.NewInsightStore(insightsDB)
 	featureFlagStore := mainAppDB.FeatureFlags()
+	alertEvaluator := alerts.NewEvaluator(logger.Scoped("alerts", "insight series alert rules"), mainAppDB, insightsDB, insightsStore)
 
 	// Start background goroutines for all of our workers.
 	// The query runner worker is started in a separate routine so it can benefit from horizontal scaling.
@@ -77,7 +81,7 @@ func GetBackgroundJobs(ctx context.Context, logger log.Logger, mainAppDB databas
 		pings.NewInsightsPingEmitterJob(ctx, mainAppDB, insightsDB),
 		NewInsightsDataPrunerJob(ctx, mainAppDB, insightsDB),
 		NewLicenseCheckJob(ctx, mainAppDB, insightsDB),
-		NewBackfillCompletedCheckJob(ctx, mainAppDB, insightsDB),
+		NewBackfillCompletedCheckJob(ctx, mainAppDB, insightsDB, alertEvaluator),
 	)
 
 	return routines
@@ -103,14 +107,35 @@ func GetBackgroundQueryRunnerJob(ctx context.Context, logger log.Logger, mainApp
 	queryRunnerWorkerMetrics, queryRunnerResetterMetrics := newWorkerMetrics(observationContext, "query_runner_worker")
 
 	workerStore := queryrunner.CreateDBWorkerStore(workerBaseStore, observationContext)
+	alertEvaluator := alerts.NewEvaluator(logger.Scoped("alerts", "insight series alert rules"), mainAppDB, insightsDB, insightsStore)
 
-	return []goroutine.BackgroundRoutine{
+	routines := []goroutine.BackgroundRoutine{
 		// Register the query-runner worker and resetter, which executes search queries and records
 		// results to the insights DB.
-		queryrunner.NewWorker(ctx, logger.Scoped("queryrunner.Worker", ""), workerStore, insightsStore, repoStore, queryRunnerWorkerMetrics),
+		queryrunner.NewWorker(ctx, logger.Scoped("queryrunner.Worker", ""), workerStore, insightsStore, repoStore, alertEvaluator, queryRunnerWorkerMetrics),
 		queryrunner.NewResetter(ctx, logger.Scoped("queryrunner.Resetter", ""), workerStore, queryRunnerResetterMetrics),
 		queryrunner.NewCleaner(ctx, workerBaseStore, observationContext),
 	}
+	// this flag will allow users to ENABLE the new backfill scheduler and its workers. It is disabled by default
+	// until the scheduler replaces the historical enqueuer.
+	if enableBackfiller, _ := strconv.ParseBool(os.Getenv("ENABLE_CODE_INSIGHTS_BACKFILLER_V2")); enableBackfiller {
+		monitor := scheduler.NewBackgroundJobMonitor(ctx, scheduler.JobMonitorConfig{
+			InsightsDB: insightsDB,
+			RepoStore:  repoStore,
+			BackfillRunner: pipeline.NewDefaultBackfiller(pipeline.BackfillerConfig{
+				CommitClient:            pipeline.NewGitCommitClient(mainAppDB),
+				RepoStore:               repoStore,
+				CompressionPlan:         &compression.NoopFilter{},
+				SearchHandlers:          queryrunner.GetSearchHandlers(),
+				InsightStore:            insightsStore,
+				SearchPlanWorkerLimit:   1,
+				SearchRunnerWorkerLimit: 5,
+			}),
+			ObsContext: observationContext,
+		})
+		routines = append(routines, monitor.Routines()...)
+	}
+	return routines
 }
 
 // newWorkerMetrics returns a basic set of metrics to be used for a worker and its resetter:
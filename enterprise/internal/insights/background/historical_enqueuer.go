@@ -228,6 +228,7 @@ func baseAnalyzer(frontend database.DB, statistics statistics) backfillAnalyzer
 		gitFindRecentCommit: func(ctx context.Context, repoName api.RepoName, target time.Time) ([]*gitdomain.Commit, error) {
 			return gitserver.NewClient(frontend).Commits(ctx, repoName, gitserver.CommitsOptions{N: 1, Before: target.Format(time.RFC3339), DateOrder: true}, authz.DefaultSubRepoPermsChecker)
 		},
+		getRepo: frontend.Repos().Get,
 	}
 }
 
@@ -348,6 +349,7 @@ type historicalEnqueuer struct {
 type backfillAnalyzer struct {
 	gitFirstEverCommit  func(ctx context.Context, db database.DB, repoName api.RepoName) (*gitdomain.Commit, error)
 	gitFindRecentCommit func(ctx context.Context, repoName api.RepoName, target time.Time) ([]*gitdomain.Commit, error)
+	getRepo             func(ctx context.Context, id api.RepoID) (*types.Repo, error)
 	statistics          statistics
 	frameFilter         compression.DataFrameFilter
 	limiter             *ratelimit.InstrumentedLimiter
@@ -535,6 +537,9 @@ func (a *backfillAnalyzer) buildForRepo(ctx context.Context, definitions []itype
 		return nil, nil, softErr
 	}
 
+	// The repository metadata is only needed by series using the repo-metadata generation method.
+	var repo *types.Repo
+
 	// For every series that we want to potentially gather historical data for, try.
 	for _, series := range definitions {
 		frames := timeseries.BuildFrames(12, timeseries.TimeInterval{
@@ -543,7 +548,23 @@ func (a *backfillAnalyzer) buildForRepo(ctx context.Context, definitions []itype
 		}, series.CreatedAt.Truncate(time.Hour*24))
 
 		log15.Debug("insights: starting frames", "repo_id", id, "series_id", series.SeriesID, "frames", frames)
-		plan := a.frameFilter.FilterFrames(ctx, frames, id)
+		var plan compression.BackfillPlan
+		if series.GenerationMethod == itypes.RepoMetadata {
+			if repo == nil {
+				var getErr error
+				repo, getErr = a.getRepo(ctx, id)
+				if getErr != nil {
+					softErr = errors.Append(softErr, errors.Wrap(getErr, "GetRepo "+repoName))
+					a.statistics[series.SeriesID].Errored += 1
+					continue
+				}
+			}
+			// Repository metadata isn't versioned by commit, so every frame is
+			// evaluated against the state of the repository at that time.
+			plan = (&compression.NoopFilter{}).FilterFrames(ctx, frames, id)
+		} else {
+			plan = a.frameFilter.FilterFrames(ctx, frames, id)
+		}
 		if len(frames) != len(plan.Executions) {
 			a.statistics[series.SeriesID].Compressed += 1
 			log15.Debug("compressed frames", "repo_id", id, "series_id", series.SeriesID, "plan", plan)
@@ -559,14 +580,21 @@ func (a *backfillAnalyzer) buildForRepo(ctx context.Context, definitions []itype
 			}
 
 			// Build historical data for this unique timeframe+repo+series.
-			err, job := a.analyzeSeries(ctx, &buildSeriesContext{
+			bctx := &buildSeriesContext{
 				execution:       queryExecution,
 				repoName:        api.RepoName(repoName),
 				id:              id,
 				firstHEADCommit: firstHEADCommit,
+				repo:            repo,
 				seriesID:        series.SeriesID,
 				series:          series,
-			})
+			}
+			var job *queryrunner.Job
+			if series.GenerationMethod == itypes.RepoMetadata {
+				err, job = a.analyzeRepoMetadataSeries(ctx, bctx)
+			} else {
+				err, job = a.analyzeSeries(ctx, bctx)
+			}
 			if err != nil {
 				softErr = errors.Append(softErr, err)
 				a.statistics[series.SeriesID].Errored += 1
@@ -594,6 +622,9 @@ type buildSeriesContext struct {
 	// The first commit made in the repository on the default branch.
 	firstHEADCommit *gitdomain.Commit
 
+	// The repository metadata, only set for the repo-metadata generation method.
+	repo *types.Repo
+
 	// The series we're building historical data for.
 	seriesID string
 	series   itypes.InsightSeries
@@ -701,6 +732,27 @@ func (a *backfillAnalyzer) analyzeSeries(ctx context.Context, bctx *buildSeriesC
 	return err, job
 }
 
+// analyzeRepoMetadataSeries is the equivalent of analyzeSeries for series using the repo-metadata
+// generation method (see querybuilder.RepoMetadataSearchQuery).
+func (a *backfillAnalyzer) analyzeRepoMetadataSeries(ctx context.Context, bctx *buildSeriesContext) (err error, job *queryrunner.Job) {
+	recordTime := bctx.execution.RecordingTime
+	defaultParams := querybuilder.CodeInsightsQueryDefaults(len(bctx.series.Repositories) == 0)
+	modifiedQuery, preempted, err := querybuilder.RepoMetadataSearchQuery(ctx, querybuilder.BasicQuery(bctx.series.Query), bctx.repo, recordTime, defaultParams, a.gitFindRecentCommit)
+	if err != nil {
+		return err, nil
+	}
+	if preempted {
+		a.statistics[bctx.seriesID].Preempted += 1
+		return nil, nil
+	}
+	if modifiedQuery == "" {
+		return nil, nil
+	}
+
+	job = queryrunner.ToQueueJob(bctx.execution, bctx.seriesID, modifiedQuery.String(), priority.Unindexed, priority.FromTimeInterval(recordTime, bctx.series.CreatedAt))
+	return nil, job
+}
+
 // cachedGitFirstEverCommit is a simple in-memory cache for gitFirstEverCommit calls. It does so
 // using a map, and entries are never evicted because they are expected to be small and in general
 // unchanging.
@@ -281,3 +281,61 @@ func Test_historicalEnqueuer(t *testing.T) {
 	})
 
 }
+
+func Test_buildForRepo_RepoMetadata(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	series := itypes.InsightSeries{
+		ID:                  1,
+		SeriesID:            "series1",
+		Query:               "type:repo repo:has(owner:platform)",
+		CreatedAt:           createdAt,
+		SampleIntervalUnit:  string(itypes.Month),
+		SampleIntervalValue: 1,
+		GenerationMethod:    itypes.RepoMetadata,
+	}
+
+	stats := make(statistics)
+	stats[series.SeriesID] = &repoBackfillStatistics{}
+	analyzer := backfillAnalyzer{
+		statistics:  stats,
+		frameFilter: &compression.NoopFilter{},
+		limiter:     ratelimit.NewInstrumentedLimiter("TestBuildForRepo", rate.NewLimiter(10, 1)),
+		gitFirstEverCommit: func(ctx context.Context, db database.DB, repoName api.RepoName) (*gitdomain.Commit, error) {
+			return &gitdomain.Commit{Author: gitdomain.Signature{Date: createdAt.AddDate(-2, 0, 0)}}, nil
+		},
+		gitFindRecentCommit: func(ctx context.Context, repoName api.RepoName, target time.Time) ([]*gitdomain.Commit, error) {
+			t.Fatal("repository metadata queries shouldn't need a commit")
+			return nil, nil
+		},
+		getRepo: func(ctx context.Context, id api.RepoID) (*types.Repo, error) {
+			// The repository was created 6 months ago and archived 3 months ago.
+			return &types.Repo{
+				ID:        id,
+				Name:      "repo/0",
+				Archived:  true,
+				CreatedAt: createdAt.AddDate(0, -6, 0),
+				UpdatedAt: createdAt.AddDate(0, -3, 0),
+			}, nil
+		},
+	}
+
+	jobs, err, softErr := analyzer.buildForRepo(ctx, []itypes.InsightSeries{series}, "repo/0", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if softErr != nil {
+		t.Fatal(softErr)
+	}
+
+	var have []string
+	for _, job := range jobs {
+		have = append(have, fmt.Sprintf("%s %s", job.RecordTime.Format(time.RFC3339), job.SearchQuery))
+	}
+	autogold.Want("only frames when the repository existed and wasn't archived", []string{
+		"2020-09-01T00:00:00Z fork:no patterntype:literal type:repo repo:has(owner:platform) archived:yes repo:^(repo/0)$",
+		"2020-08-01T00:00:00Z fork:no patterntype:literal type:repo repo:has(owner:platform) archived:yes repo:^(repo/0)$",
+		"2020-07-01T00:00:00Z fork:no patterntype:literal type:repo repo:has(owner:platform) archived:yes repo:^(repo/0)$",
+	}).Equal(t, have)
+}
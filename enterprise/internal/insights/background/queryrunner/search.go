@@ -50,6 +50,7 @@ func GetSearchHandlers() map[types.GenerationMethod]InsightsHandler {
 		types.MappingCompute: makeMappingComputeHandler(computeTextExtraSearch),
 		types.SearchCompute:  makeComputeHandler(computeSearchStream),
 		types.Search:         makeSearchHandler(searchStream),
+		types.RepoMetadata:   makeRepoMetadataHandler(searchStream),
 	}
 
 }
@@ -191,6 +192,21 @@ func makeSearchHandler(provider streamSearchProvider) InsightsHandler {
 	}
 }
 
+// makeRepoMetadataHandler records one point per matching repository, so that
+// series count repositories rather than matches.
+func makeRepoMetadataHandler(provider streamSearchProvider) InsightsHandler {
+	return func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error) {
+		recordings, err := generateSearchRecordingsStream(ctx, job, recordTime, provider)
+		if err != nil {
+			return nil, errors.Wrapf(err, "repoMetadataHandler")
+		}
+		for i := range recordings {
+			recordings[i].Point.Value = 1
+		}
+		return recordings, nil
+	}
+}
+
 func makeComputeHandler(provider streamComputeProvider) InsightsHandler {
 	return func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error) {
 		computeDelegate := func(ctx context.Context, job *SearchJob, recordTime time.Time) (_ []store.RecordSeriesPointArgs, err error) {
@@ -683,6 +683,48 @@ func TestGenerateSearchRecordingsStream(t *testing.T) {
 	})
 }
 
+func TestRepoMetadataHandler(t *testing.T) {
+	date := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	dependentDate := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+	job := SearchJob{
+		SeriesID:        "testseries1",
+		SearchQuery:     "type:repo repo:has(owner:platform)",
+		RecordTime:      &date,
+		PersistMode:     "record",
+		DependentFrames: []time.Time{dependentDate},
+	}
+
+	mocked := func(context.Context, string) (*streaming.TabulationResult, error) {
+		return &streaming.TabulationResult{
+			RepoCounts: map[string]*streaming.SearchMatch{
+				"github.com/sourcegraph/sourcegraph": {
+					RepositoryID:   11,
+					RepositoryName: "github.com/sourcegraph/sourcegraph",
+					MatchCount:     3,
+				},
+				"github.com/sourcegraph/handbook": {
+					RepositoryID:   5,
+					RepositoryName: "github.com/sourcegraph/handbook",
+					MatchCount:     1,
+				},
+			},
+			TotalCount: 4,
+		}, nil
+	}
+
+	handler := makeRepoMetadataHandler(mocked)
+	recordings, err := handler(context.Background(), &job, &types.InsightSeries{}, date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("repo metadata handler counts repositories", []string{
+		"github.com/sourcegraph/handbook 5 2021-11-01 00:00:00 +0000 UTC  1.000000",
+		"github.com/sourcegraph/handbook 5 2021-12-01 00:00:00 +0000 UTC  1.000000",
+		"github.com/sourcegraph/sourcegraph 11 2021-11-01 00:00:00 +0000 UTC  1.000000",
+		"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC  1.000000",
+	}).Equal(t, stringify(recordings))
+}
+
 func TestFilterRecordsingsByRepo(t *testing.T) {
 	date := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
 	repo1 := &dbtypes.Repo{ID: 1, Name: "repo1"}
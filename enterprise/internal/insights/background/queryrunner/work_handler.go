@@ -25,9 +25,10 @@ type workHandler struct {
 	baseWorkerStore *basestore.Store
 	insightsStore   *store.Store
 	repoStore       discovery.RepoStore
-	metadadataStore *store.InsightStore
+	metadadataStore store.DataSeriesStore
 	limiter         *ratelimit.InstrumentedLimiter
 	logger          log.Logger
+	alertEvaluator  AlertEvaluator
 
 	mu          sync.RWMutex
 	seriesCache map[string]*types.InsightSeries
@@ -35,6 +36,12 @@ type workHandler struct {
 	searchHandlers map[types.GenerationMethod]InsightsHandler
 }
 
+// AlertEvaluator evaluates the alert rules of a series against the data points recorded since they were
+// last evaluated.
+type AlertEvaluator interface {
+	EvaluateSeries(ctx context.Context, series *types.InsightSeries) error
+}
+
 type InsightsHandler func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error)
 
 func (r *workHandler) getSeries(ctx context.Context, seriesID string) (*types.InsightSeries, error) {
@@ -110,5 +117,37 @@ func (r *workHandler) Handle(ctx context.Context, logger log.Logger, record work
 	if err != nil {
 		return err
 	}
-	return r.persistRecordings(ctx, &job.SearchJob, series, recordings)
+	if err := r.persistRecordings(ctx, &job.SearchJob, series, recordings); err != nil {
+		return err
+	}
+	r.evaluateAlerts(ctx, &job.SearchJob, series)
+	return nil
+}
+
+// evaluateAlerts evaluates the alert rules of the series now that a new data point was recorded. Alerts
+// are best effort, so failures are logged but don't fail the job.
+//
+// Only points recorded by the insight enqueuer are complete when the job finishes: it runs a single
+// query for the current point of the series. Historical jobs have a record time and only add up the
+// results of a single repository or frame, so the point they record is a partial sum until all of them
+// completed. Alerts are therefore not evaluated for them, nor for series that are still backfilling,
+// since their latest point may be overwritten by the backfill. The points of a backfill are evaluated
+// once it completed, by the job that checks for completed backfills.
+func (r *workHandler) evaluateAlerts(ctx context.Context, job *SearchJob, series *types.InsightSeries) {
+	if r.alertEvaluator == nil || store.PersistMode(job.PersistMode) == store.SnapshotMode || job.RecordTime != nil {
+		return
+	}
+
+	backfilling, err := r.metadadataStore.GetDataSeries(ctx, store.GetDataSeriesArgs{SeriesID: series.SeriesID, BackfillNotComplete: true})
+	if err != nil {
+		r.logger.Error("failed to check insight series backfill", log.String("seriesID", series.SeriesID), log.Error(err))
+		return
+	}
+	if len(backfilling) > 0 {
+		return
+	}
+
+	if err := r.alertEvaluator.EvaluateSeries(ctx, series); err != nil {
+		r.logger.Error("failed to evaluate insight series alert rules", log.String("seriesID", series.SeriesID), log.Error(err))
+	}
 }
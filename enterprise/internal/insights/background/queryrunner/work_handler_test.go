@@ -70,3 +70,71 @@ func TestGetSeries(t *testing.T) {
 	})
 
 }
+
+type fakeAlertEvaluator struct {
+	evaluated []string
+}
+
+func (f *fakeAlertEvaluator) EvaluateSeries(_ context.Context, series *types.InsightSeries) error {
+	f.evaluated = append(f.evaluated, series.SeriesID)
+	return nil
+}
+
+func TestEvaluateAlerts(t *testing.T) {
+	ctx := context.Background()
+	series := &types.InsightSeries{ID: 1, SeriesID: "series1"}
+	recordTime := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	newHandler := func(backfillComplete bool) (*workHandler, *fakeAlertEvaluator) {
+		dataSeriesStore := store.NewMockDataSeriesStore()
+		dataSeriesStore.GetDataSeriesFunc.SetDefaultHook(func(_ context.Context, args store.GetDataSeriesArgs) ([]types.InsightSeries, error) {
+			if args.BackfillNotComplete && !backfillComplete {
+				return []types.InsightSeries{*series}, nil
+			}
+			return nil, nil
+		})
+		evaluator := &fakeAlertEvaluator{}
+		return &workHandler{
+			metadadataStore: dataSeriesStore,
+			alertEvaluator:  evaluator,
+			logger:          logtest.Scoped(t),
+		}, evaluator
+	}
+
+	t.Run("historical jobs for the same point", func(t *testing.T) {
+		handler, evaluator := newHandler(true)
+		// The historical enqueuer creates one job per repository and frame, each adding to the
+		// same point. None of them records a complete point.
+		for _, query := range []string{"fork:no repo:^github\\.com/a$", "fork:no repo:^github\\.com/b$"} {
+			handler.evaluateAlerts(ctx, &SearchJob{
+				SeriesID:    series.SeriesID,
+				SearchQuery: query,
+				RecordTime:  &recordTime,
+				PersistMode: string(store.RecordMode),
+			}, series)
+		}
+		if len(evaluator.evaluated) != 0 {
+			t.Fatalf("expected no evaluation for historical jobs, got %v", evaluator.evaluated)
+		}
+
+		// The insight enqueuer records the current point with a single job.
+		handler.evaluateAlerts(ctx, &SearchJob{SeriesID: series.SeriesID, PersistMode: string(store.RecordMode)}, series)
+		autogold.Want("evaluated once", []string{"series1"}).Equal(t, evaluator.evaluated)
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		handler, evaluator := newHandler(true)
+		handler.evaluateAlerts(ctx, &SearchJob{SeriesID: series.SeriesID, PersistMode: string(store.SnapshotMode)}, series)
+		if len(evaluator.evaluated) != 0 {
+			t.Fatalf("expected no evaluation for snapshots, got %v", evaluator.evaluated)
+		}
+	})
+
+	t.Run("backfill not complete", func(t *testing.T) {
+		handler, evaluator := newHandler(false)
+		handler.evaluateAlerts(ctx, &SearchJob{SeriesID: series.SeriesID, PersistMode: string(store.RecordMode)}, series)
+		if len(evaluator.evaluated) != 0 {
+			t.Fatalf("expected no evaluation while backfilling, got %v", evaluator.evaluated)
+		}
+	})
+}
@@ -37,7 +37,7 @@ import (
 
 // NewWorker returns a worker that will execute search queries and insert information about the
 // results into the code insights database.
-func NewWorker(ctx context.Context, logger log.Logger, workerStore dbworkerstore.Store, insightsStore *store.Store, repoStore discovery.RepoStore, metrics workerutil.WorkerObservability) *workerutil.Worker {
+func NewWorker(ctx context.Context, logger log.Logger, workerStore dbworkerstore.Store, insightsStore *store.Store, repoStore discovery.RepoStore, alertEvaluator AlertEvaluator, metrics workerutil.WorkerObservability) *workerutil.Worker {
 	numHandlers := conf.Get().InsightsQueryWorkerConcurrency
 	if numHandlers <= 0 {
 		// Default concurrency is set to 5.
@@ -86,6 +86,7 @@ func NewWorker(ctx context.Context, logger log.Logger, workerStore dbworkerstore
 		seriesCache:     sharedCache,
 		searchHandlers:  GetSearchHandlers(),
 		logger:          log.Scoped("insights.queryRunner.Handler", ""),
+		alertEvaluator:  alertEvaluator,
 	}, options)
 }
 
@@ -0,0 +1,155 @@
+package declarative
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+// FromInsight returns the definition of a stored insight. Repositories and intervals shared by every
+// series are hoisted to the insight.
+func FromInsight(view types.Insight) Insight {
+	insight := Insight{
+		ID:    view.UniqueID,
+		Title: view.Title,
+	}
+
+	if view.PresentationType == types.Pie {
+		insight.Presentation = PieChart
+		insight.OtherThreshold = view.OtherThreshold
+		if len(view.Series) > 0 {
+			insight.Query = view.Series[0].Query
+			insight.Repositories = view.Series[0].Repositories
+		}
+		return insight
+	}
+
+	insight.Presentation = LineChart
+	if f := view.Filters; stringOrEmpty(f.IncludeRepoRegex) != "" || stringOrEmpty(f.ExcludeRepoRegex) != "" || len(f.SearchContexts) > 0 {
+		insight.Filters = &Filters{
+			IncludeRepoRegex: stringOrEmpty(f.IncludeRepoRegex),
+			ExcludeRepoRegex: stringOrEmpty(f.ExcludeRepoRegex),
+			SearchContexts:   f.SearchContexts,
+		}
+	}
+	if o := view.SeriesOptions; o.SortOptions != nil || o.Limit != nil {
+		insight.SeriesDisplay = &SeriesDisplay{Limit: o.Limit}
+		if o.SortOptions != nil {
+			insight.SeriesDisplay.SortMode = o.SortOptions.Mode
+			insight.SeriesDisplay.SortDirection = o.SortOptions.Direction
+		}
+	}
+
+	for _, s := range view.Series {
+		insight.Series = append(insight.Series, Series{
+			Label:                      s.Label,
+			Query:                      s.Query,
+			Color:                      s.LineColor,
+			Repositories:               s.Repositories,
+			Interval:                   &Interval{Unit: types.IntervalUnit(s.SampleIntervalUnit), Value: s.SampleIntervalValue},
+			GeneratedFromCaptureGroups: s.GeneratedFromCaptureGroups,
+			GroupBy:                    strings.ToUpper(stringOrEmpty(s.GroupBy)),
+		})
+	}
+	hoistSeriesDefaults(&insight)
+	return insight
+}
+
+func hoistSeriesDefaults(insight *Insight) {
+	if len(insight.Series) == 0 {
+		return
+	}
+	first := insight.Series[0]
+	sameRepos, sameInterval := true, true
+	for _, s := range insight.Series[1:] {
+		sameRepos = sameRepos && reflect.DeepEqual(s.Repositories, first.Repositories)
+		sameInterval = sameInterval && reflect.DeepEqual(s.Interval, first.Interval)
+	}
+	if sameRepos {
+		insight.Repositories = first.Repositories
+	}
+	if sameInterval {
+		insight.Interval = first.Interval
+	}
+	for i := range insight.Series {
+		if sameRepos {
+			insight.Series[i].Repositories = nil
+		}
+		if sameInterval {
+			insight.Series[i].Interval = nil
+		}
+	}
+}
+
+// Equal returns true if the stored insight already matches the definition, ignoring the ID.
+func Equal(view types.Insight, insight Insight) bool {
+	return reflect.DeepEqual(canonical(FromInsight(view)), canonical(insight))
+}
+
+// canonical returns the insight with defaults applied and ordering removed, so that two definitions of
+// the same insight compare equal.
+func canonical(insight Insight) Insight {
+	insight.ID = ""
+	insight.Repositories = sortedOrNil(insight.Repositories)
+	if insight.Presentation == PieChart && insight.OtherThreshold == nil {
+		var zero float64
+		insight.OtherThreshold = &zero
+	}
+	if insight.Presentation == LineChart {
+		series := insight.ResolvedSeries()
+		for i := range series {
+			series[i].Repositories = sortedOrNil(series[i].Repositories)
+		}
+		sort.Slice(series, func(i, j int) bool {
+			if series[i].Label != series[j].Label {
+				return series[i].Label < series[j].Label
+			}
+			return series[i].Query < series[j].Query
+		})
+		insight.Series = series
+		insight.Repositories = nil
+		insight.Interval = nil
+	}
+	if f := insight.Filters; f != nil {
+		filters := Filters{IncludeRepoRegex: f.IncludeRepoRegex, ExcludeRepoRegex: f.ExcludeRepoRegex, SearchContexts: sortedOrNil(f.SearchContexts)}
+		if filters.IncludeRepoRegex == "" && filters.ExcludeRepoRegex == "" && filters.SearchContexts == nil {
+			insight.Filters = nil
+		} else {
+			insight.Filters = &filters
+		}
+	}
+	if d := insight.SeriesDisplay; d != nil && d.SortMode == "" && d.Limit == nil {
+		insight.SeriesDisplay = nil
+	}
+	return insight
+}
+
+// SeriesMatches returns true if a stored series computes the same data as the definition, ignoring how
+// it is presented. series must have the defaults of its insight applied.
+func SeriesMatches(existing types.InsightViewSeries, series Series) bool {
+	return existing.Query == series.Query &&
+		series.Interval != nil &&
+		existing.SampleIntervalUnit == string(series.Interval.Unit) &&
+		existing.SampleIntervalValue == series.Interval.Value &&
+		reflect.DeepEqual(sortedOrNil(existing.Repositories), sortedOrNil(series.Repositories)) &&
+		existing.GeneratedFromCaptureGroups == series.GeneratedFromCaptureGroups &&
+		strings.EqualFold(stringOrEmpty(existing.GroupBy), series.GroupBy)
+}
+
+func sortedOrNil(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	sorted := append([]string(nil), s...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,151 @@
+package declarative
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func lineChartView() types.Insight {
+	return types.Insight{
+		UniqueID:         "view",
+		Title:            "Logging",
+		PresentationType: types.Line,
+		Filters:          types.InsightViewFilters{IncludeRepoRegex: pointers("^github")},
+		Series: []types.InsightViewSeries{
+			{
+				Label:               "log15",
+				LineColor:           "#f00",
+				Query:               "log15.",
+				Repositories:        []string{"b", "a"},
+				SampleIntervalUnit:  "MONTH",
+				SampleIntervalValue: 1,
+			},
+			{
+				Label:                      "loggers",
+				Query:                      `log\.Scoped\("(\w+)"`,
+				Repositories:               []string{"b", "a"},
+				SampleIntervalUnit:         "WEEK",
+				SampleIntervalValue:        2,
+				GeneratedFromCaptureGroups: true,
+				GroupBy:                    pointers("repo"),
+			},
+		},
+	}
+}
+
+func TestFromInsight(t *testing.T) {
+	t.Run("line chart", func(t *testing.T) {
+		want := Insight{
+			ID:           "view",
+			Title:        "Logging",
+			Presentation: LineChart,
+			Repositories: []string{"b", "a"},
+			Filters:      &Filters{IncludeRepoRegex: "^github"},
+			Series: []Series{
+				{
+					Label:    "log15",
+					Query:    "log15.",
+					Color:    "#f00",
+					Interval: &Interval{Unit: types.Month, Value: 1},
+				},
+				{
+					Label:                      "loggers",
+					Query:                      `log\.Scoped\("(\w+)"`,
+					Interval:                   &Interval{Unit: types.Week, Value: 2},
+					GeneratedFromCaptureGroups: true,
+					GroupBy:                    "REPO",
+				},
+			},
+		}
+		if diff := cmp.Diff(want, FromInsight(lineChartView())); diff != "" {
+			t.Errorf("unexpected insight (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("pie chart", func(t *testing.T) {
+		view := types.Insight{
+			UniqueID:         "pie",
+			Title:            "Languages",
+			PresentationType: types.Pie,
+			OtherThreshold:   pointers(0.03),
+			Series:           []types.InsightViewSeries{{Query: "lang:go", Repositories: []string{"a"}}},
+		}
+		want := Insight{
+			ID:             "pie",
+			Title:          "Languages",
+			Presentation:   PieChart,
+			Query:          "lang:go",
+			Repositories:   []string{"a"},
+			OtherThreshold: pointers(0.03),
+		}
+		if diff := cmp.Diff(want, FromInsight(view)); diff != "" {
+			t.Errorf("unexpected insight (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestEqual(t *testing.T) {
+	view := lineChartView()
+	if !Equal(view, FromInsight(view)) {
+		t.Fatal("expected an insight to equal its own definition")
+	}
+
+	// Defaults and ordering don't matter.
+	insight := FromInsight(view)
+	insight.ID = ""
+	insight.Repositories = []string{"a", "b"}
+	insight.Series[0], insight.Series[1] = insight.Series[1], insight.Series[0]
+	insight.Series[0].GroupBy = "repo"
+	if !Equal(view, insight) {
+		t.Error("expected insight to be equal regardless of ordering")
+	}
+
+	for name, change := range map[string]func(*Insight){
+		"title":  func(i *Insight) { i.Title = "Other" },
+		"label":  func(i *Insight) { i.Series[0].Label = "other" },
+		"color":  func(i *Insight) { i.Series[0].Color = "#0f0" },
+		"query":  func(i *Insight) { i.Series[1].Query = "log15" },
+		"repos":  func(i *Insight) { i.Repositories = nil },
+		"filter": func(i *Insight) { i.Filters = nil },
+		"series": func(i *Insight) { i.Series = i.Series[:1] },
+		"display": func(i *Insight) {
+			i.SeriesDisplay = &SeriesDisplay{SortMode: types.ResultCount, SortDirection: types.Desc}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			insight := FromInsight(view)
+			change(&insight)
+			if Equal(view, insight) {
+				t.Error("expected insight to differ")
+			}
+		})
+	}
+}
+
+func TestSeriesMatches(t *testing.T) {
+	view := lineChartView()
+	series := FromInsight(view).ResolvedSeries()
+
+	if !SeriesMatches(view.Series[0], series[0]) {
+		t.Error("expected series to match")
+	}
+	if SeriesMatches(view.Series[0], series[1]) {
+		t.Error("expected different series not to match")
+	}
+
+	relabeled := series[0]
+	relabeled.Label = "other"
+	relabeled.Color = "#0f0"
+	if !SeriesMatches(view.Series[0], relabeled) {
+		t.Error("expected presentation to be ignored")
+	}
+
+	rescoped := series[0]
+	rescoped.Repositories = []string{"a"}
+	if SeriesMatches(view.Series[0], rescoped) {
+		t.Error("expected a different repository scope not to match")
+	}
+}
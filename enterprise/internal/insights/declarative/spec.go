@@ -0,0 +1,251 @@
+// Package declarative implements a YAML format that defines code insights dashboards and their insights
+// as code. Dashboards can be exported to the format, and a definition can be applied to converge the
+// stored dashboards to it.
+package declarative
+
+import (
+	"bytes"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Spec is a declarative definition of code insights dashboards.
+type Spec struct {
+	Dashboards []Dashboard `yaml:"dashboards"`
+}
+
+// Dashboard is a dashboard and the insights on it. Dashboards are identified by their title.
+type Dashboard struct {
+	Title string `yaml:"title"`
+	// Grants of the dashboard. If nil, new dashboards are granted to the user applying the definition and
+	// the grants of existing dashboards are left as is.
+	Grants   *Grants   `yaml:"grants,omitempty"`
+	Insights []Insight `yaml:"insights,omitempty"`
+}
+
+// Grants are the users and organizations that can see a dashboard. Users and organizations are referred to
+// by name so that definitions can be shared between instances.
+type Grants struct {
+	Global        bool     `yaml:"global,omitempty"`
+	Users         []string `yaml:"users,omitempty"`
+	Organizations []string `yaml:"organizations,omitempty"`
+}
+
+type Presentation string
+
+const (
+	LineChart Presentation = "line"
+	PieChart  Presentation = "pie"
+)
+
+// Insight is an insight on a dashboard. Insights are identified by their ID if it is set and exists,
+// and by their title on the dashboard otherwise.
+type Insight struct {
+	ID           string       `yaml:"id,omitempty"`
+	Title        string       `yaml:"title"`
+	Presentation Presentation `yaml:"presentation,omitempty"`
+
+	// Repositories the insight is scoped to. An empty list runs over all repositories. For line charts
+	// this is the default of every series.
+	Repositories []string `yaml:"repositories,omitempty"`
+
+	// Line chart fields.
+	Interval      *Interval      `yaml:"interval,omitempty"` // Default of every series.
+	Filters       *Filters       `yaml:"filters,omitempty"`
+	SeriesDisplay *SeriesDisplay `yaml:"seriesDisplay,omitempty"`
+	Series        []Series       `yaml:"series,omitempty"`
+
+	// Pie chart fields.
+	Query          string   `yaml:"query,omitempty"`
+	OtherThreshold *float64 `yaml:"otherThreshold,omitempty"`
+}
+
+// Series is a data series of a line chart. Repositories and Interval default to those of the insight.
+type Series struct {
+	Label                      string    `yaml:"label"`
+	Query                      string    `yaml:"query"`
+	Color                      string    `yaml:"color,omitempty"`
+	Repositories               []string  `yaml:"repositories,omitempty"`
+	Interval                   *Interval `yaml:"interval,omitempty"`
+	GeneratedFromCaptureGroups bool      `yaml:"generatedFromCaptureGroups,omitempty"`
+	GroupBy                    string    `yaml:"groupBy,omitempty"`
+}
+
+// Interval is the time between the data points of a series.
+type Interval struct {
+	Unit  types.IntervalUnit `yaml:"unit"`
+	Value int                `yaml:"value"`
+}
+
+// Filters are the default filters of a line chart.
+type Filters struct {
+	IncludeRepoRegex string   `yaml:"includeRepoRegex,omitempty"`
+	ExcludeRepoRegex string   `yaml:"excludeRepoRegex,omitempty"`
+	SearchContexts   []string `yaml:"searchContexts,omitempty"`
+}
+
+// SeriesDisplay are the default series display options of a line chart.
+type SeriesDisplay struct {
+	SortMode      types.SeriesSortMode      `yaml:"sortMode,omitempty"`
+	SortDirection types.SeriesSortDirection `yaml:"sortDirection,omitempty"`
+	Limit         *int32                    `yaml:"limit,omitempty"`
+}
+
+// Parse parses and validates a definition. Unknown fields are rejected, so typos don't silently drop
+// parts of the definition.
+func Parse(data []byte) (*Spec, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var spec Spec
+	if err := dec.Decode(&spec); err != nil {
+		return nil, errors.Wrap(err, "invalid dashboards definition")
+	}
+	for i := range spec.Dashboards {
+		for j := range spec.Dashboards[i].Insights {
+			if spec.Dashboards[i].Insights[j].Presentation == "" {
+				spec.Dashboards[i].Insights[j].Presentation = LineChart
+			}
+		}
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Marshal encodes the definition as YAML.
+func Marshal(spec *Spec) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(spec); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Validate returns an error describing every problem with the definition.
+func (s *Spec) Validate() error {
+	var errs error
+	dashboardTitles := map[string]bool{}
+	for _, d := range s.Dashboards {
+		if d.Title == "" {
+			errs = errors.Append(errs, errors.New("dashboard: title is required"))
+			continue
+		}
+		if dashboardTitles[d.Title] {
+			errs = errors.Append(errs, errors.Newf("dashboard %q: defined more than once", d.Title))
+		}
+		dashboardTitles[d.Title] = true
+
+		insightTitles := map[string]bool{}
+		for _, insight := range d.Insights {
+			if insight.Title == "" {
+				errs = errors.Append(errs, errors.Newf("dashboard %q: insight title is required", d.Title))
+				continue
+			}
+			if insightTitles[insight.Title] {
+				errs = errors.Append(errs, errors.Newf("dashboard %q: insight %q defined more than once", d.Title, insight.Title))
+			}
+			insightTitles[insight.Title] = true
+			if err := insight.validate(); err != nil {
+				errs = errors.Append(errs, errors.Wrapf(err, "dashboard %q: insight %q", d.Title, insight.Title))
+			}
+		}
+	}
+	return errs
+}
+
+func (i Insight) validate() error {
+	switch i.Presentation {
+	case LineChart:
+		if i.Query != "" || i.OtherThreshold != nil {
+			return errors.New("query and otherThreshold are only supported on pie charts")
+		}
+		if len(i.Series) == 0 {
+			return errors.New("at least one series is required")
+		}
+		for _, s := range i.ResolvedSeries() {
+			if s.Query == "" {
+				return errors.Newf("series %q: query is required", s.Label)
+			}
+			if s.Interval == nil {
+				return errors.Newf("series %q: interval is required", s.Label)
+			}
+			if err := s.Interval.validate(); err != nil {
+				return errors.Wrapf(err, "series %q", s.Label)
+			}
+			if s.GroupBy != "" && !s.GeneratedFromCaptureGroups {
+				return errors.Newf("series %q: groupBy requires generatedFromCaptureGroups", s.Label)
+			}
+		}
+		if d := i.SeriesDisplay; d != nil {
+			if err := d.validate(); err != nil {
+				return errors.Wrap(err, "seriesDisplay")
+			}
+		}
+	case PieChart:
+		if len(i.Series) > 0 || i.Interval != nil || i.Filters != nil || i.SeriesDisplay != nil {
+			return errors.New("series, interval, filters and seriesDisplay are only supported on line charts")
+		}
+		if i.Query == "" {
+			return errors.New("query is required")
+		}
+	default:
+		return errors.Newf("unknown presentation %q, must be %q or %q", i.Presentation, LineChart, PieChart)
+	}
+	return nil
+}
+
+func (i *Interval) validate() error {
+	switch i.Unit {
+	case types.Hour, types.Day, types.Week, types.Month, types.Year:
+	default:
+		return errors.Newf("unknown interval unit %q", i.Unit)
+	}
+	if i.Value < 1 {
+		return errors.New("interval value must be at least 1")
+	}
+	return nil
+}
+
+func (d *SeriesDisplay) validate() error {
+	if (d.SortMode == "") != (d.SortDirection == "") {
+		return errors.New("sortMode and sortDirection must be set together")
+	}
+	switch d.SortMode {
+	case "", types.ResultCount, types.DateAdded, types.Lexicographical:
+	default:
+		return errors.Newf("unknown sortMode %q", d.SortMode)
+	}
+	switch d.SortDirection {
+	case "", types.Asc, types.Desc:
+	default:
+		return errors.Newf("unknown sortDirection %q", d.SortDirection)
+	}
+	return nil
+}
+
+// ResolvedSeries returns the series of a line chart with the defaults of the insight applied.
+func (i Insight) ResolvedSeries() []Series {
+	resolved := make([]Series, 0, len(i.Series))
+	for _, s := range i.Series {
+		if len(s.Repositories) == 0 {
+			s.Repositories = i.Repositories
+		}
+		if s.Interval == nil {
+			s.Interval = i.Interval
+		}
+		s.GroupBy = strings.ToUpper(s.GroupBy)
+		resolved = append(resolved, s)
+	}
+	return resolved
+}
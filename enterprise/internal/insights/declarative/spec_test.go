@@ -0,0 +1,175 @@
+package declarative
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		spec, err := Parse([]byte(`
+dashboards:
+  - title: Migrations
+    grants:
+      organizations: [platform]
+    insights:
+      - title: Logging
+        repositories: [github.com/sourcegraph/sourcegraph]
+        interval: {unit: WEEK, value: 2}
+        series:
+          - label: log15
+            query: log15.
+            color: "#f00"
+          - label: sourcegraph/log
+            query: log.Scoped
+            interval: {unit: MONTH, value: 1}
+      - title: Languages
+        presentation: pie
+        query: lang:go
+        otherThreshold: 0.03
+`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &Spec{Dashboards: []Dashboard{{
+			Title:  "Migrations",
+			Grants: &Grants{Organizations: []string{"platform"}},
+			Insights: []Insight{
+				{
+					Title:        "Logging",
+					Presentation: LineChart,
+					Repositories: []string{"github.com/sourcegraph/sourcegraph"},
+					Interval:     &Interval{Unit: types.Week, Value: 2},
+					Series: []Series{
+						{Label: "log15", Query: "log15.", Color: "#f00"},
+						{Label: "sourcegraph/log", Query: "log.Scoped", Interval: &Interval{Unit: types.Month, Value: 1}},
+					},
+				},
+				{
+					Title:          "Languages",
+					Presentation:   PieChart,
+					Query:          "lang:go",
+					OtherThreshold: pointers(0.03),
+				},
+			},
+		}}}
+		if diff := cmp.Diff(want, spec); diff != "" {
+			t.Errorf("unexpected spec (-want +got):\n%s", diff)
+		}
+	})
+
+	for _, tc := range []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			yaml:    "dashboards:\n  - title: a\n    insight: []\n",
+			wantErr: "field insight not found",
+		},
+		{
+			name:    "missing dashboard title",
+			yaml:    "dashboards:\n  - insights: []\n",
+			wantErr: "dashboard: title is required",
+		},
+		{
+			name:    "duplicate dashboard",
+			yaml:    "dashboards:\n  - title: a\n  - title: a\n",
+			wantErr: `dashboard "a": defined more than once`,
+		},
+		{
+			name:    "line chart without series",
+			yaml:    "dashboards:\n  - title: a\n    insights:\n      - title: b\n",
+			wantErr: "at least one series is required",
+		},
+		{
+			name:    "missing interval",
+			yaml:    "dashboards:\n  - title: a\n    insights:\n      - title: b\n        series:\n          - {label: c, query: d}\n",
+			wantErr: `series "c": interval is required`,
+		},
+		{
+			name:    "unknown interval unit",
+			yaml:    "dashboards:\n  - title: a\n    insights:\n      - title: b\n        interval: {unit: FORTNIGHT, value: 1}\n        series:\n          - {label: c, query: d}\n",
+			wantErr: `unknown interval unit "FORTNIGHT"`,
+		},
+		{
+			name:    "pie chart with series",
+			yaml:    "dashboards:\n  - title: a\n    insights:\n      - title: b\n        presentation: pie\n        query: lang:go\n        series:\n          - {label: c, query: d}\n",
+			wantErr: "only supported on line charts",
+		},
+		{
+			name:    "unknown presentation",
+			yaml:    "dashboards:\n  - title: a\n    insights:\n      - title: b\n        presentation: bar\n",
+			wantErr: `unknown presentation "bar"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.yaml))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("unexpected error: want %q in %q", tc.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	spec := &Spec{Dashboards: []Dashboard{{
+		Title:  "Migrations",
+		Grants: &Grants{Global: true},
+		Insights: []Insight{{
+			ID:           "2FRA3AoYq6Snpxn7zjwZZybL1nW",
+			Title:        "Logging",
+			Presentation: LineChart,
+			Interval:     &Interval{Unit: types.Month, Value: 1},
+			Filters:      &Filters{SearchContexts: []string{"@platform"}},
+			Series:       []Series{{Label: "log15", Query: "log15."}},
+		}},
+	}}}
+
+	data, err := Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantYAML := `dashboards:
+  - title: Migrations
+    grants:
+      global: true
+    insights:
+      - id: 2FRA3AoYq6Snpxn7zjwZZybL1nW
+        title: Logging
+        presentation: line
+        interval:
+          unit: MONTH
+          value: 1
+        filters:
+          searchContexts:
+            - '@platform'
+        series:
+          - label: log15
+            query: log15.
+`
+	if diff := cmp.Diff(wantYAML, string(data)); diff != "" {
+		t.Errorf("unexpected YAML (-want +got):\n%s", diff)
+	}
+
+	parsed, err := Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(spec, parsed); diff != "" {
+		t.Errorf("unexpected spec after round trip (-want +got):\n%s", diff)
+	}
+}
+
+func pointers[T any](v T) *T {
+	return &v
+}
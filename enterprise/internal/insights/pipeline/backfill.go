@@ -40,12 +40,19 @@ type GitCommitClient interface {
 	RecentCommits(ctx context.Context, repoName api.RepoName, target time.Time) ([]*gitdomain.Commit, error)
 }
 
+// RepoStore provides the repository metadata used to backfill series with the
+// repo-metadata generation method.
+type RepoStore interface {
+	Get(ctx context.Context, id api.RepoID) (*itypes.Repo, error)
+}
+
 type SearchJobGenerator func(ctx context.Context, req requestContext) (context.Context, *requestContext, []*queryrunner.SearchJob, error)
 type SearchRunner func(ctx context.Context, reqContext *requestContext, jobs []*queryrunner.SearchJob, err error) (context.Context, *requestContext, []store.RecordSeriesPointArgs, error)
 type ResultsPersister func(ctx context.Context, reqContext *requestContext, points []store.RecordSeriesPointArgs, err error) (*requestContext, error)
 
 type BackfillerConfig struct {
 	CommitClient    GitCommitClient
+	RepoStore       RepoStore
 	CompressionPlan compression.DataFrameFilter
 	SearchHandlers  map[types.GenerationMethod]queryrunner.InsightsHandler
 	InsightStore    store.Interface
@@ -56,7 +63,7 @@ type BackfillerConfig struct {
 
 func NewDefaultBackfiller(config BackfillerConfig) Backfiller {
 	logger := log.Scoped("insightsBackfiller", "")
-	searchJobGenerator := makeSearchJobsFunc(logger, config.CommitClient, config.CompressionPlan, config.SearchPlanWorkerLimit)
+	searchJobGenerator := makeSearchJobsFunc(logger, config.CommitClient, config.RepoStore, config.CompressionPlan, config.SearchPlanWorkerLimit)
 	searchRunner := makeRunSearchFunc(logger, config.SearchHandlers, config.SearchRunnerWorkerLimit)
 	persister := makeSaveResultsFunc(logger, config.InsightStore)
 	return newBackfiller(searchJobGenerator, searchRunner, persister)
@@ -86,7 +93,7 @@ func (b *backfiller) Run(ctx context.Context, req BackfillRequest) error {
 
 // Implementation of steps for Backfill process
 
-func makeSearchJobsFunc(logger log.Logger, commitClient GitCommitClient, compressionPlan compression.DataFrameFilter, searchJobWorkerLimit int) SearchJobGenerator {
+func makeSearchJobsFunc(logger log.Logger, commitClient GitCommitClient, repoStore RepoStore, compressionPlan compression.DataFrameFilter, searchJobWorkerLimit int) SearchJobGenerator {
 	return func(ctx context.Context, reqContext requestContext) (context.Context, *requestContext, []*queryrunner.SearchJob, error) {
 		jobs := make([]*queryrunner.SearchJob, 0, 12)
 		if reqContext.backfillRequest == nil {
@@ -94,25 +101,45 @@ func makeSearchJobsFunc(logger log.Logger, commitClient GitCommitClient, compres
 		}
 
 		req := reqContext.backfillRequest
-		buildJob := makeHistoricalSearchJobFunc(logger, commitClient)
 		logger.Debug("making search plan")
-		// Find the first commit made to the repository on the default branch.
-		firstHEADCommit, err := commitClient.FirstCommit(ctx, req.Repo.Name)
-		if err != nil {
-			if errors.Is(err, discovery.EmptyRepoErr) {
-				// This is fine it's empty there is no work to be done
-				return ctx, &reqContext, jobs, nil
-			}
-
-			return ctx, &reqContext, jobs, err
-		}
-
 		frames := timeseries.BuildFrames(12, timeseries.TimeInterval{
 			Unit:  types.IntervalUnit(req.Series.SampleIntervalUnit),
 			Value: req.Series.SampleIntervalValue,
 		}, req.Series.CreatedAt.Truncate(time.Hour*24))
 
-		searchPlan := compressionPlan.FilterFrames(ctx, frames, req.Repo.ID)
+		var (
+			buildJob        searchJobFunc
+			searchPlan      compression.BackfillPlan
+			firstHEADCommit *gitdomain.Commit
+			repo            *itypes.Repo
+			err             error
+		)
+		if req.Series.GenerationMethod == types.RepoMetadata {
+			// Repository metadata isn't versioned by commit, so every frame is
+			// evaluated against the state of the repository at that time.
+			if repoStore == nil {
+				return ctx, &reqContext, jobs, errors.New("a repo store is required to backfill repo-metadata series")
+			}
+			buildJob = makeRepoMetadataSearchJobFunc(logger, commitClient)
+			repo, err = repoStore.Get(ctx, req.Repo.ID)
+			if err != nil {
+				return ctx, &reqContext, jobs, err
+			}
+			searchPlan = (&compression.NoopFilter{}).FilterFrames(ctx, frames, req.Repo.ID)
+		} else {
+			buildJob = makeHistoricalSearchJobFunc(logger, commitClient)
+			// Find the first commit made to the repository on the default branch.
+			firstHEADCommit, err = commitClient.FirstCommit(ctx, req.Repo.Name)
+			if err != nil {
+				if errors.Is(err, discovery.EmptyRepoErr) {
+					// This is fine it's empty there is no work to be done
+					return ctx, &reqContext, jobs, nil
+				}
+
+				return ctx, &reqContext, jobs, err
+			}
+			searchPlan = compressionPlan.FilterFrames(ctx, frames, req.Repo.ID)
+		}
 
 		mu := &sync.Mutex{}
 
@@ -128,6 +155,7 @@ func makeSearchJobsFunc(logger log.Logger, commitClient GitCommitClient, compres
 					repoName:        req.Repo.Name,
 					id:              req.Repo.ID,
 					firstHEADCommit: firstHEADCommit,
+					repo:            repo,
 					seriesID:        req.Series.SeriesID,
 					series:          req.Series,
 				})
@@ -159,6 +187,9 @@ type buildSeriesContext struct {
 	// The first commit made in the repository on the default branch.
 	firstHEADCommit *gitdomain.Commit
 
+	// The repository metadata, only set for the repo-metadata generation method.
+	repo *itypes.Repo
+
 	// The series we're building historical data for.
 	seriesID string
 	series   *types.InsightSeries
@@ -241,6 +272,28 @@ func makeHistoricalSearchJobFunc(logger log.Logger, commitClient GitCommitClient
 	}
 }
 
+// makeRepoMetadataSearchJobFunc builds jobs for series using the repo-metadata
+// generation method (see querybuilder.RepoMetadataSearchQuery).
+func makeRepoMetadataSearchJobFunc(logger log.Logger, commitClient GitCommitClient) searchJobFunc {
+	return func(ctx context.Context, bctx *buildSeriesContext) (err error, job *queryrunner.SearchJob, preempted []store.RecordSeriesPointArgs) {
+		logger.Debug("making repo metadata search job")
+		defaultParams := querybuilder.CodeInsightsQueryDefaults(len(bctx.series.Repositories) == 0)
+		modifiedQuery, _, err := querybuilder.RepoMetadataSearchQuery(ctx, querybuilder.BasicQuery(bctx.series.Query), bctx.repo, bctx.execution.RecordingTime, defaultParams, commitClient.RecentCommits)
+		if err != nil || modifiedQuery == "" {
+			return err, nil, nil
+		}
+
+		job = &queryrunner.SearchJob{
+			SeriesID:        bctx.seriesID,
+			SearchQuery:     modifiedQuery.String(),
+			RecordTime:      &bctx.execution.RecordingTime,
+			PersistMode:     string(store.RecordMode),
+			DependentFrames: bctx.execution.SharedRecordings,
+		}
+		return nil, job, nil
+	}
+}
+
 func makeRunSearchFunc(logger log.Logger, searchHandlers map[types.GenerationMethod]queryrunner.InsightsHandler, searchWorkerLimit int) SearchRunner {
 	return func(ctx context.Context, reqContext *requestContext, jobs []*queryrunner.SearchJob, incomingErr error) (context.Context, *requestContext, []store.RecordSeriesPointArgs, error) {
 		points := make([]store.RecordSeriesPointArgs, 0, len(jobs))
@@ -121,6 +121,15 @@ func newFakeCommitClient(first *gitdomain.Commit, recents []*gitdomain.Commit) G
 	}
 }
 
+type fakeRepoStore struct {
+	repo *itypes.Repo
+	err  error
+}
+
+func (f *fakeRepoStore) Get(ctx context.Context, id api.RepoID) (*itypes.Repo, error) {
+	return f.repo, f.err
+}
+
 func TestMakeSearchJobs(t *testing.T) {
 	// Setup
 	threeWeeks := 24 * 21 * time.Hour
@@ -165,6 +174,48 @@ func TestMakeSearchJobs(t *testing.T) {
 		Repo: &itypes.MinimalRepo{ID: api.RepoID(1), Name: api.RepoName("testrepo")},
 	}
 
+	backfillReqRepoMetadata := &BackfillRequest{
+		Series: &types.InsightSeries{
+			ID:                  1,
+			SeriesID:            "abc",
+			Query:               "type:repo repo:has(owner:platform)",
+			CreatedAt:           createdDate,
+			SampleIntervalUnit:  string(types.Week),
+			SampleIntervalValue: 1,
+			GenerationMethod:    types.RepoMetadata,
+		},
+		Repo: &itypes.MinimalRepo{ID: api.RepoID(1), Name: api.RepoName("testrepo")},
+	}
+
+	backfillReqRepoMetadataArchived := &BackfillRequest{
+		Series: &types.InsightSeries{
+			ID:                  1,
+			SeriesID:            "abc",
+			Query:               "type:repo archived:only",
+			CreatedAt:           createdDate,
+			SampleIntervalUnit:  string(types.Week),
+			SampleIntervalValue: 1,
+			GenerationMethod:    types.RepoMetadata,
+		},
+		Repo: &itypes.MinimalRepo{ID: api.RepoID(1), Name: api.RepoName("testrepo")},
+	}
+
+	backfillReqRepoMetadataHasFile := &BackfillRequest{
+		Series: &types.InsightSeries{
+			ID:                  1,
+			SeriesID:            "abc",
+			Query:               "type:repo repo:has.file(path:go.mod)",
+			CreatedAt:           createdDate,
+			SampleIntervalUnit:  string(types.Week),
+			SampleIntervalValue: 1,
+			GenerationMethod:    types.RepoMetadata,
+		},
+		Repo: &itypes.MinimalRepo{ID: api.RepoID(1), Name: api.RepoName("testrepo")},
+	}
+
+	basicRepoStore := &fakeRepoStore{repo: &itypes.Repo{ID: 1, Name: "testrepo", CreatedAt: createdDate.Add(-1 * threeWeeks)}}
+	archivedRepoStore := &fakeRepoStore{repo: &itypes.Repo{ID: 1, Name: "testrepo", Archived: true, CreatedAt: createdDate.Add(-10 * threeWeeks), UpdatedAt: createdDate.Add(-1 * threeWeeks)}}
+
 	basicCommitClient := newFakeCommitClient(&firstCommit, recentCommits)
 	// used to simulate a single call to recent commits failing
 	recentsErrorAfter := func(times int, commits []*gitdomain.Commit) func(ctx context.Context, repoName api.RepoName, target time.Time) ([]*gitdomain.Commit, error) {
@@ -184,6 +235,7 @@ func TestMakeSearchJobs(t *testing.T) {
 
 	testCases := []struct {
 		commitClient GitCommitClient
+		repoStore    RepoStore
 		backfillReq  *BackfillRequest
 		workers      int
 		cancled      bool
@@ -256,6 +308,38 @@ func TestMakeSearchJobs(t *testing.T) {
 		}, backfillReq: backfillReq, workers: 5, want: autogold.Want("Error in some jobs multiple worker", []string{"error occured: true"})},
 		{commitClient: basicCommitClient, backfillReq: backfillReqInvalidQuery, workers: 1, want: autogold.Want("Invalid query", []string{"error occured: true"})},
 		{commitClient: basicCommitClient, backfillReq: backfillReqRepoQuery, workers: 1, want: autogold.Want("Query with repo: in it ", []string{"error occured: false"})},
+		{commitClient: basicCommitClient, repoStore: basicRepoStore, backfillReq: backfillReqRepoMetadata, workers: 1, want: autogold.Want("Repo metadata created during backfill period", []string{
+			"job recordtime:2022-04-01T00:00:00Z query:fork:no patterntype:literal type:repo repo:has(owner:platform) archived:yes repo:^(testrepo)$",
+			"job recordtime:2022-03-25T00:00:00Z query:fork:no patterntype:literal type:repo repo:has(owner:platform) archived:yes repo:^(testrepo)$",
+			"job recordtime:2022-03-18T00:00:00Z query:fork:no patterntype:literal type:repo repo:has(owner:platform) archived:yes repo:^(testrepo)$",
+			"error occured: false",
+		})},
+		{commitClient: basicCommitClient, repoStore: archivedRepoStore, backfillReq: backfillReqRepoMetadata, workers: 1, want: autogold.Want("Repo metadata archived during backfill period", []string{
+			"job recordtime:2022-03-11T00:00:00Z query:fork:no patterntype:literal type:repo repo:has(owner:platform) archived:yes repo:^(testrepo)$",
+			"job recordtime:2022-03-04T00:00:00Z query:fork:no patterntype:literal type:repo repo:has(owner:platform) archived:yes repo:^(testrepo)$",
+			"job recordtime:2022-02-25T00:00:00Z query:fork:no patterntype:literal type:repo repo:has(owner:platform) archived:yes repo:^(testrepo)$",
+			"job recordtime:2022-02-18T00:00:00Z query:fork:no patterntype:literal type:repo repo:has(owner:platform) archived:yes repo:^(testrepo)$",
+			"job recordtime:2022-02-11T00:00:00Z query:fork:no patterntype:literal type:repo repo:has(owner:platform) archived:yes repo:^(testrepo)$",
+			"job recordtime:2022-02-04T00:00:00Z query:fork:no patterntype:literal type:repo repo:has(owner:platform) archived:yes repo:^(testrepo)$",
+			"job recordtime:2022-01-28T00:00:00Z query:fork:no patterntype:literal type:repo repo:has(owner:platform) archived:yes repo:^(testrepo)$",
+			"job recordtime:2022-01-21T00:00:00Z query:fork:no patterntype:literal type:repo repo:has(owner:platform) archived:yes repo:^(testrepo)$",
+			"job recordtime:2022-01-14T00:00:00Z query:fork:no patterntype:literal type:repo repo:has(owner:platform) archived:yes repo:^(testrepo)$",
+			"error occured: false",
+		})},
+		{commitClient: basicCommitClient, repoStore: archivedRepoStore, backfillReq: backfillReqRepoMetadataArchived, workers: 1, want: autogold.Want("Repo metadata only archived", []string{
+			"job recordtime:2022-04-01T00:00:00Z query:fork:no patterntype:literal type:repo archived:yes repo:^(testrepo)$",
+			"job recordtime:2022-03-25T00:00:00Z query:fork:no patterntype:literal type:repo archived:yes repo:^(testrepo)$",
+			"job recordtime:2022-03-18T00:00:00Z query:fork:no patterntype:literal type:repo archived:yes repo:^(testrepo)$",
+			"error occured: false",
+		})},
+		{commitClient: basicCommitClient, repoStore: basicRepoStore, backfillReq: backfillReqRepoMetadataHasFile, workers: 1, want: autogold.Want("Repo metadata revision dependent", []string{
+			"job recordtime:2022-04-01T00:00:00Z query:fork:no patterntype:literal type:repo repo:has.file(path:go.mod) archived:yes repo:^testrepo$@1",
+			"job recordtime:2022-03-25T00:00:00Z query:fork:no patterntype:literal type:repo repo:has.file(path:go.mod) archived:yes repo:^testrepo$@1",
+			"job recordtime:2022-03-18T00:00:00Z query:fork:no patterntype:literal type:repo repo:has.file(path:go.mod) archived:yes repo:^testrepo$@1",
+			"error occured: false",
+		})},
+		{commitClient: basicCommitClient, repoStore: &fakeRepoStore{err: errors.New("repo store error")}, backfillReq: backfillReqRepoMetadata, workers: 1, want: autogold.Want("Repo metadata repo store error", []string{"error occured: true"})},
+		{commitClient: basicCommitClient, backfillReq: backfillReqRepoMetadata, workers: 1, want: autogold.Want("Repo metadata without repo store", []string{"error occured: true"})},
 	}
 
 	for _, tc := range testCases {
@@ -265,7 +349,7 @@ func TestMakeSearchJobs(t *testing.T) {
 			if tc.cancled {
 				cancel()
 			}
-			jobsFunc := makeSearchJobsFunc(logtest.NoOp(t), tc.commitClient, &compression.NoopFilter{}, tc.workers)
+			jobsFunc := makeSearchJobsFunc(logtest.NoOp(t), tc.commitClient, tc.repoStore, &compression.NoopFilter{}, tc.workers)
 			_, _, jobs, err := jobsFunc(testCtx, requestContext{backfillRequest: tc.backfillReq})
 			got := []string{}
 			// sorted jobs to make test stable
@@ -0,0 +1,31 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/discovery"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+)
+
+// NewGitCommitClient returns a GitCommitClient backed by gitserver.
+func NewGitCommitClient(db database.DB) GitCommitClient {
+	return &gitCommitClient{db: db, client: gitserver.NewClient(db)}
+}
+
+type gitCommitClient struct {
+	db     database.DB
+	client gitserver.Client
+}
+
+func (g *gitCommitClient) FirstCommit(ctx context.Context, repoName api.RepoName) (*gitdomain.Commit, error) {
+	return discovery.GitFirstEverCommit(ctx, g.db, repoName)
+}
+
+func (g *gitCommitClient) RecentCommits(ctx context.Context, repoName api.RepoName, target time.Time) ([]*gitdomain.Commit, error) {
+	return g.client.Commits(ctx, repoName, gitserver.CommitsOptions{N: 1, Before: target.Format(time.RFC3339), DateOrder: true}, authz.DefaultSubRepoPermsChecker)
+}
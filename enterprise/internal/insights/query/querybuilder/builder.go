@@ -1,13 +1,18 @@
 package querybuilder
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/grafana/regexp"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	searchquery "github.com/sourcegraph/sourcegraph/internal/search/query"
 	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -274,6 +279,45 @@ func AddFileFilter(query BasicQuery, file string) (BasicQuery, error) {
 	return addFilterSimple(query, searchquery.FieldFile, file)
 }
 
+func AddLanguageFilter(query BasicQuery, language string) (BasicQuery, error) {
+	parameter := searchquery.Parameter{Field: searchquery.FieldLang, Value: language}
+	if strings.Contains(language, " ") {
+		parameter.Annotation.Labels.Set(searchquery.Quoted)
+	}
+	return addParameter(query, parameter)
+}
+
+func AddFileExtensionFilter(query BasicQuery, extension string) (BasicQuery, error) {
+	return addParameter(query, searchquery.Parameter{
+		Field: searchquery.FieldFile,
+		Value: fmt.Sprintf("%s$", regexp.QuoteMeta(extension)),
+	})
+}
+
+// AddDirectoryFilter restricts query to files in directory. The root directory
+// "/" only matches files at the root of a repository.
+func AddDirectoryFilter(query BasicQuery, directory string) (BasicQuery, error) {
+	value := fmt.Sprintf("^%s/", regexp.QuoteMeta(strings.Trim(directory, "/")))
+	if strings.Trim(directory, "/") == "" {
+		value = "^[^/]+$"
+	}
+	return addParameter(query, searchquery.Parameter{Field: searchquery.FieldFile, Value: value})
+}
+
+// AddCommitMonthFilter restricts a commit or diff query to the commits of month,
+// formatted as YYYY-MM.
+func AddCommitMonthFilter(query BasicQuery, month string) (BasicQuery, error) {
+	start, err := time.Parse("2006-01", month)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid month")
+	}
+	end := start.AddDate(0, 1, 0)
+	return addParameter(query,
+		searchquery.Parameter{Field: searchquery.FieldAfter, Value: start.Format("2006-01-02")},
+		searchquery.Parameter{Field: searchquery.FieldBefore, Value: end.Format("2006-01-02")},
+	)
+}
+
 func buildFilterText(raw string) string {
 	quoted := regexp.QuoteMeta(raw)
 	if strings.Contains(raw, " ") {
@@ -283,20 +327,24 @@ func buildFilterText(raw string) string {
 }
 
 func addFilterSimple(query BasicQuery, field, value string) (BasicQuery, error) {
+	return addParameter(query, searchquery.Parameter{
+		Field:      field,
+		Value:      buildFilterText(value),
+		Negated:    false,
+		Annotation: searchquery.Annotation{},
+	})
+}
+
+func addParameter(query BasicQuery, parameters ...searchquery.Parameter) (BasicQuery, error) {
 	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
 	if err != nil {
 		return "", err
 	}
 
 	mutatedQuery := searchquery.MapPlan(plan, func(basic searchquery.Basic) searchquery.Basic {
-		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+1)
+		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+len(parameters))
 		modified = append(modified, basic.Parameters...)
-		modified = append(modified, searchquery.Parameter{
-			Field:      field,
-			Value:      buildFilterText(value),
-			Negated:    false,
-			Annotation: searchquery.Annotation{},
-		})
+		modified = append(modified, parameters...)
 		return basic.MapParameters(modified)
 	})
 	return BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), nil
@@ -351,3 +399,190 @@ func SelectRepoQuery(query BasicQuery, defaultParams searchquery.Parameters) (Ba
 	})
 	return BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), nil
 }
+
+// IsRepoMetadataQuery returns true if every step of the query only matches repositories, i.e. it has no search
+// pattern and is either `type:repo` or `select:repo`. Such queries are evaluated from repository metadata rather
+// than from the contents of the repository.
+func IsRepoMetadataQuery(query BasicQuery) (bool, error) {
+	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
+	if err != nil {
+		return false, errors.Wrap(err, "Pipeline")
+	}
+	if len(plan) == 0 {
+		return false, nil
+	}
+
+	for _, basic := range plan {
+		if !basic.IsEmptyPattern() {
+			return false, nil
+		}
+		typeRepo := false
+		searchquery.VisitParameter(basic.ToParseTree(), func(field, value string, negated bool, _ searchquery.Annotation) {
+			if negated {
+				return
+			}
+			if (field == searchquery.FieldType || field == searchquery.FieldSelect) && value == "repo" {
+				typeRepo = true
+			}
+		})
+		if !typeRepo {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// revisionPredicates are the repo: predicates whose result depends on the contents of a revision of the repository.
+var revisionPredicates = map[string]struct{}{
+	"has.file":         {},
+	"has.path":         {},
+	"has.content":      {},
+	"contains":         {},
+	"contains.file":    {},
+	"contains.content": {},
+}
+
+// IsRevisionDependent returns true if the query contains filters that match on the contents of a revision of a
+// repository (e.g. `repo:has.file(...)`), as opposed to only matching on repository metadata.
+func IsRevisionDependent(query BasicQuery) (bool, error) {
+	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
+	if err != nil {
+		return false, errors.Wrap(err, "Pipeline")
+	}
+
+	dependent := false
+	for _, basic := range plan {
+		searchquery.VisitParameter(basic.ToParseTree(), func(field, value string, _ bool, _ searchquery.Annotation) {
+			switch field {
+			case searchquery.FieldRepoHasFile:
+				dependent = true
+			case searchquery.FieldRepo:
+				name, _ := searchquery.ParseAsPredicate(value)
+				if _, ok := revisionPredicates[name]; ok {
+					dependent = true
+				}
+			}
+		})
+	}
+	return dependent, nil
+}
+
+// ArchivedFilter returns the value of the `archived:` filter of the query, or the value in defaultParams if the
+// query doesn't specify one.
+func ArchivedFilter(query BasicQuery, defaultParams searchquery.Parameters) (searchquery.YesNoOnly, error) {
+	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
+	if err != nil {
+		return "", errors.Wrap(err, "Pipeline")
+	}
+
+	for _, basic := range plan {
+		if archived := basic.Parameters.Archived(); archived != nil {
+			return *archived, nil
+		}
+	}
+	if archived := defaultParams.Archived(); archived != nil {
+		return *archived, nil
+	}
+	return searchquery.No, nil
+}
+
+// RepoMetadataMatchesAt returns false if a repository metadata query can't match the repository at the given point in
+// time. Only the creation time and archived state of a repository are known historically: the repository doesn't
+// match before it was created, and is considered archived from its last metadata update onwards.
+//
+// The archive time is an approximation: we don't record when a repository was archived, so repo.UpdatedAt is used
+// instead. Any later metadata update moves this time forward, which means an archived repository may be counted as
+// unarchived for some points after it was actually archived.
+func RepoMetadataMatchesAt(query BasicQuery, repo *types.Repo, at time.Time, defaultParams searchquery.Parameters) (bool, error) {
+	if at.Before(repo.CreatedAt) {
+		return false, nil
+	}
+
+	archivedFilter, err := ArchivedFilter(query, defaultParams)
+	if err != nil {
+		return false, err
+	}
+	archived := repo.Archived && !at.Before(repo.UpdatedAt)
+	if (archivedFilter == searchquery.No && archived) || (archivedFilter == searchquery.Only && !archived) {
+		return false, nil
+	}
+	return true, nil
+}
+
+// RepoMetadataQuery generates a Sourcegraph query that evaluates a repository metadata query against a single
+// repository, optionally at a revision. The `archived:` filter of the query is replaced with `archived:yes` because
+// callers evaluate it against the archived state of the repository at the point in time they're interested in.
+func RepoMetadataQuery(query BasicQuery, repo, revision string, defaultParams searchquery.Parameters) (BasicQuery, error) {
+	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
+	if err != nil {
+		return "", errors.Wrap(err, "Pipeline")
+	}
+	mutatedQuery := searchquery.MapPlan(plan, func(basic searchquery.Basic) searchquery.Basic {
+		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+1)
+		for _, parameter := range basic.Parameters {
+			if parameter.Field == searchquery.FieldArchived {
+				continue
+			}
+			modified = append(modified, parameter)
+		}
+		modified = append(modified, searchquery.Parameter{
+			Field:      searchquery.FieldArchived,
+			Value:      string(searchquery.Yes),
+			Negated:    false,
+			Annotation: searchquery.Annotation{},
+		})
+		return basic.MapParameters(modified)
+	})
+
+	modified, err := withDefaults(BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), defaultParams)
+	if err != nil {
+		return "", errors.Wrap(err, "WithDefaults")
+	}
+	if revision == "" {
+		return forRepos(modified, []string{repo}), nil
+	}
+	return forRepoRevision(modified, repo, revision), nil
+}
+
+// RecentCommitsFunc returns the most recent commits of a repository at or before the target time.
+type RecentCommitsFunc func(ctx context.Context, repoName api.RepoName, target time.Time) ([]*gitdomain.Commit, error)
+
+// RepoMetadataSearchQuery generates the query that evaluates a repository metadata query against a single repository
+// at the given point in time. preempted is true if the repository can't match at that time (see
+// RepoMetadataMatchesAt), in which case no search needs to run. Queries depending on the contents of the repository
+// are evaluated at the nearest commit to the point in time; an empty query is returned if there is no such commit.
+func RepoMetadataSearchQuery(ctx context.Context, query BasicQuery, repo *types.Repo, at time.Time, defaultParams searchquery.Parameters, recentCommits RecentCommitsFunc) (_ BasicQuery, preempted bool, err error) {
+	matches, err := RepoMetadataMatchesAt(query, repo, at, defaultParams)
+	if err != nil {
+		return "", false, err
+	}
+	if !matches {
+		return "", true, nil
+	}
+
+	revisionDependent, err := IsRevisionDependent(query)
+	if err != nil {
+		return "", false, err
+	}
+	var revision string
+	if revisionDependent {
+		commits, err := recentCommits(ctx, repo.Name, at)
+		if err != nil {
+			if errors.HasType(err, &gitdomain.RevisionNotFoundError{}) || gitdomain.IsRepoNotExist(err) {
+				return "", false, nil // no error - repo may not be cloned yet (or not even pushed to code host yet)
+			}
+			return "", false, errors.Wrap(err, "FindNearestCommit")
+		}
+		if len(commits) == 0 {
+			return "", false, nil // repository has no commits at this point in time
+		}
+		revision = string(commits[0].ID)
+	}
+
+	modified, err := RepoMetadataQuery(query, string(repo.Name), revision, defaultParams)
+	if err != nil {
+		return "", false, errors.Wrap(err, "RepoMetadataQuery")
+	}
+	return modified, false, nil
+}
@@ -2,11 +2,13 @@ package querybuilder
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hexops/autogold"
 
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -601,3 +603,166 @@ func Test_addFileFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestIsRepoMetadataQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		inputQuery string
+		want       bool
+	}{
+		{
+			name:       "type repo with key value pair",
+			inputQuery: "type:repo repo:has(owner:platform)",
+			want:       true,
+		},
+		{
+			name:       "select repo with archived",
+			inputQuery: "select:repo archived:only",
+			want:       true,
+		},
+		{
+			name:       "type repo with pattern",
+			inputQuery: "type:repo sourcegraph",
+			want:       false,
+		},
+		{
+			name:       "file search",
+			inputQuery: "repo:has.file(path:go.mod) fmt.Errorf",
+			want:       false,
+		},
+		{
+			name:       "select file",
+			inputQuery: "select:file repo:has.file(path:go.mod)",
+			want:       false,
+		},
+		{
+			name:       "all steps are repo queries",
+			inputQuery: "(type:repo repo:has.file(path:go.mod)) or (type:repo repo:has.file(path:package.json))",
+			want:       true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := IsRepoMetadataQuery(BasicQuery(test.inputQuery))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("%s failed (want/got): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestIsRevisionDependent(t *testing.T) {
+	tests := []struct {
+		inputQuery string
+		want       bool
+	}{
+		{inputQuery: "type:repo repo:has(owner:platform)", want: false},
+		{inputQuery: "type:repo archived:only", want: false},
+		{inputQuery: "type:repo repo:has.file(path:go.mod)", want: true},
+		{inputQuery: "type:repo repo:contains.content(TODO)", want: true},
+		{inputQuery: "type:repo repohasfile:package.json", want: true},
+	}
+	for _, test := range tests {
+		t.Run(test.inputQuery, func(t *testing.T) {
+			got, err := IsRevisionDependent(BasicQuery(test.inputQuery))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("IsRevisionDependent(%q) = %v, want %v", test.inputQuery, got, test.want)
+			}
+		})
+	}
+}
+
+func TestRepoMetadataMatchesAt(t *testing.T) {
+	createdAt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	archivedAt := createdAt.AddDate(0, 2, 0)
+	repo := &types.Repo{CreatedAt: createdAt}
+	archivedRepo := &types.Repo{Archived: true, CreatedAt: createdAt, UpdatedAt: archivedAt}
+
+	tests := []struct {
+		name       string
+		inputQuery string
+		repo       *types.Repo
+		at         time.Time
+		want       bool
+	}{
+		{name: "before creation", inputQuery: "type:repo", repo: repo, at: createdAt.Add(-time.Hour), want: false},
+		{name: "after creation", inputQuery: "type:repo", repo: repo, at: createdAt, want: true},
+		{name: "before archived", inputQuery: "type:repo", repo: archivedRepo, at: archivedAt.Add(-time.Hour), want: true},
+		{name: "after archived", inputQuery: "type:repo", repo: archivedRepo, at: archivedAt, want: false},
+		{name: "after archived with archived:yes", inputQuery: "type:repo archived:yes", repo: archivedRepo, at: archivedAt, want: true},
+		{name: "only archived before archived", inputQuery: "type:repo archived:only", repo: archivedRepo, at: archivedAt.Add(-time.Hour), want: false},
+		{name: "only archived after archived", inputQuery: "type:repo archived:only", repo: archivedRepo, at: archivedAt, want: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := RepoMetadataMatchesAt(BasicQuery(test.inputQuery), test.repo, test.at, CodeInsightsQueryDefaults(true))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("RepoMetadataMatchesAt(%q, %v) = %v, want %v", test.inputQuery, test.at, got, test.want)
+			}
+		})
+	}
+}
+
+func TestArchivedFilter(t *testing.T) {
+	tests := []struct {
+		inputQuery string
+		defaults   query.Parameters
+		want       query.YesNoOnly
+	}{
+		{inputQuery: "type:repo", defaults: nil, want: query.No},
+		{inputQuery: "type:repo", defaults: CodeInsightsQueryDefaults(false), want: query.Yes},
+		{inputQuery: "type:repo archived:only", defaults: CodeInsightsQueryDefaults(true), want: query.Only},
+		{inputQuery: "type:repo archived:yes", defaults: CodeInsightsQueryDefaults(true), want: query.Yes},
+	}
+	for _, test := range tests {
+		t.Run(test.inputQuery, func(t *testing.T) {
+			got, err := ArchivedFilter(BasicQuery(test.inputQuery), test.defaults)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("ArchivedFilter(%q) = %v, want %v", test.inputQuery, got, test.want)
+			}
+		})
+	}
+}
+
+func TestRepoMetadataQuery(t *testing.T) {
+	tests := []struct {
+		input    string
+		revision string
+		want     autogold.Value
+	}{
+		{
+			input: "type:repo repo:has(owner:platform)",
+			want:  autogold.Want("metadata only", BasicQuery("fork:no patterntype:literal type:repo repo:has(owner:platform) archived:yes repo:^(github\\.com/sourcegraph/sourcegraph)$")),
+		},
+		{
+			input: "type:repo archived:only",
+			want:  autogold.Want("archived is replaced", BasicQuery("fork:no patterntype:literal type:repo archived:yes repo:^(github\\.com/sourcegraph/sourcegraph)$")),
+		},
+		{
+			input:    "type:repo repo:has.file(path:go.mod)",
+			revision: "abc",
+			want:     autogold.Want("with revision", BasicQuery("fork:no patterntype:literal type:repo repo:has.file(path:go.mod) archived:yes repo:^github\\.com/sourcegraph/sourcegraph$@abc")),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.want.Name(), func(t *testing.T) {
+			got, err := RepoMetadataQuery(BasicQuery(test.input), "github.com/sourcegraph/sourcegraph", test.revision, CodeInsightsQueryDefaults(true))
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.want.Equal(t, got)
+		})
+	}
+}
@@ -39,6 +39,11 @@ const authNotCommitDiffMsg = "Grouping by author is only available for diff and
 const cgInvalidQueryMsg = "Grouping by capture group is only available for regexp searches that contain a capturing group."
 const cgMultipleQueryPatternMsg = "Grouping by capture group does not support search patterns with the following: and, or, negation."
 const cgUnsupportedSelectFmt = `Grouping by capture group is not available for searches with "%s:%s".`
+const langUnsupportedFieldValueFmt = `Grouping by language is not available for searches with "%s:%s".`
+const extUnsupportedFieldValueFmt = `Grouping by file extension is not available for searches with "%s:%s".`
+const dirUnsupportedFieldValueFmt = `Grouping by directory is not available for searches with "%s:%s".`
+const commitMonthNotCommitDiffMsg = "Grouping by commit month is only available for diff and commit searches."
+const invalidDirectoryDepthMsg = "Grouping by directory requires a directory depth of at least 1."
 
 // Possible reasons that grouping would fail
 const shardTimeoutMsg = "The query was unable to complete in the allocated time."
@@ -97,6 +102,13 @@ func (r *searchAggregateResolver) Aggregations(ctx context.Context, args graphql
 		aggregationMode = types.SearchAggregationMode(*args.Mode)
 	}
 
+	directoryDepth := int(args.DirectoryDepth)
+	if aggregationMode == types.DIRECTORY_DEPTH_AGGREGATION_MODE && directoryDepth < 1 {
+		return &searchAggregationResultResolver{
+			resolver: newSearchAggregationNotAvailableResolver(notAvailableReason{reason: invalidDirectoryDepthMsg, reasonType: types.ERROR_OCCURRED}, aggregationMode),
+		}, nil
+	}
+
 	notAvailable, err := getNotAvailableReason(r.searchQuery, r.patternType, aggregationMode)
 	if notAvailable != nil {
 		return &searchAggregationResultResolver{resolver: newSearchAggregationNotAvailableResolver(*notAvailable, aggregationMode)}, nil
@@ -139,7 +151,7 @@ func (r *searchAggregateResolver) Aggregations(ctx context.Context, args graphql
 		cappedAggregator.Add(amr.Key.Group, int32(amr.Count))
 	}
 
-	countingFunc, err := aggregation.GetCountFuncForMode(r.searchQuery, r.patternType, aggregationMode)
+	countingFunc, err := aggregation.GetCountFuncForMode(r.searchQuery, r.patternType, aggregationMode, directoryDepth)
 	if err != nil {
 		r.getLogger().Debug("no aggregation counting function for mode", log.String("mode", string(aggregationMode)), log.Error(err))
 		return &searchAggregationResultResolver{
@@ -365,10 +377,14 @@ func getNotAvailableReason(query, patternType string, mode types.SearchAggregati
 
 func getAggregateBy(mode types.SearchAggregationMode) canAggregateBy {
 	checkByMode := map[types.SearchAggregationMode]canAggregateBy{
-		types.REPO_AGGREGATION_MODE:          canAggregateByRepo,
-		types.PATH_AGGREGATION_MODE:          canAggregateByPath,
-		types.AUTHOR_AGGREGATION_MODE:        canAggregateByAuthor,
-		types.CAPTURE_GROUP_AGGREGATION_MODE: canAggregateByCaptureGroup,
+		types.REPO_AGGREGATION_MODE:            canAggregateByRepo,
+		types.PATH_AGGREGATION_MODE:            canAggregateByPath,
+		types.AUTHOR_AGGREGATION_MODE:          canAggregateByAuthor,
+		types.CAPTURE_GROUP_AGGREGATION_MODE:   canAggregateByCaptureGroup,
+		types.LANGUAGE_AGGREGATION_MODE:        canAggregateByFileFunc(langUnsupportedFieldValueFmt),
+		types.FILE_EXTENSION_AGGREGATION_MODE:  canAggregateByFileFunc(extUnsupportedFieldValueFmt),
+		types.DIRECTORY_DEPTH_AGGREGATION_MODE: canAggregateByFileFunc(dirUnsupportedFieldValueFmt),
+		types.COMMIT_MONTH_AGGREGATION_MODE:    canAggregateByCommitMonth,
 	}
 	canAggregateByFunc, ok := checkByMode[mode]
 	if !ok {
@@ -394,26 +410,43 @@ func canAggregateByRepo(searchQuery, patternType string) (bool, *notAvailableRea
 }
 
 func canAggregateByPath(searchQuery, patternType string) (bool, *notAvailableReason, error) {
-	plan, err := querybuilder.ParseQuery(searchQuery, patternType)
-	if err != nil {
-		return false, &notAvailableReason{reason: invalidQueryMsg, reasonType: types.INVALID_QUERY}, errors.Wrapf(err, "ParseQuery")
-	}
-	parameters := querybuilder.ParametersFromQueryPlan(plan)
-	// cannot aggregate over:
-	// - searches by commit, diff or repo
-	for _, parameter := range parameters {
-		if parameter.Field == query.FieldSelect || parameter.Field == query.FieldType {
-			if strings.EqualFold(parameter.Value, "commit") || strings.EqualFold(parameter.Value, "diff") || strings.EqualFold(parameter.Value, "repo") {
-				reason := fmt.Sprintf(fileUnsupportedFieldValueFmt,
-					parameter.Field, parameter.Value)
-				return false, &notAvailableReason{reason: reason, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
+	return canAggregateByFileFunc(fileUnsupportedFieldValueFmt)(searchQuery, patternType)
+}
+
+// canAggregateByFileFunc returns a check for modes that group by a property of
+// the path of file results. unsupportedFmt formats the reason for queries that
+// don't return files.
+func canAggregateByFileFunc(unsupportedFmt string) canAggregateBy {
+	return func(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+		plan, err := querybuilder.ParseQuery(searchQuery, patternType)
+		if err != nil {
+			return false, &notAvailableReason{reason: invalidQueryMsg, reasonType: types.INVALID_QUERY}, errors.Wrapf(err, "ParseQuery")
+		}
+		parameters := querybuilder.ParametersFromQueryPlan(plan)
+		// cannot aggregate over:
+		// - searches by commit, diff or repo
+		for _, parameter := range parameters {
+			if parameter.Field == query.FieldSelect || parameter.Field == query.FieldType {
+				if strings.EqualFold(parameter.Value, "commit") || strings.EqualFold(parameter.Value, "diff") || strings.EqualFold(parameter.Value, "repo") {
+					reason := fmt.Sprintf(unsupportedFmt,
+						parameter.Field, parameter.Value)
+					return false, &notAvailableReason{reason: reason, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
+				}
 			}
 		}
+		return true, nil, nil
 	}
-	return true, nil, nil
 }
 
 func canAggregateByAuthor(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByCommit(searchQuery, patternType, authNotCommitDiffMsg)
+}
+
+func canAggregateByCommitMonth(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByCommit(searchQuery, patternType, commitMonthNotCommitDiffMsg)
+}
+
+func canAggregateByCommit(searchQuery, patternType, unsupportedMsg string) (bool, *notAvailableReason, error) {
 	plan, err := querybuilder.ParseQuery(searchQuery, patternType)
 	if err != nil {
 		return false, &notAvailableReason{reason: invalidQueryMsg, reasonType: types.INVALID_QUERY}, errors.Wrapf(err, "ParseQuery")
@@ -428,7 +461,7 @@ func canAggregateByAuthor(searchQuery, patternType string) (bool, *notAvailableR
 			}
 		}
 	}
-	return false, &notAvailableReason{reason: authNotCommitDiffMsg, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
+	return false, &notAvailableReason{reason: unsupportedMsg, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
 }
 
 func canAggregateByCaptureGroup(searchQuery, patternType string) (bool, *notAvailableReason, error) {
@@ -586,6 +619,14 @@ func buildDrilldownQuery(mode types.SearchAggregationMode, originalQuery string,
 		modifierFunc = querybuilder.AddFileFilter
 	case types.AUTHOR_AGGREGATION_MODE:
 		modifierFunc = querybuilder.AddAuthorFilter
+	case types.LANGUAGE_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddLanguageFilter
+	case types.FILE_EXTENSION_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddFileExtensionFilter
+	case types.DIRECTORY_DEPTH_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddDirectoryFilter
+	case types.COMMIT_MONTH_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddCommitMonthFilter
 	case types.CAPTURE_GROUP_AGGREGATION_MODE:
 		searchType, err := client.SearchTypeFromString(patternType)
 		if err != nil {
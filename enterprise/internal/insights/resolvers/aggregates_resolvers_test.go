@@ -189,6 +189,66 @@ func Test_canAggregateByAuthor(t *testing.T) {
 	suite.Test_canAggregateBy()
 }
 
+func Test_canAggregateByFileFunc(t *testing.T) {
+	testCases := []canAggregateTestCase{
+		{
+			name:         "can aggregate for content query",
+			query:        "func(t *testing.T)",
+			canAggregate: true,
+		},
+		{
+			name:         "can aggregate for symbol query",
+			query:        "type:symbol Handler",
+			canAggregate: true,
+		},
+		{
+			name:         "cannot aggregate for query with select:repo parameter",
+			query:        "repo:contains.path(README) select:repo",
+			reason:       fmt.Sprintf(langUnsupportedFieldValueFmt, "select", "repo"),
+			canAggregate: false,
+		},
+		{
+			name:         "cannot aggregate for query with type:diff parameter",
+			query:        "insights type:diff",
+			reason:       fmt.Sprintf(langUnsupportedFieldValueFmt, "type", "diff"),
+			canAggregate: false,
+		},
+	}
+	suite := canAggregateBySuite{
+		canAggregateByFunc: canAggregateByFileFunc(langUnsupportedFieldValueFmt),
+		testCases:          testCases,
+		t:                  t,
+	}
+	suite.Test_canAggregateBy()
+}
+
+func Test_canAggregateByCommitMonth(t *testing.T) {
+	testCases := []canAggregateTestCase{
+		{
+			name:         "cannot aggregate for content query",
+			query:        "func(t *testing.T)",
+			reason:       commitMonthNotCommitDiffMsg,
+			canAggregate: false,
+		},
+		{
+			name:         "can aggregate for query with type:commit parameter",
+			query:        "type:commit fix",
+			canAggregate: true,
+		},
+		{
+			name:         "can aggregate for query with type:diff parameter",
+			query:        "type:diff fix",
+			canAggregate: true,
+		},
+	}
+	suite := canAggregateBySuite{
+		canAggregateByFunc: canAggregateByCommitMonth,
+		testCases:          testCases,
+		t:                  t,
+	}
+	suite.Test_canAggregateBy()
+}
+
 func Test_canAggregateByCaptureGroup(t *testing.T) {
 	testCases := []canAggregateTestCase{
 		{
@@ -470,6 +530,48 @@ func Test_buildDrilldownQuery(t *testing.T) {
 			patternType: "standard",
 			mode:        types.CAPTURE_GROUP_AGGREGATION_MODE,
 		},
+		{
+			want:        autogold.Want("language", "lang:Go findme"),
+			query:       "findme",
+			drilldown:   "Go",
+			patternType: "standard",
+			mode:        types.LANGUAGE_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("language_with_whitespace", `lang:"Jupyter Notebook" findme`),
+			query:       "findme",
+			drilldown:   "Jupyter Notebook",
+			patternType: "standard",
+			mode:        types.LANGUAGE_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("file_extension", `file:\.go$ findme`),
+			query:       "findme",
+			drilldown:   ".go",
+			patternType: "standard",
+			mode:        types.FILE_EXTENSION_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("directory", "file:^cmd/frontend/ findme"),
+			query:       "findme",
+			drilldown:   "cmd/frontend",
+			patternType: "standard",
+			mode:        types.DIRECTORY_DEPTH_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("root_directory", "file:^[^/]+$ findme"),
+			query:       "findme",
+			drilldown:   "/",
+			patternType: "standard",
+			mode:        types.DIRECTORY_DEPTH_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("commit_month", "type:commit after:2022-12-01 before:2023-01-01 findme"),
+			query:       "findme type:commit",
+			drilldown:   "2022-12",
+			patternType: "standard",
+			mode:        types.COMMIT_MONTH_AGGREGATION_MODE,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.want.Name(), func(t *testing.T) {
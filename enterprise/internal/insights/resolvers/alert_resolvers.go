@@ -0,0 +1,171 @@
+package resolvers
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/alerts"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const alertRuleKind = "InsightSeriesAlertRule"
+
+var _ graphqlbackend.InsightSeriesAlertRuleResolver = &insightSeriesAlertRuleResolver{}
+
+// 🚨 SECURITY: Alert rules are evaluated against series values across every repository, without
+// enforcing repository permissions. Managing them is therefore restricted to site admins.
+
+func (r *Resolver) CreateInsightSeriesAlertRule(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertRuleArgs) (graphqlbackend.InsightSeriesAlertRuleResolver, error) {
+	actr := actor.FromContext(ctx)
+	if err := auth.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+		return nil, err
+	}
+
+	input := args.Input
+	kind := types.AlertRuleKind(input.Kind)
+	var threshold float64
+	if input.Threshold != nil {
+		threshold = *input.Threshold
+	}
+	if err := alerts.ValidateRule(kind, threshold); err != nil {
+		return nil, err
+	}
+	for _, u := range []*string{input.SlackWebhookURL, input.WebhookURL} {
+		if err := validateWebhookURL(u); err != nil {
+			return nil, err
+		}
+	}
+	if !input.NotifyEmail && isEmpty(input.SlackWebhookURL) && isEmpty(input.WebhookURL) {
+		return nil, errors.New("an alert rule must notify through at least one of email, Slack or a webhook")
+	}
+
+	series, err := r.dataSeriesStore.GetDataSeries(ctx, store.GetDataSeriesArgs{SeriesID: input.SeriesId})
+	if err != nil {
+		return nil, err
+	}
+	if len(series) == 0 {
+		return nil, errors.Newf("unable to fetch series with series_id: %v", input.SeriesId)
+	}
+	if series[0].JustInTime {
+		return nil, errors.New("alert rules are not supported on just in time series")
+	}
+
+	rule, err := r.alertStore.CreateAlertRule(ctx, store.CreateAlertRuleArgs{
+		SeriesID:        series[0].ID,
+		Kind:            kind,
+		Threshold:       threshold,
+		NotifyEmail:     input.NotifyEmail,
+		SlackWebhookURL: input.SlackWebhookURL,
+		WebhookURL:      input.WebhookURL,
+		CreatedBy:       actr.UID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &insightSeriesAlertRuleResolver{rule: rule, seriesID: series[0].SeriesID}, nil
+}
+
+func (r *Resolver) DeleteInsightSeriesAlertRule(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertRuleArgs) (*graphqlbackend.EmptyResponse, error) {
+	actr := actor.FromContext(ctx)
+	if err := auth.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+		return nil, err
+	}
+
+	var id int
+	if err := relay.UnmarshalSpec(args.Id, &id); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the alert rule id")
+	}
+	if err := r.alertStore.DeleteAlertRule(ctx, id); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) InsightSeriesAlertRules(ctx context.Context, args *graphqlbackend.InsightSeriesAlertRulesArgs) ([]graphqlbackend.InsightSeriesAlertRuleResolver, error) {
+	actr := actor.FromContext(ctx)
+	if err := auth.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+		return nil, err
+	}
+
+	series, err := r.dataSeriesStore.GetDataSeries(ctx, store.GetDataSeriesArgs{IncludeDeleted: true, SeriesID: args.SeriesId})
+	if err != nil {
+		return nil, err
+	}
+	if len(series) == 0 {
+		return nil, errors.Newf("unable to fetch series with series_id: %v", args.SeriesId)
+	}
+
+	rules, err := r.alertStore.ListAlertRules(ctx, series[0].ID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.InsightSeriesAlertRuleResolver, 0, len(rules))
+	for _, rule := range rules {
+		resolvers = append(resolvers, &insightSeriesAlertRuleResolver{rule: rule, seriesID: series[0].SeriesID})
+	}
+	return resolvers, nil
+}
+
+func validateWebhookURL(u *string) error {
+	if isEmpty(u) {
+		return nil
+	}
+	parsed, err := url.Parse(*u)
+	if err != nil {
+		return errors.Wrapf(err, "invalid webhook URL %q", *u)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return errors.Newf("invalid webhook URL %q: must be an http or https URL", *u)
+	}
+	return nil
+}
+
+func isEmpty(s *string) bool {
+	return s == nil || *s == ""
+}
+
+type insightSeriesAlertRuleResolver struct {
+	rule     *types.AlertRule
+	seriesID string
+}
+
+func (a *insightSeriesAlertRuleResolver) ID() graphql.ID {
+	return relay.MarshalID(alertRuleKind, a.rule.ID)
+}
+
+func (a *insightSeriesAlertRuleResolver) SeriesId() string {
+	return a.seriesID
+}
+
+func (a *insightSeriesAlertRuleResolver) Kind() string {
+	return string(a.rule.Kind)
+}
+
+func (a *insightSeriesAlertRuleResolver) Threshold() float64 {
+	return a.rule.Threshold
+}
+
+func (a *insightSeriesAlertRuleResolver) NotifyEmail() bool {
+	return a.rule.NotifyEmail
+}
+
+func (a *insightSeriesAlertRuleResolver) SlackWebhookURL() *string {
+	return a.rule.SlackWebhookURL
+}
+
+func (a *insightSeriesAlertRuleResolver) WebhookURL() *string {
+	return a.rule.WebhookURL
+}
+
+func (a *insightSeriesAlertRuleResolver) LastTriggeredAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(a.rule.LastTriggeredAt)
+}
@@ -0,0 +1,568 @@
+package resolvers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/declarative"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var _ graphqlbackend.ApplyInsightsDashboardsPayloadResolver = &applyInsightsDashboardsPayloadResolver{}
+var _ graphqlbackend.InsightsDashboardsChangeResolver = &insightsDashboardsChangeResolver{}
+
+const (
+	changeCreate              = "CREATE"
+	changeUpdate              = "UPDATE"
+	changeUnchanged           = "UNCHANGED"
+	changeAddToDashboard      = "ADD_TO_DASHBOARD"
+	changeRemoveFromDashboard = "REMOVE_FROM_DASHBOARD"
+)
+
+func (r *Resolver) ExportInsightsDashboards(ctx context.Context, args *graphqlbackend.ExportInsightsDashboardsArgs) (string, error) {
+	userIDs, orgIDs, err := getUserPermissions(ctx, r.postgresDB.Orgs())
+	if err != nil {
+		return "", errors.Wrap(err, "getUserPermissions")
+	}
+
+	var dashboards []*types.Dashboard
+	if args.Ids == nil || len(*args.Ids) > 0 {
+		queryArgs := store.DashboardQueryArgs{UserID: userIDs, OrgID: orgIDs}
+		if args.Ids != nil {
+			for _, id := range *args.Ids {
+				dashboardID, err := unmarshalDashboardID(id)
+				if err != nil {
+					return "", errors.Wrap(err, "unable to unmarshal dashboard id")
+				}
+				if dashboardID.isVirtualized() {
+					return "", errors.New("unable to export a virtualized dashboard")
+				}
+				queryArgs.ID = append(queryArgs.ID, int(dashboardID.Arg))
+			}
+		}
+		dashboards, err = r.dashboardStore.GetDashboards(ctx, queryArgs)
+		if err != nil {
+			return "", errors.Wrap(err, "GetDashboards")
+		}
+	}
+
+	spec := declarative.Spec{Dashboards: []declarative.Dashboard{}}
+	for _, dashboard := range dashboards {
+		grants, err := r.exportDashboardGrants(ctx, dashboard)
+		if err != nil {
+			return "", err
+		}
+		views, err := r.dashboardInsights(ctx, dashboard.ID)
+		if err != nil {
+			return "", err
+		}
+		d := declarative.Dashboard{Title: dashboard.Title, Grants: grants}
+		for _, view := range views {
+			d.Insights = append(d.Insights, declarative.FromInsight(view))
+		}
+		spec.Dashboards = append(spec.Dashboards, d)
+	}
+
+	data, err := declarative.Marshal(&spec)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// exportDashboardGrants returns the grants of a dashboard by user and organization name.
+func (r *Resolver) exportDashboardGrants(ctx context.Context, dashboard *types.Dashboard) (*declarative.Grants, error) {
+	grants := &declarative.Grants{Global: dashboard.GlobalGrant}
+	for _, id := range dashboard.UserIdGrants {
+		user, err := r.postgresDB.Users().GetByID(ctx, int32(id))
+		if err != nil {
+			return nil, errors.Wrapf(err, "dashboard %q: unable to fetch granted user", dashboard.Title)
+		}
+		grants.Users = append(grants.Users, user.Username)
+	}
+	for _, id := range dashboard.OrgIdGrants {
+		org, err := r.postgresDB.Orgs().GetByID(ctx, int32(id))
+		if err != nil {
+			return nil, errors.Wrapf(err, "dashboard %q: unable to fetch granted organization", dashboard.Title)
+		}
+		grants.Organizations = append(grants.Organizations, org.Name)
+	}
+	return grants, nil
+}
+
+// dashboardInsights returns the insights on a dashboard, in the order they were added.
+func (r *Resolver) dashboardInsights(ctx context.Context, dashboardID int) ([]types.Insight, error) {
+	viewSeries, err := r.insightStore.GetAllOnDashboard(ctx, store.InsightsOnDashboardQueryArgs{DashboardID: dashboardID})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetAllOnDashboard")
+	}
+	views := r.insightStore.GroupByView(ctx, viewSeries)
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].DashboardViewId < views[j].DashboardViewId
+	})
+	return views, nil
+}
+
+// dashboardPlan is the set of changes that converge a stored dashboard to its definition.
+type dashboardPlan struct {
+	spec declarative.Dashboard
+	// existing is nil if the dashboard must be created.
+	existing *types.Dashboard
+	// grants is nil if the grants of an existing dashboard already match the definition.
+	grants   *graphqlbackend.InsightsPermissionGrants
+	insights []insightPlan
+	remove   []types.Insight
+}
+
+type insightPlan struct {
+	spec declarative.Insight
+	// existing is nil if the insight must be created.
+	existing    *types.Insight
+	update      bool
+	onDashboard bool
+}
+
+// ApplyInsightsDashboards converges the stored dashboards to a declarative definition. Every change is
+// planned before any is made, so that an invalid definition doesn't leave dashboards partially applied.
+// Changes are made through the same code paths as the individual mutations, and are not made atomically.
+// Applying a definition is idempotent, so a failed apply can be retried.
+func (r *Resolver) ApplyInsightsDashboards(ctx context.Context, args *graphqlbackend.ApplyInsightsDashboardsArgs) (graphqlbackend.ApplyInsightsDashboardsPayloadResolver, error) {
+	if actor.FromContext(ctx).UID == 0 {
+		return nil, errors.New("must be signed in to apply insights dashboards")
+	}
+	spec, err := declarative.Parse([]byte(args.Input.Yaml))
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := r.planDashboards(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &applyInsightsDashboardsPayloadResolver{changes: []graphqlbackend.InsightsDashboardsChangeResolver{}}
+	for _, plan := range plans {
+		if err := r.applyDashboardPlan(ctx, plan, args.Input.DryRun, payload); err != nil {
+			return nil, errors.Wrapf(err, "dashboard %q", plan.spec.Title)
+		}
+	}
+	return payload, nil
+}
+
+func (r *Resolver) planDashboards(ctx context.Context, spec *declarative.Spec) ([]dashboardPlan, error) {
+	userIDs, orgIDs, err := getUserPermissions(ctx, r.postgresDB.Orgs())
+	if err != nil {
+		return nil, errors.Wrap(err, "getUserPermissions")
+	}
+	dashboards, err := r.dashboardStore.GetDashboards(ctx, store.DashboardQueryArgs{UserID: userIDs, OrgID: orgIDs})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDashboards")
+	}
+	dashboardsByTitle := make(map[string][]*types.Dashboard, len(dashboards))
+	for _, dashboard := range dashboards {
+		dashboardsByTitle[dashboard.Title] = append(dashboardsByTitle[dashboard.Title], dashboard)
+	}
+
+	plans := make([]dashboardPlan, 0, len(spec.Dashboards))
+	for _, d := range spec.Dashboards {
+		plan := dashboardPlan{spec: d}
+		switch existing := dashboardsByTitle[d.Title]; len(existing) {
+		case 0:
+		case 1:
+			plan.existing = existing[0]
+		default:
+			return nil, errors.Newf("dashboard %q: %d dashboards have this title, rename all but one to apply the definition", d.Title, len(existing))
+		}
+
+		if err := r.planDashboardGrants(ctx, &plan, userIDs, orgIDs); err != nil {
+			return nil, errors.Wrapf(err, "dashboard %q", d.Title)
+		}
+		if err := r.planInsights(ctx, &plan, userIDs, orgIDs); err != nil {
+			return nil, errors.Wrapf(err, "dashboard %q", d.Title)
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+func (r *Resolver) planDashboardGrants(ctx context.Context, plan *dashboardPlan, userIDs, orgIDs []int) error {
+	if plan.spec.Grants == nil {
+		if plan.existing == nil {
+			users := []graphql.ID{graphqlbackend.MarshalUserID(actor.FromContext(ctx).UID)}
+			plan.grants = &graphqlbackend.InsightsPermissionGrants{Users: &users}
+		}
+		return nil
+	}
+
+	var grantedUsers, grantedOrgs []int64
+	users := []graphql.ID{}
+	for _, username := range plan.spec.Grants.Users {
+		user, err := r.postgresDB.Users().GetByUsername(ctx, username)
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				return errors.Newf("user %q not found", username)
+			}
+			return err
+		}
+		grantedUsers = append(grantedUsers, int64(user.ID))
+		users = append(users, graphqlbackend.MarshalUserID(user.ID))
+	}
+	orgs := []graphql.ID{}
+	for _, name := range plan.spec.Grants.Organizations {
+		org, err := r.postgresDB.Orgs().GetByName(ctx, name)
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				return errors.Newf("organization %q not found", name)
+			}
+			return err
+		}
+		grantedOrgs = append(grantedOrgs, int64(org.ID))
+		orgs = append(orgs, graphqlbackend.MarshalOrgID(org.ID))
+	}
+	global := plan.spec.Grants.Global
+	grants := graphqlbackend.InsightsPermissionGrants{Users: &users, Organizations: &orgs, Global: &global}
+
+	dashboardGrants, err := parseDashboardGrants(grants)
+	if err != nil {
+		return err
+	}
+	if len(dashboardGrants) == 0 {
+		return errors.New("dashboard must be granted to at least one user, organization or globally")
+	}
+	if !hasPermissionForGrants(dashboardGrants, userIDs, orgIDs) {
+		return errors.New("user does not have permission to grant this dashboard")
+	}
+
+	if plan.existing == nil || plan.existing.GlobalGrant != global ||
+		!sameIDs(plan.existing.UserIdGrants, grantedUsers) || !sameIDs(plan.existing.OrgIdGrants, grantedOrgs) {
+		plan.grants = &grants
+	}
+	return nil
+}
+
+func (r *Resolver) planInsights(ctx context.Context, plan *dashboardPlan, userIDs, orgIDs []int) error {
+	var onDashboard []types.Insight
+	if plan.existing != nil {
+		var err error
+		onDashboard, err = r.dashboardInsights(ctx, plan.existing.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	matched := map[string]bool{}
+	for _, insight := range plan.spec.Insights {
+		existing, isOnDashboard, err := r.findInsight(ctx, onDashboard, insight, userIDs, orgIDs)
+		if err != nil {
+			return errors.Wrapf(err, "insight %q", insight.Title)
+		}
+
+		p := insightPlan{spec: insight, existing: existing, onDashboard: isOnDashboard}
+		if existing != nil {
+			if matched[existing.UniqueID] {
+				return errors.Newf("insight %q: insight %s is defined more than once", insight.Title, existing.UniqueID)
+			}
+			matched[existing.UniqueID] = true
+
+			if presentation := declarative.FromInsight(*existing).Presentation; presentation != insight.Presentation {
+				return errors.Newf("insight %q: unable to change a %s chart to a %s chart, create a new insight instead", insight.Title, presentation, insight.Presentation)
+			}
+			p.update = !declarative.Equal(*existing, insight)
+		}
+		plan.insights = append(plan.insights, p)
+	}
+
+	for _, view := range onDashboard {
+		if !matched[view.UniqueID] {
+			plan.remove = append(plan.remove, view)
+		}
+	}
+	return nil
+}
+
+// findInsight returns the stored insight an insight definition refers to, or nil if it must be created.
+// Insights are found by ID if it is set and visible to the user, and by title on the dashboard otherwise.
+func (r *Resolver) findInsight(ctx context.Context, onDashboard []types.Insight, insight declarative.Insight, userIDs, orgIDs []int) (_ *types.Insight, isOnDashboard bool, _ error) {
+	if insight.ID != "" {
+		for i := range onDashboard {
+			if onDashboard[i].UniqueID == insight.ID {
+				return &onDashboard[i], true, nil
+			}
+		}
+		views, err := r.insightStore.GetAllMapped(ctx, store.InsightQueryArgs{UniqueID: insight.ID, UserID: userIDs, OrgID: orgIDs})
+		if err != nil {
+			return nil, false, errors.Wrap(err, "GetAllMapped")
+		}
+		if len(views) > 0 {
+			return &views[0], false, nil
+		}
+	}
+
+	var found *types.Insight
+	for i := range onDashboard {
+		if onDashboard[i].Title != insight.Title {
+			continue
+		}
+		if found != nil {
+			return nil, false, errors.New("more than one insight on the dashboard has this title, set the id of the insight to apply the definition")
+		}
+		found = &onDashboard[i]
+	}
+	return found, found != nil, nil
+}
+
+func (r *Resolver) applyDashboardPlan(ctx context.Context, plan dashboardPlan, dryRun bool, payload *applyInsightsDashboardsPayloadResolver) error {
+	var dashboardID graphql.ID
+	switch {
+	case plan.existing == nil:
+		payload.add(changeCreate, plan.spec.Title, nil)
+		if !dryRun {
+			created, err := r.CreateInsightsDashboard(ctx, &graphqlbackend.CreateInsightsDashboardArgs{
+				Input: graphqlbackend.CreateInsightsDashboardInput{Title: plan.spec.Title, Grants: *plan.grants},
+			})
+			if err != nil {
+				return errors.Wrap(err, "CreateInsightsDashboard")
+			}
+			if created == nil {
+				return errors.New("dashboard was not created")
+			}
+			dashboard, err := created.Dashboard(ctx)
+			if err != nil {
+				return err
+			}
+			dashboardID = dashboard.ID()
+		}
+	case plan.grants != nil:
+		payload.add(changeUpdate, plan.spec.Title, nil)
+		dashboardID = newRealDashboardID(int64(plan.existing.ID)).marshal()
+		if !dryRun {
+			_, err := r.UpdateInsightsDashboard(ctx, &graphqlbackend.UpdateInsightsDashboardArgs{
+				Id:    dashboardID,
+				Input: graphqlbackend.UpdateInsightsDashboardInput{Grants: plan.grants},
+			})
+			if err != nil {
+				return errors.Wrap(err, "UpdateInsightsDashboard")
+			}
+		}
+	default:
+		payload.add(changeUnchanged, plan.spec.Title, nil)
+		dashboardID = newRealDashboardID(int64(plan.existing.ID)).marshal()
+	}
+
+	for _, p := range plan.insights {
+		title := p.spec.Title
+		switch {
+		case p.existing == nil:
+			payload.add(changeCreate, plan.spec.Title, &title)
+			if !dryRun {
+				if err := r.createDeclaredInsight(ctx, dashboardID, p.spec); err != nil {
+					return errors.Wrapf(err, "insight %q", title)
+				}
+			}
+		case p.update:
+			payload.add(changeUpdate, plan.spec.Title, &title)
+			if !dryRun {
+				if err := r.updateDeclaredInsight(ctx, p.existing, p.spec); err != nil {
+					return errors.Wrapf(err, "insight %q", title)
+				}
+			}
+		case p.onDashboard:
+			payload.add(changeUnchanged, plan.spec.Title, &title)
+		}
+
+		if p.existing != nil && !p.onDashboard {
+			payload.add(changeAddToDashboard, plan.spec.Title, &title)
+			if !dryRun {
+				_, err := r.AddInsightViewToDashboard(ctx, &graphqlbackend.AddInsightViewToDashboardArgs{
+					Input: graphqlbackend.AddInsightViewToDashboardInput{
+						DashboardID:   dashboardID,
+						InsightViewID: relay.MarshalID(insightKind, p.existing.UniqueID),
+					},
+				})
+				if err != nil {
+					return errors.Wrapf(err, "insight %q: AddInsightViewToDashboard", title)
+				}
+			}
+		}
+	}
+
+	for _, view := range plan.remove {
+		title := view.Title
+		payload.add(changeRemoveFromDashboard, plan.spec.Title, &title)
+		if !dryRun {
+			_, err := r.RemoveInsightViewFromDashboard(ctx, &graphqlbackend.RemoveInsightViewFromDashboardArgs{
+				Input: graphqlbackend.RemoveInsightViewFromDashboardInput{
+					DashboardID:   dashboardID,
+					InsightViewID: relay.MarshalID(insightKind, view.UniqueID),
+				},
+			})
+			if err != nil {
+				return errors.Wrapf(err, "insight %q: RemoveInsightViewFromDashboard", title)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Resolver) createDeclaredInsight(ctx context.Context, dashboardID graphql.ID, insight declarative.Insight) error {
+	dashboards := []graphql.ID{dashboardID}
+	if insight.Presentation == declarative.PieChart {
+		_, err := r.CreatePieChartSearchInsight(ctx, &graphqlbackend.CreatePieChartSearchInsightArgs{
+			Input: graphqlbackend.CreatePieChartSearchInsightInput{
+				Query:               insight.Query,
+				RepositoryScope:     graphqlbackend.RepositoryScopeInput{Repositories: insight.Repositories},
+				PresentationOptions: pieChartOptionsInput(insight),
+				Dashboards:          &dashboards,
+			},
+		})
+		return err
+	}
+
+	viewControls := viewControlsInput(insight)
+	_, err := r.CreateLineChartSearchInsight(ctx, &graphqlbackend.CreateLineChartSearchInsightArgs{
+		Input: graphqlbackend.CreateLineChartSearchInsightInput{
+			DataSeries:   dataSeriesInput(nil, insight),
+			Options:      graphqlbackend.LineChartOptionsInput{Title: &insight.Title},
+			Dashboards:   &dashboards,
+			ViewControls: &viewControls,
+		},
+	})
+	return err
+}
+
+func (r *Resolver) updateDeclaredInsight(ctx context.Context, existing *types.Insight, insight declarative.Insight) error {
+	id := relay.MarshalID(insightKind, existing.UniqueID)
+	if insight.Presentation == declarative.PieChart {
+		_, err := r.UpdatePieChartSearchInsight(ctx, &graphqlbackend.UpdatePieChartSearchInsightArgs{
+			Id: id,
+			Input: graphqlbackend.UpdatePieChartSearchInsightInput{
+				Query:               insight.Query,
+				RepositoryScope:     graphqlbackend.RepositoryScopeInput{Repositories: insight.Repositories},
+				PresentationOptions: pieChartOptionsInput(insight),
+			},
+		})
+		return err
+	}
+
+	_, err := r.UpdateLineChartSearchInsight(ctx, &graphqlbackend.UpdateLineChartSearchInsightArgs{
+		Id: id,
+		Input: graphqlbackend.UpdateLineChartSearchInsightInput{
+			DataSeries:          dataSeriesInput(existing.Series, insight),
+			PresentationOptions: graphqlbackend.LineChartOptionsInput{Title: &insight.Title},
+			ViewControls:        viewControlsInput(insight),
+		},
+	})
+	return err
+}
+
+// dataSeriesInput returns the data series of a line chart definition. Series that compute the same data
+// as an existing series reuse it, so that their recorded points are kept.
+func dataSeriesInput(existing []types.InsightViewSeries, insight declarative.Insight) []graphqlbackend.LineChartSearchInsightDataSeriesInput {
+	used := make([]bool, len(existing))
+	inputs := make([]graphqlbackend.LineChartSearchInsightDataSeriesInput, 0, len(insight.Series))
+	for _, s := range insight.ResolvedSeries() {
+		s := s
+		input := graphqlbackend.LineChartSearchInsightDataSeriesInput{
+			Query: s.Query,
+			TimeScope: graphqlbackend.TimeScopeInput{StepInterval: &graphqlbackend.TimeIntervalStepInput{
+				Unit:  string(s.Interval.Unit),
+				Value: int32(s.Interval.Value),
+			}},
+			RepositoryScope:            graphqlbackend.RepositoryScopeInput{Repositories: s.Repositories},
+			Options:                    graphqlbackend.LineChartDataSeriesOptionsInput{Label: &s.Label, LineColor: &s.Color},
+			GeneratedFromCaptureGroups: &s.GeneratedFromCaptureGroups,
+		}
+		if s.GroupBy != "" {
+			input.GroupBy = &s.GroupBy
+		}
+		for i, e := range existing {
+			if !used[i] && declarative.SeriesMatches(e, s) {
+				used[i] = true
+				seriesID := e.SeriesID
+				input.SeriesId = &seriesID
+				break
+			}
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs
+}
+
+func viewControlsInput(insight declarative.Insight) graphqlbackend.InsightViewControlsInput {
+	var controls graphqlbackend.InsightViewControlsInput
+	if f := insight.Filters; f != nil {
+		if f.IncludeRepoRegex != "" {
+			controls.Filters.IncludeRepoRegex = &f.IncludeRepoRegex
+		}
+		if f.ExcludeRepoRegex != "" {
+			controls.Filters.ExcludeRepoRegex = &f.ExcludeRepoRegex
+		}
+		if len(f.SearchContexts) > 0 {
+			controls.Filters.SearchContexts = &f.SearchContexts
+		}
+	}
+	if d := insight.SeriesDisplay; d != nil {
+		controls.SeriesDisplayOptions.Limit = d.Limit
+		if d.SortMode != "" {
+			controls.SeriesDisplayOptions.SortOptions = &graphqlbackend.SeriesSortOptionsInput{
+				Mode:      string(d.SortMode),
+				Direction: string(d.SortDirection),
+			}
+		}
+	}
+	return controls
+}
+
+func pieChartOptionsInput(insight declarative.Insight) graphqlbackend.PieChartOptionsInput {
+	options := graphqlbackend.PieChartOptionsInput{Title: insight.Title}
+	if insight.OtherThreshold != nil {
+		options.OtherThreshold = *insight.OtherThreshold
+	}
+	return options
+}
+
+func sameIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[int64]bool, len(a))
+	for _, id := range a {
+		set[id] = true
+	}
+	for _, id := range b {
+		if !set[id] {
+			return false
+		}
+	}
+	return true
+}
+
+type applyInsightsDashboardsPayloadResolver struct {
+	changes []graphqlbackend.InsightsDashboardsChangeResolver
+}
+
+func (p *applyInsightsDashboardsPayloadResolver) add(operation, dashboard string, insight *string) {
+	p.changes = append(p.changes, &insightsDashboardsChangeResolver{operation: operation, dashboard: dashboard, insight: insight})
+}
+
+func (p *applyInsightsDashboardsPayloadResolver) Changes() []graphqlbackend.InsightsDashboardsChangeResolver {
+	return p.changes
+}
+
+type insightsDashboardsChangeResolver struct {
+	operation string
+	dashboard string
+	insight   *string
+}
+
+func (c *insightsDashboardsChangeResolver) Operation() string { return c.operation }
+func (c *insightsDashboardsChangeResolver) Dashboard() string { return c.dashboard }
+func (c *insightsDashboardsChangeResolver) Insight() *string  { return c.insight }
@@ -0,0 +1,55 @@
+package resolvers
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/declarative"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestDataSeriesInput(t *testing.T) {
+	existing := []types.InsightViewSeries{
+		{SeriesID: "a", Query: "log15.", SampleIntervalUnit: "MONTH", SampleIntervalValue: 1},
+		{SeriesID: "b", Query: "log.Scoped", SampleIntervalUnit: "MONTH", SampleIntervalValue: 1},
+	}
+	insight := declarative.Insight{
+		Title:    "Logging",
+		Interval: &declarative.Interval{Unit: types.Month, Value: 1},
+		Series: []declarative.Series{
+			{Label: "renamed", Query: "log.Scoped"},
+			{Label: "new", Query: "log.Scoped", Interval: &declarative.Interval{Unit: types.Week, Value: 1}},
+			{Label: "log15", Query: "log15.", GeneratedFromCaptureGroups: true, GroupBy: "repo"},
+		},
+	}
+
+	inputs := dataSeriesInput(existing, insight)
+	if len(inputs) != 3 {
+		t.Fatalf("unexpected number of series: %d", len(inputs))
+	}
+
+	// The interval falls back to the insight and the series is matched regardless of its label.
+	if inputs[0].SeriesId == nil || *inputs[0].SeriesId != "b" {
+		t.Errorf("expected series to reuse existing series b, got %v", inputs[0].SeriesId)
+	}
+	if *inputs[0].Options.Label != "renamed" || inputs[0].TimeScope.StepInterval.Unit != "MONTH" {
+		t.Errorf("unexpected series input: %+v", inputs[0])
+	}
+	if inputs[1].SeriesId != nil {
+		t.Errorf("expected series with a different interval to be created, got %q", *inputs[1].SeriesId)
+	}
+	if inputs[2].SeriesId != nil {
+		t.Errorf("expected capture group series to be created, got %q", *inputs[2].SeriesId)
+	}
+	if inputs[2].GroupBy == nil || *inputs[2].GroupBy != "REPO" {
+		t.Errorf("unexpected group by: %v", inputs[2].GroupBy)
+	}
+}
+
+func TestSameIDs(t *testing.T) {
+	if !sameIDs([]int64{1, 2}, []int64{2, 1}) {
+		t.Error("expected IDs in a different order to be the same")
+	}
+	if sameIDs([]int64{1, 2}, []int64{1}) || sameIDs([]int64{1}, []int64{2}) {
+		t.Error("expected different IDs not to be the same")
+	}
+}
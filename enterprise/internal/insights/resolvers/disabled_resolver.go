@@ -47,6 +47,26 @@ func (r *disabledResolver) InsightSeriesQueryStatus(ctx context.Context) ([]grap
 	return nil, errors.New(r.reason)
 }
 
+func (r *disabledResolver) CreateInsightSeriesAlertRule(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertRuleArgs) (graphqlbackend.InsightSeriesAlertRuleResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) DeleteInsightSeriesAlertRule(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertRuleArgs) (*graphqlbackend.EmptyResponse, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) InsightSeriesAlertRules(ctx context.Context, args *graphqlbackend.InsightSeriesAlertRulesArgs) ([]graphqlbackend.InsightSeriesAlertRuleResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) ExportInsightsDashboards(ctx context.Context, args *graphqlbackend.ExportInsightsDashboardsArgs) (string, error) {
+	return "", errors.New(r.reason)
+}
+
+func (r *disabledResolver) ApplyInsightsDashboards(ctx context.Context, args *graphqlbackend.ApplyInsightsDashboardsArgs) (graphqlbackend.ApplyInsightsDashboardsPayloadResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
 func (r *disabledResolver) CreateLineChartSearchInsight(ctx context.Context, args *graphqlbackend.CreateLineChartSearchInsightArgs) (graphqlbackend.InsightViewPayloadResolver, error) {
 	return nil, errors.New(r.reason)
 }
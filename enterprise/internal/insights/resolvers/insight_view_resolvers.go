@@ -1011,6 +1011,10 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, scopedBa
 	if series.GeneratedFromCaptureGroups != nil {
 		dynamic = *series.GeneratedFromCaptureGroups
 	}
+	generationMethod, err := searchGenerationMethod(series)
+	if err != nil {
+		return nil, errors.Wrap(err, "query validation")
+	}
 
 	groupBy := lowercaseGroupBy(series.GroupBy)
 	var nextRecordingAfter time.Time
@@ -1050,7 +1054,7 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, scopedBa
 			SampleIntervalValue:        int(series.TimeScope.StepInterval.Value),
 			GeneratedFromCaptureGroups: dynamic,
 			JustInTime:                 len(repos) > 0 && !deprecateJustInTime,
-			GenerationMethod:           searchGenerationMethod(series),
+			GenerationMethod:           generationMethod,
 			GroupBy:                    groupBy,
 			NextRecordingAfter:         nextRecordingAfter,
 			OldestHistoricalAt:         oldestHistoricalAt,
@@ -1096,14 +1100,24 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, scopedBa
 	return &seriesToAdd, nil
 }
 
-func searchGenerationMethod(series graphqlbackend.LineChartSearchInsightDataSeriesInput) types.GenerationMethod {
+func searchGenerationMethod(series graphqlbackend.LineChartSearchInsightDataSeriesInput) (types.GenerationMethod, error) {
 	if series.GeneratedFromCaptureGroups != nil && *series.GeneratedFromCaptureGroups {
 		if series.GroupBy != nil {
-			return types.MappingCompute
+			return types.MappingCompute, nil
+		}
+		return types.SearchCompute, nil
+	}
+	if series.GeneratedFromRepoMetadata != nil && *series.GeneratedFromRepoMetadata {
+		isRepoMetadata, err := querybuilder.IsRepoMetadataQuery(querybuilder.BasicQuery(series.Query))
+		if err != nil {
+			return "", err
+		}
+		if !isRepoMetadata {
+			return "", errors.New("repository metadata series require a query that only matches repositories")
 		}
-		return types.SearchCompute
+		return types.RepoMetadata, nil
 	}
-	return types.Search
+	return types.Search, nil
 }
 
 func seriesFound(existingSeries types.InsightViewSeries, inputSeries []graphqlbackend.LineChartSearchInsightDataSeriesInput) bool {
@@ -546,3 +546,51 @@ func TestRemoveClosePoints(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchGenerationMethod(t *testing.T) {
+	yes := true
+	tests := []struct {
+		name    string
+		series  graphqlbackend.LineChartSearchInsightDataSeriesInput
+		want    types.GenerationMethod
+		wantErr bool
+	}{
+		{
+			name:   "repo query without opt-in",
+			series: graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "type:repo archived:only"},
+			want:   types.Search,
+		},
+		{
+			name:   "repo query with opt-in",
+			series: graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "type:repo archived:only", GeneratedFromRepoMetadata: &yes},
+			want:   types.RepoMetadata,
+		},
+		{
+			name:    "content query with opt-in",
+			series:  graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "todo", GeneratedFromRepoMetadata: &yes},
+			wantErr: true,
+		},
+		{
+			name:   "capture groups",
+			series: graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "(\\w+)", GeneratedFromCaptureGroups: &yes},
+			want:   types.SearchCompute,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := searchGenerationMethod(test.series)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("unexpected generation method. want=%s have=%s", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// AlertStore stores the threshold alert rules of insight series.
+type AlertStore struct {
+	*basestore.Store
+	Now func() time.Time
+}
+
+// NewAlertStore returns a new AlertStore backed by the given Postgres db.
+func NewAlertStore(db edb.InsightsDB) *AlertStore {
+	return &AlertStore{Store: basestore.NewWithHandle(db.Handle()), Now: time.Now}
+}
+
+// With creates a new AlertStore with the given basestore.Shareable store as the underlying basestore.Store.
+// Needed to implement the basestore.Store interface
+func (s *AlertStore) With(other basestore.ShareableStore) *AlertStore {
+	return &AlertStore{Store: s.Store.With(other), Now: s.Now}
+}
+
+func (s *AlertStore) Transact(ctx context.Context) (*AlertStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &AlertStore{Store: txBase, Now: s.Now}, err
+}
+
+type CreateAlertRuleArgs struct {
+	SeriesID        int
+	Kind            types.AlertRuleKind
+	Threshold       float64
+	NotifyEmail     bool
+	SlackWebhookURL *string
+	WebhookURL      *string
+	CreatedBy       int32
+}
+
+func (s *AlertStore) CreateAlertRule(ctx context.Context, args CreateAlertRuleArgs) (*types.AlertRule, error) {
+	rules, err := scanAlertRules(s.Query(ctx, sqlf.Sprintf(createAlertRuleSql,
+		args.SeriesID,
+		args.Kind,
+		args.Threshold,
+		args.NotifyEmail,
+		args.SlackWebhookURL,
+		args.WebhookURL,
+		args.CreatedBy,
+		s.Now(),
+	)))
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateAlertRule")
+	}
+	if len(rules) == 0 {
+		return nil, errors.New("CreateAlertRule: no rule returned")
+	}
+	return rules[0], nil
+}
+
+// GetAlertRule returns the rule with the given ID, or nil if it doesn't exist.
+func (s *AlertStore) GetAlertRule(ctx context.Context, id int) (*types.AlertRule, error) {
+	rules, err := scanAlertRules(s.Query(ctx, sqlf.Sprintf(getAlertRulesSql, sqlf.Sprintf("id = %s", id))))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get alert rule with id: %d", id)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	return rules[0], nil
+}
+
+// ListAlertRules returns the rules of the series with the given insight_series.id.
+func (s *AlertStore) ListAlertRules(ctx context.Context, seriesID int) ([]*types.AlertRule, error) {
+	rules, err := scanAlertRules(s.Query(ctx, sqlf.Sprintf(getAlertRulesSql, sqlf.Sprintf("series_id = %s", seriesID))))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list alert rules for series: %d", seriesID)
+	}
+	return rules, nil
+}
+
+func (s *AlertStore) DeleteAlertRule(ctx context.Context, id int) error {
+	err := s.Exec(ctx, sqlf.Sprintf(deleteAlertRuleSql, id))
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete alert rule with id: %d", id)
+	}
+	return nil
+}
+
+// ClaimAlertRulePoint records that the rule is being evaluated against the data point at pointTime. It
+// returns false if the rule has already been evaluated against this or a more recent point, which
+// guarantees a rule is evaluated at most once per data point even with concurrent workers.
+func (s *AlertStore) ClaimAlertRulePoint(ctx context.Context, id int, pointTime time.Time) (bool, error) {
+	_, claimed, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(claimAlertRulePointSql, pointTime, id, pointTime)))
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to claim data point for alert rule with id: %d", id)
+	}
+	return claimed, nil
+}
+
+func (s *AlertStore) MarkAlertRuleTriggered(ctx context.Context, id int) error {
+	err := s.Exec(ctx, sqlf.Sprintf(markAlertRuleTriggeredSql, s.Now(), id))
+	if err != nil {
+		return errors.Wrapf(err, "failed to mark alert rule with id: %d as triggered", id)
+	}
+	return nil
+}
+
+func scanAlertRules(rows *sql.Rows, queryErr error) (_ []*types.AlertRule, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var results []*types.AlertRule
+	for rows.Next() {
+		var temp types.AlertRule
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.SeriesID,
+			&temp.Kind,
+			&temp.Threshold,
+			&temp.NotifyEmail,
+			&temp.SlackWebhookURL,
+			&temp.WebhookURL,
+			&temp.CreatedBy,
+			&temp.CreatedAt,
+			&temp.LastPointTime,
+			&temp.LastTriggeredAt,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, &temp)
+	}
+	return results, nil
+}
+
+const alertRuleColumns = `
+id, series_id, kind, threshold, notify_email, slack_webhook_url, webhook_url, created_by, created_at, last_point_time, last_triggered_at
+`
+
+const createAlertRuleSql = `
+INSERT INTO insight_series_alert_rules (series_id, kind, threshold, notify_email, slack_webhook_url, webhook_url, created_by, created_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING ` + alertRuleColumns + `;`
+
+const getAlertRulesSql = `
+SELECT ` + alertRuleColumns + `
+FROM insight_series_alert_rules
+WHERE %s
+ORDER BY id;
+`
+
+const deleteAlertRuleSql = `
+DELETE FROM insight_series_alert_rules WHERE id = %s;
+`
+
+const claimAlertRulePointSql = `
+UPDATE insight_series_alert_rules
+SET last_point_time = %s
+WHERE id = %s AND (last_point_time IS NULL OR last_point_time < %s)
+RETURNING id;
+`
+
+const markAlertRuleTriggeredSql = `
+UPDATE insight_series_alert_rules SET last_triggered_at = %s WHERE id = %s;
+`
@@ -150,6 +150,36 @@ func (s *Store) SeriesPoints(ctx context.Context, opts SeriesPointsOpts) ([]Seri
 	return points, err
 }
 
+// LatestRecordedPoints returns up to limit of the most recent recorded data points of a series, newest
+// first. Values are summed across repositories and captured values, and snapshots are not included.
+//
+// 🚨 SECURITY: Repository permissions are not enforced, so callers must not expose the values to users
+// that may not have access to every repository. 🚨
+func (s *Store) LatestRecordedPoints(ctx context.Context, seriesID string, limit int) ([]SeriesPoint, error) {
+	points := make([]SeriesPoint, 0, limit)
+	err := s.query(ctx, sqlf.Sprintf(latestRecordedPointsSql, seriesID, limit), func(sc scanner) error {
+		var point SeriesPoint
+		if err := sc.Scan(&point.SeriesID, &point.Time, &point.Value); err != nil {
+			return err
+		}
+		points = append(points, point)
+		return nil
+	})
+	return points, err
+}
+
+const latestRecordedPointsSql = `
+SELECT sub.series_id, sub.interval_time, SUM(sub.value) AS value FROM (
+	SELECT series_id, date_trunc('seconds', time) AS interval_time, repo_name_id, capture, MAX(value) AS value
+	FROM series_points
+	WHERE series_id = %s
+	GROUP BY series_id, interval_time, repo_name_id, capture
+) sub
+GROUP BY sub.series_id, sub.interval_time
+ORDER BY sub.interval_time DESC
+LIMIT %s
+`
+
 func (s *Store) LoadSeriesInMem(ctx context.Context, opts SeriesPointsOpts) (points []SeriesPoint, err error) {
 	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
 	if err != nil {
@@ -124,6 +124,7 @@ const (
 	SearchCompute  GenerationMethod = "search-compute"
 	LanguageStats  GenerationMethod = "language-stats"
 	MappingCompute GenerationMethod = "mapping-compute"
+	RepoMetadata   GenerationMethod = "repo-metadata"
 )
 
 type DirtyQuery struct {
@@ -161,6 +162,31 @@ type InsightSeriesStatus struct {
 	Completed  int
 }
 
+// AlertRuleKind is the condition under which an alert rule on a series fires.
+type AlertRuleKind string
+
+const (
+	AlertAbove         AlertRuleKind = "ABOVE"          // Fires when the value rises above the threshold.
+	AlertBelow         AlertRuleKind = "BELOW"          // Fires when the value drops below the threshold.
+	AlertPercentChange AlertRuleKind = "PERCENT_CHANGE" // Fires when the value changes by at least threshold percent between datapoints.
+	AlertCrossesZero   AlertRuleKind = "CROSSES_ZERO"   // Fires when the sign of the value changes, e.g. from zero to non-zero.
+)
+
+// AlertRule is a threshold rule evaluated against the data points of an insight series.
+type AlertRule struct {
+	ID              int
+	SeriesID        int // insight_series.id, not the series_id string
+	Kind            AlertRuleKind
+	Threshold       float64
+	NotifyEmail     bool
+	SlackWebhookURL *string
+	WebhookURL      *string
+	CreatedBy       int32
+	CreatedAt       time.Time
+	LastPointTime   *time.Time
+	LastTriggeredAt *time.Time
+}
+
 type PresentationType string
 
 const (
@@ -202,13 +228,26 @@ type SeriesSortOptions struct {
 type SearchAggregationMode string
 
 const (
-	REPO_AGGREGATION_MODE          SearchAggregationMode = "REPO"
-	PATH_AGGREGATION_MODE          SearchAggregationMode = "PATH"
-	AUTHOR_AGGREGATION_MODE        SearchAggregationMode = "AUTHOR"
-	CAPTURE_GROUP_AGGREGATION_MODE SearchAggregationMode = "CAPTURE_GROUP"
+	REPO_AGGREGATION_MODE            SearchAggregationMode = "REPO"
+	PATH_AGGREGATION_MODE            SearchAggregationMode = "PATH"
+	AUTHOR_AGGREGATION_MODE          SearchAggregationMode = "AUTHOR"
+	CAPTURE_GROUP_AGGREGATION_MODE   SearchAggregationMode = "CAPTURE_GROUP"
+	LANGUAGE_AGGREGATION_MODE        SearchAggregationMode = "LANGUAGE"
+	FILE_EXTENSION_AGGREGATION_MODE  SearchAggregationMode = "FILE_EXTENSION"
+	DIRECTORY_DEPTH_AGGREGATION_MODE SearchAggregationMode = "DIRECTORY_DEPTH"
+	COMMIT_MONTH_AGGREGATION_MODE    SearchAggregationMode = "COMMIT_MONTH"
 )
 
-var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE}
+var SearchAggregationModes = []SearchAggregationMode{
+	REPO_AGGREGATION_MODE,
+	PATH_AGGREGATION_MODE,
+	AUTHOR_AGGREGATION_MODE,
+	CAPTURE_GROUP_AGGREGATION_MODE,
+	LANGUAGE_AGGREGATION_MODE,
+	FILE_EXTENSION_AGGREGATION_MODE,
+	DIRECTORY_DEPTH_AGGREGATION_MODE,
+	COMMIT_MONTH_AGGREGATION_MODE,
+}
 
 type AggregationNotAvailableReasonType string
 
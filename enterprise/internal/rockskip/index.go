@@ -69,6 +69,11 @@ func (s *Service) Index(ctx context.Context, repo, givenCommit string) (err erro
 
 	threadStatus.SetProgress(0, missingCount)
 
+	err = updateRefsBehind(ctx, conn, repoId, givenCommit, missingCount)
+	if err != nil {
+		return err
+	}
+
 	if missingCount == 0 {
 		return nil
 	}
@@ -107,7 +112,7 @@ func (s *Service) Index(ctx context.Context, repo, givenCommit string) (err erro
 		}
 
 		tasklog.Start("InsertCommit")
-		commit, err := InsertCommit(ctx, tx, repoId, entry.Commit, tipHeight+1, hops[r])
+		commit, err := InsertCommit(ctx, tx, repoId, entry.Commit, tipHeight+1, hops[r], tipCommit)
 		if err != nil {
 			return errors.Wrap(err, "InsertCommit")
 		}
@@ -134,12 +139,12 @@ func (s *Service) Index(ctx context.Context, repo, givenCommit string) (err erro
 			}
 		}
 
-		symbolsFromDeletedFiles := map[string]*goset.Set[string]{}
+		symbolsFromDeletedFiles := map[string]*goset.Set[symbolKey]{}
 		{
 			// Fill from the cache.
 			for _, path := range deletedPaths {
 				if symbols, ok := pathSymbolsCache.Get(path); ok {
-					symbolsFromDeletedFiles[path] = symbols.(*goset.Set[string])
+					symbolsFromDeletedFiles[path] = symbols.(*goset.Set[symbolKey])
 				}
 			}
 
@@ -161,7 +166,8 @@ func (s *Service) Index(ctx context.Context, repo, givenCommit string) (err erro
 			}
 		}
 
-		symbolsFromAddedFiles := map[string]*goset.Set[string]{}
+		symbolsFromAddedFiles := map[string]*goset.Set[symbolKey]{}
+		languages := map[string]string{}
 		{
 			tasklog.Start("ArchiveEach")
 			err = archiveEach(ctx, s.fetcher, repo, entry.Commit, addedPaths, func(path string, contents []byte) error {
@@ -173,9 +179,10 @@ func (s *Service) Index(ctx context.Context, repo, givenCommit string) (err erro
 					return errors.Wrap(err, "parse")
 				}
 
-				symbolsFromAddedFiles[path] = goset.NewSet[string]()
+				symbolsFromAddedFiles[path] = goset.NewSet[symbolKey]()
 				for _, symbol := range symbols {
-					symbolsFromAddedFiles[path].Add(symbol.Name)
+					symbolsFromAddedFiles[path].Add(symbolKey{name: symbol.Name, kind: symbol.Kind, parent: symbol.Parent})
+					languages[path] = symbol.Language
 				}
 
 				// Cache the symbols we just parsed.
@@ -191,16 +198,16 @@ func (s *Service) Index(ctx context.Context, repo, givenCommit string) (err erro
 		}
 
 		// Compute the symmetric difference of symbols between the added and deleted paths.
-		deletedSymbols := map[string]*goset.Set[string]{}
-		addedSymbols := map[string]*goset.Set[string]{}
+		deletedSymbols := map[string]*goset.Set[symbolKey]{}
+		addedSymbols := map[string]*goset.Set[symbolKey]{}
 		for _, pathStatus := range entry.PathStatuses {
 			deleted := symbolsFromDeletedFiles[pathStatus.Path]
 			if deleted == nil {
-				deleted = goset.NewSet[string]()
+				deleted = goset.NewSet[symbolKey]()
 			}
 			added := symbolsFromAddedFiles[pathStatus.Path]
 			if added == nil {
-				added = goset.NewSet[string]()
+				added = goset.NewSet[symbolKey]()
 			}
 			switch pathStatus.Status {
 			case gitdomain.DeletedAMD:
@@ -235,7 +242,7 @@ func (s *Service) Index(ctx context.Context, repo, givenCommit string) (err erro
 						// determined by the file itself:
 						//
 						// https://github.com/universal-ctags/ctags/pull/3300
-						log15.Error("Could not find symbol that was supposedly deleted", "repo", repo, "commit", commit, "path", path, "symbol", symbol)
+						log15.Error("Could not find symbol that was supposedly deleted", "repo", repo, "commit", commit, "path", path, "symbol", symbol.name, "kind", symbol.kind, "parent", symbol.parent)
 						continue
 					}
 				}
@@ -249,7 +256,7 @@ func (s *Service) Index(ctx context.Context, repo, givenCommit string) (err erro
 		}
 
 		tasklog.Start("BatchInsertSymbols")
-		err = BatchInsertSymbols(ctx, tasklog, tx, repoId, commit, symbolCache, addedSymbols)
+		err = BatchInsertSymbols(ctx, tasklog, tx, repoId, commit, symbolCache, addedSymbols, languages)
 		if err != nil {
 			return errors.Wrap(err, "BatchInsertSymbols")
 		}
@@ -280,11 +287,13 @@ func (s *Service) Index(ctx context.Context, repo, givenCommit string) (err erro
 	return nil
 }
 
-func BatchInsertSymbols(ctx context.Context, tasklog *TaskLog, tx *sql.Tx, repoId, commit int, symbolCache *lru.Cache, symbols map[string]*goset.Set[string]) error {
+// BatchInsertSymbols inserts the symbols as added at the given commit. languages maps each path to
+// the language ctags detected for it.
+func BatchInsertSymbols(ctx context.Context, tasklog *TaskLog, tx *sql.Tx, repoId, commit int, symbolCache *lru.Cache, symbols map[string]*goset.Set[symbolKey], languages map[string]string) error {
 	callback := func(inserter *batch.Inserter) error {
 		for path, pathSymbols := range symbols {
 			for _, symbol := range pathSymbols.Items() {
-				if err := inserter.Insert(ctx, pg.Array([]int{commit}), pg.Array([]int{}), repoId, path, symbol); err != nil {
+				if err := inserter.Insert(ctx, pg.Array([]int{commit}), pg.Array([]int{}), repoId, path, symbol.name, symbol.kind, symbol.parent, languages[path]); err != nil {
 					return err
 				}
 			}
@@ -295,9 +304,9 @@ func BatchInsertSymbols(ctx context.Context, tasklog *TaskLog, tx *sql.Tx, repoI
 
 	returningScanner := func(rows dbutil.Scanner) error {
 		var path string
-		var symbol string
+		var symbol symbolKey
 		var id int
-		if err := rows.Scan(&path, &symbol, &id); err != nil {
+		if err := rows.Scan(&path, &symbol.name, &symbol.kind, &symbol.parent, &id); err != nil {
 			return err
 		}
 		symbolCache.Add(pathSymbol{path: path, symbol: symbol}, id)
@@ -309,9 +318,9 @@ func BatchInsertSymbols(ctx context.Context, tasklog *TaskLog, tx *sql.Tx, repoI
 		tx,
 		"rockskip_symbols",
 		batch.MaxNumPostgresParameters,
-		[]string{"added", "deleted", "repo_id", "path", "name"},
+		[]string{"added", "deleted", "repo_id", "path", "name", "kind", "parent", "language"},
 		"",
-		[]string{"path", "name", "id"},
+		[]string{"path", "name", "kind", "parent", "id"},
 		returningScanner,
 		callback,
 	)
@@ -329,5 +338,13 @@ type indexRequest struct {
 
 type pathSymbol struct {
 	path   string
-	symbol string
+	symbol symbolKey
+}
+
+// symbolKey identifies a symbol within a file. Two symbols with the same name are distinct when
+// they have different kinds or containers (e.g. methods with the same name in different classes).
+type symbolKey struct {
+	name   string
+	kind   string
+	parent string
 }
@@ -51,16 +51,16 @@ func GetCommitByHash(ctx context.Context, db dbutil.DB, repoId int, commitHash s
 	return commit, height, true, nil
 }
 
-func InsertCommit(ctx context.Context, db dbutil.DB, repoId int, commitHash string, height int, ancestor CommitId) (id CommitId, err error) {
+func InsertCommit(ctx context.Context, db dbutil.DB, repoId int, commitHash string, height int, ancestor CommitId, parent CommitId) (id CommitId, err error) {
 	err = db.QueryRowContext(ctx, `
-		INSERT INTO rockskip_ancestry (commit_id, repo_id, height, ancestor)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO rockskip_ancestry (commit_id, repo_id, height, ancestor, parent)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id
-	`, commitHash, repoId, height, ancestor).Scan(&id)
+	`, commitHash, repoId, height, ancestor, parent).Scan(&id)
 	return id, errors.Wrap(err, "InsertCommit")
 }
 
-func GetSymbol(ctx context.Context, db dbutil.DB, repoId int, path string, name string, hops []CommitId) (id int, found bool, err error) {
+func GetSymbol(ctx context.Context, db dbutil.DB, repoId int, path string, symbol symbolKey, hops []CommitId) (id int, found bool, err error) {
 	err = db.QueryRowContext(ctx, `
 		SELECT id
 		FROM rockskip_symbols
@@ -68,9 +68,11 @@ func GetSymbol(ctx context.Context, db dbutil.DB, repoId int, path string, name
 			repo_id = $1 AND
 			path = $2 AND
 			name = $3 AND
-		    $4 && added AND
-			NOT $4 && deleted
-	`, repoId, path, name, pg.Array(hops)).Scan(&id)
+			kind = $4 AND
+			parent = $5 AND
+		    $6 && added AND
+			NOT $6 && deleted
+	`, repoId, path, symbol.name, symbol.kind, symbol.parent, pg.Array(hops)).Scan(&id)
 	if err == sql.ErrNoRows {
 		return 0, false, nil
 	} else if err != nil {
@@ -79,12 +81,12 @@ func GetSymbol(ctx context.Context, db dbutil.DB, repoId int, path string, name
 	return id, true, nil
 }
 
-func GetSymbolsInFiles(ctx context.Context, db dbutil.DB, repoId int, paths []string, hops []CommitId) (map[string]*goset.Set[string], error) {
-	pathToSymbols := map[string]*goset.Set[string]{}
+func GetSymbolsInFiles(ctx context.Context, db dbutil.DB, repoId int, paths []string, hops []CommitId) (map[string]*goset.Set[symbolKey], error) {
+	pathToSymbols := map[string]*goset.Set[symbolKey]{}
 
 	for _, chunk := range chunksOf(paths, 1000) {
 		rows, err := db.QueryContext(ctx, `
-			SELECT name, path
+			SELECT name, kind, parent, path
 			FROM rockskip_symbols
 			WHERE
 				repo_id = $1 AND
@@ -96,15 +98,15 @@ func GetSymbolsInFiles(ctx context.Context, db dbutil.DB, repoId int, paths []st
 			return nil, errors.Newf("GetSymbolsInFiles: %s", err)
 		}
 		for rows.Next() {
-			var name string
+			var symbol symbolKey
 			var path string
-			if err := rows.Scan(&name, &path); err != nil {
+			if err := rows.Scan(&symbol.name, &symbol.kind, &symbol.parent, &path); err != nil {
 				return nil, errors.Newf("GetSymbolsInFiles: %s", err)
 			}
 			if pathToSymbols[path] == nil {
-				pathToSymbols[path] = goset.NewSet[string]()
+				pathToSymbols[path] = goset.NewSet[symbolKey]()
 			}
-			pathToSymbols[path].Add(name)
+			pathToSymbols[path].Add(symbol)
 		}
 		err = rows.Close()
 		if err != nil {
@@ -125,12 +127,12 @@ func UpdateSymbolHops(ctx context.Context, db dbutil.DB, id int, status StatusAD
 	return errors.Wrap(err, "UpdateSymbolHops")
 }
 
-func InsertSymbol(ctx context.Context, db dbutil.DB, hop CommitId, repoId int, path string, name string) (id int, err error) {
+func InsertSymbol(ctx context.Context, db dbutil.DB, hop CommitId, repoId int, path string, symbol symbolKey, language string) (id int, err error) {
 	err = db.QueryRowContext(ctx, `
-		INSERT INTO rockskip_symbols (added, deleted, repo_id, path, name)
-		                      VALUES ($1   , $2     , $3     , $4  , $5  )
+		INSERT INTO rockskip_symbols (added, deleted, repo_id, path, name, kind, parent, language)
+		                      VALUES ($1   , $2     , $3     , $4  , $5  , $6  , $7    , $8      )
 		RETURNING id
-	`, pg.Array([]int{hop}), pg.Array([]int{}), repoId, path, name).Scan(&id)
+	`, pg.Array([]int{hop}), pg.Array([]int{}), repoId, path, symbol.name, symbol.kind, symbol.parent, language).Scan(&id)
 	return id, errors.Wrap(err, "InsertSymbol")
 }
 
@@ -234,6 +236,10 @@ func tryDeleteOldestRepo(ctx context.Context, db *sql.Conn, maxRepos int, thread
 	if err != nil {
 		return false, err
 	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM rockskip_refs WHERE repo_id = $1;", repoId)
+	if err != nil {
+		return false, err
+	}
 	_, err = tx.ExecContext(ctx, "DELETE FROM rockskip_repos WHERE id = $1;", repoId)
 	if err != nil {
 		return false, err
@@ -277,7 +283,7 @@ func PrintInternals(ctx context.Context, db dbutil.DB) error {
 	fmt.Println()
 
 	rows, err = db.QueryContext(ctx, `
-		SELECT id, path, name, added, deleted
+		SELECT id, path, name, kind, parent, added, deleted
 		FROM rockskip_symbols
 		ORDER BY id ASC
 	`)
@@ -288,13 +294,13 @@ func PrintInternals(ctx context.Context, db dbutil.DB) error {
 	for rows.Next() {
 		var id int
 		var path string
-		var name string
+		var name, kind, parent string
 		var added, deleted []int64
-		err = rows.Scan(&id, &path, &name, pg.Array(&added), pg.Array(&deleted))
+		err = rows.Scan(&id, &path, &name, &kind, &parent, pg.Array(&added), pg.Array(&deleted))
 		if err != nil {
 			return errors.Wrap(err, "PrintInternals: Scan")
 		}
-		fmt.Printf("  id %d path %-10s symbol %s\n", id, path, name)
+		fmt.Printf("  id %d path %-10s symbol %s kind %s parent %s\n", id, path, name, kind, parent)
 		for _, a := range added {
 			hash, _, _, _, err := GetCommitById(ctx, db, int(a))
 			if err != nil {
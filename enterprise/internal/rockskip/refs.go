@@ -0,0 +1,297 @@
+package rockskip
+
+import (
+	"context"
+	"database/sql"
+
+	pg "github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Refs
+//
+// Every branch, tag or bare commit that gets searched is tracked as a ref in rockskip_refs. Refs
+// of the same repo share the rows in rockskip_ancestry and rockskip_symbols for their common
+// history: indexing a ref only inserts the commits between its first already-indexed ancestor and
+// its tip.
+//
+// Refs are evicted independently of each other. Evicting a ref deletes the commits that are no
+// longer reachable from any other ref of the repo by following rockskip_ancestry.parent, which is
+// the first parent of the commit.
+
+// refName returns the name of the ref that's being searched, falling back to the commit hash when
+// the caller didn't say which revision the commit was resolved from.
+func refName(args search.SymbolsParameters) string {
+	if args.Revision != "" {
+		return args.Revision
+	}
+	return string(args.CommitID)
+}
+
+// updateRef points the ref at the given indexed commit and marks it as up to date.
+func updateRef(ctx context.Context, db dbutil.DB, repoId int, name string, commit CommitId) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO rockskip_refs (repo_id, name, commit_id, pending_commit, behind, last_accessed_at)
+		VALUES ($1, $2, $3, NULL, 0, now())
+		ON CONFLICT (repo_id, name)
+		DO UPDATE SET commit_id = $3, pending_commit = NULL, behind = 0, last_accessed_at = now()
+	`, repoId, name, commit)
+	return errors.Wrap(err, "updateRef")
+}
+
+// updatePendingRef records that the ref was searched at a commit that hasn't been indexed yet.
+func updatePendingRef(ctx context.Context, db dbutil.DB, repoId int, name string, commitHash string) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO rockskip_refs (repo_id, name, commit_id, pending_commit, behind, last_accessed_at)
+		VALUES ($1, $2, NULL, $3, 0, now())
+		ON CONFLICT (repo_id, name)
+		DO UPDATE SET pending_commit = $3, last_accessed_at = now()
+	`, repoId, name, commitHash)
+	return errors.Wrap(err, "updatePendingRef")
+}
+
+// updateRefsBehind records how many commits need to be indexed before the refs waiting on the
+// given commit are up to date.
+func updateRefsBehind(ctx context.Context, db dbutil.DB, repoId int, commitHash string, behind int) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE rockskip_refs
+		SET behind = $3
+		WHERE repo_id = $1 AND pending_commit = $2
+	`, repoId, commitHash, behind)
+	return errors.Wrap(err, "updateRefsBehind")
+}
+
+func DeleteOldRefs(ctx context.Context, db *sql.DB, maxRefsPerRepo int, threadStatus *ThreadStatus) error {
+	// Get a fresh connection from the DB pool to get deterministic "lock stacking" behavior.
+	// See doc/dev/background-information/sql/locking_behavior.md for more details.
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		return errors.Wrap(err, "failed to get connection for deleting old refs")
+	}
+	defer conn.Close()
+
+	// Keep deleting refs until each repo is back to at most maxRefsPerRepo.
+	for {
+		more, err := tryDeleteOldestRef(ctx, conn, maxRefsPerRepo, threadStatus)
+		if err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+	}
+}
+
+func tryDeleteOldestRef(ctx context.Context, db *sql.Conn, maxRefsPerRepo int, threadStatus *ThreadStatus) (more bool, err error) {
+	defer threadStatus.Tasklog.Continue("idle")
+
+	// Select a candidate ref to delete.
+	threadStatus.Tasklog.Start("select ref to delete")
+	var refId int
+	var repoId int
+	var repo string
+	err = db.QueryRowContext(ctx, `
+		SELECT sub.id, sub.repo_id, rockskip_repos.repo
+		FROM (
+			SELECT *, RANK() OVER (PARTITION BY repo_id ORDER BY last_accessed_at DESC) ref_rank
+			FROM rockskip_refs
+		) sub
+		JOIN rockskip_repos ON rockskip_repos.id = sub.repo_id
+		WHERE ref_rank > $1
+		ORDER BY sub.last_accessed_at ASC
+		LIMIT 1;`, maxRefsPerRepo,
+	).Scan(&refId, &repoId, &repo)
+	if err == sql.ErrNoRows {
+		// No more refs to delete.
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "selecting ref to delete")
+	}
+
+	// Note: a search request or deletion could have intervened here.
+
+	// Acquire the write lock on the repo.
+	releaseWLock, err := wLock(ctx, db, threadStatus, repo)
+	defer func() { err = errors.CombineErrors(err, releaseWLock()) }()
+	if err != nil {
+		return false, errors.Wrap(err, "acquiring write lock on repo")
+	}
+
+	// Make sure the ref is still old. See note above.
+	var rank int
+	threadStatus.Tasklog.Start("recheck ref rank")
+	err = db.QueryRowContext(ctx, `
+		SELECT ref_rank
+		FROM (
+			SELECT id, RANK() OVER (PARTITION BY repo_id ORDER BY last_accessed_at DESC) ref_rank
+			FROM rockskip_refs
+			WHERE repo_id = $1
+		) sub
+		WHERE id = $2;`, repoId, refId,
+	).Scan(&rank)
+	if err == sql.ErrNoRows {
+		// The ref was deleted in the meantime, so retry.
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "selecting ref rank")
+	}
+	if rank <= maxRefsPerRepo {
+		// An intervening search request must have refreshed the ref, so retry.
+		return true, nil
+	}
+
+	// Acquire the indexing lock on the repo.
+	releaseILock, err := iLock(ctx, db, threadStatus, repo)
+	defer func() { err = errors.CombineErrors(err, releaseILock()) }()
+	if err != nil {
+		return false, errors.Wrap(err, "acquiring indexing lock on repo")
+	}
+
+	// Delete the ref and the commits that only it needed.
+	threadStatus.Tasklog.Start("delete ref")
+	tx, err := db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+	if err != nil {
+		return false, err
+	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM rockskip_refs WHERE id = $1;", refId)
+	if err != nil {
+		return false, err
+	}
+	threadStatus.Tasklog.Start("delete unreachable commits")
+	err = deleteUnreachableCommits(ctx, tx, repoId)
+	if err != nil {
+		return false, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// deleteUnreachableCommits deletes the commits of the repo that can't be reached from any ref by
+// following parents, along with the symbols that were only visible at those commits.
+//
+// It starts from the commits that have no children and aren't pointed to by a ref (e.g. the tip
+// of the ref that was just deleted, or the old tip of a branch that was force-pushed) and walks
+// down the first-parent chain until it reaches a commit that's still needed. Commits indexed before
+// parents were recorded are never deleted here, only when the whole repo is evicted.
+//
+// The caller must hold the write and indexing locks on the repo.
+func deleteUnreachableCommits(ctx context.Context, tx *sql.Tx, repoId int) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT a.id
+		FROM rockskip_ancestry a
+		WHERE
+			a.repo_id = $1 AND
+			a.parent IS NOT NULL AND
+			NOT EXISTS (SELECT 1 FROM rockskip_ancestry c WHERE c.parent = a.id) AND
+			NOT EXISTS (SELECT 1 FROM rockskip_refs r WHERE r.commit_id = a.id)
+	`, repoId)
+	if err != nil {
+		return errors.Wrap(err, "selecting unreachable tips")
+	}
+	tips := []CommitId{}
+	for rows.Next() {
+		var tip CommitId
+		if err := rows.Scan(&tip); err != nil {
+			rows.Close()
+			return errors.Wrap(err, "scanning unreachable tip")
+		}
+		tips = append(tips, tip)
+	}
+	if err = rows.Close(); err != nil {
+		return errors.Wrap(err, "selecting unreachable tips")
+	}
+
+	deleted := []CommitId{}
+	for _, tip := range tips {
+		current := tip
+		for {
+			var parent sql.NullInt64
+			err = tx.QueryRowContext(ctx, "DELETE FROM rockskip_ancestry WHERE id = $1 RETURNING parent", current).Scan(&parent)
+			if err != nil {
+				return errors.Wrap(err, "deleting commit")
+			}
+			deleted = append(deleted, current)
+
+			if !parent.Valid || CommitId(parent.Int64) == NULL {
+				break
+			}
+			needed, err := isCommitNeeded(ctx, tx, CommitId(parent.Int64))
+			if err != nil {
+				return err
+			}
+			if needed {
+				break
+			}
+			current = CommitId(parent.Int64)
+		}
+	}
+
+	return deleteSymbolHops(ctx, tx, repoId, deleted)
+}
+
+// isCommitNeeded returns true if the commit is pointed to by a ref, has other children, or was
+// indexed before parents were recorded.
+func isCommitNeeded(ctx context.Context, tx *sql.Tx, commit CommitId) (bool, error) {
+	var needed bool
+	err := tx.QueryRowContext(ctx, `
+		SELECT
+			a.parent IS NULL OR
+			EXISTS (SELECT 1 FROM rockskip_ancestry c WHERE c.parent = a.id) OR
+			EXISTS (SELECT 1 FROM rockskip_refs r WHERE r.commit_id = a.id)
+		FROM rockskip_ancestry a
+		WHERE a.id = $1
+	`, commit).Scan(&needed)
+	if err == sql.ErrNoRows {
+		return false, errors.Newf("isCommitNeeded: commit %d is missing", commit)
+	}
+	return needed, errors.Wrap(err, "isCommitNeeded")
+}
+
+// deleteSymbolHops removes the given commits from the added and deleted hops of the symbols in the
+// repo and deletes the symbols that are no longer added at any commit.
+func deleteSymbolHops(ctx context.Context, tx *sql.Tx, repoId int, commits []CommitId) error {
+	for _, chunk := range chunksOf(commits, 1000) {
+		rows, err := tx.QueryContext(ctx, `
+			UPDATE rockskip_symbols
+			SET added = added - $2::integer[], deleted = deleted - $2::integer[]
+			WHERE $1 && singleton_integer(repo_id) AND ($2 && added OR $2 && deleted)
+			RETURNING id, cardinality(added)
+		`, pg.Array([]int{repoId}), pg.Array(chunk))
+		if err != nil {
+			return errors.Wrap(err, "deleteSymbolHops")
+		}
+		empty := []int{}
+		for rows.Next() {
+			var id, addedCount int
+			if err := rows.Scan(&id, &addedCount); err != nil {
+				rows.Close()
+				return errors.Wrap(err, "deleteSymbolHops: Scan")
+			}
+			if addedCount == 0 {
+				empty = append(empty, id)
+			}
+		}
+		if err = rows.Close(); err != nil {
+			return errors.Wrap(err, "deleteSymbolHops")
+		}
+
+		if len(empty) > 0 {
+			_, err = tx.ExecContext(ctx, "DELETE FROM rockskip_symbols WHERE id = ANY($1)", pg.Array(empty))
+			if err != nil {
+				return errors.Wrap(err, "deleteSymbolHops: delete")
+			}
+		}
+	}
+
+	return nil
+}
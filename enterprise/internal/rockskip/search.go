@@ -76,11 +76,18 @@ func (s *Service) Search(ctx context.Context, args search.SymbolsParameters) (_
 
 	// Check if the commit has already been indexed, and if not then index it.
 	threadStatus.Tasklog.Start("check commit presence")
+	ref := refName(args)
 	commit, _, present, err := GetCommitByHash(ctx, s.db, repoId, commitHash)
 	if err != nil {
 		return nil, err
 	} else if !present {
 
+		// Let the status page know that the ref is behind.
+		err = updatePendingRef(ctx, s.db, repoId, ref, commitHash)
+		if err != nil {
+			return nil, err
+		}
+
 		// Try to send an index request.
 		done, err := s.emitIndexRequest(repoCommit{repo: repo, commit: commitHash})
 		if err != nil {
@@ -105,6 +112,13 @@ func (s *Service) Search(ctx context.Context, args search.SymbolsParameters) (_
 
 	}
 
+	// Point the ref at the commit so that it's kept until the ref gets evicted.
+	threadStatus.Tasklog.Start("update ref")
+	err = updateRef(ctx, s.db, repoId, ref, commit)
+	if err != nil {
+		return nil, err
+	}
+
 	// Finally search.
 	symbols, err := s.querySymbols(ctx, args, repoId, commit, threadStatus)
 	if err != nil {
@@ -142,6 +156,29 @@ func mkIsMatch(args search.SymbolsParameters) (func(string) bool, error) {
 	}
 }
 
+// mkIsKindAndContainerMatch returns a function that reports whether a symbol with the given kind and
+// parent satisfies the Kinds and Container search args. It mirrors the conditions that
+// convertSearchArgsToSqlQuery pushes down to Postgres, which only narrow down the files to parse.
+func mkIsKindAndContainerMatch(args search.SymbolsParameters) func(kind, parent string) bool {
+	kinds := goset.NewSet[string]()
+	for _, kind := range args.Kinds {
+		kinds.Add(strings.ToLower(kind))
+	}
+
+	return func(kind, parent string) bool {
+		if kinds.Len() > 0 && !kinds.Contains(strings.ToLower(kind)) {
+			return false
+		}
+		if args.Container == "" {
+			return true
+		}
+		if args.IsCaseSensitive {
+			return parent == args.Container
+		}
+		return strings.EqualFold(parent, args.Container)
+	}
+}
+
 func (s *Service) emitIndexRequest(rc repoCommit) (chan struct{}, error) {
 	key := fmt.Sprintf("%s@%s", rc.repo, rc.commit)
 
@@ -228,6 +265,7 @@ func (s *Service) querySymbols(ctx context.Context, args search.SymbolsParameter
 	if err != nil {
 		return nil, err
 	}
+	isKindAndContainerMatch := mkIsKindAndContainerMatch(args)
 
 	paths := goset.NewSet[string]()
 	for rows.Next() {
@@ -262,7 +300,7 @@ func (s *Service) querySymbols(ctx context.Context, args search.SymbolsParameter
 		lines := strings.Split(string(contents), "\n")
 
 		for _, symbol := range allSymbols {
-			if isMatch(symbol.Name) {
+			if isMatch(symbol.Name) && isKindAndContainerMatch(symbol.Kind, symbol.Parent) {
 				if symbol.Line < 1 || symbol.Line > len(lines) {
 					log15.Warn("ctags returned an invalid line number", "path", path, "line", symbol.Line, "len(lines)", len(lines), "symbol", symbol.Name)
 					continue
@@ -280,6 +318,7 @@ func (s *Service) querySymbols(ctx context.Context, args search.SymbolsParameter
 					Line:      symbol.Line - 1,
 					Character: character,
 					Kind:      symbol.Kind,
+					Language:  symbol.Language,
 					Parent:    symbol.Parent,
 				})
 
@@ -412,6 +451,20 @@ func convertSearchArgsToSqlQuery(args search.SymbolsParameters) *sqlf.Query {
 	// ExcludePattern
 	conjunctOrNils = append(conjunctOrNils, negate(regexMatch(pathConditions, args.ExcludePattern, args.IsCaseSensitive)))
 
+	// Kinds
+	if len(args.Kinds) > 0 {
+		conjunctOrNils = append(conjunctOrNils, sqlf.Sprintf("%s && singleton(lower(kind))", pg.Array(lowerAll(args.Kinds))))
+	}
+
+	// Container
+	if args.Container != "" {
+		if args.IsCaseSensitive {
+			conjunctOrNils = append(conjunctOrNils, sqlf.Sprintf("ARRAY[%s] && singleton(parent)", args.Container))
+		} else {
+			conjunctOrNils = append(conjunctOrNils, sqlf.Sprintf("ARRAY[%s] && singleton(lower(parent))", strings.ToLower(args.Container)))
+		}
+	}
+
 	// Drop nils
 	conjuncts := []*sqlf.Query{}
 	for _, condition := range conjunctOrNils {
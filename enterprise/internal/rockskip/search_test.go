@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+	pg "github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
 )
 
 func TestIsFileExtensionMatch(t *testing.T) {
@@ -68,3 +72,74 @@ func TestIsLiteralPrefix(t *testing.T) {
 		}
 	}
 }
+
+func TestConvertSearchArgsToSqlQuery(t *testing.T) {
+	tests := []struct {
+		args      search.SymbolsParameters
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			args:      search.SymbolsParameters{},
+			wantQuery: "TRUE",
+			wantArgs:  []any{},
+		},
+		{
+			args:      search.SymbolsParameters{Query: "^foo$", IsCaseSensitive: true},
+			wantQuery: "ARRAY[$1] && singleton(name)",
+			wantArgs:  []any{"foo"},
+		},
+		{
+			args:      search.SymbolsParameters{Kinds: []string{"Method", "methodSpec"}},
+			wantQuery: "$1 && singleton(lower(kind))",
+			wantArgs:  []any{pg.Array([]string{"method", "methodspec"})},
+		},
+		{
+			args:      search.SymbolsParameters{Container: "Foo"},
+			wantQuery: "ARRAY[$1] && singleton(lower(parent))",
+			wantArgs:  []any{"foo"},
+		},
+		{
+			args:      search.SymbolsParameters{Container: "Foo", IsCaseSensitive: true},
+			wantQuery: "ARRAY[$1] && singleton(parent)",
+			wantArgs:  []any{"Foo"},
+		},
+		{
+			args:      search.SymbolsParameters{Query: "^bar$", Kinds: []string{"method"}, Container: "Foo"},
+			wantQuery: "ARRAY[$1] && singleton(lower(name)) AND $2 && singleton(lower(kind)) AND ARRAY[$3] && singleton(lower(parent))",
+			wantArgs:  []any{"bar", pg.Array([]string{"method"}), "foo"},
+		},
+	}
+
+	for _, test := range tests {
+		q := convertSearchArgsToSqlQuery(test.args)
+		if diff := cmp.Diff(test.wantQuery, q.Query(sqlf.PostgresBindVar)); diff != "" {
+			t.Errorf("unexpected query for %+v (-want +got):\n%s", test.args, diff)
+		}
+		if diff := cmp.Diff(test.wantArgs, q.Args()); diff != "" {
+			t.Errorf("unexpected args for %+v (-want +got):\n%s", test.args, diff)
+		}
+	}
+}
+
+func TestIsKindAndContainerMatch(t *testing.T) {
+	tests := []struct {
+		args   search.SymbolsParameters
+		kind   string
+		parent string
+		want   bool
+	}{
+		{search.SymbolsParameters{}, "function", "", true},
+		{search.SymbolsParameters{Kinds: []string{"method"}}, "Method", "Foo", true},
+		{search.SymbolsParameters{Kinds: []string{"method"}}, "function", "", false},
+		{search.SymbolsParameters{Container: "foo"}, "method", "Foo", true},
+		{search.SymbolsParameters{Container: "foo", IsCaseSensitive: true}, "method", "Foo", false},
+		{search.SymbolsParameters{Kinds: []string{"method"}, Container: "Foo"}, "field", "Foo", false},
+	}
+
+	for _, test := range tests {
+		if got := mkIsKindAndContainerMatch(test.args)(test.kind, test.parent); got != test.want {
+			t.Errorf("mkIsKindAndContainerMatch(%+v)(%q, %q) = %v, want %v", test.args, test.kind, test.parent, got, test.want)
+		}
+	}
+}
@@ -32,6 +32,7 @@ type Service struct {
 	status               *ServiceStatus
 	repoUpdates          chan struct{}
 	maxRepos             int
+	maxRefsPerRepo       int
 	logQueries           bool
 	repoCommitToDone     map[string]chan struct{}
 	repoCommitToDoneMu   sync.Mutex
@@ -47,6 +48,7 @@ func NewService(
 	createParser func() (ctags.Parser, error),
 	maxConcurrentlyIndexing int,
 	maxRepos int,
+	maxRefsPerRepo int,
 	logQueries bool,
 	indexRequestsQueueSize int,
 	symbolsCacheSize int,
@@ -68,6 +70,7 @@ func NewService(
 		status:               NewStatus(),
 		repoUpdates:          make(chan struct{}, 1),
 		maxRepos:             maxRepos,
+		maxRefsPerRepo:       maxRefsPerRepo,
 		logQueries:           logQueries,
 		repoCommitToDone:     map[string]chan struct{}{},
 		repoCommitToDoneMu:   sync.Mutex{},
@@ -99,10 +102,14 @@ func (s *Service) startCleanupLoop() {
 	for range s.repoUpdates {
 		threadStatus := s.status.NewThreadStatus("cleanup")
 		err := DeleteOldRepos(context.Background(), s.db, s.maxRepos, threadStatus)
-		threadStatus.End()
 		if err != nil {
 			log15.Error("Failed to delete old repos", "error", err)
 		}
+		err = DeleteOldRefs(context.Background(), s.db, s.maxRefsPerRepo, threadStatus)
+		if err != nil {
+			log15.Error("Failed to delete old refs", "error", err)
+		}
+		threadStatus.End()
 	}
 }
 
@@ -85,6 +85,10 @@ func TestIndex(t *testing.T) {
 		return strings.TrimSpace(gitStdout("rev-parse", "HEAD"))
 	}
 
+	getBranch := func() string {
+		return strings.TrimSpace(gitStdout("rev-parse", "--abbrev-ref", "HEAD"))
+	}
+
 	state := map[string][]string{}
 
 	add := func(filename string, contents string) {
@@ -114,13 +118,13 @@ func TestIndex(t *testing.T) {
 
 	createParser := func() (ctags.Parser, error) { return mockParser{}, nil }
 
-	service, err := NewService(db, git, newMockRepositoryFetcher(git), createParser, 1, 1, false, 1, 1, 1)
+	service, err := NewService(db, git, newMockRepositoryFetcher(git), createParser, 1, 1, 10, false, 1, 1, 1)
 	fatalIfError(err, "NewService")
 
 	verifyBlobs := func() {
 		repo := "somerepo"
 		commit := getHead()
-		args := search.SymbolsParameters{Repo: api.RepoName(repo), CommitID: api.CommitID(commit), Query: ""}
+		args := search.SymbolsParameters{Repo: api.RepoName(repo), CommitID: api.CommitID(commit), Revision: getBranch(), Query: ""}
 		symbols, err := service.Search(context.Background(), args)
 		fatalIfError(err, "Search")
 
@@ -188,6 +192,58 @@ func TestIndex(t *testing.T) {
 
 	rm("a.txt")
 	commit("rm a.txt")
+
+	// Branch off and make sure both branches are searchable.
+	defaultBranch := getBranch()
+	defaultState := copyState(state)
+
+	gitRun("checkout", "-b", "release")
+	add("d.txt", "sym3\n")
+	commit("add d.txt on release")
+
+	rm("b.txt")
+	commit("rm b.txt on release")
+	releaseState := copyState(state)
+
+	gitRun("checkout", defaultBranch)
+	state = defaultState
+	add("e.txt", "sym4\n")
+	commit("add e.txt on the default branch")
+
+	countCommits := func() int {
+		var count int
+		err := db.QueryRow("SELECT COUNT(*) FROM rockskip_ancestry").Scan(&count)
+		fatalIfError(err, "count commits")
+		return count
+	}
+
+	defaultBranchCommitCount, err := strconv.Atoi(strings.TrimSpace(gitStdout("rev-list", "--count", "--first-parent", defaultBranch)))
+	fatalIfError(err, "rev-list --count")
+	if got, want := countCommits(), defaultBranchCommitCount+2; got != want {
+		t.Fatalf("expected the release branch to reuse the commits of the default branch: got %d commits, want %d", got, want)
+	}
+
+	// Evict the release branch, which was searched less recently, and make sure that only its own
+	// commits are deleted.
+	err = DeleteOldRefs(context.Background(), db, 1, service.status.NewThreadStatus("cleanup"))
+	fatalIfError(err, "DeleteOldRefs")
+	if got, want := countCommits(), defaultBranchCommitCount; got != want {
+		t.Fatalf("unexpected number of commits after evicting the release branch: got %d, want %d", got, want)
+	}
+	verifyBlobs()
+
+	// Searching the release branch again indexes it again.
+	gitRun("checkout", "release")
+	state = releaseState
+	verifyBlobs()
+}
+
+func copyState(state map[string][]string) map[string][]string {
+	copied := map[string][]string{}
+	for path, symbols := range state {
+		copied[path] = append([]string{}, symbols...)
+	}
+	return copied
 }
 
 type SubprocessGit struct {
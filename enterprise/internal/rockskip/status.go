@@ -1,6 +1,8 @@
 package rockskip
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"net/http"
 	"os"
@@ -13,6 +15,7 @@ import (
 	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 )
 
 // RequestId is a unique int for each HTTP request.
@@ -62,12 +65,13 @@ func (s *Service) HandleStatus(w http.ResponseWriter, r *http.Request) {
 	}
 
 	type repoRow struct {
+		id             int
 		repo           string
 		lastAccessedAt time.Time
 	}
 
 	repoRows := []repoRow{}
-	repoSqlRows, err := s.db.QueryContext(ctx, "SELECT repo, last_accessed_at FROM rockskip_repos ORDER BY last_accessed_at DESC LIMIT 5")
+	repoSqlRows, err := s.db.QueryContext(ctx, "SELECT id, repo, last_accessed_at FROM rockskip_repos ORDER BY last_accessed_at DESC LIMIT 5")
 	if err != nil {
 		log15.Error("Failed to list repoRows", "error", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -75,14 +79,26 @@ func (s *Service) HandleStatus(w http.ResponseWriter, r *http.Request) {
 	}
 	defer repoSqlRows.Close()
 	for repoSqlRows.Next() {
+		var id int
 		var repo string
 		var lastAccessedAt time.Time
-		if err := repoSqlRows.Scan(&repo, &lastAccessedAt); err != nil {
+		if err := repoSqlRows.Scan(&id, &repo, &lastAccessedAt); err != nil {
 			log15.Error("Failed to scan repo", "error", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
-		repoRows = append(repoRows, repoRow{repo: repo, lastAccessedAt: lastAccessedAt})
+		repoRows = append(repoRows, repoRow{id: id, repo: repo, lastAccessedAt: lastAccessedAt})
+	}
+
+	repoIdToRefRows := map[int][]refRow{}
+	for _, repo := range repoRows {
+		refRows, err := listRefs(ctx, s.db, repo.id)
+		if err != nil {
+			log15.Error("Failed to list refs", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		repoIdToRefRows[repo.id] = refRows
 	}
 
 	symbolsSize, _, err := basestore.ScanFirstString(s.db.QueryContext(ctx, "SELECT pg_size_pretty(pg_total_relation_size('rockskip_symbols'))"))
@@ -123,9 +139,12 @@ func (s *Service) HandleStatus(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintln(w, "")
 
 	if repositoryCount > 0 {
-		fmt.Fprintf(w, "Most recently searched repositories (at most 5 shown)\n")
+		fmt.Fprintf(w, "Most recently searched repositories (at most 5 shown) and their refs\n")
 		for _, repo := range repoRows {
 			fmt.Fprintf(w, "  %s %s\n", repo.lastAccessedAt, repo.repo)
+			for _, ref := range repoIdToRefRows[repo.id] {
+				fmt.Fprintf(w, "    %s %s\n", ref.name, ref.describe())
+			}
 		}
 		fmt.Fprintln(w, "")
 	}
@@ -169,6 +188,67 @@ func (s *Service) HandleStatus(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+type refRow struct {
+	name           string
+	commitHash     sql.NullString
+	height         sql.NullInt64
+	pendingCommit  sql.NullString
+	behind         int
+	lastAccessedAt time.Time
+}
+
+func listRefs(ctx context.Context, db dbutil.DB, repoId int) ([]refRow, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT r.name, a.commit_id, a.height, r.pending_commit, r.behind, r.last_accessed_at
+		FROM rockskip_refs r
+		LEFT JOIN rockskip_ancestry a ON a.id = r.commit_id
+		WHERE r.repo_id = $1
+		ORDER BY r.last_accessed_at DESC
+	`, repoId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refRows := []refRow{}
+	for rows.Next() {
+		var ref refRow
+		if err := rows.Scan(&ref.name, &ref.commitHash, &ref.height, &ref.pendingCommit, &ref.behind, &ref.lastAccessedAt); err != nil {
+			return nil, err
+		}
+		refRows = append(refRows, ref)
+	}
+	return refRows, rows.Err()
+}
+
+// describe says which commit the ref is indexed at and how far behind it is.
+func (r refRow) describe() string {
+	var s strings.Builder
+	if r.commitHash.Valid {
+		fmt.Fprintf(&s, "indexed at %s (height %d)", shortHash(r.commitHash.String), r.height.Int64)
+	} else {
+		fmt.Fprint(&s, "not indexed yet")
+	}
+	if r.pendingCommit.Valid {
+		if r.behind > 0 {
+			fmt.Fprintf(&s, ", %d commits behind %s", r.behind, shortHash(r.pendingCommit.String))
+		} else {
+			fmt.Fprintf(&s, ", behind %s", shortHash(r.pendingCommit.String))
+		}
+	} else if r.commitHash.Valid {
+		fmt.Fprint(&s, ", up to date")
+	}
+	fmt.Fprintf(&s, ", last searched %s", humanize.Time(r.lastAccessedAt))
+	return s.String()
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
+
 type ThreadStatus struct {
 	Tasklog   *TaskLog
 	Name      string
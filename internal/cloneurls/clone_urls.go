@@ -124,6 +124,9 @@ func getRepoNameFromService(ctx context.Context, cloneURL string, svc *types.Ext
 	case *schema.BitbucketServerConnection:
 		rs = reposource.BitbucketServer{BitbucketServerConnection: c}
 		host = c.Url
+	case *schema.GiteaConnection:
+		rs = reposource.Gitea{GiteaConnection: c}
+		host = c.Url
 	case *schema.AWSCodeCommitConnection:
 		rs = reposource.AWS{AWSCodeCommitConnection: c}
 		// AWS type does not have URL
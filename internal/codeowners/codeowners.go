@@ -0,0 +1,271 @@
+// Package codeowners parses CODEOWNERS files and resolves the owners of a
+// file. Both the GitHub and the GitLab syntax are supported, including GitLab
+// sections with default owners.
+package codeowners
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Paths are the locations of a CODEOWNERS file in a repository, in the order
+// in which they are looked up. The first file that exists is used.
+var Paths = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	".gitlab/CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	sections []*section
+}
+
+// section is a GitLab CODEOWNERS section. Files without sections have a single
+// unnamed section.
+type section struct {
+	name          string
+	defaultOwners []string
+	rules         []rule
+}
+
+type rule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// Parse parses a CODEOWNERS file.
+func Parse(r io.Reader) (*Ruleset, error) {
+	current := &section{}
+	rs := &Ruleset{sections: []*section{current}}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if name, owners, ok := parseSectionHeader(line); ok {
+			current = &section{name: name, defaultOwners: owners}
+			rs.sections = append(rs.sections, current)
+			continue
+		}
+
+		fields := splitFields(line)
+		pattern, err := compilePattern(fields[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineNumber)
+		}
+		owners := fields[1:]
+		if len(owners) == 0 {
+			owners = current.defaultOwners
+		}
+		current.rules = append(current.rules, rule{pattern: pattern, owners: owners})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+// Match returns the owners of the file at the given path, which is relative
+// to the root of the repository. In each section the last matching rule
+// determines the owners, and the owners of all sections are combined. A nil
+// slice is returned if the file has no owners.
+func (rs *Ruleset) Match(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+
+	var owners []string
+	seen := make(map[string]struct{})
+	for _, s := range rs.sections {
+		for i := len(s.rules) - 1; i >= 0; i-- {
+			if !s.rules[i].pattern.MatchString(path) {
+				continue
+			}
+			for _, owner := range s.rules[i].owners {
+				if _, ok := seen[owner]; !ok {
+					seen[owner] = struct{}{}
+					owners = append(owners, owner)
+				}
+			}
+			break
+		}
+	}
+	return owners
+}
+
+// HasOwner returns true if the file at the given path is owned by owner.
+func (rs *Ruleset) HasOwner(path, owner string) bool {
+	for _, o := range rs.Match(path) {
+		if SameOwner(o, owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// SameOwner returns true if a and b refer to the same owner. Owners are
+// compared case-insensitively and the leading @ of user and team handles is
+// optional, so "@sourcegraph/search" and "sourcegraph/Search" are the same
+// owner.
+func SameOwner(a, b string) bool {
+	return strings.EqualFold(strings.TrimPrefix(a, "@"), strings.TrimPrefix(b, "@"))
+}
+
+// sectionHeaderRegexp matches GitLab section headers such as "[Docs]",
+// "^[Optional docs]" or "[Docs][2] @docs-team".
+var sectionHeaderRegexp = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?(.*)$`)
+
+func parseSectionHeader(line string) (name string, owners []string, ok bool) {
+	match := sectionHeaderRegexp.FindStringSubmatch(line)
+	if match == nil {
+		return "", nil, false
+	}
+	return strings.TrimSpace(match[1]), strings.Fields(match[2]), true
+}
+
+// splitFields splits a rule into its pattern and owners. Whitespace in the
+// pattern can be escaped with a backslash, and a # starts a comment.
+func splitFields(line string) []string {
+	var (
+		fields  []string
+		current strings.Builder
+		escaped bool
+	)
+	for _, r := range line {
+		switch {
+		case escaped:
+			if r != ' ' && r != '\t' && r != '#' {
+				current.WriteRune('\\')
+			}
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '#':
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+			}
+			return fields
+		case r == ' ' || r == '\t':
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
+
+// compilePattern compiles a CODEOWNERS pattern, which follows the gitignore
+// syntax, into a regular expression matching the paths it applies to:
+//
+//   - a pattern that starts with or contains a slash is relative to the root,
+//     otherwise it matches at any depth;
+//   - a pattern matches a file, or a directory and everything it contains,
+//     unless its last segment is a single "*", in which case it only matches
+//     the direct children of a directory;
+//   - a trailing slash only matches directories;
+//   - "*" and "?" do not match slashes, "**" matches any number of
+//     directories.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.Trim(pattern, "/")
+	if trimmed == "" {
+		return nil, errors.Errorf("invalid pattern %q", pattern)
+	}
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(trimmed, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+
+	segments := strings.Split(trimmed, "/")
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		if segment == "**" {
+			if last {
+				b.WriteString(".*")
+			} else {
+				b.WriteString("(?:.*/)?")
+			}
+			continue
+		}
+		if err := writeGlob(&b, segment); err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %q", pattern)
+		}
+		if !last {
+			b.WriteString("/")
+		}
+	}
+
+	switch {
+	case segments[len(segments)-1] == "**":
+	case segments[len(segments)-1] == "*" && !dirOnly:
+		b.WriteString("$")
+	case dirOnly:
+		b.WriteString("/.*$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(b.String())
+}
+
+// writeGlob writes the regular expression of a single path segment of a glob.
+func writeGlob(b *strings.Builder, segment string) error {
+	runes := []rune(segment)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '*':
+			// Consecutive stars in a segment are the same as a single one.
+			for i+1 < len(runes) && runes[i+1] == '*' {
+				i++
+			}
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(runes[i])))
+			}
+		case '[':
+			end := -1
+			for j := i + 1; j < len(runes); j++ {
+				if runes[j] == ']' {
+					end = j
+					break
+				}
+			}
+			if end < 0 {
+				return errors.New("unterminated character class")
+			}
+			class := string(runes[i+1 : end])
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i = end
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package codeowners
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMatch(t *testing.T) {
+	const file = `
+# Default owners
+*                   @global-owner
+
+*.js                @js-owner # Trailing comment
+/build/out/         @doctocat
+docs/*              docs@example.com
+apps/               @octocat
+/scripts/**/*.sh    @sourcegraph/devx
+**/logs             @logs
+path\ with\ spaces/ @spaces
+/internal/search    @sourcegraph/search @alice
+/internal/search/zoekt
+`
+
+	rs, err := Parse(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string][]string{
+		"README.md":                      {"@global-owner"},
+		"web/app.js":                     {"@js-owner"},
+		"build/out/a/b.log":              {"@doctocat"},
+		"build/out":                      {"@global-owner"},
+		"docs/getting-started.md":        {"docs@example.com"},
+		"docs/build-app/troubleshoot.md": {"@global-owner"},
+		"apps/a/b.go":                    {"@octocat"},
+		"x/apps/a/b.go":                  {"@octocat"},
+		"scripts/a/b/c.sh":               {"@sourcegraph/devx"},
+		"scripts/c.sh":                   {"@sourcegraph/devx"},
+		"a/logs/b.txt":                   {"@logs"},
+		"path with spaces/a.txt":         {"@spaces"},
+		"internal/search/job.go":         {"@sourcegraph/search", "@alice"},
+		"/internal/search/job.go":        {"@sourcegraph/search", "@alice"},
+		"internal/searcher/job.go":       {"@global-owner"},
+		"internal/search/zoekt/zoekt.go": nil,
+	} {
+		if diff := cmp.Diff(want, rs.Match(path)); diff != "" {
+			t.Errorf("unexpected owners for %q (-want +got):\n%s", path, diff)
+		}
+	}
+}
+
+func TestMatchSections(t *testing.T) {
+	const file = `
+*.go @go-owner
+
+[Documentation] @docs-team
+docs/
+README.md @alice
+
+^[Optional][2] @reviewers
+*.go
+`
+
+	rs, err := Parse(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string][]string{
+		"main.go":       {"@go-owner", "@reviewers"},
+		"docs/index.md": {"@docs-team"},
+		"README.md":     {"@alice"},
+		"LICENSE":       nil,
+	} {
+		if diff := cmp.Diff(want, rs.Match(path)); diff != "" {
+			t.Errorf("unexpected owners for %q (-want +got):\n%s", path, diff)
+		}
+	}
+}
+
+func TestHasOwner(t *testing.T) {
+	rs, err := Parse(strings.NewReader("* @Sourcegraph/Search"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for owner, want := range map[string]bool{
+		"@sourcegraph/search": true,
+		"sourcegraph/search":  true,
+		"@sourcegraph":        false,
+	} {
+		if got := rs.HasOwner("a.go", owner); got != want {
+			t.Errorf("HasOwner(%q) = %t, want %t", owner, got, want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, file := range []string{
+		"/ @owner",
+		"[abc @owner",
+	} {
+		if _, err := Parse(strings.NewReader(file)); err == nil {
+			t.Errorf("expected error parsing %q", file)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type Gitea struct {
+	*schema.GiteaConnection
+}
+
+var _ RepoSource = Gitea{}
+
+func (c Gitea) CloneURLToRepoName(cloneURL string) (repoName api.RepoName, err error) {
+	parsedCloneURL, baseURL, match, err := parseURLs(cloneURL, c.Url)
+	if err != nil {
+		return "", err
+	}
+	if !match {
+		return "", nil
+	}
+
+	// HTTP clone URLs of instances served below a path prefix contain the
+	// prefix, SSH clone URLs don't.
+	nameWithOwner := strings.TrimPrefix(parsedCloneURL.Path, strings.TrimSuffix(baseURL.Path, "/"))
+	nameWithOwner = strings.TrimPrefix(strings.TrimSuffix(nameWithOwner, ".git"), "/")
+
+	return GiteaRepoName(c.RepositoryPathPattern, baseURL.Hostname(), nameWithOwner), nil
+}
+
+func GiteaRepoName(repositoryPathPattern, host, nameWithOwner string) api.RepoName {
+	if repositoryPathPattern == "" {
+		repositoryPathPattern = "{host}/{nameWithOwner}"
+	}
+
+	return api.RepoName(strings.NewReplacer(
+		"{host}", host,
+		"{nameWithOwner}", nameWithOwner,
+	).Replace(repositoryPathPattern))
+}
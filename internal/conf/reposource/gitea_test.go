@@ -0,0 +1,49 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGitea_cloneURLToRepoName(t *testing.T) {
+	tests := []struct {
+		conn schema.GiteaConnection
+		urls []urlToRepoName
+	}{{
+		conn: schema.GiteaConnection{
+			Url: "https://gitea.com",
+		},
+		urls: []urlToRepoName{
+			{"git@gitea.com:gitea/tea.git", "gitea.com/gitea/tea"},
+			{"https://gitea.com/gitea/tea.git", "gitea.com/gitea/tea"},
+			{"https://token@gitea.com/gitea/tea.git", "gitea.com/gitea/tea"},
+
+			{"git@asdf.com:gitea/tea.git", ""},
+			{"https://asdf.com/gitea/tea.git", ""},
+		},
+	}, {
+		conn: schema.GiteaConnection{
+			Url:                   "https://git.mycompany.com/gitea/",
+			RepositoryPathPattern: "gitea/{nameWithOwner}",
+		},
+		urls: []urlToRepoName{
+			{"git@git.mycompany.com:foo/bar.git", "gitea/foo/bar"},
+			{"https://git.mycompany.com/gitea/foo/bar.git", "gitea/foo/bar"},
+
+			{"https://asdf.com/gitea/foo/bar.git", ""},
+		},
+	}}
+
+	for _, test := range tests {
+		for _, u := range test.urls {
+			repoName, err := Gitea{&test.conn}.CloneURLToRepoName(u.cloneURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.repoName != string(repoName) {
+				t.Errorf("expected %q but got %q for clone URL %q (connection: %+v)", u.repoName, repoName, u.cloneURL, test.conn)
+			}
+		}
+	}
+}
@@ -39,6 +39,7 @@ type DB interface {
 	Phabricator() PhabricatorStore
 	Repos() RepoStore
 	RepoKVPs() RepoKVPStore
+	RepoInventories() RepoInventoryStore
 	SavedSearches() SavedSearchStore
 	SearchContexts() SearchContextsStore
 	Settings() SettingsStore
@@ -175,6 +176,10 @@ func (d *db) RepoKVPs() RepoKVPStore {
 	return &repoKVPStore{d.Store}
 }
 
+func (d *db) RepoInventories() RepoInventoryStore {
+	return RepoInventoriesWith(d.Store)
+}
+
 func (d *db) SavedSearches() SavedSearchStore {
 	return SavedSearchesWith(d.Store)
 }
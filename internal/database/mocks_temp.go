@@ -3686,6 +3686,9 @@ type MockDB struct {
 	// QueryRowContextFunc is an instance of a mock function object
 	// controlling the behavior of the method QueryRowContext.
 	QueryRowContextFunc *DBQueryRowContextFunc
+	// RepoInventoriesFunc is an instance of a mock function object
+	// controlling the behavior of the method RepoInventories.
+	RepoInventoriesFunc *DBRepoInventoriesFunc
 	// RepoKVPsFunc is an instance of a mock function object controlling the
 	// behavior of the method RepoKVPs.
 	RepoKVPsFunc *DBRepoKVPsFunc
@@ -3853,6 +3856,11 @@ func NewMockDB() *MockDB {
 				return
 			},
 		},
+		RepoInventoriesFunc: &DBRepoInventoriesFunc{
+			defaultHook: func() (r0 RepoInventoryStore) {
+				return
+			},
+		},
 		RepoKVPsFunc: &DBRepoKVPsFunc{
 			defaultHook: func() (r0 RepoKVPStore) {
 				return
@@ -4055,6 +4063,11 @@ func NewStrictMockDB() *MockDB {
 				panic("unexpected invocation of MockDB.QueryRowContext")
 			},
 		},
+		RepoInventoriesFunc: &DBRepoInventoriesFunc{
+			defaultHook: func() RepoInventoryStore {
+				panic("unexpected invocation of MockDB.RepoInventories")
+			},
+		},
 		RepoKVPsFunc: &DBRepoKVPsFunc{
 			defaultHook: func() RepoKVPStore {
 				panic("unexpected invocation of MockDB.RepoKVPs")
@@ -4213,6 +4226,9 @@ func NewMockDBFrom(i DB) *MockDB {
 		QueryRowContextFunc: &DBQueryRowContextFunc{
 			defaultHook: i.QueryRowContext,
 		},
+		RepoInventoriesFunc: &DBRepoInventoriesFunc{
+			defaultHook: i.RepoInventories,
+		},
 		RepoKVPsFunc: &DBRepoKVPsFunc{
 			defaultHook: i.RepoKVPs,
 		},
@@ -6488,6 +6504,105 @@ func (c DBQueryRowContextFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBRepoInventoriesFunc describes the behavior when the RepoInventories
+// method of the parent MockDB instance is invoked.
+type DBRepoInventoriesFunc struct {
+	defaultHook func() RepoInventoryStore
+	hooks       []func() RepoInventoryStore
+	history     []DBRepoInventoriesFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoInventories delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDB) RepoInventories() RepoInventoryStore {
+	r0 := m.RepoInventoriesFunc.nextHook()()
+	m.RepoInventoriesFunc.appendCall(DBRepoInventoriesFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RepoInventories
+// method of the parent MockDB instance is invoked and the hook queue is
+// empty.
+func (f *DBRepoInventoriesFunc) SetDefaultHook(hook func() RepoInventoryStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoInventories method of the parent MockDB instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBRepoInventoriesFunc) PushHook(hook func() RepoInventoryStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBRepoInventoriesFunc) SetDefaultReturn(r0 RepoInventoryStore) {
+	f.SetDefaultHook(func() RepoInventoryStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBRepoInventoriesFunc) PushReturn(r0 RepoInventoryStore) {
+	f.PushHook(func() RepoInventoryStore {
+		return r0
+	})
+}
+
+func (f *DBRepoInventoriesFunc) nextHook() func() RepoInventoryStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBRepoInventoriesFunc) appendCall(r0 DBRepoInventoriesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBRepoInventoriesFuncCall objects
+// describing the invocations of this function.
+func (f *DBRepoInventoriesFunc) History() []DBRepoInventoriesFuncCall {
+	f.mutex.Lock()
+	history := make([]DBRepoInventoriesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBRepoInventoriesFuncCall is an object that describes an invocation of
+// method RepoInventories on an instance of MockDB.
+type DBRepoInventoriesFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 RepoInventoryStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBRepoInventoriesFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBRepoInventoriesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBRepoKVPsFunc describes the behavior when the RepoKVPs method of the
 // parent MockDB instance is invoked.
 type DBRepoKVPsFunc struct {
@@ -29262,382 +29377,178 @@ func (c PhabricatorStoreWithFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// MockRepoStore is a mock implementation of the RepoStore interface (from
-// the package github.com/sourcegraph/sourcegraph/internal/database) used
-// for unit testing.
-type MockRepoStore struct {
-	// CountFunc is an instance of a mock function object controlling the
-	// behavior of the method Count.
-	CountFunc *RepoStoreCountFunc
-	// CreateFunc is an instance of a mock function object controlling the
-	// behavior of the method Create.
-	CreateFunc *RepoStoreCreateFunc
-	// DeleteFunc is an instance of a mock function object controlling the
-	// behavior of the method Delete.
-	DeleteFunc *RepoStoreDeleteFunc
-	// DoneFunc is an instance of a mock function object controlling the
-	// behavior of the method Done.
-	DoneFunc *RepoStoreDoneFunc
+// MockRepoInventoryStore is a mock implementation of the RepoInventoryStore
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockRepoInventoryStore struct {
 	// GetFunc is an instance of a mock function object controlling the
 	// behavior of the method Get.
-	GetFunc *RepoStoreGetFunc
-	// GetByHashedNameFunc is an instance of a mock function object
-	// controlling the behavior of the method GetByHashedName.
-	GetByHashedNameFunc *RepoStoreGetByHashedNameFunc
-	// GetByIDsFunc is an instance of a mock function object controlling the
-	// behavior of the method GetByIDs.
-	GetByIDsFunc *RepoStoreGetByIDsFunc
-	// GetByNameFunc is an instance of a mock function object controlling
-	// the behavior of the method GetByName.
-	GetByNameFunc *RepoStoreGetByNameFunc
-	// GetFirstRepoNameByCloneURLFunc is an instance of a mock function
-	// object controlling the behavior of the method
-	// GetFirstRepoNameByCloneURL.
-	GetFirstRepoNameByCloneURLFunc *RepoStoreGetFirstRepoNameByCloneURLFunc
-	// GetRepoDescriptionsByIDsFunc is an instance of a mock function object
-	// controlling the behavior of the method GetRepoDescriptionsByIDs.
-	GetRepoDescriptionsByIDsFunc *RepoStoreGetRepoDescriptionsByIDsFunc
-	// GetReposSetByIDsFunc is an instance of a mock function object
-	// controlling the behavior of the method GetReposSetByIDs.
-	GetReposSetByIDsFunc *RepoStoreGetReposSetByIDsFunc
+	GetFunc *RepoInventoryStoreGetFunc
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
-	HandleFunc *RepoStoreHandleFunc
-	// ListFunc is an instance of a mock function object controlling the
-	// behavior of the method List.
-	ListFunc *RepoStoreListFunc
-	// ListIndexableReposFunc is an instance of a mock function object
-	// controlling the behavior of the method ListIndexableRepos.
-	ListIndexableReposFunc *RepoStoreListIndexableReposFunc
-	// ListMinimalReposFunc is an instance of a mock function object
-	// controlling the behavior of the method ListMinimalRepos.
-	ListMinimalReposFunc *RepoStoreListMinimalReposFunc
-	// MetadataFunc is an instance of a mock function object controlling the
-	// behavior of the method Metadata.
-	MetadataFunc *RepoStoreMetadataFunc
-	// QueryFunc is an instance of a mock function object controlling the
-	// behavior of the method Query.
-	QueryFunc *RepoStoreQueryFunc
-	// StreamMinimalReposFunc is an instance of a mock function object
-	// controlling the behavior of the method StreamMinimalRepos.
-	StreamMinimalReposFunc *RepoStoreStreamMinimalReposFunc
+	HandleFunc *RepoInventoryStoreHandleFunc
+	// ListStaleFunc is an instance of a mock function object controlling
+	// the behavior of the method ListStale.
+	ListStaleFunc *RepoInventoryStoreListStaleFunc
+	// MarkFailedFunc is an instance of a mock function object controlling
+	// the behavior of the method MarkFailed.
+	MarkFailedFunc *RepoInventoryStoreMarkFailedFunc
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
-	TransactFunc *RepoStoreTransactFunc
+	TransactFunc *RepoInventoryStoreTransactFunc
+	// UpsertFunc is an instance of a mock function object controlling the
+	// behavior of the method Upsert.
+	UpsertFunc *RepoInventoryStoreUpsertFunc
 	// WithFunc is an instance of a mock function object controlling the
 	// behavior of the method With.
-	WithFunc *RepoStoreWithFunc
+	WithFunc *RepoInventoryStoreWithFunc
 }
 
-// NewMockRepoStore creates a new mock of the RepoStore interface. All
-// methods return zero values for all results, unless overwritten.
-func NewMockRepoStore() *MockRepoStore {
-	return &MockRepoStore{
-		CountFunc: &RepoStoreCountFunc{
-			defaultHook: func(context.Context, ReposListOptions) (r0 int, r1 error) {
-				return
-			},
-		},
-		CreateFunc: &RepoStoreCreateFunc{
-			defaultHook: func(context.Context, ...*types.Repo) (r0 error) {
-				return
-			},
-		},
-		DeleteFunc: &RepoStoreDeleteFunc{
-			defaultHook: func(context.Context, ...api.RepoID) (r0 error) {
-				return
-			},
-		},
-		DoneFunc: &RepoStoreDoneFunc{
-			defaultHook: func(error) (r0 error) {
-				return
-			},
-		},
-		GetFunc: &RepoStoreGetFunc{
-			defaultHook: func(context.Context, api.RepoID) (r0 *types.Repo, r1 error) {
-				return
-			},
-		},
-		GetByHashedNameFunc: &RepoStoreGetByHashedNameFunc{
-			defaultHook: func(context.Context, api.RepoHashedName) (r0 *types.Repo, r1 error) {
-				return
-			},
-		},
-		GetByIDsFunc: &RepoStoreGetByIDsFunc{
-			defaultHook: func(context.Context, ...api.RepoID) (r0 []*types.Repo, r1 error) {
+// NewMockRepoInventoryStore creates a new mock of the RepoInventoryStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockRepoInventoryStore() *MockRepoInventoryStore {
+	return &MockRepoInventoryStore{
+		GetFunc: &RepoInventoryStoreGetFunc{
+			defaultHook: func(context.Context, api.RepoID) (r0 *types.RepoInventory, r1 error) {
 				return
 			},
 		},
-		GetByNameFunc: &RepoStoreGetByNameFunc{
-			defaultHook: func(context.Context, api.RepoName) (r0 *types.Repo, r1 error) {
+		HandleFunc: &RepoInventoryStoreHandleFunc{
+			defaultHook: func() (r0 basestore.TransactableHandle) {
 				return
 			},
 		},
-		GetFirstRepoNameByCloneURLFunc: &RepoStoreGetFirstRepoNameByCloneURLFunc{
-			defaultHook: func(context.Context, string) (r0 api.RepoName, r1 error) {
+		ListStaleFunc: &RepoInventoryStoreListStaleFunc{
+			defaultHook: func(context.Context, int) (r0 []types.MinimalRepo, r1 error) {
 				return
 			},
 		},
-		GetRepoDescriptionsByIDsFunc: &RepoStoreGetRepoDescriptionsByIDsFunc{
-			defaultHook: func(context.Context, ...api.RepoID) (r0 map[api.RepoID]string, r1 error) {
+		MarkFailedFunc: &RepoInventoryStoreMarkFailedFunc{
+			defaultHook: func(context.Context, api.RepoID) (r0 error) {
 				return
 			},
 		},
-		GetReposSetByIDsFunc: &RepoStoreGetReposSetByIDsFunc{
-			defaultHook: func(context.Context, ...api.RepoID) (r0 map[api.RepoID]*types.Repo, r1 error) {
+		TransactFunc: &RepoInventoryStoreTransactFunc{
+			defaultHook: func(context.Context) (r0 RepoInventoryStore, r1 error) {
 				return
 			},
 		},
-		HandleFunc: &RepoStoreHandleFunc{
-			defaultHook: func() (r0 basestore.TransactableHandle) {
+		UpsertFunc: &RepoInventoryStoreUpsertFunc{
+			defaultHook: func(context.Context, *types.RepoInventory) (r0 error) {
 				return
 			},
 		},
-		ListFunc: &RepoStoreListFunc{
-			defaultHook: func(context.Context, ReposListOptions) (r0 []*types.Repo, r1 error) {
+		WithFunc: &RepoInventoryStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) (r0 RepoInventoryStore) {
 				return
 			},
 		},
-		ListIndexableReposFunc: &RepoStoreListIndexableReposFunc{
-			defaultHook: func(context.Context, ListIndexableReposOptions) (r0 []types.MinimalRepo, r1 error) {
-				return
+	}
+}
+
+// NewStrictMockRepoInventoryStore creates a new mock of the
+// RepoInventoryStore interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockRepoInventoryStore() *MockRepoInventoryStore {
+	return &MockRepoInventoryStore{
+		GetFunc: &RepoInventoryStoreGetFunc{
+			defaultHook: func(context.Context, api.RepoID) (*types.RepoInventory, error) {
+				panic("unexpected invocation of MockRepoInventoryStore.Get")
 			},
 		},
-		ListMinimalReposFunc: &RepoStoreListMinimalReposFunc{
-			defaultHook: func(context.Context, ReposListOptions) (r0 []types.MinimalRepo, r1 error) {
-				return
+		HandleFunc: &RepoInventoryStoreHandleFunc{
+			defaultHook: func() basestore.TransactableHandle {
+				panic("unexpected invocation of MockRepoInventoryStore.Handle")
 			},
 		},
-		MetadataFunc: &RepoStoreMetadataFunc{
-			defaultHook: func(context.Context, ...api.RepoID) (r0 []*types.SearchedRepo, r1 error) {
-				return
+		ListStaleFunc: &RepoInventoryStoreListStaleFunc{
+			defaultHook: func(context.Context, int) ([]types.MinimalRepo, error) {
+				panic("unexpected invocation of MockRepoInventoryStore.ListStale")
 			},
 		},
-		QueryFunc: &RepoStoreQueryFunc{
-			defaultHook: func(context.Context, *sqlf.Query) (r0 *sql.Rows, r1 error) {
-				return
+		MarkFailedFunc: &RepoInventoryStoreMarkFailedFunc{
+			defaultHook: func(context.Context, api.RepoID) error {
+				panic("unexpected invocation of MockRepoInventoryStore.MarkFailed")
 			},
 		},
-		StreamMinimalReposFunc: &RepoStoreStreamMinimalReposFunc{
-			defaultHook: func(context.Context, ReposListOptions, func(*types.MinimalRepo)) (r0 error) {
-				return
+		TransactFunc: &RepoInventoryStoreTransactFunc{
+			defaultHook: func(context.Context) (RepoInventoryStore, error) {
+				panic("unexpected invocation of MockRepoInventoryStore.Transact")
 			},
 		},
-		TransactFunc: &RepoStoreTransactFunc{
-			defaultHook: func(context.Context) (r0 RepoStore, r1 error) {
-				return
+		UpsertFunc: &RepoInventoryStoreUpsertFunc{
+			defaultHook: func(context.Context, *types.RepoInventory) error {
+				panic("unexpected invocation of MockRepoInventoryStore.Upsert")
 			},
 		},
-		WithFunc: &RepoStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) (r0 RepoStore) {
-				return
+		WithFunc: &RepoInventoryStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) RepoInventoryStore {
+				panic("unexpected invocation of MockRepoInventoryStore.With")
 			},
 		},
 	}
 }
 
-// NewStrictMockRepoStore creates a new mock of the RepoStore interface. All
-// methods panic on invocation, unless overwritten.
-func NewStrictMockRepoStore() *MockRepoStore {
-	return &MockRepoStore{
-		CountFunc: &RepoStoreCountFunc{
-			defaultHook: func(context.Context, ReposListOptions) (int, error) {
-				panic("unexpected invocation of MockRepoStore.Count")
-			},
+// NewMockRepoInventoryStoreFrom creates a new mock of the
+// MockRepoInventoryStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockRepoInventoryStoreFrom(i RepoInventoryStore) *MockRepoInventoryStore {
+	return &MockRepoInventoryStore{
+		GetFunc: &RepoInventoryStoreGetFunc{
+			defaultHook: i.Get,
 		},
-		CreateFunc: &RepoStoreCreateFunc{
-			defaultHook: func(context.Context, ...*types.Repo) error {
-				panic("unexpected invocation of MockRepoStore.Create")
-			},
+		HandleFunc: &RepoInventoryStoreHandleFunc{
+			defaultHook: i.Handle,
 		},
-		DeleteFunc: &RepoStoreDeleteFunc{
-			defaultHook: func(context.Context, ...api.RepoID) error {
-				panic("unexpected invocation of MockRepoStore.Delete")
-			},
+		ListStaleFunc: &RepoInventoryStoreListStaleFunc{
+			defaultHook: i.ListStale,
 		},
-		DoneFunc: &RepoStoreDoneFunc{
-			defaultHook: func(error) error {
-				panic("unexpected invocation of MockRepoStore.Done")
-			},
+		MarkFailedFunc: &RepoInventoryStoreMarkFailedFunc{
+			defaultHook: i.MarkFailed,
 		},
-		GetFunc: &RepoStoreGetFunc{
-			defaultHook: func(context.Context, api.RepoID) (*types.Repo, error) {
-				panic("unexpected invocation of MockRepoStore.Get")
-			},
+		TransactFunc: &RepoInventoryStoreTransactFunc{
+			defaultHook: i.Transact,
 		},
-		GetByHashedNameFunc: &RepoStoreGetByHashedNameFunc{
-			defaultHook: func(context.Context, api.RepoHashedName) (*types.Repo, error) {
-				panic("unexpected invocation of MockRepoStore.GetByHashedName")
-			},
+		UpsertFunc: &RepoInventoryStoreUpsertFunc{
+			defaultHook: i.Upsert,
 		},
-		GetByIDsFunc: &RepoStoreGetByIDsFunc{
-			defaultHook: func(context.Context, ...api.RepoID) ([]*types.Repo, error) {
-				panic("unexpected invocation of MockRepoStore.GetByIDs")
-			},
-		},
-		GetByNameFunc: &RepoStoreGetByNameFunc{
-			defaultHook: func(context.Context, api.RepoName) (*types.Repo, error) {
-				panic("unexpected invocation of MockRepoStore.GetByName")
-			},
-		},
-		GetFirstRepoNameByCloneURLFunc: &RepoStoreGetFirstRepoNameByCloneURLFunc{
-			defaultHook: func(context.Context, string) (api.RepoName, error) {
-				panic("unexpected invocation of MockRepoStore.GetFirstRepoNameByCloneURL")
-			},
-		},
-		GetRepoDescriptionsByIDsFunc: &RepoStoreGetRepoDescriptionsByIDsFunc{
-			defaultHook: func(context.Context, ...api.RepoID) (map[api.RepoID]string, error) {
-				panic("unexpected invocation of MockRepoStore.GetRepoDescriptionsByIDs")
-			},
-		},
-		GetReposSetByIDsFunc: &RepoStoreGetReposSetByIDsFunc{
-			defaultHook: func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
-				panic("unexpected invocation of MockRepoStore.GetReposSetByIDs")
-			},
-		},
-		HandleFunc: &RepoStoreHandleFunc{
-			defaultHook: func() basestore.TransactableHandle {
-				panic("unexpected invocation of MockRepoStore.Handle")
-			},
-		},
-		ListFunc: &RepoStoreListFunc{
-			defaultHook: func(context.Context, ReposListOptions) ([]*types.Repo, error) {
-				panic("unexpected invocation of MockRepoStore.List")
-			},
-		},
-		ListIndexableReposFunc: &RepoStoreListIndexableReposFunc{
-			defaultHook: func(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error) {
-				panic("unexpected invocation of MockRepoStore.ListIndexableRepos")
-			},
-		},
-		ListMinimalReposFunc: &RepoStoreListMinimalReposFunc{
-			defaultHook: func(context.Context, ReposListOptions) ([]types.MinimalRepo, error) {
-				panic("unexpected invocation of MockRepoStore.ListMinimalRepos")
-			},
-		},
-		MetadataFunc: &RepoStoreMetadataFunc{
-			defaultHook: func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error) {
-				panic("unexpected invocation of MockRepoStore.Metadata")
-			},
-		},
-		QueryFunc: &RepoStoreQueryFunc{
-			defaultHook: func(context.Context, *sqlf.Query) (*sql.Rows, error) {
-				panic("unexpected invocation of MockRepoStore.Query")
-			},
-		},
-		StreamMinimalReposFunc: &RepoStoreStreamMinimalReposFunc{
-			defaultHook: func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error {
-				panic("unexpected invocation of MockRepoStore.StreamMinimalRepos")
-			},
-		},
-		TransactFunc: &RepoStoreTransactFunc{
-			defaultHook: func(context.Context) (RepoStore, error) {
-				panic("unexpected invocation of MockRepoStore.Transact")
-			},
-		},
-		WithFunc: &RepoStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) RepoStore {
-				panic("unexpected invocation of MockRepoStore.With")
-			},
-		},
-	}
-}
-
-// NewMockRepoStoreFrom creates a new mock of the MockRepoStore interface.
-// All methods delegate to the given implementation, unless overwritten.
-func NewMockRepoStoreFrom(i RepoStore) *MockRepoStore {
-	return &MockRepoStore{
-		CountFunc: &RepoStoreCountFunc{
-			defaultHook: i.Count,
-		},
-		CreateFunc: &RepoStoreCreateFunc{
-			defaultHook: i.Create,
-		},
-		DeleteFunc: &RepoStoreDeleteFunc{
-			defaultHook: i.Delete,
-		},
-		DoneFunc: &RepoStoreDoneFunc{
-			defaultHook: i.Done,
-		},
-		GetFunc: &RepoStoreGetFunc{
-			defaultHook: i.Get,
-		},
-		GetByHashedNameFunc: &RepoStoreGetByHashedNameFunc{
-			defaultHook: i.GetByHashedName,
-		},
-		GetByIDsFunc: &RepoStoreGetByIDsFunc{
-			defaultHook: i.GetByIDs,
-		},
-		GetByNameFunc: &RepoStoreGetByNameFunc{
-			defaultHook: i.GetByName,
-		},
-		GetFirstRepoNameByCloneURLFunc: &RepoStoreGetFirstRepoNameByCloneURLFunc{
-			defaultHook: i.GetFirstRepoNameByCloneURL,
-		},
-		GetRepoDescriptionsByIDsFunc: &RepoStoreGetRepoDescriptionsByIDsFunc{
-			defaultHook: i.GetRepoDescriptionsByIDs,
-		},
-		GetReposSetByIDsFunc: &RepoStoreGetReposSetByIDsFunc{
-			defaultHook: i.GetReposSetByIDs,
-		},
-		HandleFunc: &RepoStoreHandleFunc{
-			defaultHook: i.Handle,
-		},
-		ListFunc: &RepoStoreListFunc{
-			defaultHook: i.List,
-		},
-		ListIndexableReposFunc: &RepoStoreListIndexableReposFunc{
-			defaultHook: i.ListIndexableRepos,
-		},
-		ListMinimalReposFunc: &RepoStoreListMinimalReposFunc{
-			defaultHook: i.ListMinimalRepos,
-		},
-		MetadataFunc: &RepoStoreMetadataFunc{
-			defaultHook: i.Metadata,
-		},
-		QueryFunc: &RepoStoreQueryFunc{
-			defaultHook: i.Query,
-		},
-		StreamMinimalReposFunc: &RepoStoreStreamMinimalReposFunc{
-			defaultHook: i.StreamMinimalRepos,
-		},
-		TransactFunc: &RepoStoreTransactFunc{
-			defaultHook: i.Transact,
-		},
-		WithFunc: &RepoStoreWithFunc{
-			defaultHook: i.With,
+		WithFunc: &RepoInventoryStoreWithFunc{
+			defaultHook: i.With,
 		},
 	}
 }
 
-// RepoStoreCountFunc describes the behavior when the Count method of the
-// parent MockRepoStore instance is invoked.
-type RepoStoreCountFunc struct {
-	defaultHook func(context.Context, ReposListOptions) (int, error)
-	hooks       []func(context.Context, ReposListOptions) (int, error)
-	history     []RepoStoreCountFuncCall
+// RepoInventoryStoreGetFunc describes the behavior when the Get method of
+// the parent MockRepoInventoryStore instance is invoked.
+type RepoInventoryStoreGetFunc struct {
+	defaultHook func(context.Context, api.RepoID) (*types.RepoInventory, error)
+	hooks       []func(context.Context, api.RepoID) (*types.RepoInventory, error)
+	history     []RepoInventoryStoreGetFuncCall
 	mutex       sync.Mutex
 }
 
-// Count delegates to the next hook function in the queue and stores the
+// Get delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockRepoStore) Count(v0 context.Context, v1 ReposListOptions) (int, error) {
-	r0, r1 := m.CountFunc.nextHook()(v0, v1)
-	m.CountFunc.appendCall(RepoStoreCountFuncCall{v0, v1, r0, r1})
+func (m *MockRepoInventoryStore) Get(v0 context.Context, v1 api.RepoID) (*types.RepoInventory, error) {
+	r0, r1 := m.GetFunc.nextHook()(v0, v1)
+	m.GetFunc.appendCall(RepoInventoryStoreGetFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Count method of the
-// parent MockRepoStore instance is invoked and the hook queue is empty.
-func (f *RepoStoreCountFunc) SetDefaultHook(hook func(context.Context, ReposListOptions) (int, error)) {
+// SetDefaultHook sets function that is called when the Get method of the
+// parent MockRepoInventoryStore instance is invoked and the hook queue is
+// empty.
+func (f *RepoInventoryStoreGetFunc) SetDefaultHook(hook func(context.Context, api.RepoID) (*types.RepoInventory, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Count method of the parent MockRepoStore instance invokes the hook at the
-// front of the queue and discards it. After the queue is empty, the default
-// hook function is invoked for any future action.
-func (f *RepoStoreCountFunc) PushHook(hook func(context.Context, ReposListOptions) (int, error)) {
+// Get method of the parent MockRepoInventoryStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RepoInventoryStoreGetFunc) PushHook(hook func(context.Context, api.RepoID) (*types.RepoInventory, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -29645,20 +29556,20 @@ func (f *RepoStoreCountFunc) PushHook(hook func(context.Context, ReposListOption
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreCountFunc) SetDefaultReturn(r0 int, r1 error) {
-	f.SetDefaultHook(func(context.Context, ReposListOptions) (int, error) {
+func (f *RepoInventoryStoreGetFunc) SetDefaultReturn(r0 *types.RepoInventory, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID) (*types.RepoInventory, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreCountFunc) PushReturn(r0 int, r1 error) {
-	f.PushHook(func(context.Context, ReposListOptions) (int, error) {
+func (f *RepoInventoryStoreGetFunc) PushReturn(r0 *types.RepoInventory, r1 error) {
+	f.PushHook(func(context.Context, api.RepoID) (*types.RepoInventory, error) {
 		return r0, r1
 	})
 }
 
-func (f *RepoStoreCountFunc) nextHook() func(context.Context, ReposListOptions) (int, error) {
+func (f *RepoInventoryStoreGetFunc) nextHook() func(context.Context, api.RepoID) (*types.RepoInventory, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -29671,35 +29582,35 @@ func (f *RepoStoreCountFunc) nextHook() func(context.Context, ReposListOptions)
 	return hook
 }
 
-func (f *RepoStoreCountFunc) appendCall(r0 RepoStoreCountFuncCall) {
+func (f *RepoInventoryStoreGetFunc) appendCall(r0 RepoInventoryStoreGetFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreCountFuncCall objects describing
-// the invocations of this function.
-func (f *RepoStoreCountFunc) History() []RepoStoreCountFuncCall {
+// History returns a sequence of RepoInventoryStoreGetFuncCall objects
+// describing the invocations of this function.
+func (f *RepoInventoryStoreGetFunc) History() []RepoInventoryStoreGetFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreCountFuncCall, len(f.history))
+	history := make([]RepoInventoryStoreGetFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreCountFuncCall is an object that describes an invocation of
-// method Count on an instance of MockRepoStore.
-type RepoStoreCountFuncCall struct {
+// RepoInventoryStoreGetFuncCall is an object that describes an invocation
+// of method Get on an instance of MockRepoInventoryStore.
+type RepoInventoryStoreGetFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 ReposListOptions
+	Arg1 api.RepoID
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int
+	Result0 *types.RepoInventory
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -29707,44 +29618,45 @@ type RepoStoreCountFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c RepoStoreCountFuncCall) Args() []interface{} {
+func (c RepoInventoryStoreGetFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreCountFuncCall) Results() []interface{} {
+func (c RepoInventoryStoreGetFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// RepoStoreCreateFunc describes the behavior when the Create method of the
-// parent MockRepoStore instance is invoked.
-type RepoStoreCreateFunc struct {
-	defaultHook func(context.Context, ...*types.Repo) error
-	hooks       []func(context.Context, ...*types.Repo) error
-	history     []RepoStoreCreateFuncCall
+// RepoInventoryStoreHandleFunc describes the behavior when the Handle
+// method of the parent MockRepoInventoryStore instance is invoked.
+type RepoInventoryStoreHandleFunc struct {
+	defaultHook func() basestore.TransactableHandle
+	hooks       []func() basestore.TransactableHandle
+	history     []RepoInventoryStoreHandleFuncCall
 	mutex       sync.Mutex
 }
 
-// Create delegates to the next hook function in the queue and stores the
+// Handle delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockRepoStore) Create(v0 context.Context, v1 ...*types.Repo) error {
-	r0 := m.CreateFunc.nextHook()(v0, v1...)
-	m.CreateFunc.appendCall(RepoStoreCreateFuncCall{v0, v1, r0})
+func (m *MockRepoInventoryStore) Handle() basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(RepoInventoryStoreHandleFuncCall{r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Create method of the
-// parent MockRepoStore instance is invoked and the hook queue is empty.
-func (f *RepoStoreCreateFunc) SetDefaultHook(hook func(context.Context, ...*types.Repo) error) {
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockRepoInventoryStore instance is invoked and the hook queue is
+// empty.
+func (f *RepoInventoryStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Create method of the parent MockRepoStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *RepoStoreCreateFunc) PushHook(hook func(context.Context, ...*types.Repo) error) {
+// Handle method of the parent MockRepoInventoryStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *RepoInventoryStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -29752,20 +29664,20 @@ func (f *RepoStoreCreateFunc) PushHook(hook func(context.Context, ...*types.Repo
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreCreateFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, ...*types.Repo) error {
+func (f *RepoInventoryStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
+	f.SetDefaultHook(func() basestore.TransactableHandle {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreCreateFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, ...*types.Repo) error {
+func (f *RepoInventoryStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
+	f.PushHook(func() basestore.TransactableHandle {
 		return r0
 	})
 }
 
-func (f *RepoStoreCreateFunc) nextHook() func(context.Context, ...*types.Repo) error {
+func (f *RepoInventoryStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -29778,84 +29690,72 @@ func (f *RepoStoreCreateFunc) nextHook() func(context.Context, ...*types.Repo) e
 	return hook
 }
 
-func (f *RepoStoreCreateFunc) appendCall(r0 RepoStoreCreateFuncCall) {
+func (f *RepoInventoryStoreHandleFunc) appendCall(r0 RepoInventoryStoreHandleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreCreateFuncCall objects describing
-// the invocations of this function.
-func (f *RepoStoreCreateFunc) History() []RepoStoreCreateFuncCall {
+// History returns a sequence of RepoInventoryStoreHandleFuncCall objects
+// describing the invocations of this function.
+func (f *RepoInventoryStoreHandleFunc) History() []RepoInventoryStoreHandleFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreCreateFuncCall, len(f.history))
+	history := make([]RepoInventoryStoreHandleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreCreateFuncCall is an object that describes an invocation of
-// method Create on an instance of MockRepoStore.
-type RepoStoreCreateFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 context.Context
-	// Arg1 is a slice containing the values of the variadic arguments
-	// passed to this method invocation.
-	Arg1 []*types.Repo
+// RepoInventoryStoreHandleFuncCall is an object that describes an
+// invocation of method Handle on an instance of MockRepoInventoryStore.
+type RepoInventoryStoreHandleFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 basestore.TransactableHandle
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation. The variadic slice argument is flattened in this array such
-// that one positional argument and three variadic arguments would result in
-// a slice of four, not two.
-func (c RepoStoreCreateFuncCall) Args() []interface{} {
-	trailing := []interface{}{}
-	for _, val := range c.Arg1 {
-		trailing = append(trailing, val)
-	}
-
-	return append([]interface{}{c.Arg0}, trailing...)
+// invocation.
+func (c RepoInventoryStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreCreateFuncCall) Results() []interface{} {
+func (c RepoInventoryStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// RepoStoreDeleteFunc describes the behavior when the Delete method of the
-// parent MockRepoStore instance is invoked.
-type RepoStoreDeleteFunc struct {
-	defaultHook func(context.Context, ...api.RepoID) error
-	hooks       []func(context.Context, ...api.RepoID) error
-	history     []RepoStoreDeleteFuncCall
+// RepoInventoryStoreListStaleFunc describes the behavior when the ListStale
+// method of the parent MockRepoInventoryStore instance is invoked.
+type RepoInventoryStoreListStaleFunc struct {
+	defaultHook func(context.Context, int) ([]types.MinimalRepo, error)
+	hooks       []func(context.Context, int) ([]types.MinimalRepo, error)
+	history     []RepoInventoryStoreListStaleFuncCall
 	mutex       sync.Mutex
 }
 
-// Delete delegates to the next hook function in the queue and stores the
+// ListStale delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockRepoStore) Delete(v0 context.Context, v1 ...api.RepoID) error {
-	r0 := m.DeleteFunc.nextHook()(v0, v1...)
-	m.DeleteFunc.appendCall(RepoStoreDeleteFuncCall{v0, v1, r0})
-	return r0
+func (m *MockRepoInventoryStore) ListStale(v0 context.Context, v1 int) ([]types.MinimalRepo, error) {
+	r0, r1 := m.ListStaleFunc.nextHook()(v0, v1)
+	m.ListStaleFunc.appendCall(RepoInventoryStoreListStaleFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Delete method of the
-// parent MockRepoStore instance is invoked and the hook queue is empty.
-func (f *RepoStoreDeleteFunc) SetDefaultHook(hook func(context.Context, ...api.RepoID) error) {
+// SetDefaultHook sets function that is called when the ListStale method of
+// the parent MockRepoInventoryStore instance is invoked and the hook queue
+// is empty.
+func (f *RepoInventoryStoreListStaleFunc) SetDefaultHook(hook func(context.Context, int) ([]types.MinimalRepo, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Delete method of the parent MockRepoStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *RepoStoreDeleteFunc) PushHook(hook func(context.Context, ...api.RepoID) error) {
+// ListStale method of the parent MockRepoInventoryStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *RepoInventoryStoreListStaleFunc) PushHook(hook func(context.Context, int) ([]types.MinimalRepo, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -29863,20 +29763,20 @@ func (f *RepoStoreDeleteFunc) PushHook(hook func(context.Context, ...api.RepoID)
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreDeleteFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, ...api.RepoID) error {
-		return r0
+func (f *RepoInventoryStoreListStaleFunc) SetDefaultReturn(r0 []types.MinimalRepo, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]types.MinimalRepo, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreDeleteFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, ...api.RepoID) error {
-		return r0
+func (f *RepoInventoryStoreListStaleFunc) PushReturn(r0 []types.MinimalRepo, r1 error) {
+	f.PushHook(func(context.Context, int) ([]types.MinimalRepo, error) {
+		return r0, r1
 	})
 }
 
-func (f *RepoStoreDeleteFunc) nextHook() func(context.Context, ...api.RepoID) error {
+func (f *RepoInventoryStoreListStaleFunc) nextHook() func(context.Context, int) ([]types.MinimalRepo, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -29889,84 +29789,82 @@ func (f *RepoStoreDeleteFunc) nextHook() func(context.Context, ...api.RepoID) er
 	return hook
 }
 
-func (f *RepoStoreDeleteFunc) appendCall(r0 RepoStoreDeleteFuncCall) {
+func (f *RepoInventoryStoreListStaleFunc) appendCall(r0 RepoInventoryStoreListStaleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreDeleteFuncCall objects describing
-// the invocations of this function.
-func (f *RepoStoreDeleteFunc) History() []RepoStoreDeleteFuncCall {
+// History returns a sequence of RepoInventoryStoreListStaleFuncCall objects
+// describing the invocations of this function.
+func (f *RepoInventoryStoreListStaleFunc) History() []RepoInventoryStoreListStaleFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreDeleteFuncCall, len(f.history))
+	history := make([]RepoInventoryStoreListStaleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreDeleteFuncCall is an object that describes an invocation of
-// method Delete on an instance of MockRepoStore.
-type RepoStoreDeleteFuncCall struct {
+// RepoInventoryStoreListStaleFuncCall is an object that describes an
+// invocation of method ListStale on an instance of MockRepoInventoryStore.
+type RepoInventoryStoreListStaleFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is a slice containing the values of the variadic arguments
-	// passed to this method invocation.
-	Arg1 []api.RepoID
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 []types.MinimalRepo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation. The variadic slice argument is flattened in this array such
-// that one positional argument and three variadic arguments would result in
-// a slice of four, not two.
-func (c RepoStoreDeleteFuncCall) Args() []interface{} {
-	trailing := []interface{}{}
-	for _, val := range c.Arg1 {
-		trailing = append(trailing, val)
-	}
-
-	return append([]interface{}{c.Arg0}, trailing...)
+// invocation.
+func (c RepoInventoryStoreListStaleFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreDeleteFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c RepoInventoryStoreListStaleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// RepoStoreDoneFunc describes the behavior when the Done method of the
-// parent MockRepoStore instance is invoked.
-type RepoStoreDoneFunc struct {
-	defaultHook func(error) error
-	hooks       []func(error) error
-	history     []RepoStoreDoneFuncCall
+// RepoInventoryStoreMarkFailedFunc describes the behavior when the
+// MarkFailed method of the parent MockRepoInventoryStore instance is
+// invoked.
+type RepoInventoryStoreMarkFailedFunc struct {
+	defaultHook func(context.Context, api.RepoID) error
+	hooks       []func(context.Context, api.RepoID) error
+	history     []RepoInventoryStoreMarkFailedFuncCall
 	mutex       sync.Mutex
 }
 
-// Done delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockRepoStore) Done(v0 error) error {
-	r0 := m.DoneFunc.nextHook()(v0)
-	m.DoneFunc.appendCall(RepoStoreDoneFuncCall{v0, r0})
+// MarkFailed delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockRepoInventoryStore) MarkFailed(v0 context.Context, v1 api.RepoID) error {
+	r0 := m.MarkFailedFunc.nextHook()(v0, v1)
+	m.MarkFailedFunc.appendCall(RepoInventoryStoreMarkFailedFuncCall{v0, v1, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Done method of the
-// parent MockRepoStore instance is invoked and the hook queue is empty.
-func (f *RepoStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+// SetDefaultHook sets function that is called when the MarkFailed method of
+// the parent MockRepoInventoryStore instance is invoked and the hook queue
+// is empty.
+func (f *RepoInventoryStoreMarkFailedFunc) SetDefaultHook(hook func(context.Context, api.RepoID) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Done method of the parent MockRepoStore instance invokes the hook at the
-// front of the queue and discards it. After the queue is empty, the default
-// hook function is invoked for any future action.
-func (f *RepoStoreDoneFunc) PushHook(hook func(error) error) {
+// MarkFailed method of the parent MockRepoInventoryStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *RepoInventoryStoreMarkFailedFunc) PushHook(hook func(context.Context, api.RepoID) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -29974,20 +29872,20 @@ func (f *RepoStoreDoneFunc) PushHook(hook func(error) error) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreDoneFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(error) error {
+func (f *RepoInventoryStoreMarkFailedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreDoneFunc) PushReturn(r0 error) {
-	f.PushHook(func(error) error {
+func (f *RepoInventoryStoreMarkFailedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoID) error {
 		return r0
 	})
 }
 
-func (f *RepoStoreDoneFunc) nextHook() func(error) error {
+func (f *RepoInventoryStoreMarkFailedFunc) nextHook() func(context.Context, api.RepoID) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30000,29 +29898,32 @@ func (f *RepoStoreDoneFunc) nextHook() func(error) error {
 	return hook
 }
 
-func (f *RepoStoreDoneFunc) appendCall(r0 RepoStoreDoneFuncCall) {
+func (f *RepoInventoryStoreMarkFailedFunc) appendCall(r0 RepoInventoryStoreMarkFailedFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreDoneFuncCall objects describing
-// the invocations of this function.
-func (f *RepoStoreDoneFunc) History() []RepoStoreDoneFuncCall {
+// History returns a sequence of RepoInventoryStoreMarkFailedFuncCall
+// objects describing the invocations of this function.
+func (f *RepoInventoryStoreMarkFailedFunc) History() []RepoInventoryStoreMarkFailedFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreDoneFuncCall, len(f.history))
+	history := make([]RepoInventoryStoreMarkFailedFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreDoneFuncCall is an object that describes an invocation of method
-// Done on an instance of MockRepoStore.
-type RepoStoreDoneFuncCall struct {
+// RepoInventoryStoreMarkFailedFuncCall is an object that describes an
+// invocation of method MarkFailed on an instance of MockRepoInventoryStore.
+type RepoInventoryStoreMarkFailedFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 error
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -30030,44 +29931,45 @@ type RepoStoreDoneFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c RepoStoreDoneFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c RepoInventoryStoreMarkFailedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreDoneFuncCall) Results() []interface{} {
+func (c RepoInventoryStoreMarkFailedFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// RepoStoreGetFunc describes the behavior when the Get method of the parent
-// MockRepoStore instance is invoked.
-type RepoStoreGetFunc struct {
-	defaultHook func(context.Context, api.RepoID) (*types.Repo, error)
-	hooks       []func(context.Context, api.RepoID) (*types.Repo, error)
-	history     []RepoStoreGetFuncCall
+// RepoInventoryStoreTransactFunc describes the behavior when the Transact
+// method of the parent MockRepoInventoryStore instance is invoked.
+type RepoInventoryStoreTransactFunc struct {
+	defaultHook func(context.Context) (RepoInventoryStore, error)
+	hooks       []func(context.Context) (RepoInventoryStore, error)
+	history     []RepoInventoryStoreTransactFuncCall
 	mutex       sync.Mutex
 }
 
-// Get delegates to the next hook function in the queue and stores the
+// Transact delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockRepoStore) Get(v0 context.Context, v1 api.RepoID) (*types.Repo, error) {
-	r0, r1 := m.GetFunc.nextHook()(v0, v1)
-	m.GetFunc.appendCall(RepoStoreGetFuncCall{v0, v1, r0, r1})
+func (m *MockRepoInventoryStore) Transact(v0 context.Context) (RepoInventoryStore, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(RepoInventoryStoreTransactFuncCall{v0, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Get method of the
-// parent MockRepoStore instance is invoked and the hook queue is empty.
-func (f *RepoStoreGetFunc) SetDefaultHook(hook func(context.Context, api.RepoID) (*types.Repo, error)) {
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockRepoInventoryStore instance is invoked and the hook queue
+// is empty.
+func (f *RepoInventoryStoreTransactFunc) SetDefaultHook(hook func(context.Context) (RepoInventoryStore, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Get method of the parent MockRepoStore instance invokes the hook at the
-// front of the queue and discards it. After the queue is empty, the default
-// hook function is invoked for any future action.
-func (f *RepoStoreGetFunc) PushHook(hook func(context.Context, api.RepoID) (*types.Repo, error)) {
+// Transact method of the parent MockRepoInventoryStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *RepoInventoryStoreTransactFunc) PushHook(hook func(context.Context) (RepoInventoryStore, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30075,20 +29977,20 @@ func (f *RepoStoreGetFunc) PushHook(hook func(context.Context, api.RepoID) (*typ
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreGetFunc) SetDefaultReturn(r0 *types.Repo, r1 error) {
-	f.SetDefaultHook(func(context.Context, api.RepoID) (*types.Repo, error) {
+func (f *RepoInventoryStoreTransactFunc) SetDefaultReturn(r0 RepoInventoryStore, r1 error) {
+	f.SetDefaultHook(func(context.Context) (RepoInventoryStore, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreGetFunc) PushReturn(r0 *types.Repo, r1 error) {
-	f.PushHook(func(context.Context, api.RepoID) (*types.Repo, error) {
+func (f *RepoInventoryStoreTransactFunc) PushReturn(r0 RepoInventoryStore, r1 error) {
+	f.PushHook(func(context.Context) (RepoInventoryStore, error) {
 		return r0, r1
 	})
 }
 
-func (f *RepoStoreGetFunc) nextHook() func(context.Context, api.RepoID) (*types.Repo, error) {
+func (f *RepoInventoryStoreTransactFunc) nextHook() func(context.Context) (RepoInventoryStore, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30101,35 +30003,32 @@ func (f *RepoStoreGetFunc) nextHook() func(context.Context, api.RepoID) (*types.
 	return hook
 }
 
-func (f *RepoStoreGetFunc) appendCall(r0 RepoStoreGetFuncCall) {
+func (f *RepoInventoryStoreTransactFunc) appendCall(r0 RepoInventoryStoreTransactFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreGetFuncCall objects describing the
-// invocations of this function.
-func (f *RepoStoreGetFunc) History() []RepoStoreGetFuncCall {
+// History returns a sequence of RepoInventoryStoreTransactFuncCall objects
+// describing the invocations of this function.
+func (f *RepoInventoryStoreTransactFunc) History() []RepoInventoryStoreTransactFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreGetFuncCall, len(f.history))
+	history := make([]RepoInventoryStoreTransactFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreGetFuncCall is an object that describes an invocation of method
-// Get on an instance of MockRepoStore.
-type RepoStoreGetFuncCall struct {
+// RepoInventoryStoreTransactFuncCall is an object that describes an
+// invocation of method Transact on an instance of MockRepoInventoryStore.
+type RepoInventoryStoreTransactFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 api.RepoID
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.Repo
+	Result0 RepoInventoryStore
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -30137,45 +30036,45 @@ type RepoStoreGetFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c RepoStoreGetFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c RepoInventoryStoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreGetFuncCall) Results() []interface{} {
+func (c RepoInventoryStoreTransactFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// RepoStoreGetByHashedNameFunc describes the behavior when the
-// GetByHashedName method of the parent MockRepoStore instance is invoked.
-type RepoStoreGetByHashedNameFunc struct {
-	defaultHook func(context.Context, api.RepoHashedName) (*types.Repo, error)
-	hooks       []func(context.Context, api.RepoHashedName) (*types.Repo, error)
-	history     []RepoStoreGetByHashedNameFuncCall
+// RepoInventoryStoreUpsertFunc describes the behavior when the Upsert
+// method of the parent MockRepoInventoryStore instance is invoked.
+type RepoInventoryStoreUpsertFunc struct {
+	defaultHook func(context.Context, *types.RepoInventory) error
+	hooks       []func(context.Context, *types.RepoInventory) error
+	history     []RepoInventoryStoreUpsertFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByHashedName delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockRepoStore) GetByHashedName(v0 context.Context, v1 api.RepoHashedName) (*types.Repo, error) {
-	r0, r1 := m.GetByHashedNameFunc.nextHook()(v0, v1)
-	m.GetByHashedNameFunc.appendCall(RepoStoreGetByHashedNameFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// Upsert delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoInventoryStore) Upsert(v0 context.Context, v1 *types.RepoInventory) error {
+	r0 := m.UpsertFunc.nextHook()(v0, v1)
+	m.UpsertFunc.appendCall(RepoInventoryStoreUpsertFuncCall{v0, v1, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the GetByHashedName
-// method of the parent MockRepoStore instance is invoked and the hook queue
-// is empty.
-func (f *RepoStoreGetByHashedNameFunc) SetDefaultHook(hook func(context.Context, api.RepoHashedName) (*types.Repo, error)) {
+// SetDefaultHook sets function that is called when the Upsert method of the
+// parent MockRepoInventoryStore instance is invoked and the hook queue is
+// empty.
+func (f *RepoInventoryStoreUpsertFunc) SetDefaultHook(hook func(context.Context, *types.RepoInventory) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByHashedName method of the parent MockRepoStore instance invokes the
+// Upsert method of the parent MockRepoInventoryStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *RepoStoreGetByHashedNameFunc) PushHook(hook func(context.Context, api.RepoHashedName) (*types.Repo, error)) {
+func (f *RepoInventoryStoreUpsertFunc) PushHook(hook func(context.Context, *types.RepoInventory) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30183,20 +30082,20 @@ func (f *RepoStoreGetByHashedNameFunc) PushHook(hook func(context.Context, api.R
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreGetByHashedNameFunc) SetDefaultReturn(r0 *types.Repo, r1 error) {
-	f.SetDefaultHook(func(context.Context, api.RepoHashedName) (*types.Repo, error) {
-		return r0, r1
+func (f *RepoInventoryStoreUpsertFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *types.RepoInventory) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreGetByHashedNameFunc) PushReturn(r0 *types.Repo, r1 error) {
-	f.PushHook(func(context.Context, api.RepoHashedName) (*types.Repo, error) {
-		return r0, r1
+func (f *RepoInventoryStoreUpsertFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *types.RepoInventory) error {
+		return r0
 	})
 }
 
-func (f *RepoStoreGetByHashedNameFunc) nextHook() func(context.Context, api.RepoHashedName) (*types.Repo, error) {
+func (f *RepoInventoryStoreUpsertFunc) nextHook() func(context.Context, *types.RepoInventory) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30209,80 +30108,78 @@ func (f *RepoStoreGetByHashedNameFunc) nextHook() func(context.Context, api.Repo
 	return hook
 }
 
-func (f *RepoStoreGetByHashedNameFunc) appendCall(r0 RepoStoreGetByHashedNameFuncCall) {
+func (f *RepoInventoryStoreUpsertFunc) appendCall(r0 RepoInventoryStoreUpsertFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreGetByHashedNameFuncCall objects
+// History returns a sequence of RepoInventoryStoreUpsertFuncCall objects
 // describing the invocations of this function.
-func (f *RepoStoreGetByHashedNameFunc) History() []RepoStoreGetByHashedNameFuncCall {
+func (f *RepoInventoryStoreUpsertFunc) History() []RepoInventoryStoreUpsertFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreGetByHashedNameFuncCall, len(f.history))
+	history := make([]RepoInventoryStoreUpsertFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreGetByHashedNameFuncCall is an object that describes an
-// invocation of method GetByHashedName on an instance of MockRepoStore.
-type RepoStoreGetByHashedNameFuncCall struct {
+// RepoInventoryStoreUpsertFuncCall is an object that describes an
+// invocation of method Upsert on an instance of MockRepoInventoryStore.
+type RepoInventoryStoreUpsertFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 api.RepoHashedName
+	Arg1 *types.RepoInventory
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.Repo
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c RepoStoreGetByHashedNameFuncCall) Args() []interface{} {
+func (c RepoInventoryStoreUpsertFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreGetByHashedNameFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c RepoInventoryStoreUpsertFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// RepoStoreGetByIDsFunc describes the behavior when the GetByIDs method of
-// the parent MockRepoStore instance is invoked.
-type RepoStoreGetByIDsFunc struct {
-	defaultHook func(context.Context, ...api.RepoID) ([]*types.Repo, error)
-	hooks       []func(context.Context, ...api.RepoID) ([]*types.Repo, error)
-	history     []RepoStoreGetByIDsFuncCall
+// RepoInventoryStoreWithFunc describes the behavior when the With method of
+// the parent MockRepoInventoryStore instance is invoked.
+type RepoInventoryStoreWithFunc struct {
+	defaultHook func(basestore.ShareableStore) RepoInventoryStore
+	hooks       []func(basestore.ShareableStore) RepoInventoryStore
+	history     []RepoInventoryStoreWithFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByIDs delegates to the next hook function in the queue and stores the
+// With delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockRepoStore) GetByIDs(v0 context.Context, v1 ...api.RepoID) ([]*types.Repo, error) {
-	r0, r1 := m.GetByIDsFunc.nextHook()(v0, v1...)
-	m.GetByIDsFunc.appendCall(RepoStoreGetByIDsFuncCall{v0, v1, r0, r1})
-	return r0, r1
+func (m *MockRepoInventoryStore) With(v0 basestore.ShareableStore) RepoInventoryStore {
+	r0 := m.WithFunc.nextHook()(v0)
+	m.WithFunc.appendCall(RepoInventoryStoreWithFuncCall{v0, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the GetByIDs method of
-// the parent MockRepoStore instance is invoked and the hook queue is empty.
-func (f *RepoStoreGetByIDsFunc) SetDefaultHook(hook func(context.Context, ...api.RepoID) ([]*types.Repo, error)) {
+// SetDefaultHook sets function that is called when the With method of the
+// parent MockRepoInventoryStore instance is invoked and the hook queue is
+// empty.
+func (f *RepoInventoryStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) RepoInventoryStore) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByIDs method of the parent MockRepoStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *RepoStoreGetByIDsFunc) PushHook(hook func(context.Context, ...api.RepoID) ([]*types.Repo, error)) {
+// With method of the parent MockRepoInventoryStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *RepoInventoryStoreWithFunc) PushHook(hook func(basestore.ShareableStore) RepoInventoryStore) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30290,20 +30187,20 @@ func (f *RepoStoreGetByIDsFunc) PushHook(hook func(context.Context, ...api.RepoI
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreGetByIDsFunc) SetDefaultReturn(r0 []*types.Repo, r1 error) {
-	f.SetDefaultHook(func(context.Context, ...api.RepoID) ([]*types.Repo, error) {
-		return r0, r1
+func (f *RepoInventoryStoreWithFunc) SetDefaultReturn(r0 RepoInventoryStore) {
+	f.SetDefaultHook(func(basestore.ShareableStore) RepoInventoryStore {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreGetByIDsFunc) PushReturn(r0 []*types.Repo, r1 error) {
-	f.PushHook(func(context.Context, ...api.RepoID) ([]*types.Repo, error) {
-		return r0, r1
+func (f *RepoInventoryStoreWithFunc) PushReturn(r0 RepoInventoryStore) {
+	f.PushHook(func(basestore.ShareableStore) RepoInventoryStore {
+		return r0
 	})
 }
 
-func (f *RepoStoreGetByIDsFunc) nextHook() func(context.Context, ...api.RepoID) ([]*types.Repo, error) {
+func (f *RepoInventoryStoreWithFunc) nextHook() func(basestore.ShareableStore) RepoInventoryStore {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30316,87 +30213,1839 @@ func (f *RepoStoreGetByIDsFunc) nextHook() func(context.Context, ...api.RepoID)
 	return hook
 }
 
-func (f *RepoStoreGetByIDsFunc) appendCall(r0 RepoStoreGetByIDsFuncCall) {
+func (f *RepoInventoryStoreWithFunc) appendCall(r0 RepoInventoryStoreWithFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreGetByIDsFuncCall objects
+// History returns a sequence of RepoInventoryStoreWithFuncCall objects
 // describing the invocations of this function.
-func (f *RepoStoreGetByIDsFunc) History() []RepoStoreGetByIDsFuncCall {
+func (f *RepoInventoryStoreWithFunc) History() []RepoInventoryStoreWithFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreGetByIDsFuncCall, len(f.history))
+	history := make([]RepoInventoryStoreWithFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreGetByIDsFuncCall is an object that describes an invocation of
-// method GetByIDs on an instance of MockRepoStore.
-type RepoStoreGetByIDsFuncCall struct {
+// RepoInventoryStoreWithFuncCall is an object that describes an invocation
+// of method With on an instance of MockRepoInventoryStore.
+type RepoInventoryStoreWithFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 context.Context
-	// Arg1 is a slice containing the values of the variadic arguments
-	// passed to this method invocation.
-	Arg1 []api.RepoID
+	Arg0 basestore.ShareableStore
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*types.Repo
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 RepoInventoryStore
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation. The variadic slice argument is flattened in this array such
-// that one positional argument and three variadic arguments would result in
-// a slice of four, not two.
-func (c RepoStoreGetByIDsFuncCall) Args() []interface{} {
-	trailing := []interface{}{}
-	for _, val := range c.Arg1 {
-		trailing = append(trailing, val)
-	}
-
-	return append([]interface{}{c.Arg0}, trailing...)
+// invocation.
+func (c RepoInventoryStoreWithFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreGetByIDsFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c RepoInventoryStoreWithFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// RepoStoreGetByNameFunc describes the behavior when the GetByName method
-// of the parent MockRepoStore instance is invoked.
-type RepoStoreGetByNameFunc struct {
-	defaultHook func(context.Context, api.RepoName) (*types.Repo, error)
-	hooks       []func(context.Context, api.RepoName) (*types.Repo, error)
-	history     []RepoStoreGetByNameFuncCall
-	mutex       sync.Mutex
+// MockRepoStore is a mock implementation of the RepoStore interface (from
+// the package github.com/sourcegraph/sourcegraph/internal/database) used
+// for unit testing.
+type MockRepoStore struct {
+	// CountFunc is an instance of a mock function object controlling the
+	// behavior of the method Count.
+	CountFunc *RepoStoreCountFunc
+	// CreateFunc is an instance of a mock function object controlling the
+	// behavior of the method Create.
+	CreateFunc *RepoStoreCreateFunc
+	// DeleteFunc is an instance of a mock function object controlling the
+	// behavior of the method Delete.
+	DeleteFunc *RepoStoreDeleteFunc
+	// DoneFunc is an instance of a mock function object controlling the
+	// behavior of the method Done.
+	DoneFunc *RepoStoreDoneFunc
+	// GetFunc is an instance of a mock function object controlling the
+	// behavior of the method Get.
+	GetFunc *RepoStoreGetFunc
+	// GetByHashedNameFunc is an instance of a mock function object
+	// controlling the behavior of the method GetByHashedName.
+	GetByHashedNameFunc *RepoStoreGetByHashedNameFunc
+	// GetByIDsFunc is an instance of a mock function object controlling the
+	// behavior of the method GetByIDs.
+	GetByIDsFunc *RepoStoreGetByIDsFunc
+	// GetByNameFunc is an instance of a mock function object controlling
+	// the behavior of the method GetByName.
+	GetByNameFunc *RepoStoreGetByNameFunc
+	// GetFirstRepoNameByCloneURLFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetFirstRepoNameByCloneURL.
+	GetFirstRepoNameByCloneURLFunc *RepoStoreGetFirstRepoNameByCloneURLFunc
+	// GetRepoDescriptionsByIDsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetRepoDescriptionsByIDs.
+	GetRepoDescriptionsByIDsFunc *RepoStoreGetRepoDescriptionsByIDsFunc
+	// GetReposSetByIDsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetReposSetByIDs.
+	GetReposSetByIDsFunc *RepoStoreGetReposSetByIDsFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *RepoStoreHandleFunc
+	// ListFunc is an instance of a mock function object controlling the
+	// behavior of the method List.
+	ListFunc *RepoStoreListFunc
+	// ListIndexableReposFunc is an instance of a mock function object
+	// controlling the behavior of the method ListIndexableRepos.
+	ListIndexableReposFunc *RepoStoreListIndexableReposFunc
+	// ListMinimalReposFunc is an instance of a mock function object
+	// controlling the behavior of the method ListMinimalRepos.
+	ListMinimalReposFunc *RepoStoreListMinimalReposFunc
+	// MetadataFunc is an instance of a mock function object controlling the
+	// behavior of the method Metadata.
+	MetadataFunc *RepoStoreMetadataFunc
+	// QueryFunc is an instance of a mock function object controlling the
+	// behavior of the method Query.
+	QueryFunc *RepoStoreQueryFunc
+	// StreamMinimalReposFunc is an instance of a mock function object
+	// controlling the behavior of the method StreamMinimalRepos.
+	StreamMinimalReposFunc *RepoStoreStreamMinimalReposFunc
+	// TransactFunc is an instance of a mock function object controlling the
+	// behavior of the method Transact.
+	TransactFunc *RepoStoreTransactFunc
+	// WithFunc is an instance of a mock function object controlling the
+	// behavior of the method With.
+	WithFunc *RepoStoreWithFunc
+}
+
+// NewMockRepoStore creates a new mock of the RepoStore interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockRepoStore() *MockRepoStore {
+	return &MockRepoStore{
+		CountFunc: &RepoStoreCountFunc{
+			defaultHook: func(context.Context, ReposListOptions) (r0 int, r1 error) {
+				return
+			},
+		},
+		CreateFunc: &RepoStoreCreateFunc{
+			defaultHook: func(context.Context, ...*types.Repo) (r0 error) {
+				return
+			},
+		},
+		DeleteFunc: &RepoStoreDeleteFunc{
+			defaultHook: func(context.Context, ...api.RepoID) (r0 error) {
+				return
+			},
+		},
+		DoneFunc: &RepoStoreDoneFunc{
+			defaultHook: func(error) (r0 error) {
+				return
+			},
+		},
+		GetFunc: &RepoStoreGetFunc{
+			defaultHook: func(context.Context, api.RepoID) (r0 *types.Repo, r1 error) {
+				return
+			},
+		},
+		GetByHashedNameFunc: &RepoStoreGetByHashedNameFunc{
+			defaultHook: func(context.Context, api.RepoHashedName) (r0 *types.Repo, r1 error) {
+				return
+			},
+		},
+		GetByIDsFunc: &RepoStoreGetByIDsFunc{
+			defaultHook: func(context.Context, ...api.RepoID) (r0 []*types.Repo, r1 error) {
+				return
+			},
+		},
+		GetByNameFunc: &RepoStoreGetByNameFunc{
+			defaultHook: func(context.Context, api.RepoName) (r0 *types.Repo, r1 error) {
+				return
+			},
+		},
+		GetFirstRepoNameByCloneURLFunc: &RepoStoreGetFirstRepoNameByCloneURLFunc{
+			defaultHook: func(context.Context, string) (r0 api.RepoName, r1 error) {
+				return
+			},
+		},
+		GetRepoDescriptionsByIDsFunc: &RepoStoreGetRepoDescriptionsByIDsFunc{
+			defaultHook: func(context.Context, ...api.RepoID) (r0 map[api.RepoID]string, r1 error) {
+				return
+			},
+		},
+		GetReposSetByIDsFunc: &RepoStoreGetReposSetByIDsFunc{
+			defaultHook: func(context.Context, ...api.RepoID) (r0 map[api.RepoID]*types.Repo, r1 error) {
+				return
+			},
+		},
+		HandleFunc: &RepoStoreHandleFunc{
+			defaultHook: func() (r0 basestore.TransactableHandle) {
+				return
+			},
+		},
+		ListFunc: &RepoStoreListFunc{
+			defaultHook: func(context.Context, ReposListOptions) (r0 []*types.Repo, r1 error) {
+				return
+			},
+		},
+		ListIndexableReposFunc: &RepoStoreListIndexableReposFunc{
+			defaultHook: func(context.Context, ListIndexableReposOptions) (r0 []types.MinimalRepo, r1 error) {
+				return
+			},
+		},
+		ListMinimalReposFunc: &RepoStoreListMinimalReposFunc{
+			defaultHook: func(context.Context, ReposListOptions) (r0 []types.MinimalRepo, r1 error) {
+				return
+			},
+		},
+		MetadataFunc: &RepoStoreMetadataFunc{
+			defaultHook: func(context.Context, ...api.RepoID) (r0 []*types.SearchedRepo, r1 error) {
+				return
+			},
+		},
+		QueryFunc: &RepoStoreQueryFunc{
+			defaultHook: func(context.Context, *sqlf.Query) (r0 *sql.Rows, r1 error) {
+				return
+			},
+		},
+		StreamMinimalReposFunc: &RepoStoreStreamMinimalReposFunc{
+			defaultHook: func(context.Context, ReposListOptions, func(*types.MinimalRepo)) (r0 error) {
+				return
+			},
+		},
+		TransactFunc: &RepoStoreTransactFunc{
+			defaultHook: func(context.Context) (r0 RepoStore, r1 error) {
+				return
+			},
+		},
+		WithFunc: &RepoStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) (r0 RepoStore) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockRepoStore creates a new mock of the RepoStore interface. All
+// methods panic on invocation, unless overwritten.
+func NewStrictMockRepoStore() *MockRepoStore {
+	return &MockRepoStore{
+		CountFunc: &RepoStoreCountFunc{
+			defaultHook: func(context.Context, ReposListOptions) (int, error) {
+				panic("unexpected invocation of MockRepoStore.Count")
+			},
+		},
+		CreateFunc: &RepoStoreCreateFunc{
+			defaultHook: func(context.Context, ...*types.Repo) error {
+				panic("unexpected invocation of MockRepoStore.Create")
+			},
+		},
+		DeleteFunc: &RepoStoreDeleteFunc{
+			defaultHook: func(context.Context, ...api.RepoID) error {
+				panic("unexpected invocation of MockRepoStore.Delete")
+			},
+		},
+		DoneFunc: &RepoStoreDoneFunc{
+			defaultHook: func(error) error {
+				panic("unexpected invocation of MockRepoStore.Done")
+			},
+		},
+		GetFunc: &RepoStoreGetFunc{
+			defaultHook: func(context.Context, api.RepoID) (*types.Repo, error) {
+				panic("unexpected invocation of MockRepoStore.Get")
+			},
+		},
+		GetByHashedNameFunc: &RepoStoreGetByHashedNameFunc{
+			defaultHook: func(context.Context, api.RepoHashedName) (*types.Repo, error) {
+				panic("unexpected invocation of MockRepoStore.GetByHashedName")
+			},
+		},
+		GetByIDsFunc: &RepoStoreGetByIDsFunc{
+			defaultHook: func(context.Context, ...api.RepoID) ([]*types.Repo, error) {
+				panic("unexpected invocation of MockRepoStore.GetByIDs")
+			},
+		},
+		GetByNameFunc: &RepoStoreGetByNameFunc{
+			defaultHook: func(context.Context, api.RepoName) (*types.Repo, error) {
+				panic("unexpected invocation of MockRepoStore.GetByName")
+			},
+		},
+		GetFirstRepoNameByCloneURLFunc: &RepoStoreGetFirstRepoNameByCloneURLFunc{
+			defaultHook: func(context.Context, string) (api.RepoName, error) {
+				panic("unexpected invocation of MockRepoStore.GetFirstRepoNameByCloneURL")
+			},
+		},
+		GetRepoDescriptionsByIDsFunc: &RepoStoreGetRepoDescriptionsByIDsFunc{
+			defaultHook: func(context.Context, ...api.RepoID) (map[api.RepoID]string, error) {
+				panic("unexpected invocation of MockRepoStore.GetRepoDescriptionsByIDs")
+			},
+		},
+		GetReposSetByIDsFunc: &RepoStoreGetReposSetByIDsFunc{
+			defaultHook: func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
+				panic("unexpected invocation of MockRepoStore.GetReposSetByIDs")
+			},
+		},
+		HandleFunc: &RepoStoreHandleFunc{
+			defaultHook: func() basestore.TransactableHandle {
+				panic("unexpected invocation of MockRepoStore.Handle")
+			},
+		},
+		ListFunc: &RepoStoreListFunc{
+			defaultHook: func(context.Context, ReposListOptions) ([]*types.Repo, error) {
+				panic("unexpected invocation of MockRepoStore.List")
+			},
+		},
+		ListIndexableReposFunc: &RepoStoreListIndexableReposFunc{
+			defaultHook: func(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error) {
+				panic("unexpected invocation of MockRepoStore.ListIndexableRepos")
+			},
+		},
+		ListMinimalReposFunc: &RepoStoreListMinimalReposFunc{
+			defaultHook: func(context.Context, ReposListOptions) ([]types.MinimalRepo, error) {
+				panic("unexpected invocation of MockRepoStore.ListMinimalRepos")
+			},
+		},
+		MetadataFunc: &RepoStoreMetadataFunc{
+			defaultHook: func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error) {
+				panic("unexpected invocation of MockRepoStore.Metadata")
+			},
+		},
+		QueryFunc: &RepoStoreQueryFunc{
+			defaultHook: func(context.Context, *sqlf.Query) (*sql.Rows, error) {
+				panic("unexpected invocation of MockRepoStore.Query")
+			},
+		},
+		StreamMinimalReposFunc: &RepoStoreStreamMinimalReposFunc{
+			defaultHook: func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error {
+				panic("unexpected invocation of MockRepoStore.StreamMinimalRepos")
+			},
+		},
+		TransactFunc: &RepoStoreTransactFunc{
+			defaultHook: func(context.Context) (RepoStore, error) {
+				panic("unexpected invocation of MockRepoStore.Transact")
+			},
+		},
+		WithFunc: &RepoStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) RepoStore {
+				panic("unexpected invocation of MockRepoStore.With")
+			},
+		},
+	}
+}
+
+// NewMockRepoStoreFrom creates a new mock of the MockRepoStore interface.
+// All methods delegate to the given implementation, unless overwritten.
+func NewMockRepoStoreFrom(i RepoStore) *MockRepoStore {
+	return &MockRepoStore{
+		CountFunc: &RepoStoreCountFunc{
+			defaultHook: i.Count,
+		},
+		CreateFunc: &RepoStoreCreateFunc{
+			defaultHook: i.Create,
+		},
+		DeleteFunc: &RepoStoreDeleteFunc{
+			defaultHook: i.Delete,
+		},
+		DoneFunc: &RepoStoreDoneFunc{
+			defaultHook: i.Done,
+		},
+		GetFunc: &RepoStoreGetFunc{
+			defaultHook: i.Get,
+		},
+		GetByHashedNameFunc: &RepoStoreGetByHashedNameFunc{
+			defaultHook: i.GetByHashedName,
+		},
+		GetByIDsFunc: &RepoStoreGetByIDsFunc{
+			defaultHook: i.GetByIDs,
+		},
+		GetByNameFunc: &RepoStoreGetByNameFunc{
+			defaultHook: i.GetByName,
+		},
+		GetFirstRepoNameByCloneURLFunc: &RepoStoreGetFirstRepoNameByCloneURLFunc{
+			defaultHook: i.GetFirstRepoNameByCloneURL,
+		},
+		GetRepoDescriptionsByIDsFunc: &RepoStoreGetRepoDescriptionsByIDsFunc{
+			defaultHook: i.GetRepoDescriptionsByIDs,
+		},
+		GetReposSetByIDsFunc: &RepoStoreGetReposSetByIDsFunc{
+			defaultHook: i.GetReposSetByIDs,
+		},
+		HandleFunc: &RepoStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		ListFunc: &RepoStoreListFunc{
+			defaultHook: i.List,
+		},
+		ListIndexableReposFunc: &RepoStoreListIndexableReposFunc{
+			defaultHook: i.ListIndexableRepos,
+		},
+		ListMinimalReposFunc: &RepoStoreListMinimalReposFunc{
+			defaultHook: i.ListMinimalRepos,
+		},
+		MetadataFunc: &RepoStoreMetadataFunc{
+			defaultHook: i.Metadata,
+		},
+		QueryFunc: &RepoStoreQueryFunc{
+			defaultHook: i.Query,
+		},
+		StreamMinimalReposFunc: &RepoStoreStreamMinimalReposFunc{
+			defaultHook: i.StreamMinimalRepos,
+		},
+		TransactFunc: &RepoStoreTransactFunc{
+			defaultHook: i.Transact,
+		},
+		WithFunc: &RepoStoreWithFunc{
+			defaultHook: i.With,
+		},
+	}
+}
+
+// RepoStoreCountFunc describes the behavior when the Count method of the
+// parent MockRepoStore instance is invoked.
+type RepoStoreCountFunc struct {
+	defaultHook func(context.Context, ReposListOptions) (int, error)
+	hooks       []func(context.Context, ReposListOptions) (int, error)
+	history     []RepoStoreCountFuncCall
+	mutex       sync.Mutex
+}
+
+// Count delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoStore) Count(v0 context.Context, v1 ReposListOptions) (int, error) {
+	r0, r1 := m.CountFunc.nextHook()(v0, v1)
+	m.CountFunc.appendCall(RepoStoreCountFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Count method of the
+// parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreCountFunc) SetDefaultHook(hook func(context.Context, ReposListOptions) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Count method of the parent MockRepoStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *RepoStoreCountFunc) PushHook(hook func(context.Context, ReposListOptions) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreCountFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, ReposListOptions) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreCountFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, ReposListOptions) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoStoreCountFunc) nextHook() func(context.Context, ReposListOptions) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreCountFunc) appendCall(r0 RepoStoreCountFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreCountFuncCall objects describing
+// the invocations of this function.
+func (f *RepoStoreCountFunc) History() []RepoStoreCountFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreCountFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreCountFuncCall is an object that describes an invocation of
+// method Count on an instance of MockRepoStore.
+type RepoStoreCountFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 ReposListOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreCountFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreCountFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoStoreCreateFunc describes the behavior when the Create method of the
+// parent MockRepoStore instance is invoked.
+type RepoStoreCreateFunc struct {
+	defaultHook func(context.Context, ...*types.Repo) error
+	hooks       []func(context.Context, ...*types.Repo) error
+	history     []RepoStoreCreateFuncCall
+	mutex       sync.Mutex
+}
+
+// Create delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoStore) Create(v0 context.Context, v1 ...*types.Repo) error {
+	r0 := m.CreateFunc.nextHook()(v0, v1...)
+	m.CreateFunc.appendCall(RepoStoreCreateFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Create method of the
+// parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreCreateFunc) SetDefaultHook(hook func(context.Context, ...*types.Repo) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Create method of the parent MockRepoStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RepoStoreCreateFunc) PushHook(hook func(context.Context, ...*types.Repo) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreCreateFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, ...*types.Repo) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreCreateFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, ...*types.Repo) error {
+		return r0
+	})
+}
+
+func (f *RepoStoreCreateFunc) nextHook() func(context.Context, ...*types.Repo) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreCreateFunc) appendCall(r0 RepoStoreCreateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreCreateFuncCall objects describing
+// the invocations of this function.
+func (f *RepoStoreCreateFunc) History() []RepoStoreCreateFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreCreateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreCreateFuncCall is an object that describes an invocation of
+// method Create on an instance of MockRepoStore.
+type RepoStoreCreateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []*types.Repo
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c RepoStoreCreateFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreCreateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// RepoStoreDeleteFunc describes the behavior when the Delete method of the
+// parent MockRepoStore instance is invoked.
+type RepoStoreDeleteFunc struct {
+	defaultHook func(context.Context, ...api.RepoID) error
+	hooks       []func(context.Context, ...api.RepoID) error
+	history     []RepoStoreDeleteFuncCall
+	mutex       sync.Mutex
+}
+
+// Delete delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoStore) Delete(v0 context.Context, v1 ...api.RepoID) error {
+	r0 := m.DeleteFunc.nextHook()(v0, v1...)
+	m.DeleteFunc.appendCall(RepoStoreDeleteFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Delete method of the
+// parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreDeleteFunc) SetDefaultHook(hook func(context.Context, ...api.RepoID) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Delete method of the parent MockRepoStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RepoStoreDeleteFunc) PushHook(hook func(context.Context, ...api.RepoID) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreDeleteFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, ...api.RepoID) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreDeleteFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, ...api.RepoID) error {
+		return r0
+	})
+}
+
+func (f *RepoStoreDeleteFunc) nextHook() func(context.Context, ...api.RepoID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreDeleteFunc) appendCall(r0 RepoStoreDeleteFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreDeleteFuncCall objects describing
+// the invocations of this function.
+func (f *RepoStoreDeleteFunc) History() []RepoStoreDeleteFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreDeleteFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreDeleteFuncCall is an object that describes an invocation of
+// method Delete on an instance of MockRepoStore.
+type RepoStoreDeleteFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c RepoStoreDeleteFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreDeleteFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// RepoStoreDoneFunc describes the behavior when the Done method of the
+// parent MockRepoStore instance is invoked.
+type RepoStoreDoneFunc struct {
+	defaultHook func(error) error
+	hooks       []func(error) error
+	history     []RepoStoreDoneFuncCall
+	mutex       sync.Mutex
+}
+
+// Done delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoStore) Done(v0 error) error {
+	r0 := m.DoneFunc.nextHook()(v0)
+	m.DoneFunc.appendCall(RepoStoreDoneFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Done method of the
+// parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Done method of the parent MockRepoStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *RepoStoreDoneFunc) PushHook(hook func(error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreDoneFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreDoneFunc) PushReturn(r0 error) {
+	f.PushHook(func(error) error {
+		return r0
+	})
+}
+
+func (f *RepoStoreDoneFunc) nextHook() func(error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreDoneFunc) appendCall(r0 RepoStoreDoneFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreDoneFuncCall objects describing
+// the invocations of this function.
+func (f *RepoStoreDoneFunc) History() []RepoStoreDoneFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreDoneFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreDoneFuncCall is an object that describes an invocation of method
+// Done on an instance of MockRepoStore.
+type RepoStoreDoneFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreDoneFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreDoneFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// RepoStoreGetFunc describes the behavior when the Get method of the parent
+// MockRepoStore instance is invoked.
+type RepoStoreGetFunc struct {
+	defaultHook func(context.Context, api.RepoID) (*types.Repo, error)
+	hooks       []func(context.Context, api.RepoID) (*types.Repo, error)
+	history     []RepoStoreGetFuncCall
+	mutex       sync.Mutex
+}
+
+// Get delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoStore) Get(v0 context.Context, v1 api.RepoID) (*types.Repo, error) {
+	r0, r1 := m.GetFunc.nextHook()(v0, v1)
+	m.GetFunc.appendCall(RepoStoreGetFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Get method of the
+// parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreGetFunc) SetDefaultHook(hook func(context.Context, api.RepoID) (*types.Repo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Get method of the parent MockRepoStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *RepoStoreGetFunc) PushHook(hook func(context.Context, api.RepoID) (*types.Repo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreGetFunc) SetDefaultReturn(r0 *types.Repo, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID) (*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreGetFunc) PushReturn(r0 *types.Repo, r1 error) {
+	f.PushHook(func(context.Context, api.RepoID) (*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoStoreGetFunc) nextHook() func(context.Context, api.RepoID) (*types.Repo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreGetFunc) appendCall(r0 RepoStoreGetFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreGetFuncCall objects describing the
+// invocations of this function.
+func (f *RepoStoreGetFunc) History() []RepoStoreGetFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreGetFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreGetFuncCall is an object that describes an invocation of method
+// Get on an instance of MockRepoStore.
+type RepoStoreGetFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.Repo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreGetFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreGetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoStoreGetByHashedNameFunc describes the behavior when the
+// GetByHashedName method of the parent MockRepoStore instance is invoked.
+type RepoStoreGetByHashedNameFunc struct {
+	defaultHook func(context.Context, api.RepoHashedName) (*types.Repo, error)
+	hooks       []func(context.Context, api.RepoHashedName) (*types.Repo, error)
+	history     []RepoStoreGetByHashedNameFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByHashedName delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRepoStore) GetByHashedName(v0 context.Context, v1 api.RepoHashedName) (*types.Repo, error) {
+	r0, r1 := m.GetByHashedNameFunc.nextHook()(v0, v1)
+	m.GetByHashedNameFunc.appendCall(RepoStoreGetByHashedNameFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByHashedName
+// method of the parent MockRepoStore instance is invoked and the hook queue
+// is empty.
+func (f *RepoStoreGetByHashedNameFunc) SetDefaultHook(hook func(context.Context, api.RepoHashedName) (*types.Repo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByHashedName method of the parent MockRepoStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *RepoStoreGetByHashedNameFunc) PushHook(hook func(context.Context, api.RepoHashedName) (*types.Repo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreGetByHashedNameFunc) SetDefaultReturn(r0 *types.Repo, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoHashedName) (*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreGetByHashedNameFunc) PushReturn(r0 *types.Repo, r1 error) {
+	f.PushHook(func(context.Context, api.RepoHashedName) (*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoStoreGetByHashedNameFunc) nextHook() func(context.Context, api.RepoHashedName) (*types.Repo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreGetByHashedNameFunc) appendCall(r0 RepoStoreGetByHashedNameFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreGetByHashedNameFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreGetByHashedNameFunc) History() []RepoStoreGetByHashedNameFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreGetByHashedNameFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreGetByHashedNameFuncCall is an object that describes an
+// invocation of method GetByHashedName on an instance of MockRepoStore.
+type RepoStoreGetByHashedNameFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoHashedName
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.Repo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreGetByHashedNameFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreGetByHashedNameFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoStoreGetByIDsFunc describes the behavior when the GetByIDs method of
+// the parent MockRepoStore instance is invoked.
+type RepoStoreGetByIDsFunc struct {
+	defaultHook func(context.Context, ...api.RepoID) ([]*types.Repo, error)
+	hooks       []func(context.Context, ...api.RepoID) ([]*types.Repo, error)
+	history     []RepoStoreGetByIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByIDs delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoStore) GetByIDs(v0 context.Context, v1 ...api.RepoID) ([]*types.Repo, error) {
+	r0, r1 := m.GetByIDsFunc.nextHook()(v0, v1...)
+	m.GetByIDsFunc.appendCall(RepoStoreGetByIDsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByIDs method of
+// the parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreGetByIDsFunc) SetDefaultHook(hook func(context.Context, ...api.RepoID) ([]*types.Repo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByIDs method of the parent MockRepoStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RepoStoreGetByIDsFunc) PushHook(hook func(context.Context, ...api.RepoID) ([]*types.Repo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreGetByIDsFunc) SetDefaultReturn(r0 []*types.Repo, r1 error) {
+	f.SetDefaultHook(func(context.Context, ...api.RepoID) ([]*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreGetByIDsFunc) PushReturn(r0 []*types.Repo, r1 error) {
+	f.PushHook(func(context.Context, ...api.RepoID) ([]*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoStoreGetByIDsFunc) nextHook() func(context.Context, ...api.RepoID) ([]*types.Repo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreGetByIDsFunc) appendCall(r0 RepoStoreGetByIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreGetByIDsFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreGetByIDsFunc) History() []RepoStoreGetByIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreGetByIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreGetByIDsFuncCall is an object that describes an invocation of
+// method GetByIDs on an instance of MockRepoStore.
+type RepoStoreGetByIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.Repo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c RepoStoreGetByIDsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreGetByIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoStoreGetByNameFunc describes the behavior when the GetByName method
+// of the parent MockRepoStore instance is invoked.
+type RepoStoreGetByNameFunc struct {
+	defaultHook func(context.Context, api.RepoName) (*types.Repo, error)
+	hooks       []func(context.Context, api.RepoName) (*types.Repo, error)
+	history     []RepoStoreGetByNameFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByName delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoStore) GetByName(v0 context.Context, v1 api.RepoName) (*types.Repo, error) {
+	r0, r1 := m.GetByNameFunc.nextHook()(v0, v1)
+	m.GetByNameFunc.appendCall(RepoStoreGetByNameFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByName method of
+// the parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreGetByNameFunc) SetDefaultHook(hook func(context.Context, api.RepoName) (*types.Repo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByName method of the parent MockRepoStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RepoStoreGetByNameFunc) PushHook(hook func(context.Context, api.RepoName) (*types.Repo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreGetByNameFunc) SetDefaultReturn(r0 *types.Repo, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName) (*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreGetByNameFunc) PushReturn(r0 *types.Repo, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName) (*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoStoreGetByNameFunc) nextHook() func(context.Context, api.RepoName) (*types.Repo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreGetByNameFunc) appendCall(r0 RepoStoreGetByNameFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreGetByNameFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreGetByNameFunc) History() []RepoStoreGetByNameFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreGetByNameFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreGetByNameFuncCall is an object that describes an invocation of
+// method GetByName on an instance of MockRepoStore.
+type RepoStoreGetByNameFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.Repo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreGetByNameFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreGetByNameFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoStoreGetFirstRepoNameByCloneURLFunc describes the behavior when the
+// GetFirstRepoNameByCloneURL method of the parent MockRepoStore instance is
+// invoked.
+type RepoStoreGetFirstRepoNameByCloneURLFunc struct {
+	defaultHook func(context.Context, string) (api.RepoName, error)
+	hooks       []func(context.Context, string) (api.RepoName, error)
+	history     []RepoStoreGetFirstRepoNameByCloneURLFuncCall
+	mutex       sync.Mutex
+}
+
+// GetFirstRepoNameByCloneURL delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockRepoStore) GetFirstRepoNameByCloneURL(v0 context.Context, v1 string) (api.RepoName, error) {
+	r0, r1 := m.GetFirstRepoNameByCloneURLFunc.nextHook()(v0, v1)
+	m.GetFirstRepoNameByCloneURLFunc.appendCall(RepoStoreGetFirstRepoNameByCloneURLFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetFirstRepoNameByCloneURL method of the parent MockRepoStore instance is
+// invoked and the hook queue is empty.
+func (f *RepoStoreGetFirstRepoNameByCloneURLFunc) SetDefaultHook(hook func(context.Context, string) (api.RepoName, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetFirstRepoNameByCloneURL method of the parent MockRepoStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *RepoStoreGetFirstRepoNameByCloneURLFunc) PushHook(hook func(context.Context, string) (api.RepoName, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreGetFirstRepoNameByCloneURLFunc) SetDefaultReturn(r0 api.RepoName, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (api.RepoName, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreGetFirstRepoNameByCloneURLFunc) PushReturn(r0 api.RepoName, r1 error) {
+	f.PushHook(func(context.Context, string) (api.RepoName, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoStoreGetFirstRepoNameByCloneURLFunc) nextHook() func(context.Context, string) (api.RepoName, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreGetFirstRepoNameByCloneURLFunc) appendCall(r0 RepoStoreGetFirstRepoNameByCloneURLFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreGetFirstRepoNameByCloneURLFuncCall
+// objects describing the invocations of this function.
+func (f *RepoStoreGetFirstRepoNameByCloneURLFunc) History() []RepoStoreGetFirstRepoNameByCloneURLFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreGetFirstRepoNameByCloneURLFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreGetFirstRepoNameByCloneURLFuncCall is an object that describes
+// an invocation of method GetFirstRepoNameByCloneURL on an instance of
+// MockRepoStore.
+type RepoStoreGetFirstRepoNameByCloneURLFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 api.RepoName
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreGetFirstRepoNameByCloneURLFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreGetFirstRepoNameByCloneURLFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoStoreGetRepoDescriptionsByIDsFunc describes the behavior when the
+// GetRepoDescriptionsByIDs method of the parent MockRepoStore instance is
+// invoked.
+type RepoStoreGetRepoDescriptionsByIDsFunc struct {
+	defaultHook func(context.Context, ...api.RepoID) (map[api.RepoID]string, error)
+	hooks       []func(context.Context, ...api.RepoID) (map[api.RepoID]string, error)
+	history     []RepoStoreGetRepoDescriptionsByIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetRepoDescriptionsByIDs delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockRepoStore) GetRepoDescriptionsByIDs(v0 context.Context, v1 ...api.RepoID) (map[api.RepoID]string, error) {
+	r0, r1 := m.GetRepoDescriptionsByIDsFunc.nextHook()(v0, v1...)
+	m.GetRepoDescriptionsByIDsFunc.appendCall(RepoStoreGetRepoDescriptionsByIDsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetRepoDescriptionsByIDs method of the parent MockRepoStore instance is
+// invoked and the hook queue is empty.
+func (f *RepoStoreGetRepoDescriptionsByIDsFunc) SetDefaultHook(hook func(context.Context, ...api.RepoID) (map[api.RepoID]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetRepoDescriptionsByIDs method of the parent MockRepoStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *RepoStoreGetRepoDescriptionsByIDsFunc) PushHook(hook func(context.Context, ...api.RepoID) (map[api.RepoID]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreGetRepoDescriptionsByIDsFunc) SetDefaultReturn(r0 map[api.RepoID]string, r1 error) {
+	f.SetDefaultHook(func(context.Context, ...api.RepoID) (map[api.RepoID]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreGetRepoDescriptionsByIDsFunc) PushReturn(r0 map[api.RepoID]string, r1 error) {
+	f.PushHook(func(context.Context, ...api.RepoID) (map[api.RepoID]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoStoreGetRepoDescriptionsByIDsFunc) nextHook() func(context.Context, ...api.RepoID) (map[api.RepoID]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreGetRepoDescriptionsByIDsFunc) appendCall(r0 RepoStoreGetRepoDescriptionsByIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreGetRepoDescriptionsByIDsFuncCall
+// objects describing the invocations of this function.
+func (f *RepoStoreGetRepoDescriptionsByIDsFunc) History() []RepoStoreGetRepoDescriptionsByIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreGetRepoDescriptionsByIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreGetRepoDescriptionsByIDsFuncCall is an object that describes an
+// invocation of method GetRepoDescriptionsByIDs on an instance of
+// MockRepoStore.
+type RepoStoreGetRepoDescriptionsByIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoID]string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c RepoStoreGetRepoDescriptionsByIDsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreGetRepoDescriptionsByIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoStoreGetReposSetByIDsFunc describes the behavior when the
+// GetReposSetByIDs method of the parent MockRepoStore instance is invoked.
+type RepoStoreGetReposSetByIDsFunc struct {
+	defaultHook func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error)
+	hooks       []func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error)
+	history     []RepoStoreGetReposSetByIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetReposSetByIDs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRepoStore) GetReposSetByIDs(v0 context.Context, v1 ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
+	r0, r1 := m.GetReposSetByIDsFunc.nextHook()(v0, v1...)
+	m.GetReposSetByIDsFunc.appendCall(RepoStoreGetReposSetByIDsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetReposSetByIDs
+// method of the parent MockRepoStore instance is invoked and the hook queue
+// is empty.
+func (f *RepoStoreGetReposSetByIDsFunc) SetDefaultHook(hook func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetReposSetByIDs method of the parent MockRepoStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *RepoStoreGetReposSetByIDsFunc) PushHook(hook func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreGetReposSetByIDsFunc) SetDefaultReturn(r0 map[api.RepoID]*types.Repo, r1 error) {
+	f.SetDefaultHook(func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreGetReposSetByIDsFunc) PushReturn(r0 map[api.RepoID]*types.Repo, r1 error) {
+	f.PushHook(func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoStoreGetReposSetByIDsFunc) nextHook() func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreGetReposSetByIDsFunc) appendCall(r0 RepoStoreGetReposSetByIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreGetReposSetByIDsFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreGetReposSetByIDsFunc) History() []RepoStoreGetReposSetByIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreGetReposSetByIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreGetReposSetByIDsFuncCall is an object that describes an
+// invocation of method GetReposSetByIDs on an instance of MockRepoStore.
+type RepoStoreGetReposSetByIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoID]*types.Repo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c RepoStoreGetReposSetByIDsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreGetReposSetByIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoStoreHandleFunc describes the behavior when the Handle method of the
+// parent MockRepoStore instance is invoked.
+type RepoStoreHandleFunc struct {
+	defaultHook func() basestore.TransactableHandle
+	hooks       []func() basestore.TransactableHandle
+	history     []RepoStoreHandleFuncCall
+	mutex       sync.Mutex
+}
+
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoStore) Handle() basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(RepoStoreHandleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Handle method of the parent MockRepoStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RepoStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
+	f.SetDefaultHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
+	f.PushHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+func (f *RepoStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreHandleFunc) appendCall(r0 RepoStoreHandleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreHandleFuncCall objects describing
+// the invocations of this function.
+func (f *RepoStoreHandleFunc) History() []RepoStoreHandleFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreHandleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreHandleFuncCall is an object that describes an invocation of
+// method Handle on an instance of MockRepoStore.
+type RepoStoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 basestore.TransactableHandle
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// RepoStoreListFunc describes the behavior when the List method of the
+// parent MockRepoStore instance is invoked.
+type RepoStoreListFunc struct {
+	defaultHook func(context.Context, ReposListOptions) ([]*types.Repo, error)
+	hooks       []func(context.Context, ReposListOptions) ([]*types.Repo, error)
+	history     []RepoStoreListFuncCall
+	mutex       sync.Mutex
+}
+
+// List delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoStore) List(v0 context.Context, v1 ReposListOptions) ([]*types.Repo, error) {
+	r0, r1 := m.ListFunc.nextHook()(v0, v1)
+	m.ListFunc.appendCall(RepoStoreListFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// GetByName delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockRepoStore) GetByName(v0 context.Context, v1 api.RepoName) (*types.Repo, error) {
-	r0, r1 := m.GetByNameFunc.nextHook()(v0, v1)
-	m.GetByNameFunc.appendCall(RepoStoreGetByNameFuncCall{v0, v1, r0, r1})
+// SetDefaultHook sets function that is called when the List method of the
+// parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreListFunc) SetDefaultHook(hook func(context.Context, ReposListOptions) ([]*types.Repo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// List method of the parent MockRepoStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *RepoStoreListFunc) PushHook(hook func(context.Context, ReposListOptions) ([]*types.Repo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreListFunc) SetDefaultReturn(r0 []*types.Repo, r1 error) {
+	f.SetDefaultHook(func(context.Context, ReposListOptions) ([]*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreListFunc) PushReturn(r0 []*types.Repo, r1 error) {
+	f.PushHook(func(context.Context, ReposListOptions) ([]*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoStoreListFunc) nextHook() func(context.Context, ReposListOptions) ([]*types.Repo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreListFunc) appendCall(r0 RepoStoreListFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreListFuncCall objects describing
+// the invocations of this function.
+func (f *RepoStoreListFunc) History() []RepoStoreListFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreListFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreListFuncCall is an object that describes an invocation of method
+// List on an instance of MockRepoStore.
+type RepoStoreListFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 ReposListOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.Repo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreListFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreListFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoStoreListIndexableReposFunc describes the behavior when the
+// ListIndexableRepos method of the parent MockRepoStore instance is
+// invoked.
+type RepoStoreListIndexableReposFunc struct {
+	defaultHook func(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error)
+	hooks       []func(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error)
+	history     []RepoStoreListIndexableReposFuncCall
+	mutex       sync.Mutex
+}
+
+// ListIndexableRepos delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRepoStore) ListIndexableRepos(v0 context.Context, v1 ListIndexableReposOptions) ([]types.MinimalRepo, error) {
+	r0, r1 := m.ListIndexableReposFunc.nextHook()(v0, v1)
+	m.ListIndexableReposFunc.appendCall(RepoStoreListIndexableReposFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetByName method of
-// the parent MockRepoStore instance is invoked and the hook queue is empty.
-func (f *RepoStoreGetByNameFunc) SetDefaultHook(hook func(context.Context, api.RepoName) (*types.Repo, error)) {
+// SetDefaultHook sets function that is called when the ListIndexableRepos
+// method of the parent MockRepoStore instance is invoked and the hook queue
+// is empty.
+func (f *RepoStoreListIndexableReposFunc) SetDefaultHook(hook func(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByName method of the parent MockRepoStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *RepoStoreGetByNameFunc) PushHook(hook func(context.Context, api.RepoName) (*types.Repo, error)) {
+// ListIndexableRepos method of the parent MockRepoStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *RepoStoreListIndexableReposFunc) PushHook(hook func(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30404,20 +32053,20 @@ func (f *RepoStoreGetByNameFunc) PushHook(hook func(context.Context, api.RepoNam
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreGetByNameFunc) SetDefaultReturn(r0 *types.Repo, r1 error) {
-	f.SetDefaultHook(func(context.Context, api.RepoName) (*types.Repo, error) {
+func (f *RepoStoreListIndexableReposFunc) SetDefaultReturn(r0 []types.MinimalRepo, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreGetByNameFunc) PushReturn(r0 *types.Repo, r1 error) {
-	f.PushHook(func(context.Context, api.RepoName) (*types.Repo, error) {
+func (f *RepoStoreListIndexableReposFunc) PushReturn(r0 []types.MinimalRepo, r1 error) {
+	f.PushHook(func(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error) {
 		return r0, r1
 	})
 }
 
-func (f *RepoStoreGetByNameFunc) nextHook() func(context.Context, api.RepoName) (*types.Repo, error) {
+func (f *RepoStoreListIndexableReposFunc) nextHook() func(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30430,35 +32079,35 @@ func (f *RepoStoreGetByNameFunc) nextHook() func(context.Context, api.RepoName)
 	return hook
 }
 
-func (f *RepoStoreGetByNameFunc) appendCall(r0 RepoStoreGetByNameFuncCall) {
+func (f *RepoStoreListIndexableReposFunc) appendCall(r0 RepoStoreListIndexableReposFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreGetByNameFuncCall objects
+// History returns a sequence of RepoStoreListIndexableReposFuncCall objects
 // describing the invocations of this function.
-func (f *RepoStoreGetByNameFunc) History() []RepoStoreGetByNameFuncCall {
+func (f *RepoStoreListIndexableReposFunc) History() []RepoStoreListIndexableReposFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreGetByNameFuncCall, len(f.history))
+	history := make([]RepoStoreListIndexableReposFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreGetByNameFuncCall is an object that describes an invocation of
-// method GetByName on an instance of MockRepoStore.
-type RepoStoreGetByNameFuncCall struct {
+// RepoStoreListIndexableReposFuncCall is an object that describes an
+// invocation of method ListIndexableRepos on an instance of MockRepoStore.
+type RepoStoreListIndexableReposFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 api.RepoName
+	Arg1 ListIndexableReposOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.Repo
+	Result0 []types.MinimalRepo
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -30466,47 +32115,45 @@ type RepoStoreGetByNameFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c RepoStoreGetByNameFuncCall) Args() []interface{} {
+func (c RepoStoreListIndexableReposFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreGetByNameFuncCall) Results() []interface{} {
+func (c RepoStoreListIndexableReposFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// RepoStoreGetFirstRepoNameByCloneURLFunc describes the behavior when the
-// GetFirstRepoNameByCloneURL method of the parent MockRepoStore instance is
-// invoked.
-type RepoStoreGetFirstRepoNameByCloneURLFunc struct {
-	defaultHook func(context.Context, string) (api.RepoName, error)
-	hooks       []func(context.Context, string) (api.RepoName, error)
-	history     []RepoStoreGetFirstRepoNameByCloneURLFuncCall
+// RepoStoreListMinimalReposFunc describes the behavior when the
+// ListMinimalRepos method of the parent MockRepoStore instance is invoked.
+type RepoStoreListMinimalReposFunc struct {
+	defaultHook func(context.Context, ReposListOptions) ([]types.MinimalRepo, error)
+	hooks       []func(context.Context, ReposListOptions) ([]types.MinimalRepo, error)
+	history     []RepoStoreListMinimalReposFuncCall
 	mutex       sync.Mutex
 }
 
-// GetFirstRepoNameByCloneURL delegates to the next hook function in the
-// queue and stores the parameter and result values of this invocation.
-func (m *MockRepoStore) GetFirstRepoNameByCloneURL(v0 context.Context, v1 string) (api.RepoName, error) {
-	r0, r1 := m.GetFirstRepoNameByCloneURLFunc.nextHook()(v0, v1)
-	m.GetFirstRepoNameByCloneURLFunc.appendCall(RepoStoreGetFirstRepoNameByCloneURLFuncCall{v0, v1, r0, r1})
+// ListMinimalRepos delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRepoStore) ListMinimalRepos(v0 context.Context, v1 ReposListOptions) ([]types.MinimalRepo, error) {
+	r0, r1 := m.ListMinimalReposFunc.nextHook()(v0, v1)
+	m.ListMinimalReposFunc.appendCall(RepoStoreListMinimalReposFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// GetFirstRepoNameByCloneURL method of the parent MockRepoStore instance is
-// invoked and the hook queue is empty.
-func (f *RepoStoreGetFirstRepoNameByCloneURLFunc) SetDefaultHook(hook func(context.Context, string) (api.RepoName, error)) {
+// SetDefaultHook sets function that is called when the ListMinimalRepos
+// method of the parent MockRepoStore instance is invoked and the hook queue
+// is empty.
+func (f *RepoStoreListMinimalReposFunc) SetDefaultHook(hook func(context.Context, ReposListOptions) ([]types.MinimalRepo, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetFirstRepoNameByCloneURL method of the parent MockRepoStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *RepoStoreGetFirstRepoNameByCloneURLFunc) PushHook(hook func(context.Context, string) (api.RepoName, error)) {
+// ListMinimalRepos method of the parent MockRepoStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *RepoStoreListMinimalReposFunc) PushHook(hook func(context.Context, ReposListOptions) ([]types.MinimalRepo, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30514,20 +32161,20 @@ func (f *RepoStoreGetFirstRepoNameByCloneURLFunc) PushHook(hook func(context.Con
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreGetFirstRepoNameByCloneURLFunc) SetDefaultReturn(r0 api.RepoName, r1 error) {
-	f.SetDefaultHook(func(context.Context, string) (api.RepoName, error) {
+func (f *RepoStoreListMinimalReposFunc) SetDefaultReturn(r0 []types.MinimalRepo, r1 error) {
+	f.SetDefaultHook(func(context.Context, ReposListOptions) ([]types.MinimalRepo, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreGetFirstRepoNameByCloneURLFunc) PushReturn(r0 api.RepoName, r1 error) {
-	f.PushHook(func(context.Context, string) (api.RepoName, error) {
+func (f *RepoStoreListMinimalReposFunc) PushReturn(r0 []types.MinimalRepo, r1 error) {
+	f.PushHook(func(context.Context, ReposListOptions) ([]types.MinimalRepo, error) {
 		return r0, r1
 	})
 }
 
-func (f *RepoStoreGetFirstRepoNameByCloneURLFunc) nextHook() func(context.Context, string) (api.RepoName, error) {
+func (f *RepoStoreListMinimalReposFunc) nextHook() func(context.Context, ReposListOptions) ([]types.MinimalRepo, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30540,36 +32187,35 @@ func (f *RepoStoreGetFirstRepoNameByCloneURLFunc) nextHook() func(context.Contex
 	return hook
 }
 
-func (f *RepoStoreGetFirstRepoNameByCloneURLFunc) appendCall(r0 RepoStoreGetFirstRepoNameByCloneURLFuncCall) {
+func (f *RepoStoreListMinimalReposFunc) appendCall(r0 RepoStoreListMinimalReposFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreGetFirstRepoNameByCloneURLFuncCall
-// objects describing the invocations of this function.
-func (f *RepoStoreGetFirstRepoNameByCloneURLFunc) History() []RepoStoreGetFirstRepoNameByCloneURLFuncCall {
+// History returns a sequence of RepoStoreListMinimalReposFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreListMinimalReposFunc) History() []RepoStoreListMinimalReposFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreGetFirstRepoNameByCloneURLFuncCall, len(f.history))
+	history := make([]RepoStoreListMinimalReposFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreGetFirstRepoNameByCloneURLFuncCall is an object that describes
-// an invocation of method GetFirstRepoNameByCloneURL on an instance of
-// MockRepoStore.
-type RepoStoreGetFirstRepoNameByCloneURLFuncCall struct {
+// RepoStoreListMinimalReposFuncCall is an object that describes an
+// invocation of method ListMinimalRepos on an instance of MockRepoStore.
+type RepoStoreListMinimalReposFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 string
+	Arg1 ReposListOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 api.RepoName
+	Result0 []types.MinimalRepo
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -30577,47 +32223,44 @@ type RepoStoreGetFirstRepoNameByCloneURLFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c RepoStoreGetFirstRepoNameByCloneURLFuncCall) Args() []interface{} {
+func (c RepoStoreListMinimalReposFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreGetFirstRepoNameByCloneURLFuncCall) Results() []interface{} {
+func (c RepoStoreListMinimalReposFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// RepoStoreGetRepoDescriptionsByIDsFunc describes the behavior when the
-// GetRepoDescriptionsByIDs method of the parent MockRepoStore instance is
-// invoked.
-type RepoStoreGetRepoDescriptionsByIDsFunc struct {
-	defaultHook func(context.Context, ...api.RepoID) (map[api.RepoID]string, error)
-	hooks       []func(context.Context, ...api.RepoID) (map[api.RepoID]string, error)
-	history     []RepoStoreGetRepoDescriptionsByIDsFuncCall
+// RepoStoreMetadataFunc describes the behavior when the Metadata method of
+// the parent MockRepoStore instance is invoked.
+type RepoStoreMetadataFunc struct {
+	defaultHook func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error)
+	hooks       []func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error)
+	history     []RepoStoreMetadataFuncCall
 	mutex       sync.Mutex
 }
 
-// GetRepoDescriptionsByIDs delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockRepoStore) GetRepoDescriptionsByIDs(v0 context.Context, v1 ...api.RepoID) (map[api.RepoID]string, error) {
-	r0, r1 := m.GetRepoDescriptionsByIDsFunc.nextHook()(v0, v1...)
-	m.GetRepoDescriptionsByIDsFunc.appendCall(RepoStoreGetRepoDescriptionsByIDsFuncCall{v0, v1, r0, r1})
+// Metadata delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoStore) Metadata(v0 context.Context, v1 ...api.RepoID) ([]*types.SearchedRepo, error) {
+	r0, r1 := m.MetadataFunc.nextHook()(v0, v1...)
+	m.MetadataFunc.appendCall(RepoStoreMetadataFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// GetRepoDescriptionsByIDs method of the parent MockRepoStore instance is
-// invoked and the hook queue is empty.
-func (f *RepoStoreGetRepoDescriptionsByIDsFunc) SetDefaultHook(hook func(context.Context, ...api.RepoID) (map[api.RepoID]string, error)) {
+// SetDefaultHook sets function that is called when the Metadata method of
+// the parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreMetadataFunc) SetDefaultHook(hook func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetRepoDescriptionsByIDs method of the parent MockRepoStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *RepoStoreGetRepoDescriptionsByIDsFunc) PushHook(hook func(context.Context, ...api.RepoID) (map[api.RepoID]string, error)) {
+// Metadata method of the parent MockRepoStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RepoStoreMetadataFunc) PushHook(hook func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30625,20 +32268,20 @@ func (f *RepoStoreGetRepoDescriptionsByIDsFunc) PushHook(hook func(context.Conte
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreGetRepoDescriptionsByIDsFunc) SetDefaultReturn(r0 map[api.RepoID]string, r1 error) {
-	f.SetDefaultHook(func(context.Context, ...api.RepoID) (map[api.RepoID]string, error) {
+func (f *RepoStoreMetadataFunc) SetDefaultReturn(r0 []*types.SearchedRepo, r1 error) {
+	f.SetDefaultHook(func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreGetRepoDescriptionsByIDsFunc) PushReturn(r0 map[api.RepoID]string, r1 error) {
-	f.PushHook(func(context.Context, ...api.RepoID) (map[api.RepoID]string, error) {
+func (f *RepoStoreMetadataFunc) PushReturn(r0 []*types.SearchedRepo, r1 error) {
+	f.PushHook(func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error) {
 		return r0, r1
 	})
 }
 
-func (f *RepoStoreGetRepoDescriptionsByIDsFunc) nextHook() func(context.Context, ...api.RepoID) (map[api.RepoID]string, error) {
+func (f *RepoStoreMetadataFunc) nextHook() func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30651,27 +32294,26 @@ func (f *RepoStoreGetRepoDescriptionsByIDsFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *RepoStoreGetRepoDescriptionsByIDsFunc) appendCall(r0 RepoStoreGetRepoDescriptionsByIDsFuncCall) {
+func (f *RepoStoreMetadataFunc) appendCall(r0 RepoStoreMetadataFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreGetRepoDescriptionsByIDsFuncCall
-// objects describing the invocations of this function.
-func (f *RepoStoreGetRepoDescriptionsByIDsFunc) History() []RepoStoreGetRepoDescriptionsByIDsFuncCall {
+// History returns a sequence of RepoStoreMetadataFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreMetadataFunc) History() []RepoStoreMetadataFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreGetRepoDescriptionsByIDsFuncCall, len(f.history))
+	history := make([]RepoStoreMetadataFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreGetRepoDescriptionsByIDsFuncCall is an object that describes an
-// invocation of method GetRepoDescriptionsByIDs on an instance of
-// MockRepoStore.
-type RepoStoreGetRepoDescriptionsByIDsFuncCall struct {
+// RepoStoreMetadataFuncCall is an object that describes an invocation of
+// method Metadata on an instance of MockRepoStore.
+type RepoStoreMetadataFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -30680,7 +32322,7 @@ type RepoStoreGetRepoDescriptionsByIDsFuncCall struct {
 	Arg1 []api.RepoID
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 map[api.RepoID]string
+	Result0 []*types.SearchedRepo
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -30690,7 +32332,7 @@ type RepoStoreGetRepoDescriptionsByIDsFuncCall struct {
 // invocation. The variadic slice argument is flattened in this array such
 // that one positional argument and three variadic arguments would result in
 // a slice of four, not two.
-func (c RepoStoreGetRepoDescriptionsByIDsFuncCall) Args() []interface{} {
+func (c RepoStoreMetadataFuncCall) Args() []interface{} {
 	trailing := []interface{}{}
 	for _, val := range c.Arg1 {
 		trailing = append(trailing, val)
@@ -30701,39 +32343,38 @@ func (c RepoStoreGetRepoDescriptionsByIDsFuncCall) Args() []interface{} {
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreGetRepoDescriptionsByIDsFuncCall) Results() []interface{} {
+func (c RepoStoreMetadataFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// RepoStoreGetReposSetByIDsFunc describes the behavior when the
-// GetReposSetByIDs method of the parent MockRepoStore instance is invoked.
-type RepoStoreGetReposSetByIDsFunc struct {
-	defaultHook func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error)
-	hooks       []func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error)
-	history     []RepoStoreGetReposSetByIDsFuncCall
+// RepoStoreQueryFunc describes the behavior when the Query method of the
+// parent MockRepoStore instance is invoked.
+type RepoStoreQueryFunc struct {
+	defaultHook func(context.Context, *sqlf.Query) (*sql.Rows, error)
+	hooks       []func(context.Context, *sqlf.Query) (*sql.Rows, error)
+	history     []RepoStoreQueryFuncCall
 	mutex       sync.Mutex
 }
 
-// GetReposSetByIDs delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockRepoStore) GetReposSetByIDs(v0 context.Context, v1 ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
-	r0, r1 := m.GetReposSetByIDsFunc.nextHook()(v0, v1...)
-	m.GetReposSetByIDsFunc.appendCall(RepoStoreGetReposSetByIDsFuncCall{v0, v1, r0, r1})
+// Query delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoStore) Query(v0 context.Context, v1 *sqlf.Query) (*sql.Rows, error) {
+	r0, r1 := m.QueryFunc.nextHook()(v0, v1)
+	m.QueryFunc.appendCall(RepoStoreQueryFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetReposSetByIDs
-// method of the parent MockRepoStore instance is invoked and the hook queue
-// is empty.
-func (f *RepoStoreGetReposSetByIDsFunc) SetDefaultHook(hook func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error)) {
+// SetDefaultHook sets function that is called when the Query method of the
+// parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreQueryFunc) SetDefaultHook(hook func(context.Context, *sqlf.Query) (*sql.Rows, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetReposSetByIDs method of the parent MockRepoStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *RepoStoreGetReposSetByIDsFunc) PushHook(hook func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error)) {
+// Query method of the parent MockRepoStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *RepoStoreQueryFunc) PushHook(hook func(context.Context, *sqlf.Query) (*sql.Rows, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30741,20 +32382,20 @@ func (f *RepoStoreGetReposSetByIDsFunc) PushHook(hook func(context.Context, ...a
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreGetReposSetByIDsFunc) SetDefaultReturn(r0 map[api.RepoID]*types.Repo, r1 error) {
-	f.SetDefaultHook(func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
+func (f *RepoStoreQueryFunc) SetDefaultReturn(r0 *sql.Rows, r1 error) {
+	f.SetDefaultHook(func(context.Context, *sqlf.Query) (*sql.Rows, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreGetReposSetByIDsFunc) PushReturn(r0 map[api.RepoID]*types.Repo, r1 error) {
-	f.PushHook(func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
+func (f *RepoStoreQueryFunc) PushReturn(r0 *sql.Rows, r1 error) {
+	f.PushHook(func(context.Context, *sqlf.Query) (*sql.Rows, error) {
 		return r0, r1
 	})
 }
 
-func (f *RepoStoreGetReposSetByIDsFunc) nextHook() func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
+func (f *RepoStoreQueryFunc) nextHook() func(context.Context, *sqlf.Query) (*sql.Rows, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30767,87 +32408,82 @@ func (f *RepoStoreGetReposSetByIDsFunc) nextHook() func(context.Context, ...api.
 	return hook
 }
 
-func (f *RepoStoreGetReposSetByIDsFunc) appendCall(r0 RepoStoreGetReposSetByIDsFuncCall) {
+func (f *RepoStoreQueryFunc) appendCall(r0 RepoStoreQueryFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreGetReposSetByIDsFuncCall objects
-// describing the invocations of this function.
-func (f *RepoStoreGetReposSetByIDsFunc) History() []RepoStoreGetReposSetByIDsFuncCall {
+// History returns a sequence of RepoStoreQueryFuncCall objects describing
+// the invocations of this function.
+func (f *RepoStoreQueryFunc) History() []RepoStoreQueryFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreGetReposSetByIDsFuncCall, len(f.history))
+	history := make([]RepoStoreQueryFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreGetReposSetByIDsFuncCall is an object that describes an
-// invocation of method GetReposSetByIDs on an instance of MockRepoStore.
-type RepoStoreGetReposSetByIDsFuncCall struct {
+// RepoStoreQueryFuncCall is an object that describes an invocation of
+// method Query on an instance of MockRepoStore.
+type RepoStoreQueryFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is a slice containing the values of the variadic arguments
-	// passed to this method invocation.
-	Arg1 []api.RepoID
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *sqlf.Query
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 map[api.RepoID]*types.Repo
+	Result0 *sql.Rows
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation. The variadic slice argument is flattened in this array such
-// that one positional argument and three variadic arguments would result in
-// a slice of four, not two.
-func (c RepoStoreGetReposSetByIDsFuncCall) Args() []interface{} {
-	trailing := []interface{}{}
-	for _, val := range c.Arg1 {
-		trailing = append(trailing, val)
-	}
-
-	return append([]interface{}{c.Arg0}, trailing...)
+// invocation.
+func (c RepoStoreQueryFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreGetReposSetByIDsFuncCall) Results() []interface{} {
+func (c RepoStoreQueryFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// RepoStoreHandleFunc describes the behavior when the Handle method of the
-// parent MockRepoStore instance is invoked.
-type RepoStoreHandleFunc struct {
-	defaultHook func() basestore.TransactableHandle
-	hooks       []func() basestore.TransactableHandle
-	history     []RepoStoreHandleFuncCall
+// RepoStoreStreamMinimalReposFunc describes the behavior when the
+// StreamMinimalRepos method of the parent MockRepoStore instance is
+// invoked.
+type RepoStoreStreamMinimalReposFunc struct {
+	defaultHook func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error
+	hooks       []func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error
+	history     []RepoStoreStreamMinimalReposFuncCall
 	mutex       sync.Mutex
 }
 
-// Handle delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockRepoStore) Handle() basestore.TransactableHandle {
-	r0 := m.HandleFunc.nextHook()()
-	m.HandleFunc.appendCall(RepoStoreHandleFuncCall{r0})
+// StreamMinimalRepos delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRepoStore) StreamMinimalRepos(v0 context.Context, v1 ReposListOptions, v2 func(*types.MinimalRepo)) error {
+	r0 := m.StreamMinimalReposFunc.nextHook()(v0, v1, v2)
+	m.StreamMinimalReposFunc.appendCall(RepoStoreStreamMinimalReposFuncCall{v0, v1, v2, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Handle method of the
-// parent MockRepoStore instance is invoked and the hook queue is empty.
-func (f *RepoStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
+// SetDefaultHook sets function that is called when the StreamMinimalRepos
+// method of the parent MockRepoStore instance is invoked and the hook queue
+// is empty.
+func (f *RepoStoreStreamMinimalReposFunc) SetDefaultHook(hook func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Handle method of the parent MockRepoStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *RepoStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
+// StreamMinimalRepos method of the parent MockRepoStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *RepoStoreStreamMinimalReposFunc) PushHook(hook func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30855,20 +32491,20 @@ func (f *RepoStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle)
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
-	f.SetDefaultHook(func() basestore.TransactableHandle {
+func (f *RepoStoreStreamMinimalReposFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
-	f.PushHook(func() basestore.TransactableHandle {
+func (f *RepoStoreStreamMinimalReposFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error {
 		return r0
 	})
 }
 
-func (f *RepoStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
+func (f *RepoStoreStreamMinimalReposFunc) nextHook() func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30881,71 +32517,80 @@ func (f *RepoStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
 	return hook
 }
 
-func (f *RepoStoreHandleFunc) appendCall(r0 RepoStoreHandleFuncCall) {
+func (f *RepoStoreStreamMinimalReposFunc) appendCall(r0 RepoStoreStreamMinimalReposFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreHandleFuncCall objects describing
-// the invocations of this function.
-func (f *RepoStoreHandleFunc) History() []RepoStoreHandleFuncCall {
+// History returns a sequence of RepoStoreStreamMinimalReposFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreStreamMinimalReposFunc) History() []RepoStoreStreamMinimalReposFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreHandleFuncCall, len(f.history))
+	history := make([]RepoStoreStreamMinimalReposFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreHandleFuncCall is an object that describes an invocation of
-// method Handle on an instance of MockRepoStore.
-type RepoStoreHandleFuncCall struct {
+// RepoStoreStreamMinimalReposFuncCall is an object that describes an
+// invocation of method StreamMinimalRepos on an instance of MockRepoStore.
+type RepoStoreStreamMinimalReposFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 ReposListOptions
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func(*types.MinimalRepo)
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 basestore.TransactableHandle
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c RepoStoreHandleFuncCall) Args() []interface{} {
-	return []interface{}{}
+func (c RepoStoreStreamMinimalReposFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreHandleFuncCall) Results() []interface{} {
+func (c RepoStoreStreamMinimalReposFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// RepoStoreListFunc describes the behavior when the List method of the
-// parent MockRepoStore instance is invoked.
-type RepoStoreListFunc struct {
-	defaultHook func(context.Context, ReposListOptions) ([]*types.Repo, error)
-	hooks       []func(context.Context, ReposListOptions) ([]*types.Repo, error)
-	history     []RepoStoreListFuncCall
+// RepoStoreTransactFunc describes the behavior when the Transact method of
+// the parent MockRepoStore instance is invoked.
+type RepoStoreTransactFunc struct {
+	defaultHook func(context.Context) (RepoStore, error)
+	hooks       []func(context.Context) (RepoStore, error)
+	history     []RepoStoreTransactFuncCall
 	mutex       sync.Mutex
 }
 
-// List delegates to the next hook function in the queue and stores the
+// Transact delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockRepoStore) List(v0 context.Context, v1 ReposListOptions) ([]*types.Repo, error) {
-	r0, r1 := m.ListFunc.nextHook()(v0, v1)
-	m.ListFunc.appendCall(RepoStoreListFuncCall{v0, v1, r0, r1})
+func (m *MockRepoStore) Transact(v0 context.Context) (RepoStore, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(RepoStoreTransactFuncCall{v0, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the List method of the
-// parent MockRepoStore instance is invoked and the hook queue is empty.
-func (f *RepoStoreListFunc) SetDefaultHook(hook func(context.Context, ReposListOptions) ([]*types.Repo, error)) {
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreTransactFunc) SetDefaultHook(hook func(context.Context) (RepoStore, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// List method of the parent MockRepoStore instance invokes the hook at the
-// front of the queue and discards it. After the queue is empty, the default
-// hook function is invoked for any future action.
-func (f *RepoStoreListFunc) PushHook(hook func(context.Context, ReposListOptions) ([]*types.Repo, error)) {
+// Transact method of the parent MockRepoStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RepoStoreTransactFunc) PushHook(hook func(context.Context) (RepoStore, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -30953,20 +32598,20 @@ func (f *RepoStoreListFunc) PushHook(hook func(context.Context, ReposListOptions
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreListFunc) SetDefaultReturn(r0 []*types.Repo, r1 error) {
-	f.SetDefaultHook(func(context.Context, ReposListOptions) ([]*types.Repo, error) {
+func (f *RepoStoreTransactFunc) SetDefaultReturn(r0 RepoStore, r1 error) {
+	f.SetDefaultHook(func(context.Context) (RepoStore, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreListFunc) PushReturn(r0 []*types.Repo, r1 error) {
-	f.PushHook(func(context.Context, ReposListOptions) ([]*types.Repo, error) {
+func (f *RepoStoreTransactFunc) PushReturn(r0 RepoStore, r1 error) {
+	f.PushHook(func(context.Context) (RepoStore, error) {
 		return r0, r1
 	})
 }
 
-func (f *RepoStoreListFunc) nextHook() func(context.Context, ReposListOptions) ([]*types.Repo, error) {
+func (f *RepoStoreTransactFunc) nextHook() func(context.Context) (RepoStore, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -30979,35 +32624,32 @@ func (f *RepoStoreListFunc) nextHook() func(context.Context, ReposListOptions) (
 	return hook
 }
 
-func (f *RepoStoreListFunc) appendCall(r0 RepoStoreListFuncCall) {
+func (f *RepoStoreTransactFunc) appendCall(r0 RepoStoreTransactFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreListFuncCall objects describing
-// the invocations of this function.
-func (f *RepoStoreListFunc) History() []RepoStoreListFuncCall {
+// History returns a sequence of RepoStoreTransactFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreTransactFunc) History() []RepoStoreTransactFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreListFuncCall, len(f.history))
+	history := make([]RepoStoreTransactFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreListFuncCall is an object that describes an invocation of method
-// List on an instance of MockRepoStore.
-type RepoStoreListFuncCall struct {
+// RepoStoreTransactFuncCall is an object that describes an invocation of
+// method Transact on an instance of MockRepoStore.
+type RepoStoreTransactFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 ReposListOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*types.Repo
+	Result0 RepoStore
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -31015,46 +32657,44 @@ type RepoStoreListFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c RepoStoreListFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c RepoStoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreListFuncCall) Results() []interface{} {
+func (c RepoStoreTransactFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// RepoStoreListIndexableReposFunc describes the behavior when the
-// ListIndexableRepos method of the parent MockRepoStore instance is
-// invoked.
-type RepoStoreListIndexableReposFunc struct {
-	defaultHook func(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error)
-	hooks       []func(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error)
-	history     []RepoStoreListIndexableReposFuncCall
+// RepoStoreWithFunc describes the behavior when the With method of the
+// parent MockRepoStore instance is invoked.
+type RepoStoreWithFunc struct {
+	defaultHook func(basestore.ShareableStore) RepoStore
+	hooks       []func(basestore.ShareableStore) RepoStore
+	history     []RepoStoreWithFuncCall
 	mutex       sync.Mutex
 }
 
-// ListIndexableRepos delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockRepoStore) ListIndexableRepos(v0 context.Context, v1 ListIndexableReposOptions) ([]types.MinimalRepo, error) {
-	r0, r1 := m.ListIndexableReposFunc.nextHook()(v0, v1)
-	m.ListIndexableReposFunc.appendCall(RepoStoreListIndexableReposFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// With delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoStore) With(v0 basestore.ShareableStore) RepoStore {
+	r0 := m.WithFunc.nextHook()(v0)
+	m.WithFunc.appendCall(RepoStoreWithFuncCall{v0, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the ListIndexableRepos
-// method of the parent MockRepoStore instance is invoked and the hook queue
-// is empty.
-func (f *RepoStoreListIndexableReposFunc) SetDefaultHook(hook func(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error)) {
+// SetDefaultHook sets function that is called when the With method of the
+// parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) RepoStore) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListIndexableRepos method of the parent MockRepoStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *RepoStoreListIndexableReposFunc) PushHook(hook func(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error)) {
+// With method of the parent MockRepoStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *RepoStoreWithFunc) PushHook(hook func(basestore.ShareableStore) RepoStore) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31062,107 +32702,436 @@ func (f *RepoStoreListIndexableReposFunc) PushHook(hook func(context.Context, Li
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreListIndexableReposFunc) SetDefaultReturn(r0 []types.MinimalRepo, r1 error) {
-	f.SetDefaultHook(func(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error) {
-		return r0, r1
+func (f *RepoStoreWithFunc) SetDefaultReturn(r0 RepoStore) {
+	f.SetDefaultHook(func(basestore.ShareableStore) RepoStore {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreListIndexableReposFunc) PushReturn(r0 []types.MinimalRepo, r1 error) {
-	f.PushHook(func(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error) {
-		return r0, r1
+func (f *RepoStoreWithFunc) PushReturn(r0 RepoStore) {
+	f.PushHook(func(basestore.ShareableStore) RepoStore {
+		return r0
 	})
 }
 
-func (f *RepoStoreListIndexableReposFunc) nextHook() func(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error) {
+func (f *RepoStoreWithFunc) nextHook() func(basestore.ShareableStore) RepoStore {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
 	if len(f.hooks) == 0 {
 		return f.defaultHook
 	}
-
-	hook := f.hooks[0]
-	f.hooks = f.hooks[1:]
-	return hook
-}
-
-func (f *RepoStoreListIndexableReposFunc) appendCall(r0 RepoStoreListIndexableReposFuncCall) {
-	f.mutex.Lock()
-	f.history = append(f.history, r0)
-	f.mutex.Unlock()
-}
-
-// History returns a sequence of RepoStoreListIndexableReposFuncCall objects
-// describing the invocations of this function.
-func (f *RepoStoreListIndexableReposFunc) History() []RepoStoreListIndexableReposFuncCall {
-	f.mutex.Lock()
-	history := make([]RepoStoreListIndexableReposFuncCall, len(f.history))
-	copy(history, f.history)
-	f.mutex.Unlock()
-
-	return history
-}
-
-// RepoStoreListIndexableReposFuncCall is an object that describes an
-// invocation of method ListIndexableRepos on an instance of MockRepoStore.
-type RepoStoreListIndexableReposFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 ListIndexableReposOptions
-	// Result0 is the value of the 1st result returned from this method
-	// invocation.
-	Result0 []types.MinimalRepo
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreWithFunc) appendCall(r0 RepoStoreWithFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreWithFuncCall objects describing
+// the invocations of this function.
+func (f *RepoStoreWithFunc) History() []RepoStoreWithFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreWithFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreWithFuncCall is an object that describes an invocation of method
+// With on an instance of MockRepoStore.
+type RepoStoreWithFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 basestore.ShareableStore
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 RepoStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreWithFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreWithFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockSavedSearchStore is a mock implementation of the SavedSearchStore
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockSavedSearchStore struct {
+	// CreateFunc is an instance of a mock function object controlling the
+	// behavior of the method Create.
+	CreateFunc *SavedSearchStoreCreateFunc
+	// DeleteFunc is an instance of a mock function object controlling the
+	// behavior of the method Delete.
+	DeleteFunc *SavedSearchStoreDeleteFunc
+	// DeleteScheduleFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteSchedule.
+	DeleteScheduleFunc *SavedSearchStoreDeleteScheduleFunc
+	// GetByIDFunc is an instance of a mock function object controlling the
+	// behavior of the method GetByID.
+	GetByIDFunc *SavedSearchStoreGetByIDFunc
+	// GetScheduleFunc is an instance of a mock function object controlling
+	// the behavior of the method GetSchedule.
+	GetScheduleFunc *SavedSearchStoreGetScheduleFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *SavedSearchStoreHandleFunc
+	// IsEmptyFunc is an instance of a mock function object controlling the
+	// behavior of the method IsEmpty.
+	IsEmptyFunc *SavedSearchStoreIsEmptyFunc
+	// ListAllFunc is an instance of a mock function object controlling the
+	// behavior of the method ListAll.
+	ListAllFunc *SavedSearchStoreListAllFunc
+	// ListDueForRunFunc is an instance of a mock function object
+	// controlling the behavior of the method ListDueForRun.
+	ListDueForRunFunc *SavedSearchStoreListDueForRunFunc
+	// ListResultChangesFunc is an instance of a mock function object
+	// controlling the behavior of the method ListResultChanges.
+	ListResultChangesFunc *SavedSearchStoreListResultChangesFunc
+	// ListSavedSearchesByOrgIDFunc is an instance of a mock function object
+	// controlling the behavior of the method ListSavedSearchesByOrgID.
+	ListSavedSearchesByOrgIDFunc *SavedSearchStoreListSavedSearchesByOrgIDFunc
+	// ListSavedSearchesByUserIDFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ListSavedSearchesByUserID.
+	ListSavedSearchesByUserIDFunc *SavedSearchStoreListSavedSearchesByUserIDFunc
+	// RecordRunErrorFunc is an instance of a mock function object
+	// controlling the behavior of the method RecordRunError.
+	RecordRunErrorFunc *SavedSearchStoreRecordRunErrorFunc
+	// RecordRunResultsFunc is an instance of a mock function object
+	// controlling the behavior of the method RecordRunResults.
+	RecordRunResultsFunc *SavedSearchStoreRecordRunResultsFunc
+	// ResetResultsFunc is an instance of a mock function object controlling
+	// the behavior of the method ResetResults.
+	ResetResultsFunc *SavedSearchStoreResetResultsFunc
+	// TransactFunc is an instance of a mock function object controlling the
+	// behavior of the method Transact.
+	TransactFunc *SavedSearchStoreTransactFunc
+	// UpdateFunc is an instance of a mock function object controlling the
+	// behavior of the method Update.
+	UpdateFunc *SavedSearchStoreUpdateFunc
+	// UpsertScheduleFunc is an instance of a mock function object
+	// controlling the behavior of the method UpsertSchedule.
+	UpsertScheduleFunc *SavedSearchStoreUpsertScheduleFunc
+	// WithFunc is an instance of a mock function object controlling the
+	// behavior of the method With.
+	WithFunc *SavedSearchStoreWithFunc
+}
+
+// NewMockSavedSearchStore creates a new mock of the SavedSearchStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockSavedSearchStore() *MockSavedSearchStore {
+	return &MockSavedSearchStore{
+		CreateFunc: &SavedSearchStoreCreateFunc{
+			defaultHook: func(context.Context, *types.SavedSearch) (r0 *types.SavedSearch, r1 error) {
+				return
+			},
+		},
+		DeleteFunc: &SavedSearchStoreDeleteFunc{
+			defaultHook: func(context.Context, int32) (r0 error) {
+				return
+			},
+		},
+		DeleteScheduleFunc: &SavedSearchStoreDeleteScheduleFunc{
+			defaultHook: func(context.Context, int32) (r0 error) {
+				return
+			},
+		},
+		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
+			defaultHook: func(context.Context, int32) (r0 *api.SavedQuerySpecAndConfig, r1 error) {
+				return
+			},
+		},
+		GetScheduleFunc: &SavedSearchStoreGetScheduleFunc{
+			defaultHook: func(context.Context, int32) (r0 *types.SavedSearchSchedule, r1 error) {
+				return
+			},
+		},
+		HandleFunc: &SavedSearchStoreHandleFunc{
+			defaultHook: func() (r0 basestore.TransactableHandle) {
+				return
+			},
+		},
+		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
+			defaultHook: func(context.Context) (r0 bool, r1 error) {
+				return
+			},
+		},
+		ListAllFunc: &SavedSearchStoreListAllFunc{
+			defaultHook: func(context.Context) (r0 []api.SavedQuerySpecAndConfig, r1 error) {
+				return
+			},
+		},
+		ListDueForRunFunc: &SavedSearchStoreListDueForRunFunc{
+			defaultHook: func(context.Context, int) (r0 []*types.SavedSearch, r1 error) {
+				return
+			},
+		},
+		ListResultChangesFunc: &SavedSearchStoreListResultChangesFunc{
+			defaultHook: func(context.Context, int32, bool, int) (r0 []*types.SavedSearchResult, r1 error) {
+				return
+			},
+		},
+		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
+			defaultHook: func(context.Context, int32) (r0 []*types.SavedSearch, r1 error) {
+				return
+			},
+		},
+		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
+			defaultHook: func(context.Context, int32) (r0 []*types.SavedSearch, r1 error) {
+				return
+			},
+		},
+		RecordRunErrorFunc: &SavedSearchStoreRecordRunErrorFunc{
+			defaultHook: func(context.Context, int32, string) (r0 error) {
+				return
+			},
+		},
+		RecordRunResultsFunc: &SavedSearchStoreRecordRunResultsFunc{
+			defaultHook: func(context.Context, int32, []*types.SavedSearchResult, bool) (r0 error) {
+				return
+			},
+		},
+		ResetResultsFunc: &SavedSearchStoreResetResultsFunc{
+			defaultHook: func(context.Context, int32) (r0 error) {
+				return
+			},
+		},
+		TransactFunc: &SavedSearchStoreTransactFunc{
+			defaultHook: func(context.Context) (r0 SavedSearchStore, r1 error) {
+				return
+			},
+		},
+		UpdateFunc: &SavedSearchStoreUpdateFunc{
+			defaultHook: func(context.Context, *types.SavedSearch) (r0 *types.SavedSearch, r1 error) {
+				return
+			},
+		},
+		UpsertScheduleFunc: &SavedSearchStoreUpsertScheduleFunc{
+			defaultHook: func(context.Context, int32, int32) (r0 *types.SavedSearchSchedule, r1 error) {
+				return
+			},
+		},
+		WithFunc: &SavedSearchStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) (r0 SavedSearchStore) {
+				return
+			},
+		},
+	}
 }
 
-// Args returns an interface slice containing the arguments of this
-// invocation.
-func (c RepoStoreListIndexableReposFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+// NewStrictMockSavedSearchStore creates a new mock of the SavedSearchStore
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockSavedSearchStore() *MockSavedSearchStore {
+	return &MockSavedSearchStore{
+		CreateFunc: &SavedSearchStoreCreateFunc{
+			defaultHook: func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+				panic("unexpected invocation of MockSavedSearchStore.Create")
+			},
+		},
+		DeleteFunc: &SavedSearchStoreDeleteFunc{
+			defaultHook: func(context.Context, int32) error {
+				panic("unexpected invocation of MockSavedSearchStore.Delete")
+			},
+		},
+		DeleteScheduleFunc: &SavedSearchStoreDeleteScheduleFunc{
+			defaultHook: func(context.Context, int32) error {
+				panic("unexpected invocation of MockSavedSearchStore.DeleteSchedule")
+			},
+		},
+		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
+			defaultHook: func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+				panic("unexpected invocation of MockSavedSearchStore.GetByID")
+			},
+		},
+		GetScheduleFunc: &SavedSearchStoreGetScheduleFunc{
+			defaultHook: func(context.Context, int32) (*types.SavedSearchSchedule, error) {
+				panic("unexpected invocation of MockSavedSearchStore.GetSchedule")
+			},
+		},
+		HandleFunc: &SavedSearchStoreHandleFunc{
+			defaultHook: func() basestore.TransactableHandle {
+				panic("unexpected invocation of MockSavedSearchStore.Handle")
+			},
+		},
+		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
+			defaultHook: func(context.Context) (bool, error) {
+				panic("unexpected invocation of MockSavedSearchStore.IsEmpty")
+			},
+		},
+		ListAllFunc: &SavedSearchStoreListAllFunc{
+			defaultHook: func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+				panic("unexpected invocation of MockSavedSearchStore.ListAll")
+			},
+		},
+		ListDueForRunFunc: &SavedSearchStoreListDueForRunFunc{
+			defaultHook: func(context.Context, int) ([]*types.SavedSearch, error) {
+				panic("unexpected invocation of MockSavedSearchStore.ListDueForRun")
+			},
+		},
+		ListResultChangesFunc: &SavedSearchStoreListResultChangesFunc{
+			defaultHook: func(context.Context, int32, bool, int) ([]*types.SavedSearchResult, error) {
+				panic("unexpected invocation of MockSavedSearchStore.ListResultChanges")
+			},
+		},
+		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
+			defaultHook: func(context.Context, int32) ([]*types.SavedSearch, error) {
+				panic("unexpected invocation of MockSavedSearchStore.ListSavedSearchesByOrgID")
+			},
+		},
+		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
+			defaultHook: func(context.Context, int32) ([]*types.SavedSearch, error) {
+				panic("unexpected invocation of MockSavedSearchStore.ListSavedSearchesByUserID")
+			},
+		},
+		RecordRunErrorFunc: &SavedSearchStoreRecordRunErrorFunc{
+			defaultHook: func(context.Context, int32, string) error {
+				panic("unexpected invocation of MockSavedSearchStore.RecordRunError")
+			},
+		},
+		RecordRunResultsFunc: &SavedSearchStoreRecordRunResultsFunc{
+			defaultHook: func(context.Context, int32, []*types.SavedSearchResult, bool) error {
+				panic("unexpected invocation of MockSavedSearchStore.RecordRunResults")
+			},
+		},
+		ResetResultsFunc: &SavedSearchStoreResetResultsFunc{
+			defaultHook: func(context.Context, int32) error {
+				panic("unexpected invocation of MockSavedSearchStore.ResetResults")
+			},
+		},
+		TransactFunc: &SavedSearchStoreTransactFunc{
+			defaultHook: func(context.Context) (SavedSearchStore, error) {
+				panic("unexpected invocation of MockSavedSearchStore.Transact")
+			},
+		},
+		UpdateFunc: &SavedSearchStoreUpdateFunc{
+			defaultHook: func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+				panic("unexpected invocation of MockSavedSearchStore.Update")
+			},
+		},
+		UpsertScheduleFunc: &SavedSearchStoreUpsertScheduleFunc{
+			defaultHook: func(context.Context, int32, int32) (*types.SavedSearchSchedule, error) {
+				panic("unexpected invocation of MockSavedSearchStore.UpsertSchedule")
+			},
+		},
+		WithFunc: &SavedSearchStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) SavedSearchStore {
+				panic("unexpected invocation of MockSavedSearchStore.With")
+			},
+		},
+	}
 }
 
-// Results returns an interface slice containing the results of this
-// invocation.
-func (c RepoStoreListIndexableReposFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+// NewMockSavedSearchStoreFrom creates a new mock of the
+// MockSavedSearchStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockSavedSearchStoreFrom(i SavedSearchStore) *MockSavedSearchStore {
+	return &MockSavedSearchStore{
+		CreateFunc: &SavedSearchStoreCreateFunc{
+			defaultHook: i.Create,
+		},
+		DeleteFunc: &SavedSearchStoreDeleteFunc{
+			defaultHook: i.Delete,
+		},
+		DeleteScheduleFunc: &SavedSearchStoreDeleteScheduleFunc{
+			defaultHook: i.DeleteSchedule,
+		},
+		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
+			defaultHook: i.GetByID,
+		},
+		GetScheduleFunc: &SavedSearchStoreGetScheduleFunc{
+			defaultHook: i.GetSchedule,
+		},
+		HandleFunc: &SavedSearchStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
+			defaultHook: i.IsEmpty,
+		},
+		ListAllFunc: &SavedSearchStoreListAllFunc{
+			defaultHook: i.ListAll,
+		},
+		ListDueForRunFunc: &SavedSearchStoreListDueForRunFunc{
+			defaultHook: i.ListDueForRun,
+		},
+		ListResultChangesFunc: &SavedSearchStoreListResultChangesFunc{
+			defaultHook: i.ListResultChanges,
+		},
+		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
+			defaultHook: i.ListSavedSearchesByOrgID,
+		},
+		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
+			defaultHook: i.ListSavedSearchesByUserID,
+		},
+		RecordRunErrorFunc: &SavedSearchStoreRecordRunErrorFunc{
+			defaultHook: i.RecordRunError,
+		},
+		RecordRunResultsFunc: &SavedSearchStoreRecordRunResultsFunc{
+			defaultHook: i.RecordRunResults,
+		},
+		ResetResultsFunc: &SavedSearchStoreResetResultsFunc{
+			defaultHook: i.ResetResults,
+		},
+		TransactFunc: &SavedSearchStoreTransactFunc{
+			defaultHook: i.Transact,
+		},
+		UpdateFunc: &SavedSearchStoreUpdateFunc{
+			defaultHook: i.Update,
+		},
+		UpsertScheduleFunc: &SavedSearchStoreUpsertScheduleFunc{
+			defaultHook: i.UpsertSchedule,
+		},
+		WithFunc: &SavedSearchStoreWithFunc{
+			defaultHook: i.With,
+		},
+	}
 }
 
-// RepoStoreListMinimalReposFunc describes the behavior when the
-// ListMinimalRepos method of the parent MockRepoStore instance is invoked.
-type RepoStoreListMinimalReposFunc struct {
-	defaultHook func(context.Context, ReposListOptions) ([]types.MinimalRepo, error)
-	hooks       []func(context.Context, ReposListOptions) ([]types.MinimalRepo, error)
-	history     []RepoStoreListMinimalReposFuncCall
+// SavedSearchStoreCreateFunc describes the behavior when the Create method
+// of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreCreateFunc struct {
+	defaultHook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
+	hooks       []func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
+	history     []SavedSearchStoreCreateFuncCall
 	mutex       sync.Mutex
 }
 
-// ListMinimalRepos delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockRepoStore) ListMinimalRepos(v0 context.Context, v1 ReposListOptions) ([]types.MinimalRepo, error) {
-	r0, r1 := m.ListMinimalReposFunc.nextHook()(v0, v1)
-	m.ListMinimalReposFunc.appendCall(RepoStoreListMinimalReposFuncCall{v0, v1, r0, r1})
+// Create delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) Create(v0 context.Context, v1 *types.SavedSearch) (*types.SavedSearch, error) {
+	r0, r1 := m.CreateFunc.nextHook()(v0, v1)
+	m.CreateFunc.appendCall(SavedSearchStoreCreateFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ListMinimalRepos
-// method of the parent MockRepoStore instance is invoked and the hook queue
-// is empty.
-func (f *RepoStoreListMinimalReposFunc) SetDefaultHook(hook func(context.Context, ReposListOptions) ([]types.MinimalRepo, error)) {
+// SetDefaultHook sets function that is called when the Create method of the
+// parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreCreateFunc) SetDefaultHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListMinimalRepos method of the parent MockRepoStore instance invokes the
+// Create method of the parent MockSavedSearchStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *RepoStoreListMinimalReposFunc) PushHook(hook func(context.Context, ReposListOptions) ([]types.MinimalRepo, error)) {
+func (f *SavedSearchStoreCreateFunc) PushHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31170,20 +33139,20 @@ func (f *RepoStoreListMinimalReposFunc) PushHook(hook func(context.Context, Repo
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreListMinimalReposFunc) SetDefaultReturn(r0 []types.MinimalRepo, r1 error) {
-	f.SetDefaultHook(func(context.Context, ReposListOptions) ([]types.MinimalRepo, error) {
+func (f *SavedSearchStoreCreateFunc) SetDefaultReturn(r0 *types.SavedSearch, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreListMinimalReposFunc) PushReturn(r0 []types.MinimalRepo, r1 error) {
-	f.PushHook(func(context.Context, ReposListOptions) ([]types.MinimalRepo, error) {
+func (f *SavedSearchStoreCreateFunc) PushReturn(r0 *types.SavedSearch, r1 error) {
+	f.PushHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
 		return r0, r1
 	})
 }
 
-func (f *RepoStoreListMinimalReposFunc) nextHook() func(context.Context, ReposListOptions) ([]types.MinimalRepo, error) {
+func (f *SavedSearchStoreCreateFunc) nextHook() func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31196,35 +33165,35 @@ func (f *RepoStoreListMinimalReposFunc) nextHook() func(context.Context, ReposLi
 	return hook
 }
 
-func (f *RepoStoreListMinimalReposFunc) appendCall(r0 RepoStoreListMinimalReposFuncCall) {
+func (f *SavedSearchStoreCreateFunc) appendCall(r0 SavedSearchStoreCreateFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreListMinimalReposFuncCall objects
+// History returns a sequence of SavedSearchStoreCreateFuncCall objects
 // describing the invocations of this function.
-func (f *RepoStoreListMinimalReposFunc) History() []RepoStoreListMinimalReposFuncCall {
+func (f *SavedSearchStoreCreateFunc) History() []SavedSearchStoreCreateFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreListMinimalReposFuncCall, len(f.history))
+	history := make([]SavedSearchStoreCreateFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreListMinimalReposFuncCall is an object that describes an
-// invocation of method ListMinimalRepos on an instance of MockRepoStore.
-type RepoStoreListMinimalReposFuncCall struct {
+// SavedSearchStoreCreateFuncCall is an object that describes an invocation
+// of method Create on an instance of MockSavedSearchStore.
+type SavedSearchStoreCreateFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 ReposListOptions
+	Arg1 *types.SavedSearch
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []types.MinimalRepo
+	Result0 *types.SavedSearch
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -31232,44 +33201,45 @@ type RepoStoreListMinimalReposFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c RepoStoreListMinimalReposFuncCall) Args() []interface{} {
+func (c SavedSearchStoreCreateFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreListMinimalReposFuncCall) Results() []interface{} {
+func (c SavedSearchStoreCreateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// RepoStoreMetadataFunc describes the behavior when the Metadata method of
-// the parent MockRepoStore instance is invoked.
-type RepoStoreMetadataFunc struct {
-	defaultHook func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error)
-	hooks       []func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error)
-	history     []RepoStoreMetadataFuncCall
+// SavedSearchStoreDeleteFunc describes the behavior when the Delete method
+// of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreDeleteFunc struct {
+	defaultHook func(context.Context, int32) error
+	hooks       []func(context.Context, int32) error
+	history     []SavedSearchStoreDeleteFuncCall
 	mutex       sync.Mutex
 }
 
-// Metadata delegates to the next hook function in the queue and stores the
+// Delete delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockRepoStore) Metadata(v0 context.Context, v1 ...api.RepoID) ([]*types.SearchedRepo, error) {
-	r0, r1 := m.MetadataFunc.nextHook()(v0, v1...)
-	m.MetadataFunc.appendCall(RepoStoreMetadataFuncCall{v0, v1, r0, r1})
-	return r0, r1
+func (m *MockSavedSearchStore) Delete(v0 context.Context, v1 int32) error {
+	r0 := m.DeleteFunc.nextHook()(v0, v1)
+	m.DeleteFunc.appendCall(SavedSearchStoreDeleteFuncCall{v0, v1, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Metadata method of
-// the parent MockRepoStore instance is invoked and the hook queue is empty.
-func (f *RepoStoreMetadataFunc) SetDefaultHook(hook func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error)) {
+// SetDefaultHook sets function that is called when the Delete method of the
+// parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreDeleteFunc) SetDefaultHook(hook func(context.Context, int32) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Metadata method of the parent MockRepoStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *RepoStoreMetadataFunc) PushHook(hook func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error)) {
+// Delete method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreDeleteFunc) PushHook(hook func(context.Context, int32) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31277,20 +33247,20 @@ func (f *RepoStoreMetadataFunc) PushHook(hook func(context.Context, ...api.RepoI
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreMetadataFunc) SetDefaultReturn(r0 []*types.SearchedRepo, r1 error) {
-	f.SetDefaultHook(func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error) {
-		return r0, r1
+func (f *SavedSearchStoreDeleteFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreMetadataFunc) PushReturn(r0 []*types.SearchedRepo, r1 error) {
-	f.PushHook(func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error) {
-		return r0, r1
+func (f *SavedSearchStoreDeleteFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32) error {
+		return r0
 	})
 }
 
-func (f *RepoStoreMetadataFunc) nextHook() func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error) {
+func (f *SavedSearchStoreDeleteFunc) nextHook() func(context.Context, int32) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31303,87 +33273,79 @@ func (f *RepoStoreMetadataFunc) nextHook() func(context.Context, ...api.RepoID)
 	return hook
 }
 
-func (f *RepoStoreMetadataFunc) appendCall(r0 RepoStoreMetadataFuncCall) {
+func (f *SavedSearchStoreDeleteFunc) appendCall(r0 SavedSearchStoreDeleteFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreMetadataFuncCall objects
+// History returns a sequence of SavedSearchStoreDeleteFuncCall objects
 // describing the invocations of this function.
-func (f *RepoStoreMetadataFunc) History() []RepoStoreMetadataFuncCall {
+func (f *SavedSearchStoreDeleteFunc) History() []SavedSearchStoreDeleteFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreMetadataFuncCall, len(f.history))
+	history := make([]SavedSearchStoreDeleteFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreMetadataFuncCall is an object that describes an invocation of
-// method Metadata on an instance of MockRepoStore.
-type RepoStoreMetadataFuncCall struct {
+// SavedSearchStoreDeleteFuncCall is an object that describes an invocation
+// of method Delete on an instance of MockSavedSearchStore.
+type SavedSearchStoreDeleteFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is a slice containing the values of the variadic arguments
-	// passed to this method invocation.
-	Arg1 []api.RepoID
-	// Result0 is the value of the 1st result returned from this method
+	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Result0 []*types.SearchedRepo
-	// Result1 is the value of the 2nd result returned from this method
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation. The variadic slice argument is flattened in this array such
-// that one positional argument and three variadic arguments would result in
-// a slice of four, not two.
-func (c RepoStoreMetadataFuncCall) Args() []interface{} {
-	trailing := []interface{}{}
-	for _, val := range c.Arg1 {
-		trailing = append(trailing, val)
-	}
-
-	return append([]interface{}{c.Arg0}, trailing...)
+// invocation.
+func (c SavedSearchStoreDeleteFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreMetadataFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c SavedSearchStoreDeleteFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// RepoStoreQueryFunc describes the behavior when the Query method of the
-// parent MockRepoStore instance is invoked.
-type RepoStoreQueryFunc struct {
-	defaultHook func(context.Context, *sqlf.Query) (*sql.Rows, error)
-	hooks       []func(context.Context, *sqlf.Query) (*sql.Rows, error)
-	history     []RepoStoreQueryFuncCall
+// SavedSearchStoreDeleteScheduleFunc describes the behavior when the
+// DeleteSchedule method of the parent MockSavedSearchStore instance is
+// invoked.
+type SavedSearchStoreDeleteScheduleFunc struct {
+	defaultHook func(context.Context, int32) error
+	hooks       []func(context.Context, int32) error
+	history     []SavedSearchStoreDeleteScheduleFuncCall
 	mutex       sync.Mutex
 }
 
-// Query delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockRepoStore) Query(v0 context.Context, v1 *sqlf.Query) (*sql.Rows, error) {
-	r0, r1 := m.QueryFunc.nextHook()(v0, v1)
-	m.QueryFunc.appendCall(RepoStoreQueryFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// DeleteSchedule delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) DeleteSchedule(v0 context.Context, v1 int32) error {
+	r0 := m.DeleteScheduleFunc.nextHook()(v0, v1)
+	m.DeleteScheduleFunc.appendCall(SavedSearchStoreDeleteScheduleFuncCall{v0, v1, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Query method of the
-// parent MockRepoStore instance is invoked and the hook queue is empty.
-func (f *RepoStoreQueryFunc) SetDefaultHook(hook func(context.Context, *sqlf.Query) (*sql.Rows, error)) {
+// SetDefaultHook sets function that is called when the DeleteSchedule
+// method of the parent MockSavedSearchStore instance is invoked and the
+// hook queue is empty.
+func (f *SavedSearchStoreDeleteScheduleFunc) SetDefaultHook(hook func(context.Context, int32) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Query method of the parent MockRepoStore instance invokes the hook at the
-// front of the queue and discards it. After the queue is empty, the default
-// hook function is invoked for any future action.
-func (f *RepoStoreQueryFunc) PushHook(hook func(context.Context, *sqlf.Query) (*sql.Rows, error)) {
+// DeleteSchedule method of the parent MockSavedSearchStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SavedSearchStoreDeleteScheduleFunc) PushHook(hook func(context.Context, int32) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31391,20 +33353,20 @@ func (f *RepoStoreQueryFunc) PushHook(hook func(context.Context, *sqlf.Query) (*
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreQueryFunc) SetDefaultReturn(r0 *sql.Rows, r1 error) {
-	f.SetDefaultHook(func(context.Context, *sqlf.Query) (*sql.Rows, error) {
-		return r0, r1
+func (f *SavedSearchStoreDeleteScheduleFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreQueryFunc) PushReturn(r0 *sql.Rows, r1 error) {
-	f.PushHook(func(context.Context, *sqlf.Query) (*sql.Rows, error) {
-		return r0, r1
+func (f *SavedSearchStoreDeleteScheduleFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32) error {
+		return r0
 	})
 }
 
-func (f *RepoStoreQueryFunc) nextHook() func(context.Context, *sqlf.Query) (*sql.Rows, error) {
+func (f *SavedSearchStoreDeleteScheduleFunc) nextHook() func(context.Context, int32) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31417,82 +33379,79 @@ func (f *RepoStoreQueryFunc) nextHook() func(context.Context, *sqlf.Query) (*sql
 	return hook
 }
 
-func (f *RepoStoreQueryFunc) appendCall(r0 RepoStoreQueryFuncCall) {
+func (f *SavedSearchStoreDeleteScheduleFunc) appendCall(r0 SavedSearchStoreDeleteScheduleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreQueryFuncCall objects describing
-// the invocations of this function.
-func (f *RepoStoreQueryFunc) History() []RepoStoreQueryFuncCall {
+// History returns a sequence of SavedSearchStoreDeleteScheduleFuncCall
+// objects describing the invocations of this function.
+func (f *SavedSearchStoreDeleteScheduleFunc) History() []SavedSearchStoreDeleteScheduleFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreQueryFuncCall, len(f.history))
+	history := make([]SavedSearchStoreDeleteScheduleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreQueryFuncCall is an object that describes an invocation of
-// method Query on an instance of MockRepoStore.
-type RepoStoreQueryFuncCall struct {
+// SavedSearchStoreDeleteScheduleFuncCall is an object that describes an
+// invocation of method DeleteSchedule on an instance of
+// MockSavedSearchStore.
+type SavedSearchStoreDeleteScheduleFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 *sqlf.Query
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *sql.Rows
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c RepoStoreQueryFuncCall) Args() []interface{} {
+func (c SavedSearchStoreDeleteScheduleFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreQueryFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c SavedSearchStoreDeleteScheduleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// RepoStoreStreamMinimalReposFunc describes the behavior when the
-// StreamMinimalRepos method of the parent MockRepoStore instance is
-// invoked.
-type RepoStoreStreamMinimalReposFunc struct {
-	defaultHook func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error
-	hooks       []func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error
-	history     []RepoStoreStreamMinimalReposFuncCall
+// SavedSearchStoreGetByIDFunc describes the behavior when the GetByID
+// method of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreGetByIDFunc struct {
+	defaultHook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)
+	hooks       []func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)
+	history     []SavedSearchStoreGetByIDFuncCall
 	mutex       sync.Mutex
 }
 
-// StreamMinimalRepos delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockRepoStore) StreamMinimalRepos(v0 context.Context, v1 ReposListOptions, v2 func(*types.MinimalRepo)) error {
-	r0 := m.StreamMinimalReposFunc.nextHook()(v0, v1, v2)
-	m.StreamMinimalReposFunc.appendCall(RepoStoreStreamMinimalReposFuncCall{v0, v1, v2, r0})
-	return r0
+// GetByID delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) GetByID(v0 context.Context, v1 int32) (*api.SavedQuerySpecAndConfig, error) {
+	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
+	m.GetByIDFunc.appendCall(SavedSearchStoreGetByIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the StreamMinimalRepos
-// method of the parent MockRepoStore instance is invoked and the hook queue
-// is empty.
-func (f *RepoStoreStreamMinimalReposFunc) SetDefaultHook(hook func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error) {
+// SetDefaultHook sets function that is called when the GetByID method of
+// the parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// StreamMinimalRepos method of the parent MockRepoStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *RepoStoreStreamMinimalReposFunc) PushHook(hook func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error) {
+// GetByID method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreGetByIDFunc) PushHook(hook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31500,20 +33459,20 @@ func (f *RepoStoreStreamMinimalReposFunc) PushHook(hook func(context.Context, Re
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreStreamMinimalReposFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error {
-		return r0
+func (f *SavedSearchStoreGetByIDFunc) SetDefaultReturn(r0 *api.SavedQuerySpecAndConfig, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreStreamMinimalReposFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error {
-		return r0
+func (f *SavedSearchStoreGetByIDFunc) PushReturn(r0 *api.SavedQuerySpecAndConfig, r1 error) {
+	f.PushHook(func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+		return r0, r1
 	})
 }
 
-func (f *RepoStoreStreamMinimalReposFunc) nextHook() func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error {
+func (f *SavedSearchStoreGetByIDFunc) nextHook() func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31526,80 +33485,82 @@ func (f *RepoStoreStreamMinimalReposFunc) nextHook() func(context.Context, Repos
 	return hook
 }
 
-func (f *RepoStoreStreamMinimalReposFunc) appendCall(r0 RepoStoreStreamMinimalReposFuncCall) {
+func (f *SavedSearchStoreGetByIDFunc) appendCall(r0 SavedSearchStoreGetByIDFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreStreamMinimalReposFuncCall objects
+// History returns a sequence of SavedSearchStoreGetByIDFuncCall objects
 // describing the invocations of this function.
-func (f *RepoStoreStreamMinimalReposFunc) History() []RepoStoreStreamMinimalReposFuncCall {
+func (f *SavedSearchStoreGetByIDFunc) History() []SavedSearchStoreGetByIDFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreStreamMinimalReposFuncCall, len(f.history))
+	history := make([]SavedSearchStoreGetByIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreStreamMinimalReposFuncCall is an object that describes an
-// invocation of method StreamMinimalRepos on an instance of MockRepoStore.
-type RepoStoreStreamMinimalReposFuncCall struct {
+// SavedSearchStoreGetByIDFuncCall is an object that describes an invocation
+// of method GetByID on an instance of MockSavedSearchStore.
+type SavedSearchStoreGetByIDFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 ReposListOptions
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 func(*types.MinimalRepo)
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 *api.SavedQuerySpecAndConfig
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c RepoStoreStreamMinimalReposFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c SavedSearchStoreGetByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreStreamMinimalReposFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c SavedSearchStoreGetByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// RepoStoreTransactFunc describes the behavior when the Transact method of
-// the parent MockRepoStore instance is invoked.
-type RepoStoreTransactFunc struct {
-	defaultHook func(context.Context) (RepoStore, error)
-	hooks       []func(context.Context) (RepoStore, error)
-	history     []RepoStoreTransactFuncCall
+// SavedSearchStoreGetScheduleFunc describes the behavior when the
+// GetSchedule method of the parent MockSavedSearchStore instance is
+// invoked.
+type SavedSearchStoreGetScheduleFunc struct {
+	defaultHook func(context.Context, int32) (*types.SavedSearchSchedule, error)
+	hooks       []func(context.Context, int32) (*types.SavedSearchSchedule, error)
+	history     []SavedSearchStoreGetScheduleFuncCall
 	mutex       sync.Mutex
 }
 
-// Transact delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockRepoStore) Transact(v0 context.Context) (RepoStore, error) {
-	r0, r1 := m.TransactFunc.nextHook()(v0)
-	m.TransactFunc.appendCall(RepoStoreTransactFuncCall{v0, r0, r1})
+// GetSchedule delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) GetSchedule(v0 context.Context, v1 int32) (*types.SavedSearchSchedule, error) {
+	r0, r1 := m.GetScheduleFunc.nextHook()(v0, v1)
+	m.GetScheduleFunc.appendCall(SavedSearchStoreGetScheduleFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Transact method of
-// the parent MockRepoStore instance is invoked and the hook queue is empty.
-func (f *RepoStoreTransactFunc) SetDefaultHook(hook func(context.Context) (RepoStore, error)) {
+// SetDefaultHook sets function that is called when the GetSchedule method
+// of the parent MockSavedSearchStore instance is invoked and the hook queue
+// is empty.
+func (f *SavedSearchStoreGetScheduleFunc) SetDefaultHook(hook func(context.Context, int32) (*types.SavedSearchSchedule, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Transact method of the parent MockRepoStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *RepoStoreTransactFunc) PushHook(hook func(context.Context) (RepoStore, error)) {
+// GetSchedule method of the parent MockSavedSearchStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SavedSearchStoreGetScheduleFunc) PushHook(hook func(context.Context, int32) (*types.SavedSearchSchedule, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31607,20 +33568,20 @@ func (f *RepoStoreTransactFunc) PushHook(hook func(context.Context) (RepoStore,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreTransactFunc) SetDefaultReturn(r0 RepoStore, r1 error) {
-	f.SetDefaultHook(func(context.Context) (RepoStore, error) {
+func (f *SavedSearchStoreGetScheduleFunc) SetDefaultReturn(r0 *types.SavedSearchSchedule, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*types.SavedSearchSchedule, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreTransactFunc) PushReturn(r0 RepoStore, r1 error) {
-	f.PushHook(func(context.Context) (RepoStore, error) {
+func (f *SavedSearchStoreGetScheduleFunc) PushReturn(r0 *types.SavedSearchSchedule, r1 error) {
+	f.PushHook(func(context.Context, int32) (*types.SavedSearchSchedule, error) {
 		return r0, r1
 	})
 }
 
-func (f *RepoStoreTransactFunc) nextHook() func(context.Context) (RepoStore, error) {
+func (f *SavedSearchStoreGetScheduleFunc) nextHook() func(context.Context, int32) (*types.SavedSearchSchedule, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31633,32 +33594,35 @@ func (f *RepoStoreTransactFunc) nextHook() func(context.Context) (RepoStore, err
 	return hook
 }
 
-func (f *RepoStoreTransactFunc) appendCall(r0 RepoStoreTransactFuncCall) {
+func (f *SavedSearchStoreGetScheduleFunc) appendCall(r0 SavedSearchStoreGetScheduleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreTransactFuncCall objects
+// History returns a sequence of SavedSearchStoreGetScheduleFuncCall objects
 // describing the invocations of this function.
-func (f *RepoStoreTransactFunc) History() []RepoStoreTransactFuncCall {
+func (f *SavedSearchStoreGetScheduleFunc) History() []SavedSearchStoreGetScheduleFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreTransactFuncCall, len(f.history))
+	history := make([]SavedSearchStoreGetScheduleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoStoreTransactFuncCall is an object that describes an invocation of
-// method Transact on an instance of MockRepoStore.
-type RepoStoreTransactFuncCall struct {
+// SavedSearchStoreGetScheduleFuncCall is an object that describes an
+// invocation of method GetSchedule on an instance of MockSavedSearchStore.
+type SavedSearchStoreGetScheduleFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 RepoStore
+	Result0 *types.SavedSearchSchedule
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -31666,44 +33630,45 @@ type RepoStoreTransactFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c RepoStoreTransactFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c SavedSearchStoreGetScheduleFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoStoreTransactFuncCall) Results() []interface{} {
+func (c SavedSearchStoreGetScheduleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// RepoStoreWithFunc describes the behavior when the With method of the
-// parent MockRepoStore instance is invoked.
-type RepoStoreWithFunc struct {
-	defaultHook func(basestore.ShareableStore) RepoStore
-	hooks       []func(basestore.ShareableStore) RepoStore
-	history     []RepoStoreWithFuncCall
+// SavedSearchStoreHandleFunc describes the behavior when the Handle method
+// of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreHandleFunc struct {
+	defaultHook func() basestore.TransactableHandle
+	hooks       []func() basestore.TransactableHandle
+	history     []SavedSearchStoreHandleFuncCall
 	mutex       sync.Mutex
 }
 
-// With delegates to the next hook function in the queue and stores the
+// Handle delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockRepoStore) With(v0 basestore.ShareableStore) RepoStore {
-	r0 := m.WithFunc.nextHook()(v0)
-	m.WithFunc.appendCall(RepoStoreWithFuncCall{v0, r0})
+func (m *MockSavedSearchStore) Handle() basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(SavedSearchStoreHandleFuncCall{r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the With method of the
-// parent MockRepoStore instance is invoked and the hook queue is empty.
-func (f *RepoStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) RepoStore) {
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// With method of the parent MockRepoStore instance invokes the hook at the
-// front of the queue and discards it. After the queue is empty, the default
-// hook function is invoked for any future action.
-func (f *RepoStoreWithFunc) PushHook(hook func(basestore.ShareableStore) RepoStore) {
+// Handle method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -31711,20 +33676,20 @@ func (f *RepoStoreWithFunc) PushHook(hook func(basestore.ShareableStore) RepoSto
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoStoreWithFunc) SetDefaultReturn(r0 RepoStore) {
-	f.SetDefaultHook(func(basestore.ShareableStore) RepoStore {
+func (f *SavedSearchStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
+	f.SetDefaultHook(func() basestore.TransactableHandle {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoStoreWithFunc) PushReturn(r0 RepoStore) {
-	f.PushHook(func(basestore.ShareableStore) RepoStore {
+func (f *SavedSearchStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
+	f.PushHook(func() basestore.TransactableHandle {
 		return r0
 	})
 }
 
-func (f *RepoStoreWithFunc) nextHook() func(basestore.ShareableStore) RepoStore {
+func (f *SavedSearchStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -31737,282 +33702,72 @@ func (f *RepoStoreWithFunc) nextHook() func(basestore.ShareableStore) RepoStore
 	return hook
 }
 
-func (f *RepoStoreWithFunc) appendCall(r0 RepoStoreWithFuncCall) {
+func (f *SavedSearchStoreHandleFunc) appendCall(r0 SavedSearchStoreHandleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoStoreWithFuncCall objects describing
-// the invocations of this function.
-func (f *RepoStoreWithFunc) History() []RepoStoreWithFuncCall {
+// History returns a sequence of SavedSearchStoreHandleFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreHandleFunc) History() []SavedSearchStoreHandleFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoStoreWithFuncCall, len(f.history))
+	history := make([]SavedSearchStoreHandleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
-	return history
-}
-
-// RepoStoreWithFuncCall is an object that describes an invocation of method
-// With on an instance of MockRepoStore.
-type RepoStoreWithFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 basestore.ShareableStore
-	// Result0 is the value of the 1st result returned from this method
-	// invocation.
-	Result0 RepoStore
-}
-
-// Args returns an interface slice containing the arguments of this
-// invocation.
-func (c RepoStoreWithFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
-}
-
-// Results returns an interface slice containing the results of this
-// invocation.
-func (c RepoStoreWithFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
-}
-
-// MockSavedSearchStore is a mock implementation of the SavedSearchStore
-// interface (from the package
-// github.com/sourcegraph/sourcegraph/internal/database) used for unit
-// testing.
-type MockSavedSearchStore struct {
-	// CreateFunc is an instance of a mock function object controlling the
-	// behavior of the method Create.
-	CreateFunc *SavedSearchStoreCreateFunc
-	// DeleteFunc is an instance of a mock function object controlling the
-	// behavior of the method Delete.
-	DeleteFunc *SavedSearchStoreDeleteFunc
-	// GetByIDFunc is an instance of a mock function object controlling the
-	// behavior of the method GetByID.
-	GetByIDFunc *SavedSearchStoreGetByIDFunc
-	// HandleFunc is an instance of a mock function object controlling the
-	// behavior of the method Handle.
-	HandleFunc *SavedSearchStoreHandleFunc
-	// IsEmptyFunc is an instance of a mock function object controlling the
-	// behavior of the method IsEmpty.
-	IsEmptyFunc *SavedSearchStoreIsEmptyFunc
-	// ListAllFunc is an instance of a mock function object controlling the
-	// behavior of the method ListAll.
-	ListAllFunc *SavedSearchStoreListAllFunc
-	// ListSavedSearchesByOrgIDFunc is an instance of a mock function object
-	// controlling the behavior of the method ListSavedSearchesByOrgID.
-	ListSavedSearchesByOrgIDFunc *SavedSearchStoreListSavedSearchesByOrgIDFunc
-	// ListSavedSearchesByUserIDFunc is an instance of a mock function
-	// object controlling the behavior of the method
-	// ListSavedSearchesByUserID.
-	ListSavedSearchesByUserIDFunc *SavedSearchStoreListSavedSearchesByUserIDFunc
-	// TransactFunc is an instance of a mock function object controlling the
-	// behavior of the method Transact.
-	TransactFunc *SavedSearchStoreTransactFunc
-	// UpdateFunc is an instance of a mock function object controlling the
-	// behavior of the method Update.
-	UpdateFunc *SavedSearchStoreUpdateFunc
-	// WithFunc is an instance of a mock function object controlling the
-	// behavior of the method With.
-	WithFunc *SavedSearchStoreWithFunc
-}
-
-// NewMockSavedSearchStore creates a new mock of the SavedSearchStore
-// interface. All methods return zero values for all results, unless
-// overwritten.
-func NewMockSavedSearchStore() *MockSavedSearchStore {
-	return &MockSavedSearchStore{
-		CreateFunc: &SavedSearchStoreCreateFunc{
-			defaultHook: func(context.Context, *types.SavedSearch) (r0 *types.SavedSearch, r1 error) {
-				return
-			},
-		},
-		DeleteFunc: &SavedSearchStoreDeleteFunc{
-			defaultHook: func(context.Context, int32) (r0 error) {
-				return
-			},
-		},
-		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
-			defaultHook: func(context.Context, int32) (r0 *api.SavedQuerySpecAndConfig, r1 error) {
-				return
-			},
-		},
-		HandleFunc: &SavedSearchStoreHandleFunc{
-			defaultHook: func() (r0 basestore.TransactableHandle) {
-				return
-			},
-		},
-		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
-			defaultHook: func(context.Context) (r0 bool, r1 error) {
-				return
-			},
-		},
-		ListAllFunc: &SavedSearchStoreListAllFunc{
-			defaultHook: func(context.Context) (r0 []api.SavedQuerySpecAndConfig, r1 error) {
-				return
-			},
-		},
-		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
-			defaultHook: func(context.Context, int32) (r0 []*types.SavedSearch, r1 error) {
-				return
-			},
-		},
-		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
-			defaultHook: func(context.Context, int32) (r0 []*types.SavedSearch, r1 error) {
-				return
-			},
-		},
-		TransactFunc: &SavedSearchStoreTransactFunc{
-			defaultHook: func(context.Context) (r0 SavedSearchStore, r1 error) {
-				return
-			},
-		},
-		UpdateFunc: &SavedSearchStoreUpdateFunc{
-			defaultHook: func(context.Context, *types.SavedSearch) (r0 *types.SavedSearch, r1 error) {
-				return
-			},
-		},
-		WithFunc: &SavedSearchStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) (r0 SavedSearchStore) {
-				return
-			},
-		},
-	}
-}
-
-// NewStrictMockSavedSearchStore creates a new mock of the SavedSearchStore
-// interface. All methods panic on invocation, unless overwritten.
-func NewStrictMockSavedSearchStore() *MockSavedSearchStore {
-	return &MockSavedSearchStore{
-		CreateFunc: &SavedSearchStoreCreateFunc{
-			defaultHook: func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
-				panic("unexpected invocation of MockSavedSearchStore.Create")
-			},
-		},
-		DeleteFunc: &SavedSearchStoreDeleteFunc{
-			defaultHook: func(context.Context, int32) error {
-				panic("unexpected invocation of MockSavedSearchStore.Delete")
-			},
-		},
-		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
-			defaultHook: func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
-				panic("unexpected invocation of MockSavedSearchStore.GetByID")
-			},
-		},
-		HandleFunc: &SavedSearchStoreHandleFunc{
-			defaultHook: func() basestore.TransactableHandle {
-				panic("unexpected invocation of MockSavedSearchStore.Handle")
-			},
-		},
-		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
-			defaultHook: func(context.Context) (bool, error) {
-				panic("unexpected invocation of MockSavedSearchStore.IsEmpty")
-			},
-		},
-		ListAllFunc: &SavedSearchStoreListAllFunc{
-			defaultHook: func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
-				panic("unexpected invocation of MockSavedSearchStore.ListAll")
-			},
-		},
-		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
-			defaultHook: func(context.Context, int32) ([]*types.SavedSearch, error) {
-				panic("unexpected invocation of MockSavedSearchStore.ListSavedSearchesByOrgID")
-			},
-		},
-		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
-			defaultHook: func(context.Context, int32) ([]*types.SavedSearch, error) {
-				panic("unexpected invocation of MockSavedSearchStore.ListSavedSearchesByUserID")
-			},
-		},
-		TransactFunc: &SavedSearchStoreTransactFunc{
-			defaultHook: func(context.Context) (SavedSearchStore, error) {
-				panic("unexpected invocation of MockSavedSearchStore.Transact")
-			},
-		},
-		UpdateFunc: &SavedSearchStoreUpdateFunc{
-			defaultHook: func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
-				panic("unexpected invocation of MockSavedSearchStore.Update")
-			},
-		},
-		WithFunc: &SavedSearchStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) SavedSearchStore {
-				panic("unexpected invocation of MockSavedSearchStore.With")
-			},
-		},
-	}
+	return history
 }
 
-// NewMockSavedSearchStoreFrom creates a new mock of the
-// MockSavedSearchStore interface. All methods delegate to the given
-// implementation, unless overwritten.
-func NewMockSavedSearchStoreFrom(i SavedSearchStore) *MockSavedSearchStore {
-	return &MockSavedSearchStore{
-		CreateFunc: &SavedSearchStoreCreateFunc{
-			defaultHook: i.Create,
-		},
-		DeleteFunc: &SavedSearchStoreDeleteFunc{
-			defaultHook: i.Delete,
-		},
-		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
-			defaultHook: i.GetByID,
-		},
-		HandleFunc: &SavedSearchStoreHandleFunc{
-			defaultHook: i.Handle,
-		},
-		IsEmptyFunc: &SavedSearchStoreIsEmptyFunc{
-			defaultHook: i.IsEmpty,
-		},
-		ListAllFunc: &SavedSearchStoreListAllFunc{
-			defaultHook: i.ListAll,
-		},
-		ListSavedSearchesByOrgIDFunc: &SavedSearchStoreListSavedSearchesByOrgIDFunc{
-			defaultHook: i.ListSavedSearchesByOrgID,
-		},
-		ListSavedSearchesByUserIDFunc: &SavedSearchStoreListSavedSearchesByUserIDFunc{
-			defaultHook: i.ListSavedSearchesByUserID,
-		},
-		TransactFunc: &SavedSearchStoreTransactFunc{
-			defaultHook: i.Transact,
-		},
-		UpdateFunc: &SavedSearchStoreUpdateFunc{
-			defaultHook: i.Update,
-		},
-		WithFunc: &SavedSearchStoreWithFunc{
-			defaultHook: i.With,
-		},
-	}
+// SavedSearchStoreHandleFuncCall is an object that describes an invocation
+// of method Handle on an instance of MockSavedSearchStore.
+type SavedSearchStoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 basestore.TransactableHandle
 }
 
-// SavedSearchStoreCreateFunc describes the behavior when the Create method
-// of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreCreateFunc struct {
-	defaultHook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
-	hooks       []func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
-	history     []SavedSearchStoreCreateFuncCall
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SavedSearchStoreIsEmptyFunc describes the behavior when the IsEmpty
+// method of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreIsEmptyFunc struct {
+	defaultHook func(context.Context) (bool, error)
+	hooks       []func(context.Context) (bool, error)
+	history     []SavedSearchStoreIsEmptyFuncCall
 	mutex       sync.Mutex
 }
 
-// Create delegates to the next hook function in the queue and stores the
+// IsEmpty delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) Create(v0 context.Context, v1 *types.SavedSearch) (*types.SavedSearch, error) {
-	r0, r1 := m.CreateFunc.nextHook()(v0, v1)
-	m.CreateFunc.appendCall(SavedSearchStoreCreateFuncCall{v0, v1, r0, r1})
+func (m *MockSavedSearchStore) IsEmpty(v0 context.Context) (bool, error) {
+	r0, r1 := m.IsEmptyFunc.nextHook()(v0)
+	m.IsEmptyFunc.appendCall(SavedSearchStoreIsEmptyFuncCall{v0, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Create method of the
-// parent MockSavedSearchStore instance is invoked and the hook queue is
+// SetDefaultHook sets function that is called when the IsEmpty method of
+// the parent MockSavedSearchStore instance is invoked and the hook queue is
 // empty.
-func (f *SavedSearchStoreCreateFunc) SetDefaultHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
+func (f *SavedSearchStoreIsEmptyFunc) SetDefaultHook(hook func(context.Context) (bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Create method of the parent MockSavedSearchStore instance invokes the
+// IsEmpty method of the parent MockSavedSearchStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *SavedSearchStoreCreateFunc) PushHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
+func (f *SavedSearchStoreIsEmptyFunc) PushHook(hook func(context.Context) (bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32020,20 +33775,20 @@ func (f *SavedSearchStoreCreateFunc) PushHook(hook func(context.Context, *types.
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreCreateFunc) SetDefaultReturn(r0 *types.SavedSearch, r1 error) {
-	f.SetDefaultHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+func (f *SavedSearchStoreIsEmptyFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context) (bool, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreCreateFunc) PushReturn(r0 *types.SavedSearch, r1 error) {
-	f.PushHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+func (f *SavedSearchStoreIsEmptyFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context) (bool, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreCreateFunc) nextHook() func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+func (f *SavedSearchStoreIsEmptyFunc) nextHook() func(context.Context) (bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32046,35 +33801,32 @@ func (f *SavedSearchStoreCreateFunc) nextHook() func(context.Context, *types.Sav
 	return hook
 }
 
-func (f *SavedSearchStoreCreateFunc) appendCall(r0 SavedSearchStoreCreateFuncCall) {
+func (f *SavedSearchStoreIsEmptyFunc) appendCall(r0 SavedSearchStoreIsEmptyFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreCreateFuncCall objects
+// History returns a sequence of SavedSearchStoreIsEmptyFuncCall objects
 // describing the invocations of this function.
-func (f *SavedSearchStoreCreateFunc) History() []SavedSearchStoreCreateFuncCall {
+func (f *SavedSearchStoreIsEmptyFunc) History() []SavedSearchStoreIsEmptyFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreCreateFuncCall, len(f.history))
+	history := make([]SavedSearchStoreIsEmptyFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreCreateFuncCall is an object that describes an invocation
-// of method Create on an instance of MockSavedSearchStore.
-type SavedSearchStoreCreateFuncCall struct {
+// SavedSearchStoreIsEmptyFuncCall is an object that describes an invocation
+// of method IsEmpty on an instance of MockSavedSearchStore.
+type SavedSearchStoreIsEmptyFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 *types.SavedSearch
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.SavedSearch
+	Result0 bool
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -32082,45 +33834,45 @@ type SavedSearchStoreCreateFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreCreateFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c SavedSearchStoreIsEmptyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreCreateFuncCall) Results() []interface{} {
+func (c SavedSearchStoreIsEmptyFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreDeleteFunc describes the behavior when the Delete method
-// of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreDeleteFunc struct {
-	defaultHook func(context.Context, int32) error
-	hooks       []func(context.Context, int32) error
-	history     []SavedSearchStoreDeleteFuncCall
+// SavedSearchStoreListAllFunc describes the behavior when the ListAll
+// method of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreListAllFunc struct {
+	defaultHook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)
+	hooks       []func(context.Context) ([]api.SavedQuerySpecAndConfig, error)
+	history     []SavedSearchStoreListAllFuncCall
 	mutex       sync.Mutex
 }
 
-// Delete delegates to the next hook function in the queue and stores the
+// ListAll delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) Delete(v0 context.Context, v1 int32) error {
-	r0 := m.DeleteFunc.nextHook()(v0, v1)
-	m.DeleteFunc.appendCall(SavedSearchStoreDeleteFuncCall{v0, v1, r0})
-	return r0
+func (m *MockSavedSearchStore) ListAll(v0 context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+	r0, r1 := m.ListAllFunc.nextHook()(v0)
+	m.ListAllFunc.appendCall(SavedSearchStoreListAllFuncCall{v0, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Delete method of the
-// parent MockSavedSearchStore instance is invoked and the hook queue is
+// SetDefaultHook sets function that is called when the ListAll method of
+// the parent MockSavedSearchStore instance is invoked and the hook queue is
 // empty.
-func (f *SavedSearchStoreDeleteFunc) SetDefaultHook(hook func(context.Context, int32) error) {
+func (f *SavedSearchStoreListAllFunc) SetDefaultHook(hook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Delete method of the parent MockSavedSearchStore instance invokes the
+// ListAll method of the parent MockSavedSearchStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *SavedSearchStoreDeleteFunc) PushHook(hook func(context.Context, int32) error) {
+func (f *SavedSearchStoreListAllFunc) PushHook(hook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32128,20 +33880,20 @@ func (f *SavedSearchStoreDeleteFunc) PushHook(hook func(context.Context, int32)
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreDeleteFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32) error {
-		return r0
+func (f *SavedSearchStoreListAllFunc) SetDefaultReturn(r0 []api.SavedQuerySpecAndConfig, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreDeleteFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32) error {
-		return r0
+func (f *SavedSearchStoreListAllFunc) PushReturn(r0 []api.SavedQuerySpecAndConfig, r1 error) {
+	f.PushHook(func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreDeleteFunc) nextHook() func(context.Context, int32) error {
+func (f *SavedSearchStoreListAllFunc) nextHook() func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32154,78 +33906,190 @@ func (f *SavedSearchStoreDeleteFunc) nextHook() func(context.Context, int32) err
 	return hook
 }
 
-func (f *SavedSearchStoreDeleteFunc) appendCall(r0 SavedSearchStoreDeleteFuncCall) {
+func (f *SavedSearchStoreListAllFunc) appendCall(r0 SavedSearchStoreListAllFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreDeleteFuncCall objects
+// History returns a sequence of SavedSearchStoreListAllFuncCall objects
 // describing the invocations of this function.
-func (f *SavedSearchStoreDeleteFunc) History() []SavedSearchStoreDeleteFuncCall {
+func (f *SavedSearchStoreListAllFunc) History() []SavedSearchStoreListAllFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreListAllFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreListAllFuncCall is an object that describes an invocation
+// of method ListAll on an instance of MockSavedSearchStore.
+type SavedSearchStoreListAllFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []api.SavedQuerySpecAndConfig
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreListAllFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreListAllFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SavedSearchStoreListDueForRunFunc describes the behavior when the
+// ListDueForRun method of the parent MockSavedSearchStore instance is
+// invoked.
+type SavedSearchStoreListDueForRunFunc struct {
+	defaultHook func(context.Context, int) ([]*types.SavedSearch, error)
+	hooks       []func(context.Context, int) ([]*types.SavedSearch, error)
+	history     []SavedSearchStoreListDueForRunFuncCall
+	mutex       sync.Mutex
+}
+
+// ListDueForRun delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) ListDueForRun(v0 context.Context, v1 int) ([]*types.SavedSearch, error) {
+	r0, r1 := m.ListDueForRunFunc.nextHook()(v0, v1)
+	m.ListDueForRunFunc.appendCall(SavedSearchStoreListDueForRunFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListDueForRun method
+// of the parent MockSavedSearchStore instance is invoked and the hook queue
+// is empty.
+func (f *SavedSearchStoreListDueForRunFunc) SetDefaultHook(hook func(context.Context, int) ([]*types.SavedSearch, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListDueForRun method of the parent MockSavedSearchStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SavedSearchStoreListDueForRunFunc) PushHook(hook func(context.Context, int) ([]*types.SavedSearch, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreListDueForRunFunc) SetDefaultReturn(r0 []*types.SavedSearch, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]*types.SavedSearch, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreListDueForRunFunc) PushReturn(r0 []*types.SavedSearch, r1 error) {
+	f.PushHook(func(context.Context, int) ([]*types.SavedSearch, error) {
+		return r0, r1
+	})
+}
+
+func (f *SavedSearchStoreListDueForRunFunc) nextHook() func(context.Context, int) ([]*types.SavedSearch, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreListDueForRunFunc) appendCall(r0 SavedSearchStoreListDueForRunFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SavedSearchStoreListDueForRunFuncCall
+// objects describing the invocations of this function.
+func (f *SavedSearchStoreListDueForRunFunc) History() []SavedSearchStoreListDueForRunFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreDeleteFuncCall, len(f.history))
+	history := make([]SavedSearchStoreListDueForRunFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreDeleteFuncCall is an object that describes an invocation
-// of method Delete on an instance of MockSavedSearchStore.
-type SavedSearchStoreDeleteFuncCall struct {
+// SavedSearchStoreListDueForRunFuncCall is an object that describes an
+// invocation of method ListDueForRun on an instance of
+// MockSavedSearchStore.
+type SavedSearchStoreListDueForRunFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 []*types.SavedSearch
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreDeleteFuncCall) Args() []interface{} {
+func (c SavedSearchStoreListDueForRunFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreDeleteFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c SavedSearchStoreListDueForRunFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreGetByIDFunc describes the behavior when the GetByID
-// method of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreGetByIDFunc struct {
-	defaultHook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)
-	hooks       []func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)
-	history     []SavedSearchStoreGetByIDFuncCall
+// SavedSearchStoreListResultChangesFunc describes the behavior when the
+// ListResultChanges method of the parent MockSavedSearchStore instance is
+// invoked.
+type SavedSearchStoreListResultChangesFunc struct {
+	defaultHook func(context.Context, int32, bool, int) ([]*types.SavedSearchResult, error)
+	hooks       []func(context.Context, int32, bool, int) ([]*types.SavedSearchResult, error)
+	history     []SavedSearchStoreListResultChangesFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByID delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSavedSearchStore) GetByID(v0 context.Context, v1 int32) (*api.SavedQuerySpecAndConfig, error) {
-	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
-	m.GetByIDFunc.appendCall(SavedSearchStoreGetByIDFuncCall{v0, v1, r0, r1})
+// ListResultChanges delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) ListResultChanges(v0 context.Context, v1 int32, v2 bool, v3 int) ([]*types.SavedSearchResult, error) {
+	r0, r1 := m.ListResultChangesFunc.nextHook()(v0, v1, v2, v3)
+	m.ListResultChangesFunc.appendCall(SavedSearchStoreListResultChangesFuncCall{v0, v1, v2, v3, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetByID method of
-// the parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)) {
+// SetDefaultHook sets function that is called when the ListResultChanges
+// method of the parent MockSavedSearchStore instance is invoked and the
+// hook queue is empty.
+func (f *SavedSearchStoreListResultChangesFunc) SetDefaultHook(hook func(context.Context, int32, bool, int) ([]*types.SavedSearchResult, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByID method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreGetByIDFunc) PushHook(hook func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)) {
+// ListResultChanges method of the parent MockSavedSearchStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SavedSearchStoreListResultChangesFunc) PushHook(hook func(context.Context, int32, bool, int) ([]*types.SavedSearchResult, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32233,20 +34097,20 @@ func (f *SavedSearchStoreGetByIDFunc) PushHook(hook func(context.Context, int32)
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreGetByIDFunc) SetDefaultReturn(r0 *api.SavedQuerySpecAndConfig, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+func (f *SavedSearchStoreListResultChangesFunc) SetDefaultReturn(r0 []*types.SavedSearchResult, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, bool, int) ([]*types.SavedSearchResult, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreGetByIDFunc) PushReturn(r0 *api.SavedQuerySpecAndConfig, r1 error) {
-	f.PushHook(func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+func (f *SavedSearchStoreListResultChangesFunc) PushReturn(r0 []*types.SavedSearchResult, r1 error) {
+	f.PushHook(func(context.Context, int32, bool, int) ([]*types.SavedSearchResult, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreGetByIDFunc) nextHook() func(context.Context, int32) (*api.SavedQuerySpecAndConfig, error) {
+func (f *SavedSearchStoreListResultChangesFunc) nextHook() func(context.Context, int32, bool, int) ([]*types.SavedSearchResult, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32259,35 +34123,42 @@ func (f *SavedSearchStoreGetByIDFunc) nextHook() func(context.Context, int32) (*
 	return hook
 }
 
-func (f *SavedSearchStoreGetByIDFunc) appendCall(r0 SavedSearchStoreGetByIDFuncCall) {
+func (f *SavedSearchStoreListResultChangesFunc) appendCall(r0 SavedSearchStoreListResultChangesFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreGetByIDFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreGetByIDFunc) History() []SavedSearchStoreGetByIDFuncCall {
+// History returns a sequence of SavedSearchStoreListResultChangesFuncCall
+// objects describing the invocations of this function.
+func (f *SavedSearchStoreListResultChangesFunc) History() []SavedSearchStoreListResultChangesFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreGetByIDFuncCall, len(f.history))
+	history := make([]SavedSearchStoreListResultChangesFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreGetByIDFuncCall is an object that describes an invocation
-// of method GetByID on an instance of MockSavedSearchStore.
-type SavedSearchStoreGetByIDFuncCall struct {
+// SavedSearchStoreListResultChangesFuncCall is an object that describes an
+// invocation of method ListResultChanges on an instance of
+// MockSavedSearchStore.
+type SavedSearchStoreListResultChangesFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *api.SavedQuerySpecAndConfig
+	Result0 []*types.SavedSearchResult
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -32295,45 +34166,47 @@ type SavedSearchStoreGetByIDFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreGetByIDFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c SavedSearchStoreListResultChangesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreGetByIDFuncCall) Results() []interface{} {
+func (c SavedSearchStoreListResultChangesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreHandleFunc describes the behavior when the Handle method
-// of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreHandleFunc struct {
-	defaultHook func() basestore.TransactableHandle
-	hooks       []func() basestore.TransactableHandle
-	history     []SavedSearchStoreHandleFuncCall
+// SavedSearchStoreListSavedSearchesByOrgIDFunc describes the behavior when
+// the ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
+// instance is invoked.
+type SavedSearchStoreListSavedSearchesByOrgIDFunc struct {
+	defaultHook func(context.Context, int32) ([]*types.SavedSearch, error)
+	hooks       []func(context.Context, int32) ([]*types.SavedSearch, error)
+	history     []SavedSearchStoreListSavedSearchesByOrgIDFuncCall
 	mutex       sync.Mutex
 }
 
-// Handle delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSavedSearchStore) Handle() basestore.TransactableHandle {
-	r0 := m.HandleFunc.nextHook()()
-	m.HandleFunc.appendCall(SavedSearchStoreHandleFuncCall{r0})
-	return r0
+// ListSavedSearchesByOrgID delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) ListSavedSearchesByOrgID(v0 context.Context, v1 int32) ([]*types.SavedSearch, error) {
+	r0, r1 := m.ListSavedSearchesByOrgIDFunc.nextHook()(v0, v1)
+	m.ListSavedSearchesByOrgIDFunc.appendCall(SavedSearchStoreListSavedSearchesByOrgIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Handle method of the
-// parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
+// SetDefaultHook sets function that is called when the
+// ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
+// instance is invoked and the hook queue is empty.
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) SetDefaultHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Handle method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
+// ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) PushHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32341,20 +34214,20 @@ func (f *SavedSearchStoreHandleFunc) PushHook(hook func() basestore.Transactable
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
-	f.SetDefaultHook(func() basestore.TransactableHandle {
-		return r0
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) SetDefaultReturn(r0 []*types.SavedSearch, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
-	f.PushHook(func() basestore.TransactableHandle {
-		return r0
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) PushReturn(r0 []*types.SavedSearch, r1 error) {
+	f.PushHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
+		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) nextHook() func(context.Context, int32) ([]*types.SavedSearch, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32367,72 +34240,85 @@ func (f *SavedSearchStoreHandleFunc) nextHook() func() basestore.TransactableHan
 	return hook
 }
 
-func (f *SavedSearchStoreHandleFunc) appendCall(r0 SavedSearchStoreHandleFuncCall) {
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) appendCall(r0 SavedSearchStoreListSavedSearchesByOrgIDFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreHandleFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreHandleFunc) History() []SavedSearchStoreHandleFuncCall {
+// History returns a sequence of
+// SavedSearchStoreListSavedSearchesByOrgIDFuncCall objects describing the
+// invocations of this function.
+func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) History() []SavedSearchStoreListSavedSearchesByOrgIDFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreHandleFuncCall, len(f.history))
+	history := make([]SavedSearchStoreListSavedSearchesByOrgIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreHandleFuncCall is an object that describes an invocation
-// of method Handle on an instance of MockSavedSearchStore.
-type SavedSearchStoreHandleFuncCall struct {
+// SavedSearchStoreListSavedSearchesByOrgIDFuncCall is an object that
+// describes an invocation of method ListSavedSearchesByOrgID on an instance
+// of MockSavedSearchStore.
+type SavedSearchStoreListSavedSearchesByOrgIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 basestore.TransactableHandle
+	Result0 []*types.SavedSearch
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreHandleFuncCall) Args() []interface{} {
-	return []interface{}{}
+func (c SavedSearchStoreListSavedSearchesByOrgIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreHandleFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c SavedSearchStoreListSavedSearchesByOrgIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreIsEmptyFunc describes the behavior when the IsEmpty
-// method of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreIsEmptyFunc struct {
-	defaultHook func(context.Context) (bool, error)
-	hooks       []func(context.Context) (bool, error)
-	history     []SavedSearchStoreIsEmptyFuncCall
+// SavedSearchStoreListSavedSearchesByUserIDFunc describes the behavior when
+// the ListSavedSearchesByUserID method of the parent MockSavedSearchStore
+// instance is invoked.
+type SavedSearchStoreListSavedSearchesByUserIDFunc struct {
+	defaultHook func(context.Context, int32) ([]*types.SavedSearch, error)
+	hooks       []func(context.Context, int32) ([]*types.SavedSearch, error)
+	history     []SavedSearchStoreListSavedSearchesByUserIDFuncCall
 	mutex       sync.Mutex
 }
 
-// IsEmpty delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSavedSearchStore) IsEmpty(v0 context.Context) (bool, error) {
-	r0, r1 := m.IsEmptyFunc.nextHook()(v0)
-	m.IsEmptyFunc.appendCall(SavedSearchStoreIsEmptyFuncCall{v0, r0, r1})
+// ListSavedSearchesByUserID delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) ListSavedSearchesByUserID(v0 context.Context, v1 int32) ([]*types.SavedSearch, error) {
+	r0, r1 := m.ListSavedSearchesByUserIDFunc.nextHook()(v0, v1)
+	m.ListSavedSearchesByUserIDFunc.appendCall(SavedSearchStoreListSavedSearchesByUserIDFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the IsEmpty method of
-// the parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreIsEmptyFunc) SetDefaultHook(hook func(context.Context) (bool, error)) {
+// SetDefaultHook sets function that is called when the
+// ListSavedSearchesByUserID method of the parent MockSavedSearchStore
+// instance is invoked and the hook queue is empty.
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) SetDefaultHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// IsEmpty method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreIsEmptyFunc) PushHook(hook func(context.Context) (bool, error)) {
+// ListSavedSearchesByUserID method of the parent MockSavedSearchStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) PushHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32440,20 +34326,20 @@ func (f *SavedSearchStoreIsEmptyFunc) PushHook(hook func(context.Context) (bool,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreIsEmptyFunc) SetDefaultReturn(r0 bool, r1 error) {
-	f.SetDefaultHook(func(context.Context) (bool, error) {
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) SetDefaultReturn(r0 []*types.SavedSearch, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreIsEmptyFunc) PushReturn(r0 bool, r1 error) {
-	f.PushHook(func(context.Context) (bool, error) {
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) PushReturn(r0 []*types.SavedSearch, r1 error) {
+	f.PushHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreIsEmptyFunc) nextHook() func(context.Context) (bool, error) {
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) nextHook() func(context.Context, int32) ([]*types.SavedSearch, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32466,32 +34352,37 @@ func (f *SavedSearchStoreIsEmptyFunc) nextHook() func(context.Context) (bool, er
 	return hook
 }
 
-func (f *SavedSearchStoreIsEmptyFunc) appendCall(r0 SavedSearchStoreIsEmptyFuncCall) {
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) appendCall(r0 SavedSearchStoreListSavedSearchesByUserIDFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreIsEmptyFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreIsEmptyFunc) History() []SavedSearchStoreIsEmptyFuncCall {
+// History returns a sequence of
+// SavedSearchStoreListSavedSearchesByUserIDFuncCall objects describing the
+// invocations of this function.
+func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) History() []SavedSearchStoreListSavedSearchesByUserIDFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreIsEmptyFuncCall, len(f.history))
+	history := make([]SavedSearchStoreListSavedSearchesByUserIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreIsEmptyFuncCall is an object that describes an invocation
-// of method IsEmpty on an instance of MockSavedSearchStore.
-type SavedSearchStoreIsEmptyFuncCall struct {
+// SavedSearchStoreListSavedSearchesByUserIDFuncCall is an object that
+// describes an invocation of method ListSavedSearchesByUserID on an
+// instance of MockSavedSearchStore.
+type SavedSearchStoreListSavedSearchesByUserIDFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 bool
+	Result0 []*types.SavedSearch
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -32499,45 +34390,46 @@ type SavedSearchStoreIsEmptyFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreIsEmptyFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c SavedSearchStoreListSavedSearchesByUserIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreIsEmptyFuncCall) Results() []interface{} {
+func (c SavedSearchStoreListSavedSearchesByUserIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreListAllFunc describes the behavior when the ListAll
-// method of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreListAllFunc struct {
-	defaultHook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)
-	hooks       []func(context.Context) ([]api.SavedQuerySpecAndConfig, error)
-	history     []SavedSearchStoreListAllFuncCall
+// SavedSearchStoreRecordRunErrorFunc describes the behavior when the
+// RecordRunError method of the parent MockSavedSearchStore instance is
+// invoked.
+type SavedSearchStoreRecordRunErrorFunc struct {
+	defaultHook func(context.Context, int32, string) error
+	hooks       []func(context.Context, int32, string) error
+	history     []SavedSearchStoreRecordRunErrorFuncCall
 	mutex       sync.Mutex
 }
 
-// ListAll delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSavedSearchStore) ListAll(v0 context.Context) ([]api.SavedQuerySpecAndConfig, error) {
-	r0, r1 := m.ListAllFunc.nextHook()(v0)
-	m.ListAllFunc.appendCall(SavedSearchStoreListAllFuncCall{v0, r0, r1})
-	return r0, r1
+// RecordRunError delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) RecordRunError(v0 context.Context, v1 int32, v2 string) error {
+	r0 := m.RecordRunErrorFunc.nextHook()(v0, v1, v2)
+	m.RecordRunErrorFunc.appendCall(SavedSearchStoreRecordRunErrorFuncCall{v0, v1, v2, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the ListAll method of
-// the parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreListAllFunc) SetDefaultHook(hook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)) {
+// SetDefaultHook sets function that is called when the RecordRunError
+// method of the parent MockSavedSearchStore instance is invoked and the
+// hook queue is empty.
+func (f *SavedSearchStoreRecordRunErrorFunc) SetDefaultHook(hook func(context.Context, int32, string) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListAll method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreListAllFunc) PushHook(hook func(context.Context) ([]api.SavedQuerySpecAndConfig, error)) {
+// RecordRunError method of the parent MockSavedSearchStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SavedSearchStoreRecordRunErrorFunc) PushHook(hook func(context.Context, int32, string) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32545,20 +34437,20 @@ func (f *SavedSearchStoreListAllFunc) PushHook(hook func(context.Context) ([]api
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreListAllFunc) SetDefaultReturn(r0 []api.SavedQuerySpecAndConfig, r1 error) {
-	f.SetDefaultHook(func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
-		return r0, r1
+func (f *SavedSearchStoreRecordRunErrorFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreListAllFunc) PushReturn(r0 []api.SavedQuerySpecAndConfig, r1 error) {
-	f.PushHook(func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
-		return r0, r1
+func (f *SavedSearchStoreRecordRunErrorFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string) error {
+		return r0
 	})
 }
 
-func (f *SavedSearchStoreListAllFunc) nextHook() func(context.Context) ([]api.SavedQuerySpecAndConfig, error) {
+func (f *SavedSearchStoreRecordRunErrorFunc) nextHook() func(context.Context, int32, string) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32571,80 +34463,84 @@ func (f *SavedSearchStoreListAllFunc) nextHook() func(context.Context) ([]api.Sa
 	return hook
 }
 
-func (f *SavedSearchStoreListAllFunc) appendCall(r0 SavedSearchStoreListAllFuncCall) {
+func (f *SavedSearchStoreRecordRunErrorFunc) appendCall(r0 SavedSearchStoreRecordRunErrorFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreListAllFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreListAllFunc) History() []SavedSearchStoreListAllFuncCall {
+// History returns a sequence of SavedSearchStoreRecordRunErrorFuncCall
+// objects describing the invocations of this function.
+func (f *SavedSearchStoreRecordRunErrorFunc) History() []SavedSearchStoreRecordRunErrorFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreListAllFuncCall, len(f.history))
+	history := make([]SavedSearchStoreRecordRunErrorFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreListAllFuncCall is an object that describes an invocation
-// of method ListAll on an instance of MockSavedSearchStore.
-type SavedSearchStoreListAllFuncCall struct {
+// SavedSearchStoreRecordRunErrorFuncCall is an object that describes an
+// invocation of method RecordRunError on an instance of
+// MockSavedSearchStore.
+type SavedSearchStoreRecordRunErrorFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Result0 is the value of the 1st result returned from this method
+	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Result0 []api.SavedQuerySpecAndConfig
-	// Result1 is the value of the 2nd result returned from this method
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Result1 error
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreListAllFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c SavedSearchStoreRecordRunErrorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreListAllFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c SavedSearchStoreRecordRunErrorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// SavedSearchStoreListSavedSearchesByOrgIDFunc describes the behavior when
-// the ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
-// instance is invoked.
-type SavedSearchStoreListSavedSearchesByOrgIDFunc struct {
-	defaultHook func(context.Context, int32) ([]*types.SavedSearch, error)
-	hooks       []func(context.Context, int32) ([]*types.SavedSearch, error)
-	history     []SavedSearchStoreListSavedSearchesByOrgIDFuncCall
+// SavedSearchStoreRecordRunResultsFunc describes the behavior when the
+// RecordRunResults method of the parent MockSavedSearchStore instance is
+// invoked.
+type SavedSearchStoreRecordRunResultsFunc struct {
+	defaultHook func(context.Context, int32, []*types.SavedSearchResult, bool) error
+	hooks       []func(context.Context, int32, []*types.SavedSearchResult, bool) error
+	history     []SavedSearchStoreRecordRunResultsFuncCall
 	mutex       sync.Mutex
 }
 
-// ListSavedSearchesByOrgID delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockSavedSearchStore) ListSavedSearchesByOrgID(v0 context.Context, v1 int32) ([]*types.SavedSearch, error) {
-	r0, r1 := m.ListSavedSearchesByOrgIDFunc.nextHook()(v0, v1)
-	m.ListSavedSearchesByOrgIDFunc.appendCall(SavedSearchStoreListSavedSearchesByOrgIDFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// RecordRunResults delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) RecordRunResults(v0 context.Context, v1 int32, v2 []*types.SavedSearchResult, v3 bool) error {
+	r0 := m.RecordRunResultsFunc.nextHook()(v0, v1, v2, v3)
+	m.RecordRunResultsFunc.appendCall(SavedSearchStoreRecordRunResultsFuncCall{v0, v1, v2, v3, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the
-// ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
-// instance is invoked and the hook queue is empty.
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) SetDefaultHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
+// SetDefaultHook sets function that is called when the RecordRunResults
+// method of the parent MockSavedSearchStore instance is invoked and the
+// hook queue is empty.
+func (f *SavedSearchStoreRecordRunResultsFunc) SetDefaultHook(hook func(context.Context, int32, []*types.SavedSearchResult, bool) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListSavedSearchesByOrgID method of the parent MockSavedSearchStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) PushHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
+// RecordRunResults method of the parent MockSavedSearchStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SavedSearchStoreRecordRunResultsFunc) PushHook(hook func(context.Context, int32, []*types.SavedSearchResult, bool) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32652,20 +34548,20 @@ func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) PushHook(hook func(contex
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) SetDefaultReturn(r0 []*types.SavedSearch, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
-		return r0, r1
+func (f *SavedSearchStoreRecordRunResultsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, []*types.SavedSearchResult, bool) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) PushReturn(r0 []*types.SavedSearch, r1 error) {
-	f.PushHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
-		return r0, r1
+func (f *SavedSearchStoreRecordRunResultsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, []*types.SavedSearchResult, bool) error {
+		return r0
 	})
 }
 
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) nextHook() func(context.Context, int32) ([]*types.SavedSearch, error) {
+func (f *SavedSearchStoreRecordRunResultsFunc) nextHook() func(context.Context, int32, []*types.SavedSearchResult, bool) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32678,85 +34574,86 @@ func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) nextHook() func(context.C
 	return hook
 }
 
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) appendCall(r0 SavedSearchStoreListSavedSearchesByOrgIDFuncCall) {
+func (f *SavedSearchStoreRecordRunResultsFunc) appendCall(r0 SavedSearchStoreRecordRunResultsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SavedSearchStoreListSavedSearchesByOrgIDFuncCall objects describing the
-// invocations of this function.
-func (f *SavedSearchStoreListSavedSearchesByOrgIDFunc) History() []SavedSearchStoreListSavedSearchesByOrgIDFuncCall {
+// History returns a sequence of SavedSearchStoreRecordRunResultsFuncCall
+// objects describing the invocations of this function.
+func (f *SavedSearchStoreRecordRunResultsFunc) History() []SavedSearchStoreRecordRunResultsFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreListSavedSearchesByOrgIDFuncCall, len(f.history))
+	history := make([]SavedSearchStoreRecordRunResultsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreListSavedSearchesByOrgIDFuncCall is an object that
-// describes an invocation of method ListSavedSearchesByOrgID on an instance
-// of MockSavedSearchStore.
-type SavedSearchStoreListSavedSearchesByOrgIDFuncCall struct {
+// SavedSearchStoreRecordRunResultsFuncCall is an object that describes an
+// invocation of method RecordRunResults on an instance of
+// MockSavedSearchStore.
+type SavedSearchStoreRecordRunResultsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int32
-	// Result0 is the value of the 1st result returned from this method
+	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Result0 []*types.SavedSearch
-	// Result1 is the value of the 2nd result returned from this method
+	Arg2 []*types.SavedSearchResult
+	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Result1 error
+	Arg3 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreListSavedSearchesByOrgIDFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c SavedSearchStoreRecordRunResultsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreListSavedSearchesByOrgIDFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c SavedSearchStoreRecordRunResultsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// SavedSearchStoreListSavedSearchesByUserIDFunc describes the behavior when
-// the ListSavedSearchesByUserID method of the parent MockSavedSearchStore
-// instance is invoked.
-type SavedSearchStoreListSavedSearchesByUserIDFunc struct {
-	defaultHook func(context.Context, int32) ([]*types.SavedSearch, error)
-	hooks       []func(context.Context, int32) ([]*types.SavedSearch, error)
-	history     []SavedSearchStoreListSavedSearchesByUserIDFuncCall
+// SavedSearchStoreResetResultsFunc describes the behavior when the
+// ResetResults method of the parent MockSavedSearchStore instance is
+// invoked.
+type SavedSearchStoreResetResultsFunc struct {
+	defaultHook func(context.Context, int32) error
+	hooks       []func(context.Context, int32) error
+	history     []SavedSearchStoreResetResultsFuncCall
 	mutex       sync.Mutex
 }
 
-// ListSavedSearchesByUserID delegates to the next hook function in the
-// queue and stores the parameter and result values of this invocation.
-func (m *MockSavedSearchStore) ListSavedSearchesByUserID(v0 context.Context, v1 int32) ([]*types.SavedSearch, error) {
-	r0, r1 := m.ListSavedSearchesByUserIDFunc.nextHook()(v0, v1)
-	m.ListSavedSearchesByUserIDFunc.appendCall(SavedSearchStoreListSavedSearchesByUserIDFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// ResetResults delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) ResetResults(v0 context.Context, v1 int32) error {
+	r0 := m.ResetResultsFunc.nextHook()(v0, v1)
+	m.ResetResultsFunc.appendCall(SavedSearchStoreResetResultsFuncCall{v0, v1, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the
-// ListSavedSearchesByUserID method of the parent MockSavedSearchStore
-// instance is invoked and the hook queue is empty.
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) SetDefaultHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
+// SetDefaultHook sets function that is called when the ResetResults method
+// of the parent MockSavedSearchStore instance is invoked and the hook queue
+// is empty.
+func (f *SavedSearchStoreResetResultsFunc) SetDefaultHook(hook func(context.Context, int32) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListSavedSearchesByUserID method of the parent MockSavedSearchStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) PushHook(hook func(context.Context, int32) ([]*types.SavedSearch, error)) {
+// ResetResults method of the parent MockSavedSearchStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SavedSearchStoreResetResultsFunc) PushHook(hook func(context.Context, int32) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -32764,20 +34661,20 @@ func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) PushHook(hook func(conte
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) SetDefaultReturn(r0 []*types.SavedSearch, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
-		return r0, r1
+func (f *SavedSearchStoreResetResultsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) PushReturn(r0 []*types.SavedSearch, r1 error) {
-	f.PushHook(func(context.Context, int32) ([]*types.SavedSearch, error) {
-		return r0, r1
+func (f *SavedSearchStoreResetResultsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32) error {
+		return r0
 	})
 }
 
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) nextHook() func(context.Context, int32) ([]*types.SavedSearch, error) {
+func (f *SavedSearchStoreResetResultsFunc) nextHook() func(context.Context, int32) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -32790,28 +34687,26 @@ func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) nextHook() func(context.
 	return hook
 }
 
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) appendCall(r0 SavedSearchStoreListSavedSearchesByUserIDFuncCall) {
+func (f *SavedSearchStoreResetResultsFunc) appendCall(r0 SavedSearchStoreResetResultsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// SavedSearchStoreListSavedSearchesByUserIDFuncCall objects describing the
-// invocations of this function.
-func (f *SavedSearchStoreListSavedSearchesByUserIDFunc) History() []SavedSearchStoreListSavedSearchesByUserIDFuncCall {
+// History returns a sequence of SavedSearchStoreResetResultsFuncCall
+// objects describing the invocations of this function.
+func (f *SavedSearchStoreResetResultsFunc) History() []SavedSearchStoreResetResultsFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreListSavedSearchesByUserIDFuncCall, len(f.history))
+	history := make([]SavedSearchStoreResetResultsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreListSavedSearchesByUserIDFuncCall is an object that
-// describes an invocation of method ListSavedSearchesByUserID on an
-// instance of MockSavedSearchStore.
-type SavedSearchStoreListSavedSearchesByUserIDFuncCall struct {
+// SavedSearchStoreResetResultsFuncCall is an object that describes an
+// invocation of method ResetResults on an instance of MockSavedSearchStore.
+type SavedSearchStoreResetResultsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -32820,22 +34715,19 @@ type SavedSearchStoreListSavedSearchesByUserIDFuncCall struct {
 	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*types.SavedSearch
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreListSavedSearchesByUserIDFuncCall) Args() []interface{} {
+func (c SavedSearchStoreResetResultsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreListSavedSearchesByUserIDFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c SavedSearchStoreResetResultsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
 // SavedSearchStoreTransactFunc describes the behavior when the Transact
@@ -33051,6 +34943,119 @@ func (c SavedSearchStoreUpdateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// SavedSearchStoreUpsertScheduleFunc describes the behavior when the
+// UpsertSchedule method of the parent MockSavedSearchStore instance is
+// invoked.
+type SavedSearchStoreUpsertScheduleFunc struct {
+	defaultHook func(context.Context, int32, int32) (*types.SavedSearchSchedule, error)
+	hooks       []func(context.Context, int32, int32) (*types.SavedSearchSchedule, error)
+	history     []SavedSearchStoreUpsertScheduleFuncCall
+	mutex       sync.Mutex
+}
+
+// UpsertSchedule delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) UpsertSchedule(v0 context.Context, v1 int32, v2 int32) (*types.SavedSearchSchedule, error) {
+	r0, r1 := m.UpsertScheduleFunc.nextHook()(v0, v1, v2)
+	m.UpsertScheduleFunc.appendCall(SavedSearchStoreUpsertScheduleFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UpsertSchedule
+// method of the parent MockSavedSearchStore instance is invoked and the
+// hook queue is empty.
+func (f *SavedSearchStoreUpsertScheduleFunc) SetDefaultHook(hook func(context.Context, int32, int32) (*types.SavedSearchSchedule, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpsertSchedule method of the parent MockSavedSearchStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SavedSearchStoreUpsertScheduleFunc) PushHook(hook func(context.Context, int32, int32) (*types.SavedSearchSchedule, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreUpsertScheduleFunc) SetDefaultReturn(r0 *types.SavedSearchSchedule, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, int32) (*types.SavedSearchSchedule, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreUpsertScheduleFunc) PushReturn(r0 *types.SavedSearchSchedule, r1 error) {
+	f.PushHook(func(context.Context, int32, int32) (*types.SavedSearchSchedule, error) {
+		return r0, r1
+	})
+}
+
+func (f *SavedSearchStoreUpsertScheduleFunc) nextHook() func(context.Context, int32, int32) (*types.SavedSearchSchedule, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreUpsertScheduleFunc) appendCall(r0 SavedSearchStoreUpsertScheduleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SavedSearchStoreUpsertScheduleFuncCall
+// objects describing the invocations of this function.
+func (f *SavedSearchStoreUpsertScheduleFunc) History() []SavedSearchStoreUpsertScheduleFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreUpsertScheduleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreUpsertScheduleFuncCall is an object that describes an
+// invocation of method UpsertSchedule on an instance of
+// MockSavedSearchStore.
+type SavedSearchStoreUpsertScheduleFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.SavedSearchSchedule
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreUpsertScheduleFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreUpsertScheduleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // SavedSearchStoreWithFunc describes the behavior when the With method of
 // the parent MockSavedSearchStore instance is invoked.
 type SavedSearchStoreWithFunc struct {
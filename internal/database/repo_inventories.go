@@ -0,0 +1,178 @@
+package database
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// RepoInventoryStore stores the language statistics and file counts of the
+// default branch of repositories. They back the repo:has.language() and
+// repo:has.size() search predicates.
+type RepoInventoryStore interface {
+	basestore.ShareableStore
+	Transact(context.Context) (RepoInventoryStore, error)
+	With(basestore.ShareableStore) RepoInventoryStore
+	Get(ctx context.Context, repoID api.RepoID) (*types.RepoInventory, error)
+	ListStale(ctx context.Context, limit int) ([]types.MinimalRepo, error)
+	Upsert(ctx context.Context, inv *types.RepoInventory) error
+	MarkFailed(ctx context.Context, repoID api.RepoID) error
+}
+
+type repoInventoryStore struct {
+	*basestore.Store
+}
+
+var _ RepoInventoryStore = (*repoInventoryStore)(nil)
+
+// RepoInventoriesWith instantiates and returns a new RepoInventoryStore using the other store handle.
+func RepoInventoriesWith(other basestore.ShareableStore) RepoInventoryStore {
+	return &repoInventoryStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *repoInventoryStore) Transact(ctx context.Context) (RepoInventoryStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &repoInventoryStore{Store: txBase}, err
+}
+
+func (s *repoInventoryStore) With(other basestore.ShareableStore) RepoInventoryStore {
+	return &repoInventoryStore{Store: s.Store.With(other)}
+}
+
+// Get returns the inventory of the repository with the given ID, or nil if it
+// has not been computed yet.
+func (s *repoInventoryStore) Get(ctx context.Context, repoID api.RepoID) (*types.RepoInventory, error) {
+	inv, ok, err := basestore.NewFirstScanner(scanRepoInventory)(s.Query(ctx, sqlf.Sprintf(getRepoInventoryFmtstr, repoID)))
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	inv.Languages, err = basestore.NewSliceScanner(scanRepoLanguage)(s.Query(ctx, sqlf.Sprintf(listRepoLanguagesFmtstr, repoID)))
+	if err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+const getRepoInventoryFmtstr = `
+SELECT repo_id, commit_id, file_count, total_bytes, updated_at
+FROM repo_inventories
+WHERE repo_id = %s
+`
+
+const listRepoLanguagesFmtstr = `
+SELECT language, total_bytes, total_lines, total_files
+FROM repo_languages
+WHERE repo_id = %s
+ORDER BY total_bytes DESC, language
+`
+
+// ListStale returns at most limit cloned repositories whose inventory has not
+// been computed yet or has changed since it was computed. Repositories without
+// an inventory are returned first, followed by the repositories whose
+// inventory is the oldest.
+func (s *repoInventoryStore) ListStale(ctx context.Context, limit int) ([]types.MinimalRepo, error) {
+	return basestore.NewSliceScanner(func(sc dbutil.Scanner) (r types.MinimalRepo, err error) {
+		err = sc.Scan(&r.ID, &r.Name)
+		return r, err
+	})(s.Query(ctx, sqlf.Sprintf(listStaleRepoInventoriesFmtstr, limit)))
+}
+
+const listStaleRepoInventoriesFmtstr = `
+SELECT repo.id, repo.name
+FROM repo
+JOIN gitserver_repos gr ON gr.repo_id = repo.id
+LEFT JOIN repo_inventories ri ON ri.repo_id = repo.id
+WHERE
+	repo.deleted_at IS NULL AND
+	repo.blocked IS NULL AND
+	gr.clone_status = 'cloned' AND
+	(ri.repo_id IS NULL OR ri.updated_at < gr.last_changed)
+ORDER BY ri.updated_at NULLS FIRST, repo.id
+LIMIT %s
+`
+
+// Upsert replaces the inventory of a repository and its languages.
+func (s *repoInventoryStore) Upsert(ctx context.Context, inv *types.RepoInventory) (err error) {
+	var (
+		names      = make([]string, 0, len(inv.Languages))
+		totalBytes = make([]int64, 0, len(inv.Languages))
+		totalLines = make([]int64, 0, len(inv.Languages))
+		totalFiles = make([]int64, 0, len(inv.Languages))
+	)
+	for _, l := range inv.Languages {
+		names = append(names, l.Name)
+		totalBytes = append(totalBytes, l.TotalBytes)
+		totalLines = append(totalLines, l.TotalLines)
+		totalFiles = append(totalFiles, l.TotalFiles)
+	}
+
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(upsertRepoInventoryFmtstr, inv.RepoID, inv.CommitID, inv.FileCount, inv.TotalBytes)); err != nil {
+		return err
+	}
+	if err := tx.Exec(ctx, sqlf.Sprintf(`DELETE FROM repo_languages WHERE repo_id = %s`, inv.RepoID)); err != nil {
+		return err
+	}
+	return tx.Exec(ctx, sqlf.Sprintf(
+		insertRepoLanguagesFmtstr,
+		inv.RepoID,
+		pq.Array(names),
+		pq.Array(totalBytes),
+		pq.Array(totalLines),
+		pq.Array(totalFiles),
+	))
+}
+
+const upsertRepoInventoryFmtstr = `
+INSERT INTO repo_inventories (repo_id, commit_id, file_count, total_bytes, updated_at)
+VALUES (%s, %s, %s, %s, now())
+ON CONFLICT (repo_id) DO UPDATE SET
+	commit_id = EXCLUDED.commit_id,
+	file_count = EXCLUDED.file_count,
+	total_bytes = EXCLUDED.total_bytes,
+	updated_at = EXCLUDED.updated_at
+`
+
+const insertRepoLanguagesFmtstr = `
+INSERT INTO repo_languages (repo_id, language, total_bytes, total_lines, total_files)
+SELECT %s, l.language, l.total_bytes, l.total_lines, l.total_files
+FROM unnest(%s::text[], %s::bigint[], %s::bigint[], %s::bigint[])
+	AS l(language, total_bytes, total_lines, total_files)
+`
+
+// MarkFailed records that computing the inventory of a repository failed, so
+// that it is not returned by ListStale again until the repository changes. The
+// previous inventory of the repository, if any, is kept.
+func (s *repoInventoryStore) MarkFailed(ctx context.Context, repoID api.RepoID) error {
+	return s.Exec(ctx, sqlf.Sprintf(markRepoInventoryFailedFmtstr, repoID))
+}
+
+const markRepoInventoryFailedFmtstr = `
+INSERT INTO repo_inventories (repo_id, commit_id, updated_at)
+VALUES (%s, '', now())
+ON CONFLICT (repo_id) DO UPDATE SET updated_at = EXCLUDED.updated_at
+`
+
+func scanRepoInventory(sc dbutil.Scanner) (*types.RepoInventory, error) {
+	var inv types.RepoInventory
+	err := sc.Scan(&inv.RepoID, &inv.CommitID, &inv.FileCount, &inv.TotalBytes, &inv.UpdatedAt)
+	return &inv, err
+}
+
+func scanRepoLanguage(sc dbutil.Scanner) (types.RepoLanguage, error) {
+	var l types.RepoLanguage
+	err := sc.Scan(&l.Name, &l.TotalBytes, &l.TotalLines, &l.TotalFiles)
+	return l, err
+}
@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRepoInventories(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := actor.WithInternalActor(context.Background())
+
+	repo1 := mustCreate(ctx, t, db, &types.Repo{Name: "repo-1"})
+	repo2 := mustCreate(ctx, t, db, &types.Repo{Name: "repo-2"})
+	repo3 := mustCreate(ctx, t, db, &types.Repo{Name: "repo-3"})
+	setGitserverRepoCloneStatus(t, db, repo1.Name, types.CloneStatusCloned)
+	setGitserverRepoCloneStatus(t, db, repo2.Name, types.CloneStatusCloned)
+	require.NoError(t, db.GitserverRepos().SetRepoSize(ctx, repo1.Name, 10<<20, ""))
+	require.NoError(t, db.GitserverRepos().SetRepoSize(ctx, repo2.Name, 1<<30, ""))
+
+	store := db.RepoInventories()
+
+	inv, err := store.Get(ctx, repo1.ID)
+	require.NoError(t, err)
+	require.Nil(t, inv)
+
+	// Only cloned repositories without an inventory are stale
+	stale, err := store.ListStale(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, []types.MinimalRepo{{ID: repo1.ID, Name: repo1.Name}, {ID: repo2.ID, Name: repo2.Name}}, stale)
+
+	require.NoError(t, store.Upsert(ctx, &types.RepoInventory{
+		RepoID:     repo1.ID,
+		CommitID:   "deadbeef",
+		FileCount:  40,
+		TotalBytes: 1000,
+		Languages: []types.RepoLanguage{
+			{Name: "Go", TotalBytes: 800, TotalLines: 80, TotalFiles: 30},
+			{Name: "Markdown", TotalBytes: 200, TotalLines: 20, TotalFiles: 10},
+		},
+	}))
+	require.NoError(t, store.Upsert(ctx, &types.RepoInventory{
+		RepoID:     repo2.ID,
+		CommitID:   "cafebabe",
+		FileCount:  5,
+		TotalBytes: 1000,
+		Languages: []types.RepoLanguage{
+			{Name: "Go", TotalBytes: 100, TotalLines: 10, TotalFiles: 1},
+			{Name: "TypeScript", TotalBytes: 900, TotalLines: 90, TotalFiles: 4},
+		},
+	}))
+
+	inv, err = store.Get(ctx, repo1.ID)
+	require.NoError(t, err)
+	require.Equal(t, "deadbeef", string(inv.CommitID))
+	require.Equal(t, int64(40), inv.FileCount)
+	require.Equal(t, []types.RepoLanguage{
+		{Name: "Go", TotalBytes: 800, TotalLines: 80, TotalFiles: 30},
+		{Name: "Markdown", TotalBytes: 200, TotalLines: 20, TotalFiles: 10},
+	}, inv.Languages)
+
+	stale, err = store.ListStale(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, stale)
+
+	// A failed update keeps the previous inventory
+	require.NoError(t, store.MarkFailed(ctx, repo1.ID))
+	inv, err = store.Get(ctx, repo1.ID)
+	require.NoError(t, err)
+	require.Equal(t, "deadbeef", string(inv.CommitID))
+	require.Len(t, inv.Languages, 2)
+
+	t.Run("ListFilters", func(t *testing.T) {
+		tests := []struct {
+			name string
+			opt  ReposListOptions
+			want []*types.Repo
+		}{
+			{"has language", ReposListOptions{LanguageFilters: []RepoLanguageFilter{{Language: "go"}}}, []*types.Repo{repo1, repo2}},
+			{"has language share", ReposListOptions{LanguageFilters: []RepoLanguageFilter{{Language: "Go", Comparator: ">", Percent: 50}}}, []*types.Repo{repo1}},
+			{"has language share at most", ReposListOptions{LanguageFilters: []RepoLanguageFilter{{Language: "Go", Comparator: "<=", Percent: 10}}}, []*types.Repo{repo2}},
+			{"has not language", ReposListOptions{LanguageFilters: []RepoLanguageFilter{{Language: "TypeScript", Negated: true}}}, []*types.Repo{repo1, repo3}},
+			{"size", ReposListOptions{SizeFilters: []RepoSizeFilter{{Comparator: ">", Value: 100 << 20}}}, []*types.Repo{repo2}},
+			{"negated size", ReposListOptions{SizeFilters: []RepoSizeFilter{{Comparator: ">", Value: 100 << 20, Negated: true}}}, []*types.Repo{repo1, repo3}},
+			{"file count", ReposListOptions{SizeFilters: []RepoSizeFilter{{Comparator: ">=", Value: 10, Files: true}}}, []*types.Repo{repo1}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				repos, err := db.Repos().List(ctx, test.opt)
+				require.NoError(t, err)
+				assertJSONEqual(t, test.want, repos)
+			})
+		}
+
+		_, err := db.Repos().List(ctx, ReposListOptions{SizeFilters: []RepoSizeFilter{{Comparator: "; DROP TABLE repo"}}})
+		require.Error(t, err)
+	})
+}
@@ -27,6 +27,11 @@ type repoKVPStore struct {
 
 var _ RepoKVPStore = (*repoKVPStore)(nil)
 
+// RepoKVPsWith instantiates and returns a new RepoKVPStore using the other store handle.
+func RepoKVPsWith(other basestore.ShareableStore) RepoKVPStore {
+	return &repoKVPStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
 func (s *repoKVPStore) Transact(ctx context.Context) (RepoKVPStore, error) {
 	txBase, err := s.Store.Transact(ctx)
 	return &repoKVPStore{Store: txBase}, err
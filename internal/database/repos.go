@@ -29,9 +29,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
@@ -542,6 +544,10 @@ func scanRepo(logger log.Logger, rows *sql.Rows, r *types.Repo) (err error) {
 		r.Metadata = new(bitbucketcloud.Repo)
 	case extsvc.TypeAWSCodeCommit:
 		r.Metadata = new(awscodecommit.Repository)
+	case extsvc.TypeAzureDevOps:
+		r.Metadata = new(azuredevops.Repository)
+	case extsvc.TypeGitea:
+		r.Metadata = new(gitea.Repository)
 	case extsvc.TypeGitolite:
 		r.Metadata = new(gitolite.Repo)
 	case extsvc.TypePerforce:
@@ -602,6 +608,14 @@ type ReposListOptions struct {
 	// A set of filters to select only repos with a given set of key-value pairs.
 	KVPFilters []RepoKVPFilter
 
+	// A set of filters to select only repos by the languages of their code,
+	// as recorded in their inventory.
+	LanguageFilters []RepoLanguageFilter
+
+	// A set of filters to select only repos by their size on disk or the
+	// number of files recorded in their inventory.
+	SizeFilters []RepoSizeFilter
+
 	// CaseSensitivePatterns determines if IncludePatterns and ExcludePattern are treated
 	// with case sensitivity or not.
 	CaseSensitivePatterns bool
@@ -735,6 +749,35 @@ type RepoKVPFilter struct {
 	Negated bool
 }
 
+type RepoLanguageFilter struct {
+	// Language is the name of the language, compared case-insensitively.
+	Language string
+	// Comparator is one of >, >=, < or <=. If it is set, the share of the
+	// language in the code of the repository in percent of bytes is compared
+	// to Percent. Otherwise any repo with code in the language matches.
+	Comparator string
+	Percent    float64
+	// If negated is true, this filter will select only repos
+	// that do _not_ match the language
+	Negated bool
+}
+
+type RepoSizeFilter struct {
+	// Comparator is one of >, >=, < or <=.
+	Comparator string
+	// Value is compared to the size of the repository on disk in bytes or,
+	// if Files is true, to the number of files in its inventory.
+	Value int64
+	Files bool
+	// If negated is true, this filter will select only repos
+	// that do _not_ match the size
+	Negated bool
+}
+
+// repoFilterComparators are the comparators allowed in RepoLanguageFilter and
+// RepoSizeFilter. They are interpolated into queries verbatim.
+var repoFilterComparators = map[string]struct{}{">": {}, ">=": {}, "<": {}, "<=": {}}
+
 type RepoListOrderBy []RepoListSort
 
 func (r RepoListOrderBy) SQL() *sqlf.Query {
@@ -1104,6 +1147,48 @@ func (s *repoStore) listSQL(ctx context.Context, tr *trace.Trace, opt ReposListO
 		where = append(where, sqlf.Join(ands, "AND"))
 	}
 
+	if len(opt.LanguageFilters) > 0 {
+		var ands []*sqlf.Query
+		for _, filter := range opt.LanguageFilters {
+			var q *sqlf.Query
+			if filter.Comparator == "" {
+				q = sqlf.Sprintf("EXISTS (SELECT 1 FROM repo_languages WHERE repo_id = repo.id AND lower(language) = lower(%s))", filter.Language)
+			} else {
+				if _, ok := repoFilterComparators[filter.Comparator]; !ok {
+					return nil, errors.Errorf("invalid language filter comparator %q", filter.Comparator)
+				}
+				q = sqlf.Sprintf(
+					"EXISTS (SELECT 1 FROM repo_languages rl JOIN repo_inventories ri ON ri.repo_id = rl.repo_id WHERE rl.repo_id = repo.id AND lower(rl.language) = lower(%s) AND rl.total_bytes * 100 "+filter.Comparator+" %s * ri.total_bytes)",
+					filter.Language,
+					filter.Percent,
+				)
+			}
+			if filter.Negated {
+				q = sqlf.Sprintf("NOT %s", q)
+			}
+			ands = append(ands, q)
+		}
+		where = append(where, sqlf.Join(ands, "AND"))
+	}
+
+	if len(opt.SizeFilters) > 0 {
+		var ands []*sqlf.Query
+		for _, filter := range opt.SizeFilters {
+			if _, ok := repoFilterComparators[filter.Comparator]; !ok {
+				return nil, errors.Errorf("invalid size filter comparator %q", filter.Comparator)
+			}
+			q := "EXISTS (SELECT 1 FROM gitserver_repos WHERE repo_id = repo.id AND repo_size_bytes " + filter.Comparator + " %s)"
+			if filter.Files {
+				q = "EXISTS (SELECT 1 FROM repo_inventories WHERE repo_id = repo.id AND file_count " + filter.Comparator + " %s)"
+			}
+			if filter.Negated {
+				q = "NOT " + q
+			}
+			ands = append(ands, sqlf.Sprintf(q, filter.Value))
+		}
+		where = append(where, sqlf.Join(ands, "AND"))
+	}
+
 	baseConds := sqlf.Sprintf("TRUE")
 	if !opt.IncludeDeleted {
 		baseConds = sqlf.Sprintf("repo.deleted_at IS NULL")
@@ -0,0 +1,282 @@
+package database
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// GetSchedule returns the schedule of the saved search with the given ID, or
+// nil if the saved search is not run in the background.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure this response
+// only makes it to users with proper permissions to access the saved search.
+func (s *savedSearchStore) GetSchedule(ctx context.Context, savedSearchID int32) (*types.SavedSearchSchedule, error) {
+	schedule, ok, err := basestore.NewFirstScanner(scanSavedSearchSchedule)(s.Query(ctx, sqlf.Sprintf(getSavedSearchScheduleFmtstr, savedSearchID)))
+	if err != nil || !ok {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+const getSavedSearchScheduleFmtstr = `
+SELECT saved_search_id, interval_minutes, next_run_at, last_run_at, last_run_error
+FROM saved_search_schedules
+WHERE saved_search_id = %s
+`
+
+// UpsertSchedule runs the saved search with the given ID every
+// intervalMinutes minutes. The saved search is run as soon as possible after
+// the schedule is created or changed.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure the user has
+// proper permissions to schedule the saved search.
+func (s *savedSearchStore) UpsertSchedule(ctx context.Context, savedSearchID, intervalMinutes int32) (*types.SavedSearchSchedule, error) {
+	schedule, _, err := basestore.NewFirstScanner(scanSavedSearchSchedule)(s.Query(ctx, sqlf.Sprintf(upsertSavedSearchScheduleFmtstr, savedSearchID, intervalMinutes)))
+	return schedule, err
+}
+
+const upsertSavedSearchScheduleFmtstr = `
+INSERT INTO saved_search_schedules (saved_search_id, interval_minutes)
+VALUES (%s, %s)
+ON CONFLICT (saved_search_id) DO UPDATE SET
+	interval_minutes = EXCLUDED.interval_minutes,
+	next_run_at = now(),
+	updated_at = now()
+RETURNING saved_search_id, interval_minutes, next_run_at, last_run_at, last_run_error
+`
+
+// DeleteSchedule stops running the saved search with the given ID in the
+// background and deletes the results of its previous runs.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure the user has
+// proper permissions to unschedule the saved search.
+func (s *savedSearchStore) DeleteSchedule(ctx context.Context, savedSearchID int32) (err error) {
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(`DELETE FROM saved_search_results WHERE saved_search_id = %s`, savedSearchID)); err != nil {
+		return err
+	}
+	return tx.Exec(ctx, sqlf.Sprintf(`DELETE FROM saved_search_schedules WHERE saved_search_id = %s`, savedSearchID))
+}
+
+// ResetResults deletes the results of the previous runs of the saved search
+// with the given ID, so that the next run starts from scratch. This must be
+// called when the query of a scheduled saved search changes, as the results of
+// different queries cannot be compared.
+func (s *savedSearchStore) ResetResults(ctx context.Context, savedSearchID int32) (err error) {
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(`DELETE FROM saved_search_results WHERE saved_search_id = %s`, savedSearchID)); err != nil {
+		return err
+	}
+	return tx.Exec(ctx, sqlf.Sprintf(resetSavedSearchScheduleFmtstr, savedSearchID))
+}
+
+const resetSavedSearchScheduleFmtstr = `
+UPDATE saved_search_schedules
+SET last_run_at = NULL, last_run_error = NULL, next_run_at = now(), updated_at = now()
+WHERE saved_search_id = %s
+`
+
+// ListDueForRun returns at most limit scheduled saved searches whose next run
+// is due. Only saved searches owned by a user are returned, as they are run on
+// behalf of their owner.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure the returned
+// saved searches are only run with the permissions of their owner.
+func (s *savedSearchStore) ListDueForRun(ctx context.Context, limit int) ([]*types.SavedSearch, error) {
+	return basestore.NewSliceScanner(scanSavedSearch)(s.Query(ctx, sqlf.Sprintf(listDueSavedSearchesFmtstr, limit)))
+}
+
+const listDueSavedSearchesFmtstr = `
+SELECT
+	s.id,
+	s.description,
+	s.query,
+	s.notify_owner,
+	s.notify_slack,
+	s.user_id,
+	s.org_id,
+	s.slack_webhook_url
+FROM saved_searches s
+JOIN saved_search_schedules ss ON ss.saved_search_id = s.id
+WHERE ss.next_run_at <= now() AND s.user_id IS NOT NULL
+ORDER BY ss.next_run_at
+LIMIT %s
+`
+
+// RecordRunResults stores the results of a successful run of the saved search
+// with the given ID and schedules its next run.
+//
+// Results that were not returned by the previous run are marked as first seen
+// by this run. If complete is true, the results of the previous run that were
+// not returned by this run are marked as removed by it. Callers must set
+// complete to false when the search was limited or skipped repositories, as
+// the results that were not returned may still exist.
+func (s *savedSearchStore) RecordRunResults(ctx context.Context, savedSearchID int32, results []*types.SavedSearchResult, complete bool) (err error) {
+	var (
+		keys        = make([]string, 0, len(results))
+		resultTypes = make([]string, 0, len(results))
+		repoIDs     = make([]int32, 0, len(results))
+		paths       = make([]string, 0, len(results))
+		lineNumbers = make([]int32, 0, len(results))
+		labels      = make([]string, 0, len(results))
+	)
+	for _, r := range results {
+		keys = append(keys, r.Key)
+		resultTypes = append(resultTypes, string(r.Type))
+		repoIDs = append(repoIDs, int32(r.RepoID))
+		paths = append(paths, r.Path)
+		lineNumbers = append(lineNumbers, r.LineNumber)
+		labels = append(labels, r.Label)
+	}
+
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// now() is the start time of the transaction, so all the queries below
+	// agree on the time of the run.
+	if err := tx.Exec(ctx, sqlf.Sprintf(`DELETE FROM saved_search_results WHERE saved_search_id = %s AND removed_at IS NOT NULL`, savedSearchID)); err != nil {
+		return err
+	}
+	if err := tx.Exec(ctx, sqlf.Sprintf(
+		insertSavedSearchResultsFmtstr,
+		savedSearchID,
+		pq.Array(keys),
+		pq.Array(resultTypes),
+		pq.Array(repoIDs),
+		pq.Array(paths),
+		pq.Array(lineNumbers),
+		pq.Array(labels),
+	)); err != nil {
+		return err
+	}
+	if complete {
+		if err := tx.Exec(ctx, sqlf.Sprintf(removeSavedSearchResultsFmtstr, savedSearchID, pq.Array(keys))); err != nil {
+			return err
+		}
+	}
+	return tx.Exec(ctx, sqlf.Sprintf(recordSavedSearchRunFmtstr, savedSearchID))
+}
+
+const insertSavedSearchResultsFmtstr = `
+INSERT INTO saved_search_results (saved_search_id, key, result_type, repo_id, path, line_number, label, first_seen_at)
+SELECT %s, r.key, r.result_type, r.repo_id, NULLIF(r.path, ''), NULLIF(r.line_number, 0), r.label, now()
+FROM unnest(%s::text[], %s::text[], %s::integer[], %s::text[], %s::integer[], %s::text[])
+	AS r(key, result_type, repo_id, path, line_number, label)
+-- Skip results of repositories that were deleted since the search ran
+WHERE EXISTS (SELECT 1 FROM repo WHERE repo.id = r.repo_id)
+ON CONFLICT (saved_search_id, key) DO NOTHING
+`
+
+const removeSavedSearchResultsFmtstr = `
+UPDATE saved_search_results
+SET removed_at = now()
+WHERE saved_search_id = %s AND removed_at IS NULL AND NOT key = ANY(%s)
+`
+
+const recordSavedSearchRunFmtstr = `
+UPDATE saved_search_schedules
+SET
+	last_run_at = now(),
+	last_run_error = NULL,
+	next_run_at = now() + interval_minutes * interval '1 minute',
+	updated_at = now()
+WHERE saved_search_id = %s
+`
+
+// RecordRunError records that the last run of the saved search with the given
+// ID failed and schedules its next run. The results of the last successful run
+// are kept.
+func (s *savedSearchStore) RecordRunError(ctx context.Context, savedSearchID int32, runErr string) error {
+	return s.Exec(ctx, sqlf.Sprintf(recordSavedSearchRunErrorFmtstr, runErr, savedSearchID))
+}
+
+const recordSavedSearchRunErrorFmtstr = `
+UPDATE saved_search_schedules
+SET
+	last_run_error = %s,
+	next_run_at = now() + interval_minutes * interval '1 minute',
+	updated_at = now()
+WHERE saved_search_id = %s
+`
+
+// ListResultChanges returns at most limit results that changed in the last
+// successful run of the saved search with the given ID. If removed is false,
+// the results first seen by the last run are returned, otherwise the results
+// that the last run no longer returned.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure this response
+// only makes it to users with proper permissions to access the saved search
+// and the repositories of the results.
+func (s *savedSearchStore) ListResultChanges(ctx context.Context, savedSearchID int32, removed bool, limit int) ([]*types.SavedSearchResult, error) {
+	cond := sqlf.Sprintf("r.first_seen_at = ss.last_run_at AND r.removed_at IS NULL")
+	if removed {
+		cond = sqlf.Sprintf("r.removed_at = ss.last_run_at")
+	}
+	return basestore.NewSliceScanner(scanSavedSearchResult)(s.Query(ctx, sqlf.Sprintf(listSavedSearchResultChangesFmtstr, savedSearchID, cond, limit)))
+}
+
+const listSavedSearchResultChangesFmtstr = `
+SELECT r.key, r.result_type, r.repo_id, COALESCE(r.path, ''), COALESCE(r.line_number, 0), r.label, r.first_seen_at, r.removed_at
+FROM saved_search_results r
+JOIN saved_search_schedules ss ON ss.saved_search_id = r.saved_search_id
+WHERE r.saved_search_id = %s AND %s
+ORDER BY r.repo_id, r.path NULLS FIRST, r.line_number NULLS FIRST, r.key
+LIMIT %s
+`
+
+func scanSavedSearch(sc dbutil.Scanner) (*types.SavedSearch, error) {
+	var ss types.SavedSearch
+	err := sc.Scan(&ss.ID, &ss.Description, &ss.Query, &ss.Notify, &ss.NotifySlack, &ss.UserID, &ss.OrgID, &ss.SlackWebhookURL)
+	return &ss, err
+}
+
+func scanSavedSearchSchedule(sc dbutil.Scanner) (*types.SavedSearchSchedule, error) {
+	var schedule types.SavedSearchSchedule
+	err := sc.Scan(
+		&schedule.SavedSearchID,
+		&schedule.IntervalMinutes,
+		&schedule.NextRunAt,
+		&schedule.LastRunAt,
+		&schedule.LastRunError,
+	)
+	return &schedule, err
+}
+
+func scanSavedSearchResult(sc dbutil.Scanner) (*types.SavedSearchResult, error) {
+	var r types.SavedSearchResult
+	err := sc.Scan(
+		&r.Key,
+		&r.Type,
+		&r.RepoID,
+		&r.Path,
+		&r.LineNumber,
+		&r.Label,
+		&r.FirstSeenAt,
+		&r.RemovedAt,
+	)
+	return &r, err
+}
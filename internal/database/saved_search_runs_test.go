@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSavedSearchRuns(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	user, err := db.Users().Create(ctx, NewUser{DisplayName: "test", Email: "test@test.com", Username: "test", Password: "test", EmailVerificationCode: "c2"})
+	require.NoError(t, err)
+	require.NoError(t, db.Repos().Create(ctx, &types.Repo{ID: 1, Name: "test1"}))
+
+	ss, err := db.SavedSearches().Create(ctx, &types.SavedSearch{
+		Query:       "test",
+		Description: "test",
+		UserID:      &user.ID,
+	})
+	require.NoError(t, err)
+
+	store := db.SavedSearches()
+
+	schedule, err := store.GetSchedule(ctx, ss.ID)
+	require.NoError(t, err)
+	require.Nil(t, schedule)
+
+	due, err := store.ListDueForRun(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, due)
+
+	schedule, err = store.UpsertSchedule(ctx, ss.ID, 60)
+	require.NoError(t, err)
+	require.Equal(t, int32(60), schedule.IntervalMinutes)
+	require.Nil(t, schedule.LastRunAt)
+
+	due, err = store.ListDueForRun(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, ss.ID, due[0].ID)
+
+	result := func(key string) *types.SavedSearchResult {
+		return &types.SavedSearchResult{Key: key, Type: types.SavedSearchResultTypePath, RepoID: 1, Path: key, Label: key}
+	}
+	changes := func(t *testing.T, removed bool) []string {
+		t.Helper()
+		results, err := store.ListResultChanges(ctx, ss.ID, removed, 10)
+		require.NoError(t, err)
+		keys := []string{}
+		for _, r := range results {
+			keys = append(keys, r.Key)
+		}
+		return keys
+	}
+
+	// All results of the first run are new
+	require.NoError(t, store.RecordRunResults(ctx, ss.ID, []*types.SavedSearchResult{result("a"), result("b")}, true))
+	require.Equal(t, []string{"a", "b"}, changes(t, false))
+	require.Equal(t, []string{}, changes(t, true))
+
+	// The next run is scheduled after the interval
+	due, err = store.ListDueForRun(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, due)
+
+	require.NoError(t, store.RecordRunResults(ctx, ss.ID, []*types.SavedSearchResult{result("b"), result("c")}, true))
+	require.Equal(t, []string{"c"}, changes(t, false))
+	require.Equal(t, []string{"a"}, changes(t, true))
+
+	// An incomplete run does not remove results
+	require.NoError(t, store.RecordRunResults(ctx, ss.ID, []*types.SavedSearchResult{result("c")}, false))
+	require.Equal(t, []string{}, changes(t, false))
+	require.Equal(t, []string{}, changes(t, true))
+
+	// A failed run keeps the changes of the last successful run
+	require.NoError(t, store.RecordRunError(ctx, ss.ID, "boom"))
+	schedule, err = store.GetSchedule(ctx, ss.ID)
+	require.NoError(t, err)
+	require.Equal(t, "boom", *schedule.LastRunError)
+	require.NotNil(t, schedule.LastRunAt)
+
+	// Removed results are new again when they come back
+	require.NoError(t, store.RecordRunResults(ctx, ss.ID, []*types.SavedSearchResult{result("a"), result("c")}, true))
+	require.Equal(t, []string{"a"}, changes(t, false))
+	require.Equal(t, []string{"b"}, changes(t, true))
+
+	// Resetting the results starts from scratch
+	require.NoError(t, store.ResetResults(ctx, ss.ID))
+	require.Equal(t, []string{}, changes(t, false))
+	due, err = store.ListDueForRun(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+
+	require.NoError(t, store.DeleteSchedule(ctx, ss.ID))
+	schedule, err = store.GetSchedule(ctx, ss.ID)
+	require.NoError(t, err)
+	require.Nil(t, schedule)
+}
@@ -17,13 +17,21 @@ import (
 type SavedSearchStore interface {
 	Create(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
 	Delete(context.Context, int32) error
+	DeleteSchedule(context.Context, int32) error
 	GetByID(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)
+	GetSchedule(context.Context, int32) (*types.SavedSearchSchedule, error)
 	IsEmpty(context.Context) (bool, error)
 	ListAll(context.Context) ([]api.SavedQuerySpecAndConfig, error)
+	ListDueForRun(ctx context.Context, limit int) ([]*types.SavedSearch, error)
+	ListResultChanges(ctx context.Context, savedSearchID int32, removed bool, limit int) ([]*types.SavedSearchResult, error)
 	ListSavedSearchesByOrgID(ctx context.Context, orgID int32) ([]*types.SavedSearch, error)
 	ListSavedSearchesByUserID(ctx context.Context, userID int32) ([]*types.SavedSearch, error)
+	RecordRunError(ctx context.Context, savedSearchID int32, runErr string) error
+	RecordRunResults(ctx context.Context, savedSearchID int32, results []*types.SavedSearchResult, complete bool) error
+	ResetResults(context.Context, int32) error
 	Transact(context.Context) (SavedSearchStore, error)
 	Update(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
+	UpsertSchedule(ctx context.Context, savedSearchID, intervalMinutes int32) (*types.SavedSearchSchedule, error)
 	With(basestore.ShareableStore) SavedSearchStore
 	basestore.ShareableStore
 }
@@ -0,0 +1,287 @@
+//nolint:bodyclose // Body is closed in Client.Do, but the response is still returned to provide access to the headers
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// apiVersion is the version of the Azure DevOps REST API used by the client.
+// 6.0 is supported by both Azure DevOps Services and Azure DevOps Server 2020
+// and later.
+const apiVersion = "6.0"
+
+// Client access Azure DevOps Services or Azure DevOps Server via the REST API.
+type Client struct {
+	// Config is the code host connection config for this client
+	Config *schema.AzureDevOpsConnection
+
+	// URL is the base URL of Azure DevOps.
+	URL *url.URL
+
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// RateLimit is the self-imposed rate limiter (since Azure DevOps throttles
+	// based on resource consumption rather than on request counts).
+	rateLimit *ratelimit.InstrumentedLimiter
+}
+
+// NewClient returns an authenticated Azure DevOps API client with
+// the provided configuration. If a nil httpClient is provided, http.DefaultClient
+// will be used.
+func NewClient(urn string, config *schema.AzureDevOpsConnection, httpClient httpcli.Doer) (*Client, error) {
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	// Azure DevOps Server instances are often hosted below a path prefix (e.g.
+	// /tfs), so we need a trailing slash for relative references to resolve
+	// below it.
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	return &Client{
+		Config:     config,
+		URL:        u,
+		httpClient: httpClient,
+		rateLimit:  ratelimit.DefaultRegistry.Get(urn),
+	}, nil
+}
+
+// ListRepositoriesByOrg returns all the Git repositories in the given
+// organization (or project collection on Azure DevOps Server) that are visible
+// to the authenticated user.
+func (c *Client) ListRepositoriesByOrg(ctx context.Context, org string) ([]*Repository, error) {
+	return c.listRepositories(ctx, url.PathEscape(org)+"/_apis/git/repositories")
+}
+
+// ListRepositoriesByProject returns all the Git repositories in the given
+// project of the given organization.
+func (c *Client) ListRepositoriesByProject(ctx context.Context, org, project string) ([]*Repository, error) {
+	return c.listRepositories(ctx, url.PathEscape(org)+"/"+url.PathEscape(project)+"/_apis/git/repositories")
+}
+
+// GetRepository returns the Git repository with the given name or ID in the
+// given project of the given organization.
+func (c *Client) GetRepository(ctx context.Context, org, project, nameOrID string) (*Repository, error) {
+	u := url.URL{
+		Path:     url.PathEscape(org) + "/" + url.PathEscape(project) + "/_apis/git/repositories/" + url.PathEscape(nameOrID),
+		RawQuery: url.Values{"api-version": []string{apiVersion}}.Encode(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo Repository
+	if _, err := c.do(ctx, req, &repo); err != nil {
+		return nil, err
+	}
+
+	return &repo, nil
+}
+
+// listResponse is the envelope the Azure DevOps API uses for list results.
+type listResponse[T any] struct {
+	Count int `json:"count"`
+	Value []T `json:"value"`
+}
+
+func (c *Client) listRepositories(ctx context.Context, urlPath string) ([]*Repository, error) {
+	// The repositories endpoints are not paginated, all results are returned
+	// in a single response.
+	u := url.URL{
+		Path:     urlPath,
+		RawQuery: url.Values{"api-version": []string{apiVersion}}.Encode(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp listResponse[*Repository]
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Value, nil
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) {
+	req.URL = c.URL.ResolveReference(req.URL)
+	req.Header.Set("Accept", "application/json")
+
+	// Personal access tokens are passed as the password of basic auth, the
+	// username is ignored by Azure DevOps Services but required by some Azure
+	// DevOps Server configurations.
+	req.SetBasicAuth(c.Config.Username, c.Config.Token)
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Azure DevOps answers requests with invalid credentials with a 203 and an
+	// HTML sign-in page instead of a 401, so we treat it as an error too.
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 || resp.StatusCode == http.StatusNonAuthoritativeInfo {
+		return nil, errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	return resp, json.Unmarshal(bs, result)
+}
+
+// Repository is a Git repository hosted on Azure DevOps.
+type Repository struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	URL           string  `json:"url"`
+	Project       Project `json:"project"`
+	DefaultBranch string  `json:"defaultBranch,omitempty"`
+	Size          int64   `json:"size"`
+	RemoteURL     string  `json:"remoteUrl"`
+	SSHURL        string  `json:"sshUrl"`
+	WebURL        string  `json:"webUrl"`
+	IsDisabled    bool    `json:"isDisabled"`
+	IsFork        bool    `json:"isFork"`
+}
+
+// IsPrivate returns true if the repository is only visible to members of its
+// organization.
+func (r *Repository) IsPrivate() bool {
+	return r.Project.Visibility != VisibilityPublic
+}
+
+// Path returns the path of the repository on its code host without the "_git"
+// segment, e.g. "org/project/repo" for a repository with remote URL
+// https://org@dev.azure.com/org/project/_git/repo.
+func (r *Repository) Path() (string, error) {
+	u, err := url.Parse(r.RemoteURL)
+	if err != nil {
+		return "", err
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	path := make([]string, 0, len(segments))
+	for _, s := range segments {
+		if s == "_git" {
+			continue
+		}
+		path = append(path, s)
+	}
+
+	return strings.Join(path, "/"), nil
+}
+
+// Project visibilities as returned by the API.
+const (
+	VisibilityPrivate = "private"
+	VisibilityPublic  = "public"
+)
+
+// Project is an Azure DevOps team project, which contains repositories.
+type Project struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	State      string `json:"state"`
+	Visibility string `json:"visibility"`
+}
+
+// Event types of service hooks we handle.
+const (
+	EventTypeGitPush = "git.push"
+)
+
+// Event is the envelope of a service hook notification. Resource holds the
+// event type specific payload, which can be decoded with ParseEvent.
+type Event struct {
+	ID          string          `json:"id"`
+	EventType   string          `json:"eventType"`
+	PublisherID string          `json:"publisherId"`
+	Resource    json.RawMessage `json:"resource"`
+}
+
+// PushEvent is the resource of a "git.push" service hook notification.
+type PushEvent struct {
+	PushID     int         `json:"pushId"`
+	Repository Repository  `json:"repository"`
+	RefUpdates []RefUpdate `json:"refUpdates"`
+}
+
+// RefUpdate describes a single ref updated by a push.
+type RefUpdate struct {
+	Name        string `json:"name"`
+	OldObjectID string `json:"oldObjectId"`
+	NewObjectID string `json:"newObjectId"`
+}
+
+// ParseEvent decodes the given service hook payload. It returns the event
+// type and the decoded resource, which is a *PushEvent for "git.push" events.
+func ParseEvent(payload []byte) (string, any, error) {
+	var e Event
+	if err := json.Unmarshal(payload, &e); err != nil {
+		return "", nil, err
+	}
+
+	switch e.EventType {
+	case EventTypeGitPush:
+		var push PushEvent
+		if err := json.Unmarshal(e.Resource, &push); err != nil {
+			return e.EventType, nil, errors.Wrapf(err, "decoding %q event resource", e.EventType)
+		}
+		return e.EventType, &push, nil
+	default:
+		return e.EventType, nil, errors.Newf("unsupported event type %q", e.EventType)
+	}
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Azure DevOps API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusNonAuthoritativeInfo
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,194 @@
+package azuredevops
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const testRepositories = `{
+  "count": 2,
+  "value": [
+    {
+      "id": "5febef5a-833d-4e14-b9c0-14cb638f91e6",
+      "name": "AnotherRepository",
+      "url": "https://dev.azure.com/fabrikam/_apis/git/repositories/5febef5a-833d-4e14-b9c0-14cb638f91e6",
+      "project": {
+        "id": "6ce954b1-ce1f-45d1-b94d-e6bf2464ba2c",
+        "name": "Fabrikam-Fiber-Git",
+        "state": "wellFormed",
+        "visibility": "private"
+      },
+      "defaultBranch": "refs/heads/main",
+      "size": 1024,
+      "remoteUrl": "https://fabrikam@dev.azure.com/fabrikam/Fabrikam-Fiber-Git/_git/AnotherRepository",
+      "sshUrl": "git@ssh.dev.azure.com:v3/fabrikam/Fabrikam-Fiber-Git/AnotherRepository",
+      "webUrl": "https://dev.azure.com/fabrikam/Fabrikam-Fiber-Git/_git/AnotherRepository",
+      "isDisabled": false
+    },
+    {
+      "id": "2f3d611a-f012-4b39-b157-8db63f380226",
+      "name": "Public",
+      "url": "https://dev.azure.com/fabrikam/_apis/git/repositories/2f3d611a-f012-4b39-b157-8db63f380226",
+      "project": {
+        "id": "3b3ae425-0079-421f-9101-bcf15d6df041",
+        "name": "Open Source",
+        "state": "wellFormed",
+        "visibility": "public"
+      },
+      "remoteUrl": "https://fabrikam@dev.azure.com/fabrikam/Open%20Source/_git/Public",
+      "sshUrl": "git@ssh.dev.azure.com:v3/fabrikam/Open%20Source/Public",
+      "webUrl": "https://dev.azure.com/fabrikam/Open%20Source/_git/Public",
+      "isDisabled": false
+    }
+  ]
+}`
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, password, _ := r.BasicAuth(); password != "secret-token" {
+			// Mimic Azure DevOps, which serves a sign in page.
+			w.WriteHeader(http.StatusNonAuthoritativeInfo)
+			_, _ = w.Write([]byte("<html>Sign in</html>"))
+			return
+		}
+		if r.URL.Query().Get("api-version") != apiVersion {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch r.URL.EscapedPath() {
+		case "/tfs/fabrikam/_apis/git/repositories", "/tfs/fabrikam/Fabrikam-Fiber-Git/_apis/git/repositories":
+			_, _ = w.Write([]byte(testRepositories))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func TestClient_ListRepositories(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+
+	cli, err := NewClient("urn", &schema.AzureDevOpsConnection{
+		Url:      s.URL + "/tfs",
+		Username: "admin",
+		Token:    "secret-token",
+	}, s.Client())
+	require.NoError(t, err)
+
+	t.Run("by org", func(t *testing.T) {
+		repos, err := cli.ListRepositoriesByOrg(ctx, "fabrikam")
+		require.NoError(t, err)
+		require.Len(t, repos, 2)
+
+		assert.Equal(t, "AnotherRepository", repos[0].Name)
+		assert.True(t, repos[0].IsPrivate())
+		assert.Equal(t, "Public", repos[1].Name)
+		assert.False(t, repos[1].IsPrivate())
+	})
+
+	t.Run("by project", func(t *testing.T) {
+		repos, err := cli.ListRepositoriesByProject(ctx, "fabrikam", "Fabrikam-Fiber-Git")
+		require.NoError(t, err)
+		require.Len(t, repos, 2)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := cli.ListRepositoriesByOrg(ctx, "unknown")
+		require.Error(t, err)
+
+		var e *httpError
+		require.ErrorAs(t, err, &e)
+		assert.True(t, e.NotFound())
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		cli, err := NewClient("urn", &schema.AzureDevOpsConnection{
+			Url:      s.URL + "/tfs",
+			Username: "admin",
+			Token:    "wrong",
+		}, s.Client())
+		require.NoError(t, err)
+
+		_, err = cli.ListRepositoriesByOrg(ctx, "fabrikam")
+		var e *httpError
+		require.ErrorAs(t, err, &e)
+		assert.True(t, e.Unauthorized())
+	})
+}
+
+func TestRepository_Path(t *testing.T) {
+	for remoteURL, want := range map[string]string{
+		"https://fabrikam@dev.azure.com/fabrikam/Fabrikam-Fiber-Git/_git/AnotherRepository": "fabrikam/Fabrikam-Fiber-Git/AnotherRepository",
+		"https://fabrikam@dev.azure.com/fabrikam/Open%20Source/_git/Public":                 "fabrikam/Open Source/Public",
+		"https://azure.example.com/tfs/DefaultCollection/Project/_git/Repo":                 "tfs/DefaultCollection/Project/Repo",
+		"https://fabrikam.visualstudio.com/Project/_git/Repo":                               "Project/Repo",
+	} {
+		t.Run(remoteURL, func(t *testing.T) {
+			r := &Repository{RemoteURL: remoteURL}
+			have, err := r.Path()
+			require.NoError(t, err)
+			assert.Equal(t, want, have)
+		})
+	}
+}
+
+func TestParseEvent(t *testing.T) {
+	payload := []byte(`{
+  "id": "03c164c2-8912-4d5e-8009-3707d5f83734",
+  "eventType": "git.push",
+  "publisherId": "tfs",
+  "resource": {
+    "pushId": 14,
+    "refUpdates": [
+      {
+        "name": "refs/heads/main",
+        "oldObjectId": "aad331d8d3b131fa9ae03cf5e53965b51942618a",
+        "newObjectId": "33b55f7cb7e7e245323987634f960cf4a6e6bc74"
+      }
+    ],
+    "repository": {
+      "id": "278d5cd2-584d-4b63-824a-2ba458937249",
+      "name": "Fabrikam-Fiber-Git",
+      "remoteUrl": "https://fabrikam@dev.azure.com/fabrikam/Fabrikam-Fiber-Git/_git/Fabrikam-Fiber-Git"
+    }
+  }
+}`)
+
+	eventType, e, err := ParseEvent(payload)
+	require.NoError(t, err)
+	assert.Equal(t, EventTypeGitPush, eventType)
+
+	want := &PushEvent{
+		PushID: 14,
+		Repository: Repository{
+			ID:        "278d5cd2-584d-4b63-824a-2ba458937249",
+			Name:      "Fabrikam-Fiber-Git",
+			RemoteURL: "https://fabrikam@dev.azure.com/fabrikam/Fabrikam-Fiber-Git/_git/Fabrikam-Fiber-Git",
+		},
+		RefUpdates: []RefUpdate{{
+			Name:        "refs/heads/main",
+			OldObjectID: "aad331d8d3b131fa9ae03cf5e53965b51942618a",
+			NewObjectID: "33b55f7cb7e7e245323987634f960cf4a6e6bc74",
+		}},
+	}
+	if diff := cmp.Diff(want, e); diff != "" {
+		t.Fatalf("unexpected event (-want +got):\n%s", diff)
+	}
+
+	_, _, err = ParseEvent([]byte(`{"eventType": "build.complete", "resource": {}}`))
+	assert.Error(t, err)
+}
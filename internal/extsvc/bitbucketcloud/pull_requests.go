@@ -21,6 +21,15 @@ type PullRequestInput struct {
 	// If SourceRepo is provided, only FullName is actually used.
 	SourceRepo        *Repo
 	DestinationBranch *string
+	// If Reviewers is not empty, it replaces the reviewers of the pull request.
+	Reviewers []ReviewerInput
+}
+
+// ReviewerInput identifies a reviewer of a pull request by either the UUID or
+// the Atlassian account ID of the user.
+type ReviewerInput struct {
+	UUID      string `json:"uuid,omitempty"`
+	AccountID string `json:"account_id,omitempty"`
 }
 
 // CreatePullRequest opens a new pull request.
@@ -198,10 +207,11 @@ func (input *PullRequestInput) MarshalJSON() ([]byte, error) {
 	}
 
 	type request struct {
-		Title       string  `json:"title"`
-		Description string  `json:"description,omitempty"`
-		Source      source  `json:"source"`
-		Destination *source `json:"destination,omitempty"`
+		Title       string          `json:"title"`
+		Description string          `json:"description,omitempty"`
+		Source      source          `json:"source"`
+		Destination *source         `json:"destination,omitempty"`
+		Reviewers   []ReviewerInput `json:"reviewers,omitempty"`
 	}
 
 	req := request{
@@ -210,6 +220,7 @@ func (input *PullRequestInput) MarshalJSON() ([]byte, error) {
 		Source: source{
 			Branch: branch{Name: input.SourceBranch},
 		},
+		Reviewers: input.Reviewers,
 	}
 	if input.SourceRepo != nil {
 		req.Source.Repository = &repository{
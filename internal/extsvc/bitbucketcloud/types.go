@@ -18,6 +18,7 @@ type Account struct {
 	Website       string        `json:"website"`
 	CreatedOn     time.Time     `json:"created_on"`
 	UUID          string        `json:"uuid"`
+	AccountID     string        `json:"account_id"`
 }
 
 type Author struct {
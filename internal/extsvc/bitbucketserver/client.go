@@ -477,6 +477,17 @@ type UpdatePullRequestInput struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	ToRef       Ref    `json:"toRef"`
+
+	// Reviewers, if not empty, replaces the reviewers of the pull request.
+	Reviewers []ReviewerInput `json:"reviewers,omitempty"`
+}
+
+// ReviewerInput identifies a reviewer of a pull request by the slug of the
+// user.
+type ReviewerInput struct {
+	User struct {
+		Name string `json:"name"`
+	} `json:"user"`
 }
 
 func (c *Client) UpdatePullRequest(ctx context.Context, in *UpdatePullRequestInput) (*PullRequest, error) {
@@ -0,0 +1,263 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChangeStatus is the status of a Gerrit change.
+type ChangeStatus string
+
+const (
+	ChangeStatusNew       ChangeStatus = "NEW"
+	ChangeStatusMerged    ChangeStatus = "MERGED"
+	ChangeStatusAbandoned ChangeStatus = "ABANDONED"
+)
+
+// Labels used by the default Gerrit review workflow.
+const (
+	LabelCodeReview = "Code-Review"
+	LabelVerified   = "Verified"
+)
+
+// Change is a Gerrit change, as returned by the changes endpoints with the
+// options in changeQueryOptions.
+type Change struct {
+	ID              string                  `json:"id"`
+	Number          int64                   `json:"_number"`
+	ChangeID        string                  `json:"change_id"`
+	Project         string                  `json:"project"`
+	Branch          string                  `json:"branch"`
+	Topic           string                  `json:"topic,omitempty"`
+	Subject         string                  `json:"subject"`
+	Status          ChangeStatus            `json:"status"`
+	WorkInProgress  bool                    `json:"work_in_progress,omitempty"`
+	Created         Timestamp               `json:"created"`
+	Updated         Timestamp               `json:"updated"`
+	Owner           Account                 `json:"owner"`
+	Labels          map[string]LabelInfo    `json:"labels,omitempty"`
+	CurrentRevision string                  `json:"current_revision,omitempty"`
+	Revisions       map[string]RevisionInfo `json:"revisions,omitempty"`
+
+	// URL is the web URL of the change. It is not part of the API response,
+	// but set by the Client.
+	URL string `json:"url,omitempty"`
+}
+
+// Current returns the current revision of the change, if it was requested.
+func (c *Change) Current() (RevisionInfo, bool) {
+	r, ok := c.Revisions[c.CurrentRevision]
+	return r, ok
+}
+
+// Description returns the commit message of the current revision without its
+// subject and its Change-Id footer, if the current revision was requested.
+func (c *Change) Description() string {
+	r, ok := c.Current()
+	if !ok {
+		return ""
+	}
+
+	_, body, _ := strings.Cut(r.Commit.Message, "\n")
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	if n := len(lines); n > 0 && strings.HasPrefix(lines[n-1], "Change-Id: ") {
+		lines = lines[:n-1]
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// LabelInfo holds the votes on a label of a change.
+type LabelInfo struct {
+	Approved *Account       `json:"approved,omitempty"`
+	Rejected *Account       `json:"rejected,omitempty"`
+	Blocking bool           `json:"blocking,omitempty"`
+	All      []ApprovalInfo `json:"all,omitempty"`
+}
+
+// ApprovalInfo is the vote of a single account on a label.
+type ApprovalInfo struct {
+	Account
+	Value int       `json:"value"`
+	Date  Timestamp `json:"date"`
+}
+
+// RevisionInfo is a patch set of a change.
+type RevisionInfo struct {
+	Number int        `json:"_number"`
+	Ref    string     `json:"ref"`
+	Commit CommitInfo `json:"commit"`
+}
+
+// CommitInfo is the commit of a patch set.
+type CommitInfo struct {
+	Parents []CommitParent `json:"parents"`
+	Subject string         `json:"subject"`
+	Message string         `json:"message"`
+}
+
+// CommitParent is a parent of a commit.
+type CommitParent struct {
+	Commit string `json:"commit"`
+}
+
+// timestampLayout is the format of timestamps in the Gerrit API, which are
+// always in UTC.
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+// Timestamp is a timestamp in the format used by the Gerrit API.
+type Timestamp struct {
+	time.Time
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte(`""`), nil
+	}
+	return json.Marshal(t.UTC().Format(timestampLayout))
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	parsed, err := time.ParseInLocation(timestampLayout, s, time.UTC)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// changeQueryOptions are the additional fields requested for every change.
+var changeQueryOptions = []string{"DETAILED_LABELS", "DETAILED_ACCOUNTS", "CURRENT_REVISION", "CURRENT_COMMIT"}
+
+// GetChange returns the change with the given ID, which can be any identifier
+// accepted by Gerrit, such as the change number.
+func (c *Client) GetChange(ctx context.Context, changeID string) (*Change, error) {
+	qs := url.Values{"o": changeQueryOptions}
+	u := url.URL{Path: "a/changes/" + url.PathEscape(changeID), RawQuery: qs.Encode()}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if _, err = c.do(ctx, req, &change); err != nil {
+		return nil, err
+	}
+	c.setChangeURL(&change)
+	return &change, nil
+}
+
+// QueryChanges returns the changes matching the given search query, e.g.
+// "change:I8473b95934b5732ac55d26311a706c9c2bde9940 project:myProject".
+func (c *Client) QueryChanges(ctx context.Context, query string) ([]*Change, error) {
+	qs := url.Values{"q": {query}, "o": changeQueryOptions}
+	u := url.URL{Path: "a/changes/", RawQuery: qs.Encode()}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*Change
+	if _, err = c.do(ctx, req, &changes); err != nil {
+		return nil, err
+	}
+	for _, change := range changes {
+		c.setChangeURL(change)
+	}
+	return changes, nil
+}
+
+// AbandonChange abandons the given change.
+func (c *Client) AbandonChange(ctx context.Context, changeID string) error {
+	return c.postChangeAction(ctx, changeID, "abandon", struct{}{})
+}
+
+// RestoreChange restores the given abandoned change.
+func (c *Client) RestoreChange(ctx context.Context, changeID string) error {
+	return c.postChangeAction(ctx, changeID, "restore", struct{}{})
+}
+
+// SubmitChange submits the given change, merging it into its target branch.
+func (c *Client) SubmitChange(ctx context.Context, changeID string) error {
+	return c.postChangeAction(ctx, changeID, "submit", struct{}{})
+}
+
+// MoveChange moves the given change to another branch of its project.
+func (c *Client) MoveChange(ctx context.Context, changeID, branch string) error {
+	return c.postChangeAction(ctx, changeID, "move", struct {
+		DestinationBranch string `json:"destination_branch"`
+	}{DestinationBranch: branch})
+}
+
+// ReviewInput is the input to SetReview.
+type ReviewInput struct {
+	Message string         `json:"message,omitempty"`
+	Labels  map[string]int `json:"labels,omitempty"`
+}
+
+// SetReview posts a review, i.e. a message and label votes, on the current
+// revision of the given change.
+func (c *Client) SetReview(ctx context.Context, changeID string, input ReviewInput) error {
+	return c.postChangeAction(ctx, changeID, "revisions/current/review", input)
+}
+
+// SetCommitMessage changes the commit message of the given change, which
+// creates a new patch set. The message must contain the Change-Id footer of
+// the change.
+func (c *Client) SetCommitMessage(ctx context.Context, changeID, message string) error {
+	req, err := newJSONRequest("PUT", "a/changes/"+url.PathEscape(changeID)+"/message", struct {
+		Message string `json:"message"`
+	}{Message: message})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+func (c *Client) postChangeAction(ctx context.Context, changeID, action string, input any) error {
+	req, err := newJSONRequest("POST", "a/changes/"+url.PathEscape(changeID)+"/"+action, input)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+func (c *Client) setChangeURL(change *Change) {
+	u := *c.URL
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/c/" + change.Project + "/+/" + strconv.FormatInt(change.Number, 10)
+	change.URL = u.String()
+}
+
+func newJSONRequest(method, path string, body any) (*http.Request, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
@@ -0,0 +1,168 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const testChange = `)]}'
+{
+  "id": "myProject~main~I8473b95934b5732ac55d26311a706c9c2bde9940",
+  "_number": 3965,
+  "change_id": "I8473b95934b5732ac55d26311a706c9c2bde9940",
+  "project": "myProject",
+  "branch": "main",
+  "topic": "batch/update-deps",
+  "subject": "Update dependencies",
+  "status": "NEW",
+  "created": "2022-10-01 09:59:32.126000000",
+  "updated": "2022-10-02 10:00:00.000000000",
+  "owner": {"_account_id": 1000096, "username": "jdoe", "email": "jdoe@example.com"},
+  "labels": {
+    "Code-Review": {"all": [{"_account_id": 1000097, "value": 2}]},
+    "Verified": {"all": [{"_account_id": 1000098, "value": -1}]}
+  },
+  "current_revision": "184ebe53805e102605d11f6b143486d15c23a09c",
+  "revisions": {
+    "184ebe53805e102605d11f6b143486d15c23a09c": {
+      "_number": 2,
+      "ref": "refs/changes/65/3965/2",
+      "commit": {
+        "parents": [{"commit": "1eee2c9d8f352483781e772f35dc586a69ff5646"}],
+        "subject": "Update dependencies",
+        "message": "Update dependencies\n\nBumps all the things.\n\nChange-Id: I8473b95934b5732ac55d26311a706c9c2bde9940\n"
+      }
+    }
+  }
+}`
+
+func newTestServerClient(t *testing.T, h http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	cli, err := NewClient("Test", &schema.GerritConnection{Url: srv.URL + "/", Username: "admin", Password: "secret"}, nil)
+	require.NoError(t, err)
+	return cli
+}
+
+func TestClient_GetChange(t *testing.T) {
+	cli := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a/changes/3965":
+			assert.ElementsMatch(t, changeQueryOptions, r.URL.Query()["o"])
+			_, _ = w.Write([]byte(testChange))
+		default:
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	change, err := cli.GetChange(ctx, "3965")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3965), change.Number)
+	assert.Equal(t, ChangeStatusNew, change.Status)
+	assert.Equal(t, "batch/update-deps", change.Topic)
+	assert.Equal(t, time.Date(2022, 10, 1, 9, 59, 32, 126000000, time.UTC), change.Created.Time)
+	assert.Equal(t, 2, change.Labels[LabelCodeReview].All[0].Value)
+	assert.Equal(t, "Bumps all the things.", change.Description())
+	assert.Equal(t, cli.URL.String()+"c/myProject/+/3965", change.URL)
+
+	current, ok := change.Current()
+	require.True(t, ok)
+	assert.Equal(t, "refs/changes/65/3965/2", current.Ref)
+
+	_, err = cli.GetChange(ctx, "1")
+	assert.True(t, errcode.IsNotFound(err))
+}
+
+func TestChange_MarshalRoundTrip(t *testing.T) {
+	// Changes are stored as changeset metadata, so they must survive a round
+	// trip through JSON unchanged.
+	var change Change
+	require.NoError(t, json.Unmarshal([]byte(testChange[5:]), &change))
+
+	data, err := json.Marshal(&change)
+	require.NoError(t, err)
+
+	var have Change
+	require.NoError(t, json.Unmarshal(data, &have))
+	assert.Equal(t, change, have)
+}
+
+func TestClient_ChangeActions(t *testing.T) {
+	type request struct {
+		Method string
+		Path   string
+		Body   string
+	}
+	var requests []request
+
+	cli := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, request{Method: r.Method, Path: r.URL.Path, Body: string(body)})
+		if r.URL.Path == "/a/changes/3965/message" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte(testChange))
+	})
+	ctx := context.Background()
+
+	require.NoError(t, cli.AbandonChange(ctx, "3965"))
+	require.NoError(t, cli.RestoreChange(ctx, "3965"))
+	require.NoError(t, cli.SubmitChange(ctx, "3965"))
+	require.NoError(t, cli.MoveChange(ctx, "3965", "release"))
+	require.NoError(t, cli.SetReview(ctx, "3965", ReviewInput{Message: "LGTM"}))
+	require.NoError(t, cli.SetCommitMessage(ctx, "3965", "Title\n\nChange-Id: I1\n"))
+
+	assert.Equal(t, []request{
+		{Method: "POST", Path: "/a/changes/3965/abandon", Body: `{}`},
+		{Method: "POST", Path: "/a/changes/3965/restore", Body: `{}`},
+		{Method: "POST", Path: "/a/changes/3965/submit", Body: `{}`},
+		{Method: "POST", Path: "/a/changes/3965/move", Body: `{"destination_branch":"release"}`},
+		{Method: "POST", Path: "/a/changes/3965/revisions/current/review", Body: `{"message":"LGTM"}`},
+		{Method: "PUT", Path: "/a/changes/3965/message", Body: `{"message":"Title\n\nChange-Id: I1\n"}`},
+	}, requests)
+}
+
+func TestClient_WithAuthenticator(t *testing.T) {
+	var username, password string
+	cli := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ = r.BasicAuth()
+		_, _ = w.Write([]byte(`)]}'
+{"_account_id": 1000096, "username": "jdoe"}`))
+	})
+	ctx := context.Background()
+
+	assert.Equal(t, &auth.BasicAuth{Username: "admin", Password: "secret"}, cli.Authenticator())
+
+	_, err := cli.GetAuthenticatedAccount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "admin", username)
+	assert.Equal(t, "secret", password)
+
+	a := &auth.BasicAuth{Username: "jdoe", Password: "http-password"}
+	userCli := cli.WithAuthenticator(a)
+	assert.Equal(t, a, userCli.Authenticator())
+
+	account, err := userCli.GetAuthenticatedAccount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe", account.Username)
+	assert.Equal(t, "jdoe", username)
+	assert.Equal(t, "http-password", password)
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -26,6 +27,10 @@ type Client struct {
 	// URL is the base URL of Gerrit.
 	URL *url.URL
 
+	// auth is the authenticator used for requests. If nil, the username and
+	// password of Config are used.
+	auth auth.Authenticator
+
 	// RateLimit is the self-imposed rate limiter (since Gerrit does not have a concept
 	// of rate limiting in HTTP response headers).
 	rateLimit *ratelimit.InstrumentedLimiter
@@ -52,6 +57,40 @@ func NewClient(urn string, config *schema.GerritConnection, httpClient httpcli.D
 	}, nil
 }
 
+// Authenticator returns the authenticator used by the client. If none was
+// set with WithAuthenticator, it is derived from the username and password of
+// the connection config.
+func (c *Client) Authenticator() auth.Authenticator {
+	if c.auth != nil {
+		return c.auth
+	}
+	return &auth.BasicAuth{Username: c.Config.Username, Password: c.Config.Password}
+}
+
+// WithAuthenticator returns a new Client that uses the same configuration,
+// HTTP client and rate limiter as the current Client, except authenticated
+// with the given authenticator instance. Gerrit only supports HTTP basic
+// authentication, using the HTTP password of the user.
+func (c *Client) WithAuthenticator(a auth.Authenticator) *Client {
+	cc := *c
+	cc.auth = a
+	return &cc
+}
+
+// GetAuthenticatedAccount returns the account of the authenticated user.
+func (c *Client) GetAuthenticatedAccount(ctx context.Context) (*Account, error) {
+	req, err := http.NewRequest("GET", "a/accounts/self", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if _, err = c.do(ctx, req, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
 type ListAccountsResponse []Account
 
 func (c *Client) ListAccountsByEmail(ctx context.Context, email string) (ListAccountsResponse, error) {
@@ -171,7 +210,13 @@ func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.R
 	req.URL = c.URL.ResolveReference(req.URL)
 
 	// Add Basic Auth headers for authenticated requests.
-	req.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.Config.Username+":"+c.Config.Password)))
+	if c.auth != nil {
+		if err := c.auth.Authenticate(req); err != nil {
+			return nil, err
+		}
+	} else {
+		req.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.Config.Username+":"+c.Config.Password)))
+	}
 
 	if err := c.rateLimit.Wait(ctx); err != nil {
 		return nil, err
@@ -199,6 +244,12 @@ func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.R
 		}
 	}
 
+	// Some endpoints respond with 204 No Content, and callers that don't
+	// expect a response body pass a nil result.
+	if result == nil {
+		return resp, nil
+	}
+
 	// The first 4 characters of the Gerrit API responses need to be stripped, see: https://gerrit-review.googlesource.com/Documentation/rest-api.html#output .
 	if len(bs) < 4 {
 		return nil, &httpError{
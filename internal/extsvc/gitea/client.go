@@ -0,0 +1,322 @@
+//nolint:bodyclose // Body is closed in Client.Do, but the response is still returned to provide access to the headers
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// defaultPageSize is the page size used for paginated requests. It is the
+// default maximum page size of Gitea (see MAX_RESPONSE_ITEMS).
+const defaultPageSize = 50
+
+// Client access a Gitea (or Forgejo) instance via the REST API.
+type Client struct {
+	// Config is the code host connection config for this client
+	Config *schema.GiteaConnection
+
+	// URL is the base URL of Gitea.
+	URL *url.URL
+
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// RateLimit is the self-imposed rate limiter (since Gitea does not have a
+	// concept of rate limiting in HTTP response headers).
+	rateLimit *ratelimit.InstrumentedLimiter
+
+	// sudo is the username of the user the client impersonates, if any.
+	sudo string
+}
+
+// NewClient returns an authenticated Gitea API client with the provided
+// configuration. If a nil httpClient is provided, httpcli.ExternalDoer will
+// be used.
+func NewClient(urn string, config *schema.GiteaConnection, httpClient httpcli.Doer) (*Client, error) {
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gitea can be served below a path prefix (ROOT_URL), so we need a
+	// trailing slash for relative references to resolve below it.
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	return &Client{
+		Config:     config,
+		URL:        u,
+		httpClient: httpClient,
+		rateLimit:  ratelimit.DefaultRegistry.Get(urn),
+	}, nil
+}
+
+// WithSudo returns a copy of the client that impersonates the user with the
+// given username. This requires the token of the client to belong to a site
+// admin.
+func (c *Client) WithSudo(username string) *Client {
+	sc := *c
+	sc.sudo = username
+	return &sc
+}
+
+// ListOrgRepos returns all the repositories of the given organization that
+// are visible to the authenticated user.
+func (c *Client) ListOrgRepos(ctx context.Context, org string) ([]*Repository, error) {
+	return c.listRepos(ctx, "api/v1/orgs/"+url.PathEscape(org)+"/repos", nil)
+}
+
+// ListUserRepos returns all the repositories owned by the given user that are
+// visible to the authenticated user.
+func (c *Client) ListUserRepos(ctx context.Context, user string) ([]*Repository, error) {
+	return c.listRepos(ctx, "api/v1/users/"+url.PathEscape(user)+"/repos", nil)
+}
+
+// ListAuthenticatedUserRepos returns all the repositories the authenticated
+// (or impersonated) user has access to.
+func (c *Client) ListAuthenticatedUserRepos(ctx context.Context) ([]*Repository, error) {
+	return c.listRepos(ctx, "api/v1/user/repos", nil)
+}
+
+// SearchRepos returns all the repositories matching the given query string of
+// the repository search API, e.g. "q=docs&topic=true".
+func (c *Client) SearchRepos(ctx context.Context, query string) ([]*Repository, error) {
+	q, err := url.ParseQuery(strings.TrimPrefix(query, "?"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing search query %q", query)
+	}
+
+	var repos []*Repository
+	err = c.paginate(ctx, "api/v1/repos/search", q, func(req *http.Request) (int, error) {
+		var resp struct {
+			OK   bool          `json:"ok"`
+			Data []*Repository `json:"data"`
+		}
+		if _, err := c.do(ctx, req, &resp); err != nil {
+			return 0, err
+		}
+		repos = append(repos, resp.Data...)
+		return len(resp.Data), nil
+	})
+	return repos, err
+}
+
+// GetRepoByID returns the repository with the given ID.
+func (c *Client) GetRepoByID(ctx context.Context, id int64) (*Repository, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "api/v1/repositories/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo Repository
+	if _, err := c.do(ctx, req, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// ListCollaborators returns the users that have been added as collaborators
+// to the given repository.
+func (c *Client) ListCollaborators(ctx context.Context, owner, name string) ([]*User, error) {
+	return listAll[*User](ctx, c, "api/v1/repos/"+url.PathEscape(owner)+"/"+url.PathEscape(name)+"/collaborators", nil)
+}
+
+// ListRepoTeams returns the teams that have access to the given repository.
+// Repositories that are not owned by an organization have no teams.
+func (c *Client) ListRepoTeams(ctx context.Context, owner, name string) ([]*Team, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "api/v1/repos/"+url.PathEscape(owner)+"/"+url.PathEscape(name)+"/teams", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// This endpoint is not paginated.
+	var teams []*Team
+	if _, err := c.do(ctx, req, &teams); err != nil {
+		// Gitea responds with a 405 for repositories owned by users.
+		var e *httpError
+		if errors.As(err, &e) && e.StatusCode == http.StatusMethodNotAllowed {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return teams, nil
+}
+
+// ListTeamMembers returns the members of the team with the given ID.
+func (c *Client) ListTeamMembers(ctx context.Context, teamID int64) ([]*User, error) {
+	return listAll[*User](ctx, c, "api/v1/teams/"+strconv.FormatInt(teamID, 10)+"/members", nil)
+}
+
+// GetUser returns the user with the given username.
+func (c *Client) GetUser(ctx context.Context, username string) (*User, error) {
+	return c.getUser(ctx, "api/v1/users/"+url.PathEscape(username))
+}
+
+// GetAuthenticatedUser returns the authenticated (or impersonated) user.
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (*User, error) {
+	return c.getUser(ctx, "api/v1/user")
+}
+
+func (c *Client) getUser(ctx context.Context, urlPath string) (*User, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", urlPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if _, err := c.do(ctx, req, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (c *Client) listRepos(ctx context.Context, urlPath string, q url.Values) ([]*Repository, error) {
+	return listAll[*Repository](ctx, c, urlPath, q)
+}
+
+// listAll returns all the items of a paginated endpoint that responds with a
+// plain JSON array.
+func listAll[T any](ctx context.Context, c *Client, urlPath string, q url.Values) ([]T, error) {
+	var all []T
+	err := c.paginate(ctx, urlPath, q, func(req *http.Request) (int, error) {
+		var page []T
+		if _, err := c.do(ctx, req, &page); err != nil {
+			return 0, err
+		}
+		all = append(all, page...)
+		return len(page), nil
+	})
+	return all, err
+}
+
+// paginate calls fetch with requests for consecutive pages of the given
+// endpoint until fetch reports a page that isn't full.
+func (c *Client) paginate(ctx context.Context, urlPath string, q url.Values, fetch func(*http.Request) (int, error)) error {
+	if q == nil {
+		q = url.Values{}
+	}
+	q.Set("limit", strconv.Itoa(defaultPageSize))
+
+	for page := 1; ; page++ {
+		q.Set("page", strconv.Itoa(page))
+		u := url.URL{Path: urlPath, RawQuery: q.Encode()}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+		if err != nil {
+			return err
+		}
+
+		n, err := fetch(req)
+		if err != nil {
+			return err
+		}
+		if n < defaultPageSize {
+			return nil
+		}
+	}
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) {
+	req.URL = c.URL.ResolveReference(req.URL)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "token "+c.Config.Token)
+	if c.sudo != "" {
+		req.Header.Set("Sudo", c.sudo)
+	}
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	return resp, json.Unmarshal(bs, result)
+}
+
+// Repository is a Git repository hosted on Gitea.
+type Repository struct {
+	ID            int64    `json:"id"`
+	Owner         User     `json:"owner"`
+	Name          string   `json:"name"`
+	FullName      string   `json:"full_name"`
+	Description   string   `json:"description"`
+	Empty         bool     `json:"empty"`
+	Private       bool     `json:"private"`
+	Fork          bool     `json:"fork"`
+	Mirror        bool     `json:"mirror"`
+	Archived      bool     `json:"archived"`
+	HTMLURL       string   `json:"html_url"`
+	CloneURL      string   `json:"clone_url"`
+	SSHURL        string   `json:"ssh_url"`
+	DefaultBranch string   `json:"default_branch"`
+	StarsCount    int      `json:"stars_count"`
+	Topics        []string `json:"topics"`
+}
+
+// User is a Gitea user or organization.
+type User struct {
+	ID       int64  `json:"id"`
+	Login    string `json:"login"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+	IsAdmin  bool   `json:"is_admin"`
+}
+
+// Team is a team of an organization.
+type Team struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Permission string `json:"permission"`
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Gitea API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,152 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	// The org has one more repository than fits on a page, to exercise
+	// pagination.
+	var orgRepos []*Repository
+	for i := 1; i <= defaultPageSize+1; i++ {
+		orgRepos = append(orgRepos, &Repository{
+			ID:       int64(i),
+			Name:     fmt.Sprintf("repo-%d", i),
+			FullName: fmt.Sprintf("acme/repo-%d", i),
+		})
+	}
+
+	writeJSON := func(w http.ResponseWriter, v any) {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token secret-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		switch r.URL.Path {
+		case "/gitea/api/v1/orgs/acme/repos":
+			start, end := (page-1)*limit, page*limit
+			if start > len(orgRepos) {
+				start = len(orgRepos)
+			}
+			if end > len(orgRepos) {
+				end = len(orgRepos)
+			}
+			writeJSON(w, orgRepos[start:end])
+		case "/gitea/api/v1/repos/search":
+			if r.URL.Query().Get("q") != "docs" {
+				writeJSON(w, map[string]any{"ok": true, "data": []any{}})
+				return
+			}
+			writeJSON(w, map[string]any{"ok": true, "data": []*Repository{{ID: 7, FullName: "acme/docs", Topics: []string{"docs"}}}})
+		case "/gitea/api/v1/repos/alice/dotfiles/teams":
+			http.Error(w, `{"message":"repo is not owned by an organization"}`, http.StatusMethodNotAllowed)
+		case "/gitea/api/v1/repos/acme/docs/teams":
+			writeJSON(w, []*Team{{ID: 3, Name: "Owners", Permission: "owner"}})
+		case "/gitea/api/v1/user":
+			writeJSON(w, &User{ID: 1, Login: r.Header.Get("Sudo")})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func newTestClient(t *testing.T, s *httptest.Server, token string) *Client {
+	t.Helper()
+
+	cli, err := NewClient("urn", &schema.GiteaConnection{
+		Url:   s.URL + "/gitea",
+		Token: token,
+	}, s.Client())
+	require.NoError(t, err)
+
+	return cli
+}
+
+func TestClient_ListOrgRepos(t *testing.T) {
+	s := newTestServer(t)
+	cli := newTestClient(t, s, "secret-token")
+	ctx := context.Background()
+
+	repos, err := cli.ListOrgRepos(ctx, "acme")
+	require.NoError(t, err)
+	require.Len(t, repos, defaultPageSize+1)
+	assert.Equal(t, "acme/repo-51", repos[defaultPageSize].FullName)
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := cli.ListOrgRepos(ctx, "unknown")
+		var e *httpError
+		require.ErrorAs(t, err, &e)
+		assert.True(t, e.NotFound())
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		_, err := newTestClient(t, s, "wrong").ListOrgRepos(ctx, "acme")
+		var e *httpError
+		require.ErrorAs(t, err, &e)
+		assert.True(t, e.Unauthorized())
+	})
+}
+
+func TestClient_SearchRepos(t *testing.T) {
+	s := newTestServer(t)
+	cli := newTestClient(t, s, "secret-token")
+
+	repos, err := cli.SearchRepos(context.Background(), "?q=docs")
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.Equal(t, "acme/docs", repos[0].FullName)
+	assert.Equal(t, []string{"docs"}, repos[0].Topics)
+}
+
+func TestClient_ListRepoTeams(t *testing.T) {
+	s := newTestServer(t)
+	cli := newTestClient(t, s, "secret-token")
+	ctx := context.Background()
+
+	teams, err := cli.ListRepoTeams(ctx, "acme", "docs")
+	require.NoError(t, err)
+	assert.Equal(t, []*Team{{ID: 3, Name: "Owners", Permission: "owner"}}, teams)
+
+	// User-owned repositories have no teams.
+	teams, err = cli.ListRepoTeams(ctx, "alice", "dotfiles")
+	require.NoError(t, err)
+	assert.Empty(t, teams)
+}
+
+func TestClient_WithSudo(t *testing.T) {
+	s := newTestServer(t)
+	cli := newTestClient(t, s, "secret-token")
+	ctx := context.Background()
+
+	user, err := cli.WithSudo("alice").GetAuthenticatedUser(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user.Login)
+
+	// The original client must not be affected.
+	user, err = cli.GetAuthenticatedUser(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "", user.Login)
+}
@@ -180,6 +180,8 @@ type PullRequest struct {
 	HeadRepository PullRequestRepo
 	Participants   []Actor
 	Labels         struct{ Nodes []Label }
+	Assignees      struct{ Nodes []Actor }
+	Milestone      *Milestone
 	TimelineItems  []TimelineItem
 	Commits        struct{ Nodes []CommitWithChecks }
 	IsDraft        bool
@@ -1256,8 +1258,12 @@ fragment timelineItems on PullRequestTimelineItems {
     requestedTeam: requestedReviewer {
       ... on Team {
         name
+        slug
         url
         avatarUrl
+        organization {
+          login
+        }
       }
     }
     createdAt
@@ -1272,8 +1278,12 @@ fragment timelineItems on PullRequestTimelineItems {
     requestedTeam: requestedReviewer {
       ... on Team {
         name
+        slug
         url
         avatarUrl
+        organization {
+          login
+        }
       }
     }
     createdAt
@@ -1417,6 +1427,15 @@ fragment pr on PullRequest {
       ...label
     }
   }
+  assignees(first: 100) {
+    nodes {
+      ...actor
+    }
+  }
+  milestone {
+    number
+    title
+  }
   commits(last: 1) {
     nodes {
       ...prCommit
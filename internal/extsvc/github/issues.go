@@ -0,0 +1,171 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Issue is a GitHub issue as returned by the REST API.
+type Issue struct {
+	ID      int64  `json:"id"`
+	NodeID  string `json:"node_id"`
+	Number  int64  `json:"number"`
+	State   string `json:"state"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+}
+
+// CreateIssueInput is the payload to create an issue.
+type CreateIssueInput struct {
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+}
+
+// CreateIssue creates an issue in the given repository.
+//
+// API docs: https://docs.github.com/en/rest/issues/issues#create-an-issue
+func (c *V3Client) CreateIssue(ctx context.Context, owner, repo string, input *CreateIssueInput) (*Issue, error) {
+	var issue Issue
+	if _, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/issues", owner, repo), input, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// GetIssue gets the issue with the given number.
+//
+// API docs: https://docs.github.com/en/rest/issues/issues#get-an-issue
+func (c *V3Client) GetIssue(ctx context.Context, owner, repo string, number int64) (*Issue, error) {
+	var issue Issue
+	if _, err := c.get(ctx, fmt.Sprintf("repos/%s/%s/issues/%d", owner, repo, number), &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// CreateIssueComment adds a comment to the issue with the given number.
+//
+// API docs: https://docs.github.com/en/rest/issues/comments#create-an-issue-comment
+func (c *V3Client) CreateIssueComment(ctx context.Context, owner, repo string, number int64, body string) error {
+	payload := struct {
+		Body string `json:"body"`
+	}{Body: body}
+
+	var result struct {
+		ID int64 `json:"id"`
+	}
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/comments", owner, repo, number), payload, &result)
+	return err
+}
+
+// AddIssueLabels adds the given labels to the issue or pull request with the
+// given number. Labels that don't exist in the repository yet are created.
+//
+// API docs: https://docs.github.com/en/rest/issues/labels#add-labels-to-an-issue
+func (c *V3Client) AddIssueLabels(ctx context.Context, owner, repo string, number int64, labels []string) error {
+	payload := struct {
+		Labels []string `json:"labels"`
+	}{Labels: labels}
+
+	var result []struct {
+		Name string `json:"name"`
+	}
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/labels", owner, repo, number), payload, &result)
+	return err
+}
+
+// RemoveIssueLabel removes the given label from the issue or pull request with
+// the given number. It is not an error if the issue doesn't have the label.
+//
+// API docs: https://docs.github.com/en/rest/issues/labels#remove-a-label-from-an-issue
+func (c *V3Client) RemoveIssueLabel(ctx context.Context, owner, repo string, number int64, label string) error {
+	_, err := c.delete(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/labels/%s", owner, repo, number, url.PathEscape(label)))
+	if err != nil && !IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// AddIssueAssignees assigns the given users to the issue or pull request with
+// the given number. Users that can't be assigned are silently ignored by GitHub.
+//
+// API docs: https://docs.github.com/en/rest/issues/assignees#add-assignees-to-an-issue
+func (c *V3Client) AddIssueAssignees(ctx context.Context, owner, repo string, number int64, assignees []string) error {
+	payload := struct {
+		Assignees []string `json:"assignees"`
+	}{Assignees: assignees}
+
+	var issue Issue
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/assignees", owner, repo, number), payload, &issue)
+	return err
+}
+
+// RemoveIssueAssignees unassigns the given users from the issue or pull request
+// with the given number.
+//
+// API docs: https://docs.github.com/en/rest/issues/assignees#remove-assignees-from-an-issue
+func (c *V3Client) RemoveIssueAssignees(ctx context.Context, owner, repo string, number int64, assignees []string) error {
+	payload := struct {
+		Assignees []string `json:"assignees"`
+	}{Assignees: assignees}
+
+	var issue Issue
+	_, err := c.requestWithPayload(ctx, "DELETE", fmt.Sprintf("repos/%s/%s/issues/%d/assignees", owner, repo, number), payload, &issue)
+	return err
+}
+
+// Milestone is a GitHub milestone as returned by the REST API, or the milestone
+// of a pull request as returned by the GraphQL API.
+type Milestone struct {
+	Number int64  `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+}
+
+// ListMilestones lists the open and closed milestones of the given repository.
+//
+// page is the page of results to return, and is 1-indexed (so the first call
+// should be for page 1).
+//
+// API docs: https://docs.github.com/en/rest/issues/milestones#list-milestones
+func (c *V3Client) ListMilestones(ctx context.Context, owner, repo string, page int) (milestones []*Milestone, hasNextPage bool, err error) {
+	path := fmt.Sprintf("repos/%s/%s/milestones?state=all&per_page=100&page=%d", owner, repo, page)
+	if _, err := c.get(ctx, path, &milestones); err != nil {
+		return nil, false, err
+	}
+	return milestones, len(milestones) == 100, nil
+}
+
+// SetIssueMilestone adds the issue or pull request with the given number to the
+// milestone with the given number.
+//
+// API docs: https://docs.github.com/en/rest/issues/issues#update-an-issue
+func (c *V3Client) SetIssueMilestone(ctx context.Context, owner, repo string, number, milestone int64) error {
+	payload := struct {
+		Milestone int64 `json:"milestone"`
+	}{Milestone: milestone}
+
+	var issue Issue
+	_, err := c.patch(ctx, fmt.Sprintf("repos/%s/%s/issues/%d", owner, repo, number), payload, &issue)
+	return err
+}
+
+// RequestPullRequestReviewers requests a review of the pull request with the
+// given number from the given users and teams. Teams are identified by their
+// slug.
+//
+// API docs: https://docs.github.com/en/rest/pulls/review-requests#request-reviewers-for-a-pull-request
+func (c *V3Client) RequestPullRequestReviewers(ctx context.Context, owner, repo string, number int64, reviewers, teamReviewers []string) error {
+	payload := struct {
+		Reviewers     []string `json:"reviewers,omitempty"`
+		TeamReviewers []string `json:"team_reviewers,omitempty"`
+	}{Reviewers: reviewers, TeamReviewers: teamReviewers}
+
+	var result struct {
+		Number int64 `json:"number"`
+	}
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/pulls/%d/requested_reviewers", owner, repo, number), payload, &result)
+	return err
+}
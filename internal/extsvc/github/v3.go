@@ -149,12 +149,21 @@ func (c *V3Client) get(ctx context.Context, requestURI string, result any) (*htt
 
 //nolint:unparam // Return *httpResponseState for consistency with other methods
 func (c *V3Client) post(ctx context.Context, requestURI string, payload, result any) (*httpResponseState, error) {
+	return c.requestWithPayload(ctx, "POST", requestURI, payload, result)
+}
+
+//nolint:unparam // Return *httpResponseState for consistency with other methods
+func (c *V3Client) patch(ctx context.Context, requestURI string, payload, result any) (*httpResponseState, error) {
+	return c.requestWithPayload(ctx, "PATCH", requestURI, payload, result)
+}
+
+func (c *V3Client) requestWithPayload(ctx context.Context, method, requestURI string, payload, result any) (*httpResponseState, error) {
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return nil, errors.Wrap(err, "marshalling payload")
 	}
 
-	req, err := http.NewRequest("POST", requestURI, bytes.NewReader(body))
+	req, err := http.NewRequest(method, requestURI, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
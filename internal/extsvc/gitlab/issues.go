@@ -0,0 +1,116 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type IssueState string
+
+const (
+	IssueStateOpened IssueState = "opened"
+	IssueStateClosed IssueState = "closed"
+)
+
+type Issue struct {
+	ID          ID         `json:"id"`
+	IID         ID         `json:"iid"`
+	ProjectID   ID         `json:"project_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	State       IssueState `json:"state"`
+	WebURL      string     `json:"web_url"`
+}
+
+type CreateIssueOpts struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	// TODO: other fields at
+	// https://docs.gitlab.com/ee/api/issues.html#new-issue as needed.
+}
+
+func (c *Client) CreateIssue(ctx context.Context, project *Project, opts CreateIssueOpts) (*Issue, error) {
+	if MockCreateIssue != nil {
+		return MockCreateIssue(c, ctx, project, opts)
+	}
+
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling options")
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("projects/%d/issues", project.ID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to create an issue")
+	}
+
+	resp := &Issue{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		if aerr := c.convertToArchivedError(ctx, err, project); aerr != nil {
+			return nil, aerr
+		}
+		return nil, errors.Wrap(err, "sending request to create an issue")
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetIssue(ctx context.Context, project *Project, iid ID) (*Issue, error) {
+	if MockGetIssue != nil {
+		return MockGetIssue(c, ctx, project, iid)
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("projects/%d/issues/%d", project.ID, iid), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to get an issue")
+	}
+
+	resp := &Issue{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		return nil, errors.Wrap(err, "sending request to get an issue")
+	}
+
+	return resp, nil
+}
+
+func (c *Client) CreateIssueNote(ctx context.Context, project *Project, issue *Issue, body string) error {
+	if MockCreateIssueNote != nil {
+		return MockCreateIssueNote(c, ctx, project, issue, body)
+	}
+
+	var payload = struct {
+		Body string `json:"body"`
+	}{
+		Body: body,
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshalling payload")
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("projects/%d/issues/%d/notes", project.ID, issue.IID), bytes.NewBuffer(data))
+	if err != nil {
+		return errors.Wrap(err, "creating request to comment on an issue")
+	}
+
+	var resp struct {
+		ID int32 `json:"id"`
+	}
+	if _, _, err := c.do(ctx, req, &resp); err != nil {
+		return errors.Wrap(err, "sending request to comment on an issue")
+	}
+
+	return nil
+}
@@ -47,6 +47,9 @@ type MergeRequest struct {
 	WorkInProgress         bool              `json:"work_in_progress"`
 	Draft                  bool              `json:"draft"`
 	Author                 User              `json:"author"`
+	Assignees              []User            `json:"assignees"`
+	Reviewers              []User            `json:"reviewers"`
+	Milestone              *Milestone        `json:"milestone"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
@@ -221,6 +224,15 @@ type UpdateMergeRequestOpts struct {
 	Title        string                       `json:"title,omitempty"`
 	Description  string                       `json:"description,omitempty"`
 	StateEvent   UpdateMergeRequestStateEvent `json:"state_event,omitempty"`
+
+	// AddLabels and RemoveLabels are comma-separated lists of labels.
+	AddLabels    string `json:"add_labels,omitempty"`
+	RemoveLabels string `json:"remove_labels,omitempty"`
+	// AssigneeIDs and ReviewerIDs replace the assignees and reviewers of the
+	// merge request.
+	AssigneeIDs []int32 `json:"assignee_ids,omitempty"`
+	ReviewerIDs []int32 `json:"reviewer_ids,omitempty"`
+	MilestoneID ID      `json:"milestone_id,omitempty"`
 }
 
 type UpdateMergeRequestStateEvent string
@@ -0,0 +1,43 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type Milestone struct {
+	ID    ID     `json:"id"`
+	IID   ID     `json:"iid"`
+	Title string `json:"title"`
+	State string `json:"state"`
+}
+
+// GetProjectMilestoneByTitle returns the milestone of the given project with
+// the given title, or nil if the project has no such milestone.
+func (c *Client) GetProjectMilestoneByTitle(ctx context.Context, project *Project, title string) (*Milestone, error) {
+	if MockGetProjectMilestoneByTitle != nil {
+		return MockGetProjectMilestoneByTitle(c, ctx, project, title)
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("projects/%d/milestones?title=%s", project.ID, url.QueryEscape(title)), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to get milestones")
+	}
+
+	var resp []*Milestone
+	if _, _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, errors.Wrap(err, "sending request to get milestones")
+	}
+
+	if len(resp) == 0 {
+		return nil, nil
+	}
+	return resp[0], nil
+}
@@ -53,6 +53,20 @@ var MockMergeMergeRequest func(c *Client, ctx context.Context, project *Project,
 // Client.CreateMergeRequestNote
 var MockCreateMergeRequestNote func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, body string) error
 
+// MockCreateIssue, if non-nil, will be called instead of Client.CreateIssue
+var MockCreateIssue func(c *Client, ctx context.Context, project *Project, opts CreateIssueOpts) (*Issue, error)
+
+// MockGetIssue, if non-nil, will be called instead of Client.GetIssue
+var MockGetIssue func(c *Client, ctx context.Context, project *Project, iid ID) (*Issue, error)
+
+// MockCreateIssueNote, if non-nil, will be called instead of
+// Client.CreateIssueNote
+var MockCreateIssueNote func(c *Client, ctx context.Context, project *Project, issue *Issue, body string) error
+
+// MockGetProjectMilestoneByTitle, if non-nil, will be called instead of
+// Client.GetProjectMilestoneByTitle
+var MockGetProjectMilestoneByTitle func(c *Client, ctx context.Context, project *Project, title string) (*Milestone, error)
+
 // MockForkProject, if non-nil, will be called instead of Client.ForkProject
 var MockForkProject func(c *Client, ctx context.Context, project *Project, namespace *string) (*Project, error)
 
@@ -113,9 +113,11 @@ const (
 	// in preference to the Type values below.
 
 	KindAWSCodeCommit   = "AWSCODECOMMIT"
+	KindAzureDevOps     = "AZUREDEVOPS"
 	KindBitbucketServer = "BITBUCKETSERVER"
 	KindBitbucketCloud  = "BITBUCKETCLOUD"
 	KindGerrit          = "GERRIT"
+	KindGitea           = "GITEA"
 	KindGitHub          = "GITHUB"
 	KindGitLab          = "GITLAB"
 	KindGitolite        = "GITOLITE"
@@ -139,6 +141,11 @@ const (
 	// suffix (e.g., "arn:aws:codecommit:us-west-1:123456789:").
 	TypeAWSCodeCommit = "awscodecommit"
 
+	// TypeAzureDevOps is the (api.ExternalRepoSpec).ServiceType value for Azure DevOps repositories. The
+	// ServiceID value is the base URL to the Azure DevOps instance (https://dev.azure.com or the Azure
+	// DevOps Server URL).
+	TypeAzureDevOps = "azuredevops"
+
 	// TypeBitbucketServer is the (api.ExternalRepoSpec).ServiceType value for Bitbucket Server projects. The
 	// ServiceID value is the base URL to the Bitbucket Server instance.
 	TypeBitbucketServer = "bitbucketServer"
@@ -150,6 +157,10 @@ const (
 	// TypeGerrit is the (api.ExternalRepoSpec).ServiceType value for Gerrit projects.
 	TypeGerrit = "gerrit"
 
+	// TypeGitea is the (api.ExternalRepoSpec).ServiceType value for Gitea (and Forgejo) repositories. The
+	// ServiceID value is the base URL to the Gitea instance.
+	TypeGitea = "gitea"
+
 	// TypeGitHub is the (api.ExternalRepoSpec).ServiceType value for GitHub repositories. The ServiceID value
 	// is the base URL to the GitHub instance (https://github.com or the GitHub Enterprise URL).
 	TypeGitHub = "github"
@@ -201,12 +212,16 @@ func KindToType(kind string) string {
 	switch kind {
 	case KindAWSCodeCommit:
 		return TypeAWSCodeCommit
+	case KindAzureDevOps:
+		return TypeAzureDevOps
 	case KindBitbucketServer:
 		return TypeBitbucketServer
 	case KindBitbucketCloud:
 		return TypeBitbucketCloud
 	case KindGerrit:
 		return TypeGerrit
+	case KindGitea:
+		return TypeGitea
 	case KindGitHub:
 		return TypeGitHub
 	case KindGitLab:
@@ -244,12 +259,16 @@ func TypeToKind(t string) string {
 	switch t {
 	case TypeAWSCodeCommit:
 		return KindAWSCodeCommit
+	case TypeAzureDevOps:
+		return KindAzureDevOps
 	case TypeBitbucketServer:
 		return KindBitbucketServer
 	case TypeBitbucketCloud:
 		return KindBitbucketCloud
 	case TypeGerrit:
 		return KindGerrit
+	case TypeGitea:
+		return KindGitea
 	case TypeGitHub:
 		return KindGitHub
 	case TypeGitLab:
@@ -299,12 +318,16 @@ func ParseServiceType(s string) (string, bool) {
 	switch strings.ToLower(s) {
 	case TypeAWSCodeCommit:
 		return TypeAWSCodeCommit, true
+	case TypeAzureDevOps:
+		return TypeAzureDevOps, true
 	case bbsLower:
 		return TypeBitbucketServer, true
 	case bbcLower:
 		return TypeBitbucketCloud, true
 	case TypeGerrit:
 		return TypeGerrit, true
+	case TypeGitea:
+		return TypeGitea, true
 	case TypeGitHub:
 		return TypeGitHub, true
 	case TypeGitLab:
@@ -342,12 +365,16 @@ func ParseServiceKind(s string) (string, bool) {
 	switch strings.ToUpper(s) {
 	case KindAWSCodeCommit:
 		return KindAWSCodeCommit, true
+	case KindAzureDevOps:
+		return KindAzureDevOps, true
 	case KindBitbucketServer:
 		return KindBitbucketServer, true
 	case KindBitbucketCloud:
 		return KindBitbucketCloud, true
 	case KindGerrit:
 		return KindGerrit, true
+	case KindGitea:
+		return KindGitea, true
 	case KindGitHub:
 		return KindGitHub, true
 	case KindGitLab:
@@ -421,12 +448,16 @@ func getConfigPrototype(kind string) (any, error) {
 	switch strings.ToUpper(kind) {
 	case KindAWSCodeCommit:
 		return &schema.AWSCodeCommitConnection{}, nil
+	case KindAzureDevOps:
+		return &schema.AzureDevOpsConnection{}, nil
 	case KindBitbucketServer:
 		return &schema.BitbucketServerConnection{}, nil
 	case KindBitbucketCloud:
 		return &schema.BitbucketCloudConnection{}, nil
 	case KindGerrit:
 		return &schema.GerritConnection{}, nil
+	case KindGitea:
+		return &schema.GiteaConnection{}, nil
 	case KindGitHub:
 		return &schema.GitHubConnection{}, nil
 	case KindGitLab:
@@ -471,6 +502,8 @@ func WebhookURL(kind string, externalServiceID int64, cfg any, externalURL strin
 		path = "bitbucket-server-webhooks"
 	case KindGitLab:
 		path = "gitlab-webhooks"
+	case KindAzureDevOps:
+		path = "azuredevops-webhooks"
 	case KindBitbucketCloud:
 		path = "bitbucket-cloud-webhooks"
 
@@ -522,6 +555,10 @@ func extractToken(parsed any, kind string) (string, error) {
 		return c.Token, nil
 	case *schema.PagureConnection:
 		return c.Token, nil
+	case *schema.AzureDevOpsConnection:
+		return c.Token, nil
+	case *schema.GiteaConnection:
+		return c.Token, nil
 	default:
 		return "", errors.Errorf("unable to extract token for service kind %q", kind)
 	}
@@ -599,6 +636,20 @@ func GetLimitFromConfig(kind string, config any) (rate.Limit, error) {
 		if c != nil && c.Maven != nil && c.Maven.RateLimit != nil {
 			limit = limitOrInf(c.Maven.RateLimit.Enabled, c.Maven.RateLimit.RequestsPerHour)
 		}
+	case *schema.AzureDevOpsConnection:
+		// 2/s is the default limit we enforce, Azure DevOps throttles based on
+		// usage rather than on a fixed number of requests.
+		limit = rate.Limit(2)
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
+	case *schema.GiteaConnection:
+		// 2/s is the default limit we enforce, Gitea itself doesn't rate limit
+		// API requests.
+		limit = rate.Limit(2)
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.PagureConnection:
 		// 8/s is the default limit we enforce
 		limit = rate.Limit(8)
@@ -734,6 +785,10 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		rawURL = c.Url
 	case *schema.GerritConnection:
 		rawURL = c.Url
+	case *schema.AzureDevOpsConnection:
+		rawURL = c.Url
+	case *schema.GiteaConnection:
+		rawURL = c.Url
 	case *schema.PhabricatorConnection:
 		rawURL = c.Url
 	case *schema.OtherExternalServiceConnection:
@@ -36,6 +36,16 @@ func TestExtractToken(t *testing.T) {
 			kind:   KindPhabricator,
 			want:   "deadbeef",
 		},
+		{
+			config: `{"token": "deadbeef"}`,
+			kind:   KindAzureDevOps,
+			want:   "deadbeef",
+		},
+		{
+			config: `{"token": "deadbeef"}`,
+			kind:   KindGitea,
+			want:   "deadbeef",
+		},
 	} {
 		t.Run(tc.kind, func(t *testing.T) {
 			have, err := ExtractToken(tc.config, tc.kind)
@@ -87,6 +97,18 @@ func TestExtractRateLimitConfig(t *testing.T) {
 			kind:   KindBitbucketCloud,
 			want:   2.0,
 		},
+		{
+			name:   "Azure DevOps default",
+			config: `{"url": "https://dev.azure.com"}`,
+			kind:   KindAzureDevOps,
+			want:   2.0,
+		},
+		{
+			name:   "Gitea default",
+			config: `{"url": "https://gitea.com"}`,
+			kind:   KindGitea,
+			want:   2.0,
+		},
 		{
 			name:   "GitLab non-default",
 			config: `{"url": "https://example.com/", "rateLimit": {"enabled": true, "requestsPerHour": 3600}}`,
@@ -111,6 +133,18 @@ func TestExtractRateLimitConfig(t *testing.T) {
 			kind:   KindBitbucketCloud,
 			want:   1.0,
 		},
+		{
+			name:   "Azure DevOps non-default",
+			config: `{"url": "https://dev.azure.com", "rateLimit": {"enabled": true, "requestsPerHour": 3600}}`,
+			kind:   KindAzureDevOps,
+			want:   1.0,
+		},
+		{
+			name:   "Gitea non-default",
+			config: `{"url": "https://gitea.com", "rateLimit": {"enabled": true, "requestsPerHour": 3600}}`,
+			kind:   KindGitea,
+			want:   1.0,
+		},
 		{
 			name:   "NPM default",
 			config: `{"registry": "https://registry.npmjs.org"}`,
@@ -288,6 +322,16 @@ func TestUniqueCodeHostIdentifier(t *testing.T) {
 			config: `{"url": "https://example.com"}`,
 			want:   "https://example.com/",
 		},
+		{
+			kind:   KindAzureDevOps,
+			config: `{"url": "https://dev.azure.com"}`,
+			want:   "https://dev.azure.com/",
+		},
+		{
+			kind:   KindGitea,
+			config: `{"url": "https://gitea.com"}`,
+			want:   "https://gitea.com/",
+		},
 		{
 			kind:   KindBitbucketServer,
 			config: `{"url": "https://bitbucket.sgdev.org/"}`,
@@ -353,6 +397,7 @@ func TestWebhookURL(t *testing.T) {
 			KindGitHub:          externalURL + "/.api/github-webhooks?externalServiceID=42",
 			KindBitbucketServer: externalURL + "/.api/bitbucket-server-webhooks?externalServiceID=42",
 			KindGitLab:          externalURL + "/.api/gitlab-webhooks?externalServiceID=42",
+			KindAzureDevOps:     externalURL + "/.api/azuredevops-webhooks?externalServiceID=42",
 		} {
 			t.Run(kind, func(t *testing.T) {
 				// Note the use of a nil configuration here: these kinds do not
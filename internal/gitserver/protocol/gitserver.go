@@ -264,6 +264,10 @@ type CreateCommitFromPatchRequest struct {
 	// Push specifies whether the target ref will be pushed to the code host: if
 	// nil, no push will be attempted, if non-nil, a push will be attempted.
 	Push *PushConfig
+	// PushRef is the ref on the code host the commit is pushed to. If empty,
+	// the commit is pushed to TargetRef. This is used for code hosts such as
+	// Gerrit, where changes are created by pushing to a magic ref.
+	PushRef string
 	// GitApplyArgs are the arguments that will be passed to `git apply` along
 	// with `--cached`.
 	GitApplyArgs []string
@@ -131,6 +131,7 @@ func Sum(invs []Inventory) Inventory {
 			}
 			x.TotalBytes += lang.TotalBytes
 			x.TotalLines += lang.TotalLines
+			x.TotalFiles += lang.TotalFiles
 		}
 	}
 
@@ -72,8 +72,8 @@ func TestContext_Entries(t *testing.T) {
 	}
 	if want := (Inventory{
 		Languages: []Lang{
-			{Name: "Go", TotalBytes: 21, TotalLines: 2},
-			{Name: "Objective-C", TotalBytes: 24, TotalLines: 1},
+			{Name: "Go", TotalBytes: 21, TotalLines: 2, TotalFiles: 2},
+			{Name: "Objective-C", TotalBytes: 24, TotalLines: 1, TotalFiles: 1},
 		},
 	}); !reflect.DeepEqual(inv, want) {
 		t.Fatalf("got  %#v\nwant %#v", inv, want)
@@ -97,18 +97,18 @@ func TestContext_Entries(t *testing.T) {
 	want := map[string]Inventory{
 		"d": {
 			Languages: []Lang{
-				{Name: "Objective-C", TotalBytes: 24, TotalLines: 1},
-				{Name: "Go", TotalBytes: 12, TotalLines: 1},
+				{Name: "Objective-C", TotalBytes: 24, TotalLines: 1, TotalFiles: 1},
+				{Name: "Go", TotalBytes: 12, TotalLines: 1, TotalFiles: 1},
 			},
 		},
 		"d/a": {
 			Languages: []Lang{
-				{Name: "Objective-C", TotalBytes: 24, TotalLines: 1},
+				{Name: "Objective-C", TotalBytes: 24, TotalLines: 1, TotalFiles: 1},
 			},
 		},
 		"f.go": {
 			Languages: []Lang{
-				{Name: "Go", TotalBytes: 9, TotalLines: 1},
+				{Name: "Go", TotalBytes: 9, TotalLines: 1, TotalFiles: 1},
 			},
 		},
 	}
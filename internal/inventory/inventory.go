@@ -33,11 +33,22 @@ type Lang struct {
 	// TotalLines is the total number of lines of code written in the
 	// programming language.
 	TotalLines uint64 `json:"TotalLines,omitempty"`
+	// TotalFiles is the total number of files written in the programming
+	// language.
+	TotalFiles uint64 `json:"TotalFiles,omitempty"`
 }
 
 var newLine = []byte{'\n'}
 
 func getLang(ctx context.Context, file fs.FileInfo, buf []byte, getFileReader func(ctx context.Context, path string) (io.ReadCloser, error)) (Lang, error) {
+	lang, err := detectLang(ctx, file, buf, getFileReader)
+	if err == nil && lang.Name != "" {
+		lang.TotalFiles = 1
+	}
+	return lang, err
+}
+
+func detectLang(ctx context.Context, file fs.FileInfo, buf []byte, getFileReader func(ctx context.Context, path string) (io.ReadCloser, error)) (Lang, error) {
 	if file == nil {
 		return Lang{}, nil
 	}
@@ -27,6 +27,7 @@ func TestGetLang_language(t *testing.T) {
 			Name:       "Java",
 			TotalBytes: 0,
 			TotalLines: 0,
+			TotalFiles: 1,
 		}},
 		"empty file_unsafe_path": {file: fi{"a.ml", ""}, want: Lang{
 			Name:       "",
@@ -37,16 +38,19 @@ func TestGetLang_language(t *testing.T) {
 			Name:       "Java",
 			TotalBytes: 1,
 			TotalLines: 1,
+			TotalFiles: 1,
 		}},
 		"go": {file: fi{"a.go", "a"}, want: Lang{
 			Name:       "Go",
 			TotalBytes: 1,
 			TotalLines: 1,
+			TotalFiles: 1,
 		}},
 		"go-with-newline": {file: fi{"a.go", "a\n"}, want: Lang{
 			Name:       "Go",
 			TotalBytes: 2,
 			TotalLines: 1,
+			TotalFiles: 1,
 		}},
 		// Ensure that .tsx and .jsx are considered as valid extensions for TypeScript and JavaScript,
 		// respectively.
@@ -54,11 +58,13 @@ func TestGetLang_language(t *testing.T) {
 			Name:       "TypeScript",
 			TotalBytes: 2,
 			TotalLines: 1,
+			TotalFiles: 1,
 		}},
 		"override jsx": {file: fi{"b.jsx", "x"}, want: Lang{
 			Name:       "JavaScript",
 			TotalBytes: 1,
 			TotalLines: 1,
+			TotalFiles: 1,
 		}},
 	}
 	for label, test := range tests {
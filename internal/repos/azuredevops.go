@@ -0,0 +1,170 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// An AzureDevOpsSource yields repositories from a single Azure DevOps connection configured
+// in Sourcegraph via the external services configuration.
+type AzureDevOpsSource struct {
+	svc       *types.ExternalService
+	cli       *azuredevops.Client
+	serviceID string
+	exclude   excludeFunc
+}
+
+// NewAzureDevOpsSource returns a new AzureDevOpsSource from the given external service.
+func NewAzureDevOpsSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.AzureDevOpsConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := azuredevops.NewClient(svc.URN(), &c, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		eb.Exact(r.Id)
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureDevOpsSource{
+		svc:       svc,
+		cli:       cli,
+		serviceID: extsvc.NormalizeBaseURL(cli.URL).String(),
+		exclude:   exclude,
+	}, nil
+}
+
+// ListRepos returns all Azure DevOps repositories configured with this AzureDevOpsSource's config.
+func (s *AzureDevOpsSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	seen := make(map[string]bool)
+	emit := func(org string, repos []*azuredevops.Repository) {
+		for _, r := range repos {
+			// Disabled repositories can neither be cloned nor fetched.
+			if r.IsDisabled || seen[r.ID] || s.excludes(org, r) {
+				continue
+			}
+			seen[r.ID] = true
+
+			repo, err := s.makeRepo(r)
+			if err != nil {
+				results <- SourceResult{Source: s, Err: err}
+				continue
+			}
+			results <- SourceResult{Source: s, Repo: repo}
+		}
+	}
+
+	for _, org := range s.cli.Config.Orgs {
+		repos, err := s.cli.ListRepositoriesByOrg(ctx, org)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "azuredevops.orgs: item=%q", org)}
+			continue
+		}
+		emit(org, repos)
+	}
+
+	for _, p := range s.cli.Config.Projects {
+		org, project, ok := strings.Cut(p, "/")
+		if !ok {
+			results <- SourceResult{Source: s, Err: errors.Errorf("azuredevops.projects: invalid project %q, expected \"org/project\"", p)}
+			continue
+		}
+
+		repos, err := s.cli.ListRepositoriesByProject(ctx, org, project)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "azuredevops.projects: item=%q", p)}
+			continue
+		}
+		emit(org, repos)
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *AzureDevOpsSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *AzureDevOpsSource) excludes(org string, r *azuredevops.Repository) bool {
+	return s.exclude(path.Join(org, r.Project.Name, r.Name)) || s.exclude(r.ID)
+}
+
+func (s *AzureDevOpsSource) makeRepo(r *azuredevops.Repository) (*types.Repo, error) {
+	urn := s.svc.URN()
+
+	name, err := azureDevOpsRepoName(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Repo{
+		Name:    name,
+		URI:     string(name),
+		Fork:    r.IsFork,
+		Private: r.IsPrivate(),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          r.ID,
+			ServiceType: extsvc.TypeAzureDevOps,
+			ServiceID:   s.serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: r.RemoteURL,
+			},
+		},
+		Metadata: r,
+	}, nil
+}
+
+// azureDevOpsRepoName returns the Sourcegraph repository name of the given
+// Azure DevOps repository, e.g. "dev.azure.com/org/project/repo". It only
+// depends on the repository itself so that it can also be computed for
+// repositories referenced by webhook payloads.
+func azureDevOpsRepoName(r *azuredevops.Repository) (api.RepoName, error) {
+	u, err := url.Parse(r.RemoteURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing remote URL of Azure DevOps repository %q", r.ID)
+	}
+
+	p, err := r.Path()
+	if err != nil {
+		return "", err
+	}
+
+	return api.RepoName(path.Join(u.Host, p)), nil
+}
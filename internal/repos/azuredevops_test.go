@@ -0,0 +1,129 @@
+package repos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestAzureDevOpsSource_ListRepos(t *testing.T) {
+	const repos = `{
+  "count": 4,
+  "value": [
+    {
+      "id": "1",
+      "name": "api",
+      "project": {"id": "p1", "name": "backend", "visibility": "private"},
+      "remoteUrl": "https://fabrikam@dev.azure.com/fabrikam/backend/_git/api"
+    },
+    {
+      "id": "2",
+      "name": "api-archive",
+      "project": {"id": "p1", "name": "backend", "visibility": "private"},
+      "remoteUrl": "https://fabrikam@dev.azure.com/fabrikam/backend/_git/api-archive"
+    },
+    {
+      "id": "3",
+      "name": "legacy",
+      "project": {"id": "p1", "name": "backend", "visibility": "private"},
+      "remoteUrl": "https://fabrikam@dev.azure.com/fabrikam/backend/_git/legacy",
+      "isDisabled": true
+    },
+    {
+      "id": "4",
+      "name": "docs",
+      "project": {"id": "p2", "name": "public", "visibility": "public"},
+      "remoteUrl": "https://fabrikam@dev.azure.com/fabrikam/public/_git/docs",
+      "isFork": true
+    }
+  ]
+}`
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		// Both the org and the project return the same repositories, to ensure
+		// we don't yield duplicates.
+		case "/fabrikam/_apis/git/repositories", "/fabrikam/backend/_apis/git/repositories":
+			_, _ = w.Write([]byte(repos))
+		default:
+			http.Error(w, r.URL.String()+" not found", http.StatusNotFound)
+		}
+	}))
+	defer s.Close()
+
+	conf := &schema.AzureDevOpsConnection{
+		Url:      s.URL,
+		Username: "admin",
+		Token:    "token",
+		Orgs:     []string{"fabrikam"},
+		Projects: []string{"fabrikam/backend"},
+		Exclude: []*schema.ExcludedAzureDevOpsRepo{
+			{Pattern: "-archive$"},
+			{Id: "does-not-exist"},
+		},
+	}
+
+	svc := &types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindAzureDevOps,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, conf)),
+	}
+
+	ctx := context.Background()
+	src, err := NewAzureDevOpsSource(ctx, svc, httpcli.NewFactory(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := listAll(ctx, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(have, func(i, j int) bool { return have[i].Name < have[j].Name })
+
+	type repo struct {
+		Name     string
+		ID       string
+		Private  bool
+		Fork     bool
+		CloneURL string
+	}
+
+	var got []repo
+	for _, r := range have {
+		got = append(got, repo{
+			Name:     string(r.Name),
+			ID:       r.ExternalRepo.ID,
+			Private:  r.Private,
+			Fork:     r.Fork,
+			CloneURL: r.Sources[svc.URN()].CloneURL,
+		})
+	}
+
+	want := []repo{
+		{
+			Name:     "dev.azure.com/fabrikam/backend/api",
+			ID:       "1",
+			Private:  true,
+			CloneURL: "https://fabrikam@dev.azure.com/fabrikam/backend/_git/api",
+		},
+		{
+			Name:     "dev.azure.com/fabrikam/public/docs",
+			ID:       "4",
+			Fork:     true,
+			CloneURL: "https://fabrikam@dev.azure.com/fabrikam/public/_git/docs",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected repos (-want +got):\n%s", diff)
+	}
+}
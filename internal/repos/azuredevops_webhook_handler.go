@@ -0,0 +1,42 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type AzureDevOpsWebhookHandler struct {
+	logger log.Logger
+}
+
+func (a *AzureDevOpsWebhookHandler) Register(router *webhooks.AzureDevOpsWebhook) {
+	a.logger = log.Scoped("repos.AzureDevOpsWebhookHandler", "azure devops webhook handler")
+	router.Register(a.handleAzureDevOpsWebhook, azuredevops.EventTypeGitPush)
+}
+
+func (a *AzureDevOpsWebhookHandler) handleAzureDevOpsWebhook(ctx context.Context, _ *types.ExternalService, payload any) error {
+	event, ok := payload.(*azuredevops.PushEvent)
+	if !ok {
+		return errors.Newf("expected azuredevops.PushEvent, got %T", payload)
+	}
+
+	repoName, err := azureDevOpsRepoName(&event.Repository)
+	if err != nil {
+		return errors.Wrap(err, "handleAzureDevOpsWebhook: get name failed")
+	}
+
+	resp, err := repoupdater.DefaultClient.EnqueueRepoUpdate(ctx, repoName)
+	if err != nil {
+		return errors.Wrap(err, "handleAzureDevOpsWebhook: EnqueueRepoUpdate failed")
+	}
+
+	a.logger.Info("successfully updated", log.String("name", resp.Name))
+	return nil
+}
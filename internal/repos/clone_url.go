@@ -11,9 +11,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
@@ -52,6 +54,14 @@ func cloneURL(parsed any, logger log.Logger, kind string, repo *types.Repo) (str
 		if r, ok := repo.Metadata.(*awscodecommit.Repository); ok {
 			return awsCodeCloneURL(logger, r, t), nil
 		}
+	case *schema.AzureDevOpsConnection:
+		if r, ok := repo.Metadata.(*azuredevops.Repository); ok {
+			return azureDevOpsCloneURL(logger, r, t), nil
+		}
+	case *schema.GiteaConnection:
+		if r, ok := repo.Metadata.(*gitea.Repository); ok {
+			return giteaCloneURL(logger, r, t), nil
+		}
 	case *schema.BitbucketServerConnection:
 		if r, ok := repo.Metadata.(*bitbucketserver.Repo); ok {
 			return bitbucketServerCloneURL(r, t), nil
@@ -233,6 +243,29 @@ func gitlabCloneURL(logger log.Logger, repo *gitlab.Project, cfg *schema.GitLabC
 	return u.String()
 }
 
+func azureDevOpsCloneURL(logger log.Logger, repo *azuredevops.Repository, cfg *schema.AzureDevOpsConnection) string {
+	u, err := url.Parse(repo.RemoteURL)
+	if err != nil {
+		logger.Warn("Error adding authentication to Azure DevOps repository remote URL.", log.String("url", repo.RemoteURL), log.Error(err))
+		return repo.RemoteURL
+	}
+	u.User = url.UserPassword(cfg.Username, cfg.Token)
+
+	return u.String()
+}
+
+func giteaCloneURL(logger log.Logger, repo *gitea.Repository, cfg *schema.GiteaConnection) string {
+	u, err := url.Parse(repo.CloneURL)
+	if err != nil {
+		logger.Warn("Error adding authentication to Gitea repository clone URL.", log.String("url", repo.CloneURL), log.Error(err))
+		return repo.CloneURL
+	}
+	// Gitea accepts access tokens as the username of basic auth.
+	u.User = url.User(cfg.Token)
+
+	return u.String()
+}
+
 func gerritCloneURL(logger log.Logger, project *gerrit.Project, cfg *schema.GerritConnection) string {
 	u, err := url.Parse(cfg.Url)
 	if err != nil {
@@ -0,0 +1,206 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GiteaSource yields repositories from a single Gitea (or Forgejo)
+// connection configured in Sourcegraph via the external services
+// configuration.
+type GiteaSource struct {
+	svc     *types.ExternalService
+	config  *schema.GiteaConnection
+	cli     *gitea.Client
+	baseURL *url.URL
+
+	exclude         excludeFunc
+	excludeArchived bool
+	excludeForks    bool
+}
+
+// NewGiteaSource returns a new GiteaSource from the given external service.
+func NewGiteaSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GiteaSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GiteaConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := gitea.NewClient(svc.URN(), &c, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		eb              excludeBuilder
+		excludeArchived bool
+		excludeForks    bool
+	)
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		if r.Id != 0 {
+			eb.Exact(strconv.Itoa(r.Id))
+		}
+		eb.Pattern(r.Pattern)
+
+		if r.Archived {
+			excludeArchived = true
+		}
+		if r.Forks {
+			excludeForks = true
+		}
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GiteaSource{
+		svc:             svc,
+		config:          &c,
+		cli:             cli,
+		baseURL:         extsvc.NormalizeBaseURL(cli.URL),
+		exclude:         exclude,
+		excludeArchived: excludeArchived,
+		excludeForks:    excludeForks,
+	}, nil
+}
+
+// ListRepos returns all Gitea repositories configured with this GiteaSource's config.
+func (s *GiteaSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	seen := make(map[int64]bool)
+	emit := func(repos []*gitea.Repository) {
+		for _, r := range repos {
+			if seen[r.ID] || s.excludes(r) {
+				continue
+			}
+			seen[r.ID] = true
+			results <- SourceResult{Source: s, Repo: s.makeRepo(r)}
+		}
+	}
+
+	for _, org := range s.config.Orgs {
+		repos, err := s.cli.ListOrgRepos(ctx, org)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gitea.orgs: item=%q", org)}
+			continue
+		}
+		emit(repos)
+	}
+
+	for _, user := range s.config.Users {
+		repos, err := s.cli.ListUserRepos(ctx, user)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gitea.users: item=%q", user)}
+			continue
+		}
+		emit(repos)
+	}
+
+	for _, query := range s.config.SearchQuery {
+		q := query
+		// An empty search query matches all repositories the token can access.
+		if q == "all" {
+			q = ""
+		}
+
+		repos, err := s.cli.SearchRepos(ctx, q)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gitea.searchQuery: item=%q", query)}
+			continue
+		}
+		emit(repos)
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *GiteaSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *GiteaSource) excludes(r *gitea.Repository) bool {
+	// Empty repositories can't be cloned.
+	if r.Empty {
+		return true
+	}
+
+	if s.exclude(r.FullName) || s.exclude(strconv.FormatInt(r.ID, 10)) {
+		return true
+	}
+
+	if s.excludeArchived && r.Archived {
+		return true
+	}
+
+	if s.excludeForks && r.Fork {
+		return true
+	}
+
+	return false
+}
+
+func (s *GiteaSource) makeRepo(r *gitea.Repository) *types.Repo {
+	urn := s.svc.URN()
+	host := s.baseURL.Hostname()
+
+	return &types.Repo{
+		Name:        reposource.GiteaRepoName(s.config.RepositoryPathPattern, host, r.FullName),
+		URI:         string(reposource.GiteaRepoName("", host, r.FullName)),
+		Description: r.Description,
+		Fork:        r.Fork,
+		Archived:    r.Archived,
+		Stars:       r.StarsCount,
+		Private:     r.Private,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          strconv.FormatInt(r.ID, 10),
+			ServiceType: extsvc.TypeGitea,
+			ServiceID:   s.baseURL.String(),
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: r.CloneURL,
+			},
+		},
+		Metadata:      r,
+		KeyValuePairs: giteaTopicTags(r.Topics),
+	}
+}
+
+// giteaTopicTags returns the topics of a Gitea repository as key-value pair
+// tags, i.e. keys without a value.
+func giteaTopicTags(topics []string) map[string]*string {
+	if len(topics) == 0 {
+		return nil
+	}
+
+	tags := make(map[string]*string, len(topics))
+	for _, t := range topics {
+		tags[t] = nil
+	}
+	return tags
+}
@@ -0,0 +1,171 @@
+package repos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGiteaSource_ListRepos(t *testing.T) {
+	const orgRepos = `[
+  {
+    "id": 1,
+    "name": "api",
+    "full_name": "acme/api",
+    "private": true,
+    "clone_url": "https://gitea.example.com/acme/api.git",
+    "topics": ["go", "backend"]
+  },
+  {
+    "id": 2,
+    "name": "api-archive",
+    "full_name": "acme/api-archive",
+    "clone_url": "https://gitea.example.com/acme/api-archive.git"
+  },
+  {
+    "id": 3,
+    "name": "legacy",
+    "full_name": "acme/legacy",
+    "archived": true,
+    "clone_url": "https://gitea.example.com/acme/legacy.git"
+  },
+  {
+    "id": 4,
+    "name": "scratch",
+    "full_name": "acme/scratch",
+    "empty": true,
+    "clone_url": "https://gitea.example.com/acme/scratch.git"
+  }
+]`
+
+	const userRepos = `[
+  {
+    "id": 5,
+    "name": "api",
+    "full_name": "alice/api",
+    "fork": true,
+    "clone_url": "https://gitea.example.com/alice/api.git"
+  }
+]`
+
+	// The search returns a repository we've already seen through the org.
+	const searchResults = `{
+  "ok": true,
+  "data": [
+    {
+      "id": 1,
+      "name": "api",
+      "full_name": "acme/api",
+      "private": true,
+      "clone_url": "https://gitea.example.com/acme/api.git",
+      "topics": ["go", "backend"]
+    },
+    {
+      "id": 6,
+      "name": "docs",
+      "full_name": "bob/docs",
+      "clone_url": "https://gitea.example.com/bob/docs.git"
+    }
+  ]
+}`
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/orgs/acme/repos":
+			_, _ = w.Write([]byte(orgRepos))
+		case "/api/v1/users/alice/repos":
+			_, _ = w.Write([]byte(userRepos))
+		case "/api/v1/repos/search":
+			_, _ = w.Write([]byte(searchResults))
+		default:
+			http.Error(w, r.URL.String()+" not found", http.StatusNotFound)
+		}
+	}))
+	defer s.Close()
+
+	conf := &schema.GiteaConnection{
+		Url:         s.URL,
+		Token:       "secret-token",
+		Orgs:        []string{"acme"},
+		Users:       []string{"alice"},
+		SearchQuery: []string{"q=docs"},
+		Exclude: []*schema.ExcludedGiteaRepo{
+			{Pattern: "-archive$"},
+			{Archived: true},
+			{Id: 42},
+		},
+	}
+
+	svc := &types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindGitea,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, conf)),
+	}
+
+	ctx := context.Background()
+	src, err := NewGiteaSource(ctx, svc, httpcli.NewFactory(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := listAll(ctx, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(have, func(i, j int) bool { return have[i].Name < have[j].Name })
+
+	type repo struct {
+		Name     string
+		ID       string
+		Private  bool
+		Fork     bool
+		CloneURL string
+		KVPs     map[string]*string
+	}
+
+	var got []repo
+	for _, r := range have {
+		got = append(got, repo{
+			Name:     string(r.Name),
+			ID:       r.ExternalRepo.ID,
+			Private:  r.Private,
+			Fork:     r.Fork,
+			CloneURL: r.Sources[svc.URN()].CloneURL,
+			KVPs:     r.KeyValuePairs,
+		})
+	}
+
+	host := src.baseURL.Hostname()
+	want := []repo{
+		{
+			Name:     host + "/acme/api",
+			ID:       "1",
+			Private:  true,
+			CloneURL: "https://gitea.example.com/acme/api.git",
+			KVPs:     map[string]*string{"go": nil, "backend": nil},
+		},
+		{
+			Name:     host + "/alice/api",
+			ID:       "5",
+			Fork:     true,
+			CloneURL: "https://gitea.example.com/alice/api.git",
+		},
+		{
+			Name:     host + "/bob/docs",
+			ID:       "6",
+			CloneURL: "https://gitea.example.com/bob/docs.git",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected repos (-want +got):\n%s", diff)
+	}
+}
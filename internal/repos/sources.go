@@ -51,6 +51,10 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewGitLabSource(ctx, logger.Scoped("GitLabSource", "GitLab repo source"), db, svc, cf)
 	case extsvc.KindGerrit:
 		return NewGerritSource(ctx, svc, cf)
+	case extsvc.KindAzureDevOps:
+		return NewAzureDevOpsSource(ctx, svc, cf)
+	case extsvc.KindGitea:
+		return NewGiteaSource(ctx, svc, cf)
 	case extsvc.KindBitbucketServer:
 		return NewBitbucketServerSource(ctx, logger.Scoped("BitbucketServerSource", "bitbucket server repo source"), svc, cf)
 	case extsvc.KindBitbucketCloud:
@@ -803,6 +803,11 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 		stored = types.Repos{existing}
 		fallthrough
 	case 1: // Existing repo, update.
+		stored[0].KeyValuePairs, err = addSourcedKVPs(ctx, tx, stored[0].ID, stored[0].KeyValuePairs, sourced.KeyValuePairs)
+		if err != nil {
+			return Diff{}, errors.Wrap(err, "syncer: failed to add key-value pairs")
+		}
+
 		modified := stored[0].Update(sourced)
 		if modified == types.RepoUnmodified {
 			d.Unmodified = append(d.Unmodified, stored[0])
@@ -820,6 +825,10 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 			return Diff{}, errors.Wrap(err, "syncer: failed to create external service repo")
 		}
 
+		if _, err = addSourcedKVPs(ctx, tx, sourced.ID, nil, sourced.KeyValuePairs); err != nil {
+			return Diff{}, errors.Wrap(err, "syncer: failed to add key-value pairs")
+		}
+
 		d.Added = append(d.Added, sourced)
 	default: // Impossible since we have two separate unique constraints on name and external repo spec
 		panic("unreachable")
@@ -828,6 +837,32 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 	return d, nil
 }
 
+// addSourcedKVPs adds the key-value pairs reported by a code host for a repo
+// (e.g. Gitea topics) that the repo doesn't have yet, and returns the
+// resulting key-value pairs of the repo. Existing keys are left untouched and
+// keys are never removed, since key-value pairs can also be edited by users.
+func addSourcedKVPs(ctx context.Context, tx Store, id api.RepoID, existing, sourced map[string]*string) (map[string]*string, error) {
+	var kvps database.RepoKVPStore
+	for k, v := range sourced {
+		if _, ok := existing[k]; ok {
+			continue
+		}
+
+		if kvps == nil {
+			kvps = database.RepoKVPsWith(tx)
+		}
+		if err := kvps.Create(ctx, id, database.KeyValuePair{Key: k, Value: v}); err != nil {
+			return existing, err
+		}
+
+		if existing == nil {
+			existing = make(map[string]*string, len(sourced))
+		}
+		existing[k] = v
+	}
+	return existing, nil
+}
+
 func (s *Syncer) delete(ctx context.Context, svc *types.ExternalService, seen map[api.RepoID]struct{}) (int, error) {
 	// We do deletion in a best effort manner, returning any errors for individual repos that failed to be deleted.
 	deleted, err := s.Store.DeleteExternalServiceReposNotIn(ctx, svc, seen)
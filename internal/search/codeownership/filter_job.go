@@ -0,0 +1,132 @@
+// Package codeownership implements the search jobs for file:has.owner() and
+// select:file.owners, which use the CODEOWNERS file of each repository.
+package codeownership
+
+import (
+	"context"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewFilterJob creates a job that filters the file matches streamed by child
+// to the files that are owned by all of includeOwners and by none of
+// excludeOwners. Matches that are not file matches are dropped.
+func NewFilterJob(child job.Job, includeOwners, excludeOwners []string) job.Job {
+	return &filterJob{
+		child:         child,
+		includeOwners: includeOwners,
+		excludeOwners: excludeOwners,
+	}
+}
+
+type filterJob struct {
+	child job.Job
+
+	includeOwners []string
+	excludeOwners []string
+}
+
+func (s *filterJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, s)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	rulesets := newRulesetCache(clients.Gitserver)
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var err error
+		event.Results, err = s.filterMatches(ctx, rulesets, event.Results)
+		if err != nil {
+			mu.Lock()
+			errs = errors.Append(errs, err)
+			mu.Unlock()
+		}
+		stream.Send(event)
+	})
+
+	alert, err = s.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (s *filterJob) filterMatches(ctx context.Context, rulesets *rulesetCache, matches []result.Match) ([]result.Match, error) {
+	var errs error
+
+	// Filter matches in place
+	filtered := matches[:0]
+	for _, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		ruleset, err := rulesets.get(ctx, fm.Repo.Name, fm.CommitID)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+		if s.matches(ruleset, fm.Path) {
+			filtered = append(filtered, fm)
+		}
+	}
+	return filtered, errs
+}
+
+func (s *filterJob) matches(ruleset *codeowners.Ruleset, path string) bool {
+	if ruleset == nil {
+		// A file in a repository without a CODEOWNERS file has no owners.
+		return len(s.includeOwners) == 0
+	}
+	for _, owner := range s.includeOwners {
+		if !ruleset.HasOwner(path, owner) {
+			return false
+		}
+	}
+	for _, owner := range s.excludeOwners {
+		if ruleset.HasOwner(path, owner) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *filterJob) Name() string {
+	return "CodeOwnershipFilterJob"
+}
+
+func (s *filterJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Strings("includeOwners", s.includeOwners),
+			trace.Strings("excludeOwners", s.excludeOwners),
+		)
+	}
+	return res
+}
+
+func (s *filterJob) Children() []job.Describer {
+	return []job.Describer{s.child}
+}
+
+func (s *filterJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *s
+	cp.child = job.Map(s.child, fn)
+	return &cp
+}
@@ -0,0 +1,94 @@
+package codeownership
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestJobs(t *testing.T) {
+	gs := gitserver.NewMockClient()
+	gs.ReadFileFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, _ api.CommitID, name string, _ authz.SubRepoPermissionChecker) ([]byte, error) {
+		if repo == "owned" && name == "CODEOWNERS" {
+			return []byte("*.go @go-team\n/docs/ @docs-team @alice\n"), nil
+		}
+		return nil, fs.ErrNotExist
+	})
+
+	fileMatch := func(repo api.RepoName, path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{
+			Repo:     types.MinimalRepo{Name: repo},
+			CommitID: "deadbeef",
+			Path:     path,
+		}}
+	}
+
+	child := mockjob.NewMockJob()
+	child.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: []result.Match{
+			fileMatch("owned", "main.go"),
+			fileMatch("owned", "docs/index.md"),
+			fileMatch("owned", "docs/main.go"),
+			fileMatch("owned", "LICENSE"),
+			fileMatch("unowned", "main.go"),
+			&result.RepoMatch{Name: "owned"},
+		}})
+		return nil, nil
+	})
+
+	run := func(j job.Job) []result.Match {
+		agg := streaming.NewAggregatingStream()
+		_, err := j.Run(context.Background(), job.RuntimeClients{Gitserver: gs}, agg)
+		require.NoError(t, err)
+		return agg.Results
+	}
+
+	paths := func(matches []result.Match) []string {
+		var res []string
+		for _, m := range matches {
+			fm := m.(*result.FileMatch)
+			res = append(res, string(fm.Repo.Name)+"/"+fm.Path)
+		}
+		return res
+	}
+
+	t.Run("include", func(t *testing.T) {
+		got := run(NewFilterJob(child, []string{"go-team"}, nil))
+		require.Equal(t, []string{"owned/main.go"}, paths(got))
+	})
+
+	t.Run("exclude", func(t *testing.T) {
+		got := run(NewFilterJob(child, nil, []string{"@Alice"}))
+		require.Equal(t, []string{"owned/main.go", "owned/LICENSE", "unowned/main.go"}, paths(got))
+	})
+
+	t.Run("select owners", func(t *testing.T) {
+		got := run(NewSelectJob(child))
+		require.Equal(t, []result.Match{
+			&result.OwnerMatch{Handle: "@go-team"},
+			&result.OwnerMatch{Handle: "@docs-team"},
+			&result.OwnerMatch{Handle: "@alice"},
+		}, got)
+	})
+
+	// The CODEOWNERS file of a repository is only read once per job run.
+	reads := 0
+	for _, call := range gs.ReadFileFunc.History() {
+		if call.Arg1 == "owned" && call.Arg3 == "CODEOWNERS" {
+			reads++
+		}
+	}
+	require.Equal(t, 3, reads)
+}
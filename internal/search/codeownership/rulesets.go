@@ -0,0 +1,79 @@
+package codeownership
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// rulesetCache loads the CODEOWNERS file of a repository at a commit at most
+// once per search.
+type rulesetCache struct {
+	gitserver gitserver.Client
+
+	mu      sync.Mutex
+	entries map[rulesetKey]*rulesetEntry
+}
+
+type rulesetKey struct {
+	repo   api.RepoName
+	commit api.CommitID
+}
+
+type rulesetEntry struct {
+	once    sync.Once
+	ruleset *codeowners.Ruleset
+	err     error
+}
+
+func newRulesetCache(client gitserver.Client) *rulesetCache {
+	return &rulesetCache{
+		gitserver: client,
+		entries:   make(map[rulesetKey]*rulesetEntry),
+	}
+}
+
+// get returns the CODEOWNERS ruleset of the repository at the given commit,
+// or nil if the repository has no CODEOWNERS file.
+func (c *rulesetCache) get(ctx context.Context, repo api.RepoName, commit api.CommitID) (*codeowners.Ruleset, error) {
+	key := rulesetKey{repo: repo, commit: commit}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &rulesetEntry{}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.ruleset, entry.err = c.load(ctx, repo, commit)
+	})
+	return entry.ruleset, entry.err
+}
+
+func (c *rulesetCache) load(ctx context.Context, repo api.RepoName, commit api.CommitID) (*codeowners.Ruleset, error) {
+	for _, path := range codeowners.Paths {
+		content, err := c.gitserver.ReadFile(ctx, repo, commit, path, authz.DefaultSubRepoPermsChecker)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s in %s", path, repo)
+		}
+
+		ruleset, err := codeowners.Parse(bytes.NewReader(content))
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s in %s", path, repo)
+		}
+		return ruleset, nil
+	}
+	return nil, nil
+}
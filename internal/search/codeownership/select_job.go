@@ -0,0 +1,92 @@
+package codeownership
+
+import (
+	"context"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewSelectJob creates a job that replaces the file matches streamed by child
+// with the owners of the files, for select:file.owners. Each owner is only
+// streamed once, and files without owners are dropped.
+func NewSelectJob(child job.Job) job.Job {
+	return &selectJob{child: child}
+}
+
+type selectJob struct {
+	child job.Job
+}
+
+func (s *selectJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, s)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		errs error
+		seen = make(map[string]struct{})
+	)
+
+	rulesets := newRulesetCache(clients.Gitserver)
+	selectingStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var owners []result.Match
+		for _, m := range event.Results {
+			fm, ok := m.(*result.FileMatch)
+			if !ok {
+				continue
+			}
+
+			ruleset, err := rulesets.get(ctx, fm.Repo.Name, fm.CommitID)
+			if err != nil {
+				mu.Lock()
+				errs = errors.Append(errs, err)
+				mu.Unlock()
+				continue
+			}
+			if ruleset == nil {
+				continue
+			}
+
+			mu.Lock()
+			for _, handle := range ruleset.Match(fm.Path) {
+				if _, ok := seen[handle]; ok {
+					continue
+				}
+				seen[handle] = struct{}{}
+				owners = append(owners, &result.OwnerMatch{Handle: handle})
+			}
+			mu.Unlock()
+		}
+		event.Results = owners
+		stream.Send(event)
+	})
+
+	alert, err = s.child.Run(ctx, clients, selectingStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (s *selectJob) Name() string {
+	return "CodeOwnershipSelectJob"
+}
+
+func (s *selectJob) Fields(job.Verbosity) []otlog.Field { return nil }
+
+func (s *selectJob) Children() []job.Describer {
+	return []job.Describer{s.child}
+}
+
+func (s *selectJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *s
+	cp.child = job.Map(s.child, fn)
+	return &cp
+}
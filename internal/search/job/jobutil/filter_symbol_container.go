@@ -0,0 +1,102 @@
+package jobutil
+
+import (
+	"context"
+	"strings"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// NewSymbolContainerFilterJob creates a filter job to post-filter symbol
+// results for the container: filter.
+//
+// The symbols service already pushes the container down into its symbol
+// store, so this job only has an effect on backends that can't, like Zoekt.
+// Symbols whose parent doesn't equal container are dropped, and file matches
+// that are left without symbols are dropped altogether.
+func NewSymbolContainerFilterJob(container string, caseSensitive bool, child job.Job) job.Job {
+	return &symbolContainerFilterJob{
+		container:     container,
+		caseSensitive: caseSensitive,
+		child:         child,
+	}
+}
+
+type symbolContainerFilterJob struct {
+	container     string
+	caseSensitive bool
+	child         job.Job
+}
+
+func (j *symbolContainerFilterJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		event.Results = j.filterMatches(event.Results)
+		stream.Send(event)
+	})
+
+	return j.child.Run(ctx, clients, filteredStream)
+}
+
+func (j *symbolContainerFilterJob) filterMatches(matches result.Matches) result.Matches {
+	filtered := matches[:0]
+	for _, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok || len(fm.Symbols) == 0 {
+			continue
+		}
+		symbols := fm.Symbols[:0]
+		for _, s := range fm.Symbols {
+			if j.matches(s.Symbol.Parent) {
+				symbols = append(symbols, s)
+			}
+		}
+		if len(symbols) == 0 {
+			continue
+		}
+		fm.Symbols = symbols
+		filtered = append(filtered, fm)
+	}
+	return filtered
+}
+
+func (j *symbolContainerFilterJob) matches(parent string) bool {
+	if j.caseSensitive {
+		return parent == j.container
+	}
+	return strings.EqualFold(parent, j.container)
+}
+
+func (j *symbolContainerFilterJob) MapChildren(f job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, f)
+	return &cp
+}
+
+func (j *symbolContainerFilterJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *symbolContainerFilterJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			otlog.String("container", j.container),
+			otlog.Bool("caseSensitive", j.caseSensitive),
+		)
+	}
+	return res
+}
+
+func (j *symbolContainerFilterJob) Name() string {
+	return "SymbolContainerFilterJob"
+}
@@ -0,0 +1,85 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+func TestSymbolContainerFilterJob(t *testing.T) {
+	sym := func(name, parent string) *result.SymbolMatch {
+		return &result.SymbolMatch{Symbol: result.Symbol{Name: name, Parent: parent}}
+	}
+	fm := func(path string, symbols ...*result.SymbolMatch) *result.FileMatch {
+		return &result.FileMatch{File: result.File{Path: path}, Symbols: symbols}
+	}
+
+	cases := []struct {
+		name          string
+		container     string
+		caseSensitive bool
+		input         result.Matches
+		output        result.Matches
+	}{{
+		name:      "keeps symbols of the container",
+		container: "Foo",
+		input: result.Matches{
+			fm("a.go", sym("bar", "Foo"), sym("baz", "Qux")),
+			fm("b.go", sym("qux", "Qux")),
+		},
+		output: result.Matches{
+			fm("a.go", sym("bar", "Foo")),
+		},
+	}, {
+		name:      "case insensitive",
+		container: "foo",
+		input: result.Matches{
+			fm("a.go", sym("bar", "Foo")),
+		},
+		output: result.Matches{
+			fm("a.go", sym("bar", "Foo")),
+		},
+	}, {
+		name:          "case sensitive",
+		container:     "foo",
+		caseSensitive: true,
+		input: result.Matches{
+			fm("a.go", sym("bar", "Foo")),
+		},
+		output: result.Matches{},
+	}, {
+		name:      "drops non-symbol matches",
+		container: "Foo",
+		input: result.Matches{
+			fm("a.go"),
+			&result.RepoMatch{Name: "repo"},
+		},
+		output: result.Matches{},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			childJob := mockjob.NewMockJob()
+			childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+				s.Send(streaming.SearchEvent{Results: tc.input})
+				return nil, nil
+			})
+			var got streaming.SearchEvent
+			streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+				got = ev
+			})
+			j := NewSymbolContainerFilterJob(tc.container, tc.caseSensitive, childJob)
+			alert, err := j.Run(context.Background(), job.RuntimeClients{}, streamCollector)
+			require.Nil(t, alert)
+			require.NoError(t, err)
+			require.Equal(t, tc.output, got.Results)
+		})
+	}
+}
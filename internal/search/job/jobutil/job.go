@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/codeownership"
 	"github.com/sourcegraph/sourcegraph/internal/search/commit"
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
@@ -182,10 +183,24 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply file:has.owner() post-filter
+		if includeOwners, excludeOwners := b.FileHasOwner(); len(includeOwners) > 0 || len(excludeOwners) > 0 {
+			basicJob = codeownership.NewFilterJob(basicJob, includeOwners, excludeOwners)
+		}
+	}
+
+	{ // Apply container: post-filter
+		if container := b.FindValue(query.FieldContainer); container != "" {
+			basicJob = NewSymbolContainerFilterJob(container, b.IsCaseSensitive(), basicJob)
+		}
+	}
+
+	selectOwners := false
 	{ // Apply selectors
 		if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
 			sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
 			basicJob = NewSelectJob(sp, basicJob)
+			selectOwners = sp.Root() == filter.File && len(sp) > 1 && sp[1] == "owners"
 		}
 	}
 
@@ -196,6 +211,13 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply select:file.owners after the subrepo permissions checks, so
+		// that the owners of files the user cannot see are not returned.
+		if selectOwners {
+			basicJob = codeownership.NewSelectJob(basicJob)
+		}
+	}
+
 	{ // Apply limit
 		maxResults := b.ToParseTree().MaxResults(inputs.DefaultLimit())
 		basicJob = NewLimitJob(maxResults, basicJob)
@@ -595,6 +617,7 @@ func toTextPatternInfo(b query.Basic, resultTypes result.Types, p search.Protoco
 		CombyRule:                    b.FindValue(query.FieldCombyRule),
 		Index:                        b.Index(),
 		Select:                       selector,
+		SymbolContainer:              b.FindValue(query.FieldContainer),
 	}
 }
 
@@ -668,6 +691,8 @@ func toRepoOptions(b query.Basic, userSettings *schema.Settings) search.RepoOpti
 		CommitAfter:         b.RepoContainsCommitAfter(),
 		UseIndex:            b.Index(),
 		HasKVPs:             b.RepoHasKVPs(),
+		HasLanguages:        b.RepoHasLanguages(),
+		HasSizes:            b.RepoHasSizes(),
 	}
 }
 
@@ -940,6 +965,13 @@ func isGlobal(op search.RepoOptions) bool {
 		return false
 	}
 
+	// repo:has.language() and repo:has.size() are resolved from the
+	// inventories of repositories in the database, which Zoekt does not know
+	// about.
+	if len(op.HasLanguages) > 0 || len(op.HasSizes) > 0 {
+		return false
+	}
+
 	// There should be no cursors when calling this, but if there are that
 	// means we're already paginating. Cursors should probably not live on this
 	// struct since they are an implementation detail of pagination.
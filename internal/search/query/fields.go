@@ -19,6 +19,7 @@ const (
 	FieldVisibility         = "visibility"
 	FieldRev                = "rev"
 	FieldContext            = "context"
+	FieldContainer          = "container"
 
 	// For diff and commit search only:
 	FieldBefore    = "before"
@@ -71,6 +72,7 @@ var allFields = map[string]struct{}{
 	FieldRev:                empty,
 	"revision":              empty,
 	FieldSelect:             empty,
+	FieldContainer:          empty,
 }
 
 var aliases = map[string]string{
@@ -2,8 +2,10 @@ package query
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/go-enry/go-enry/v2"
 	"github.com/grafana/regexp"
 	"github.com/grafana/regexp/syntax"
 
@@ -37,10 +39,13 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"has.description":       func() Predicate { return &RepoHasDescriptionPredicate{} },
 		"has.tag":               func() Predicate { return &RepoHasTagPredicate{} },
 		"has":                   func() Predicate { return &RepoHasKVPPredicate{} },
+		"has.language":          func() Predicate { return &RepoHasLanguagePredicate{} },
+		"has.size":              func() Predicate { return &RepoHasSizePredicate{} },
 	},
 	FieldFile: {
 		"contains.content": func() Predicate { return &FileContainsContentPredicate{} },
 		"has.content":      func() Predicate { return &FileContainsContentPredicate{} },
+		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
 	},
 }
 
@@ -300,6 +305,104 @@ func (p *RepoHasKVPPredicate) Unmarshal(params string, negated bool) (err error)
 func (p *RepoHasKVPPredicate) Field() string { return FieldRepo }
 func (p *RepoHasKVPPredicate) Name() string  { return "has" }
 
+/* repo:has.language(language>percent%) */
+
+// RepoHasLanguagePredicate matches repositories that contain code written in
+// a language. If Comparator is set, the share of the language in the code of
+// the repository, in percent of bytes, is compared to Percent.
+type RepoHasLanguagePredicate struct {
+	Language   string
+	Comparator string
+	Percent    float64
+	Negated    bool
+}
+
+var repoHasLanguageRegexp = regexp.MustCompile(`^([^<>=]+?)\s*(?:(>=|<=|>|<)\s*(\d+(?:\.\d+)?)\s*%?)?$`)
+
+func (p *RepoHasLanguagePredicate) Unmarshal(params string, negated bool) error {
+	match := repoHasLanguageRegexp.FindStringSubmatch(strings.TrimSpace(params))
+	if match == nil {
+		return errors.Errorf("invalid repo:has.language() argument %q, expected a language optionally followed by a comparison such as go>50%%", params)
+	}
+
+	language, ok := enry.GetLanguageByAlias(match[1])
+	if !ok {
+		return errors.Errorf("unknown language: %q", match[1])
+	}
+	p.Language = language
+
+	if match[2] != "" {
+		percent, err := strconv.ParseFloat(match[3], 64)
+		if err != nil || percent > 100 {
+			return errors.Errorf("invalid repo:has.language() percentage %q, expected a number between 0 and 100", match[3])
+		}
+		p.Comparator = match[2]
+		p.Percent = percent
+	}
+	p.Negated = negated
+	return nil
+}
+
+func (p *RepoHasLanguagePredicate) Field() string { return FieldRepo }
+func (p *RepoHasLanguagePredicate) Name() string  { return "has.language" }
+
+/* repo:has.size(>size) */
+
+// RepoHasSizePredicate matches repositories by their size on disk in bytes
+// or, if Files is true, by the number of files on their default branch.
+type RepoHasSizePredicate struct {
+	Comparator string
+	Value      int64
+	Files      bool
+	Negated    bool
+}
+
+var repoHasSizeRegexp = regexp.MustCompile(`^(>=|<=|>|<)\s*(\d+(?:\.\d+)?)\s*([a-zA-Z]*)$`)
+
+// sizeUnits are the multipliers of the units accepted by repo:has.size().
+var sizeUnits = map[string]float64{
+	"":   1,
+	"b":  1,
+	"kb": 1 << 10,
+	"k":  1 << 10,
+	"mb": 1 << 20,
+	"m":  1 << 20,
+	"gb": 1 << 30,
+	"g":  1 << 30,
+}
+
+func (p *RepoHasSizePredicate) Unmarshal(params string, negated bool) error {
+	match := repoHasSizeRegexp.FindStringSubmatch(strings.TrimSpace(params))
+	if match == nil {
+		return errors.Errorf("invalid repo:has.size() argument %q, expected a comparison such as >10MB or <1000 files", params)
+	}
+
+	value, err := strconv.ParseFloat(match[2], 64)
+	if err != nil {
+		return errors.Errorf("invalid repo:has.size() value %q", match[2])
+	}
+
+	unit := strings.ToLower(match[3])
+	switch unit {
+	case "file", "files":
+		p.Files = true
+	default:
+		multiplier, ok := sizeUnits[unit]
+		if !ok {
+			return errors.Errorf("invalid repo:has.size() unit %q, expected one of B, KB, MB, GB or files", match[3])
+		}
+		value *= multiplier
+	}
+
+	p.Comparator = match[1]
+	p.Value = int64(value)
+	p.Negated = negated
+	return nil
+}
+
+func (p *RepoHasSizePredicate) Field() string { return FieldRepo }
+func (p *RepoHasSizePredicate) Name() string  { return "has.size" }
+
 /* file:contains.content(pattern) */
 
 type FileContainsContentPredicate struct {
@@ -323,3 +426,23 @@ func (f *FileContainsContentPredicate) Unmarshal(params string, negated bool) er
 
 func (f FileContainsContentPredicate) Field() string { return FieldFile }
 func (f FileContainsContentPredicate) Name() string  { return "contains.content" }
+
+/* file:has.owner(owner) */
+
+type FileHasOwnerPredicate struct {
+	Owner   string
+	Negated bool
+}
+
+func (f *FileHasOwnerPredicate) Unmarshal(params string, negated bool) error {
+	params = strings.TrimSpace(params)
+	if params == "" || params == "@" {
+		return errors.Errorf("file:has.owner argument should not be empty")
+	}
+	f.Owner = params
+	f.Negated = negated
+	return nil
+}
+
+func (f FileHasOwnerPredicate) Field() string { return FieldFile }
+func (f FileHasOwnerPredicate) Name() string  { return "has.owner" }
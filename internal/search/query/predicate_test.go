@@ -124,3 +124,152 @@ func TestRepoHasDescriptionPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestFileHasOwnerPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			negated  bool
+			expected *FileHasOwnerPredicate
+		}
+
+		valid := []test{
+			{`team`, `@sourcegraph/search`, false, &FileHasOwnerPredicate{Owner: "@sourcegraph/search"}},
+			{`email`, `alice@example.com`, false, &FileHasOwnerPredicate{Owner: "alice@example.com"}},
+			{`negated`, `@alice`, true, &FileHasOwnerPredicate{Owner: "@alice", Negated: true}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasOwnerPredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, false, nil},
+			{`only @`, `@`, false, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasOwnerPredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
+
+func TestRepoHasLanguagePredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			negated  bool
+			expected *RepoHasLanguagePredicate
+		}
+
+		valid := []test{
+			{`language`, `go`, false, &RepoHasLanguagePredicate{Language: "Go"}},
+			{`alias`, `ts`, false, &RepoHasLanguagePredicate{Language: "TypeScript"}},
+			{`percent`, `go>50%`, false, &RepoHasLanguagePredicate{Language: "Go", Comparator: ">", Percent: 50}},
+			{`spaces`, ` go >= 12.5 % `, false, &RepoHasLanguagePredicate{Language: "Go", Comparator: ">=", Percent: 12.5}},
+			{`without percent sign`, `python<10`, false, &RepoHasLanguagePredicate{Language: "Python", Comparator: "<", Percent: 10}},
+			{`negated`, `java`, true, &RepoHasLanguagePredicate{Language: "Java", Negated: true}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RepoHasLanguagePredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, false, nil},
+			{`unknown language`, `notalanguage`, false, nil},
+			{`missing percent`, `go>`, false, nil},
+			{`equals`, `go=50%`, false, nil},
+			{`percent out of range`, `go>150%`, false, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RepoHasLanguagePredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
+
+func TestRepoHasSizePredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			negated  bool
+			expected *RepoHasSizePredicate
+		}
+
+		valid := []test{
+			{`bytes`, `>1000`, false, &RepoHasSizePredicate{Comparator: ">", Value: 1000}},
+			{`megabytes`, `<=10MB`, false, &RepoHasSizePredicate{Comparator: "<=", Value: 10 << 20}},
+			{`fractional gigabytes`, `>1.5GB`, false, &RepoHasSizePredicate{Comparator: ">", Value: 3 << 29}},
+			{`lowercase unit`, `< 2 kb`, false, &RepoHasSizePredicate{Comparator: "<", Value: 2 << 10}},
+			{`files`, `>1000 files`, false, &RepoHasSizePredicate{Comparator: ">", Value: 1000, Files: true}},
+			{`negated`, `>=1 file`, true, &RepoHasSizePredicate{Comparator: ">=", Value: 1, Files: true, Negated: true}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RepoHasSizePredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, false, nil},
+			{`missing comparator`, `10MB`, false, nil},
+			{`unknown unit`, `>10TB`, false, nil},
+			{`missing value`, `>MB`, false, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RepoHasSizePredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
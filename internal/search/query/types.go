@@ -393,6 +393,19 @@ func (p Parameters) FileContainsContent() (include []string) {
 	return include
 }
 
+// FileHasOwner returns the owners of file:has.owner() predicates, split into
+// the owners a file must have and the owners it must not have.
+func (p Parameters) FileHasOwner() (include, exclude []string) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasOwnerPredicate) {
+		if pred.Negated {
+			exclude = append(exclude, pred.Owner)
+		} else {
+			include = append(include, pred.Owner)
+		}
+	})
+	return include, exclude
+}
+
 type RepoHasCommitAfterArgs struct {
 	TimeRef string
 	Negated bool
@@ -444,6 +457,72 @@ func (p Parameters) RepoHasKVPs() (res []RepoKVPFilter) {
 	return res
 }
 
+// RepoLanguageFilter filters repositories by the languages of their code. If
+// Comparator is empty, any repository containing code in Language matches.
+type RepoLanguageFilter struct {
+	Language   string
+	Comparator string
+	Percent    float64
+	Negated    bool
+}
+
+func (f RepoLanguageFilter) String() string {
+	s := f.Language
+	if f.Comparator != "" {
+		s += f.Comparator + strconv.FormatFloat(f.Percent, 'f', -1, 64) + "%"
+	}
+	if f.Negated {
+		s = "-" + s
+	}
+	return s
+}
+
+func (p Parameters) RepoHasLanguages() (res []RepoLanguageFilter) {
+	VisitTypedPredicate(toNodes(p), func(pred *RepoHasLanguagePredicate) {
+		res = append(res, RepoLanguageFilter{
+			Language:   pred.Language,
+			Comparator: pred.Comparator,
+			Percent:    pred.Percent,
+			Negated:    pred.Negated,
+		})
+	})
+	return res
+}
+
+// RepoSizeFilter filters repositories by their size in bytes or, if Files is
+// true, by their number of files.
+type RepoSizeFilter struct {
+	Comparator string
+	Value      int64
+	Files      bool
+	Negated    bool
+}
+
+func (f RepoSizeFilter) String() string {
+	s := f.Comparator + strconv.FormatInt(f.Value, 10)
+	if f.Files {
+		s += " files"
+	} else {
+		s += "B"
+	}
+	if f.Negated {
+		s = "-" + s
+	}
+	return s
+}
+
+func (p Parameters) RepoHasSizes() (res []RepoSizeFilter) {
+	VisitTypedPredicate(toNodes(p), func(pred *RepoHasSizePredicate) {
+		res = append(res, RepoSizeFilter{
+			Comparator: pred.Comparator,
+			Value:      pred.Value,
+			Files:      pred.Files,
+			Negated:    pred.Negated,
+		})
+	})
+	return res
+}
+
 // Exists returns whether a parameter exists in the query (whether negated or not).
 func (p Parameters) Exists(field string) bool {
 	found := false
@@ -292,6 +292,9 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldSelect:
 		return satisfies(isSingular, isNotNegated, isValidSelect)
+	case
+		FieldContainer:
+		return satisfies(isSingular, isNotNegated)
 	default:
 		return isUnrecognizedField()
 	}
@@ -352,6 +355,24 @@ func validateCommitParameters(nodes []Node) error {
 	return nil
 }
 
+// Queries containing symbol parameters without type:symbol are not valid.
+func validateSymbolParameters(nodes []Node) error {
+	var seenSymbolParam string
+	var typeSymbolExists bool
+	VisitParameter(nodes, func(field, value string, _ bool, _ Annotation) {
+		if field == FieldContainer {
+			seenSymbolParam = field
+		}
+		if field == FieldType && strings.EqualFold(value, "symbol") {
+			typeSymbolExists = true
+		}
+	})
+	if seenSymbolParam != "" && !typeSymbolExists {
+		return errors.Errorf(`your query contains the field '%s', which requires type:symbol in the query`, seenSymbolParam)
+	}
+	return nil
+}
+
 func validateTypeStructural(nodes []Node) error {
 	seenStructural := false
 	seenType := false
@@ -492,6 +513,7 @@ func validate(nodes []Node) error {
 		validateRepoRevPair,
 		validateRepoHasFile,
 		validateCommitParameters,
+		validateSymbolParameters,
 		validateTypeStructural,
 		validateRefGlobs,
 	)
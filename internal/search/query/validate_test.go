@@ -83,6 +83,10 @@ func TestValidation(t *testing.T) {
 			input: "repo:foo author:rob@saucegraph.com",
 			want:  `your query contains the field 'author', which requires type:commit or type:diff in the query`,
 		},
+		{
+			input: "container:Foo bar",
+			want:  `your query contains the field 'container', which requires type:symbol in the query`,
+		},
 		{
 			input: "repohasfile:README type:symbol yolo",
 			want:  "repohasfile is not compatible for type:symbol. Subscribe to https://github.com/sourcegraph/sourcegraph/issues/4610 for updates",
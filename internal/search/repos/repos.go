@@ -140,12 +140,34 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (_ Resolv
 		})
 	}
 
+	languageFilters := make([]database.RepoLanguageFilter, 0, len(op.HasLanguages))
+	for _, filter := range op.HasLanguages {
+		languageFilters = append(languageFilters, database.RepoLanguageFilter{
+			Language:   filter.Language,
+			Comparator: filter.Comparator,
+			Percent:    filter.Percent,
+			Negated:    filter.Negated,
+		})
+	}
+
+	sizeFilters := make([]database.RepoSizeFilter, 0, len(op.HasSizes))
+	for _, filter := range op.HasSizes {
+		sizeFilters = append(sizeFilters, database.RepoSizeFilter{
+			Comparator: filter.Comparator,
+			Value:      filter.Value,
+			Files:      filter.Files,
+			Negated:    filter.Negated,
+		})
+	}
+
 	options := database.ReposListOptions{
 		IncludePatterns:       includePatterns,
 		ExcludePattern:        query.UnionRegExps(excludePatterns),
 		DescriptionPatterns:   op.DescriptionPatterns,
 		CaseSensitivePatterns: op.CaseSensitiveRepoFilters,
 		KVPFilters:            kvpFilters,
+		LanguageFilters:       languageFilters,
+		SizeFilters:           sizeFilters,
 		Cursors:               op.Cursors,
 		// List N+1 repos so we can see if there are repos omitted due to our repo limit.
 		LimitOffset:  &database.LimitOffset{Limit: limit + 1},
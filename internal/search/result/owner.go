@@ -0,0 +1,44 @@
+package result
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// OwnerMatch is an owner of matched files, as defined by a CODEOWNERS file.
+// It is the result of select:file.owners.
+type OwnerMatch struct {
+	// Handle is the owner as written in the CODEOWNERS file, for example
+	// "@sourcegraph/search" or "alice@example.com".
+	Handle string
+}
+
+func (o *OwnerMatch) RepoName() types.MinimalRepo {
+	// Owners are not associated with a single repository.
+	return types.MinimalRepo{}
+}
+
+func (o *OwnerMatch) Limit(limit int) int {
+	// Always represents one result and limit > 0 so we just return limit - 1.
+	return limit - 1
+}
+
+func (o *OwnerMatch) ResultCount() int {
+	return 1
+}
+
+func (o *OwnerMatch) Select(path filter.SelectPath) Match {
+	if path.Root() == filter.File && len(path) > 1 && path[1] == "owners" {
+		return o
+	}
+	return nil
+}
+
+func (o *OwnerMatch) Key() Key {
+	return Key{
+		TypeRank: rankOwnerMatch,
+		Owner:    o.Handle,
+	}
+}
+
+func (o *OwnerMatch) searchResultMarker() {}
@@ -3,6 +3,7 @@ package result
 import (
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -240,3 +241,17 @@ func SelectSymbolKind(symbols []*SymbolMatch, field string) []*SymbolMatch {
 		return field == toSelectKind[strings.ToLower(s.Symbol.Kind)]
 	})
 }
+
+// SymbolKindsForSelect returns the internal symbol kinds that SelectSymbolKind
+// keeps for the given selector kind value, so that symbol searches can filter
+// by kind before results are returned.
+func SymbolKindsForSelect(field string) []string {
+	var kinds []string
+	for kind, selectKind := range toSelectKind {
+		if selectKind == field {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
@@ -53,3 +53,15 @@ func TestSymbolURL(t *testing.T) {
 		})
 	}
 }
+
+func TestSymbolKindsForSelect(t *testing.T) {
+	require.Equal(t, []string{"method", "methodspec"}, SymbolKindsForSelect("method"))
+	require.Equal(t, []string{"anonmember", "field", "member", "recordfield"}, SymbolKindsForSelect("field"))
+	require.Empty(t, SymbolKindsForSelect("nonexistent"))
+
+	// Every kind that's returned must be selected by SelectSymbolKind.
+	for _, kind := range SymbolKindsForSelect("class") {
+		symbols := []*SymbolMatch{{Symbol: Symbol{Kind: kind}}}
+		require.Len(t, SelectSymbolKind(symbols, "class"), 1, kind)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
@@ -121,11 +122,14 @@ func searchInRepo(ctx context.Context, db database.DB, repoRevs *search.Reposito
 	symbols, err := backend.Symbols.ListTags(ctx, search.SymbolsParameters{
 		Repo:            repoRevs.Repo.Name,
 		CommitID:        commitID,
+		Revision:        inputRev,
 		Query:           patternInfo.Pattern,
 		IsCaseSensitive: patternInfo.IsCaseSensitive,
 		IsRegExp:        patternInfo.IsRegExp,
 		IncludePatterns: patternInfo.IncludePatterns,
 		ExcludePattern:  patternInfo.ExcludePattern,
+		Kinds:           symbolKinds(patternInfo.Select),
+		Container:       patternInfo.SymbolContainer,
 		// Ask for limit + 1 so we can detect whether there are more results than the limit.
 		First: limit + 1,
 	})
@@ -135,6 +139,16 @@ func searchInRepo(ctx context.Context, db database.DB, repoRevs *search.Reposito
 	return symbolsToMatches(symbols, repoRevs.Repo, commitID, inputRev), err
 }
 
+// symbolKinds returns the ctags kinds that select:symbol.<kind> selects, so
+// that the symbols service can filter by kind instead of returning symbols
+// that the select job would drop anyway.
+func symbolKinds(selectPath filter.SelectPath) []string {
+	if selectPath.Root() != filter.Symbol || len(selectPath) != 2 {
+		return nil
+	}
+	return result.SymbolKindsForSelect(selectPath[1])
+}
+
 func symbolsToMatches(symbols []result.Symbol, repo types.MinimalRepo, commitID api.CommitID, inputRev string) result.Matches {
 	symbolsByPath := make(map[string][]result.Symbol)
 	for _, symbol := range symbols {
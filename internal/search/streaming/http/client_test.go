@@ -48,6 +48,10 @@ func TestFrontendClient(t *testing.T) {
 				Type:   CommitMatchType,
 				Detail: "test",
 			},
+			&EventOwnerMatch{
+				Type:   OwnerMatchType,
+				Handle: "@test",
+			},
 		},
 	}, {
 		Name: "filters",
@@ -163,6 +163,18 @@ type EventCommitMatch struct {
 
 func (e *EventCommitMatch) eventMatch() {}
 
+// EventOwnerMatch is an owner of matched files, as returned by
+// select:file.owners.
+type EventOwnerMatch struct {
+	// Type is always OwnerMatchType. Included here for marshalling.
+	Type MatchType `json:"type"`
+
+	// Handle is the owner as written in the CODEOWNERS file.
+	Handle string `json:"handle"`
+}
+
+func (e *EventOwnerMatch) eventMatch() {}
+
 // EventFilter is a suggestion for a search filter. Currently has a 1-1
 // correspondance with the SearchFilter graphql type.
 type EventFilter struct {
@@ -208,8 +220,28 @@ const (
 	SymbolMatchType
 	CommitMatchType
 	PathMatchType
+	OwnerMatchType
 )
 
+func (t MatchType) String() string {
+	switch t {
+	case ContentMatchType:
+		return "content"
+	case RepoMatchType:
+		return "repo"
+	case SymbolMatchType:
+		return "symbol"
+	case CommitMatchType:
+		return "commit"
+	case PathMatchType:
+		return "path"
+	case OwnerMatchType:
+		return "owner"
+	default:
+		return "unknown"
+	}
+}
+
 func (t MatchType) MarshalJSON() ([]byte, error) {
 	switch t {
 	case ContentMatchType:
@@ -222,6 +254,8 @@ func (t MatchType) MarshalJSON() ([]byte, error) {
 		return []byte(`"commit"`), nil
 	case PathMatchType:
 		return []byte(`"path"`), nil
+	case OwnerMatchType:
+		return []byte(`"owner"`), nil
 	default:
 		return nil, errors.Errorf("unknown MatchType: %d", t)
 	}
@@ -238,6 +272,8 @@ func (t *MatchType) UnmarshalJSON(b []byte) error {
 		*t = CommitMatchType
 	} else if bytes.Equal(b, []byte(`"path"`)) {
 		*t = PathMatchType
+	} else if bytes.Equal(b, []byte(`"owner"`)) {
+		*t = OwnerMatchType
 	} else {
 		return errors.Errorf("unknown MatchType: %s", b)
 	}
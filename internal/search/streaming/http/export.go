@@ -0,0 +1,224 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ExportFormat is the format of the rows written by the search export
+// endpoint.
+type ExportFormat string
+
+const (
+	// ExportFormatJSON writes one JSON encoded ExportRow per line.
+	ExportFormatJSON ExportFormat = "json"
+
+	// ExportFormatCSV writes a header followed by one CSV record per row.
+	ExportFormatCSV ExportFormat = "csv"
+)
+
+// ExportRow is a single row of a search export. Every match is flattened into
+// one or more rows: a content match has a row per matched line, a symbol match
+// has a row per symbol, and path, commit and repository matches have a single
+// row.
+type ExportRow struct {
+	// Type is the type of the match the row belongs to.
+	Type MatchType `json:"type"`
+
+	RepositoryID int32  `json:"repositoryID"`
+	Repository   string `json:"repository"`
+	Revision     string `json:"revision,omitempty"`
+	Commit       string `json:"commit,omitempty"`
+	Path         string `json:"path,omitempty"`
+
+	// LineNumber is the 1-based line number of the matched line or symbol. It
+	// is zero for rows that do not refer to a line.
+	LineNumber int32 `json:"lineNumber,omitempty"`
+
+	// Line is the content of the matched line.
+	Line string `json:"line,omitempty"`
+
+	// Ranges are the [start, end) rune columns of the matches in Line, or in
+	// Path for path matches.
+	Ranges [][2]int32 `json:"ranges,omitempty"`
+
+	SymbolName      string `json:"symbolName,omitempty"`
+	SymbolKind      string `json:"symbolKind,omitempty"`
+	SymbolContainer string `json:"symbolContainer,omitempty"`
+
+	AuthorName string     `json:"authorName,omitempty"`
+	AuthorDate *time.Time `json:"authorDate,omitempty"`
+	Message    string     `json:"message,omitempty"`
+
+	// Cursor can be passed to the export endpoint to resume the export after
+	// this row.
+	Cursor string `json:"cursor"`
+}
+
+// ExportCSVHeader is the header of exports in the CSV format. The columns
+// correspond to the fields of ExportRow.
+var ExportCSVHeader = []string{
+	"type",
+	"repository_id",
+	"repository",
+	"revision",
+	"commit",
+	"path",
+	"line_number",
+	"line",
+	"ranges",
+	"symbol_name",
+	"symbol_kind",
+	"symbol_container",
+	"author_name",
+	"author_date",
+	"message",
+	"cursor",
+}
+
+func (r *ExportRow) csvRecord() []string {
+	var lineNumber, authorDate string
+	if r.LineNumber > 0 {
+		lineNumber = strconv.Itoa(int(r.LineNumber))
+	}
+	if r.AuthorDate != nil {
+		authorDate = r.AuthorDate.Format(time.RFC3339)
+	}
+
+	// Ranges are written as "start-end" pairs separated by semicolons.
+	ranges := make([]string, 0, len(r.Ranges))
+	for _, rg := range r.Ranges {
+		ranges = append(ranges, strconv.Itoa(int(rg[0]))+"-"+strconv.Itoa(int(rg[1])))
+	}
+
+	return []string{
+		r.Type.String(),
+		strconv.Itoa(int(r.RepositoryID)),
+		r.Repository,
+		r.Revision,
+		r.Commit,
+		r.Path,
+		lineNumber,
+		r.Line,
+		strings.Join(ranges, ";"),
+		r.SymbolName,
+		r.SymbolKind,
+		r.SymbolContainer,
+		r.AuthorName,
+		authorDate,
+		r.Message,
+		r.Cursor,
+	}
+}
+
+// ExportWriter writes the rows of a search export to an HTTP response.
+type ExportWriter struct {
+	w     io.Writer
+	flush func()
+	csv   *csv.Writer
+
+	written bool
+}
+
+func NewExportWriter(w http.ResponseWriter, format ExportFormat) (*ExportWriter, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, errors.New("http flushing not supported")
+	}
+
+	switch format {
+	case ExportFormatJSON:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	case ExportFormatCSV:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	default:
+		return nil, errors.Errorf("unsupported export format %q", format)
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+
+	// This informs nginx to not buffer, see NewWriter.
+	w.Header().Set("X-Accel-Buffering", "no")
+
+	ew := &ExportWriter{
+		w:     w,
+		flush: flusher.Flush,
+	}
+	if format == ExportFormatCSV {
+		ew.csv = csv.NewWriter(w)
+	}
+	return ew, nil
+}
+
+// Written returns whether any data has been written to the response.
+func (e *ExportWriter) Written() bool {
+	return e.written
+}
+
+// Row writes a single row. Rows are buffered until Flush is called.
+func (e *ExportWriter) Row(row *ExportRow) error {
+	if e.csv != nil {
+		if err := e.writeCSVHeader(); err != nil {
+			return err
+		}
+		return e.csv.Write(row.csvRecord())
+	}
+
+	encoded, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	e.written = true
+	_, err = e.w.Write(append(encoded, '\n'))
+	return err
+}
+
+// Error reports an error that occurred after rows have been written. The
+// error is written as a JSON object with an "error" field in the JSON format.
+// The CSV format has no way to report errors, so the error is only flushed.
+// In both cases the export can be resumed with the cursor of the last row.
+func (e *ExportWriter) Error(err error) error {
+	if e.csv == nil {
+		encoded, merr := json.Marshal(struct {
+			Error string `json:"error"`
+		}{Error: err.Error()})
+		if merr != nil {
+			return merr
+		}
+		e.written = true
+		if _, werr := e.w.Write(append(encoded, '\n')); werr != nil {
+			return werr
+		}
+	}
+	return e.Flush()
+}
+
+// Flush sends the buffered rows to the client.
+func (e *ExportWriter) Flush() error {
+	if e.csv != nil {
+		// Always write the header so that empty exports are valid CSV files.
+		if err := e.writeCSVHeader(); err != nil {
+			return err
+		}
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	e.flush()
+	return nil
+}
+
+func (e *ExportWriter) writeCSVHeader() error {
+	if e.written {
+		return nil
+	}
+	e.written = true
+	return e.csv.Write(ExportCSVHeader)
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestExportWriter(t *testing.T) {
+	t.Parallel()
+
+	authorDate := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	rows := []*ExportRow{{
+		Type:         ContentMatchType,
+		RepositoryID: 1,
+		Repository:   "github.com/sourcegraph/sourcegraph",
+		Commit:       "abc",
+		Path:         "main.go",
+		LineNumber:   3,
+		Line:         `fmt.Println("a, b")`,
+		Ranges:       [][2]int32{{0, 3}, {4, 11}},
+		Cursor:       "c1",
+	}, {
+		Type:         CommitMatchType,
+		RepositoryID: 1,
+		Repository:   "github.com/sourcegraph/sourcegraph",
+		Commit:       "def",
+		AuthorName:   "alice",
+		AuthorDate:   &authorDate,
+		Message:      "fix\n\nbody",
+		Cursor:       "c2",
+	}}
+
+	t.Run("json", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		w, err := NewExportWriter(rec, ExportFormatJSON)
+		require.NoError(t, err)
+		require.False(t, w.Written())
+
+		for _, row := range rows {
+			require.NoError(t, w.Row(row))
+		}
+		require.NoError(t, w.Error(errors.New("boom")))
+
+		require.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+		require.Equal(t, ``+
+			`{"type":"content","repositoryID":1,"repository":"github.com/sourcegraph/sourcegraph","commit":"abc","path":"main.go","lineNumber":3,"line":"fmt.Println(\"a, b\")","ranges":[[0,3],[4,11]],"cursor":"c1"}`+"\n"+
+			`{"type":"commit","repositoryID":1,"repository":"github.com/sourcegraph/sourcegraph","commit":"def","authorName":"alice","authorDate":"2022-10-01T12:00:00Z","message":"fix\n\nbody","cursor":"c2"}`+"\n"+
+			`{"error":"boom"}`+"\n",
+			rec.Body.String(),
+		)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		w, err := NewExportWriter(rec, ExportFormatCSV)
+		require.NoError(t, err)
+
+		for _, row := range rows {
+			require.NoError(t, w.Row(row))
+		}
+		require.NoError(t, w.Flush())
+
+		require.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+		require.Equal(t, ""+
+			"type,repository_id,repository,revision,commit,path,line_number,line,ranges,symbol_name,symbol_kind,symbol_container,author_name,author_date,message,cursor\n"+
+			`content,1,github.com/sourcegraph/sourcegraph,,abc,main.go,3,"fmt.Println(""a, b"")",0-3;4-11,,,,,,,c1`+"\n"+
+			"commit,1,github.com/sourcegraph/sourcegraph,,def,,,,,,,,alice,2022-10-01T12:00:00Z,\"fix\n\nbody\",c2\n",
+			rec.Body.String(),
+		)
+	})
+
+	t.Run("empty csv has a header", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		w, err := NewExportWriter(rec, ExportFormatCSV)
+		require.NoError(t, err)
+		require.NoError(t, w.Flush())
+		require.Equal(t, "type,repository_id,repository,revision,commit,path,line_number,line,ranges,symbol_name,symbol_kind,symbol_container,author_name,author_date,message,cursor\n", rec.Body.String())
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := NewExportWriter(httptest.NewRecorder(), ExportFormat("xml"))
+		require.Error(t, err)
+	})
+}
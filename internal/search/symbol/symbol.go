@@ -228,6 +228,9 @@ func Compute(ctx context.Context, checker authz.SubRepoPermissionChecker, repoNa
 		IncludePatterns: includePatternsSlice,
 		Timeout:         int(serverTimeout.Seconds()),
 	}
+	if inputRev != nil {
+		searchArgs.Revision = *inputRev
+	}
 	if query != nil {
 		searchArgs.Query = *query
 	}
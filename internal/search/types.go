@@ -77,6 +77,10 @@ type SymbolsParameters struct {
 	// CommitID is the commit to search in.
 	CommitID api.CommitID `json:"commitID"`
 
+	// Revision is the revision (e.g. a branch or tag name) that CommitID was resolved from, if
+	// known. Rockskip uses it to keep track of which refs of a repository are indexed.
+	Revision string `json:"revision,omitempty"`
+
 	// Query is the search query.
 	Query string
 
@@ -100,6 +104,16 @@ type SymbolsParameters struct {
 	// need to match to get included in the result
 	ExcludePattern string
 
+	// Kinds is an optional list of ctags kinds (e.g. "method" or "class"). If it
+	// is set, only symbols of one of these kinds are returned. Kinds are
+	// matched case-insensitively.
+	Kinds []string
+
+	// Container is an optional name of the symbol (e.g. a class) that contains
+	// the symbols to return. It is matched exactly against the parent of each
+	// symbol, ignoring case unless IsCaseSensitive is set.
+	Container string
+
 	// First indicates that only the first n symbols should be returned.
 	First int
 
@@ -211,6 +225,10 @@ type TextPatternInfo struct {
 	PatternMatchesPath    bool
 
 	Languages []string
+
+	// SymbolContainer is the value of the container: filter, which scopes
+	// symbol searches to the symbols of a parent symbol.
+	SymbolContainer string
 }
 
 func (p *TextPatternInfo) Fields() []otlog.Field {
@@ -261,6 +279,9 @@ func (p *TextPatternInfo) Fields() []otlog.Field {
 	if len(p.Languages) > 0 {
 		add(trace.Strings("languages", p.Languages))
 	}
+	if p.SymbolContainer != "" {
+		add(otlog.String("symbolContainer", p.SymbolContainer))
+	}
 	return res
 }
 
@@ -294,6 +315,9 @@ func (p *TextPatternInfo) String() string {
 	for _, lang := range p.Languages {
 		args = append(args, fmt.Sprintf("lang:%s", lang))
 	}
+	if p.SymbolContainer != "" {
+		args = append(args, fmt.Sprintf("container:%q", p.SymbolContainer))
+	}
 
 	path := "f"
 	if p.PathPatternsAreCaseSensitive {
@@ -368,6 +392,8 @@ type RepoOptions struct {
 	UseIndex       query.YesNoOnly
 	HasFileContent []query.RepoHasFileContentArgs
 	HasKVPs        []query.RepoKVPFilter
+	HasLanguages   []query.RepoLanguageFilter
+	HasSizes       []query.RepoSizeFilter
 
 	// ForkSet indicates whether `fork:` was set explicitly in the query,
 	// or whether the values were set from defaults.
@@ -451,6 +477,12 @@ func (op *RepoOptions) Tags() []otlog.Field {
 			add(trace.Scoped(fmt.Sprintf("hasKVPs[%d]", i), nondefault...))
 		}
 	}
+	for i, arg := range op.HasLanguages {
+		add(otlog.String(fmt.Sprintf("hasLanguages[%d]", i), arg.String()))
+	}
+	for i, arg := range op.HasSizes {
+		add(otlog.String(fmt.Sprintf("hasSizes[%d]", i), arg.String()))
+	}
 	if op.ForkSet {
 		add(otlog.Bool("forkSet", op.ForkSet))
 	}
@@ -527,6 +559,12 @@ func (op *RepoOptions) String() string {
 			}
 		}
 	}
+	for i, arg := range op.HasLanguages {
+		fmt.Fprintf(&b, "HasLanguages[%d]: %s\n", i, arg)
+	}
+	for i, arg := range op.HasSizes {
+		fmt.Fprintf(&b, "HasSizes[%d]: %s\n", i, arg)
+	}
 
 	if op.CaseSensitiveRepoFilters {
 		fmt.Fprintf(&b, "CaseSensitiveRepoFilters: %t\n", op.CaseSensitiveRepoFilters)
@@ -295,6 +295,87 @@ func (c *Client) SymbolInfo(ctx context.Context, args types.RepoCommitPathPoint)
 	return result, nil
 }
 
+func (c *Client) SymbolReferences(ctx context.Context, args types.RepoCommitPathPoint) (result *types.SymbolReferences, err error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "squirrel.Client.SymbolReferences")
+	defer func() {
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.LogFields(otlog.Error(err))
+		}
+		span.Finish()
+	}()
+	span.SetTag("Repo", args.Repo)
+	span.SetTag("CommitID", args.Commit)
+
+	resp, err := c.httpPost(ctx, "symbolReferences", api.RepoName(args.Repo), args)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// best-effort inclusion of body in error message
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return nil, errors.Errorf(
+			"Squirrel.SymbolReferences http status %d: %s",
+			resp.StatusCode,
+			string(body),
+		)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding response body")
+	}
+
+	// 🚨 SECURITY: We have a valid result, so we need to apply sub-repo permissions filtering.
+	if result == nil || c.SubRepoPermsChecker == nil {
+		return result, err
+	}
+
+	checker := c.SubRepoPermsChecker()
+	if !authz.SubRepoEnabled(checker) {
+		return result, err
+	}
+
+	a := actor.FromContext(ctx)
+	canRead := func(path string) (bool, error) {
+		perm, err := authz.ActorPermissions(ctx, checker, a, authz.RepoContent{
+			Repo: api.RepoName(args.Repo),
+			Path: path,
+		})
+		if err != nil {
+			return false, errors.Wrap(err, "checking sub-repo permissions")
+		}
+		return perm.Include(authz.Read), nil
+	}
+
+	for _, path := range []string{args.Path, result.Definition.Path} {
+		ok, err := canRead(path)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, nil
+		}
+	}
+
+	// Filter in place
+	filtered := result.References[:0]
+	for _, ref := range result.References {
+		ok, err := canRead(ref.Path)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filtered = append(filtered, ref)
+		}
+	}
+	result.References = filtered
+
+	return result, nil
+}
+
 func (c *Client) httpPost(
 	ctx context.Context,
 	method string,
@@ -147,3 +147,70 @@ func TestDefinitionWithFiltering(t *testing.T) {
 		t.Fatal("expected nil result when getting a definition for an unauthorized path")
 	}
 }
+
+func TestReferencesWithFiltering(t *testing.T) {
+	start := types.RepoCommitPathPoint{
+		RepoCommitPath: types.RepoCommitPath{Repo: "somerepo", Commit: "somecommit", Path: "start"},
+		Point:          types.Point{Row: 0, Column: 0},
+	}
+	reference := func(path string) types.SymbolReference {
+		return types.SymbolReference{
+			RepoCommitPathRange: types.RepoCommitPathRange{
+				RepoCommitPath: types.RepoCommitPath{Repo: "somerepo", Commit: "somecommit", Path: path},
+			},
+			Confidence: types.ReferenceConfidenceScopeResolved,
+		}
+	}
+	fixture := types.SymbolReferences{
+		Definition: types.RepoCommitPathMaybeRange{
+			RepoCommitPath: types.RepoCommitPath{Repo: "somerepo", Commit: "somecommit", Path: "def"},
+			Range:          &types.Range{},
+		},
+		References: []types.SymbolReference{reference("allowed"), reference("denied")},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(fixture)
+	}))
+	t.Cleanup(srv.Close)
+
+	denied := map[string]bool{"denied": true}
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultHook(func() bool {
+		return true
+	})
+	checker.PermissionsFunc.SetDefaultHook(func(ctx context.Context, i int32, content authz.RepoContent) (authz.Perms, error) {
+		if denied[content.Path] {
+			return authz.None, nil
+		}
+		return authz.Read, nil
+	})
+	client := &Client{
+		URL:                 srv.URL,
+		HTTPClient:          http.DefaultClient,
+		SubRepoPermsChecker: func() authz.SubRepoPermissionChecker { return checker },
+	}
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	results, err := client.SymbolReferences(ctx, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results == nil {
+		t.Fatal("nil result")
+	}
+	if len(results.References) != 1 || results.References[0].Path != "allowed" {
+		t.Fatalf("expected only the allowed reference, got %+v", results.References)
+	}
+
+	// The whole result is hidden when the definition can't be read.
+	denied["def"] = true
+	results, err = client.SymbolReferences(ctx, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results != nil {
+		t.Fatal("expected nil result when the definition is in an unauthorized path")
+	}
+}
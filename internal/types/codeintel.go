@@ -200,3 +200,25 @@ func (s SymbolInfo) String() string {
 	}
 	return fmt.Sprintf("SymbolInfo{Definition: %s %s, Hover: %q}", s.Definition.RepoCommitPath, rnge, hover)
 }
+
+type SymbolReferences struct {
+	Definition RepoCommitPathMaybeRange `json:"definition"`
+	References []SymbolReference        `json:"references"`
+}
+
+type SymbolReference struct {
+	RepoCommitPathRange
+	Confidence ReferenceConfidence `json:"confidence"`
+}
+
+// ReferenceConfidence describes how a reference was found.
+type ReferenceConfidence string
+
+const (
+	// ReferenceConfidenceScopeResolved is used for references whose definition was resolved to the
+	// symbol through scopes, imports and types.
+	ReferenceConfidenceScopeResolved ReferenceConfidence = "scope-resolved"
+	// ReferenceConfidenceNameOnly is used for references that have the same name as the symbol, but
+	// whose definition could not be resolved.
+	ReferenceConfidenceNameOnly ReferenceConfidence = "name-only"
+)
@@ -0,0 +1,28 @@
+package types
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// RepoInventory summarizes the files on the default branch of a repository.
+// It is used to filter repositories by language and file count before a
+// search is run.
+type RepoInventory struct {
+	RepoID     api.RepoID
+	CommitID   api.CommitID // the commit the inventory was computed at, empty if it was never computed successfully
+	FileCount  int64        // the number of files with a detected language
+	TotalBytes int64        // the total size of the files with a detected language
+	Languages  []RepoLanguage
+	UpdatedAt  time.Time
+}
+
+// RepoLanguage describes the files of a repository written in a single
+// language.
+type RepoLanguage struct {
+	Name       string
+	TotalBytes int64
+	TotalLines int64
+	TotalFiles int64
+}
@@ -1,5 +1,11 @@
 package types
 
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
 // SavedSearch represents a saved search
 type SavedSearch struct {
 	ID              int32 // the globally unique DB ID
@@ -11,3 +17,40 @@ type SavedSearch struct {
 	OrgID           *int32  // if non-nil, the owner is this organization. UserID/OrgID are mutually exclusive.
 	SlackWebhookURL *string // if non-nil && NotifySlack == true, indicates that this Slack webhook URL should be used instead of the owners default Slack webhook.
 }
+
+// SavedSearchSchedule describes how often a saved search is run in the
+// background and the outcome of its last run.
+type SavedSearchSchedule struct {
+	SavedSearchID   int32
+	IntervalMinutes int32      // the number of minutes between two runs
+	NextRunAt       time.Time  // the time after which the saved search is run next
+	LastRunAt       *time.Time // the time of the last successful run, if any
+	LastRunError    *string    // the error of the last run, if it failed
+}
+
+// SavedSearchResultType is the type of a result stored for a scheduled saved
+// search. It matches the type of the streaming search event the result was
+// derived from.
+type SavedSearchResultType string
+
+const (
+	SavedSearchResultTypeRepo    SavedSearchResultType = "repo"
+	SavedSearchResultTypePath    SavedSearchResultType = "path"
+	SavedSearchResultTypeContent SavedSearchResultType = "content"
+	SavedSearchResultTypeSymbol  SavedSearchResultType = "symbol"
+	SavedSearchResultTypeCommit  SavedSearchResultType = "commit"
+)
+
+// SavedSearchResult is a single result returned by a run of a scheduled saved
+// search. Content matches are split into one result per matched line and
+// symbol matches into one result per symbol.
+type SavedSearchResult struct {
+	Key         string // identifies the result across runs
+	Type        SavedSearchResultType
+	RepoID      api.RepoID
+	Path        string     // empty for repo and commit results
+	LineNumber  int32      // 1-based, zero for results that do not refer to a line
+	Label       string     // the matched line, the symbol name, the commit subject or the repository name
+	FirstSeenAt time.Time  // the time of the run that first returned the result
+	RemovedAt   *time.Time // the time of the run that no longer returned the result, if any
+}
@@ -52,6 +52,10 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		es.redactString(c.Token, "token")
 	case *schema.BitbucketCloudConnection:
 		es.redactString(c.AppPassword, "appPassword")
+	case *schema.AzureDevOpsConnection:
+		es.redactString(c.Token, "token")
+	case *schema.GiteaConnection:
+		es.redactString(c.Token, "token")
 	case *schema.AWSCodeCommitConnection:
 		es.redactString(c.SecretAccessKey, "secretAccessKey")
 		es.redactString(c.GitCredentials.Password, "gitCredentials", "password")
@@ -152,6 +156,12 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 	case *schema.BitbucketCloudConnection:
 		o := oldCfg.(*schema.BitbucketCloudConnection)
 		es.unredactString(c.AppPassword, o.AppPassword, "appPassword")
+	case *schema.AzureDevOpsConnection:
+		o := oldCfg.(*schema.AzureDevOpsConnection)
+		es.unredactString(c.Token, o.Token, "token")
+	case *schema.GiteaConnection:
+		o := oldCfg.(*schema.GiteaConnection)
+		es.unredactString(c.Token, o.Token, "token")
 	case *schema.AWSCodeCommitConnection:
 		o := oldCfg.(*schema.AWSCodeCommitConnection)
 		es.unredactString(c.SecretAccessKey, o.SecretAccessKey, "secretAccessKey")
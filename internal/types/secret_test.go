@@ -46,6 +46,16 @@ func TestExternalService_RedactedConfig(t *testing.T) {
 			in:   schema.BitbucketCloudConnection{AppPassword: "foobar", Url: "https://bitbucket.org"},
 			out:  schema.BitbucketCloudConnection{AppPassword: RedactedSecret, Url: "https://bitbucket.org"},
 		},
+		{
+			kind: extsvc.KindAzureDevOps,
+			in:   schema.AzureDevOpsConnection{Token: "foobar", Username: "admin", Url: "https://dev.azure.com"},
+			out:  schema.AzureDevOpsConnection{Token: RedactedSecret, Username: "admin", Url: "https://dev.azure.com"},
+		},
+		{
+			kind: extsvc.KindGitea,
+			in:   schema.GiteaConnection{Token: "foobar", Url: "https://gitea.com"},
+			out:  schema.GiteaConnection{Token: RedactedSecret, Url: "https://gitea.com"},
+		},
 		{
 			kind: extsvc.KindAWSCodeCommit,
 			in: schema.AWSCodeCommitConnection{
@@ -207,6 +217,18 @@ func TestExternalService_UnredactConfig(t *testing.T) {
 			in:   schema.BitbucketCloudConnection{AppPassword: RedactedSecret, Url: "https://bitbucket.corp.com"},
 			out:  schema.BitbucketCloudConnection{AppPassword: "foobar", Url: "https://bitbucket.corp.com"},
 		},
+		{
+			kind: extsvc.KindAzureDevOps,
+			old:  schema.AzureDevOpsConnection{Token: "foobar", Username: "admin", Url: "https://dev.azure.com"},
+			in:   schema.AzureDevOpsConnection{Token: RedactedSecret, Username: "admin", Url: "https://azure.corp.com"},
+			out:  schema.AzureDevOpsConnection{Token: "foobar", Username: "admin", Url: "https://azure.corp.com"},
+		},
+		{
+			kind: extsvc.KindGitea,
+			old:  schema.GiteaConnection{Token: "foobar", Url: "https://gitea.com"},
+			in:   schema.GiteaConnection{Token: RedactedSecret, Url: "https://gitea.corp.com"},
+			out:  schema.GiteaConnection{Token: "foobar", Url: "https://gitea.corp.com"},
+		},
 		{
 			kind: extsvc.KindAWSCodeCommit,
 			old: schema.AWSCodeCommitConnection{
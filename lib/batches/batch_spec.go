@@ -45,6 +45,10 @@ type ChangesetTemplate struct {
 	Branch    string                       `json:"branch,omitempty" yaml:"branch"`
 	Commit    ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
 	Published *overridable.BoolOrString    `json:"published" yaml:"published"`
+	Labels    []string                     `json:"labels,omitempty" yaml:"labels"`
+	Reviewers []string                     `json:"reviewers,omitempty" yaml:"reviewers"`
+	Assignees []string                     `json:"assignees,omitempty" yaml:"assignees"`
+	Milestone string                       `json:"milestone,omitempty" yaml:"milestone"`
 }
 
 type GitCommitAuthor struct {
@@ -95,6 +99,16 @@ type Step struct {
 	Outputs   Outputs           `json:"outputs,omitempty" yaml:"outputs,omitempty"`
 	Mount     []Mount           `json:"mount,omitempty" yaml:"mount,omitempty"`
 	If        any               `json:"if,omitempty" yaml:"if,omitempty"`
+
+	Matrix          map[string][]any `json:"matrix,omitempty" yaml:"matrix,omitempty"`
+	Timeout         string           `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Retries         int              `json:"retries,omitempty" yaml:"retries,omitempty"`
+	ContinueOnError bool             `json:"continueOnError,omitempty" yaml:"continueOnError,omitempty"`
+
+	// MatrixValues are the values of the matrix combination the step was
+	// expanded for. It is set by ParseBatchSpec, which replaces each step with
+	// a matrix by one step per combination, and can't be set in a batch spec.
+	MatrixValues map[string]any `json:"matrixValues,omitempty" yaml:"-"`
 }
 
 func (s *Step) IfCondition() string {
@@ -174,9 +188,23 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 				errs = errors.Append(errs, NewValidationError(errors.Newf("step %d mount mountpoint contains invalid characters", i+1)))
 			}
 		}
+		if _, err := step.TimeoutDuration(); err != nil {
+			errs = errors.Append(errs, NewValidationError(errors.Wrapf(err, "step %d", i+1)))
+		}
+		if step.MatrixCombinations() > maxMatrixCombinations {
+			errs = errors.Append(errs, NewValidationError(errors.Newf("step %d matrix has more than %d combinations", i+1, maxMatrixCombinations)))
+		}
+	}
+	if errs != nil {
+		return &spec, errs
 	}
 
-	return &spec, errs
+	steps, err := expandMatrixSteps(spec.Steps)
+	if err != nil {
+		return &spec, NewValidationError(err)
+	}
+	spec.Steps = steps
+	return &spec, nil
 }
 
 const invalidMountCharacters = ","
@@ -231,6 +259,7 @@ func SkippedStepsForRepo(spec *BatchSpec, repoName string, fileMatches []string)
 				FileMatches: fileMatches,
 			},
 			BatchChange: batchChange,
+			Matrix:      step.MatrixValues,
 		}
 		static, boolVal, err := template.IsStaticBool(step.IfCondition(), stepCtx)
 		if err != nil {
@@ -209,6 +209,131 @@ changesetTemplate:
 		_, err := ParseBatchSpec([]byte(spec))
 		assert.Equal(t, "step 1 mount mountpoint contains invalid characters", err.Error())
 	})
+
+	t.Run("matrix", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: go test -race=${{ matrix.race }} ./... > ${{ outputs.dir }}/go${{ matrix.go }}.log
+    container: golang:${{ matrix.go }}
+    if: ${{ and (eq matrix.go "1.18") (not matrix.race) }}
+    env:
+      GOFLAGS: -tags=go${{ matrix.go }}
+    matrix:
+      go: ["1.17", "1.18"]
+      race: [true, false]
+    timeout: 10m
+    retries: 2
+    continueOnError: true
+  - run: echo done
+    container: alpine:3
+changesetTemplate:
+  title: Test Matrix
+  body: Test a matrix
+  branch: test
+  commit:
+    message: Test
+`
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var have []map[string]any
+		for _, step := range batchSpec.Steps {
+			assert.Nil(t, step.Matrix)
+			have = append(have, step.MatrixValues)
+		}
+		want := []map[string]any{
+			{"go": "1.17", "race": true},
+			{"go": "1.17", "race": false},
+			{"go": "1.18", "race": true},
+			{"go": "1.18", "race": false},
+			nil,
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("wrong matrix values (-want +have):\n%s", diff)
+		}
+
+		// The matrix values are rendered into the templates of the expanded
+		// steps, everything else is rendered when the step runs.
+		step := batchSpec.Steps[1]
+		assert.Equal(t, "go test -race=false ./... > ${{ outputs.dir }}/go1.17.log", step.Run)
+		assert.Equal(t, "golang:1.17", step.Container)
+		assert.Equal(t, `${{ and (eq "1.17" "1.18") (not false) }}`, step.If)
+		env, err := step.Env.Resolve(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, map[string]string{"GOFLAGS": "-tags=go1.17"}, env)
+
+		for _, step := range batchSpec.Steps[:4] {
+			assert.Equal(t, "10m", step.Timeout)
+			assert.Equal(t, 2, step.Retries)
+			assert.True(t, step.ContinueOnError)
+		}
+	})
+
+	t.Run("matrix with too many combinations", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: echo ${{ matrix.a }} ${{ matrix.b }}
+    container: alpine:3
+    matrix:
+      a: [1, 2, 3, 4, 5, 6, 7, 8, 9]
+      b: [1, 2, 3, 4, 5, 6, 7, 8, 9]
+changesetTemplate:
+  title: Test Matrix
+  body: Test a matrix
+  branch: test
+  commit:
+    message: Test
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Equal(t, "step 1 matrix has more than 64 combinations", err.Error())
+	})
+
+	t.Run("unknown matrix value", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: echo ${{ matrix.b }}
+    container: alpine:3
+    matrix:
+      a: [1, 2]
+changesetTemplate:
+  title: Test Matrix
+  body: Test a matrix
+  branch: test
+  commit:
+    message: Test
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Equal(t, `step 1: run: unknown matrix value "b"`, err.Error())
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: echo Hello World
+    container: alpine:3
+    timeout: 0s
+changesetTemplate:
+  title: Test Timeout
+  body: Test a timeout
+  branch: test
+  commit:
+    message: Test
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Equal(t, `step 1: invalid timeout "0s": must be positive`, err.Error())
+	})
 }
 
 func TestOnQueryOrRepository_Branches(t *testing.T) {
@@ -80,6 +80,11 @@ type ChangesetSpec struct {
 	Commits []GitCommitDescription `json:"commits,omitempty"`
 
 	Published PublishedValue `json:"published,omitempty"`
+
+	Labels    []string `json:"labels,omitempty"`
+	Reviewers []string `json:"reviewers,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Milestone string   `json:"milestone,omitempty"`
 }
 
 // MarshalJSON overwrites the default behavior of the json lib while unmarshalling
@@ -102,6 +107,10 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Body           string                 `json:"body,omitempty"`
 		Commits        []GitCommitDescription `json:"commits,omitempty"`
 		Published      *PublishedValue        `json:"published,omitempty"`
+		Labels         []string               `json:"labels,omitempty"`
+		Reviewers      []string               `json:"reviewers,omitempty"`
+		Assignees      []string               `json:"assignees,omitempty"`
+		Milestone      string                 `json:"milestone,omitempty"`
 	}{
 		BaseRepository: c.BaseRepository,
 		ExternalID:     c.ExternalID,
@@ -112,6 +121,10 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Title:          c.Title,
 		Body:           c.Body,
 		Commits:        c.Commits,
+		Labels:         c.Labels,
+		Reviewers:      c.Reviewers,
+		Assignees:      c.Assignees,
+		Milestone:      c.Milestone,
 	}
 	if !c.Published.Nil() {
 		v.Published = &c.Published
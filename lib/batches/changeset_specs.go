@@ -2,6 +2,7 @@ package batches
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/sourcegraph/go-diff/diff"
@@ -94,6 +95,26 @@ func BuildChangesetSpecs(input *ChangesetSpecInput) ([]*ChangesetSpec, error) {
 		return nil, err
 	}
 
+	labels, err := renderChangesetTemplateList("labels", input.Template.Labels, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers, err := renderChangesetTemplateList("reviewers", input.Template.Reviewers, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	assignees, err := renderChangesetTemplateList("assignees", input.Template.Assignees, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	milestone, err := template.RenderChangesetTemplateField("milestone", input.Template.Milestone, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
 	newSpec := func(branch, diff string) (*ChangesetSpec, error) {
 		var published any = nil
 		if input.Template.Published != nil {
@@ -118,6 +139,10 @@ func BuildChangesetSpecs(input *ChangesetSpecInput) ([]*ChangesetSpec, error) {
 				},
 			},
 			Published: PublishedValue{Val: published},
+			Labels:    labels,
+			Reviewers: reviewers,
+			Assignees: assignees,
+			Milestone: milestone,
 		}, nil
 	}
 
@@ -154,6 +179,23 @@ func BuildChangesetSpecs(input *ChangesetSpecInput) ([]*ChangesetSpec, error) {
 	return specs, nil
 }
 
+// renderChangesetTemplateList renders each of the given templates and returns the
+// rendered values that are not empty, so that a template can conditionally omit a
+// value, e.g. a label that only applies to some repositories.
+func renderChangesetTemplateList(name string, tmpls []string, tmplCtx *template.ChangesetTemplateContext) ([]string, error) {
+	var values []string
+	for i, tmpl := range tmpls {
+		value, err := template.RenderChangesetTemplateField(fmt.Sprintf("%s[%d]", name, i), tmpl, tmplCtx)
+		if err != nil {
+			return nil, err
+		}
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
 type RepoFetcher func(context.Context, []string) (map[string]string, error)
 
 func BuildImportChangesetSpecs(ctx context.Context, importChangesets []ImportChangeset, repoFetcher RepoFetcher) (specs []*ChangesetSpec, errs error) {
@@ -144,6 +144,32 @@ func TestCreateChangesetSpecs(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "labels, reviewers, assignees and milestone",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Labels = []string{"batch-change", "${{ batch_change.name }}", `${{ if eq repository.name "github.com/sourcegraph/sourcegraph" }}frontend${{ end }}`}
+				input.Template.Reviewers = []string{"alice", "sourcegraph/batchers"}
+				input.Template.Assignees = []string{"${{ repository.branch }}-owner"}
+				input.Template.Milestone = "Release ${{ len repository.search_result_paths }}"
+				input.Template.Published = parsePublishedFieldString(t, "false")
+			}),
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.Labels = []string{"batch-change", "the name"}
+					s.Reviewers = []string{"alice", "sourcegraph/batchers"}
+					s.Assignees = []string{"my-cool-base-ref-owner"}
+					s.Milestone = "Release 2"
+				}),
+			},
+			wantErr: "",
+		},
+		{
+			name: "invalid label template",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Labels = []string{"${{ repository.unknown }}"}
+			}),
+			wantErr: `template: labels[0]:1:4: executing "labels[0]" at <repository>: map has no entry for key "unknown"`,
+		},
 	}
 
 	for _, tt := range tests {
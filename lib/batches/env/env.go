@@ -143,6 +143,29 @@ func (e Environment) Resolve(outer []string) (map[string]string, error) {
 	return resolved, nil
 }
 
+// MapValues returns a copy of the environment with the given function applied
+// to the values of the variables that have one. Variables that are resolved
+// from the outer environment are kept as they are.
+func (e Environment) MapValues(fn func(string) (string, error)) (Environment, error) {
+	if e.vars == nil {
+		return e, nil
+	}
+
+	mapped := Environment{vars: make([]variable, len(e.vars))}
+	for i, v := range e.vars {
+		if v.value != nil {
+			value, err := fn(*v.value)
+			if err != nil {
+				return Environment{}, errors.Wrapf(err, "environment variable %q", v.name)
+			}
+			v.value = &value
+		}
+		mapped.vars[i] = v
+	}
+
+	return mapped, nil
+}
+
 // Equal verifies if two environments are equal.
 func (e Environment) Equal(other Environment) bool {
 	return cmp.Equal(e.mapify(), other.mapify())
@@ -212,6 +212,10 @@ type TaskPreparingStepMetadata struct {
 type TaskStepMetadata struct {
 	TaskID string `json:"taskID,omitempty"`
 	Step   int    `json:"step,omitempty"`
+	// Attempt is the number of the attempt to run the step that the event
+	// belongs to, starting at 1. Versions of src-cli that don't retry steps
+	// don't set it.
+	Attempt int `json:"attempt,omitempty"`
 
 	RunScript string            `json:"runScript,omitempty"`
 	Env       map[string]string `json:"env,omitempty"`
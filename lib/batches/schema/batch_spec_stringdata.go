@@ -237,6 +237,36 @@ const BatchSpecJSON = `{
                 }
               }
             }
+          },
+          "matrix": {
+            "type": ["object", "null"],
+            "description": "Runs the step once for every combination of the given values, in the order of the keys. The values of the current combination can be referenced in templates via matrix.<key>.",
+            "additionalProperties": {
+              "type": "array",
+              "minItems": 1,
+              "items": {
+                "type": ["string", "number", "boolean"]
+              }
+            },
+            "examples": [{ "module": ["api", "worker"] }, { "goVersion": ["1.18", "1.19"], "os": ["linux", "darwin"] }]
+          },
+          "timeout": {
+            "type": ["string", "null"],
+            "description": "The maximum duration of a single attempt to run the step, as a Go duration. An attempt that takes longer is canceled and counts as failed.",
+            "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$",
+            "examples": ["30s", "10m", "1h30m"]
+          },
+          "retries": {
+            "type": ["integer", "null"],
+            "description": "The number of times the step is retried if it fails or times out.",
+            "minimum": 0,
+            "maximum": 10,
+            "default": 0
+          },
+          "continueOnError": {
+            "type": ["boolean", "null"],
+            "description": "If true, the following steps are run even if all attempts to run the step failed.",
+            "default": false
           }
         }
       }
@@ -395,6 +425,31 @@ const BatchSpecJSON = `{
               }
             }
           ]
+        },
+        "labels": {
+          "type": "array",
+          "description": "The labels to add to the changeset on the code host. Labels added on the code host that are not in this list are left in place. Each label can include template variables, and labels that render to an empty string are omitted.",
+          "items": {
+            "type": "string"
+          }
+        },
+        "reviewers": {
+          "type": "array",
+          "description": "The usernames of the users to request a review from when the changeset is published. On GitHub, a team can be requested as \"org/team-slug\". Each reviewer can include template variables, and reviewers that render to an empty string are omitted.",
+          "items": {
+            "type": "string"
+          }
+        },
+        "assignees": {
+          "type": "array",
+          "description": "The usernames of the users to assign to the changeset. Each assignee can include template variables, and assignees that render to an empty string are omitted.",
+          "items": {
+            "type": "string"
+          }
+        },
+        "milestone": {
+          "type": "string",
+          "description": "The title of the milestone to add the changeset to. The milestone must already exist in the repository on the code host. Can include template variables."
         }
       }
     }
@@ -0,0 +1,187 @@
+package batches
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxMatrixCombinations is the maximum number of steps a single step with a
+// matrix can be expanded to.
+const maxMatrixCombinations = 64
+
+// MatrixCombinations returns the number of combinations of the matrix of the
+// step, or 1 if the step has no matrix.
+func (s *Step) MatrixCombinations() int {
+	n := 1
+	for _, values := range s.Matrix {
+		n *= len(values)
+		// Stop early, so that huge matrices don't overflow.
+		if n > maxMatrixCombinations {
+			return n
+		}
+	}
+	return n
+}
+
+// expandMatrixSteps replaces every step with a matrix by one step per
+// combination of its matrix values, with MatrixValues set to the combination
+// and the references to them in the templates of the step rendered.
+// Combinations are ordered by the keys of the matrix, with the values of the
+// first key changing the slowest, and the values in the order they are given.
+func expandMatrixSteps(steps []Step) ([]Step, error) {
+	var expanded []Step
+	for i, step := range steps {
+		if len(step.Matrix) == 0 {
+			expanded = append(expanded, step)
+			continue
+		}
+
+		keys := make([]string, 0, len(step.Matrix))
+		for key := range step.Matrix {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		combinations := []map[string]any{{}}
+		for _, key := range keys {
+			next := make([]map[string]any, 0, len(combinations)*len(step.Matrix[key]))
+			for _, combination := range combinations {
+				for _, value := range step.Matrix[key] {
+					c := make(map[string]any, len(combination)+1)
+					for k, v := range combination {
+						c[k] = v
+					}
+					c[key] = value
+					next = append(next, c)
+				}
+			}
+			combinations = next
+		}
+
+		for _, combination := range combinations {
+			s, err := renderMatrixValues(step, combination)
+			if err != nil {
+				return nil, errors.Wrapf(err, "step %d", i+1)
+			}
+			expanded = append(expanded, s)
+		}
+	}
+	return expanded, nil
+}
+
+// renderMatrixValues returns a copy of the given step for the given matrix
+// combination. The values are rendered into every templated field of the step,
+// because the step runner renders the templates without them.
+func renderMatrixValues(step Step, combination map[string]any) (_ Step, err error) {
+	render := func(tmpl string) (string, error) {
+		return template.RenderMatrix(tmpl, combination)
+	}
+
+	s := step
+	s.Matrix = nil
+	s.MatrixValues = combination
+
+	if s.Run, err = render(step.Run); err != nil {
+		return Step{}, errors.Wrap(err, "run")
+	}
+	if s.Container, err = render(step.Container); err != nil {
+		return Step{}, errors.Wrap(err, "container")
+	}
+	if cond, ok := step.If.(string); ok {
+		if s.If, err = render(cond); err != nil {
+			return Step{}, errors.Wrap(err, "if")
+		}
+	}
+	if s.Env, err = step.Env.MapValues(render); err != nil {
+		return Step{}, errors.Wrap(err, "env")
+	}
+	if step.Files != nil {
+		s.Files = make(map[string]string, len(step.Files))
+		for path, content := range step.Files {
+			if s.Files[path], err = render(content); err != nil {
+				return Step{}, errors.Wrapf(err, "files %q", path)
+			}
+		}
+	}
+	if step.Outputs != nil {
+		s.Outputs = make(Outputs, len(step.Outputs))
+		for name, output := range step.Outputs {
+			if output.Value, err = render(output.Value); err != nil {
+				return Step{}, errors.Wrapf(err, "outputs %q", name)
+			}
+			s.Outputs[name] = output
+		}
+	}
+	return s, nil
+}
+
+// TimeoutDuration returns the timeout of a single attempt to run the step, or
+// zero if the step has no timeout.
+func (s *Step) TimeoutDuration() (time.Duration, error) {
+	if s.Timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s.Timeout)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid timeout")
+	}
+	if d <= 0 {
+		return 0, errors.Newf("invalid timeout %q: must be positive", s.Timeout)
+	}
+	return d, nil
+}
+
+// MaxAttempts returns the number of times the step is run at most.
+func (s *Step) MaxAttempts() int {
+	if s.Retries < 0 {
+		return 1
+	}
+	return s.Retries + 1
+}
+
+// ErrStepTimeout is returned for attempts to run a step that took longer than
+// the timeout of the step.
+var ErrStepTimeout = errors.New("step timed out")
+
+// StepAttemptFunc runs a single attempt of a step. Attempts are numbered
+// starting at 1. The context is canceled once the timeout of the step passed.
+type StepAttemptFunc func(ctx context.Context, attempt int) error
+
+// RunStep runs the attempts of the given step until one succeeds or all
+// attempts the step allows for have failed, in which case the error of the
+// last attempt is returned. Attempts that time out fail with ErrStepTimeout.
+//
+// Whether the following steps are run after RunStep returned an error is up to
+// the caller, depending on the ContinueOnError field of the step.
+func RunStep(ctx context.Context, step *Step, run StepAttemptFunc) error {
+	timeout, err := step.TimeoutDuration()
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = runStepAttempt(ctx, timeout, attempt, run)
+		if err == nil || attempt >= step.MaxAttempts() || ctx.Err() != nil {
+			return err
+		}
+	}
+}
+
+func runStepAttempt(ctx context.Context, timeout time.Duration, attempt int, run StepAttemptFunc) error {
+	if timeout == 0 {
+		return run(ctx, attempt)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := run(attemptCtx, attempt)
+	if err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+		return errors.Wrapf(ErrStepTimeout, "attempt %d exceeded timeout of %s", attempt, timeout)
+	}
+	return err
+}
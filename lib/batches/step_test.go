@@ -0,0 +1,75 @@
+package batches
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestRunStep(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	t.Run("succeeds after retries", func(t *testing.T) {
+		var attempts []int
+		err := RunStep(context.Background(), &Step{Retries: 2}, func(ctx context.Context, attempt int) error {
+			attempts = append(attempts, attempt)
+			if attempt < 2 {
+				return errFailed
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(attempts) != 2 {
+			t.Fatalf("wrong number of attempts. want=%d, have=%d", 2, len(attempts))
+		}
+	})
+
+	t.Run("all attempts fail", func(t *testing.T) {
+		var attempts int
+		err := RunStep(context.Background(), &Step{Retries: 2}, func(ctx context.Context, attempt int) error {
+			attempts++
+			return errFailed
+		})
+		if !errors.Is(err, errFailed) {
+			t.Fatalf("wrong error. want=%s, have=%s", errFailed, err)
+		}
+		if attempts != 3 {
+			t.Fatalf("wrong number of attempts. want=%d, have=%d", 3, attempts)
+		}
+	})
+
+	t.Run("attempt times out", func(t *testing.T) {
+		var attempts int
+		err := RunStep(context.Background(), &Step{Timeout: "10ms", Retries: 1}, func(ctx context.Context, attempt int) error {
+			attempts++
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		if !errors.Is(err, ErrStepTimeout) {
+			t.Fatalf("wrong error. want=%s, have=%s", ErrStepTimeout, err)
+		}
+		if attempts != 2 {
+			t.Fatalf("wrong number of attempts. want=%d, have=%d", 2, attempts)
+		}
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var attempts int
+		err := RunStep(ctx, &Step{Timeout: time.Minute.String(), Retries: 5}, func(ctx context.Context, attempt int) error {
+			attempts++
+			cancel()
+			return ctx.Err()
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("wrong error. want=%s, have=%s", context.Canceled, err)
+		}
+		if attempts != 1 {
+			t.Fatalf("wrong number of attempts. want=%d, have=%d", 1, attempts)
+		}
+	})
+}
@@ -0,0 +1,214 @@
+package template
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// RenderMatrix replaces the references to matrix values (`matrix.<key>`) in
+// the given template with the values of the given matrix combination, and
+// leaves the rest of the template as is, so that it can be rendered once the
+// step runs.
+//
+// Matrix values are rendered ahead of execution because the step runner
+// doesn't know about matrices: it renders templates with a StepContext that
+// has no Matrix.
+func RenderMatrix(input string, matrix map[string]any) (string, error) {
+	if !strings.Contains(input, startDelim) || !strings.Contains(input, "matrix") {
+		return input, nil
+	}
+
+	t, err := template.
+		New("matrix").
+		Delims(startDelim, endDelim).
+		Funcs(builtins).
+		Funcs((&StepContext{}).ToFuncMap()).
+		Parse(input)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	if err := writeMatrixNode(&out, t.Tree.Root, matrix); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// writeMatrixNode writes the given node back to its template source, with the
+// references to matrix values replaced.
+func writeMatrixNode(out *strings.Builder, n parse.Node, matrix map[string]any) error {
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Nodes {
+			if err := writeMatrixNode(out, c, matrix); err != nil {
+				return err
+			}
+		}
+
+	case *parse.TextNode:
+		out.Write(n.Text)
+
+	case *parse.ActionNode:
+		// Plain references are replaced by their value, so that the rendered
+		// template reads like it was written with the value.
+		if len(n.Pipe.Decl) == 0 && len(n.Pipe.Cmds) == 1 && len(n.Pipe.Cmds[0].Args) == 1 {
+			if key, ok := matrixKey(n.Pipe.Cmds[0].Args[0]); ok {
+				v, err := matrixValue(matrix, key)
+				if err != nil {
+					return err
+				}
+				fmt.Fprint(out, v)
+				return nil
+			}
+		}
+		if err := replaceMatrixValues(n.Pipe, matrix); err != nil {
+			return err
+		}
+		writeAction(out, n.Pipe.String())
+
+	case *parse.IfNode:
+		return writeMatrixBranch(out, "if", &n.BranchNode, matrix)
+	case *parse.RangeNode:
+		return writeMatrixBranch(out, "range", &n.BranchNode, matrix)
+	case *parse.WithNode:
+		return writeMatrixBranch(out, "with", &n.BranchNode, matrix)
+
+	case *parse.TemplateNode:
+		action := fmt.Sprintf("template %q", n.Name)
+		if n.Pipe != nil {
+			if err := replaceMatrixValues(n.Pipe, matrix); err != nil {
+				return err
+			}
+			action += " " + n.Pipe.String()
+		}
+		writeAction(out, action)
+
+	case *parse.BreakNode:
+		writeAction(out, "break")
+	case *parse.ContinueNode:
+		writeAction(out, "continue")
+
+	case *parse.CommentNode:
+		// Comments don't render to anything.
+
+	default:
+		return errors.Newf("unsupported template node %q", n.String())
+	}
+	return nil
+}
+
+func writeMatrixBranch(out *strings.Builder, keyword string, n *parse.BranchNode, matrix map[string]any) error {
+	if err := replaceMatrixValues(n.Pipe, matrix); err != nil {
+		return err
+	}
+	writeAction(out, keyword+" "+n.Pipe.String())
+	if err := writeMatrixNode(out, n.List, matrix); err != nil {
+		return err
+	}
+	if n.ElseList != nil {
+		writeAction(out, "else")
+		if err := writeMatrixNode(out, n.ElseList, matrix); err != nil {
+			return err
+		}
+	}
+	writeAction(out, "end")
+	return nil
+}
+
+func writeAction(out *strings.Builder, action string) {
+	out.WriteString(startDelim)
+	out.WriteString(" ")
+	out.WriteString(action)
+	out.WriteString(" ")
+	out.WriteString(endDelim)
+}
+
+// replaceMatrixValues replaces the references to matrix values in the
+// arguments of the commands of the given pipe by literals.
+func replaceMatrixValues(p *parse.PipeNode, matrix map[string]any) error {
+	if p == nil {
+		return nil
+	}
+	for _, c := range p.Cmds {
+		for i, arg := range c.Args {
+			if key, ok := matrixKey(arg); ok {
+				v, err := matrixValue(matrix, key)
+				if err != nil {
+					return err
+				}
+				lit, err := matrixLiteral(v)
+				if err != nil {
+					return err
+				}
+				c.Args[i] = lit
+				continue
+			}
+
+			switch arg := arg.(type) {
+			case *parse.IdentifierNode:
+				if arg.Ident == "matrix" {
+					return errors.New("matrix values can only be referenced as matrix.<key>")
+				}
+			case *parse.ChainNode:
+				if ident, ok := arg.Node.(*parse.IdentifierNode); ok && ident.Ident == "matrix" {
+					return errors.Newf("matrix values can only be referenced as matrix.<key>, not %s", arg)
+				}
+				if pipe, ok := arg.Node.(*parse.PipeNode); ok {
+					if err := replaceMatrixValues(pipe, matrix); err != nil {
+						return err
+					}
+				}
+			case *parse.PipeNode:
+				if err := replaceMatrixValues(arg, matrix); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// matrixKey returns the key of the matrix value the given node references, if
+// it is a reference to a matrix value.
+func matrixKey(n parse.Node) (string, bool) {
+	chain, ok := n.(*parse.ChainNode)
+	if !ok || len(chain.Field) != 1 {
+		return "", false
+	}
+	ident, ok := chain.Node.(*parse.IdentifierNode)
+	if !ok || ident.Ident != "matrix" {
+		return "", false
+	}
+	return chain.Field[0], true
+}
+
+func matrixValue(matrix map[string]any, key string) (any, error) {
+	v, ok := matrix[key]
+	if !ok {
+		return nil, errors.Newf("unknown matrix value %q", key)
+	}
+	return v, nil
+}
+
+// matrixLiteral returns the template literal of the given matrix value.
+func matrixLiteral(v any) (parse.Node, error) {
+	switch v := v.(type) {
+	case string:
+		return &parse.StringNode{NodeType: parse.NodeString, Quoted: strconv.Quote(v), Text: v}, nil
+	case bool:
+		return &parse.BoolNode{NodeType: parse.NodeBool, True: v}, nil
+	case int, int64, uint64, float64:
+		return &parse.NumberNode{NodeType: parse.NodeNumber, Text: fmt.Sprint(v)}, nil
+	default:
+		return nil, errors.Newf("unsupported matrix value of type %T", v)
+	}
+}
@@ -0,0 +1,89 @@
+package template
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMatrix(t *testing.T) {
+	matrix := map[string]any{"go": "1.18", "race": true, "shards": 4}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "no template",
+			input: `go test ./...`,
+			want:  `go test ./...`,
+		},
+		{
+			name:  "no matrix",
+			input: `echo ${{- repository.name }} > ${{ outputs.file }}`,
+			want:  `echo ${{- repository.name }} > ${{ outputs.file }}`,
+		},
+		{
+			name:  "references",
+			input: `go${{ matrix.go }} test -race=${{ matrix.race }} -shards=${{matrix.shards}} ./...`,
+			want:  `go1.18 test -race=true -shards=4 ./...`,
+		},
+		{
+			name:  "mixed with other values",
+			input: `${{ join (split repository.name "/") matrix.go }} ${{ outputs.file }}`,
+			want:  `${{ join (split repository.name "/") "1.18" }} ${{ outputs.file }}`,
+		},
+		{
+			name:  "control structures",
+			input: `${{ if eq matrix.go "1.18" }}go1.18${{ else if matrix.race }}race${{ else }}{{ literal }}${{ end }}`,
+			want:  `${{ if eq "1.18" "1.18" }}go1.18${{ else }}${{ if true }}race${{ else }}{{ literal }}${{ end }}${{ end }}`,
+		},
+		{
+			name:    "unknown value",
+			input:   `echo ${{ matrix.os }}`,
+			wantErr: `unknown matrix value "os"`,
+		},
+		{
+			name:    "unsupported reference",
+			input:   `echo ${{ index matrix "go" }}`,
+			wantErr: `matrix values can only be referenced as matrix.<key>`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := RenderMatrix(tc.input, matrix)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("wrong error. want=%q, have=%v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tc.want {
+				t.Fatalf("wrong output. want=%q, have=%q", tc.want, have)
+			}
+
+			// The rendered template must be valid and render the same as the
+			// original template with the matrix values.
+			stepCtx := &StepContext{
+				Repository: Repository{Name: "github.com/sourcegraph/src-cli"},
+				Outputs:    map[string]any{"file": "out.txt"},
+				Matrix:     matrix,
+			}
+			var want, got strings.Builder
+			if err := RenderStepTemplate("want", tc.input, &want, stepCtx); err != nil {
+				t.Fatal(err)
+			}
+			if err := RenderStepTemplate("have", have, &got, stepCtx); err != nil {
+				t.Fatal(err)
+			}
+			if want.String() != got.String() {
+				t.Fatalf("rendered output differs. want=%q, have=%q", want.String(), got.String())
+			}
+		})
+	}
+}
@@ -207,6 +207,11 @@ func evalNode(ctx *StepContext, n parse.Node) (reflect.Value, bool) {
 				case "description":
 					return reflect.ValueOf(ctx.BatchChange.Description), true
 				}
+
+			case "matrix":
+				if v, ok := ctx.Matrix[n.Field[0]]; ok {
+					return reflect.ValueOf(v), true
+				}
 			}
 		}
 		return noValue, false
@@ -190,6 +190,9 @@ type StepContext struct {
 	PreviousStep execution.AfterStepResult
 	// Repository is the Sourcegraph repository in which the steps are executed.
 	Repository Repository
+	// Matrix are the values of the matrix combination the current step was
+	// expanded for. Empty when the step has no matrix.
+	Matrix map[string]any
 }
 
 // ToFuncMap returns a template.FuncMap to access fields on the StepContext in a
@@ -240,6 +243,9 @@ func (stepCtx *StepContext) ToFuncMap() template.FuncMap {
 				"branch":              stepCtx.Repository.Branch,
 			}
 		},
+		"matrix": func() map[string]any {
+			return stepCtx.Matrix
+		},
 		"batch_change": func() map[string]any {
 			return map[string]any{
 				"name":        stepCtx.BatchChange.Name,
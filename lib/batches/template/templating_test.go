@@ -347,6 +347,12 @@ ${{ steps.path }}
 
 `,
 		},
+		{
+			name:    "matrix",
+			stepCtx: &StepContext{Matrix: map[string]any{"go": "1.18", "race": true}},
+			run:     `go${{ matrix.go }} test -race=${{ matrix.race }} ./...`,
+			want:    `go1.18 test -race=true ./...`,
+		},
 	}
 
 	for _, tc := range tests {
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS insight_series_alert_rules
+(
+    id                SERIAL
+        CONSTRAINT insight_series_alert_rules_pk PRIMARY KEY,
+    series_id         INT                         NOT NULL,
+    kind              TEXT                        NOT NULL,
+    threshold         DOUBLE PRECISION            NOT NULL DEFAULT 0,
+    notify_email      BOOLEAN                     NOT NULL DEFAULT FALSE,
+    slack_webhook_url TEXT,
+    webhook_url       TEXT,
+    created_by        INT                         NOT NULL,
+    created_at        TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_point_time   TIMESTAMP WITHOUT TIME ZONE,
+    last_triggered_at TIMESTAMP WITHOUT TIME ZONE,
+
+    CONSTRAINT insight_series_alert_rules_series_id_fk
+        FOREIGN KEY (series_id) REFERENCES insight_series (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS insight_series_alert_rules_series_id_idx ON insight_series_alert_rules (series_id);
+
+COMMENT ON COLUMN insight_series_alert_rules.kind IS 'The condition of the rule: ABOVE, BELOW, PERCENT_CHANGE or CROSSES_ZERO.';
+COMMENT ON COLUMN insight_series_alert_rules.notify_email IS 'Whether to email the user who created the rule.';
+COMMENT ON COLUMN insight_series_alert_rules.created_by IS 'The ID of the user in the frontend database who created the rule.';
+COMMENT ON COLUMN insight_series_alert_rules.last_point_time IS 'The time of the most recent data point the rule was evaluated against. Rules are evaluated at most once per data point.';
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS rockskip_refs;
+
+DROP INDEX IF EXISTS rockskip_ancestry_parent;
+
+ALTER TABLE rockskip_ancestry DROP COLUMN IF EXISTS parent;
@@ -0,0 +1,24 @@
+ALTER TABLE rockskip_ancestry ADD COLUMN IF NOT EXISTS parent integer;
+
+COMMENT ON COLUMN rockskip_ancestry.parent IS 'The first parent of the commit (0 for root commits). NULL for commits indexed before refs were tracked';
+
+CREATE INDEX IF NOT EXISTS rockskip_ancestry_parent ON rockskip_ancestry USING btree (parent);
+
+CREATE TABLE IF NOT EXISTS rockskip_refs (
+    id SERIAL PRIMARY KEY,
+    repo_id integer NOT NULL,
+    name text NOT NULL,
+    commit_id integer,
+    pending_commit text,
+    behind integer NOT NULL DEFAULT 0,
+    last_accessed_at timestamp with time zone NOT NULL,
+    UNIQUE (repo_id, name)
+);
+
+COMMENT ON TABLE rockskip_refs IS 'The refs (branches, tags or bare commits) that have been searched with Rockskip, which are evicted independently of each other';
+COMMENT ON COLUMN rockskip_refs.commit_id IS 'The rockskip_ancestry row of the indexed commit the ref points to. NULL until the first commit of the ref has been indexed';
+COMMENT ON COLUMN rockskip_refs.pending_commit IS 'The hash of the most recently searched commit of the ref if it has not been indexed yet';
+COMMENT ON COLUMN rockskip_refs.behind IS 'How many commits pending_commit is ahead of the last indexed commit, once known';
+
+CREATE INDEX IF NOT EXISTS rockskip_refs_commit_id ON rockskip_refs USING btree (commit_id);
+CREATE INDEX IF NOT EXISTS rockskip_refs_last_accessed_at ON rockskip_refs USING btree (repo_id, last_accessed_at);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS rockskip_symbols_repo_id_path_name;
+DROP INDEX IF EXISTS rockskip_symbols_gin;
+
+ALTER TABLE rockskip_symbols DROP COLUMN IF EXISTS kind;
+ALTER TABLE rockskip_symbols DROP COLUMN IF EXISTS parent;
+ALTER TABLE rockskip_symbols DROP COLUMN IF EXISTS language;
+
+CREATE INDEX IF NOT EXISTS rockskip_symbols_gin ON rockskip_symbols USING gin (singleton_integer(repo_id) gin__int_ops, added gin__int_ops, deleted gin__int_ops, name gin_trgm_ops, singleton(name), singleton(lower(name)), path gin_trgm_ops, singleton(path), path_prefixes(path), singleton(lower(path)), path_prefixes(lower(path)), singleton(get_file_extension(path)), singleton(get_file_extension(lower(path))));
+CREATE INDEX IF NOT EXISTS rockskip_symbols_repo_id_path_name ON rockskip_symbols USING btree (repo_id, path, name);
@@ -0,0 +1,18 @@
+-- Symbols indexed so far have no kind, parent or language, and symbols that only differ in those
+-- were collapsed into a single row. Drop the indexes so that repositories get re-indexed the next
+-- time they're searched.
+TRUNCATE rockskip_symbols, rockskip_ancestry, rockskip_refs, rockskip_repos;
+
+ALTER TABLE rockskip_symbols ADD COLUMN IF NOT EXISTS kind text NOT NULL DEFAULT '';
+ALTER TABLE rockskip_symbols ADD COLUMN IF NOT EXISTS parent text NOT NULL DEFAULT '';
+ALTER TABLE rockskip_symbols ADD COLUMN IF NOT EXISTS language text NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN rockskip_symbols.kind IS 'The ctags kind of the symbol (e.g. method or class)';
+COMMENT ON COLUMN rockskip_symbols.parent IS 'The name of the symbol that contains this symbol (e.g. the class of a method), or the empty string';
+COMMENT ON COLUMN rockskip_symbols.language IS 'The language ctags detected for the file';
+
+DROP INDEX IF EXISTS rockskip_symbols_gin;
+CREATE INDEX IF NOT EXISTS rockskip_symbols_gin ON rockskip_symbols USING gin (singleton_integer(repo_id) gin__int_ops, added gin__int_ops, deleted gin__int_ops, name gin_trgm_ops, singleton(name), singleton(lower(name)), path gin_trgm_ops, singleton(path), path_prefixes(path), singleton(lower(path)), path_prefixes(lower(path)), singleton(get_file_extension(path)), singleton(get_file_extension(lower(path))), singleton(lower(kind)), singleton(parent), singleton(lower(parent)));
+
+DROP INDEX IF EXISTS rockskip_symbols_repo_id_path_name;
+CREATE INDEX IF NOT EXISTS rockskip_symbols_repo_id_path_name ON rockskip_symbols USING btree (repo_id, path, name, kind, parent);
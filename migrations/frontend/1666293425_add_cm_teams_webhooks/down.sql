@@ -0,0 +1,14 @@
+DELETE FROM cm_action_jobs WHERE teams_webhook IS NOT NULL;
+
+ALTER TABLE cm_action_jobs DROP CONSTRAINT IF EXISTS cm_action_jobs_only_one_action_type;
+ALTER TABLE cm_action_jobs ADD CONSTRAINT cm_action_jobs_only_one_action_type CHECK ((
+    CASE WHEN email IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN slack_webhook IS NULL THEN 0 ELSE 1 END
+) = 1);
+
+COMMENT ON CONSTRAINT cm_action_jobs_only_one_action_type ON cm_action_jobs IS 'Constrains that each queued code monitor action has exactly one action type';
+
+ALTER TABLE cm_action_jobs DROP COLUMN IF EXISTS teams_webhook;
+
+DROP TABLE IF EXISTS cm_teams_webhooks;
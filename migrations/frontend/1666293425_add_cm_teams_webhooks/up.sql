@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS cm_teams_webhooks (
+    id bigserial PRIMARY KEY,
+    monitor bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    url text NOT NULL,
+    enabled boolean NOT NULL,
+    include_results boolean NOT NULL DEFAULT false,
+    created_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    changed_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS cm_teams_webhooks_monitor ON cm_teams_webhooks USING btree (monitor);
+
+COMMENT ON TABLE cm_teams_webhooks IS 'Microsoft Teams webhook actions configured on code monitors';
+COMMENT ON COLUMN cm_teams_webhooks.monitor IS 'The code monitor that the action is defined on';
+COMMENT ON COLUMN cm_teams_webhooks.url IS 'The Microsoft Teams incoming webhook URL we send the code monitor event to';
+COMMENT ON COLUMN cm_teams_webhooks.enabled IS 'Whether this Microsoft Teams webhook action is enabled. When not enabled, the action will not be run when its code monitor generates events';
+
+ALTER TABLE cm_action_jobs
+    ADD COLUMN IF NOT EXISTS teams_webhook bigint REFERENCES cm_teams_webhooks(id) ON DELETE CASCADE;
+
+COMMENT ON COLUMN cm_action_jobs.teams_webhook IS 'The ID of the cm_teams_webhooks action to execute if this is a Microsoft Teams webhook job. Mutually exclusive with email, webhook and slack_webhook';
+
+ALTER TABLE cm_action_jobs DROP CONSTRAINT IF EXISTS cm_action_jobs_only_one_action_type;
+ALTER TABLE cm_action_jobs ADD CONSTRAINT cm_action_jobs_only_one_action_type CHECK ((
+    CASE WHEN email IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN slack_webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN teams_webhook IS NULL THEN 0 ELSE 1 END
+) = 1);
+
+COMMENT ON CONSTRAINT cm_action_jobs_only_one_action_type ON cm_action_jobs IS 'Constrains that each queued code monitor action has exactly one action type';
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS cm_content_matches (
+    monitor_id bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    match_keys text[] NOT NULL,
+    PRIMARY KEY (monitor_id, repo_id)
+);
+
+COMMENT ON TABLE cm_content_matches IS 'The file content matches found in a repository by the last run of a code monitor over a file content query';
+COMMENT ON COLUMN cm_content_matches.match_keys IS 'The set of hashed (path, line) keys of the matches. Matches whose key is not in this set on the next run are new';
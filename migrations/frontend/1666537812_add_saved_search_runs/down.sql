@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS saved_search_results;
+DROP TABLE IF EXISTS saved_search_schedules;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS saved_search_schedules (
+    saved_search_id integer PRIMARY KEY REFERENCES saved_searches(id) ON DELETE CASCADE,
+    interval_minutes integer NOT NULL CHECK (interval_minutes > 0),
+    next_run_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_run_at timestamp with time zone,
+    last_run_error text,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS saved_search_schedules_next_run_at ON saved_search_schedules(next_run_at);
+
+COMMENT ON TABLE saved_search_schedules IS 'Saved searches that are run periodically by the worker';
+COMMENT ON COLUMN saved_search_schedules.last_run_at IS 'The time of the last successful run. Results first seen or removed at this time make up the changes of the last run';
+
+CREATE TABLE IF NOT EXISTS saved_search_results (
+    saved_search_id integer NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE,
+    key text NOT NULL,
+    result_type text NOT NULL,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    path text,
+    line_number integer,
+    label text NOT NULL,
+    first_seen_at timestamp with time zone NOT NULL,
+    removed_at timestamp with time zone,
+    PRIMARY KEY (saved_search_id, key)
+);
+
+COMMENT ON TABLE saved_search_results IS 'The results returned by the last run of a scheduled saved search';
+COMMENT ON COLUMN saved_search_results.key IS 'A hash identifying the result across runs';
+COMMENT ON COLUMN saved_search_results.removed_at IS 'The time of the run that no longer returned the result. The row is deleted by the next run';
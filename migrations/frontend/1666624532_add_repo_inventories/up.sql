@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS repo_inventories (
+    repo_id integer PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    commit_id text NOT NULL,
+    file_count bigint NOT NULL DEFAULT 0,
+    total_bytes bigint NOT NULL DEFAULT 0,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE repo_inventories IS 'Summary of the files on the default branch of a repository, used by the repo:has.language() and repo:has.size() search predicates';
+COMMENT ON COLUMN repo_inventories.commit_id IS 'The commit the inventory was computed at. Empty if it was never computed successfully';
+COMMENT ON COLUMN repo_inventories.total_bytes IS 'The total size of the files with a detected language, used to compute the share of each language';
+
+CREATE TABLE IF NOT EXISTS repo_languages (
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    language text NOT NULL,
+    total_bytes bigint NOT NULL DEFAULT 0,
+    total_lines bigint NOT NULL DEFAULT 0,
+    total_files bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (repo_id, language)
+);
+
+CREATE INDEX IF NOT EXISTS repo_languages_lower_language ON repo_languages(lower(language));
+
+COMMENT ON TABLE repo_languages IS 'The languages of the files on the default branch of a repository, as computed for repo_inventories';
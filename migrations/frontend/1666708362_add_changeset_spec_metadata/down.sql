@@ -0,0 +1,4 @@
+ALTER TABLE changeset_specs DROP COLUMN IF EXISTS labels;
+ALTER TABLE changeset_specs DROP COLUMN IF EXISTS reviewers;
+ALTER TABLE changeset_specs DROP COLUMN IF EXISTS assignees;
+ALTER TABLE changeset_specs DROP COLUMN IF EXISTS milestone;
@@ -0,0 +1,4 @@
+ALTER TABLE changeset_specs ADD COLUMN IF NOT EXISTS labels text[];
+ALTER TABLE changeset_specs ADD COLUMN IF NOT EXISTS reviewers text[];
+ALTER TABLE changeset_specs ADD COLUMN IF NOT EXISTS assignees text[];
+ALTER TABLE changeset_specs ADD COLUMN IF NOT EXISTS milestone text;
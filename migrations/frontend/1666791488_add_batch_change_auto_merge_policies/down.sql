@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS changeset_auto_merge_events;
+DROP TABLE IF EXISTS batch_change_auto_merge_policies;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS batch_change_auto_merge_policies (
+    batch_change_id bigint PRIMARY KEY REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    require_passing_checks boolean DEFAULT true NOT NULL,
+    require_approval boolean DEFAULT true NOT NULL,
+    squash boolean DEFAULT false NOT NULL,
+    windows jsonb DEFAULT '[]'::jsonb NOT NULL,
+    max_merges_per_hour integer DEFAULT 0 NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT batch_change_auto_merge_policies_max_merges_per_hour_check CHECK (max_merges_per_hour >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS changeset_auto_merge_events (
+    id bigserial PRIMARY KEY,
+    batch_change_id bigint NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    changeset_id bigint NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    decision text NOT NULL,
+    reason text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS changeset_auto_merge_events_batch_change_id_created_at ON changeset_auto_merge_events USING btree (batch_change_id, created_at);
+CREATE INDEX IF NOT EXISTS changeset_auto_merge_events_changeset_id ON changeset_auto_merge_events USING btree (changeset_id, id);
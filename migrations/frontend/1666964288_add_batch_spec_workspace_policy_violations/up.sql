@@ -0,0 +1 @@
+ALTER TABLE batch_spec_workspaces ADD COLUMN IF NOT EXISTS policy_violations jsonb NOT NULL DEFAULT '[]'::jsonb;
@@ -165,6 +165,35 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
 }
 
+// AzureDevOpsConnection description: Configuration for a connection to Azure DevOps Services or Azure DevOps Server.
+type AzureDevOpsConnection struct {
+	// Exclude description: A list of repositories to never mirror from Azure DevOps. Takes precedence over "orgs" and "projects" configuration.
+	//
+	// Supports excluding by name ({"name": "org/project/repo"}), by ID ({"id": "a8a3a1b2-0b4e-4a67-9b58-6b7d1a4e5f60"}) or by pattern ({"pattern": "^org/project/.*-archive$"}).
+	Exclude []*ExcludedAzureDevOpsRepo `json:"exclude,omitempty"`
+	// Orgs description: An array of organization names identifying Azure DevOps organizations whose repositories should be mirrored on Sourcegraph.
+	Orgs []string `json:"orgs,omitempty"`
+	// Projects description: An array of projects "org/project" strings specifying which Azure DevOps projects' repositories should be mirrored on Sourcegraph.
+	Projects []string `json:"projects,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to Azure DevOps.
+	RateLimit *AzureDevOpsRateLimit `json:"rateLimit,omitempty"`
+	// Token description: A personal access token with at least the "Code (Read)" scope for the organizations and projects that should be mirrored.
+	Token string `json:"token"`
+	// Url description: URL of Azure DevOps Services (https://dev.azure.com) or of an Azure DevOps Server instance, such as https://azure.example.com/tfs. For Azure DevOps Server, organizations correspond to project collections.
+	Url string `json:"url"`
+	// Username description: A username for authentication with the Azure DevOps code host.
+	Username string `json:"username"`
+	// WebhookSecret description: A shared secret used to authenticate incoming service hooks (minimum 12 characters). Configure it as the basic authentication password of the "Web Hooks" service hook subscription in Azure DevOps.
+	WebhookSecret string `json:"webhookSecret,omitempty"`
+}
+
+// AzureDevOpsRateLimit description: Rate limit applied when making background API requests to Azure DevOps.
+type AzureDevOpsRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 500, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 500 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
 type BackendInsight struct {
 	// Description description: The description of this insight
 	Description string          `json:"description,omitempty"`
@@ -193,6 +222,20 @@ type BatchChangeRolloutWindow struct {
 	Start string `json:"start,omitempty"`
 }
 
+// BatchChangesChangesetPolicy description: Checks that the changesets of batch changes have to pass before they can be published. Changeset specs violating the policy are not created, and the violations are shown on the workspaces they were found in.
+type BatchChangesChangesetPolicy struct {
+	// AllowedPaths description: Glob patterns of the files changesets are allowed to change, relative to the root of the repository. `*` matches any characters except `/`, and `**` any characters including `/`. If not set, changesets can change any file.
+	AllowedPaths []string `json:"allowedPaths,omitempty"`
+	// DetectSecrets description: Fail changesets whose added lines contain strings that look like secrets, such as private keys, cloud access keys and access tokens.
+	DetectSecrets bool `json:"detectSecrets,omitempty"`
+	// MaxChangedLines description: The maximum number of lines a single changeset can add and delete in total. If not set, changesets can change any number of lines.
+	MaxChangedLines int `json:"maxChangedLines,omitempty"`
+	// ProtectedCodeOwners description: Code owners whose files changesets are not allowed to change, according to the CODEOWNERS file of the repository at the base commit. Use `*` to protect every file that has an owner.
+	ProtectedCodeOwners []string `json:"protectedCodeOwners,omitempty"`
+	// SecretPatterns description: Additional regular expressions for secrets changesets are not allowed to add. Only used if `detectSecrets` is enabled.
+	SecretPatterns []string `json:"secretPatterns,omitempty"`
+}
+
 // BatchSpec description: A batch specification, which describes the batch change and what kinds of changes to make (or what existing changesets to track).
 type BatchSpec struct {
 	// ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
@@ -380,6 +423,8 @@ type BitbucketServerUsernameIdentity struct {
 	Type string `json:"type"`
 }
 type BranchChangesetSpec struct {
+	// Assignees description: The usernames of the users to assign to the changeset.
+	Assignees []string `json:"assignees,omitempty"`
 	// BaseRef description: The full name of the Git ref in the base repository that this changeset is based on (and is proposing to be merged into). This ref must exist on the base repository.
 	BaseRef string `json:"baseRef"`
 	// BaseRepository description: The GraphQL ID of the repository that this changeset spec is proposing to change.
@@ -394,8 +439,14 @@ type BranchChangesetSpec struct {
 	HeadRef string `json:"headRef"`
 	// HeadRepository description: The GraphQL ID of the repository that contains the branch with this changeset's changes. Fork repositories and cross-repository changesets are not yet supported. Therefore, headRepository must be equal to baseRepository.
 	HeadRepository string `json:"headRepository"`
+	// Labels description: The labels to add to the changeset on the code host.
+	Labels []string `json:"labels,omitempty"`
+	// Milestone description: The title of the milestone to add the changeset to.
+	Milestone string `json:"milestone,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host.
 	Published interface{} `json:"published,omitempty"`
+	// Reviewers description: The usernames of the users, or on GitHub the "org/team-slug" of the teams, to request a review from.
+	Reviewers []string `json:"reviewers,omitempty"`
 	// Title description: The title of the changeset on the code host.
 	Title string `json:"title"`
 }
@@ -431,14 +482,22 @@ type BuiltinAuthProvider struct {
 
 // ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 type ChangesetTemplate struct {
+	// Assignees description: The usernames of the users to assign to the changeset. Each assignee can include template variables, and assignees that render to an empty string are omitted.
+	Assignees []string `json:"assignees,omitempty"`
 	// Body description: The body (description) of the changeset.
 	Body string `json:"body,omitempty"`
 	// Branch description: The name of the Git branch to create or update on each repository with the changes.
 	Branch string `json:"branch"`
 	// Commit description: The Git commit to create with the changes.
 	Commit ExpandedGitCommitDescription `json:"commit"`
+	// Labels description: The labels to add to the changeset on the code host. Labels added on the code host that are not in this list are left in place. Each label can include template variables, and labels that render to an empty string are omitted.
+	Labels []string `json:"labels,omitempty"`
+	// Milestone description: The title of the milestone to add the changeset to. The milestone must already exist in the repository on the code host. Can include template variables.
+	Milestone string `json:"milestone,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If omitted, the publication state is controlled from the Batch Changes UI.
 	Published interface{} `json:"published,omitempty"`
+	// Reviewers description: The usernames of the users to request a review from when the changeset is published. On GitHub, a team can be requested as "org/team-slug". Each reviewer can include template variables, and reviewers that render to an empty string are omitted.
+	Reviewers []string `json:"reviewers,omitempty"`
 	// Title description: The title of the changeset.
 	Title string `json:"title"`
 }
@@ -547,6 +606,14 @@ type ExcludedAWSCodeCommitRepo struct {
 	// Name description: The name of an AWS CodeCommit repository ("repo-name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 }
+type ExcludedAzureDevOpsRepo struct {
+	// Id description: The ID of an Azure DevOps repository (as returned by the Azure DevOps API) to exclude from mirroring.
+	Id string `json:"id,omitempty"`
+	// Name description: The name of an Azure DevOps repository ("org/project/repo") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of an Azure DevOps repository ("org/project/repo").
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedBitbucketCloudRepo struct {
 	// Name description: The name of a Bitbucket Cloud repo ("myorg/myrepo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
@@ -581,6 +648,18 @@ type ExcludedGitLabProject struct {
 	// Name description: The name of a GitLab project ("group/name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 }
+type ExcludedGiteaRepo struct {
+	// Archived description: If set to true, archived repositories will be excluded.
+	Archived bool `json:"archived,omitempty"`
+	// Forks description: If set to true, forks will be excluded.
+	Forks bool `json:"forks,omitempty"`
+	// Id description: The ID of a Gitea repository (as returned by the Gitea API) to exclude from mirroring. Use this to exclude the repository, even if renamed.
+	Id int `json:"id,omitempty"`
+	// Name description: The name of a Gitea repository ("owner/name") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of a Gitea repository ("owner/name").
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedGitoliteRepo struct {
 	// Name description: The name of a Gitolite repo ("my-repo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
@@ -608,6 +687,8 @@ type ExperimentalFeatures struct {
 	AndOrQuery string `json:"andOrQuery,omitempty"`
 	// ApidocsSearchIndexing description: Deprecated.
 	ApidocsSearchIndexing string `json:"apidocs.search.indexing,omitempty"`
+	// AzureDevOps description: Allow adding Azure DevOps code host connections
+	AzureDevOps string `json:"azureDevOps,omitempty"`
 	// BitbucketServerFastPerm description: DEPRECATED: Configure in Bitbucket Server config.
 	BitbucketServerFastPerm string `json:"bitbucketServerFastPerm,omitempty"`
 	// CustomGitFetch description: JSON array of configuration that maps from Git clone URL domain/path to custom git fetch command. To enable this feature set environment variable `ENABLE_CUSTOM_GIT_FETCH` as `true` on gitserver.
@@ -632,6 +713,8 @@ type ExperimentalFeatures struct {
 	Gerrit string `json:"gerrit,omitempty"`
 	// GitServerPinnedRepos description: List of repositories pinned to specific gitserver instances. The specified repositories will remain at their pinned servers on scaling the cluster. If the specified pinned server differs from the current server that stores the repository, then it must be re-cloned to the specified server.
 	GitServerPinnedRepos map[string]string `json:"gitServerPinnedRepos,omitempty"`
+	// Gitea description: Allow adding Gitea and Forgejo code host connections
+	Gitea string `json:"gitea,omitempty"`
 	// GoPackages description: Allow adding Go package host connections
 	GoPackages string `json:"goPackages,omitempty"`
 	// HideSourcegraphOperatorLogin description: Enables hiding Sourcegraph operator auth provider on login page.
@@ -985,6 +1068,53 @@ type GitLabWebhook struct {
 	Secret string `json:"secret"`
 }
 
+// GiteaAuthorization description: If non-null, enforces Gitea repository permissions. Permissions are computed from repository collaborators and the members of the teams with access to the repository. This requires the token to belong to a site admin.
+type GiteaAuthorization struct {
+	// IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Gitea identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical in Sourcegraph and Gitea accounts and `auth.enableUsernameChanges` must be set to false for security reasons.
+	IdentityProvider GiteaIdentityProvider `json:"identityProvider"`
+}
+
+// GiteaConnection description: Configuration for a connection to Gitea or Forgejo.
+type GiteaConnection struct {
+	// Authorization description: If non-null, enforces Gitea repository permissions. Permissions are computed from repository collaborators and the members of the teams with access to the repository. This requires the token to belong to a site admin.
+	Authorization *GiteaAuthorization `json:"authorization,omitempty"`
+	// Exclude description: A list of repositories to never mirror from Gitea. Takes precedence over "orgs", "users" and "searchQuery" configuration.
+	//
+	// Supports excluding by name ({"name": "owner/name"}), by ID ({"id": 42}), by pattern ({"pattern": "^owner/.*-archive$"}), as well as all forks ({"forks": true}) or archived repositories ({"archived": true}).
+	Exclude []*ExcludedGiteaRepo `json:"exclude,omitempty"`
+	// Orgs description: An array of organization names whose repositories should be mirrored on Sourcegraph.
+	Orgs []string `json:"orgs,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to Gitea.
+	RateLimit *GiteaRateLimit `json:"rateLimit,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Gitea repository. In the pattern, the variable "{host}" is replaced with the Gitea URL's host (such as gitea.example.com), and "{nameWithOwner}" is replaced with the Gitea repository's "owner/name" path (such as "myorg/myrepo").
+	//
+	// For example, if your Gitea URL is https://gitea.example.com and your Sourcegraph URL is https://src.example.com, then a repositoryPathPattern of "{host}/{nameWithOwner}" would mean that a Gitea repository at https://gitea.example.com/myorg/myrepo is available on Sourcegraph at https://src.example.com/gitea.example.com/myorg/myrepo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// SearchQuery description: An array of query strings for the Gitea repository search API (https://try.gitea.io/api/swagger#/repository/repoSearch). All repositories matching any of the queries are mirrored on Sourcegraph. Use the special value "all" to mirror all repositories the token can access.
+	SearchQuery []string `json:"searchQuery,omitempty"`
+	// Token description: An access token with the "read:repository", "read:organization" and "read:user" scopes. If "authorization" is set, the token must belong to a site admin.
+	Token string `json:"token"`
+	// Url description: URL of a Gitea or Forgejo instance, such as https://gitea.example.com.
+	Url string `json:"url"`
+	// Users description: An array of user names whose repositories should be mirrored on Sourcegraph.
+	Users []string `json:"users,omitempty"`
+}
+
+// GiteaIdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Gitea identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical in Sourcegraph and Gitea accounts and `auth.enableUsernameChanges` must be set to false for security reasons.
+type GiteaIdentityProvider struct {
+	Type string `json:"type"`
+}
+
+// GiteaRateLimit description: Rate limit applied when making background API requests to Gitea.
+type GiteaRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 500, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 500 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // Github description: GitHub configuration, both for queries and receiving release webhooks.
 type Github struct {
 	// Repository description: The repository to get the latest version of.
@@ -2022,6 +2152,8 @@ type SiteConfiguration struct {
 	AuthzEnforceForSiteAdmins bool `json:"authz.enforceForSiteAdmins,omitempty"`
 	// AuthzRefreshInterval description: Time interval (in seconds) of how often each component picks up authorization changes in external services.
 	AuthzRefreshInterval int `json:"authz.refreshInterval,omitempty"`
+	// BatchChangesChangesetPolicy description: Checks that the changesets of batch changes have to pass before they can be published. Changeset specs violating the policy are not created, and the violations are shown on the workspaces they were found in.
+	BatchChangesChangesetPolicy *BatchChangesChangesetPolicy `json:"batchChanges.changesetPolicy,omitempty"`
 	// BatchChangesChangesetsRetention description: How long changesets will be retained after they have been detached from a batch change.
 	BatchChangesChangesetsRetention string `json:"batchChanges.changesetsRetention,omitempty"`
 	// BatchChangesDisableWebhooksWarning description: Hides Batch Changes warnings about webhooks not being configured.
@@ -2225,18 +2357,26 @@ type SrcCliVersionCache struct {
 type Step struct {
 	// Container description: The Docker image used to launch the Docker container in which the shell command is run.
 	Container string `json:"container"`
+	// ContinueOnError description: If true, the following steps are run even if all attempts to run the step failed.
+	ContinueOnError bool `json:"continueOnError,omitempty"`
 	// Env description: Environment variables to set in the step environment.
 	Env interface{} `json:"env,omitempty"`
 	// Files description: Files that should be mounted into or be created inside the Docker container.
 	Files map[string]string `json:"files,omitempty"`
 	// If description: A condition to check before executing steps. Supports templating. The value 'true' is interpreted as true.
 	If interface{} `json:"if,omitempty"`
+	// Matrix description: Runs the step once for every combination of the given values, in the order of the keys. The values of the current combination can be referenced in templates via matrix.<key>.
+	Matrix map[string][]interface{} `json:"matrix,omitempty"`
 	// Mount description: Files that are mounted to the Docker container.
 	Mount []*Mount `json:"mount,omitempty"`
 	// Outputs description: Output variables of this step that can be referenced in the changesetTemplate or other steps via outputs.<name-of-output>
 	Outputs map[string]OutputVariable `json:"outputs,omitempty"`
+	// Retries description: The number of times the step is retried if it fails or times out.
+	Retries int `json:"retries,omitempty"`
 	// Run description: The shell command to run in the container. It can also be a multi-line shell script. The working directory is the root directory of the repository checkout.
 	Run string `json:"run"`
+	// Timeout description: The maximum duration of a single attempt to run the step, as a Go duration. An attempt that takes longer is canceled and counts as failed.
+	Timeout string `json:"timeout,omitempty"`
 }
 type SubRepoPermissions struct {
 	// Enabled description: Enables sub-repo permission checking